@@ -13,6 +13,6 @@ func main() {
 	ctx := context.Background()
 	if err := fang.Execute(ctx); err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(fang.ExitCode(err))
 	}
 }
@@ -233,9 +233,7 @@ func (s *CloningService) CloneSingle(
 			currentJob, exists := s.activeJobs[job.ID]
 			s.mu.RUnlock()
 
-			if !exists || currentJob.Status == cloning.JobStatusCompleted ||
-				currentJob.Status == cloning.JobStatusFailed ||
-				currentJob.Status == cloning.JobStatusSkipped {
+			if !exists || currentJob.Status.Finished() {
 
 				duration := time.Since(startTime)
 
@@ -304,13 +302,11 @@ func (s *CloningService) CancelJob(jobID string) error {
 		return fmt.Errorf("job %s not found", jobID)
 	}
 
-	if job.Status == cloning.JobStatusCompleted ||
-		job.Status == cloning.JobStatusFailed ||
-		job.Status == cloning.JobStatusSkipped {
+	if job.Status.Finished() {
 		return fmt.Errorf("job %s is already finished", jobID)
 	}
 
-	job.MarkFailed(fmt.Errorf("cancelled by user"))
+	job.MarkCancelled()
 	delete(s.activeJobs, jobID)
 
 	s.logger.Info("Job cancelled",
@@ -328,7 +324,7 @@ func (s *CloningService) CancelAllJobs() error {
 	cancelledCount := 0
 	for jobID, job := range s.activeJobs {
 		if job.Status == cloning.JobStatusRunning || job.Status == cloning.JobStatusPending {
-			job.MarkFailed(fmt.Errorf("cancelled by user"))
+			job.MarkCancelled()
 			delete(s.activeJobs, jobID)
 			cancelledCount++
 		}
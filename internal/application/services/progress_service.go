@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,10 +14,14 @@ import (
 // ProgressService manages progress tracking for cloning operations
 type ProgressService struct {
 	batches        map[string]*cloning.ProgressTracker
+	history        map[string]*cloning.Progress // Last known progress of removed or restored batches
 	subscribers    map[string][]chan *cloning.Progress
 	logger         shared.Logger
 	mu             sync.RWMutex
 	updateInterval time.Duration
+	statePath      string
+	persistEvery   time.Duration
+	maxHistory     int
 	ctx            context.Context
 	cancel         context.CancelFunc
 	wg             sync.WaitGroup
@@ -26,6 +31,13 @@ type ProgressService struct {
 type ProgressServiceConfig struct {
 	Logger         shared.Logger
 	UpdateInterval time.Duration
+
+	// StatePath persists batch progress to this file and restores it on
+	// start, so last-known progress and completed summaries survive
+	// restarts. Empty keeps state in memory only.
+	StatePath       string
+	PersistInterval time.Duration // Defaults to DefaultPersistInterval
+	MaxHistory      int           // Removed batches kept, the latest first; defaults to DefaultMaxHistory
 }
 
 // NewProgressService creates a new progress tracking service
@@ -34,17 +46,42 @@ func NewProgressService(config *ProgressServiceConfig) *ProgressService {
 		config.UpdateInterval = 500 * time.Millisecond
 	}
 
+	if config.PersistInterval == 0 {
+		config.PersistInterval = DefaultPersistInterval
+	}
+
+	if config.MaxHistory <= 0 {
+		config.MaxHistory = DefaultMaxHistory
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	ps := &ProgressService{
 		batches:        make(map[string]*cloning.ProgressTracker),
+		history:        make(map[string]*cloning.Progress),
 		subscribers:    make(map[string][]chan *cloning.Progress),
 		logger:         config.Logger.With(shared.StringField("service", "progress")),
 		updateInterval: config.UpdateInterval,
+		statePath:      config.StatePath,
+		persistEvery:   config.PersistInterval,
+		maxHistory:     config.MaxHistory,
 		ctx:            ctx,
 		cancel:         cancel,
 	}
 
+	if ps.statePath != "" {
+		history, err := loadProgressState(ps.statePath)
+		if err != nil {
+			ps.logger.Warn("Starting without saved progress", shared.ErrorField(err))
+		} else {
+			ps.history = history
+			ps.trimHistory()
+			ps.logger.Info("Progress state restored",
+				shared.StringField("path", ps.statePath),
+				shared.IntField("batches", len(history)))
+		}
+	}
+
 	// Start progress update loop
 	ps.wg.Add(1)
 	go ps.updateLoop()
@@ -63,6 +100,7 @@ func (ps *ProgressService) CreateBatch(batchID string, totalJobs int) error {
 
 	tracker := cloning.NewProgressTracker(totalJobs)
 	ps.batches[batchID] = tracker
+	delete(ps.history, batchID)
 	ps.subscribers[batchID] = make([]chan *cloning.Progress, 0)
 
 	ps.logger.Info("Progress batch created",
@@ -72,17 +110,55 @@ func (ps *ProgressService) CreateBatch(batchID string, totalJobs int) error {
 	return nil
 }
 
-// GetProgress returns the current progress for a batch
+// TrackBatch adds a batch following a tracker created elsewhere, e.g. by the
+// clone use case; RemoveBatch keeps its final progress as history
+func (ps *ProgressService) TrackBatch(batchID string, tracker *cloning.ProgressTracker) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if _, exists := ps.batches[batchID]; exists {
+		return fmt.Errorf("batch %s already exists", batchID)
+	}
+
+	ps.batches[batchID] = tracker
+	delete(ps.history, batchID)
+	ps.subscribers[batchID] = make([]chan *cloning.Progress, 0)
+
+	ps.logger.Info("Progress batch tracked",
+		shared.StringField("batch_id", batchID))
+
+	return nil
+}
+
+// GetProgress returns the current progress for a batch, or the last known
+// progress of a removed or restored one
 func (ps *ProgressService) GetProgress(batchID string) (*cloning.Progress, error) {
 	ps.mu.RLock()
 	defer ps.mu.RUnlock()
 
-	tracker, exists := ps.batches[batchID]
-	if !exists {
-		return nil, fmt.Errorf("batch %s not found", batchID)
+	if tracker, exists := ps.batches[batchID]; exists {
+		return tracker.GetProgress(), nil
+	}
+	if progress, exists := ps.history[batchID]; exists {
+		progressCopy := *progress
+		return &progressCopy, nil
+	}
+
+	return nil, fmt.Errorf("batch %s not found", batchID)
+}
+
+// GetHistory returns the last known progress of removed and restored batches
+func (ps *ProgressService) GetHistory() map[string]*cloning.Progress {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	result := make(map[string]*cloning.Progress, len(ps.history))
+	for batchID, progress := range ps.history {
+		progressCopy := *progress
+		result[batchID] = &progressCopy
 	}
 
-	return tracker.GetProgress(), nil
+	return result
 }
 
 // GetAllProgress returns progress for all active batches
@@ -117,6 +193,7 @@ func (ps *ProgressService) GetOverallProgress() *cloning.Progress {
 		overall.Completed += progress.Completed
 		overall.Failed += progress.Failed
 		overall.Skipped += progress.Skipped
+		overall.Queued += progress.Queued
 		overall.InProgress += progress.InProgress
 
 		// Use earliest start time
@@ -269,7 +346,9 @@ func (ps *ProgressService) RemoveBatch(batchID string) error {
 		close(ch)
 	}
 
-	// Clean up
+	// Clean up, keeping the final progress as history
+	ps.history[batchID] = tracker.GetProgress()
+	ps.trimHistory()
 	tracker.Close()
 	delete(ps.batches, batchID)
 	delete(ps.subscribers, batchID)
@@ -285,12 +364,15 @@ func (ps *ProgressService) GetBatchStats(batchID string) (*BatchStats, error) {
 	ps.mu.RLock()
 	defer ps.mu.RUnlock()
 
-	tracker, exists := ps.batches[batchID]
-	if !exists {
+	var progress *cloning.Progress
+	if tracker, exists := ps.batches[batchID]; exists {
+		progress = tracker.GetProgress()
+	} else if saved, exists := ps.history[batchID]; exists {
+		progressCopy := *saved
+		progress = &progressCopy
+	} else {
 		return nil, fmt.Errorf("batch %s not found", batchID)
 	}
-
-	progress := tracker.GetProgress()
 	subscriberCount := len(ps.subscribers[batchID])
 
 	return &BatchStats{
@@ -337,6 +419,8 @@ func (ps *ProgressService) Close() error {
 	// Wait for goroutines to finish
 	ps.wg.Wait()
 
+	ps.persist()
+
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
 
@@ -364,16 +448,66 @@ func (ps *ProgressService) updateLoop() {
 	ticker := time.NewTicker(ps.updateInterval)
 	defer ticker.Stop()
 
+	var persist <-chan time.Time
+	if ps.statePath != "" {
+		persistTicker := time.NewTicker(ps.persistEvery)
+		defer persistTicker.Stop()
+		persist = persistTicker.C
+	}
+
 	for {
 		select {
 		case <-ticker.C:
 			ps.sendUpdates()
+		case <-persist:
+			ps.persist()
 		case <-ps.ctx.Done():
 			return
 		}
 	}
 }
 
+// trimHistory drops the batches updated least recently from the history
+// beyond maxHistory. Callers hold the lock.
+func (ps *ProgressService) trimHistory() {
+	if len(ps.history) <= ps.maxHistory {
+		return
+	}
+
+	ids := make([]string, 0, len(ps.history))
+	for batchID := range ps.history {
+		ids = append(ids, batchID)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ps.history[ids[i]].LastUpdate.After(ps.history[ids[j]].LastUpdate)
+	})
+	for _, batchID := range ids[ps.maxHistory:] {
+		delete(ps.history, batchID)
+	}
+}
+
+// persist writes the progress of live and historical batches to the state
+// file. Failures are logged; the next interval retries.
+func (ps *ProgressService) persist() {
+	if ps.statePath == "" {
+		return
+	}
+
+	ps.mu.RLock()
+	batches := make(map[string]*cloning.Progress, len(ps.batches)+len(ps.history))
+	for batchID, progress := range ps.history {
+		batches[batchID] = progress
+	}
+	for batchID, tracker := range ps.batches {
+		batches[batchID] = tracker.GetProgress()
+	}
+	ps.mu.RUnlock()
+
+	if err := saveProgressState(ps.statePath, batches); err != nil {
+		ps.logger.Warn("Failed to persist progress state", shared.ErrorField(err))
+	}
+}
+
 // sendUpdates sends current progress to all subscribers
 func (ps *ProgressService) sendUpdates() {
 	ps.mu.RLock()
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestProgressService_PersistsAcrossRestarts(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "progress.json")
+	newService := func() *ProgressService {
+		return NewProgressService(&ProgressServiceConfig{
+			Logger:    logging.NewNoOpLogger(),
+			StatePath: statePath,
+		})
+	}
+
+	ps := newService()
+	require.NoError(t, ps.CreateBatch("nightly", 2))
+	require.NoError(t, ps.StartJob("nightly"))
+	require.NoError(t, ps.CompleteJob("nightly"))
+	require.NoError(t, ps.CreateBatch("done", 1))
+	require.NoError(t, ps.StartJob("done"))
+	require.NoError(t, ps.FailJob("done"))
+	require.NoError(t, ps.RemoveBatch("done"))
+	require.NoError(t, ps.Close())
+
+	restarted := newService()
+	defer func() { _ = restarted.Close() }()
+
+	progress, err := restarted.GetProgress("nightly")
+	require.NoError(t, err)
+	assert.Equal(t, 2, progress.Total)
+	assert.Equal(t, 1, progress.Completed)
+
+	stats, err := restarted.GetBatchStats("done")
+	require.NoError(t, err)
+	assert.True(t, stats.IsComplete)
+	assert.Equal(t, 1, stats.Progress.Failed)
+	assert.Len(t, restarted.GetHistory(), 2)
+
+	// Reusing an ID starts a fresh batch
+	require.NoError(t, restarted.CreateBatch("nightly", 5))
+	progress, err = restarted.GetProgress("nightly")
+	require.NoError(t, err)
+	assert.Equal(t, 5, progress.Total)
+	assert.Zero(t, progress.Completed)
+}
+
+func TestProgressService_HistoryLimit(t *testing.T) {
+	ps := NewProgressService(&ProgressServiceConfig{
+		Logger:     logging.NewNoOpLogger(),
+		MaxHistory: 2,
+	})
+	defer func() { _ = ps.Close() }()
+
+	for i := 1; i <= 3; i++ {
+		batchID := fmt.Sprintf("batch-%d", i)
+		tracker := cloning.NewProgressTracker(1)
+		require.NoError(t, ps.TrackBatch(batchID, tracker))
+		tracker.CompleteJob()
+		require.NoError(t, ps.RemoveBatch(batchID))
+	}
+
+	history := ps.GetHistory()
+	assert.Len(t, history, 2)
+	assert.NotContains(t, history, "batch-1", "the oldest batch is dropped")
+	assert.Equal(t, 1, history["batch-3"].Completed)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// DefaultPersistInterval is how often batch state is written to the state file
+const DefaultPersistInterval = 5 * time.Second
+
+// DefaultMaxHistory is how many removed batches the progress service keeps
+const DefaultMaxHistory = 100
+
+// progressState is the on-disk form of the progress service batches
+type progressState struct {
+	SavedAt time.Time                    `json:"saved_at"`
+	Batches map[string]*cloning.Progress `json:"batches"`
+}
+
+// loadProgressState reads the batches saved at path. A missing file is an
+// empty state.
+func loadProgressState(path string) (map[string]*cloning.Progress, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]*cloning.Progress{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read progress state: %w", err)
+	}
+
+	var state progressState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid progress state %s: %w", path, err)
+	}
+	if state.Batches == nil {
+		state.Batches = map[string]*cloning.Progress{}
+	}
+	return state.Batches, nil
+}
+
+// saveProgressState writes batches to path through a temporary file, so a
+// crash while saving never leaves a truncated state behind
+func saveProgressState(path string, batches map[string]*cloning.Progress) error {
+	data, err := json.Marshal(&progressState{SavedAt: time.Now(), Batches: batches})
+	if err != nil {
+		return fmt.Errorf("failed to encode progress state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create progress state directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write progress state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write progress state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/schedule"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// RunFunc executes one scheduled run
+type RunFunc func(ctx context.Context, run *RunReport) error
+
+// RunReport describes one scheduled run. Skipped runs were due while the
+// previous run was still in progress and never started.
+type RunReport struct {
+	ID          int           `json:"id"`
+	ScheduledAt time.Time     `json:"scheduled_at"`
+	StartedAt   time.Time     `json:"started_at,omitempty"`
+	FinishedAt  time.Time     `json:"finished_at,omitempty"`
+	Duration    time.Duration `json:"duration,omitempty"`
+	Skipped     bool          `json:"skipped,omitempty"`
+	Error       string        `json:"error,omitempty"`
+	LogFile     string        `json:"log_file,omitempty"` // Set by the run function when it keeps a log
+}
+
+// SchedulerConfig holds configuration for the scheduler
+type SchedulerConfig struct {
+	Schedule *schedule.Cron
+	Run      RunFunc
+	OnReport func(*RunReport) // Called once per finished or skipped run
+	RunNow   bool             // Start a run immediately instead of waiting for the first match
+	Logger   shared.Logger
+
+	now   func() time.Time
+	after func(time.Duration) <-chan time.Time
+}
+
+// Scheduler triggers runs on a cron schedule. Runs never overlap: a run due
+// while the previous one is still active is reported as skipped.
+type Scheduler struct {
+	config   *SchedulerConfig
+	mu       sync.Mutex
+	running  bool
+	nextID   int
+	wg       sync.WaitGroup
+	reportMu sync.Mutex // Serializes OnReport calls
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(config *SchedulerConfig) (*Scheduler, error) {
+	if config.Schedule == nil {
+		return nil, fmt.Errorf("schedule is required")
+	}
+	if config.Run == nil {
+		return nil, fmt.Errorf("run function is required")
+	}
+	if config.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if config.now == nil {
+		config.now = time.Now
+	}
+	if config.after == nil {
+		config.after = time.After
+	}
+
+	return &Scheduler{config: config}, nil
+}
+
+// Run triggers runs until ctx is cancelled, then waits for the active run.
+// Cancelling ctx also cancels the active run.
+func (s *Scheduler) Run(ctx context.Context) error {
+	if s.config.RunNow {
+		s.trigger(ctx, s.config.now())
+	}
+
+	for {
+		now := s.config.now()
+		next := s.config.Schedule.Next(now)
+		if next.IsZero() {
+			s.wg.Wait()
+			return fmt.Errorf("schedule %q never matches", s.config.Schedule)
+		}
+
+		s.config.Logger.Info("Next scheduled run",
+			shared.StringField("schedule", s.config.Schedule.String()),
+			shared.StringField("at", next.Format(time.RFC3339)))
+
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+			return nil
+		case <-s.config.after(next.Sub(now)):
+			s.trigger(ctx, next)
+		}
+	}
+}
+
+// trigger starts a run in the background unless one is already active
+func (s *Scheduler) trigger(ctx context.Context, scheduledAt time.Time) {
+	s.mu.Lock()
+	s.nextID++
+	report := &RunReport{ID: s.nextID, ScheduledAt: scheduledAt}
+	if s.running {
+		s.mu.Unlock()
+
+		report.Skipped = true
+		s.config.Logger.Warn("Skipping scheduled run, previous run still in progress",
+			shared.IntField("run", report.ID))
+		s.report(report)
+		return
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			s.running = false
+			s.mu.Unlock()
+		}()
+
+		report.StartedAt = s.config.now()
+		s.config.Logger.Info("Scheduled run started", shared.IntField("run", report.ID))
+
+		err := s.config.Run(ctx, report)
+
+		report.FinishedAt = s.config.now()
+		report.Duration = report.FinishedAt.Sub(report.StartedAt)
+		if err != nil {
+			report.Error = err.Error()
+			s.config.Logger.Error("Scheduled run failed",
+				shared.IntField("run", report.ID),
+				shared.ErrorField(err))
+		} else {
+			s.config.Logger.Info("Scheduled run finished",
+				shared.IntField("run", report.ID),
+				shared.DurationField("duration", report.Duration))
+		}
+		s.report(report)
+	}()
+}
+
+// report hands a finished or skipped run to the report callback
+func (s *Scheduler) report(report *RunReport) {
+	if s.config.OnReport == nil {
+		return
+	}
+	s.reportMu.Lock()
+	defer s.reportMu.Unlock()
+	s.config.OnReport(report)
+}
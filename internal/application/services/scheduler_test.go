@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/schedule"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	cron, err := schedule.ParseCron("* * * * *")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ticks := make(chan time.Time)
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	var mu sync.Mutex
+	var reports []*RunReport
+
+	scheduler, err := NewScheduler(&SchedulerConfig{
+		Schedule: cron,
+		RunNow:   true,
+		Logger:   logging.NewNoOpLogger(),
+		Run: func(ctx context.Context, run *RunReport) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		},
+		OnReport: func(report *RunReport) {
+			mu.Lock()
+			defer mu.Unlock()
+			reports = append(reports, report)
+		},
+		after: func(time.Duration) <-chan time.Time { return ticks },
+	})
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- scheduler.Run(ctx) }()
+
+	<-started
+	ticks <- time.Now() // Due while the first run is still active
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(reports) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(reports) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, reports[0].ID)
+	assert.True(t, reports[0].Skipped)
+	assert.Equal(t, 1, reports[1].ID)
+	assert.False(t, reports[1].Skipped)
+	assert.Empty(t, reports[1].Error)
+}
@@ -0,0 +1,264 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+)
+
+// Archive formats written by git archive
+const (
+	ArchiveTar   = "tar"
+	ArchiveTarGz = "tar.gz"
+	ArchiveZip   = "zip"
+)
+
+// ChecksumsFile is the checksum manifest written next to the archives, in
+// the format of sha256sum so `sha256sum -c SHA256SUMS` verifies them
+const ChecksumsFile = "SHA256SUMS"
+
+// ParseArchiveFormat validates a user supplied archive format
+func ParseArchiveFormat(value string) (string, error) {
+	switch format := strings.ToLower(strings.TrimSpace(value)); format {
+	case "", ArchiveTarGz, "tgz":
+		return ArchiveTarGz, nil
+	case ArchiveTar, ArchiveZip:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid archive format %q (supported: tar.gz, tar, zip)", value)
+	}
+}
+
+// ArchiveRepositoriesRequest represents the input for archiving cloned repositories
+type ArchiveRepositoriesRequest struct {
+	Clones          []*cloning.CloneJob // Finished jobs whose destination holds the clone
+	OutputDirectory string
+	Format          string // tar.gz by default
+	Concurrency     int
+
+	// ProgressTracker optionally receives progress updates with one job per
+	// archive. It is closed when Execute returns.
+	ProgressTracker *cloning.ProgressTracker
+}
+
+// RepositoryArchive is the outcome of archiving one repository
+type RepositoryArchive struct {
+	Repository *repository.Repository
+	Path       string // Archive file
+	Revision   string // Commit SHA archived
+	SHA256     string
+	Size       int64
+	Existing   bool // The archive of this revision was already written
+	Error      error
+	Duration   time.Duration
+}
+
+// ArchiveRepositoriesResponse represents the output of archiving cloned repositories
+type ArchiveRepositoriesResponse struct {
+	Archived      int
+	Existing      int
+	Failed        int
+	Bytes         int64
+	Archives      []*RepositoryArchive
+	ChecksumsPath string
+	TotalDuration time.Duration
+}
+
+// ArchiveRepositoriesUseCase turns clones into <owner>-<repo>-<sha> archives
+// of their checked out revision, concurrently, and records their checksums
+type ArchiveRepositoriesUseCase struct {
+	logger shared.Logger
+}
+
+// NewArchiveRepositoriesUseCase creates a new archive repositories use case
+func NewArchiveRepositoriesUseCase(logger shared.Logger) *ArchiveRepositoriesUseCase {
+	return &ArchiveRepositoriesUseCase{logger: logger}
+}
+
+// Execute archives the HEAD revision of every clone into the output
+// directory and writes the checksums of the archives to SHA256SUMS. Archives
+// of a revision written by an earlier run are kept and listed again.
+func (uc *ArchiveRepositoriesUseCase) Execute(ctx context.Context, req *ArchiveRepositoriesRequest) (*ArchiveRepositoriesResponse, error) {
+	var tracker *cloning.ProgressTracker
+	if req != nil {
+		tracker = req.ProgressTracker
+	}
+	if tracker == nil {
+		tracker = cloning.NewProgressTracker(0)
+	}
+	defer tracker.Close()
+
+	if req == nil || req.OutputDirectory == "" {
+		return nil, fmt.Errorf("output directory cannot be empty")
+	}
+	format, err := ParseArchiveFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(req.OutputDirectory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	startTime := time.Now()
+	tracker.SetTotal(len(req.Clones))
+
+	pool, err := concurrency.NewTaskPool(&concurrency.TaskPoolConfig{
+		MaxWorkers: req.Concurrency,
+		Logger:     uc.logger.With(shared.StringField("component", "task_pool")),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Close()
+
+	resp := &ArchiveRepositoriesResponse{}
+	var mu sync.Mutex
+	for _, job := range req.Clones {
+		archive := &RepositoryArchive{Repository: job.Repository}
+		name := job.Repository.GetFullName()
+
+		tracker.StartJob()
+		started := time.Now()
+		err := pool.Submit(ctx, name, func(ctx context.Context) error {
+			return uc.archive(ctx, job.GetDestinationPath(), req.OutputDirectory, format, archive)
+		}, func(err error) {
+			archive.Duration = time.Since(started)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				archive.Error = err
+				resp.Failed++
+				tracker.FailJobWithDetails(name, archive.Duration, err)
+				uc.logger.Error("Repository archive failed",
+					shared.StringField("repo", name),
+					shared.ErrorField(err))
+			case archive.Existing:
+				resp.Existing++
+				tracker.SkipJobWithDetails(name, archive.Duration, "archive already exists")
+			default:
+				resp.Archived++
+				resp.Bytes += archive.Size
+				tracker.CompleteJobWithDetails(name, archive.Duration, archive.Size)
+			}
+			resp.Archives = append(resp.Archives, archive)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	pool.Wait()
+
+	slices.SortFunc(resp.Archives, func(a, b *RepositoryArchive) int {
+		return strings.Compare(a.Repository.GetFullName(), b.Repository.GetFullName())
+	})
+
+	resp.ChecksumsPath = filepath.Join(req.OutputDirectory, ChecksumsFile)
+	if err := writeChecksums(resp.ChecksumsPath, resp.Archives); err != nil {
+		return nil, err
+	}
+	resp.TotalDuration = time.Since(startTime)
+
+	uc.logger.Info("Repositories archived",
+		shared.IntField("archived", resp.Archived),
+		shared.IntField("existing", resp.Existing),
+		shared.IntField("failed", resp.Failed),
+		shared.DurationField("total_duration", resp.TotalDuration))
+
+	return resp, nil
+}
+
+// ClonedJobs returns the jobs of clone results whose destination holds a
+// clone of their repository: cloned, updated, or skipped as an existing clone
+// of the same remote
+func ClonedJobs(results []*cloning.JobResult) []*cloning.CloneJob {
+	var jobs []*cloning.CloneJob
+	for _, result := range results {
+		switch job := result.Job; job.Status {
+		case cloning.JobStatusCompleted, cloning.JobStatusUpdated:
+			jobs = append(jobs, job)
+		case cloning.JobStatusSkipped:
+			if git.ExistingClone(job) {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	return jobs
+}
+
+// archive writes the archive of the HEAD revision of the clone at path. The
+// archive is written next to its destination and renamed into place once
+// complete, so an interrupted run never leaves a truncated archive behind.
+func (uc *ArchiveRepositoriesUseCase) archive(ctx context.Context, path, outputDir, format string, archive *RepositoryArchive) error {
+	state, err := git.InspectRepository(path)
+	if err != nil {
+		return err
+	}
+	archive.Revision = state.Revision
+
+	repo := archive.Repository
+	archive.Path = filepath.Join(outputDir, fmt.Sprintf("%s-%s-%s.%s", repo.Owner, repo.Name, state.Revision, format))
+
+	if _, err := os.Stat(archive.Path); err == nil {
+		archive.Existing = true
+	} else {
+		partial := archive.Path + ".part"
+		if err := git.Archive(ctx, path, state.Revision, format, repo.Name, partial); err != nil {
+			_ = os.Remove(partial)
+			return err
+		}
+		if err := os.Rename(partial, archive.Path); err != nil {
+			return fmt.Errorf("failed to move archive into place: %w", err)
+		}
+	}
+
+	archive.SHA256, archive.Size, err = fileChecksum(archive.Path)
+	return err
+}
+
+// fileChecksum returns the SHA-256 and size of a file
+func fileChecksum(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// writeChecksums writes the checksums of the written archives in sha256sum
+// format, with file names relative to the checksum file
+func writeChecksums(path string, archives []*RepositoryArchive) error {
+	var b strings.Builder
+	for _, archive := range archives {
+		if archive.Error == nil {
+			fmt.Fprintf(&b, "%s  %s\n", archive.SHA256, filepath.Base(archive.Path))
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write checksums: %w", err)
+	}
+	return nil
+}
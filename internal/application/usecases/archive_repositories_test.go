@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestParseArchiveFormat(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{value: "", expected: ArchiveTarGz},
+		{value: "tgz", expected: ArchiveTarGz},
+		{value: "ZIP", expected: ArchiveZip},
+		{value: "tar", expected: ArchiveTar},
+		{value: "rar", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		format, err := ParseArchiveFormat(tt.value)
+		if tt.wantErr {
+			assert.Error(t, err, tt.value)
+			continue
+		}
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, format)
+	}
+}
+
+func TestArchiveRepositoriesUseCase_Execute(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	baseDir := t.TempDir()
+	repo := testRepositories(t, 1)[0]
+	job := cloning.NewCloneJob(repo, baseDir, cloning.NewDefaultCloneOptions())
+
+	// A clone with one commit
+	clone, err := gogit.PlainInit(job.GetDestinationPath(), false)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(job.GetDestinationPath(), "README.md"), []byte("hello"), 0644))
+	worktree, err := clone.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add("README.md")
+	require.NoError(t, err)
+	signature := &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}
+	revision, err := worktree.Commit("initial", &gogit.CommitOptions{Author: signature})
+	require.NoError(t, err)
+
+	outDir := filepath.Join(t.TempDir(), "archives")
+	uc := NewArchiveRepositoriesUseCase(logging.NewNoOpLogger())
+	req := &ArchiveRepositoriesRequest{Clones: []*cloning.CloneJob{job}, OutputDirectory: outDir}
+
+	resp, err := uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, resp.Archives, 1)
+	archive := resp.Archives[0]
+	require.NoError(t, archive.Error)
+	assert.Equal(t, 1, resp.Archived)
+	assert.Equal(t, revision.String(), archive.Revision)
+	assert.Equal(t, filepath.Join(outDir, "owner-repo-0-"+revision.String()+".tar.gz"), archive.Path)
+	assert.FileExists(t, archive.Path)
+	assert.Positive(t, archive.Size)
+
+	checksums, err := os.ReadFile(resp.ChecksumsPath)
+	require.NoError(t, err)
+	assert.Equal(t, archive.SHA256+"  "+filepath.Base(archive.Path)+"\n", string(checksums))
+
+	// The archive of an unchanged revision is kept
+	resp, err = uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.Archived)
+	assert.Equal(t, 1, resp.Existing)
+	assert.Equal(t, archive.SHA256, resp.Archives[0].SHA256)
+}
+
+func TestClonedJobs(t *testing.T) {
+	baseDir := t.TempDir()
+	repos := testRepositories(t, 4)
+	jobs := make([]*cloning.CloneJob, len(repos))
+	for i, repo := range repos {
+		jobs[i] = cloning.NewCloneJob(repo, baseDir, cloning.NewDefaultCloneOptions())
+	}
+
+	// jobs[2] was skipped as a clone of its own remote, jobs[3] for holding another one
+	for i, origin := range map[int]string{2: repos[2].CloneURL, 3: "https://github.com/other/repo.git"} {
+		clone, err := gogit.PlainInit(jobs[i].GetDestinationPath(), false)
+		require.NoError(t, err)
+		_, err = clone.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{origin}})
+		require.NoError(t, err)
+	}
+
+	jobs[0].MarkCompleted()
+	jobs[1].MarkFailed(assert.AnError)
+	jobs[2].MarkSkipped("exists")
+	jobs[3].MarkSkipped("exists")
+	results := make([]*cloning.JobResult, len(jobs))
+	for i, job := range jobs {
+		results[i] = cloning.NewJobResult(job, true, 0)
+	}
+
+	assert.Equal(t, []*cloning.CloneJob{jobs[0], jobs[2]}, ClonedJobs(results))
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+)
+
+// CleanWorkspaceRequest represents the input for cleaning a clone tree
+type CleanWorkspaceRequest struct {
+	BaseDirectory string
+	MaxDepth      int
+	DryRun        bool // Only report what would be removed
+}
+
+// CleanWorkspaceResponse lists what was removed from a clone tree, or would
+// be on a dry run. Paths are relative to the base directory.
+type CleanWorkspaceResponse struct {
+	Partials     []string
+	PartialBytes int64 // Disk space taken by the partial clones
+	EmptyDirs    []string
+	DryRun       bool
+}
+
+// Removed returns the number of directories removed
+func (r *CleanWorkspaceResponse) Removed() int {
+	return len(r.Partials) + len(r.EmptyDirs)
+}
+
+// CleanWorkspaceUseCase removes the partial clones and empty directories
+// failed and interrupted runs leave in a clone tree
+type CleanWorkspaceUseCase struct {
+	logger shared.Logger
+}
+
+// NewCleanWorkspaceUseCase creates a new clean workspace use case
+func NewCleanWorkspaceUseCase(logger shared.Logger) *CleanWorkspaceUseCase {
+	return &CleanWorkspaceUseCase{logger: logger}
+}
+
+// Execute finds the leftovers below the base directory and removes them
+// unless the request is a dry run
+func (uc *CleanWorkspaceUseCase) Execute(ctx context.Context, req *CleanWorkspaceRequest) (*CleanWorkspaceResponse, error) {
+	if req == nil || req.BaseDirectory == "" {
+		return nil, fmt.Errorf("base directory cannot be empty")
+	}
+	if req.MaxDepth <= 0 {
+		req.MaxDepth = defaultManifestScanDepth
+	}
+
+	root, err := filepath.Abs(req.BaseDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base directory: %w", err)
+	}
+
+	leftovers, err := git.FindLeftovers(root, req.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &CleanWorkspaceResponse{DryRun: req.DryRun}
+	for _, path := range leftovers.Partials {
+		size, _ := git.DirectorySize(path)
+		resp.PartialBytes += size
+		resp.Partials = append(resp.Partials, relativePath(root, path))
+	}
+	for _, path := range leftovers.EmptyDirs {
+		resp.EmptyDirs = append(resp.EmptyDirs, relativePath(root, path))
+	}
+
+	if !req.DryRun {
+		if err := leftovers.Remove(); err != nil {
+			return nil, err
+		}
+		uc.logger.Info("Clone tree cleaned",
+			shared.StringField("base_directory", root),
+			shared.IntField("partial_clones", len(resp.Partials)),
+			shared.IntField("empty_directories", len(resp.EmptyDirs)),
+			shared.IntField("reclaimed_bytes", int(resp.PartialBytes)))
+	}
+	return resp, nil
+}
+
+// relativePath returns path relative to root with forward slashes, path
+// itself when it is not below root
+func relativePath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestCleanWorkspaceUseCase_Execute(t *testing.T) {
+	baseDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "acme", "api.partial-job_1_1"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "acme", "api.partial-job_1_1", "pack"), make([]byte, 1024), 0644))
+	stale := time.Now().Add(-24 * time.Hour)
+	for _, path := range []string{"api.partial-job_1_1/pack", "api.partial-job_1_1"} {
+		require.NoError(t, os.Chtimes(filepath.Join(baseDir, "acme", path), stale, stale))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "bob", "tools", ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "Public"), 0755)) // Not a clone
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "carol", "web", ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "carol", "web", ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0644))
+
+	uc := NewCleanWorkspaceUseCase(logging.NewNoOpLogger())
+	resp, err := uc.Execute(context.Background(), &CleanWorkspaceRequest{BaseDirectory: baseDir, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/api.partial-job_1_1"}, resp.Partials)
+	assert.Equal(t, int64(1024), resp.PartialBytes)
+	assert.Equal(t, []string{"acme", "bob"}, resp.EmptyDirs)
+	assert.DirExists(t, filepath.Join(baseDir, "bob", "tools"), "a dry run removes nothing")
+
+	resp, err = uc.Execute(context.Background(), &CleanWorkspaceRequest{BaseDirectory: baseDir})
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.Removed())
+	assert.NoDirExists(t, filepath.Join(baseDir, "acme"))
+	assert.NoDirExists(t, filepath.Join(baseDir, "bob"))
+	assert.DirExists(t, filepath.Join(baseDir, "carol", "web"))
+	assert.DirExists(t, filepath.Join(baseDir, "Public"))
+
+	_, err = uc.Execute(context.Background(), &CleanWorkspaceRequest{})
+	assert.Error(t, err)
+}
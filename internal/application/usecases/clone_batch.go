@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+)
+
+// CloneBatch is a clone run started by CloneRepositoriesUseCase.Start. The
+// TUI or an API follows it through its own progress tracker while other
+// batches run on the same worker pool.
+type CloneBatch struct {
+	ID        string
+	StartedAt time.Time
+
+	tracker  *cloning.ProgressTracker
+	owners   *cloning.BatchProgress // Per-owner breakdown, nil unless requested
+	pool     *concurrency.Batch
+	cancel   context.CancelFunc
+	done     chan struct{}
+	response *CloneRepositoriesResponse
+	err      error
+
+	mu        sync.Mutex
+	listeners []chan cloning.JobEvent // Subscribers of job events
+	failures  []cloning.JobEvent      // Final events of the failed jobs
+	finished  bool
+}
+
+// eventBuffer is how many job events a subscriber may fall behind before
+// further events are dropped for it
+const eventBuffer = 1024
+
+// Progress returns the current progress of the batch
+func (b *CloneBatch) Progress() *cloning.Progress {
+	return b.tracker.GetProgress()
+}
+
+// Subscribe returns a channel receiving the progress of the batch after
+// every change, closed once the batch finishes, and the func that stops the
+// updates when the subscriber goes away first
+func (b *CloneBatch) Subscribe() (<-chan *cloning.Progress, func()) {
+	updates := b.tracker.Subscribe()
+	return updates, func() { b.tracker.Unsubscribe(updates) }
+}
+
+// SubscribeEvents returns a channel receiving the lifecycle events of the
+// jobs of the batch from now on, closed once the batch finishes, and the func
+// that stops the events when the subscriber goes away first. A subscriber
+// more than eventBuffer events behind misses the events that do not fit.
+func (b *CloneBatch) SubscribeEvents() (<-chan cloning.JobEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := make(chan cloning.JobEvent, eventBuffer)
+	if b.finished {
+		close(events)
+		return events, func() {}
+	}
+	b.listeners = append(b.listeners, events)
+	return events, func() { b.unsubscribeEvents(events) }
+}
+
+// unsubscribeEvents removes an event subscriber; its channel is left open
+func (b *CloneBatch) unsubscribeEvents(events chan cloning.JobEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.listeners = slices.DeleteFunc(b.listeners, func(listener chan cloning.JobEvent) bool {
+		return listener == events
+	})
+}
+
+// Subscribers returns how many subscribers follow the batch, counting the
+// progress and event subscriptions
+func (b *CloneBatch) Subscribers() int {
+	b.mu.Lock()
+	listeners := len(b.listeners)
+	b.mu.Unlock()
+	return listeners + b.tracker.Subscribers()
+}
+
+// publish hands a job event to the subscribers without waiting for them
+func (b *CloneBatch) publish(event cloning.JobEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if event.Type == cloning.JobEventFailed {
+		b.failures = append(b.failures, event)
+	}
+	for _, events := range b.listeners {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// Failures returns the final events of the jobs of the batch that failed so
+// far, in the order they failed
+func (b *CloneBatch) Failures() []cloning.JobEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.failures)
+}
+
+// closeEvents closes the channels of the event subscribers
+func (b *CloneBatch) closeEvents() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.finished = true
+	for _, events := range b.listeners {
+		close(events)
+	}
+	b.listeners = nil
+}
+
+// Owners returns the per-owner progress of the batch, or nil when the
+// request did not ask for it
+func (b *CloneBatch) Owners() *cloning.BatchProgress {
+	return b.owners
+}
+
+// Cancel stops the in-flight clones of the batch; its remaining jobs are
+// reported as cancelled. Other batches keep running.
+func (b *CloneBatch) Cancel() {
+	b.cancel()
+}
+
+// Drain lets the in-flight clones of the batch finish but starts no more of
+// its jobs, which are reported as cancelled. Cancel stops the in-flight
+// clones too, e.g. once a shutdown grace period is over.
+func (b *CloneBatch) Drain() {
+	b.pool.Drain()
+}
+
+// Done is closed once the batch has finished
+func (b *CloneBatch) Done() <-chan struct{} {
+	return b.done
+}
+
+// Wait waits for the batch to finish and returns its result
+func (b *CloneBatch) Wait() (*CloneRepositoriesResponse, error) {
+	<-b.done
+	return b.response, b.err
+}
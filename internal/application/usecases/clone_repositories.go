@@ -2,14 +2,20 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/italoag/repocloner/internal/domain/cloning"
 	"github.com/italoag/repocloner/internal/domain/repository"
 	"github.com/italoag/repocloner/internal/domain/shared"
 	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
 )
 
 // CloneRepositoriesRequest represents the input for cloning repositories
@@ -18,17 +24,65 @@ type CloneRepositoriesRequest struct {
 	BaseDirectory string
 	Options       *cloning.CloneOptions
 	Concurrency   int
+	Order         cloning.JobOrder // Scheduling order, defaults to the repository order
+
+	// Dedupe skips repositories whose remote is already part of the request
+	// or already cloned elsewhere in BaseDirectory
+	Dedupe bool
+
+	// Overrides optionally customizes individual jobs (e.g. manifest entries)
+	Overrides map[repository.RepositoryID]JobOverride
+
+	// ProgressTracker optionally receives progress updates; subscribe to it
+	// before calling Execute or Start. It is closed once the batch finishes.
+	ProgressTracker *cloning.ProgressTracker
+
+	// BatchID names the batch in logs and BatchProgress, defaults to a
+	// generated ID
+	BatchID string
+
+	// Batches optionally breaks progress down per repository owner: a batch
+	// per owner is added once jobs are known and updated as results arrive
+	Batches *cloning.BatchProgress
+
+	// OnEvent optionally receives the lifecycle events of every job, from
+	// queued to its final state
+	OnEvent cloning.JobEventFunc
+}
+
+// JobOverride customizes the clone job of a single repository
+type JobOverride struct {
+	RelativePath string // Path relative to BaseDirectory
+	Branch       string // Branch to check out instead of Options.Branch
+	Ref          string // Tag or commit SHA to pin instead of Options.Ref
 }
 
 // CloneRepositoriesResponse represents the output of cloning repositories
 type CloneRepositoriesResponse struct {
+	RunID         string // Run of the invocation that cloned, empty when not set
 	TotalJobs     int
 	CompletedJobs int
 	FailedJobs    int
 	SkippedJobs   int
+	UpdatedJobs   int   // Existing clones updated instead of cloned
+	CancelledJobs int   // Stopped by cancellation, not counted in FailedJobs
+	TimedOutJobs  int   // Failed on the per-job timeout, also counted in FailedJobs
+	TooLargeJobs  int   // Skipped for exceeding Options.MaxSize, also counted in SkippedJobs
+	TooLargeBytes int64 // Reported size of the TooLargeJobs, the disk space saved
+	OffBranchJobs int   // Skipped for a default branch outside Options.DefaultBranches, also counted in SkippedJobs
+	LowSpaceJobs  int   // Skipped for the free disk space reserve, also counted in SkippedJobs
 	TotalDuration time.Duration
 	Results       []*cloning.JobResult
 	Progress      *cloning.Progress
+	Duplicates    []repository.Duplicate // Repositories skipped by Dedupe
+}
+
+// ProgressHistory keeps the progress of batches past their end, e.g. across
+// restarts; implemented by services.ProgressService
+type ProgressHistory interface {
+	TrackBatch(batchID string, tracker *cloning.ProgressTracker) error
+	RemoveBatch(batchID string) error
+	GetProgress(batchID string) (*cloning.Progress, error)
 }
 
 // CloneRepositoriesUseCase handles the business logic for cloning multiple repositories
@@ -36,7 +90,15 @@ type CloneRepositoriesUseCase struct {
 	workerPool      *concurrency.WorkerPool
 	domainService   *cloning.DomainCloneService
 	logger          shared.Logger
-	progressTracker *cloning.ProgressTracker
+	optionOverrides cloning.OptionOverrides
+	batches         *cloning.BatchProgress // Progress of the running batches
+	handles         sync.Map               // Running batches by ID, *CloneBatch
+	batchSeq        atomic.Int64
+	runID           string
+	history         ProgressHistory
+
+	partialsMutex sync.Mutex
+	activeBatches int // Batches started and not yet finished
 }
 
 // NewCloneRepositoriesUseCase creates a new clone repositories use case
@@ -49,19 +111,67 @@ func NewCloneRepositoriesUseCase(
 		workerPool:    workerPool,
 		domainService: domainService,
 		logger:        logger,
+		batches:       cloning.NewBatchProgress(),
 	}
 }
 
-// Execute executes the clone repositories use case
+// SetOptionOverrides sets the per-pattern clone option overrides merged over
+// the options of every request when its jobs are created
+func (uc *CloneRepositoriesUseCase) SetOptionOverrides(overrides cloning.OptionOverrides) {
+	uc.optionOverrides = overrides
+}
+
+// SetRunID sets the ID of the run the batches of the use case belong to,
+// reported in their responses
+func (uc *CloneRepositoriesUseCase) SetRunID(id string) {
+	uc.runID = id
+}
+
+// SetProgressHistory sets where the progress of every batch is kept once it
+// finished, see FinishedProgress
+func (uc *CloneRepositoriesUseCase) SetProgressHistory(history ProgressHistory) {
+	uc.history = history
+}
+
+// RunID returns the ID set with SetRunID
+func (uc *CloneRepositoriesUseCase) RunID() string {
+	return uc.runID
+}
+
+// Execute clones the repositories of a request and waits for the result
 func (uc *CloneRepositoriesUseCase) Execute(
 	ctx context.Context,
 	req *CloneRepositoriesRequest,
 ) (*CloneRepositoriesResponse, error) {
-	// Validate request
+	batch, err := uc.Start(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return batch.Wait()
+}
+
+// Start creates the jobs of a request and clones them in the background,
+// returning a handle to follow, cancel and wait for the batch. Batches started
+// while others run share the worker pool but have their own context,
+// progress and results.
+func (uc *CloneRepositoriesUseCase) Start(
+	ctx context.Context,
+	req *CloneRepositoriesRequest,
+) (*CloneBatch, error) {
+	// Validate request; closing the tracker tells subscribers that cloning
+	// has finished, even when it never started
 	if err := uc.validateRequest(req); err != nil {
+		if req != nil && req.ProgressTracker != nil {
+			req.ProgressTracker.Close()
+		}
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	progressTracker := req.ProgressTracker
+	if progressTracker == nil {
+		progressTracker = cloning.NewProgressTracker(0)
+	}
+
 	// Set defaults
 	if req.Options == nil {
 		req.Options = cloning.NewDefaultCloneOptions()
@@ -69,108 +179,265 @@ func (uc *CloneRepositoriesUseCase) Execute(
 
 	startTime := time.Now()
 
-	uc.logger.Info("Starting concurrent repository cloning",
+	id := req.BatchID
+	if id == "" && uc.runID != "" {
+		// Unique across runs, as batches outlive their run in the history
+		id = fmt.Sprintf("%s-%d", uc.runID, uc.batchSeq.Add(1))
+	} else if id == "" {
+		id = fmt.Sprintf("batch-%d", uc.batchSeq.Add(1))
+	}
+	if uc.batches.GetBatch(id) != nil {
+		progressTracker.Close()
+		return nil, fmt.Errorf("batch %s is already running", id)
+	}
+	uc.beginBatch(req.BaseDirectory)
+	logger := uc.logger.With(shared.StringField("batch_id", id))
+
+	logger.Info("Starting concurrent repository cloning",
 		shared.IntField("repository_count", len(req.Repositories)),
 		shared.StringField("base_directory", req.BaseDirectory),
 		shared.IntField("concurrency", req.Concurrency))
 
 	// Create jobs
 	jobs := uc.createCloneJobs(req.Repositories, req.BaseDirectory, req.Options)
+	applyJobOverrides(jobs, req.Overrides)
+
+	var duplicates []repository.Duplicate
+	if req.Dedupe {
+		jobs, duplicates = uc.dedupeJobs(jobs, req.BaseDirectory)
+	}
 
 	// Filter jobs based on domain rules
 	validJobs := uc.filterValidJobs(jobs)
 
-	uc.logger.Info("Jobs created and filtered",
-		shared.IntField("total_jobs", len(jobs)),
-		shared.IntField("valid_jobs", len(validJobs)))
+	// Workers pick jobs in submission order
+	req.Order.Sort(validJobs)
 
-	// Create progress tracker with valid job count
-	progressTracker := cloning.NewProgressTracker(len(validJobs))
-	uc.progressTracker = progressTracker
+	logger.Info("Jobs created and filtered",
+		shared.IntField("total_jobs", len(jobs)),
+		shared.IntField("valid_jobs", len(validJobs)),
+		shared.IntField("duplicates", len(duplicates)),
+		shared.StringField("order", string(req.Order)))
+
+	// Track progress against the valid job count
+	progressTracker.SetTotal(len(validJobs))
+	progressTracker.SetJobSizes(jobSizes(validJobs))
+	if req.Batches != nil {
+		addOwnerBatches(req.Batches, validJobs)
+	}
 
-	// Set progress tracker on worker pool for real-time updates
-	uc.workerPool.SetProgressTracker(progressTracker)
+	// The pool batch reports progress and events of these jobs only
+	poolBatch := uc.workerPool.NewBatch()
+	batchCtx, cancel := context.WithCancel(ctx)
+	batch := &CloneBatch{
+		ID:        id,
+		StartedAt: startTime,
+		tracker:   progressTracker,
+		owners:    req.Batches,
+		pool:      poolBatch,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	onEvent := func(event cloning.JobEvent) {
+		if req.OnEvent != nil {
+			req.OnEvent(event)
+		}
+		batch.publish(event)
+	}
+	poolBatch.SetProgressTracker(progressTracker)
+	poolBatch.SetEventHandler(onEvent)
+	for _, job := range validJobs {
+		onEvent(cloning.NewJobEvent(cloning.JobEventQueued, job))
+	}
 
-	// Submit jobs to worker pool
-	if err := uc.workerPool.SubmitJobs(validJobs); err != nil {
-		return nil, fmt.Errorf("failed to submit jobs: %w", err)
+	uc.batches.Track(id, progressTracker)
+	uc.handles.Store(id, batch)
+	if uc.history != nil {
+		if err := uc.history.TrackBatch(id, progressTracker); err != nil {
+			logger.Warn("Failed to keep batch progress history", shared.ErrorField(err))
+		}
 	}
 
-	// Collect results
-	results := uc.collectResults(ctx, len(validJobs))
+	go func() {
+		defer close(batch.done)
+		defer uc.endBatch()
+		defer cancel()
+		defer progressTracker.Close()
+		defer batch.closeEvents()
+		defer uc.handles.Delete(id)
+		defer uc.batches.RemoveBatch(id)
+		if uc.history != nil {
+			defer func() { _ = uc.history.RemoveBatch(id) }()
+		}
+
+		batch.response, batch.err = uc.run(batchCtx, logger, poolBatch, progressTracker, validJobs, req.Batches)
+		if batch.response != nil {
+			batch.response.Duplicates = duplicates
+			batch.response.TotalDuration = time.Since(startTime)
+		}
+	}()
+
+	return batch, nil
+}
+
+// run submits the jobs of a batch and collects their results
+func (uc *CloneRepositoriesUseCase) run(
+	ctx context.Context,
+	logger shared.Logger,
+	batch *concurrency.Batch,
+	progressTracker *cloning.ProgressTracker,
+	validJobs []*cloning.CloneJob,
+	owners *cloning.BatchProgress,
+) (*CloneRepositoriesResponse, error) {
+	startTime := time.Now()
+
+	// Repositories above the size limit or off the default branches, and
+	// clones already at their destination are skipped up front, without taking
+	// a worker or spawning git
+	pending, tooLarge, tooLargeBytes := skipTooLarge(logger, batch, validJobs)
+	pending, offBranch := skipOffBranch(logger, batch, pending)
+	pending = skipExistingClones(logger, batch, pending)
+
+	// Cancelling ctx stops in-flight clones. Submission blocks while all
+	// workers are busy, so results are collected concurrently.
+	submitErr := make(chan error, 1)
+	go func() {
+		err := batch.SubmitAll(ctx, pending)
 
-	// Wait for all jobs to complete
-	uc.workerPool.Wait()
+		// Close the results channel once every submitted job has reported
+		batch.Wait()
+		submitErr <- err
+	}()
+
+	// Collect results
+	results := uc.collectResults(ctx, logger, batch, owners)
+	if err := <-submitErr; err != nil {
+		return nil, fmt.Errorf("failed to submit jobs: %w", err)
+	}
+	pruneFailedClones(logger, results)
+	cancelledJobs := countCancelled(results)
+	if cancelledJobs > 0 {
+		logger.Warn("Repository cloning cancelled",
+			shared.IntField("cancelled", cancelledJobs),
+			shared.IntField("total_jobs", len(validJobs)))
+	}
 
-	// Ensure final progress update shows 100% completion
 	finalProgress := progressTracker.GetProgress()
 
 	// Log progress state for debugging
-	uc.logger.Info("Progress state after worker pool completion",
+	logger.Info("Progress state after worker pool completion",
 		shared.IntField("total", finalProgress.Total),
 		shared.IntField("completed", finalProgress.Completed),
 		shared.IntField("failed", finalProgress.Failed),
 		shared.IntField("skipped", finalProgress.Skipped),
 		shared.IntField("in_progress", finalProgress.InProgress))
 
-	// Force completion if somehow not detected
+	// Every job reports its result before Wait returns, so the counts add up
 	if !finalProgress.IsComplete() {
-		uc.logger.Warn("Forcing completion state - jobs finished but progress incomplete",
+		logger.Warn("Progress incomplete after every job reported",
 			shared.IntField("completed", finalProgress.Completed),
 			shared.IntField("failed", finalProgress.Failed),
 			shared.IntField("skipped", finalProgress.Skipped),
 			shared.IntField("total", finalProgress.Total),
 			shared.IntField("in_progress", finalProgress.InProgress))
-
-		// First try to synchronize progress properly
-		progressTracker.ForceSynchronize()
-		finalProgress = progressTracker.GetProgress()
-
-		// If still not complete, force remaining jobs
-		for finalProgress.InProgress > 0 {
-			progressTracker.CompleteJob() // Mark remaining as completed instead of failed
-			finalProgress = progressTracker.GetProgress()
-			uc.logger.Debug("Forced completion of remaining job",
-				shared.IntField("remaining_in_progress", finalProgress.InProgress))
-		}
-
-		// Update final progress after forced completion
-		finalProgress = progressTracker.GetProgress()
-		uc.logger.Info("Final progress after forced completion",
-			shared.IntField("completed", finalProgress.Completed),
-			shared.IntField("failed", finalProgress.Failed),
-			shared.IntField("skipped", finalProgress.Skipped),
-			shared.IntField("in_progress", finalProgress.InProgress))
 	}
 
-	// Give a moment for the final progress update to propagate to TUI
-	time.Sleep(200 * time.Millisecond)
-
-	// Clear progress tracker from worker pool to avoid state leaking
-	uc.workerPool.SetProgressTracker(nil)
-	uc.progressTracker = nil
-
-	totalDuration := time.Since(startTime)
-	finalProgress = progressTracker.GetProgress() // Get final progress after cleanup
-
-	uc.logger.Info("Repository cloning completed",
+	logger.Info("Repository cloning completed",
 		shared.IntField("total_jobs", len(validJobs)),
 		shared.IntField("completed", finalProgress.Completed),
 		shared.IntField("failed", finalProgress.Failed),
 		shared.IntField("skipped", finalProgress.Skipped),
-		shared.DurationField("total_duration", totalDuration))
+		shared.IntField("updated", finalProgress.Updated),
+		shared.IntField("cancelled", finalProgress.Cancelled),
+		shared.DurationField("total_duration", time.Since(startTime)))
 
 	return &CloneRepositoriesResponse{
+		RunID:         uc.runID,
 		TotalJobs:     len(validJobs),
 		CompletedJobs: finalProgress.Completed,
 		FailedJobs:    finalProgress.Failed,
 		SkippedJobs:   finalProgress.Skipped,
-		TotalDuration: totalDuration,
+		UpdatedJobs:   finalProgress.Updated,
+		CancelledJobs: cancelledJobs,
+		TimedOutJobs:  CountTimedOut(results),
+		TooLargeJobs:  tooLarge,
+		TooLargeBytes: tooLargeBytes,
+		OffBranchJobs: offBranch,
+		LowSpaceJobs:  CountLowSpace(results),
 		Results:       results,
 		Progress:      finalProgress,
 	}, nil
 }
 
+// skipTooLarge marks the jobs whose repository is reported larger than their
+// MaxSize as skipped and returns the jobs left to clone, with the count and
+// reported size of the skipped ones
+func skipTooLarge(logger shared.Logger, batch *concurrency.Batch, jobs []*cloning.CloneJob) ([]*cloning.CloneJob, int, int64) {
+	pending := make([]*cloning.CloneJob, 0, len(jobs))
+	var saved int64
+	for _, job := range jobs {
+		if !job.ExceedsMaxSize() {
+			pending = append(pending, job)
+			continue
+		}
+		saved += job.Repository.Size
+		batch.Skip(job, fmt.Sprintf("too large: %s reported, limit %s",
+			cloning.FormatSize(job.Repository.Size), cloning.FormatSize(job.Options.MaxSize)))
+	}
+
+	skipped := len(jobs) - len(pending)
+	if skipped > 0 {
+		logger.Info("Skipped repositories above the size limit",
+			shared.IntField("skipped", skipped),
+			shared.IntField("saved_bytes", int(saved)))
+	}
+	return pending, skipped, saved
+}
+
+// skipOffBranch marks the jobs whose repository has a default branch outside
+// their DefaultBranches as skipped and returns the jobs left to clone, with
+// the count of the skipped ones
+func skipOffBranch(logger shared.Logger, batch *concurrency.Batch, jobs []*cloning.CloneJob) ([]*cloning.CloneJob, int) {
+	pending := make([]*cloning.CloneJob, 0, len(jobs))
+	for _, job := range jobs {
+		if !job.SkipsDefaultBranch() {
+			pending = append(pending, job)
+			continue
+		}
+		batch.Skip(job, fmt.Sprintf("default branch %s does not match %s",
+			job.Repository.DefaultBranch, strings.Join(job.Options.DefaultBranches, ", ")))
+	}
+
+	skipped := len(jobs) - len(pending)
+	if skipped > 0 {
+		logger.Info("Skipped repositories off the default branches",
+			shared.IntField("skipped", skipped))
+	}
+	return pending, skipped
+}
+
+// skipExistingClones marks the jobs whose destination already holds a clone
+// of their remote as skipped and returns the jobs left to clone. Jobs updating
+// existing clones and destinations of other remotes are left to the workers.
+func skipExistingClones(logger shared.Logger, batch *concurrency.Batch, jobs []*cloning.CloneJob) []*cloning.CloneJob {
+	pending := make([]*cloning.CloneJob, 0, len(jobs))
+	for _, job := range jobs {
+		if !job.Options.SkipExisting || job.Options.Existing == cloning.ExistingUpdate || !git.ExistingClone(job) {
+			pending = append(pending, job)
+			continue
+		}
+		exists := &git.RepositoryExistsError{Path: job.GetDestinationPath()}
+		batch.Skip(job, exists.Error())
+	}
+
+	if skipped := len(jobs) - len(pending); skipped > 0 {
+		logger.Info("Skipped existing clones before cloning",
+			shared.IntField("skipped", skipped),
+			shared.IntField("remaining", len(pending)))
+	}
+	return pending
+}
+
 // createCloneJobs creates clone jobs from repositories
 func (uc *CloneRepositoriesUseCase) createCloneJobs(
 	repos []*repository.Repository,
@@ -179,11 +446,153 @@ func (uc *CloneRepositoriesUseCase) createCloneJobs(
 ) []*cloning.CloneJob {
 	jobs := make([]*cloning.CloneJob, len(repos))
 	for i, repo := range repos {
-		jobs[i] = cloning.NewCloneJob(repo, baseDir, options)
+		jobs[i] = cloning.NewCloneJob(repo, baseDir, uc.optionOverrides.Apply(repo, options))
 	}
 	return jobs
 }
 
+// applyJobOverrides applies per-repository customizations to clone jobs
+func applyJobOverrides(jobs []*cloning.CloneJob, overrides map[repository.RepositoryID]JobOverride) {
+	for _, job := range jobs {
+		override, ok := overrides[job.Repository.ID]
+		if !ok {
+			continue
+		}
+
+		job.RelativePath = override.RelativePath
+		if override.Branch != "" || override.Ref != "" {
+			options := *job.Options
+			if override.Branch != "" {
+				options.Branch = override.Branch
+			}
+			if override.Ref != "" {
+				options.Ref = override.Ref
+			}
+			job.Options = &options
+		}
+	}
+}
+
+// dedupeJobs drops jobs whose remote is already selected by an earlier job or
+// cloned at another path of the base directory. A clone at the job's own
+// destination is left to the regular skip-existing handling.
+func (uc *CloneRepositoriesUseCase) dedupeJobs(
+	jobs []*cloning.CloneJob,
+	baseDir string,
+) ([]*cloning.CloneJob, []repository.Duplicate) {
+	destinations := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		if path, err := filepath.Abs(job.GetDestinationPath()); err == nil {
+			destinations[path] = true
+		}
+	}
+
+	deduplicator := repository.NewDeduplicator()
+	for path, remoteURL := range uc.existingClones(baseDir) {
+		if !destinations[path] {
+			deduplicator.Seen(remoteURL, path)
+		}
+	}
+
+	unique := make([]*cloning.CloneJob, 0, len(jobs))
+	var duplicates []repository.Duplicate
+	for _, job := range jobs {
+		if of, dup := deduplicator.Add(job.Repository); dup {
+			uc.logger.Info("Skipping duplicate repository",
+				shared.StringField("repo", job.Repository.GetFullName()),
+				shared.StringField("clone_url", job.Repository.CloneURL),
+				shared.StringField("duplicate_of", of))
+			duplicates = append(duplicates, repository.Duplicate{Repository: job.Repository, Of: of})
+			continue
+		}
+		unique = append(unique, job)
+	}
+
+	return unique, duplicates
+}
+
+// beginBatch counts a starting batch as active, first removing the partial
+// clones left in its base directory when no other batch is active: the
+// partial clones of active batches are clones in progress
+func (uc *CloneRepositoriesUseCase) beginBatch(baseDir string) {
+	uc.partialsMutex.Lock()
+	defer uc.partialsMutex.Unlock()
+	if uc.activeBatches == 0 {
+		uc.removePartialClones(baseDir)
+	}
+	uc.activeBatches++
+}
+
+// endBatch counts a finished batch as no longer active
+func (uc *CloneRepositoriesUseCase) endBatch() {
+	uc.partialsMutex.Lock()
+	defer uc.partialsMutex.Unlock()
+	uc.activeBatches--
+}
+
+// removePartialClones deletes clones interrupted by an earlier run, so they
+// are cloned again instead of being taken for existing repositories
+func (uc *CloneRepositoriesUseCase) removePartialClones(baseDir string) {
+	if _, err := os.Stat(baseDir); err != nil {
+		return
+	}
+
+	removed, err := git.RemovePartialClones(baseDir, defaultManifestScanDepth)
+	if err != nil {
+		uc.logger.Warn("Failed to remove partial clones",
+			shared.StringField("base_directory", baseDir),
+			shared.ErrorField(err))
+	}
+	for _, path := range removed {
+		uc.logger.Warn("Removed partial clone left by an interrupted run",
+			shared.StringField("path", path))
+	}
+}
+
+// pruneFailedClones removes the destinations failed and cancelled clones
+// left empty, and the owner directories left empty with them, keeping the
+// clone tree free of folders without repositories
+func pruneFailedClones(logger shared.Logger, results []*cloning.JobResult) {
+	for _, result := range results {
+		job := result.Job
+		if job.Status != cloning.JobStatusFailed && job.Status != cloning.JobStatusCancelled {
+			continue
+		}
+		for _, path := range git.PruneEmptyParents(job.GetDestinationPath(), job.BaseDirectory) {
+			logger.Debug("Removed empty directory left by a failed clone",
+				shared.StringField("repository", job.Repository.GetFullName()),
+				shared.StringField("path", path))
+		}
+	}
+}
+
+// existingClones maps the repositories already present in the base directory
+// to their origin remote
+func (uc *CloneRepositoriesUseCase) existingClones(baseDir string) map[string]string {
+	clones := make(map[string]string)
+	if _, err := os.Stat(baseDir); err != nil {
+		return clones
+	}
+
+	paths, err := git.FindRepositories(baseDir, defaultManifestScanDepth)
+	if err != nil {
+		uc.logger.Warn("Failed to scan base directory for existing clones",
+			shared.StringField("base_directory", baseDir),
+			shared.ErrorField(err))
+		return clones
+	}
+
+	for _, path := range paths {
+		state, err := git.InspectRepository(path)
+		if err != nil || state.RemoteURL == "" {
+			continue
+		}
+		clones[path] = state.RemoteURL
+	}
+
+	return clones
+}
+
 // filterValidJobs filters jobs based on domain rules
 func (uc *CloneRepositoriesUseCase) filterValidJobs(jobs []*cloning.CloneJob) []*cloning.CloneJob {
 	var validJobs []*cloning.CloneJob
@@ -203,37 +612,101 @@ func (uc *CloneRepositoriesUseCase) filterValidJobs(jobs []*cloning.CloneJob) []
 	return validJobs
 }
 
-// collectResults collects results from worker pool
-func (uc *CloneRepositoriesUseCase) collectResults(ctx context.Context, expectedResults int) []*cloning.JobResult {
+// collectResults collects the results of a batch until every submitted job
+// has reported. Cancelled jobs report a result too, so this returns promptly
+// once ctx is cancelled.
+func (uc *CloneRepositoriesUseCase) collectResults(ctx context.Context, logger shared.Logger, batch *concurrency.Batch, batches *cloning.BatchProgress) []*cloning.JobResult {
 	var results []*cloning.JobResult
-	var mu sync.Mutex
 
-	resultsChan := uc.workerPool.Results()
-
-	// Collect results
-	for i := 0; i < expectedResults; i++ {
-		select {
-		case result := <-resultsChan:
-			if result != nil {
-				mu.Lock()
-				results = append(results, result)
-				mu.Unlock()
-
-				uc.logger.Debug("Job result collected",
-					shared.StringField("job_id", result.Job.ID),
-					shared.StringField("repo", result.Job.Repository.GetFullName()),
-					shared.StringField("status", result.Job.Status.String()),
-					shared.DurationField("duration", result.Duration))
+	for result := range batch.Results() {
+		if result == nil {
+			continue
+		}
+		results = append(results, result)
+		if batches != nil {
+			if tracker := batches.GetBatch(OwnerBatchID(result.Job.Repository)); tracker != nil {
+				tracker.RecordResult(result)
 			}
-		case <-ctx.Done():
-			uc.logger.Warn("Context cancelled while collecting results")
-			return results
 		}
+
+		logger.Debug("Job result collected",
+			shared.StringField("job_id", result.Job.ID),
+			shared.StringField("repo", result.Job.Repository.GetFullName()),
+			shared.StringField("status", result.Job.Status.String()),
+			shared.DurationField("duration", result.Duration))
+	}
+
+	if ctx.Err() != nil {
+		logger.Warn("Context cancelled while collecting results",
+			shared.IntField("collected", len(results)))
 	}
 
 	return results
 }
 
+// OwnerBatchID returns the ID of the per-owner progress batch of a
+// repository: its provider and owner, e.g. github/kubernetes
+func OwnerBatchID(repo *repository.Repository) string {
+	return repo.Provider() + "/" + repo.Owner
+}
+
+// addOwnerBatches adds a progress batch per repository provider and owner
+func addOwnerBatches(batches *cloning.BatchProgress, jobs []*cloning.CloneJob) {
+	owners := make(map[string][]*cloning.CloneJob)
+	for _, job := range jobs {
+		id := OwnerBatchID(job.Repository)
+		owners[id] = append(owners[id], job)
+	}
+	for owner, ownerJobs := range owners {
+		batches.AddBatch(owner, len(ownerJobs)).SetJobSizes(jobSizes(ownerJobs))
+	}
+}
+
+// jobSizes returns the API-reported size of the repositories of jobs, which
+// weights the ETA of their progress tracker
+func jobSizes(jobs []*cloning.CloneJob) map[string]int64 {
+	sizes := make(map[string]int64, len(jobs))
+	for _, job := range jobs {
+		sizes[job.Repository.GetFullName()] = job.Repository.Size
+	}
+	return sizes
+}
+
+// countCancelled returns the number of results whose job was cancelled
+func countCancelled(results []*cloning.JobResult) int {
+	count := 0
+	for _, result := range results {
+		if result.Job.Status == cloning.JobStatusCancelled {
+			count++
+		}
+	}
+	return count
+}
+
+// CountTimedOut returns the number of results whose job failed on the
+// per-job timeout
+func CountTimedOut(results []*cloning.JobResult) int {
+	count := 0
+	for _, result := range results {
+		if result.Job.Status == cloning.JobStatusFailed && errors.Is(result.Job.Error, cloning.ErrCloneTimeout) {
+			count++
+		}
+	}
+	return count
+}
+
+// CountLowSpace returns the number of results whose job was skipped for the
+// free disk space reserve
+func CountLowSpace(results []*cloning.JobResult) int {
+	count := 0
+	for _, result := range results {
+		if result.Job.Status == cloning.JobStatusSkipped && errors.Is(result.Job.Error, cloning.ErrLowDiskSpace) {
+			count++
+		}
+	}
+	return count
+}
+
 // validateRequest validates the clone repositories request
 func (uc *CloneRepositoriesUseCase) validateRequest(req *CloneRepositoriesRequest) error {
 	if req == nil {
@@ -277,33 +750,85 @@ func (uc *CloneRepositoriesUseCase) validateRequest(req *CloneRepositoriesReques
 	return nil
 }
 
-// GetProgress returns the current progress
-func (uc *CloneRepositoriesUseCase) GetProgress() *cloning.Progress {
-	if uc.progressTracker != nil {
-		return uc.progressTracker.GetProgress()
+// Batch returns the running batch with the given ID, nil when there is none
+func (uc *CloneRepositoriesUseCase) Batch(id string) *CloneBatch {
+	if batch, ok := uc.handles.Load(id); ok {
+		return batch.(*CloneBatch)
 	}
 	return nil
 }
 
-// EstimateDuration estimates how long the cloning operation will take
-func (uc *CloneRepositoriesUseCase) EstimateDuration(repositories []*repository.Repository) time.Duration {
-	if len(repositories) == 0 {
-		return 0
+// FinishedProgress returns the last known progress of a batch that is no
+// longer running, nil when the progress history set with SetProgressHistory
+// does not hold it
+func (uc *CloneRepositoriesUseCase) FinishedProgress(id string) *cloning.Progress {
+	if uc.history == nil || uc.Batch(id) != nil {
+		return nil
+	}
+	progress, err := uc.history.GetProgress(id)
+	if err != nil {
+		return nil
 	}
+	return progress
+}
+
+// GetProgress returns the combined progress of the running batches, or nil
+// when none runs
+func (uc *CloneRepositoriesUseCase) GetProgress() *cloning.Progress {
+	if len(uc.batches.Snapshot()) == 0 {
+		return nil
+	}
+	return uc.batches.GetOverallProgress()
+}
+
+// BatchProgress returns the progress of every running batch by batch ID
+func (uc *CloneRepositoriesUseCase) BatchProgress() []cloning.BatchSnapshot {
+	return uc.batches.Snapshot()
+}
 
-	var totalEstimate int64
+// WorkerStats returns the live worker pool statistics; the worker count of an
+// adaptive pool changes while cloning
+func (uc *CloneRepositoriesUseCase) WorkerStats() *concurrency.WorkerPoolStats {
+	if uc.workerPool == nil {
+		return &concurrency.WorkerPoolStats{}
+	}
+	return uc.workerPool.GetStats()
+}
+
+// CloneEstimate summarizes the expected size and duration of a clone run
+type CloneEstimate struct {
+	Repositories int
+	Bytes        int64
+	Duration     time.Duration
+	Workers      int
+}
+
+// Estimate estimates the download size and duration of cloning repositories
+// with the given number of workers, defaulting to the worker pool size
+func (uc *CloneRepositoriesUseCase) Estimate(repositories []*repository.Repository, workers int) CloneEstimate {
+	if workers <= 0 && uc.workerPool != nil {
+		workers = uc.workerPool.GetStats().TotalWorkers
+	}
+
+	estimate := CloneEstimate{Repositories: len(repositories), Workers: workers}
+	var seconds int64
 	for _, repo := range repositories {
-		estimate := uc.domainService.EstimateCloneDuration(repo)
-		totalEstimate += estimate
+		estimate.Bytes += repo.Size
+		seconds += uc.domainService.EstimateCloneDuration(repo)
 	}
 
 	// With concurrency, divide by number of workers (roughly)
-	stats := uc.workerPool.GetStats()
-	if stats.TotalWorkers > 0 {
-		totalEstimate = totalEstimate / int64(stats.TotalWorkers)
+	if workers > 0 {
+		seconds /= int64(workers)
 	}
+	estimate.Duration = time.Duration(seconds) * time.Second
+
+	return estimate
+}
 
-	return time.Duration(totalEstimate) * time.Second
+// EstimateDuration estimates how long the cloning operation will take
+func (uc *CloneRepositoriesUseCase) EstimateDuration(repositories []*repository.Repository) time.Duration {
+	return uc.Estimate(repositories, 0).Duration
 }
 
 // CloneSingleRepositoryRequest represents input for cloning a single repository
@@ -370,12 +895,14 @@ func (uc *CloneSingleRepositoryUseCase) Execute(
 	}
 
 	// Submit job
-	if err := uc.workerPool.SubmitJob(job); err != nil {
+	batch := uc.workerPool.NewBatch()
+	if err := batch.Submit(ctx, job); err != nil {
 		return nil, fmt.Errorf("failed to submit job: %w", err)
 	}
+	go batch.Wait()
 
 	// Wait for result
-	resultsChan := uc.workerPool.Results()
+	resultsChan := batch.Results()
 	select {
 	case result := <-resultsChan:
 		duration := time.Since(startTime)
@@ -392,6 +919,11 @@ func (uc *CloneSingleRepositoryUseCase) Execute(
 		}, nil
 
 	case <-ctx.Done():
+		// The cancelled job still reports; drain it so the batch can finish
+		go func() {
+			for range resultsChan {
+			}
+		}()
 		return nil, ctx.Err()
 	}
 }
@@ -0,0 +1,357 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// fakeBackend clones every repository at once without touching the disk
+type fakeBackend struct{}
+
+func (fakeBackend) Name() string { return "fake" }
+
+func (fakeBackend) CloneRepository(ctx context.Context, _ *cloning.CloneJob) error {
+	return ctx.Err()
+}
+
+func (fakeBackend) CloneRepositoryWithProgress(ctx context.Context, _ *cloning.CloneJob, _ cloning.TransferProgressFunc) error {
+	return ctx.Err()
+}
+
+func (fakeBackend) UpdateClone(context.Context, *cloning.CloneJob) error { return nil }
+
+func (fakeBackend) GetRepositorySize(string) (int64, error) { return 0, nil }
+
+func (fakeBackend) Validate(context.Context) error { return nil }
+
+// gatedBackend holds every clone until release is closed
+type gatedBackend struct {
+	fakeBackend
+	release chan struct{}
+}
+
+func (b gatedBackend) CloneRepositoryWithProgress(ctx context.Context, _ *cloning.CloneJob, _ cloning.TransferProgressFunc) error {
+	select {
+	case <-b.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// countingBackend counts the clones it is asked for
+type countingBackend struct {
+	fakeBackend
+	clones *atomic.Int64
+}
+
+func (b countingBackend) CloneRepositoryWithProgress(ctx context.Context, _ *cloning.CloneJob, _ cloning.TransferProgressFunc) error {
+	b.clones.Add(1)
+	return ctx.Err()
+}
+
+// missingBackend creates the destination of every clone, like a clone
+// failing halfway, and fails it as not found
+type missingBackend struct{ fakeBackend }
+
+func (missingBackend) CloneRepositoryWithProgress(_ context.Context, job *cloning.CloneJob, _ cloning.TransferProgressFunc) error {
+	if err := os.MkdirAll(job.GetDestinationPath(), 0755); err != nil {
+		return err
+	}
+	return &git.RepositoryNotFoundError{Message: "repository not found"}
+}
+
+func newTestCloneUseCase(t *testing.T, backend ...git.CloneBackend) *CloneRepositoriesUseCase {
+	t.Helper()
+	logger := logging.NewNoOpLogger()
+	var cloneBackend git.CloneBackend = fakeBackend{}
+	if len(backend) > 0 {
+		cloneBackend = backend[0]
+	}
+	pool, err := concurrency.NewWorkerPool(&concurrency.WorkerPoolConfig{
+		MaxWorkers: 8,
+		Backend:    cloneBackend,
+		Logger:     logger,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pool.ForceClose() })
+	return NewCloneRepositoriesUseCase(pool, cloning.NewDomainCloneService(logger), logger)
+}
+
+func testRepositories(t *testing.T, n int) []*repository.Repository {
+	t.Helper()
+	repos := make([]*repository.Repository, n)
+	for i := range repos {
+		name := fmt.Sprintf("repo-%d", i)
+		repo, err := repository.NewRepository(repository.RepositoryID(i+1), name, "https://github.com/owner/"+name+".git", "owner", false, 0, "main")
+		require.NoError(t, err)
+		repos[i] = repo
+	}
+	return repos
+}
+
+func TestCloneRepositoriesUseCase_ExecuteRepeatedly(t *testing.T) {
+	uc := newTestCloneUseCase(t)
+
+	// Each run collects its own results, e.g. retries of the failure triage
+	for _, n := range []int{50, 5} {
+		resp, err := uc.Execute(context.Background(), &CloneRepositoriesRequest{
+			Repositories:  testRepositories(t, n),
+			BaseDirectory: t.TempDir(),
+		})
+		require.NoError(t, err)
+		assert.Len(t, resp.Results, n)
+		assert.Equal(t, n, resp.CompletedJobs)
+		assert.True(t, resp.Progress.IsComplete())
+	}
+}
+
+func TestCloneRepositoriesUseCase_ConcurrentBatches(t *testing.T) {
+	backend := gatedBackend{release: make(chan struct{})}
+	uc := newTestCloneUseCase(t, backend)
+	repos := testRepositories(t, 5)
+
+	first, err := uc.Start(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  repos[:3],
+		BaseDirectory: t.TempDir(),
+		BatchID:       "first",
+	})
+	require.NoError(t, err)
+	second, err := uc.Start(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  repos[3:],
+		BaseDirectory: t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	_, err = uc.Start(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  repos[:1],
+		BaseDirectory: t.TempDir(),
+		BatchID:       "first",
+	})
+	assert.ErrorContains(t, err, "already running")
+
+	running := uc.BatchProgress()
+	require.Len(t, running, 2)
+	assert.Equal(t, 5, uc.GetProgress().Total)
+	assert.Equal(t, 3, first.Progress().Total)
+	assert.Equal(t, 2, second.Progress().Total)
+
+	// Cancelling one batch leaves the other running
+	first.Cancel()
+	resp, err := first.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.CancelledJobs)
+	select {
+	case <-second.Done():
+		t.Fatal("the second batch stopped with the first")
+	default:
+	}
+
+	close(backend.release)
+	resp, err = second.Wait()
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.CompletedJobs)
+	assert.Zero(t, resp.CancelledJobs)
+	assert.Empty(t, uc.BatchProgress(), "finished batches are removed")
+	assert.Nil(t, uc.GetProgress())
+}
+
+func TestCloneRepositoriesUseCase_ConcurrentBatchesKeepPartialClones(t *testing.T) {
+	backend := gatedBackend{release: make(chan struct{})}
+	uc := newTestCloneUseCase(t, backend)
+	repos := testRepositories(t, 2)
+	baseDir := t.TempDir()
+
+	first, err := uc.Start(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  repos[:1],
+		BaseDirectory: baseDir,
+	})
+	require.NoError(t, err)
+
+	// A partial clone of the running batch survives a batch started next to it
+	partial := filepath.Join(baseDir, "owner", "repo-0.partial-job_1_1")
+	require.NoError(t, os.MkdirAll(partial, 0o755))
+	stale := time.Now().Add(-24 * time.Hour)
+	require.NoError(t, os.Chtimes(partial, stale, stale))
+
+	second, err := uc.Start(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  repos[1:],
+		BaseDirectory: baseDir,
+	})
+	require.NoError(t, err)
+	assert.DirExists(t, partial)
+
+	close(backend.release)
+	_, err = first.Wait()
+	require.NoError(t, err)
+	_, err = second.Wait()
+	require.NoError(t, err)
+
+	// Once no batch runs, the next one removes it again
+	_, err = uc.Execute(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  repos[:1],
+		BaseDirectory: baseDir,
+	})
+	require.NoError(t, err)
+	assert.NoDirExists(t, partial)
+}
+
+func TestCloneRepositoriesUseCase_SkipsExistingClonesUpFront(t *testing.T) {
+	repos := testRepositories(t, 3)
+	baseDir := t.TempDir()
+
+	// repo-0 is already cloned, repo-1's destination holds another remote
+	initClone := func(repo *repository.Repository, origin string) {
+		path := cloning.NewCloneJob(repo, baseDir, cloning.NewDefaultCloneOptions()).GetDestinationPath()
+		clone, err := gogit.PlainInit(path, false)
+		require.NoError(t, err)
+		_, err = clone.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{origin}})
+		require.NoError(t, err)
+	}
+	initClone(repos[0], repos[0].CloneURL)
+	initClone(repos[1], "https://github.com/other/repo-1.git")
+
+	tests := []struct {
+		name           string
+		existing       cloning.ExistingAction
+		expectedClones int64
+		expectedSkips  int
+	}{
+		{name: "skip", existing: cloning.ExistingSkip, expectedClones: 2, expectedSkips: 1},
+		{name: "update", existing: cloning.ExistingUpdate, expectedClones: 3, expectedSkips: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := countingBackend{clones: &atomic.Int64{}}
+			uc := newTestCloneUseCase(t, backend)
+
+			options := cloning.NewDefaultCloneOptions()
+			options.Existing = tt.existing
+			resp, err := uc.Execute(context.Background(), &CloneRepositoriesRequest{
+				Repositories:  repos,
+				BaseDirectory: baseDir,
+				Options:       options,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedClones, backend.clones.Load())
+			assert.Equal(t, tt.expectedSkips, resp.SkippedJobs)
+			assert.Len(t, resp.Results, 3)
+			assert.True(t, resp.Progress.IsComplete())
+		})
+	}
+}
+
+func TestCloneRepositoriesUseCase_SkipsTooLarge(t *testing.T) {
+	repos := testRepositories(t, 3)
+	repos[0].Size = 3 << 30 // Above the limit
+	repos[1].Size = 2 << 30 // At the limit
+	repos[2].Size = 0       // Unknown size
+
+	backend := countingBackend{clones: &atomic.Int64{}}
+	uc := newTestCloneUseCase(t, backend)
+
+	options := cloning.NewDefaultCloneOptions()
+	options.MaxSize = 2 << 30
+	resp, err := uc.Execute(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  repos,
+		BaseDirectory: t.TempDir(),
+		Options:       options,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), backend.clones.Load())
+	assert.Equal(t, 1, resp.SkippedJobs)
+	assert.Equal(t, 1, resp.TooLargeJobs)
+	assert.Equal(t, int64(3<<30), resp.TooLargeBytes)
+	assert.True(t, resp.Progress.IsComplete())
+	assert.Equal(t, 2, resp.Progress.Completed, "skips up front are not counted as completions")
+	assert.Zero(t, resp.Progress.InProgress)
+
+	for _, result := range resp.Results {
+		if result.Job.Repository == repos[0] {
+			assert.Equal(t, cloning.JobStatusSkipped, result.Job.Status)
+			assert.EqualError(t, result.Job.Error, "skipped: too large: 3.0 GB reported, limit 2.0 GB")
+		}
+	}
+}
+
+func TestCloneRepositoriesUseCase_SkipsOffBranch(t *testing.T) {
+	repos := testRepositories(t, 3)
+	repos[0].DefaultBranch = "master"
+	repos[1].DefaultBranch = "main"
+	repos[2].DefaultBranch = "" // Unknown default branch
+
+	backend := countingBackend{clones: &atomic.Int64{}}
+	uc := newTestCloneUseCase(t, backend)
+
+	options := cloning.NewDefaultCloneOptions()
+	options.DefaultBranches = []string{"main"}
+	resp, err := uc.Execute(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  repos,
+		BaseDirectory: t.TempDir(),
+		Options:       options,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), backend.clones.Load())
+	assert.Equal(t, 1, resp.SkippedJobs)
+	assert.Equal(t, 1, resp.OffBranchJobs)
+
+	for _, result := range resp.Results {
+		if result.Job.Repository == repos[0] {
+			assert.Equal(t, cloning.JobStatusSkipped, result.Job.Status)
+			assert.EqualError(t, result.Job.Error, "skipped: default branch master does not match main")
+		}
+	}
+}
+
+func TestCloneRepositoriesUseCase_PrunesFailedClones(t *testing.T) {
+	uc := newTestCloneUseCase(t, missingBackend{})
+	baseDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "owner", "kept"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "owner", "kept", "README"), nil, 0644))
+
+	options := cloning.NewDefaultCloneOptions()
+	options.CreateOrgDirs = true
+	resp, err := uc.Execute(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  testRepositories(t, 3),
+		BaseDirectory: baseDir,
+		Options:       options,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.FailedJobs)
+
+	entries, err := os.ReadDir(filepath.Join(baseDir, "owner"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "the destinations of failed clones are removed")
+	assert.Equal(t, "kept", entries[0].Name())
+
+	require.NoError(t, os.RemoveAll(filepath.Join(baseDir, "owner", "kept")))
+	_, err = uc.Execute(context.Background(), &CloneRepositoriesRequest{
+		Repositories:  testRepositories(t, 3),
+		BaseDirectory: baseDir,
+		Options:       options,
+	})
+	require.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(baseDir, "owner"), "owner directories left empty are removed")
+	assert.DirExists(t, baseDir)
+}
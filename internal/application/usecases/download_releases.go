@@ -0,0 +1,304 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/release"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+)
+
+// ReleaseSource lists the releases of a repository and downloads their assets
+type ReleaseSource interface {
+	FetchReleases(ctx context.Context, owner, repo string) ([]*release.Release, error)
+	DownloadAsset(ctx context.Context, asset *release.Asset, w io.Writer) (int64, error)
+}
+
+// DownloadReleasesRequest represents the input for downloading release assets
+type DownloadReleasesRequest struct {
+	Repositories  []*repository.Repository
+	BaseDirectory string
+	Filter        *release.Filter
+	Concurrency   int
+	MaxRetries    int
+
+	// ProgressTracker optionally receives progress updates with one job per
+	// asset; its total is set once the releases have been listed. It is
+	// closed when Execute returns.
+	ProgressTracker *cloning.ProgressTracker
+}
+
+// AssetResult is the outcome of downloading a single release asset
+type AssetResult struct {
+	Repository *repository.Repository
+	Tag        string
+	Asset      release.Asset
+	Path       string
+	Status     cloning.JobStatus // Completed, Failed or Skipped
+	Error      error
+	Duration   time.Duration
+}
+
+// DownloadReleasesResponse represents the output of downloading release assets
+type DownloadReleasesResponse struct {
+	Releases      int
+	Downloaded    int
+	Skipped       int // Already present with the expected size
+	Failed        int
+	Bytes         int64
+	Results       []*AssetResult
+	ListErrors    map[string]error // Repositories whose releases could not be listed, by full name
+	TotalDuration time.Duration
+}
+
+// DownloadReleasesUseCase downloads release assets of many repositories
+// concurrently into <base>/<owner>/<repo>/releases/<tag>/
+type DownloadReleasesUseCase struct {
+	source ReleaseSource
+	logger shared.Logger
+}
+
+// NewDownloadReleasesUseCase creates a new download releases use case
+func NewDownloadReleasesUseCase(source ReleaseSource, logger shared.Logger) *DownloadReleasesUseCase {
+	return &DownloadReleasesUseCase{
+		source: source,
+		logger: logger,
+	}
+}
+
+// assetDownload is a selected asset waiting to be downloaded
+type assetDownload struct {
+	repo  *repository.Repository
+	tag   string
+	asset release.Asset
+}
+
+// Execute lists the releases of every repository and downloads the selected assets
+func (uc *DownloadReleasesUseCase) Execute(
+	ctx context.Context,
+	req *DownloadReleasesRequest,
+) (*DownloadReleasesResponse, error) {
+	var tracker *cloning.ProgressTracker
+	if req != nil {
+		tracker = req.ProgressTracker
+	}
+	if tracker == nil {
+		tracker = cloning.NewProgressTracker(0)
+	}
+	defer tracker.Close()
+
+	if err := uc.validateRequest(req); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	if req.Filter == nil {
+		req.Filter = &release.Filter{}
+	}
+
+	startTime := time.Now()
+
+	pool, err := concurrency.NewTaskPool(&concurrency.TaskPoolConfig{
+		MaxWorkers:  req.Concurrency,
+		MaxRetries:  req.MaxRetries,
+		IsPermanent: isPermanentProviderError,
+		Logger:      uc.logger.With(shared.StringField("component", "task_pool")),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Close()
+
+	resp := &DownloadReleasesResponse{ListErrors: make(map[string]error)}
+
+	downloads, err := uc.listAssets(ctx, pool, req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("Release assets selected",
+		shared.IntField("repositories", len(req.Repositories)),
+		shared.IntField("releases", resp.Releases),
+		shared.IntField("assets", len(downloads)))
+
+	tracker.SetTotal(len(downloads))
+
+	var mu sync.Mutex
+	for _, download := range downloads {
+		download := download
+		result := &AssetResult{
+			Repository: download.repo,
+			Tag:        download.tag,
+			Asset:      download.asset,
+			Path: release.AssetPath(req.BaseDirectory, download.repo.Owner, download.repo.Name,
+				download.tag, download.asset.Name),
+		}
+		name := fmt.Sprintf("%s@%s/%s", download.repo.GetFullName(), download.tag, download.asset.Name)
+
+		if assetPresent(result.Path, download.asset.Size) {
+			result.Status = cloning.JobStatusSkipped
+			tracker.StartJob()
+			tracker.SkipJobWithDetails(name, 0, "already downloaded")
+			resp.Skipped++
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		tracker.StartJob()
+		started := time.Now()
+		err := pool.Submit(ctx, name, func(ctx context.Context) error {
+			return uc.download(ctx, &download.asset, result.Path)
+		}, func(err error) {
+			result.Duration = time.Since(started)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Status = cloning.JobStatusFailed
+				result.Error = err
+				resp.Failed++
+				tracker.FailJobWithDetails(name, result.Duration, err)
+				uc.logger.Error("Release asset download failed",
+					shared.StringField("asset", name),
+					shared.ErrorField(err))
+			} else {
+				result.Status = cloning.JobStatusCompleted
+				resp.Downloaded++
+				resp.Bytes += download.asset.Size
+				tracker.CompleteJobWithDetails(name, result.Duration, download.asset.Size)
+			}
+			resp.Results = append(resp.Results, result)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	pool.Wait()
+
+	resp.TotalDuration = time.Since(startTime)
+
+	uc.logger.Info("Release assets downloaded",
+		shared.IntField("downloaded", resp.Downloaded),
+		shared.IntField("skipped", resp.Skipped),
+		shared.IntField("failed", resp.Failed),
+		shared.DurationField("total_duration", resp.TotalDuration))
+
+	return resp, nil
+}
+
+// listAssets lists the releases of every repository concurrently and returns
+// the assets selected by the filter
+func (uc *DownloadReleasesUseCase) listAssets(
+	ctx context.Context,
+	pool *concurrency.TaskPool,
+	req *DownloadReleasesRequest,
+	resp *DownloadReleasesResponse,
+) ([]assetDownload, error) {
+	var mu sync.Mutex
+	var downloads []assetDownload
+
+	for _, repo := range req.Repositories {
+		repo := repo
+		var releases []*release.Release
+
+		err := pool.Submit(ctx, repo.GetFullName(), func(ctx context.Context) error {
+			var err error
+			releases, err = uc.source.FetchReleases(ctx, repo.Owner, repo.Name)
+			return err
+		}, func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				resp.ListErrors[repo.GetFullName()] = err
+				uc.logger.Warn("Failed to list releases",
+					shared.StringField("repo", repo.GetFullName()),
+					shared.ErrorField(err))
+				return
+			}
+
+			for _, rel := range req.Filter.Select(releases) {
+				resp.Releases++
+				for _, asset := range rel.Assets {
+					downloads = append(downloads, assetDownload{repo: repo, tag: rel.TagName, asset: asset})
+				}
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	pool.Wait()
+
+	return downloads, nil
+}
+
+// download writes an asset next to its destination and moves it into place
+// once complete, so interrupted downloads never look finished
+func (uc *DownloadReleasesUseCase) download(ctx context.Context, asset *release.Asset, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create release directory: %w", err)
+	}
+
+	partial := path + ".part"
+	file, err := os.Create(partial)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", partial, err)
+	}
+
+	written, err := uc.source.DownloadAsset(ctx, asset, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && asset.Size > 0 && written != asset.Size {
+		err = fmt.Errorf("incomplete download of %s: got %d of %d bytes", asset.Name, written, asset.Size)
+	}
+	if err != nil {
+		_ = os.Remove(partial)
+		return err
+	}
+
+	return os.Rename(partial, path)
+}
+
+// assetPresent reports whether an asset was already downloaded completely
+func assetPresent(path string, size int64) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && info.Size() == size
+}
+
+// isPermanentProviderError reports errors that retrying cannot fix
+func isPermanentProviderError(err error) bool {
+	return errors.Is(err, repository.ErrRepositoryNotFound) ||
+		errors.Is(err, repository.ErrAuthenticationFailed) ||
+		errors.Is(err, repository.ErrRepositoryAccessDenied)
+}
+
+// validateRequest validates the download releases request
+func (uc *DownloadReleasesUseCase) validateRequest(req *DownloadReleasesRequest) error {
+	if req == nil {
+		return fmt.Errorf("request cannot be nil")
+	}
+	if len(req.Repositories) == 0 {
+		return fmt.Errorf("repositories list cannot be empty")
+	}
+	if req.BaseDirectory == "" {
+		return fmt.Errorf("base directory cannot be empty")
+	}
+	if req.Concurrency < 0 {
+		return fmt.Errorf("concurrency cannot be negative")
+	}
+	if req.Filter != nil {
+		if err := req.Filter.Validate(); err != nil {
+			return fmt.Errorf("invalid release filter: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/release"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// fakeReleaseSource serves releases from memory
+type fakeReleaseSource struct {
+	releases map[string][]*release.Release
+	contents map[string]string // Asset content by URL
+}
+
+func (s *fakeReleaseSource) FetchReleases(_ context.Context, owner, repo string) ([]*release.Release, error) {
+	releases, ok := s.releases[owner+"/"+repo]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s: %w", owner, repo, repository.ErrRepositoryNotFound)
+	}
+	return releases, nil
+}
+
+func (s *fakeReleaseSource) DownloadAsset(_ context.Context, asset *release.Asset, w io.Writer) (int64, error) {
+	return io.Copy(w, strings.NewReader(s.contents[asset.URL]))
+}
+
+func TestDownloadReleasesUseCase(t *testing.T) {
+	source := &fakeReleaseSource{
+		releases: map[string][]*release.Release{
+			"acme/app": {
+				{TagName: "v2.0.0", PublishedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Assets: []release.Asset{
+					{Name: "app.tar.gz", Size: 3, URL: "app-v2"},
+					{Name: "app.zip", Size: 3, URL: "app-v2-zip"},
+				}},
+				{TagName: "v1.0.0", PublishedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Assets: []release.Asset{
+					{Name: "app.tar.gz", Size: 3, URL: "app-v1"},
+				}},
+			},
+		},
+		contents: map[string]string{"app-v2": "two", "app-v2-zip": "zip", "app-v1": "one"},
+	}
+
+	app, err := repository.NewRepository(1, "app", "https://github.com/acme/app.git", "acme", false, 0, "main")
+	require.NoError(t, err)
+	missing, err := repository.NewRepository(2, "missing", "https://github.com/acme/missing.git", "acme", false, 0, "main")
+	require.NoError(t, err)
+
+	baseDir := t.TempDir()
+	useCase := NewDownloadReleasesUseCase(source, logging.NewNoOpLogger())
+	req := func() *DownloadReleasesRequest {
+		return &DownloadReleasesRequest{
+			Repositories:  []*repository.Repository{app, missing},
+			BaseDirectory: baseDir,
+			Filter:        &release.Filter{AssetPattern: "*.tar.gz"},
+			Concurrency:   2,
+		}
+	}
+
+	resp, err := useCase.Execute(context.Background(), req())
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Releases)
+	assert.Equal(t, 2, resp.Downloaded)
+	assert.Equal(t, int64(6), resp.Bytes)
+	assert.Zero(t, resp.Failed)
+	assert.Contains(t, resp.ListErrors, "acme/missing")
+
+	data, err := os.ReadFile(filepath.Join(baseDir, "acme", "app", "releases", "v2.0.0", "app.tar.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, "two", string(data))
+	assert.NoFileExists(t, filepath.Join(baseDir, "acme", "app", "releases", "v2.0.0", "app.zip"))
+
+	// A second run skips what is already on disk
+	tracker := cloning.NewProgressTracker(0)
+	rerun := req()
+	rerun.ProgressTracker = tracker
+	resp, err = useCase.Execute(context.Background(), rerun)
+	require.NoError(t, err)
+	assert.Zero(t, resp.Downloaded)
+	assert.Equal(t, 2, resp.Skipped)
+	assert.Equal(t, 2, tracker.GetProgress().Skipped)
+}
+
+func TestDownloadReleasesUseCase_InvalidRequest(t *testing.T) {
+	useCase := NewDownloadReleasesUseCase(&fakeReleaseSource{}, logging.NewNoOpLogger())
+
+	_, err := useCase.Execute(context.Background(), &DownloadReleasesRequest{BaseDirectory: t.TempDir()})
+	assert.Error(t, err)
+}
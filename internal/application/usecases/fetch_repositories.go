@@ -3,19 +3,53 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/italoag/repocloner/internal/domain/repository"
 	"github.com/italoag/repocloner/internal/domain/shared"
-	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
 	"github.com/italoag/repocloner/internal/infrastructure/github"
+	"github.com/italoag/repocloner/internal/infrastructure/providers"
 )
 
+// RepositoryLister lists the repositories of an owner page by page, like the
+// provider clients. It lets provider plugins list owners of other systems.
+type RepositoryLister interface {
+	FetchRepositoryPages(
+		ctx context.Context,
+		owner string,
+		repoType repository.RepositoryType,
+		filter *repository.RepositoryFilter,
+		pagination *repository.PaginationOptions,
+		handler repository.PageHandler,
+	) error
+}
+
 // FetchRepositoriesRequest represents the input for fetching repositories
 type FetchRepositoriesRequest struct {
 	Owner      string
 	Type       repository.RepositoryType
 	Filter     *repository.RepositoryFilter
 	Pagination *repository.PaginationOptions
+	OnPage     repository.PageHandler // Optional, receives repositories as each page arrives
+	Team       string                 // Optional GitHub team slug, lists only the team's repositories
+
+	// Query optionally selects GitHub repositories with search qualifiers,
+	// e.g. "org:acme language:go", instead of listing Owner
+	Query string
+
+	// ResolveUpstreams looks up the parent of forks, which listings do not
+	// report, so clones get an upstream remote. Costs a request per fork on
+	// providers supporting it.
+	ResolveUpstreams bool
+
+	// Provider optionally names the registered provider listing Owner,
+	// instead of the first one supporting Type
+	Provider string
+
+	// Lister optionally lists Owner instead of the provider of Type, e.g. a
+	// provider plugin. Type is passed on and may be empty.
+	Lister RepositoryLister
 }
 
 // FetchRepositoriesResponse represents the output of fetching repositories
@@ -27,21 +61,19 @@ type FetchRepositoriesResponse struct {
 
 // FetchRepositoriesUseCase handles the business logic for fetching repositories
 type FetchRepositoriesUseCase struct {
-	githubClient    *github.GitHubClient
-	bitbucketClient *bitbucket.BitbucketClient
-	logger          shared.Logger
+	registry *providers.Registry
+	logger   shared.Logger
 }
 
 // NewFetchRepositoriesUseCase creates a new fetch repositories use case
+// listing owners through the providers of a registry
 func NewFetchRepositoriesUseCase(
-	githubClient *github.GitHubClient,
-	bitbucketClient *bitbucket.BitbucketClient,
+	registry *providers.Registry,
 	logger shared.Logger,
 ) *FetchRepositoriesUseCase {
 	return &FetchRepositoriesUseCase{
-		githubClient:    githubClient,
-		bitbucketClient: bitbucketClient,
-		logger:          logger,
+		registry: registry,
+		logger:   logger,
 	}
 }
 
@@ -66,38 +98,71 @@ func (uc *FetchRepositoriesUseCase) Execute(
 	uc.logger.Info("Fetching repositories",
 		shared.StringField("owner", req.Owner),
 		shared.StringField("type", req.Type.String()),
+		shared.StringField("team", req.Team),
+		shared.StringField("query", req.Query),
 		shared.IntField("page", req.Pagination.Page),
 		shared.IntField("per_page", req.Pagination.PerPage))
 
-	// Fetch repositories from appropriate provider
+	provider, err := uc.selectProvider(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch repositories from the provider, page by page
 	var repositories []*repository.Repository
-	var err error
 
-	switch {
-	case req.Type.IsGitHubType():
-		if uc.githubClient == nil {
-			return nil, fmt.Errorf("GitHub client not configured")
+	var resolver providers.UpstreamResolver
+	if req.ResolveUpstreams && provider != nil && provider.Capabilities().ForkUpstreams {
+		resolver, _ = provider.(providers.UpstreamResolver)
+	}
+
+	collect := func(page []*repository.Repository) error {
+		if resolver != nil {
+			uc.resolveUpstreams(ctx, resolver, page)
+		}
+		repositories = append(repositories, page...)
+		if req.OnPage != nil {
+			return req.OnPage(page)
 		}
-		repositories, err = uc.githubClient.FetchRepositories(
+		return nil
+	}
+
+	switch {
+	case req.Lister != nil:
+		err = req.Lister.FetchRepositoryPages(
 			ctx,
 			req.Owner,
 			req.Type,
 			req.Filter,
 			req.Pagination,
+			collect,
 		)
-	case req.Type.IsBitbucketType():
-		if uc.bitbucketClient == nil {
-			return nil, fmt.Errorf("bitbucket client not configured")
-		}
-		repositories, err = uc.bitbucketClient.FetchRepositories(
+	case req.Query != "":
+		err = provider.(providers.Searcher).SearchRepositories(
+			ctx,
+			req.Query,
+			req.Filter,
+			req.Pagination,
+			collect,
+		)
+	case req.Team != "":
+		err = provider.(providers.TeamLister).FetchTeamRepositories(
 			ctx,
 			req.Owner,
-			req.Type,
+			req.Team,
 			req.Filter,
 			req.Pagination,
+			collect,
 		)
 	default:
-		return nil, fmt.Errorf("unsupported repository type: %s", req.Type)
+		err = provider.FetchRepositories(
+			ctx,
+			req.Owner,
+			req.Type,
+			req.Filter,
+			req.Pagination,
+			collect,
+		)
 	}
 
 	if err != nil {
@@ -133,18 +198,144 @@ func (uc *FetchRepositoriesUseCase) Execute(
 	}, nil
 }
 
+// selectProvider returns the provider serving a request: the one it names,
+// the first supporting search for queries, or else the first listing owners
+// of its type. Requests with a Lister need none.
+func (uc *FetchRepositoriesUseCase) selectProvider(req *FetchRepositoriesRequest) (providers.Provider, error) {
+	if req.Lister != nil {
+		return nil, nil
+	}
+
+	var provider providers.Provider
+	switch {
+	case req.Provider != "":
+		var err error
+		if provider, err = uc.registry.Get(req.Provider); err != nil {
+			return nil, err
+		}
+		if req.Query == "" && !provider.Capabilities().Supports(req.Type) {
+			return nil, fmt.Errorf("provider %s does not list owners of type %s", provider.Name(), req.Type)
+		}
+	case req.Query != "":
+		for _, candidate := range uc.registry.Providers() {
+			if candidate.Capabilities().Search {
+				provider = candidate
+				break
+			}
+		}
+		if provider == nil {
+			return nil, fmt.Errorf("no configured provider supports search queries")
+		}
+	default:
+		if provider = uc.registry.ForType(req.Type); provider == nil {
+			return nil, fmt.Errorf("no provider configured for owners of type %s", req.Type)
+		}
+	}
+
+	capabilities := provider.Capabilities()
+	if _, ok := provider.(providers.Searcher); req.Query != "" && (!ok || !capabilities.Search) {
+		return nil, fmt.Errorf("provider %s does not support search queries", provider.Name())
+	}
+	if _, ok := provider.(providers.TeamLister); req.Team != "" && (!ok || !capabilities.Teams) {
+		return nil, fmt.Errorf("provider %s does not support teams", provider.Name())
+	}
+	return provider, nil
+}
+
+// resolveUpstreams fills the upstream URL of forks. A failed lookup only
+// costs the fork its upstream remote, so it is logged.
+func (uc *FetchRepositoriesUseCase) resolveUpstreams(ctx context.Context, resolver providers.UpstreamResolver, repos []*repository.Repository) {
+	for _, repo := range repos {
+		if !repo.IsFork || repo.UpstreamURL != "" {
+			continue
+		}
+
+		upstream, err := resolver.FetchUpstreamURL(ctx, repo.Owner, repo.Name)
+		if err != nil {
+			uc.logger.Warn("Failed to resolve fork parent",
+				shared.StringField("repo", repo.GetFullName()),
+				shared.ErrorField(err))
+			continue
+		}
+		repo.UpstreamURL = upstream
+	}
+}
+
+// ResolveRenames looks up the repositories of a reference list, e.g. of
+// --from-file, on the providers supporting lookups of their host, and moves
+// those renamed or transferred since to their current owner and name, so
+// they are cloned under it. Their reported size and default branch are
+// filled in too. Lookups failing leave a repository as referenced; git
+// follows the redirect of its old URL.
+func (uc *FetchRepositoriesUseCase) ResolveRenames(ctx context.Context, repos []*repository.Repository) {
+	finders := make(map[string]providers.RepositoryFinder)
+	for _, provider := range uc.registry.Providers() {
+		if finder, ok := provider.(providers.RepositoryFinder); ok && provider.Capabilities().Lookup {
+			finders[strings.ToLower(finder.Host())] = finder
+		}
+	}
+	if len(finders) == 0 {
+		return
+	}
+
+	for _, repo := range repos {
+		parsed, err := url.Parse(repo.CloneURL)
+		if err != nil {
+			continue
+		}
+		finder, ok := finders[strings.ToLower(parsed.Hostname())]
+		if !ok {
+			continue
+		}
+
+		current, err := finder.FetchRepository(ctx, repo.Owner, repo.Name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			uc.logger.Debug("Failed to look up referenced repository",
+				shared.StringField("repo", repo.GetFullName()),
+				shared.ErrorField(err))
+			continue
+		}
+
+		repo.Size = current.Size
+		repo.DefaultBranch = current.DefaultBranch
+		repo.IsFork = current.IsFork
+		repo.Archived = current.Archived
+		repo.PushedAt = current.PushedAt
+		// Names are case-insensitive, a reference in another case is no rename
+		if !strings.EqualFold(current.GetFullName(), repo.GetFullName()) {
+			uc.logger.Warn("Repository was renamed, cloning it under its current name",
+				shared.StringField("repo", repo.GetFullName()),
+				shared.StringField("current", current.GetFullName()))
+			repo.Rename(current.Owner, current.Name)
+		}
+	}
+}
+
 // validateRequest validates the fetch repositories request
 func (uc *FetchRepositoriesUseCase) validateRequest(req *FetchRepositoriesRequest) error {
 	if req == nil {
 		return fmt.Errorf("request cannot be nil")
 	}
 
-	if req.Owner == "" {
-		return fmt.Errorf("owner cannot be empty")
+	if req.Query != "" {
+		if req.Team != "" {
+			return fmt.Errorf("teams cannot be combined with a search query")
+		}
+	} else {
+		if req.Owner == "" {
+			return fmt.Errorf("owner cannot be empty")
+		}
+
+		if req.Lister == nil && !req.Type.IsValid() {
+			return fmt.Errorf("invalid repository type: %s", req.Type)
+		}
 	}
 
-	if !req.Type.IsValid() {
-		return fmt.Errorf("invalid repository type: %s", req.Type)
+	if req.Team != "" && req.Type != repository.RepositoryTypeOrganization {
+		return fmt.Errorf("teams are only supported for GitHub organizations")
 	}
 
 	if req.Pagination != nil {
@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/infrastructure/providers"
+)
+
+func TestFetchRepositoriesUseCase_ResolveUpstreams(t *testing.T) {
+	var lookups []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/me/repos":
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "name": "tool", "fork": true, "clone_url": "https://github.com/me/tool.git", "owner": {"login": "me"}},
+				{"id": 2, "name": "app", "fork": false, "clone_url": "https://github.com/me/app.git", "owner": {"login": "me"}}
+			]`))
+		case "/repos/me/tool":
+			lookups = append(lookups, r.URL.Path)
+			_, _ = w.Write([]byte(`{"name": "tool", "fork": true, "parent": {"clone_url": "https://github.com/acme/tool.git"}}`))
+		default:
+			lookups = append(lookups, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	logger := logging.NewNoOpLogger()
+	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{BaseURL: api.URL, Logger: logger})
+	useCase := NewFetchRepositoriesUseCase(providers.Default(githubClient, nil, nil), logger)
+
+	filter := repository.NewRepositoryFilter()
+	filter.OnlyPublic = false
+	filter.OnlyForks = true
+
+	resp, err := useCase.Execute(context.Background(), &FetchRepositoriesRequest{
+		Owner:            "me",
+		Type:             repository.RepositoryTypeUser,
+		Filter:           filter,
+		ResolveUpstreams: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Repositories, 1)
+	assert.Equal(t, "https://github.com/acme/tool.git", resp.Repositories[0].UpstreamURL)
+	assert.Equal(t, []string{"/repos/me/tool"}, lookups, "only forks are looked up")
+}
+
+// staticLister lists a fixed set of repositories, like a provider plugin
+type staticLister struct {
+	repos []*repository.Repository
+}
+
+func (l *staticLister) FetchRepositoryPages(
+	_ context.Context,
+	_ string,
+	_ repository.RepositoryType,
+	_ *repository.RepositoryFilter,
+	_ *repository.PaginationOptions,
+	handler repository.PageHandler,
+) error {
+	return handler(l.repos)
+}
+
+func TestFetchRepositoriesUseCase_Lister(t *testing.T) {
+	app, err := repository.NewRepository(0, "app", "https://gerrit.example.com/platform/app", "platform", false, 0, "main")
+	require.NoError(t, err)
+	fork, err := repository.NewRepository(0, "fork", "https://gerrit.example.com/platform/fork", "platform", true, 0, "main")
+	require.NoError(t, err)
+
+	useCase := NewFetchRepositoriesUseCase(providers.Default(nil, nil, nil), logging.NewNoOpLogger())
+	resp, err := useCase.Execute(context.Background(), &FetchRepositoriesRequest{
+		Owner:  "platform",
+		Lister: &staticLister{repos: []*repository.Repository{app, fork}},
+	})
+	require.NoError(t, err, "listers need no repository type")
+
+	require.Len(t, resp.Repositories, 1)
+	assert.Equal(t, "platform/app", resp.Repositories[0].GetFullName())
+	assert.Equal(t, 1, resp.FilteredOut, "filters apply to listed repositories")
+}
+
+func TestFetchRepositoriesUseCase_ResolveRenames(t *testing.T) {
+	var lookups []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups = append(lookups, r.URL.Path)
+		switch r.URL.Path {
+		case "/repos/acme/old-name", "/repos/Acme/App":
+			// Renamed repositories redirect to their ID
+			http.Redirect(w, r, map[string]string{
+				"/repos/acme/old-name": "/repositories/1",
+				"/repos/Acme/App":      "/repositories/2",
+			}[r.URL.Path], http.StatusMovedPermanently)
+		case "/repositories/1":
+			_, _ = w.Write([]byte(`{"id": 1, "name": "new-name", "size": 2048, "default_branch": "trunk", "clone_url": "https://github.com/acme-labs/new-name.git", "owner": {"login": "acme-labs"}}`))
+		case "/repositories/2":
+			_, _ = w.Write([]byte(`{"id": 2, "name": "app", "clone_url": "https://github.com/acme/app.git", "owner": {"login": "acme"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	logger := logging.NewNoOpLogger()
+	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{BaseURL: api.URL, Logger: logger})
+	useCase := NewFetchRepositoriesUseCase(providers.Default(githubClient, nil, nil), logger)
+
+	var repos []*repository.Repository
+	for _, ref := range []string{"git@github.com:acme/old-name.git", "Acme/App", "acme/missing", "bitbucket.org/team/repo"} {
+		repo, err := repository.ParseReference(ref)
+		require.NoError(t, err)
+		repos = append(repos, repo)
+	}
+
+	useCase.ResolveRenames(context.Background(), repos)
+
+	renamed := repos[0]
+	assert.Equal(t, "acme-labs/new-name", renamed.GetFullName())
+	assert.Equal(t, "acme/old-name", renamed.RenamedFrom)
+	assert.Equal(t, "ssh://git@github.com/acme-labs/new-name.git", renamed.CloneURL, "the clone URL keeps its scheme")
+	assert.Equal(t, int64(2048*1024), renamed.Size)
+	assert.Equal(t, "trunk", renamed.DefaultBranch)
+
+	assert.Equal(t, "Acme/App", repos[1].GetFullName(), "a reference in another case is not a rename")
+	assert.Empty(t, repos[1].RenamedFrom)
+	assert.Equal(t, "acme/missing", repos[2].GetFullName())
+	assert.Empty(t, repos[2].RenamedFrom)
+	assert.NotContains(t, lookups, "/repos/team/repo", "only GitHub repositories are looked up")
+}
+
+// namedProvider is a provider plugged into the registry, serving organizations
+type namedProvider struct {
+	staticLister
+	name string
+}
+
+func (p *namedProvider) Name() string { return p.name }
+func (p *namedProvider) Capabilities() providers.Capabilities {
+	return providers.Capabilities{OwnerTypes: []repository.RepositoryType{repository.RepositoryTypeOrganization}}
+}
+func (p *namedProvider) Validate(context.Context) error { return nil }
+func (p *namedProvider) RateLimitInfo(context.Context) (*providers.RateLimit, error) {
+	return nil, providers.ErrNotSupported
+}
+func (p *namedProvider) FetchRepositories(
+	ctx context.Context,
+	owner string,
+	repoType repository.RepositoryType,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	handler repository.PageHandler,
+) error {
+	return p.FetchRepositoryPages(ctx, owner, repoType, filter, pagination, handler)
+}
+
+func TestFetchRepositoriesUseCase_Providers(t *testing.T) {
+	app, err := repository.NewRepository(0, "app", "https://gitea.example.com/platform/app.git", "platform", false, 0, "main")
+	require.NoError(t, err)
+
+	gitea := &namedProvider{name: "gitea", staticLister: staticLister{repos: []*repository.Repository{app}}}
+	registry, err := providers.NewRegistry(gitea)
+	require.NoError(t, err)
+	useCase := NewFetchRepositoriesUseCase(registry, logging.NewNoOpLogger())
+
+	tests := []struct {
+		name    string
+		req     *FetchRepositoriesRequest
+		wantErr string
+	}{
+		{name: "by type", req: &FetchRepositoriesRequest{Owner: "platform", Type: repository.RepositoryTypeOrganization}},
+		{name: "by name", req: &FetchRepositoriesRequest{Owner: "platform", Type: repository.RepositoryTypeOrganization, Provider: "gitea"}},
+		{name: "unknown name", req: &FetchRepositoriesRequest{Owner: "platform", Type: repository.RepositoryTypeOrganization, Provider: "gitlab"},
+			wantErr: `unknown provider "gitlab"`},
+		{name: "unsupported type", req: &FetchRepositoriesRequest{Owner: "platform", Type: repository.RepositoryTypeUser, Provider: "gitea"},
+			wantErr: "provider gitea does not list owners of type users"},
+		{name: "no provider for type", req: &FetchRepositoriesRequest{Owner: "platform", Type: repository.RepositoryTypeBitbucketWorkspace},
+			wantErr: "no provider configured for owners of type bitbucket_workspaces"},
+		{name: "teams", req: &FetchRepositoriesRequest{Owner: "platform", Type: repository.RepositoryTypeOrganization, Team: "core"},
+			wantErr: "provider gitea does not support teams"},
+		{name: "search", req: &FetchRepositoriesRequest{Query: "org:platform"},
+			wantErr: "no configured provider supports search queries"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := useCase.Execute(context.Background(), tt.req)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, resp.Repositories, 1)
+			assert.Equal(t, "platform/app", resp.Repositories[0].GetFullName())
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+)
+
+// GarbageCollectRequest represents the input for garbage collecting a clone tree
+type GarbageCollectRequest struct {
+	BaseDirectory string
+	MaxDepth      int
+	Concurrency   int
+	Args          []string // Arguments of git gc, e.g. --aggressive
+
+	// ProgressTracker optionally receives progress updates with one job per
+	// repository. It is closed when Execute returns.
+	ProgressTracker *cloning.ProgressTracker
+}
+
+// GarbageCollectResult is the outcome of garbage collecting one repository
+type GarbageCollectResult struct {
+	Path       string // Relative to the base directory
+	SizeBefore int64
+	SizeAfter  int64
+	Error      error
+	Duration   time.Duration
+}
+
+// Reclaimed returns the bytes freed in the repository
+func (r *GarbageCollectResult) Reclaimed() int64 {
+	return r.SizeBefore - r.SizeAfter
+}
+
+// GarbageCollectResponse represents the output of garbage collecting a clone tree
+type GarbageCollectResponse struct {
+	Collected     int
+	Failed        int
+	SizeBefore    int64
+	SizeAfter     int64 // Sizes of the collected repositories only
+	Results       []*GarbageCollectResult
+	TotalDuration time.Duration
+}
+
+// Reclaimed returns the bytes freed across the clone tree
+func (r *GarbageCollectResponse) Reclaimed() int64 {
+	return r.SizeBefore - r.SizeAfter
+}
+
+// GarbageCollectUseCase runs git gc concurrently over the repositories of a
+// clone tree
+type GarbageCollectUseCase struct {
+	logger shared.Logger
+}
+
+// NewGarbageCollectUseCase creates a new garbage collect use case
+func NewGarbageCollectUseCase(logger shared.Logger) *GarbageCollectUseCase {
+	return &GarbageCollectUseCase{logger: logger}
+}
+
+// Execute finds the repositories below the base directory and garbage
+// collects them on a task pool, measuring the space each one reclaimed
+func (uc *GarbageCollectUseCase) Execute(ctx context.Context, req *GarbageCollectRequest) (*GarbageCollectResponse, error) {
+	var tracker *cloning.ProgressTracker
+	if req != nil {
+		tracker = req.ProgressTracker
+	}
+	if tracker == nil {
+		tracker = cloning.NewProgressTracker(0)
+	}
+	defer tracker.Close()
+
+	if req == nil || req.BaseDirectory == "" {
+		return nil, fmt.Errorf("base directory cannot be empty")
+	}
+	if req.MaxDepth <= 0 {
+		req.MaxDepth = defaultManifestScanDepth
+	}
+
+	root, err := filepath.Abs(req.BaseDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base directory: %w", err)
+	}
+
+	paths, err := git.FindRepositories(root, req.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	tracker.SetTotal(len(paths))
+
+	// Failed collections are not retried: git gc leaves the repository
+	// intact and a rerun would most likely fail the same way
+	pool, err := concurrency.NewTaskPool(&concurrency.TaskPoolConfig{
+		MaxWorkers: req.Concurrency,
+		Logger:     uc.logger.With(shared.StringField("component", "task_pool")),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Close()
+
+	resp := &GarbageCollectResponse{}
+	var mu sync.Mutex
+	for _, path := range paths {
+		result := &GarbageCollectResult{Path: path}
+		if rel, err := filepath.Rel(root, path); err == nil {
+			result.Path = filepath.ToSlash(rel)
+		}
+
+		tracker.StartJob()
+		started := time.Now()
+		err := pool.Submit(ctx, result.Path, func(ctx context.Context) error {
+			result.SizeBefore, _ = git.DirectorySize(path)
+			if err := git.GarbageCollect(ctx, path, req.Args...); err != nil {
+				return err
+			}
+			result.SizeAfter, _ = git.DirectorySize(path)
+			return nil
+		}, func(err error) {
+			result.Duration = time.Since(started)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Error = err
+				resp.Failed++
+				tracker.FailJobWithDetails(result.Path, result.Duration, err)
+				uc.logger.Error("Garbage collection failed",
+					shared.StringField("path", path),
+					shared.ErrorField(err))
+			} else {
+				resp.Collected++
+				resp.SizeBefore += result.SizeBefore
+				resp.SizeAfter += result.SizeAfter
+				tracker.CompleteJobWithDetails(result.Path, result.Duration, max(result.Reclaimed(), 0))
+			}
+			resp.Results = append(resp.Results, result)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	pool.Wait()
+
+	slices.SortFunc(resp.Results, func(a, b *GarbageCollectResult) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	resp.TotalDuration = time.Since(startTime)
+
+	uc.logger.Info("Garbage collection completed",
+		shared.IntField("collected", resp.Collected),
+		shared.IntField("failed", resp.Failed),
+		shared.IntField("reclaimed_bytes", int(resp.Reclaimed())),
+		shared.DurationField("total_duration", resp.TotalDuration))
+
+	return resp, nil
+}
@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestGarbageCollectUseCase_Execute(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	baseDir := t.TempDir()
+	for _, name := range []string{"alice/tools", "bob/api"} {
+		_, err := gogit.PlainInit(filepath.Join(baseDir, name), false)
+		require.NoError(t, err)
+	}
+	// Looks like a clone but is not a repository git can open
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "broken", ".git"), 0755))
+
+	tracker := cloning.NewProgressTracker(0)
+	resp, err := NewGarbageCollectUseCase(logging.NewNoOpLogger()).Execute(context.Background(), &GarbageCollectRequest{
+		BaseDirectory:   baseDir,
+		Concurrency:     2,
+		Args:            []string{"--prune=now"},
+		ProgressTracker: tracker,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, resp.Collected)
+	assert.Equal(t, 1, resp.Failed)
+	require.Len(t, resp.Results, 3)
+	assert.Equal(t, []string{"alice/tools", "bob/api", "broken"},
+		[]string{resp.Results[0].Path, resp.Results[1].Path, resp.Results[2].Path})
+	assert.NoError(t, resp.Results[0].Error)
+	assert.Positive(t, resp.Results[0].SizeBefore)
+	assert.Error(t, resp.Results[2].Error)
+
+	progress := tracker.GetProgress()
+	assert.Equal(t, 3, progress.Total)
+	assert.Equal(t, 2, progress.Completed)
+	assert.Equal(t, 1, progress.Failed)
+	assert.True(t, progress.IsComplete())
+}
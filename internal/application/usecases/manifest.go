@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/manifest"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+)
+
+// defaultManifestScanDepth covers the flat (base/repo), org (base/owner/repo)
+// and provider (base/provider/owner/repo) layouts
+const defaultManifestScanDepth = 3
+
+// ExportManifestRequest represents the input for exporting a workspace manifest
+type ExportManifestRequest struct {
+	BaseDirectory string
+	MaxDepth      int
+}
+
+// ExportManifestUseCase builds a manifest from the repositories in a workspace
+type ExportManifestUseCase struct {
+	logger shared.Logger
+}
+
+// NewExportManifestUseCase creates a new export manifest use case
+func NewExportManifestUseCase(logger shared.Logger) *ExportManifestUseCase {
+	return &ExportManifestUseCase{logger: logger}
+}
+
+// Execute scans the base directory and records every repository with its pinned revision
+func (uc *ExportManifestUseCase) Execute(ctx context.Context, req *ExportManifestRequest) (*manifest.Manifest, error) {
+	if req == nil || req.BaseDirectory == "" {
+		return nil, fmt.Errorf("base directory cannot be empty")
+	}
+	if req.MaxDepth <= 0 {
+		req.MaxDepth = defaultManifestScanDepth
+	}
+
+	paths, err := git.FindRepositories(req.BaseDirectory, req.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := filepath.Abs(req.BaseDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base directory: %w", err)
+	}
+
+	entries := make([]manifest.Entry, 0, len(paths))
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		state, err := git.InspectRepository(path)
+		if err != nil {
+			uc.logger.Warn("Skipping repository that could not be inspected",
+				shared.StringField("path", path),
+				shared.ErrorField(err))
+			continue
+		}
+		if state.RemoteURL == "" {
+			uc.logger.Warn("Skipping repository without origin remote",
+				shared.StringField("path", path))
+			continue
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute relative path for %s: %w", path, err)
+		}
+
+		cloneURL := manifest.NormalizeCloneURL(state.RemoteURL)
+		entry := manifest.Entry{
+			Name:     filepath.Base(path),
+			Path:     filepath.ToSlash(rel),
+			URL:      cloneURL,
+			Branch:   state.Branch,
+			Revision: state.Revision,
+		}
+		if repo, err := entry.ToRepository(); err == nil {
+			entry.Owner = repo.Owner
+		}
+
+		entries = append(entries, entry)
+	}
+
+	uc.logger.Info("Workspace manifest exported",
+		shared.StringField("base_directory", root),
+		shared.IntField("repositories", len(entries)))
+
+	return manifest.NewManifest(entries), nil
+}
+
+// NewCloneRequestFromManifest converts a manifest into a clone request that
+// reproduces the workspace layout under baseDir
+func NewCloneRequestFromManifest(
+	m *manifest.Manifest,
+	baseDir string,
+	options *cloning.CloneOptions,
+	concurrency int,
+) (*CloneRepositoriesRequest, error) {
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	repos := make([]*repository.Repository, 0, len(m.Repositories))
+	overrides := make(map[repository.RepositoryID]JobOverride, len(m.Repositories))
+
+	for i := range m.Repositories {
+		entry := &m.Repositories[i]
+		repo, err := entry.ToRepository()
+		if err != nil {
+			return nil, fmt.Errorf("manifest entry %q: %w", entry.Path, err)
+		}
+		repos = append(repos, repo)
+		overrides[repo.ID] = JobOverride{
+			RelativePath: filepath.FromSlash(entry.Path),
+			Branch:       entry.Branch,
+			Ref:          entry.Revision,
+		}
+	}
+
+	return &CloneRepositoriesRequest{
+		Repositories:  repos,
+		BaseDirectory: baseDir,
+		Options:       options,
+		Concurrency:   concurrency,
+		Overrides:     overrides,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+)
+
+// Well-known provider hosts
+const (
+	GitHubHost    = "github.com"
+	BitbucketHost = "bitbucket.org"
+)
+
+// ResolveSourceResponse describes which provider and owner a source URL refers to
+type ResolveSourceResponse struct {
+	Source *repository.Source
+	Type   repository.RepositoryType
+	Owner  string
+}
+
+// ResolveSourceUseCase maps provider URLs such as github.com/org or
+// bitbucket.org/workspace to the repository type and owner used to fetch
+// repositories, so a single clone command serves every provider
+type ResolveSourceUseCase struct {
+	githubClient          *github.GitHubClient
+	bitbucketServerClient *bitbucket.BitbucketServerClient
+	logger                shared.Logger
+}
+
+// NewResolveSourceUseCase creates a new resolve source use case. The
+// Bitbucket Server client is optional.
+func NewResolveSourceUseCase(
+	githubClient *github.GitHubClient,
+	bitbucketServerClient *bitbucket.BitbucketServerClient,
+	logger shared.Logger,
+) *ResolveSourceUseCase {
+	return &ResolveSourceUseCase{
+		githubClient:          githubClient,
+		bitbucketServerClient: bitbucketServerClient,
+		logger:                logger,
+	}
+}
+
+// Execute resolves a source URL
+func (uc *ResolveSourceUseCase) Execute(ctx context.Context, rawSource string) (*ResolveSourceResponse, error) {
+	source, err := repository.ParseSource(rawSource)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ResolveSourceResponse{Source: source, Owner: source.Owner()}
+
+	switch {
+	case source.Host == GitHubHost || source.Host == "www."+GitHubHost:
+		if uc.githubClient == nil {
+			return nil, fmt.Errorf("GitHub client not configured")
+		}
+		// Users and organizations are listed through different endpoints
+		resp.Type, err = uc.githubClient.GetOwnerType(ctx, resp.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve GitHub account %s: %w", resp.Owner, err)
+		}
+	case source.Host == BitbucketHost || source.Host == "www."+BitbucketHost:
+		// Bitbucket Cloud lists users and workspaces through the same endpoint
+		resp.Type = repository.RepositoryTypeBitbucketWorkspace
+	case uc.bitbucketServerClient != nil && source.Host == strings.ToLower(uc.bitbucketServerClient.Host()):
+		resp.Type = repository.RepositoryTypeBitbucketProject
+		resp.Owner = bitbucketServerProjectKey(source)
+	default:
+		return nil, fmt.Errorf("no provider configured for host %s (supported: %s)", source.Host, strings.Join(uc.supportedHosts(), ", "))
+	}
+
+	uc.logger.Debug("Resolved source",
+		shared.StringField("source", source.String()),
+		shared.StringField("type", resp.Type.String()),
+		shared.StringField("owner", resp.Owner))
+
+	return resp, nil
+}
+
+// supportedHosts lists the hosts this use case can resolve
+func (uc *ResolveSourceUseCase) supportedHosts() []string {
+	hosts := []string{GitHubHost, BitbucketHost}
+	if uc.bitbucketServerClient != nil {
+		hosts = append(hosts, uc.bitbucketServerClient.Host())
+	}
+	return hosts
+}
+
+// bitbucketServerProjectKey extracts the project key from either host/PROJ or
+// a browser URL such as host/projects/PROJ/repos. Instances served under a
+// context path (host/bitbucket/PROJ) are handled as well.
+func bitbucketServerProjectKey(source *repository.Source) string {
+	segments := source.Segments()
+	for i := 0; i < len(segments)-1; i++ {
+		if strings.EqualFold(segments[i], "projects") {
+			return segments[i+1]
+		}
+	}
+	return segments[len(segments)-1]
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestResolveSourceUseCase(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/kubernetes":
+			_, _ = w.Write([]byte(`{"login":"kubernetes","type":"Organization"}`))
+		case "/users/octocat":
+			_, _ = w.Write([]byte(`{"login":"octocat","type":"User"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	logger := logging.NewNoOpLogger()
+	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{BaseURL: api.URL, Logger: logger})
+	serverClient, err := bitbucket.NewBitbucketServerClient(&bitbucket.BitbucketServerClientConfig{
+		BaseURL: "https://git.example.com/bitbucket",
+		Logger:  logger,
+	})
+	require.NoError(t, err)
+
+	useCase := NewResolveSourceUseCase(githubClient, serverClient, logger)
+
+	tests := []struct {
+		source    string
+		wantType  repository.RepositoryType
+		wantOwner string
+		wantErr   bool
+	}{
+		{source: "github.com/kubernetes", wantType: repository.RepositoryTypeOrganization, wantOwner: "kubernetes"},
+		{source: "https://github.com/octocat/", wantType: repository.RepositoryTypeUser, wantOwner: "octocat"},
+		{source: "bitbucket.org/myworkspace", wantType: repository.RepositoryTypeBitbucketWorkspace, wantOwner: "myworkspace"},
+		{source: "git.example.com/bitbucket/projects/PROJ/repos", wantType: repository.RepositoryTypeBitbucketProject, wantOwner: "PROJ"},
+		{source: "git.example.com/PROJ", wantType: repository.RepositoryTypeBitbucketProject, wantOwner: "PROJ"},
+		{source: "github.com/missing", wantErr: true},
+		{source: "gitlab.example.com/group", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			resp, err := useCase.Execute(context.Background(), tt.source)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, resp.Type)
+			assert.Equal(t, tt.wantOwner, resp.Owner)
+		})
+	}
+}
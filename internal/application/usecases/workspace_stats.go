@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/manifest"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+)
+
+// WorkspaceStatsRequest represents the input for analyzing a clone tree
+type WorkspaceStatsRequest struct {
+	BaseDirectory string
+	MaxDepth      int
+}
+
+// CloneStats describes a repository found in a clone tree
+type CloneStats struct {
+	Path      string    `json:"path"` // Relative to the base directory
+	RemoteURL string    `json:"remote_url,omitempty"`
+	Host      string    `json:"host,omitempty"`
+	Branch    string    `json:"branch,omitempty"`
+	Size      int64     `json:"size"` // Bytes on disk, .git included
+	Dirty     bool      `json:"dirty"`
+	LastFetch time.Time `json:"last_fetch,omitzero"` // Zero when never fetched since cloned
+	Error     string    `json:"error,omitempty"`     // Why the repository could not be fully inspected
+}
+
+// HostStats sums the repositories of a clone tree cloned from one host
+type HostStats struct {
+	Host         string `json:"host"`
+	Repositories int    `json:"repositories"`
+	Size         int64  `json:"size"`
+}
+
+// WorkspaceStats summarizes a clone tree
+type WorkspaceStats struct {
+	BaseDirectory string       `json:"base_directory"`
+	Repositories  []CloneStats `json:"repositories"`
+	Hosts         []HostStats  `json:"hosts"`
+	TotalSize     int64        `json:"total_size"`
+	Dirty         int          `json:"dirty"`
+}
+
+// WorkspaceStatsUseCase analyzes the repositories of an existing clone tree
+type WorkspaceStatsUseCase struct {
+	logger shared.Logger
+}
+
+// NewWorkspaceStatsUseCase creates a new workspace stats use case
+func NewWorkspaceStatsUseCase(logger shared.Logger) *WorkspaceStatsUseCase {
+	return &WorkspaceStatsUseCase{logger: logger}
+}
+
+// Execute finds the repositories below the base directory and inspects their
+// disk usage, remote, working tree and last fetch. Repositories are inspected
+// concurrently since walking large clones dominates the run.
+func (uc *WorkspaceStatsUseCase) Execute(ctx context.Context, req *WorkspaceStatsRequest) (*WorkspaceStats, error) {
+	if req == nil || req.BaseDirectory == "" {
+		return nil, fmt.Errorf("base directory cannot be empty")
+	}
+	if req.MaxDepth <= 0 {
+		req.MaxDepth = defaultManifestScanDepth
+	}
+
+	root, err := filepath.Abs(req.BaseDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base directory: %w", err)
+	}
+
+	paths, err := git.FindRepositories(root, req.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	clones := make([]CloneStats, len(paths))
+	sem := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		if err := ctx.Err(); err != nil {
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			clones[i] = uc.inspect(root, path)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stats := &WorkspaceStats{BaseDirectory: root, Repositories: clones}
+	hosts := make(map[string]*HostStats)
+	for _, clone := range clones {
+		stats.TotalSize += clone.Size
+		if clone.Dirty {
+			stats.Dirty++
+		}
+
+		host := clone.Host
+		if host == "" {
+			host = "unknown"
+		}
+		if hosts[host] == nil {
+			hosts[host] = &HostStats{Host: host}
+		}
+		hosts[host].Repositories++
+		hosts[host].Size += clone.Size
+	}
+	for _, host := range hosts {
+		stats.Hosts = append(stats.Hosts, *host)
+	}
+	slices.SortFunc(stats.Hosts, func(a, b HostStats) int {
+		return cmp.Or(cmp.Compare(b.Size, a.Size), cmp.Compare(a.Host, b.Host))
+	})
+
+	uc.logger.Info("Workspace analyzed",
+		shared.StringField("base_directory", root),
+		shared.IntField("repositories", len(clones)),
+		shared.IntField("dirty", stats.Dirty))
+
+	return stats, nil
+}
+
+// inspect collects the statistics of one repository, recording inspection
+// errors instead of failing the whole analysis
+func (uc *WorkspaceStatsUseCase) inspect(root, path string) CloneStats {
+	clone := CloneStats{Path: path, LastFetch: git.LastFetch(path)}
+	if rel, err := filepath.Rel(root, path); err == nil {
+		clone.Path = filepath.ToSlash(rel)
+	}
+
+	var errs []string
+	size, err := git.DirectorySize(path)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	clone.Size = size
+
+	if state, err := git.InspectRepository(path); err == nil {
+		clone.RemoteURL = manifest.NormalizeCloneURL(state.RemoteURL) // Without credentials
+		clone.Branch = state.Branch
+	} else if origin, originErr := git.OriginURL(path); originErr == nil {
+		// A repository without commits has no HEAD but may have a remote
+		clone.RemoteURL = manifest.NormalizeCloneURL(origin)
+	}
+	if clone.RemoteURL != "" {
+		clone.Host, _, _ = strings.Cut(repository.CloneURLKey(clone.RemoteURL), "/")
+	}
+
+	if clone.Dirty, err = git.IsDirty(path); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		clone.Error = strings.Join(errs, "; ")
+		uc.logger.Warn("Repository could not be fully inspected",
+			shared.StringField("path", path),
+			shared.StringField("error", clone.Error))
+	}
+	return clone
+}
@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestWorkspaceStatsUseCase_Execute(t *testing.T) {
+	baseDir := t.TempDir()
+	initRepo := func(rel, origin string) string {
+		path := filepath.Join(baseDir, rel)
+		repo, err := gogit.PlainInit(path, false)
+		require.NoError(t, err)
+		_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{origin}})
+		require.NoError(t, err)
+		return path
+	}
+
+	// A dirty clone that fetched, a clean one that never did
+	dirty := initRepo("alice/tools", "https://token@github.com/alice/tools.git")
+	require.NoError(t, os.WriteFile(filepath.Join(dirty, "notes.txt"), []byte("draft"), 0644))
+	fetched := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	fetchHead := filepath.Join(dirty, ".git", "FETCH_HEAD")
+	require.NoError(t, os.WriteFile(fetchHead, nil, 0644))
+	require.NoError(t, os.Chtimes(fetchHead, fetched, fetched))
+	initRepo("bob/api", "git@gitlab.com:bob/api.git")
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "not-a-repo"), 0755))
+
+	stats, err := NewWorkspaceStatsUseCase(logging.NewNoOpLogger()).Execute(context.Background(), &WorkspaceStatsRequest{
+		BaseDirectory: baseDir,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, stats.Repositories, 2)
+	tools, api := stats.Repositories[0], stats.Repositories[1]
+
+	assert.Equal(t, "alice/tools", tools.Path)
+	assert.Equal(t, "https://github.com/alice/tools.git", tools.RemoteURL, "credentials are stripped")
+	assert.Equal(t, "github.com", tools.Host)
+	assert.True(t, tools.Dirty)
+	assert.True(t, tools.LastFetch.Equal(fetched))
+	assert.Positive(t, tools.Size)
+
+	assert.Equal(t, "bob/api", api.Path)
+	assert.Equal(t, "gitlab.com", api.Host)
+	assert.False(t, api.Dirty)
+	assert.True(t, api.LastFetch.IsZero())
+
+	assert.Equal(t, 1, stats.Dirty)
+	assert.Equal(t, tools.Size+api.Size, stats.TotalSize)
+	require.Len(t, stats.Hosts, 2)
+	for _, host := range stats.Hosts {
+		assert.Equal(t, 1, host.Repositories)
+	}
+}
+
+func TestWorkspaceStatsUseCase_MissingDirectory(t *testing.T) {
+	uc := NewWorkspaceStatsUseCase(logging.NewNoOpLogger())
+
+	_, err := uc.Execute(context.Background(), &WorkspaceStatsRequest{})
+	assert.Error(t, err)
+
+	_, err = uc.Execute(context.Background(), &WorkspaceStatsRequest{BaseDirectory: filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, err)
+}
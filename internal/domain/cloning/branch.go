@@ -0,0 +1,49 @@
+package cloning
+
+import (
+	"fmt"
+	"path"
+)
+
+// ValidateBranchPatterns checks the default branch patterns of
+// Options.DefaultBranches, shell patterns such as main or release/*
+func ValidateBranchPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			return fmt.Errorf("default branch pattern cannot be empty")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid default branch pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// ExpectedBranch returns the branch the clone of the job checks out:
+// Options.Branch, or the default branch of the repository when it is empty.
+// It is empty for jobs pinned to a ref, which leave HEAD detached.
+func (cj *CloneJob) ExpectedBranch() string {
+	switch {
+	case cj.Options.Ref != "":
+		return ""
+	case cj.Options.Branch != "":
+		return cj.Options.Branch
+	default:
+		return cj.Repository.DefaultBranch
+	}
+}
+
+// SkipsDefaultBranch reports whether the default branch of the job's
+// repository matches none of Options.DefaultBranches. Repositories of unknown
+// default branch, such as those of repository lists, are never skipped.
+func (cj *CloneJob) SkipsDefaultBranch() bool {
+	if len(cj.Options.DefaultBranches) == 0 || cj.Repository.DefaultBranch == "" {
+		return false
+	}
+	for _, pattern := range cj.Options.DefaultBranches {
+		if matched, _ := path.Match(pattern, cj.Repository.DefaultBranch); matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package cloning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func TestValidateBranchPatterns(t *testing.T) {
+	assert.NoError(t, ValidateBranchPatterns(nil))
+	assert.NoError(t, ValidateBranchPatterns([]string{"main", "release/*"}))
+	assert.ErrorContains(t, ValidateBranchPatterns([]string{""}), "cannot be empty")
+	assert.ErrorContains(t, ValidateBranchPatterns([]string{"[main"}), `invalid default branch pattern "[main"`)
+}
+
+func TestCloneJob_DefaultBranch(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultBranch string
+		options       CloneOptions
+		expected      string
+		skipped       bool
+	}{
+		{name: "default branch", defaultBranch: "trunk", expected: "trunk"},
+		{name: "requested branch", defaultBranch: "trunk", options: CloneOptions{Branch: "dev"}, expected: "dev"},
+		{name: "pinned ref", defaultBranch: "trunk", options: CloneOptions{Ref: "v1.0.0"}, expected: ""},
+		{name: "matching pattern", defaultBranch: "main", options: CloneOptions{DefaultBranches: []string{"master", "main"}}, expected: "main"},
+		{name: "matching glob", defaultBranch: "release/2.0", options: CloneOptions{DefaultBranches: []string{"release/*"}}, expected: "release/2.0"},
+		{name: "other branch", defaultBranch: "master", options: CloneOptions{DefaultBranches: []string{"main"}}, expected: "master", skipped: true},
+		{name: "unknown default branch", options: CloneOptions{DefaultBranches: []string{"main"}}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := repository.NewRepository(1, "repo", "https://github.com/owner/repo.git", "owner", false, 0, tt.defaultBranch)
+			require.NoError(t, err)
+			job := NewCloneJob(repo, t.TempDir(), &tt.options)
+
+			assert.Equal(t, tt.expected, job.ExpectedBranch())
+			assert.Equal(t, tt.skipped, job.SkipsDefaultBranch())
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package cloning
+
+import (
+	"slices"
+	"time"
+)
+
+// JobEventType names a step of the clone job lifecycle
+type JobEventType string
+
+const (
+	JobEventQueued    JobEventType = "queued"    // Submitted to the worker pool
+	JobEventStarted   JobEventType = "started"   // Picked up by a worker
+	JobEventRetry     JobEventType = "retry"     // An attempt failed and will be retried
+	JobEventCompleted JobEventType = "completed" // Cloned
+	JobEventUpdated   JobEventType = "updated"   // An existing clone was updated instead
+	JobEventFailed    JobEventType = "failed"    // Failed after all attempts
+	JobEventSkipped   JobEventType = "skipped"   // Left alone, e.g. the destination exists
+	JobEventCancelled JobEventType = "cancelled" // Stopped before it finished
+)
+
+// Final reports whether the event ends the lifecycle of a job
+func (t JobEventType) Final() bool {
+	switch t {
+	case JobEventCompleted, JobEventUpdated, JobEventFailed, JobEventSkipped, JobEventCancelled:
+		return true
+	}
+	return false
+}
+
+// JobEvent reports a step of the lifecycle of a clone job
+type JobEvent struct {
+	Type        JobEventType `json:"event"`
+	Time        time.Time    `json:"time"`
+	JobID       string       `json:"job_id"`
+	Repository  string       `json:"repository"`
+	CloneURL    string       `json:"clone_url"`
+	Destination string       `json:"destination"`
+	Attempt     int          `json:"attempt,omitempty"`     // 1-based clone attempt of started, retry and final events
+	DurationMS  int64        `json:"duration_ms,omitempty"` // Time since the job started, for final events
+	SizeBytes   int64        `json:"size_bytes,omitempty"`
+	Revision    string       `json:"revision,omitempty"` // Commit SHA of HEAD, for completed and updated events
+	Branch      string       `json:"branch,omitempty"`   // Branch of HEAD, for completed and updated events
+	Error       string       `json:"error,omitempty"`
+	LogFile     string       `json:"log_file,omitempty"`
+	Attempts    []JobAttempt `json:"attempts,omitempty"` // Attempt history, for final events
+}
+
+// JobEventFunc receives the lifecycle events of clone jobs. It is called
+// concurrently from the workers.
+type JobEventFunc func(JobEvent)
+
+// NewJobEvent describes the current state of a job
+func NewJobEvent(eventType JobEventType, job *CloneJob) JobEvent {
+	event := JobEvent{
+		Type:        eventType,
+		Time:        time.Now(),
+		JobID:       job.ID,
+		Repository:  job.Repository.GetFullName(),
+		CloneURL:    job.Repository.CloneURL,
+		Destination: job.GetDestinationPath(),
+		LogFile:     job.LogFile,
+	}
+	if eventType != JobEventQueued {
+		event.Attempt = job.RetryCount + 1
+	}
+	if !job.StartedAt.IsZero() && !job.CompletedAt.IsZero() {
+		event.DurationMS = job.CompletedAt.Sub(job.StartedAt).Milliseconds()
+	}
+	if job.Error != nil {
+		event.Error = job.Error.Error()
+	}
+	if eventType.Final() {
+		event.Attempts = slices.Clone(job.Attempts)
+	}
+	return event
+}
@@ -0,0 +1,53 @@
+package cloning
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConflictPolicy decides what happens when a clone destination already holds
+// a clone of a different remote, e.g. same-named repositories of two owners
+type ConflictPolicy string
+
+const (
+	ConflictSkip   ConflictPolicy = "skip"   // Keep the existing clone and skip the job
+	ConflictRename ConflictPolicy = "rename" // Clone next to it as <name>-<owner>
+	ConflictError  ConflictPolicy = "error"  // Fail the job
+)
+
+// ParseConflictPolicy validates a user supplied conflict policy
+func ParseConflictPolicy(value string) (ConflictPolicy, error) {
+	policy := ConflictPolicy(strings.ToLower(strings.TrimSpace(value)))
+
+	switch policy {
+	case "":
+		return ConflictSkip, nil
+	case ConflictSkip, ConflictRename, ConflictError:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid conflict policy %q (supported: skip, rename, error)", value)
+	}
+}
+
+// ExistingAction decides what happens to a destination that already holds a
+// clone of the job's remote
+type ExistingAction string
+
+const (
+	ExistingSkip   ExistingAction = "skip"   // Leave the clone untouched
+	ExistingUpdate ExistingAction = "update" // Fetch and fast-forward the clone
+)
+
+// ParseExistingAction validates a user supplied existing destination action
+func ParseExistingAction(value string) (ExistingAction, error) {
+	action := ExistingAction(strings.ToLower(strings.TrimSpace(value)))
+
+	switch action {
+	case "":
+		return ExistingSkip, nil
+	case ExistingSkip, ExistingUpdate:
+		return action, nil
+	default:
+		return "", fmt.Errorf("invalid existing action %q (supported: skip, update)", value)
+	}
+}
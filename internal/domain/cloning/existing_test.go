@@ -0,0 +1,58 @@
+package cloning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConflictPolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    ConflictPolicy
+		wantErr bool
+	}{
+		{value: "", want: ConflictSkip},
+		{value: "skip", want: ConflictSkip},
+		{value: "Rename", want: ConflictRename},
+		{value: " error ", want: ConflictError},
+		{value: "overwrite", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseConflictPolicy(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseExistingAction(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    ExistingAction
+		wantErr bool
+	}{
+		{value: "", want: ExistingSkip},
+		{value: "skip", want: ExistingSkip},
+		{value: "UPDATE", want: ExistingUpdate},
+		{value: "pull", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseExistingAction(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package cloning
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CloneFilter is a git partial clone filter: the objects of the history a
+// clone leaves on the server and fetches on demand
+type CloneFilter string
+
+const (
+	FilterNone     CloneFilter = ""          // Full clone
+	FilterBlobless CloneFilter = "blob:none" // Fetch commits and trees, blobs on checkout
+	FilterTreeless CloneFilter = "tree:0"    // Fetch commits, trees and blobs on checkout
+)
+
+// blobLimitPattern matches blob:limit=<n>[kmg] filters, omitting larger blobs
+var blobLimitPattern = regexp.MustCompile(`^blob:limit=[0-9]+[kmg]?$`)
+
+// ParseCloneFilter validates a user supplied partial clone filter, accepting
+// the blobless and treeless aliases besides git filter specs
+func ParseCloneFilter(value string) (CloneFilter, error) {
+	filter := strings.ToLower(strings.TrimSpace(value))
+
+	switch {
+	case filter == "" || filter == "none":
+		return FilterNone, nil
+	case filter == "blobless" || filter == string(FilterBlobless):
+		return FilterBlobless, nil
+	case filter == "treeless" || filter == string(FilterTreeless):
+		return FilterTreeless, nil
+	case blobLimitPattern.MatchString(filter):
+		return CloneFilter(filter), nil
+	default:
+		return "", fmt.Errorf("invalid clone filter %q (supported: blobless, treeless, blob:none, tree:0, blob:limit=<size>)", value)
+	}
+}
@@ -0,0 +1,38 @@
+package cloning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCloneFilter(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    CloneFilter
+		wantErr bool
+	}{
+		{value: "", want: FilterNone},
+		{value: "none", want: FilterNone},
+		{value: "blobless", want: FilterBlobless},
+		{value: "blob:none", want: FilterBlobless},
+		{value: "Treeless", want: FilterTreeless},
+		{value: "tree:0", want: FilterTreeless},
+		{value: "blob:limit=1m", want: "blob:limit=1m"},
+		{value: "blob:limit=", wantErr: true},
+		{value: "tree:1", wantErr: true},
+		{value: "sparse:oid=abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseCloneFilter(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/italoag/repocloner/internal/domain/repository"
@@ -19,6 +21,8 @@ const (
 	JobStatusCompleted
 	JobStatusFailed
 	JobStatusSkipped
+	JobStatusUpdated   // An existing clone was updated instead of cloned
+	JobStatusCancelled // Stopped by cancellation before it finished
 )
 
 // String returns the string representation of job status
@@ -34,18 +38,42 @@ func (js JobStatus) String() string {
 		return "failed"
 	case JobStatusSkipped:
 		return "skipped"
+	case JobStatusUpdated:
+		return "updated"
+	case JobStatusCancelled:
+		return "cancelled"
 	default:
 		return "unknown"
 	}
 }
 
+// Finished reports whether a job with this status is done
+func (js JobStatus) Finished() bool {
+	switch js {
+	case JobStatusCompleted, JobStatusFailed, JobStatusSkipped, JobStatusUpdated, JobStatusCancelled:
+		return true
+	}
+	return false
+}
+
 // CloneOptions represents options for cloning repositories
 type CloneOptions struct {
 	Depth             int
 	RecurseSubmodules bool
+	SubmoduleDepth    int  // Maximum submodule nesting to initialize, 0 for unlimited
+	ShallowSubmodules bool // Clone submodules with a history depth of 1
 	Branch            string
+	Ref               string // Tag or commit SHA to check out after cloning
 	SkipExisting      bool
-	CreateOrgDirs     bool
+	OnConflict        ConflictPolicy // Destination holding a clone of a different remote
+	Existing          ExistingAction // Destination holding a clone of the same remote
+	CreateOrgDirs     bool           // Clone into <owner>/<repo>
+	ProviderDirs      bool           // With CreateOrgDirs, clone into <provider>/<owner>/<repo>
+	SkipLFS           bool           // Check out Git LFS pointers without downloading their objects
+	SparsePaths       []string       // Directories checked out of a blob-less clone, empty checks out everything
+	Filter            CloneFilter    // Partial clone filter, empty for a full clone
+	MaxSize           int64          // Skip repositories reported larger, in bytes; 0 for no limit
+	DefaultBranches   []string       // Skip repositories whose default branch matches none of these patterns; empty keeps all
 }
 
 // NewDefaultCloneOptions creates clone options with sensible defaults
@@ -55,6 +83,8 @@ func NewDefaultCloneOptions() *CloneOptions {
 		RecurseSubmodules: true,
 		Branch:            "", // Use default branch
 		SkipExisting:      true,
+		OnConflict:        ConflictSkip,
+		Existing:          ExistingSkip,
 		CreateOrgDirs:     false,
 	}
 }
@@ -64,6 +94,37 @@ func (co *CloneOptions) Validate() error {
 	if co.Depth < 0 {
 		return fmt.Errorf("depth cannot be negative")
 	}
+	if co.SubmoduleDepth < 0 {
+		return fmt.Errorf("submodule depth cannot be negative")
+	}
+	if co.MaxSize < 0 {
+		return fmt.Errorf("maximum size cannot be negative")
+	}
+	if _, err := ParseCloneFilter(string(co.Filter)); err != nil {
+		return err
+	}
+	if err := ValidateBranchPatterns(co.DefaultBranches); err != nil {
+		return err
+	}
+	return ValidateSparsePaths(co.SparsePaths)
+}
+
+// ValidateSparsePaths checks sparse checkout directories: cone mode patterns
+// are directories relative to the repository root
+func ValidateSparsePaths(paths []string) error {
+	for _, path := range paths {
+		clean := filepath.ToSlash(filepath.Clean(path))
+		switch {
+		case strings.TrimSpace(path) == "":
+			return fmt.Errorf("sparse path cannot be empty")
+		case filepath.IsAbs(path) || strings.HasPrefix(path, "/") || strings.HasPrefix(path, "-"):
+			return fmt.Errorf("sparse path %q must be relative to the repository root", path)
+		case clean == ".." || strings.HasPrefix(clean, "../"):
+			return fmt.Errorf("sparse path %q leaves the repository", path)
+		case strings.ContainsAny(path, "*?[!"):
+			return fmt.Errorf("sparse path %q must be a directory, patterns are not supported", path)
+		}
+	}
 	return nil
 }
 
@@ -72,6 +133,7 @@ type CloneJob struct {
 	ID            string
 	Repository    *repository.Repository
 	BaseDirectory string
+	RelativePath  string // Optional path under BaseDirectory overriding the default layout
 	Options       *CloneOptions
 	Status        JobStatus
 	StartedAt     time.Time
@@ -79,6 +141,17 @@ type CloneJob struct {
 	Error         error
 	RetryCount    int
 	MaxRetries    int
+	Attempts      []JobAttempt // Clone attempts in order, the last one decided the status
+	LogFile       string       // Per-repository git output log, empty when job logs are disabled
+}
+
+// JobAttempt records one clone attempt of a job
+type JobAttempt struct {
+	Number     int           `json:"number"` // 1-based
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration"`
+	Error      string        `json:"error,omitempty"`       // Empty when the attempt succeeded
+	RetryDelay time.Duration `json:"retry_delay,omitempty"` // Wait before the next attempt
 }
 
 // NewCloneJob creates a new clone job
@@ -103,17 +176,55 @@ func NewCloneJob(
 
 // GetDestinationPath returns the full path where the repository will be cloned
 func (cj *CloneJob) GetDestinationPath() string {
+	if cj.RelativePath != "" {
+		return filepath.Join(cj.BaseDirectory, cj.RelativePath)
+	}
 	if cj.Options.CreateOrgDirs {
-		return filepath.Join(cj.BaseDirectory, cj.Repository.Owner, cj.Repository.Name)
+		owner := pathSegment(cj.Repository.Owner)
+		if cj.Options.ProviderDirs {
+			return filepath.Join(cj.BaseDirectory, pathSegment(cj.Repository.Provider()), owner, cj.Repository.Name)
+		}
+		return filepath.Join(cj.BaseDirectory, owner, cj.Repository.Name)
 	}
 	return cj.Repository.GetLocalPath(cj.BaseDirectory)
 }
 
+// pathSegment makes a provider or owner name usable as a single directory
+// name on every platform: separators and characters Windows rejects in file
+// names become underscores
+func pathSegment(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
 // CanRetry checks if the job can be retried
 func (cj *CloneJob) CanRetry() bool {
 	return cj.RetryCount < cj.MaxRetries && cj.Status == JobStatusFailed
 }
 
+// RecordAttempt appends a finished attempt started at startedAt to the
+// history; errMessage is empty when the attempt succeeded
+func (cj *CloneJob) RecordAttempt(startedAt time.Time, errMessage string) {
+	cj.Attempts = append(cj.Attempts, JobAttempt{
+		Number:    len(cj.Attempts) + 1,
+		StartedAt: startedAt,
+		Duration:  time.Since(startedAt),
+		Error:     errMessage,
+	})
+}
+
+// LastAttempt returns the most recent attempt, or nil before the first one
+func (cj *CloneJob) LastAttempt() *JobAttempt {
+	if len(cj.Attempts) == 0 {
+		return nil
+	}
+	return &cj.Attempts[len(cj.Attempts)-1]
+}
+
 // MarkStarted marks the job as started
 func (cj *CloneJob) MarkStarted() {
 	cj.Status = JobStatusRunning
@@ -127,6 +238,13 @@ func (cj *CloneJob) MarkCompleted() {
 	cj.Error = nil
 }
 
+// MarkUpdated marks the job as done by updating an existing clone
+func (cj *CloneJob) MarkUpdated() {
+	cj.Status = JobStatusUpdated
+	cj.CompletedAt = time.Now()
+	cj.Error = nil
+}
+
 // MarkFailed marks the job as failed with an error
 func (cj *CloneJob) MarkFailed(err error) {
 	cj.Status = JobStatusFailed
@@ -134,6 +252,22 @@ func (cj *CloneJob) MarkFailed(err error) {
 	cj.Error = err
 }
 
+// MarkCancelled marks the job as stopped by cancellation. Its error is
+// ErrJobCancelled, so callers can still tell why it did not finish.
+func (cj *CloneJob) MarkCancelled() {
+	cj.Status = JobStatusCancelled
+	cj.CompletedAt = time.Now()
+	cj.Error = ErrJobCancelled
+}
+
+// MarkTimedOut marks the job as stopped by the deadline of the whole run. It
+// counts as cancelled, with an error matching both ErrJobCancelled and
+// ErrCloneTimeout.
+func (cj *CloneJob) MarkTimedOut() {
+	cj.MarkCancelled()
+	cj.Error = fmt.Errorf("%w by the run deadline: %w", ErrJobCancelled, ErrCloneTimeout)
+}
+
 // MarkSkipped marks the job as skipped
 func (cj *CloneJob) MarkSkipped(reason string) {
 	cj.Status = JobStatusSkipped
@@ -141,6 +275,14 @@ func (cj *CloneJob) MarkSkipped(reason string) {
 	cj.Error = fmt.Errorf("skipped: %s", reason)
 }
 
+// MarkSkippedBy marks the job as skipped with an error wrapping err, so
+// callers can tell the cause apart from other skips
+func (cj *CloneJob) MarkSkippedBy(err error) {
+	cj.Status = JobStatusSkipped
+	cj.CompletedAt = time.Now()
+	cj.Error = fmt.Errorf("skipped: %w", err)
+}
+
 // Retry increments retry count and resets status
 func (cj *CloneJob) Retry() {
 	if cj.CanRetry() {
@@ -192,6 +334,8 @@ type JobResult struct {
 	Duration  time.Duration
 	BytesSize int64
 	Success   bool
+	Revision  string // Commit SHA of HEAD once cloned or updated, empty when unknown
+	Branch    string // Branch of HEAD once cloned or updated, empty when detached
 }
 
 // NewJobResult creates a new job result
@@ -204,7 +348,10 @@ func NewJobResult(job *CloneJob, success bool, bytesSize int64) *JobResult {
 	}
 }
 
+// jobSequence tells apart jobs created within the same clock tick
+var jobSequence atomic.Uint64
+
 // generateJobID generates a unique job ID
 func generateJobID() string {
-	return fmt.Sprintf("job_%d", time.Now().UnixNano())
+	return fmt.Sprintf("job_%d_%d", time.Now().UnixNano(), jobSequence.Add(1))
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/italoag/repocloner/internal/domain/repository"
 )
@@ -25,6 +26,17 @@ func TestNewCloneJob(t *testing.T) {
 	assert.Equal(t, 3, job.MaxRetries)
 }
 
+func TestNewCloneJob_UniqueIDs(t *testing.T) {
+	repo := createTestRepository()
+
+	ids := make(map[string]bool)
+	for range 1000 {
+		job := NewCloneJob(repo, "/tmp/test", nil)
+		require.False(t, ids[job.ID], "duplicate job ID %s", job.ID)
+		ids[job.ID] = true
+	}
+}
+
 func TestCloneJob_GetDestinationPath(t *testing.T) {
 	repo := createTestRepository()
 
@@ -60,6 +72,75 @@ func TestCloneJob_GetDestinationPath(t *testing.T) {
 	}
 }
 
+func TestCloneJob_GetDestinationPath_ProviderDirs(t *testing.T) {
+	tests := []struct {
+		name     string
+		cloneURL string
+		owner    string
+		want     []string
+	}{
+		{
+			name:     "github",
+			cloneURL: "https://github.com/octocat/tools.git",
+			owner:    "octocat",
+			want:     []string{"github", "octocat", "tools"},
+		},
+		{
+			name:     "bitbucket",
+			cloneURL: "https://bitbucket.org/acme/tools.git",
+			owner:    "acme",
+			want:     []string{"bitbucket", "acme", "tools"},
+		},
+		{
+			name:     "self-hosted instance is named by host",
+			cloneURL: "ssh://git@git.example.com:7999/plat/tools.git",
+			owner:    "PLAT",
+			want:     []string{"git.example.com", "PLAT", "tools"},
+		},
+		{
+			name:     "owner with characters invalid in file names",
+			cloneURL: "https://git.example.com/team/tools.git",
+			owner:    `team/sub:a`,
+			want:     []string{"git.example.com", "team_sub_a", "tools"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := repository.NewRepository(1, "tools", tt.cloneURL, tt.owner, false, 0, "main")
+			require.NoError(t, err)
+			options := NewDefaultCloneOptions()
+			options.CreateOrgDirs = true
+			options.ProviderDirs = true
+
+			job := NewCloneJob(repo, "base", options)
+
+			assert.Equal(t, filepath.Join(append([]string{"base"}, tt.want...)...), job.GetDestinationPath())
+		})
+	}
+
+	// ProviderDirs only applies to the org layout
+	options := NewDefaultCloneOptions()
+	options.ProviderDirs = true
+	job := NewCloneJob(createTestRepository(), "base", options)
+	assert.Equal(t, filepath.Join("base", "test-repo"), job.GetDestinationPath())
+}
+
+func TestPathSegment(t *testing.T) {
+	tests := map[string]string{
+		"octocat":          "octocat",
+		"git.example.com":  "git.example.com",
+		`a\b/c`:            "a_b_c",
+		`x<y>z:"w"|?*`:     "x_y_z__w____",
+		"tab\tname":        "tab_name",
+		"Ünïcode-owner_01": "Ünïcode-owner_01",
+	}
+
+	for name, want := range tests {
+		assert.Equal(t, want, pathSegment(name), name)
+	}
+}
+
 func TestCloneJob_CanRetry(t *testing.T) {
 	job := NewCloneJob(createTestRepository(), "/tmp", NewDefaultCloneOptions())
 
@@ -127,6 +208,16 @@ func TestCloneJob_MarkSkipped(t *testing.T) {
 	assert.Contains(t, job.Error.Error(), reason)
 }
 
+func TestCloneJob_MarkTimedOut(t *testing.T) {
+	job := NewCloneJob(createTestRepository(), "/tmp", NewDefaultCloneOptions())
+	job.MarkStarted()
+	job.MarkTimedOut()
+
+	assert.Equal(t, JobStatusCancelled, job.Status)
+	assert.ErrorIs(t, job.Error, ErrJobCancelled)
+	assert.ErrorIs(t, job.Error, ErrCloneTimeout)
+}
+
 func TestCloneJob_Retry(t *testing.T) {
 	job := NewCloneJob(createTestRepository(), "/tmp", NewDefaultCloneOptions())
 	job.MarkFailed(assert.AnError)
@@ -258,3 +349,48 @@ func createTestRepository() *repository.Repository {
 	)
 	return repo
 }
+
+func TestCloneJob_RecordAttempt(t *testing.T) {
+	repo, err := repository.NewRepository(1, "repo", "https://github.com/owner/repo.git", "owner", false, 0, "main")
+	require.NoError(t, err)
+	job := NewCloneJob(repo, t.TempDir(), nil)
+	assert.Nil(t, job.LastAttempt())
+
+	job.RecordAttempt(time.Now().Add(-time.Second), "connection reset")
+	job.LastAttempt().RetryDelay = 5 * time.Second
+	job.RecordAttempt(time.Now(), "")
+
+	require.Len(t, job.Attempts, 2)
+	assert.Equal(t, 1, job.Attempts[0].Number)
+	assert.GreaterOrEqual(t, job.Attempts[0].Duration, time.Second)
+	assert.Equal(t, 5*time.Second, job.Attempts[0].RetryDelay)
+	assert.Equal(t, 2, job.LastAttempt().Number)
+	assert.Empty(t, job.LastAttempt().Error)
+}
+
+func TestValidateSparsePaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		wantErr bool
+	}{
+		{name: "directories", paths: []string{"services/api", "docs/"}},
+		{name: "none", paths: nil},
+		{name: "empty", paths: []string{" "}, wantErr: true},
+		{name: "absolute", paths: []string{"/services"}, wantErr: true},
+		{name: "option", paths: []string{"-x"}, wantErr: true},
+		{name: "outside", paths: []string{"services/../../etc"}, wantErr: true},
+		{name: "pattern", paths: []string{"src/*.go"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSparsePaths(tt.paths)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package cloning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func TestCloneJob_GetDestinationPath_Windows(t *testing.T) {
+	repo, err := repository.NewRepository(1, "tools", "https://github.com/octocat/tools.git", "octocat", false, 0, "main")
+	require.NoError(t, err)
+
+	options := NewDefaultCloneOptions()
+	options.CreateOrgDirs = true
+	options.ProviderDirs = true
+
+	job := NewCloneJob(repo, `C:\Users\dev\repos`, options)
+	assert.Equal(t, `C:\Users\dev\repos\github\octocat\tools`, job.GetDestinationPath())
+
+	// Forward slashes in the base directory are normalized
+	job = NewCloneJob(repo, "C:/Users/dev/repos", options)
+	assert.Equal(t, `C:\Users\dev\repos\github\octocat\tools`, job.GetDestinationPath())
+
+	// Drive-relative separators in an owner cannot escape the owner directory
+	repo.Owner = `..\..\evil`
+	job = NewCloneJob(repo, `C:\repos`, options)
+	assert.Equal(t, `C:\repos\github\.._.._evil\tools`, job.GetDestinationPath())
+}
@@ -0,0 +1,59 @@
+package cloning
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// JobOrder selects the order in which the jobs of a batch are submitted.
+// Workers pick jobs in submission order, so sorting a batch before submitting
+// it decides which repositories start first. It is not a priority: jobs
+// waiting for a slot of their host, retries and other batches sharing the
+// pool do not follow it.
+type JobOrder string
+
+const (
+	OrderFetched  JobOrder = "fetched"  // Keep the order returned by the provider
+	OrderSmallest JobOrder = "smallest" // Smallest repositories first for fast feedback
+	OrderLargest  JobOrder = "largest"  // Largest repositories first to shorten the tail
+	OrderName     JobOrder = "name"     // Alphabetical by owner/name
+	OrderPushed   JobOrder = "pushed"   // Most recently pushed first
+)
+
+// ParseJobOrder validates a user supplied scheduling order
+func ParseJobOrder(value string) (JobOrder, error) {
+	order := JobOrder(strings.ToLower(strings.TrimSpace(value)))
+
+	switch order {
+	case "":
+		return OrderFetched, nil
+	case OrderFetched, OrderSmallest, OrderLargest, OrderName, OrderPushed:
+		return order, nil
+	default:
+		return "", fmt.Errorf("invalid order %q (supported: fetched, smallest, largest, name, pushed)", value)
+	}
+}
+
+// Sort orders jobs in place. The sort is stable, so jobs that compare equal
+// keep the provider order.
+func (o JobOrder) Sort(jobs []*CloneJob) {
+	var less func(a, b *CloneJob) bool
+
+	switch o {
+	case OrderSmallest:
+		less = func(a, b *CloneJob) bool { return a.Repository.Size < b.Repository.Size }
+	case OrderLargest:
+		less = func(a, b *CloneJob) bool { return a.Repository.Size > b.Repository.Size }
+	case OrderName:
+		less = func(a, b *CloneJob) bool {
+			return strings.ToLower(a.Repository.GetFullName()) < strings.ToLower(b.Repository.GetFullName())
+		}
+	case OrderPushed:
+		less = func(a, b *CloneJob) bool { return a.Repository.PushedAt.After(b.Repository.PushedAt) }
+	default:
+		return
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool { return less(jobs[i], jobs[j]) })
+}
@@ -0,0 +1,80 @@
+package cloning
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func TestParseJobOrder(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    JobOrder
+		wantErr bool
+	}{
+		{value: "", want: OrderFetched},
+		{value: "fetched", want: OrderFetched},
+		{value: "Smallest", want: OrderSmallest},
+		{value: "largest", want: OrderLargest},
+		{value: "name", want: OrderName},
+		{value: "pushed", want: OrderPushed},
+		{value: "random", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			order, err := ParseJobOrder(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, order)
+		})
+	}
+}
+
+func TestJobOrder_Sort(t *testing.T) {
+	now := time.Now()
+	newJob := func(name string, size int64, pushed time.Time) *CloneJob {
+		repo, err := repository.NewRepository(1, name, fmt.Sprintf("https://github.com/owner/%s.git", name), "owner", false, size, "main")
+		require.NoError(t, err)
+		repo.PushedAt = pushed
+		return NewCloneJob(repo, t.TempDir(), nil)
+	}
+
+	tests := []struct {
+		order JobOrder
+		want  []string
+	}{
+		{order: OrderFetched, want: []string{"beta", "alpha", "gamma", "delta"}},
+		{order: OrderSmallest, want: []string{"gamma", "alpha", "delta", "beta"}},
+		{order: OrderLargest, want: []string{"beta", "alpha", "delta", "gamma"}},
+		{order: OrderName, want: []string{"alpha", "beta", "delta", "gamma"}},
+		{order: OrderPushed, want: []string{"gamma", "beta", "delta", "alpha"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			jobs := []*CloneJob{
+				newJob("beta", 300, now.Add(-time.Hour)),
+				newJob("alpha", 200, now.Add(-3*time.Hour)),
+				newJob("gamma", 100, now),
+				newJob("delta", 200, now.Add(-2*time.Hour)),
+			}
+
+			tt.order.Sort(jobs)
+
+			names := make([]string, len(jobs))
+			for i, job := range jobs {
+				names[i] = job.Repository.Name
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
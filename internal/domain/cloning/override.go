@@ -0,0 +1,79 @@
+package cloning
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// OptionOverride replaces clone options of the repositories matching a
+// pattern, e.g. full history for infra-* repositories in a shallow run. Unset
+// fields keep the options of the run.
+type OptionOverride struct {
+	// Match is a glob on the repository name, or on owner/name when it has a
+	// slash; matching is case-insensitive
+	Match string `yaml:"match" json:"match"`
+
+	Depth             *int    `yaml:"depth,omitempty" json:"depth,omitempty"`
+	Branch            *string `yaml:"branch,omitempty" json:"branch,omitempty"`
+	Submodules        *bool   `yaml:"submodules,omitempty" json:"submodules,omitempty"`
+	ShallowSubmodules *bool   `yaml:"shallow_submodules,omitempty" json:"shallow_submodules,omitempty"`
+	LFS               *bool   `yaml:"lfs,omitempty" json:"lfs,omitempty"` // Download Git LFS objects
+}
+
+// OptionOverrides are applied in order, so later matching overrides win
+type OptionOverrides []OptionOverride
+
+// Validate checks the patterns and values of the overrides
+func (o OptionOverrides) Validate() error {
+	for i, override := range o {
+		if override.Match == "" {
+			return fmt.Errorf("override %d: match cannot be empty", i+1)
+		}
+		if _, err := path.Match(strings.ToLower(override.Match), ""); err != nil {
+			return fmt.Errorf("override %d: invalid match %q: %w", i+1, override.Match, err)
+		}
+		if override.Depth != nil && *override.Depth < 0 {
+			return fmt.Errorf("override %d: depth cannot be negative", i+1)
+		}
+	}
+	return nil
+}
+
+// Apply returns the options of a repository: options itself when no override
+// matches, otherwise a copy with the matching overrides merged in
+func (o OptionOverrides) Apply(repo *repository.Repository, options *CloneOptions) *CloneOptions {
+	merged := options
+	for _, override := range o {
+		if !repo.MatchesPattern(override.Match) {
+			continue
+		}
+		if merged == options {
+			copied := *options
+			merged = &copied
+		}
+		override.applyTo(merged)
+	}
+	return merged
+}
+
+// applyTo sets the fields of the override on options
+func (o *OptionOverride) applyTo(options *CloneOptions) {
+	if o.Depth != nil {
+		options.Depth = *o.Depth
+	}
+	if o.Branch != nil {
+		options.Branch = *o.Branch
+	}
+	if o.Submodules != nil {
+		options.RecurseSubmodules = *o.Submodules
+	}
+	if o.ShallowSubmodules != nil {
+		options.ShallowSubmodules = *o.ShallowSubmodules
+	}
+	if o.LFS != nil {
+		options.SkipLFS = !*o.LFS
+	}
+}
@@ -0,0 +1,68 @@
+package cloning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func TestOptionOverrides_Apply(t *testing.T) {
+	full, lfs, branch := 0, true, "develop"
+	overrides := OptionOverrides{
+		{Match: "infra-*", Depth: &full, LFS: &lfs},
+		{Match: "acme/infra-legacy", Branch: &branch},
+	}
+	require.NoError(t, overrides.Validate())
+
+	options := NewDefaultCloneOptions()
+	options.SkipLFS = true
+
+	tests := []struct {
+		name        string
+		owner, repo string
+		wantDepth   int
+		wantBranch  string
+		wantSkipLFS bool
+	}{
+		{name: "no match keeps the run options", owner: "acme", repo: "web", wantDepth: 1, wantSkipLFS: true},
+		{name: "name pattern", owner: "acme", repo: "Infra-Network", wantDepth: 0},
+		{name: "later overrides merge in", owner: "acme", repo: "infra-legacy", wantDepth: 0, wantBranch: "develop"},
+		{name: "owner pattern of another owner", owner: "other", repo: "infra-legacy", wantDepth: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := repository.NewRepository(1, tt.repo, "https://github.com/"+tt.owner+"/"+tt.repo+".git", tt.owner, false, 0, "main")
+			require.NoError(t, err)
+
+			got := overrides.Apply(repo, options)
+			assert.Equal(t, tt.wantDepth, got.Depth)
+			assert.Equal(t, tt.wantBranch, got.Branch)
+			assert.Equal(t, tt.wantSkipLFS, got.SkipLFS)
+		})
+	}
+
+	assert.Equal(t, 1, options.Depth, "the run options are not modified")
+}
+
+func TestOptionOverrides_Validate(t *testing.T) {
+	negative := -1
+	tests := []struct {
+		name      string
+		overrides OptionOverrides
+		wantErr   string
+	}{
+		{name: "empty match", overrides: OptionOverrides{{}}, wantErr: "match cannot be empty"},
+		{name: "invalid match", overrides: OptionOverrides{{Match: "infra-["}}, wantErr: "invalid match"},
+		{name: "negative depth", overrides: OptionOverrides{{Match: "*", Depth: &negative}}, wantErr: "depth cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.overrides.Validate(), tt.wantErr)
+		})
+	}
+}
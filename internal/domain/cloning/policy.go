@@ -0,0 +1,70 @@
+package cloning
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FailureMode selects when failed clones should fail the whole run
+type FailureMode string
+
+const (
+	FailOnAny       FailureMode = "any"       // Any failed clone fails the run
+	FailOnNone      FailureMode = "none"      // Failed clones never fail the run
+	FailOnThreshold FailureMode = "threshold" // The run fails above a failure percentage
+)
+
+// FailurePolicy decides whether a run with failed clones is a failure
+type FailurePolicy struct {
+	Mode      FailureMode
+	Threshold float64 // Maximum tolerated failure percentage for FailOnThreshold
+}
+
+// ParseFailurePolicy parses "any", "none" or "threshold=N%"
+func ParseFailurePolicy(value string) (*FailurePolicy, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	switch value {
+	case "", string(FailOnAny):
+		return &FailurePolicy{Mode: FailOnAny}, nil
+	case string(FailOnNone):
+		return &FailurePolicy{Mode: FailOnNone}, nil
+	}
+
+	raw, ok := strings.CutPrefix(value, string(FailOnThreshold)+"=")
+	if !ok {
+		return nil, fmt.Errorf("invalid failure policy %q (supported: any, none, threshold=N%%)", value)
+	}
+
+	threshold, err := strconv.ParseFloat(strings.TrimSuffix(raw, "%"), 64)
+	if err != nil || threshold < 0 || threshold > 100 {
+		return nil, fmt.Errorf("invalid failure threshold %q, expected a percentage between 0 and 100", raw)
+	}
+
+	return &FailurePolicy{Mode: FailOnThreshold, Threshold: threshold}, nil
+}
+
+// Exceeded reports whether failed out of total clones fails the run
+func (p *FailurePolicy) Exceeded(total, failed int) bool {
+	if failed == 0 || total == 0 {
+		return false
+	}
+
+	switch p.Mode {
+	case FailOnNone:
+		return false
+	case FailOnThreshold:
+		return float64(failed)*100/float64(total) > p.Threshold
+	default:
+		return true
+	}
+}
+
+// String returns the policy in its flag syntax
+func (p *FailurePolicy) String() string {
+	if p.Mode == FailOnThreshold {
+		return fmt.Sprintf("%s=%s%%", p.Mode, strconv.FormatFloat(p.Threshold, 'f', -1, 64))
+	}
+	return string(p.Mode)
+}
@@ -0,0 +1,60 @@
+package cloning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFailurePolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    FailurePolicy
+		wantErr bool
+	}{
+		{value: "", want: FailurePolicy{Mode: FailOnAny}},
+		{value: "any", want: FailurePolicy{Mode: FailOnAny}},
+		{value: "NONE", want: FailurePolicy{Mode: FailOnNone}},
+		{value: "threshold=10%", want: FailurePolicy{Mode: FailOnThreshold, Threshold: 10}},
+		{value: "threshold=2.5", want: FailurePolicy{Mode: FailOnThreshold, Threshold: 2.5}},
+		{value: "threshold=", wantErr: true},
+		{value: "threshold=150%", wantErr: true},
+		{value: "threshold=-1%", wantErr: true},
+		{value: "sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			policy, err := ParseFailurePolicy(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *policy)
+		})
+	}
+}
+
+func TestFailurePolicy_Exceeded(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy FailurePolicy
+		total  int
+		failed int
+		want   bool
+	}{
+		{name: "any without failures", policy: FailurePolicy{Mode: FailOnAny}, total: 10, failed: 0, want: false},
+		{name: "any with one failure", policy: FailurePolicy{Mode: FailOnAny}, total: 10, failed: 1, want: true},
+		{name: "none with failures", policy: FailurePolicy{Mode: FailOnNone}, total: 10, failed: 10, want: false},
+		{name: "at threshold", policy: FailurePolicy{Mode: FailOnThreshold, Threshold: 10}, total: 10, failed: 1, want: false},
+		{name: "above threshold", policy: FailurePolicy{Mode: FailOnThreshold, Threshold: 10}, total: 10, failed: 2, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Exceeded(tt.total, tt.failed))
+		})
+	}
+}
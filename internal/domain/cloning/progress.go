@@ -2,6 +2,8 @@ package cloning
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 )
@@ -18,17 +20,29 @@ type RecentCompletion struct {
 
 // Progress represents the current state of cloning operations
 type Progress struct {
-	Total            int               `json:"total"`
-	Completed        int               `json:"completed"`
-	Failed           int               `json:"failed"`
-	Skipped          int               `json:"skipped"`
-	InProgress       int               `json:"in_progress"`
-	ElapsedTime      time.Duration     `json:"elapsed_time"`
-	ETA              time.Duration     `json:"eta"`
-	StartTime        time.Time         `json:"start_time"`
-	Throughput       float64           `json:"throughput"` // Jobs per second
-	RecentCompletion *RecentCompletion `json:"recent_completion,omitempty"`
-	LastUpdate       time.Time         `json:"last_update"`
+	Total            int                `json:"total"`
+	Completed        int                `json:"completed"`
+	Failed           int                `json:"failed"`
+	Skipped          int                `json:"skipped"`
+	Updated          int                `json:"updated"`     // Existing clones updated instead of cloned
+	Cancelled        int                `json:"cancelled"`   // Jobs stopped by cancellation before they finished
+	Queued           int                `json:"queued"`      // Submitted to the worker pool, waiting for a worker
+	InProgress       int                `json:"in_progress"` // Picked up by a worker
+	ElapsedTime      time.Duration      `json:"elapsed_time"`
+	ETA              time.Duration      `json:"eta"`                      // SizeETA when known, CountETA otherwise
+	CountETA         time.Duration      `json:"count_eta"`                // Assuming every remaining job costs the same
+	SizeETA          time.Duration      `json:"size_eta,omitempty"`       // Weighted by the size of the remaining jobs
+	TotalSize        int64              `json:"total_size,omitempty"`     // API-reported size of the jobs, when known
+	ProcessedSize    int64              `json:"processed_size,omitempty"` // API-reported size of the finished jobs
+	StartTime        time.Time          `json:"start_time"`
+	Throughput       float64            `json:"throughput"` // Jobs per second
+	RecentCompletion *RecentCompletion  `json:"recent_completion,omitempty"`
+	LastUpdate       time.Time          `json:"last_update"`
+	BytesReceived    int64              `json:"bytes_received"`       // Bytes transferred by clone backends
+	DiskUsage        int64              `json:"disk_usage,omitempty"` // On-disk size of the repositories cloned
+	FreeSpace        int64              `json:"free_space,omitempty"` // Free space left for the clones at the last measure, 0 when unknown
+	Transfers        []TransferProgress `json:"transfers,omitempty"`
+	History          []ThroughputSample `json:"history,omitempty"` // Throughput per interval, oldest first
 }
 
 // NewProgress creates a new progress tracker
@@ -58,12 +72,27 @@ func (p *Progress) UpdateRecentCompletion(repo string, status JobStatus, duratio
 	p.LastUpdate = time.Now()
 }
 
+// Processed returns the number of finished jobs
+func (p *Progress) Processed() int {
+	return p.Completed + p.Failed + p.Skipped + p.Updated + p.Cancelled
+}
+
+// Pending returns the number of jobs not submitted to the worker pool yet
+func (p *Progress) Pending() int {
+	return max(p.Total-p.Processed()-p.Queued-p.InProgress, 0)
+}
+
+// Waiting returns the number of jobs no worker picked up yet, submitted or not
+func (p *Progress) Waiting() int {
+	return p.Queued + p.Pending()
+}
+
 // GetPercentage returns the completion percentage
 func (p *Progress) GetPercentage() float64 {
 	if p.Total == 0 {
 		return 100.0
 	}
-	processed := float64(p.Completed + p.Failed + p.Skipped)
+	processed := float64(p.Processed())
 	total := float64(p.Total)
 	percentage := (processed / total) * 100.0
 
@@ -77,16 +106,16 @@ func (p *Progress) GetPercentage() float64 {
 
 // GetSuccessRate returns the success rate as a percentage
 func (p *Progress) GetSuccessRate() float64 {
-	processed := p.Completed + p.Failed + p.Skipped
+	processed := p.Processed()
 	if processed == 0 {
 		return 0.0
 	}
-	return float64(p.Completed) / float64(processed) * 100.0
+	return float64(p.Completed+p.Updated) / float64(processed) * 100.0
 }
 
 // IsComplete checks if all jobs are finished
 func (p *Progress) IsComplete() bool {
-	processed := p.Completed + p.Failed + p.Skipped
+	processed := p.Processed()
 	// Ensure we handle edge cases where processed might exceed total
 	return processed >= p.Total && p.InProgress == 0
 }
@@ -97,18 +126,18 @@ func (p *Progress) UpdateElapsedTime() {
 	p.LastUpdate = time.Now()
 }
 
-// CalculateETA estimates the time remaining
+// CalculateETA estimates the time remaining, from the size of the remaining
+// jobs when their sizes are known and from their count otherwise
 func (p *Progress) CalculateETA() {
+	p.ETA, p.CountETA, p.SizeETA = 0, 0, 0
 	if p.Total == 0 || p.IsComplete() {
-		p.ETA = 0
 		return
 	}
 
 	p.UpdateElapsedTime()
-	processed := p.Completed + p.Failed + p.Skipped
+	processed := p.Processed()
 
 	if processed == 0 {
-		p.ETA = 0
 		return
 	}
 
@@ -117,29 +146,59 @@ func (p *Progress) CalculateETA() {
 
 	if p.Throughput > 0 {
 		remaining := p.Total - processed - p.InProgress
-		p.ETA = time.Duration(float64(remaining)/p.Throughput) * time.Second
+		p.CountETA = time.Duration(float64(remaining)/p.Throughput) * time.Second
+	}
+
+	p.SizeETA = p.sizeETA()
+	p.ETA = p.CountETA
+	if p.SizeETA > 0 {
+		p.ETA = p.SizeETA
 	}
 }
 
+// sizeETA divides the size of the remaining jobs by the bytes per second
+// processed so far. Both sides use the API-reported sizes, which shallow
+// clones and pack compression would skew if compared to the bytes received.
+// It is zero until a job of known size finished.
+func (p *Progress) sizeETA() time.Duration {
+	remaining := p.TotalSize - p.ProcessedSize
+	if p.ProcessedSize <= 0 || remaining <= 0 || p.ElapsedTime <= 0 {
+		return 0
+	}
+
+	bytesPerSecond := float64(p.ProcessedSize) / p.ElapsedTime.Seconds()
+	return time.Duration(float64(remaining)/bytesPerSecond) * time.Second
+}
+
 // String returns a formatted string representation
 func (p *Progress) String() string {
 	return ""
 }
 
+// subscriberBuffer is the number of updates buffered per subscriber
+const subscriberBuffer = 16
+
+// transferNotifyInterval throttles notifications caused by transfer updates
+const transferNotifyInterval = 100 * time.Millisecond
+
 // ProgressTracker manages progress tracking for clone operations
 type ProgressTracker struct {
-	progress *Progress
-	mutex    sync.RWMutex
-	updates  chan *Progress
-	done     chan struct{}
+	progress           *Progress
+	transfers          map[string]*TransferProgress
+	mutex              sync.RWMutex
+	subscribers        []chan *Progress
+	closed             bool
+	lastTransferNotify time.Time
+	throughput         *throughputHistory
+	jobSizes           map[string]int64 // API-reported size of the unfinished jobs by repository
 }
 
 // NewProgressTracker creates a new progress tracker
 func NewProgressTracker(total int) *ProgressTracker {
 	return &ProgressTracker{
-		progress: NewProgress(total),
-		updates:  make(chan *Progress, 10),
-		done:     make(chan struct{}),
+		progress:   NewProgress(total),
+		transfers:  make(map[string]*TransferProgress),
+		throughput: newThroughputHistory(),
 	}
 }
 
@@ -148,17 +207,106 @@ func (pt *ProgressTracker) GetProgress() *Progress {
 	pt.mutex.RLock()
 	defer pt.mutex.RUnlock()
 
+	return pt.snapshot()
+}
+
+// SetTotal updates the number of jobs being tracked
+func (pt *ProgressTracker) SetTotal(total int) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	pt.progress.Total = total
+	pt.notifyUpdate()
+}
+
+// SetJobSizes records the API-reported size of the jobs by repository, which
+// weights the ETA by the size of the remaining jobs. Repositories without a
+// size weigh nothing.
+func (pt *ProgressTracker) SetJobSizes(sizes map[string]int64) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	pt.jobSizes = make(map[string]int64, len(sizes))
+	pt.progress.TotalSize = pt.progress.ProcessedSize
+	for repo, size := range sizes {
+		if size > 0 {
+			pt.jobSizes[repo] = size
+			pt.progress.TotalSize += size
+		}
+	}
+	pt.notifyUpdate()
+}
+
+// finishSize accounts for the size of a finished job (mutex must be held).
+// The size of skipped and cancelled jobs leaves the total instead, since
+// they took no time and would inflate the rate of the others.
+func (pt *ProgressTracker) finishSize(repo string, processed bool) {
+	size, ok := pt.jobSizes[repo]
+	if !ok {
+		return
+	}
+	delete(pt.jobSizes, repo)
+
+	if processed {
+		pt.progress.ProcessedSize += size
+	} else {
+		pt.progress.TotalSize -= size
+	}
+}
+
+// snapshot returns a copy of the progress including active transfers (mutex must be held)
+func (pt *ProgressTracker) snapshot() *Progress {
 	// Create a copy to avoid race conditions
 	progressCopy := *pt.progress
+	transfers, activeBytes := pt.activeTransfers()
+	progressCopy.Transfers = transfers
+	progressCopy.BytesReceived += activeBytes
 	progressCopy.CalculateETA()
+
+	pt.throughput.observe(time.Now(), progressCopy.Processed(), progressCopy.BytesReceived)
+	progressCopy.History = pt.throughput.history()
 	return &progressCopy
 }
 
-// StartJob marks a job as started
+// SetFreeSpace records the free space left on the file system the jobs
+// clone into
+func (pt *ProgressTracker) SetFreeSpace(bytes int64) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	pt.progress.FreeSpace = bytes
+	pt.notifyUpdate()
+}
+
+// QueueJob marks a job as submitted to the worker pool, waiting for a worker
+func (pt *ProgressTracker) QueueJob() {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	pt.progress.Queued++
+	pt.notifyUpdate()
+}
+
+// DequeueJob takes back a queued job the worker pool rejected
+func (pt *ProgressTracker) DequeueJob() {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	if pt.progress.Queued > 0 {
+		pt.progress.Queued--
+	}
+	pt.notifyUpdate()
+}
+
+// StartJob marks a job as picked up by a worker, moving it out of the queued
+// jobs when it was queued with QueueJob
 func (pt *ProgressTracker) StartJob() {
 	pt.mutex.Lock()
 	defer pt.mutex.Unlock()
 
+	if pt.progress.Queued > 0 {
+		pt.progress.Queued--
+	}
 	pt.progress.InProgress++
 	pt.notifyUpdate()
 }
@@ -186,7 +334,24 @@ func (pt *ProgressTracker) CompleteJobWithDetails(repo string, duration time.Dur
 		pt.progress.InProgress--
 	}
 	pt.progress.Completed++
+	pt.progress.DiskUsage += size
 	pt.progress.UpdateRecentCompletion(repo, JobStatusCompleted, duration, size, nil)
+	pt.finishSize(repo, true)
+	pt.notifyUpdate()
+}
+
+// UpdateJobWithDetails marks a job as done by updating an existing clone
+func (pt *ProgressTracker) UpdateJobWithDetails(repo string, duration time.Duration, size int64) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	// Ensure we don't go negative
+	if pt.progress.InProgress > 0 {
+		pt.progress.InProgress--
+	}
+	pt.progress.Updated++
+	pt.progress.UpdateRecentCompletion(repo, JobStatusUpdated, duration, size, nil)
+	pt.finishSize(repo, true)
 	pt.notifyUpdate()
 }
 
@@ -214,6 +379,22 @@ func (pt *ProgressTracker) FailJobWithDetails(repo string, duration time.Duratio
 	}
 	pt.progress.Failed++
 	pt.progress.UpdateRecentCompletion(repo, JobStatusFailed, duration, 0, err)
+	pt.finishSize(repo, true)
+	pt.notifyUpdate()
+}
+
+// CancelJobWithDetails marks a job as stopped by cancellation
+func (pt *ProgressTracker) CancelJobWithDetails(repo string, duration time.Duration) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	// Ensure we don't go negative
+	if pt.progress.InProgress > 0 {
+		pt.progress.InProgress--
+	}
+	pt.progress.Cancelled++
+	pt.progress.UpdateRecentCompletion(repo, JobStatusCancelled, duration, 0, ErrJobCancelled)
+	pt.finishSize(repo, false)
 	pt.notifyUpdate()
 }
 
@@ -241,69 +422,132 @@ func (pt *ProgressTracker) SkipJobWithDetails(repo string, duration time.Duratio
 	}
 	pt.progress.Skipped++
 	pt.progress.UpdateRecentCompletion(repo, JobStatusSkipped, duration, 0, fmt.Errorf("skipped: %s", reason))
+	pt.finishSize(repo, false)
 	pt.notifyUpdate()
 }
 
-// Subscribe returns a channel for progress updates
-func (pt *ProgressTracker) Subscribe() <-chan *Progress {
-	return pt.updates
-}
-
-// ForceSynchronize forces progress to be consistent with expected totals
-// This should only be used as a last resort when jobs are complete but progress is inconsistent
-func (pt *ProgressTracker) ForceSynchronize() {
+// SkipUnstartedJobWithDetails marks a job no worker started as skipped, such
+// as a duplicate or a clone found before submission. Unlike
+// SkipJobWithDetails it leaves InProgress alone, and Queued too: the job was
+// never submitted.
+func (pt *ProgressTracker) SkipUnstartedJobWithDetails(repo string, duration time.Duration, reason string) {
 	pt.mutex.Lock()
 	defer pt.mutex.Unlock()
 
-	processed := pt.progress.Completed + pt.progress.Failed + pt.progress.Skipped
-
-	// If we have InProgress jobs but all jobs should be done, convert them to completed
-	if processed < pt.progress.Total && pt.progress.InProgress > 0 {
-		// Move remaining InProgress to Completed to reach total
-		remaining := pt.progress.Total - processed
-		if remaining <= pt.progress.InProgress {
-			pt.progress.Completed += remaining
-			pt.progress.InProgress -= remaining
-		} else {
-			// All InProgress become completed
-			pt.progress.Completed += pt.progress.InProgress
-			pt.progress.InProgress = 0
+	pt.progress.Skipped++
+	pt.progress.UpdateRecentCompletion(repo, JobStatusSkipped, duration, 0, fmt.Errorf("skipped: %s", reason))
+	pt.finishSize(repo, false)
+	pt.notifyUpdate()
+}
+
+// RecordResult counts a finished job by its final status. It is meant for
+// trackers that only observe results, such as per-owner batches.
+func (pt *ProgressTracker) RecordResult(result *JobResult) {
+	job := result.Job
+	switch job.Status {
+	case JobStatusCompleted:
+		pt.CompleteJobWithDetails(job.Repository.GetFullName(), result.Duration, result.BytesSize)
+	case JobStatusUpdated:
+		pt.UpdateJobWithDetails(job.Repository.GetFullName(), result.Duration, result.BytesSize)
+	case JobStatusSkipped:
+		reason := "already exists"
+		if job.Error != nil {
+			reason = job.Error.Error()
 		}
+		pt.SkipJobWithDetails(job.Repository.GetFullName(), result.Duration, reason)
+	case JobStatusCancelled:
+		pt.CancelJobWithDetails(job.Repository.GetFullName(), result.Duration)
+	default:
+		pt.FailJobWithDetails(job.Repository.GetFullName(), result.Duration, job.Error)
 	}
+}
 
-	// Ensure InProgress is never negative
-	if pt.progress.InProgress < 0 {
-		pt.progress.InProgress = 0
+// Subscribe returns a channel receiving a progress snapshot after every change.
+// Slow subscribers only miss intermediate snapshots, never the latest one. The
+// channel is closed by Close once tracking is finished.
+func (pt *ProgressTracker) Subscribe() <-chan *Progress {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	updates := make(chan *Progress, subscriberBuffer)
+	if pt.closed {
+		close(updates)
+		return updates
 	}
 
-	pt.notifyUpdate()
+	pt.subscribers = append(pt.subscribers, updates)
+	return updates
+}
+
+// Unsubscribe stops the updates of a channel returned by Subscribe, e.g. when
+// its reader goes away before tracking is finished. The channel is not closed.
+func (pt *ProgressTracker) Unsubscribe(updates <-chan *Progress) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	pt.subscribers = slices.DeleteFunc(pt.subscribers, func(subscriber chan *Progress) bool {
+		return subscriber == updates
+	})
+}
+
+// Subscribers returns how many channels receive the progress updates
+func (pt *ProgressTracker) Subscribers() int {
+	pt.mutex.RLock()
+	defer pt.mutex.RUnlock()
+	return len(pt.subscribers)
 }
 
-// Close stops the progress tracker
+// Close stops the progress tracker, closing all subscriber channels after
+// delivering the final progress. It is safe to call more than once.
 func (pt *ProgressTracker) Close() {
-	close(pt.done)
-	close(pt.updates)
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	if pt.closed {
+		return
+	}
+
+	pt.notifyUpdate()
+	pt.closed = true
+	for _, updates := range pt.subscribers {
+		close(updates)
+	}
+	pt.subscribers = nil
 }
 
-// notifyUpdate sends a progress update (must be called with mutex held)
+// notifyUpdate sends a progress update to every subscriber (must be called with mutex held)
 func (pt *ProgressTracker) notifyUpdate() {
-	progressCopy := *pt.progress
-	progressCopy.CalculateETA()
+	if pt.closed || len(pt.subscribers) == 0 {
+		return
+	}
+
+	progressCopy := pt.snapshot()
 
 	// Validate progress consistency
-	pt.validateProgressConsistency(&progressCopy)
+	pt.validateProgressConsistency(progressCopy)
 
-	select {
-	case pt.updates <- &progressCopy:
-	case <-pt.done:
-	default:
-		// Channel is full, skip this update
+	for _, updates := range pt.subscribers {
+		select {
+		case updates <- progressCopy:
+			continue
+		default:
+		}
+
+		// Channel is full, drop the oldest update so the latest state is never lost
+		select {
+		case <-updates:
+		default:
+		}
+		select {
+		case updates <- progressCopy:
+		default:
+		}
 	}
 }
 
 // validateProgressConsistency ensures progress counts are logically consistent
 func (pt *ProgressTracker) validateProgressConsistency(progress *Progress) {
-	processed := progress.Completed + progress.Failed + progress.Skipped
+	processed := progress.Processed()
 	totalActive := processed + progress.InProgress
 
 	// If we've processed more than total, something is wrong
@@ -347,6 +591,15 @@ func (bp *BatchProgress) AddBatch(batchID string, total int) *ProgressTracker {
 	return tracker
 }
 
+// Track adds an existing tracker as a batch, replacing any batch of the
+// same ID
+func (bp *BatchProgress) Track(batchID string, tracker *ProgressTracker) {
+	bp.mutex.Lock()
+	defer bp.mutex.Unlock()
+
+	bp.batches[batchID] = tracker
+}
+
 // GetBatch returns a batch progress tracker
 func (bp *BatchProgress) GetBatch(batchID string) *ProgressTracker {
 	bp.mutex.RLock()
@@ -355,6 +608,25 @@ func (bp *BatchProgress) GetBatch(batchID string) *ProgressTracker {
 	return bp.batches[batchID]
 }
 
+// BatchSnapshot is the progress of one batch at a point in time
+type BatchSnapshot struct {
+	ID       string
+	Progress *Progress
+}
+
+// Snapshot returns the progress of every batch, ordered by batch ID
+func (bp *BatchProgress) Snapshot() []BatchSnapshot {
+	bp.mutex.RLock()
+	defer bp.mutex.RUnlock()
+
+	snapshots := make([]BatchSnapshot, 0, len(bp.batches))
+	for id, tracker := range bp.batches {
+		snapshots = append(snapshots, BatchSnapshot{ID: id, Progress: tracker.GetProgress()})
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID < snapshots[j].ID })
+	return snapshots
+}
+
 // GetOverallProgress returns combined progress across all batches
 func (bp *BatchProgress) GetOverallProgress() *Progress {
 	bp.mutex.RLock()
@@ -374,7 +646,16 @@ func (bp *BatchProgress) GetOverallProgress() *Progress {
 		overall.Completed += progress.Completed
 		overall.Failed += progress.Failed
 		overall.Skipped += progress.Skipped
+		overall.Updated += progress.Updated
+		overall.Cancelled += progress.Cancelled
+		overall.Queued += progress.Queued
 		overall.InProgress += progress.InProgress
+		overall.TotalSize += progress.TotalSize
+		overall.ProcessedSize += progress.ProcessedSize
+		overall.DiskUsage += progress.DiskUsage
+		if progress.FreeSpace > 0 && (overall.FreeSpace == 0 || progress.FreeSpace < overall.FreeSpace) {
+			overall.FreeSpace = progress.FreeSpace
+		}
 
 		// Use earliest start time
 		if overall.StartTime.After(progress.StartTime) {
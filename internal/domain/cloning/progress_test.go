@@ -190,6 +190,75 @@ func TestProgress_CalculateETA(t *testing.T) {
 	assert.True(t, progress.Throughput > 0)
 }
 
+func TestProgress_CalculateETA_SizeWeighted(t *testing.T) {
+	tests := []struct {
+		name          string
+		totalSize     int64
+		processedSize int64
+		expectedETA   time.Duration
+		expectedSize  time.Duration
+	}{
+		// 4 of 10 jobs in 1m leave 6 jobs, 90s by count
+		{name: "no sizes", expectedETA: 90 * time.Second},
+		{name: "no finished size", totalSize: 1000, expectedETA: 90 * time.Second},
+		// 100 bytes per minute leave 900 bytes, 9m by size
+		{name: "large jobs remaining", totalSize: 1000, processedSize: 100,
+			expectedETA: 9 * time.Minute, expectedSize: 9 * time.Minute},
+		{name: "small jobs remaining", totalSize: 1000, processedSize: 900,
+			expectedETA: 6 * time.Second, expectedSize: 6 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress := NewProgress(10)
+			progress.StartTime = time.Now().Add(-1 * time.Minute)
+			progress.Completed = 4
+			progress.TotalSize = tt.totalSize
+			progress.ProcessedSize = tt.processedSize
+
+			progress.CalculateETA()
+
+			assert.InDelta(t, 90*time.Second, progress.CountETA, float64(time.Second))
+			assert.InDelta(t, tt.expectedSize, progress.SizeETA, float64(time.Second))
+			assert.InDelta(t, tt.expectedETA, progress.ETA, float64(time.Second))
+		})
+	}
+}
+
+func TestProgressTracker_JobSizes(t *testing.T) {
+	tracker := NewProgressTracker(4)
+	tracker.SetJobSizes(map[string]int64{"o/a": 100, "o/b": 200, "o/c": 300, "o/d": 0})
+
+	for range 4 {
+		tracker.StartJob()
+	}
+	tracker.CompleteJobWithDetails("o/a", time.Second, 50)
+	tracker.FailJobWithDetails("o/b", time.Second, assert.AnError)
+	tracker.SkipJobWithDetails("o/c", 0, "already exists")
+	tracker.CompleteJobWithDetails("o/a", time.Second, 50)
+
+	progress := tracker.GetProgress()
+	assert.Equal(t, int64(300), progress.TotalSize)
+	assert.Equal(t, int64(300), progress.ProcessedSize)
+}
+
+func TestProgressTracker_SkipUnstartedJob(t *testing.T) {
+	tracker := NewProgressTracker(3)
+	tracker.QueueJob()
+	tracker.StartJob()
+	tracker.QueueJob()
+
+	// Skips before submission leave the running and queued jobs alone
+	tracker.SkipUnstartedJobWithDetails("o/b", 0, "too large")
+	tracker.SkipUnstartedJobWithDetails("o/c", 0, "duplicate")
+
+	progress := tracker.GetProgress()
+	assert.Equal(t, 1, progress.InProgress)
+	assert.Equal(t, 1, progress.Queued)
+	assert.Equal(t, 2, progress.Skipped)
+	assert.Equal(t, JobStatusSkipped, progress.RecentCompletion.Status)
+}
+
 func TestNewProgressTracker(t *testing.T) {
 	total := 5
 	tracker := NewProgressTracker(total)
@@ -209,6 +278,32 @@ func TestProgressTracker_StartJob(t *testing.T) {
 	assert.Equal(t, 1, progress.InProgress)
 }
 
+func TestProgressTracker_QueueJob(t *testing.T) {
+	tracker := NewProgressTracker(5)
+
+	tracker.QueueJob()
+	tracker.QueueJob()
+	tracker.QueueJob()
+	progress := tracker.GetProgress()
+	assert.Equal(t, 3, progress.Queued)
+	assert.Equal(t, 0, progress.InProgress)
+	assert.Equal(t, 2, progress.Pending())
+	assert.Equal(t, 5, progress.Waiting())
+
+	tracker.StartJob()
+	tracker.DequeueJob()
+	progress = tracker.GetProgress()
+	assert.Equal(t, 1, progress.Queued)
+	assert.Equal(t, 1, progress.InProgress)
+	assert.Equal(t, 4, progress.Waiting())
+
+	tracker.CompleteJob()
+	progress = tracker.GetProgress()
+	assert.Equal(t, 1, progress.Queued)
+	assert.Equal(t, 0, progress.InProgress)
+	assert.Equal(t, 3, progress.Pending())
+}
+
 func TestProgressTracker_CompleteJob(t *testing.T) {
 	tracker := NewProgressTracker(5)
 	tracker.StartJob()
@@ -242,6 +337,22 @@ func TestProgressTracker_SkipJob(t *testing.T) {
 	assert.Equal(t, 0, progress.InProgress)
 }
 
+func TestProgressTracker_CancelJob(t *testing.T) {
+	tracker := NewProgressTracker(2)
+	tracker.StartJob()
+	tracker.StartJob()
+
+	tracker.CompleteJob()
+	tracker.CancelJobWithDetails("owner/repo", time.Second)
+
+	progress := tracker.GetProgress()
+	assert.Equal(t, 1, progress.Cancelled)
+	assert.Equal(t, 0, progress.Failed)
+	assert.Equal(t, 2, progress.Processed())
+	assert.True(t, progress.IsComplete())
+	assert.Equal(t, JobStatusCancelled, progress.RecentCompletion.Status)
+}
+
 func TestProgressTracker_Subscribe(t *testing.T) {
 	tracker := NewProgressTracker(5)
 
@@ -259,12 +370,36 @@ func TestProgressTracker_Subscribe(t *testing.T) {
 	}
 }
 
+func TestProgressTracker_Unsubscribe(t *testing.T) {
+	tracker := NewProgressTracker(5)
+	updates := tracker.Subscribe()
+	other := tracker.Subscribe()
+	assert.Equal(t, 2, tracker.Subscribers())
+
+	tracker.Unsubscribe(updates)
+	assert.Equal(t, 1, tracker.Subscribers())
+
+	tracker.StartJob()
+	assert.Len(t, updates, 0, "unsubscribed channels get no more updates")
+	assert.Len(t, other, 1)
+
+	// Unsubscribing after close is safe
+	tracker.Close()
+	tracker.Unsubscribe(other)
+	assert.Zero(t, tracker.Subscribers())
+}
+
 func TestProgressTracker_Close(t *testing.T) {
 	tracker := NewProgressTracker(5)
 	updates := tracker.Subscribe()
 
 	tracker.Close()
 
+	// The final progress is delivered before the channel is closed
+	final, ok := <-updates
+	require.True(t, ok, "Final progress should be delivered")
+	assert.Equal(t, 5, final.Total)
+
 	// Channel should be closed
 	select {
 	case _, ok := <-updates:
@@ -272,6 +407,53 @@ func TestProgressTracker_Close(t *testing.T) {
 	case <-time.After(1 * time.Second):
 		t.Fatal("Channel should have been closed")
 	}
+
+	// Closing twice and subscribing after close are safe
+	tracker.Close()
+	_, ok = <-tracker.Subscribe()
+	assert.False(t, ok)
+}
+
+func TestProgressTracker_SubscribeKeepsLatest(t *testing.T) {
+	tracker := NewProgressTracker(100)
+	updates := tracker.Subscribe()
+
+	// Nobody reads while the jobs run, overflowing the subscriber buffer
+	for i := 0; i < 100; i++ {
+		tracker.StartJob()
+		tracker.CompleteJob()
+	}
+	tracker.Close()
+
+	var last *Progress
+	for progress := range updates {
+		last = progress
+	}
+
+	require.NotNil(t, last)
+	assert.True(t, last.IsComplete())
+	assert.Equal(t, 100, last.Completed)
+}
+
+func TestProgressTracker_Transfers(t *testing.T) {
+	tracker := NewProgressTracker(2)
+	defer tracker.Close()
+
+	tracker.UpdateTransfer(TransferProgress{JobID: "b", Repository: "owner/b", Percent: 20, BytesReceived: 200})
+	tracker.UpdateTransfer(TransferProgress{JobID: "a", Repository: "owner/a", Percent: 50, BytesReceived: 100})
+	// A later update without byte information keeps the last known count
+	tracker.UpdateTransfer(TransferProgress{JobID: "a", Repository: "owner/a", Phase: "Resolving deltas", Percent: 10})
+
+	progress := tracker.GetProgress()
+	require.Len(t, progress.Transfers, 2)
+	assert.Equal(t, "owner/a", progress.Transfers[0].Repository)
+	assert.Equal(t, int64(100), progress.Transfers[0].BytesReceived)
+	assert.Equal(t, int64(300), progress.BytesReceived)
+
+	tracker.FinishTransfer("a")
+	progress = tracker.GetProgress()
+	require.Len(t, progress.Transfers, 1)
+	assert.Equal(t, int64(300), progress.BytesReceived, "finished transfers still count towards the total")
 }
 
 func TestNewBatchProgress(t *testing.T) {
@@ -391,3 +573,20 @@ func TestProgressTracker_ConcurrentAccess(t *testing.T) {
 	assert.Equal(t, 0, progress.InProgress)
 	assert.True(t, progress.IsComplete())
 }
+
+func TestProgressTracker_UpdateJobWithDetails(t *testing.T) {
+	tracker := NewProgressTracker(2)
+
+	tracker.StartJob()
+	tracker.StartJob()
+	tracker.UpdateJobWithDetails("owner/updated", time.Second, 1024)
+	tracker.CompleteJobWithDetails("owner/cloned", time.Second, 2048)
+
+	progress := tracker.GetProgress()
+	assert.Equal(t, 1, progress.Updated)
+	assert.Equal(t, 1, progress.Completed)
+	assert.Equal(t, 2, progress.Processed())
+	assert.True(t, progress.IsComplete())
+	assert.Equal(t, 100.0, progress.GetSuccessRate())
+	assert.Equal(t, JobStatusCompleted, progress.RecentCompletion.Status)
+}
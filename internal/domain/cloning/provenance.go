@@ -0,0 +1,54 @@
+package cloning
+
+import (
+	"net/url"
+	"time"
+)
+
+// ProvenanceFileName is the file recording the provenance of a clone, at the
+// root of the clone
+const ProvenanceFileName = ".ghclone.json"
+
+// Provenance records where a clone came from, so a clone can be matched to
+// its provider repository even after its directory was moved or renamed
+type Provenance struct {
+	Provider      string    `json:"provider"`      // e.g. github or bitbucket, or the host of other instances
+	RepositoryID  int64     `json:"repository_id"` // Provider API ID, 0 when unknown
+	FullName      string    `json:"full_name"`
+	SourceURL     string    `json:"source_url"`     // Clone URL without credentials
+	Ref           string    `json:"ref,omitempty"`  // Requested branch or ref, empty for the default branch
+	DefaultBranch string    `json:"default_branch"` // Default branch when cloned
+	ClonedAt      time.Time `json:"cloned_at"`
+	UpdatedAt     time.Time `json:"updated_at,omitzero"` // Last update of the clone, zero until updated
+}
+
+// NewProvenance describes the clone of a job made at the given time
+func NewProvenance(job *CloneJob, clonedAt time.Time) *Provenance {
+	repo := job.Repository
+
+	ref := job.Options.Ref
+	if ref == "" {
+		ref = job.Options.Branch
+	}
+
+	return &Provenance{
+		Provider:      repo.Provider(),
+		RepositoryID:  int64(repo.ID),
+		FullName:      repo.GetFullName(),
+		SourceURL:     stripCredentials(repo.CloneURL),
+		Ref:           ref,
+		DefaultBranch: repo.DefaultBranch,
+		ClonedAt:      clonedAt.UTC(),
+	}
+}
+
+// stripCredentials removes the user information of a URL; scp-like
+// addresses such as git@host:path are returned unchanged
+func stripCredentials(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.User == nil || parsed.Scheme == "ssh" {
+		return rawURL
+	}
+	parsed.User = nil
+	return parsed.String()
+}
@@ -18,6 +18,8 @@ var (
 	ErrJobNotFound            = errors.New("job not found")
 	ErrCloneTimeout           = errors.New("clone operation timed out")
 	ErrInvalidCloneOptions    = errors.New("invalid clone options")
+	ErrJobCancelled           = errors.New("job cancelled")
+	ErrLowDiskSpace           = errors.New("free disk space below the reserve")
 )
 
 // DomainCloneService implements core cloning business logic
@@ -0,0 +1,34 @@
+package cloning
+
+import (
+	"fmt"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// ParseSize parses a human readable size such as "2GB", "512KiB" or "1.5M"
+// into bytes. Units are binary; plain numbers are bytes.
+func ParseSize(value string) (int64, error) {
+	return repository.ParseSize(value)
+}
+
+// FormatSize formats a byte count in binary units, e.g. "1.5 GB"
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// ExceedsMaxSize reports whether the provider-reported size of the job's
+// repository is above Options.MaxSize. Repositories of unknown size are
+// never considered too large.
+func (cj *CloneJob) ExceedsMaxSize() bool {
+	return cj.Options.MaxSize > 0 && cj.Repository.Size > cj.Options.MaxSize
+}
@@ -0,0 +1,48 @@
+package cloning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{"2GB", 2 << 30, false},
+		{"512KiB", 512 << 10, false},
+		{"1.5g", 1536 << 20, false},
+		{"1T", 1 << 40, false},
+		{"4096", 4096, false},
+		{"", 0, true},
+		{"big", 0, true},
+		{"0", 0, true},
+		{"-1GB", 0, true},
+		{"NaN", 0, true},
+		{"+Inf", 0, true},
+		{"infinity", 0, true},
+		{"1e30T", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSize(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", FormatSize(512))
+	assert.Equal(t, "1.5 KB", FormatSize(1536))
+	assert.Equal(t, "2.0 GB", FormatSize(2<<30))
+}
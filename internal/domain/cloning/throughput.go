@@ -0,0 +1,94 @@
+package cloning
+
+import (
+	"sync"
+	"time"
+)
+
+// ThroughputSample is the throughput of a run over one sampling interval
+type ThroughputSample struct {
+	Time           time.Time `json:"time"`             // End of the interval
+	JobsPerSecond  float64   `json:"jobs_per_second"`  // Jobs finished, whatever their status
+	BytesPerSecond float64   `json:"bytes_per_second"` // Bytes received by clone backends
+}
+
+const (
+	// ThroughputInterval is the length of the interval of a throughput sample
+	ThroughputInterval = time.Second
+
+	// throughputSamples is the number of samples kept, the last two minutes
+	throughputSamples = 120
+)
+
+// throughputHistory records throughput samples in a ring buffer. Progress is
+// observed whenever a snapshot is taken; the jobs and bytes counted since the
+// previous observation are spread evenly over the intervals that ended since,
+// as when they finished within these intervals is unknown.
+type throughputHistory struct {
+	mutex    sync.Mutex
+	samples  []ThroughputSample
+	next     int // Ring position of the next sample
+	start    time.Time
+	jobs     int   // Jobs processed when the current interval started
+	bytes    int64 // Bytes received when the current interval started
+	observed bool
+}
+
+// newThroughputHistory creates an empty throughput history
+func newThroughputHistory() *throughputHistory {
+	return &throughputHistory{samples: make([]ThroughputSample, 0, throughputSamples)}
+}
+
+// observe records the progress counters at now, closing the intervals that
+// ended since the previous observation
+func (h *throughputHistory) observe(now time.Time, jobs int, bytes int64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.observed {
+		h.observed = true
+		h.start, h.jobs, h.bytes = now, jobs, bytes
+		return
+	}
+
+	elapsed := int(now.Sub(h.start) / ThroughputInterval)
+	if elapsed == 0 {
+		return
+	}
+
+	seconds := float64(elapsed) * ThroughputInterval.Seconds()
+	sample := ThroughputSample{
+		JobsPerSecond:  float64(max(jobs-h.jobs, 0)) / seconds,
+		BytesPerSecond: float64(max(bytes-h.bytes, 0)) / seconds,
+	}
+	// Skip intervals that would not fit in the buffer anyway
+	skipped := max(elapsed-throughputSamples, 0)
+	for i := skipped; i < elapsed; i++ {
+		sample.Time = h.start.Add(time.Duration(i+1) * ThroughputInterval)
+		h.add(sample)
+	}
+
+	h.start = h.start.Add(time.Duration(elapsed) * ThroughputInterval)
+	h.jobs, h.bytes = jobs, bytes
+}
+
+// add appends a sample, overwriting the oldest one once the buffer is full
+// (mutex must be held)
+func (h *throughputHistory) add(sample ThroughputSample) {
+	if len(h.samples) < throughputSamples {
+		h.samples = append(h.samples, sample)
+		return
+	}
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % throughputSamples
+}
+
+// history returns the samples from oldest to newest
+func (h *throughputHistory) history() []ThroughputSample {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	history := make([]ThroughputSample, 0, len(h.samples))
+	history = append(history, h.samples[h.next:]...)
+	return append(history, h.samples[:h.next]...)
+}
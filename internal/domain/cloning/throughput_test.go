@@ -0,0 +1,80 @@
+package cloning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThroughputHistory(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newThroughputHistory()
+
+	h.observe(start, 0, 0)
+	h.observe(start.Add(500*time.Millisecond), 1, 1024)
+	assert.Empty(t, h.history(), "the first interval is still open")
+
+	h.observe(start.Add(1200*time.Millisecond), 3, 4096)
+	h.observe(start.Add(3500*time.Millisecond), 4, 5120)
+
+	history := h.history()
+	require.Len(t, history, 3)
+	assert.Equal(t, ThroughputSample{Time: start.Add(time.Second), JobsPerSecond: 3, BytesPerSecond: 4096}, history[0])
+	assert.Equal(t, ThroughputSample{Time: start.Add(2 * time.Second), JobsPerSecond: 0.5, BytesPerSecond: 512}, history[1])
+	assert.Equal(t, ThroughputSample{Time: start.Add(3 * time.Second), JobsPerSecond: 0.5, BytesPerSecond: 512}, history[2],
+		"progress observed after several intervals is spread over them")
+}
+
+func TestThroughputHistory_QuietStretch(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newThroughputHistory()
+
+	// Ten jobs finish over ten seconds without a snapshot in between
+	h.observe(start, 0, 0)
+	h.observe(start.Add(10*time.Second), 10, 10240)
+
+	history := h.history()
+	require.Len(t, history, 10)
+	for _, sample := range history {
+		assert.Equal(t, 1.0, sample.JobsPerSecond, "no spike at %s", sample.Time)
+		assert.Equal(t, 1024.0, sample.BytesPerSecond)
+	}
+}
+
+func TestThroughputHistory_Ring(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newThroughputHistory()
+
+	h.observe(start, 0, 0)
+	for i := 1; i <= throughputSamples+10; i++ {
+		h.observe(start.Add(time.Duration(i)*time.Second), i, 0)
+	}
+
+	history := h.history()
+	require.Len(t, history, throughputSamples)
+	assert.Equal(t, start.Add(11*time.Second), history[0].Time, "oldest samples are overwritten")
+	assert.Equal(t, start.Add(time.Duration(throughputSamples+10)*time.Second), history[len(history)-1].Time)
+
+	// A long stall keeps only the samples that fit
+	h.observe(start.Add(time.Hour), throughputSamples+20, 0)
+	history = h.history()
+	require.Len(t, history, throughputSamples)
+	assert.Equal(t, start.Add(time.Hour), history[len(history)-1].Time)
+	assert.InDelta(t, 10.0/float64(3600-throughputSamples-10), history[len(history)-1].JobsPerSecond, 1e-9)
+}
+
+func TestProgressTracker_History(t *testing.T) {
+	tracker := NewProgressTracker(2)
+	tracker.GetProgress()
+	tracker.StartJob()
+	tracker.CompleteJob()
+
+	// Backdate the open interval instead of sleeping
+	tracker.throughput.start = tracker.throughput.start.Add(-ThroughputInterval)
+
+	history := tracker.GetProgress().History
+	require.Len(t, history, 1)
+	assert.Equal(t, 1.0, history[0].JobsPerSecond)
+}
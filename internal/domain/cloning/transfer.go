@@ -0,0 +1,80 @@
+package cloning
+
+import (
+	"sort"
+	"time"
+)
+
+// TransferProgress describes the object/byte transfer state of a single clone
+type TransferProgress struct {
+	JobID          string    `json:"job_id"`
+	Repository     string    `json:"repository"`
+	Phase          string    `json:"phase"` // e.g. "Receiving objects", "Resolving deltas"
+	Percent        float64   `json:"percent"`
+	ObjectsDone    int       `json:"objects_done"`
+	ObjectsTotal   int       `json:"objects_total"`
+	BytesReceived  int64     `json:"bytes_received"`
+	BytesPerSecond float64   `json:"bytes_per_second"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TransferProgressFunc receives transfer progress updates from a clone backend
+type TransferProgressFunc func(TransferProgress)
+
+// UpdateTransfer records the latest transfer progress of a running job.
+// Transfer updates are frequent, so subscriber notifications are throttled.
+func (pt *ProgressTracker) UpdateTransfer(update TransferProgress) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	if pt.transfers == nil {
+		pt.transfers = make(map[string]*TransferProgress)
+	}
+
+	if update.UpdatedAt.IsZero() {
+		update.UpdatedAt = time.Now()
+	}
+
+	// Byte counters are not reported during every phase; keep the last known value
+	if previous, ok := pt.transfers[update.JobID]; ok && update.BytesReceived < previous.BytesReceived {
+		update.BytesReceived = previous.BytesReceived
+	}
+
+	pt.transfers[update.JobID] = &update
+
+	if update.UpdatedAt.Sub(pt.lastTransferNotify) >= transferNotifyInterval {
+		pt.lastTransferNotify = update.UpdatedAt
+		pt.notifyUpdate()
+	}
+}
+
+// FinishTransfer removes a job's transfer state and accumulates its received bytes
+func (pt *ProgressTracker) FinishTransfer(jobID string) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	if transfer, ok := pt.transfers[jobID]; ok {
+		pt.progress.BytesReceived += transfer.BytesReceived
+		delete(pt.transfers, jobID)
+	}
+}
+
+// activeTransfers returns a sorted snapshot of running transfers (mutex must be held)
+func (pt *ProgressTracker) activeTransfers() ([]TransferProgress, int64) {
+	if len(pt.transfers) == 0 {
+		return nil, 0
+	}
+
+	transfers := make([]TransferProgress, 0, len(pt.transfers))
+	var activeBytes int64
+	for _, transfer := range pt.transfers {
+		transfers = append(transfers, *transfer)
+		activeBytes += transfer.BytesReceived
+	}
+
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].Repository < transfers[j].Repository
+	})
+
+	return transfers, activeBytes
+}
@@ -0,0 +1,220 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// CurrentVersion is the manifest schema version written by this release
+const CurrentVersion = 1
+
+// Format represents a manifest serialization format
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// FormatFromPath infers the manifest format from a file extension, defaulting to YAML
+func FormatFromPath(filename string) Format {
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// ParseFormat validates a user supplied format name
+func ParseFormat(value string) (Format, error) {
+	switch strings.ToLower(value) {
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported manifest format %q (supported: yaml, json)", value)
+	}
+}
+
+// Entry describes a single repository of a workspace
+type Entry struct {
+	Name     string `json:"name" yaml:"name"`
+	Owner    string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Path     string `json:"path" yaml:"path"` // Relative to the workspace base directory
+	URL      string `json:"url" yaml:"url"`
+	Branch   string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Revision string `json:"revision,omitempty" yaml:"revision,omitempty"` // Pinned commit SHA
+}
+
+// Manifest describes a reproducible multi-repository workspace
+type Manifest struct {
+	Version      int       `json:"version" yaml:"version"`
+	GeneratedAt  time.Time `json:"generated_at" yaml:"generated_at"`
+	Repositories []Entry   `json:"repositories" yaml:"repositories"`
+}
+
+// NewManifest creates a manifest for the given entries
+func NewManifest(entries []Entry) *Manifest {
+	return &Manifest{
+		Version:      CurrentVersion,
+		GeneratedAt:  time.Now().UTC(),
+		Repositories: entries,
+	}
+}
+
+// Validate checks manifest consistency
+func (m *Manifest) Validate() error {
+	if m.Version <= 0 || m.Version > CurrentVersion {
+		return fmt.Errorf("unsupported manifest version %d", m.Version)
+	}
+
+	seen := make(map[string]bool, len(m.Repositories))
+	for i, entry := range m.Repositories {
+		if entry.URL == "" {
+			return fmt.Errorf("repository %d: url cannot be empty", i)
+		}
+		if entry.Path == "" {
+			return fmt.Errorf("repository %d: path cannot be empty", i)
+		}
+
+		clean := path.Clean(filepath.ToSlash(entry.Path))
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("repository %d: path %q must be relative to the workspace", i, entry.Path)
+		}
+		if seen[clean] {
+			return fmt.Errorf("repository %d: duplicate path %q", i, entry.Path)
+		}
+		seen[clean] = true
+	}
+
+	return nil
+}
+
+// Marshal serializes the manifest in the requested format
+func (m *Manifest) Marshal(format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatYAML:
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(m); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported manifest format %q", format)
+	}
+}
+
+// Unmarshal parses and validates a manifest
+func Unmarshal(data []byte, format Format) (*Manifest, error) {
+	var m Manifest
+
+	var err error
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal(data, &m)
+	case FormatYAML:
+		err = yaml.Unmarshal(data, &m)
+	default:
+		err = fmt.Errorf("unsupported manifest format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	return &m, nil
+}
+
+// ToRepository converts a manifest entry into a domain repository
+func (e *Entry) ToRepository() (*repository.Repository, error) {
+	cloneURL := NormalizeCloneURL(e.URL)
+
+	name := e.Name
+	if name == "" {
+		name = path.Base(filepath.ToSlash(e.Path))
+	}
+
+	owner := e.Owner
+	if owner == "" {
+		owner = ownerFromURL(cloneURL)
+	}
+
+	return repository.NewRepository(
+		repository.RepositoryID(hashID(e.Path)),
+		name,
+		cloneURL,
+		owner,
+		false,
+		0,
+		e.Branch,
+	)
+}
+
+// NormalizeCloneURL strips credentials from HTTPS URLs and converts scp-like
+// SSH addresses (git@host:owner/repo.git) into ssh:// URLs
+func NormalizeCloneURL(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		if at := strings.Index(rawURL, "@"); at >= 0 {
+			if colon := strings.Index(rawURL[at:], ":"); colon > 0 {
+				host := rawURL[:at+colon]
+				return "ssh://" + host + "/" + strings.TrimPrefix(rawURL[at+colon+1:], "/")
+			}
+		}
+		return rawURL
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	if strings.HasPrefix(parsed.Scheme, "http") {
+		parsed.User = nil
+	}
+	return parsed.String()
+}
+
+// ownerFromURL extracts the first path segment of a clone URL
+func ownerFromURL(cloneURL string) string {
+	parsed, err := url.Parse(cloneURL)
+	if err != nil {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 {
+		return parsed.Hostname()
+	}
+	return segments[0]
+}
+
+// hashID derives a stable repository ID from a manifest path
+func hashID(value string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(value))
+	return int64(h.Sum64() >> 1)
+}
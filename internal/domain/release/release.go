@@ -0,0 +1,105 @@
+// Package release models repository releases and the selection of release
+// assets to download.
+package release
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Asset is a file attached to a release
+type Asset struct {
+	ID          int64
+	Name        string
+	Size        int64 // Bytes
+	ContentType string
+	URL         string // API URL, downloaded with Accept: application/octet-stream
+}
+
+// Release is a tagged release of a repository
+type Release struct {
+	ID          int64
+	TagName     string
+	Name        string
+	Draft       bool
+	Prerelease  bool
+	PublishedAt time.Time
+	Assets      []Asset
+}
+
+// Filter selects the releases and assets to download
+type Filter struct {
+	TagPattern         string // Glob matched against the tag, e.g. v1.*; empty matches all
+	AssetPattern       string // Glob matched against asset names; empty matches all
+	LatestOnly         bool   // Keep only the most recently published matching release
+	IncludePrereleases bool
+}
+
+// Validate checks the glob patterns of the filter
+func (f *Filter) Validate() error {
+	for _, pattern := range []string{f.TagPattern, f.AssetPattern} {
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Select returns the matching releases, most recently published first. Drafts
+// are never selected and assets not matching AssetPattern are dropped.
+func (f *Filter) Select(releases []*Release) []*Release {
+	var selected []*Release
+	for _, rel := range releases {
+		if rel.Draft || (rel.Prerelease && !f.IncludePrereleases) {
+			continue
+		}
+		if f.TagPattern != "" {
+			if ok, _ := path.Match(f.TagPattern, rel.TagName); !ok {
+				continue
+			}
+		}
+
+		matched := *rel
+		matched.Assets = nil
+		for _, asset := range rel.Assets {
+			if f.AssetPattern != "" {
+				if ok, _ := path.Match(f.AssetPattern, asset.Name); !ok {
+					continue
+				}
+			}
+			matched.Assets = append(matched.Assets, asset)
+		}
+		selected = append(selected, &matched)
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].PublishedAt.After(selected[j].PublishedAt)
+	})
+
+	if f.LatestOnly && len(selected) > 1 {
+		selected = selected[:1]
+	}
+	return selected
+}
+
+// AssetPath returns where an asset is stored:
+// <baseDir>/<owner>/<repo>/releases/<tag>/<asset>
+func AssetPath(baseDir, owner, repo, tag, asset string) string {
+	return filepath.Join(baseDir, owner, repo, "releases", sanitize(tag), sanitize(asset))
+}
+
+// sanitize keeps tags and asset names from escaping their directory
+func sanitize(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+	if name == "." || name == ".." || name == "" {
+		return "_"
+	}
+	return name
+}
@@ -0,0 +1,64 @@
+package release
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Select(t *testing.T) {
+	now := time.Now()
+	releases := []*Release{
+		{TagName: "v1.0.0", PublishedAt: now.Add(-48 * time.Hour), Assets: []Asset{{Name: "app.tar.gz"}, {Name: "app.sha256"}}},
+		{TagName: "v1.1.0", PublishedAt: now.Add(-24 * time.Hour), Assets: []Asset{{Name: "app.tar.gz"}}},
+		{TagName: "v2.0.0-rc1", Prerelease: true, PublishedAt: now},
+		{TagName: "v2.0.0", Draft: true},
+		{TagName: "nightly", PublishedAt: now.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		wantTags []string
+	}{
+		{name: "all published", filter: Filter{}, wantTags: []string{"nightly", "v1.1.0", "v1.0.0"}},
+		{name: "prereleases", filter: Filter{IncludePrereleases: true}, wantTags: []string{"v2.0.0-rc1", "nightly", "v1.1.0", "v1.0.0"}},
+		{name: "tag pattern", filter: Filter{TagPattern: "v1.*"}, wantTags: []string{"v1.1.0", "v1.0.0"}},
+		{name: "latest matching", filter: Filter{TagPattern: "v*", LatestOnly: true}, wantTags: []string{"v1.1.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tags []string
+			for _, rel := range tt.filter.Select(releases) {
+				tags = append(tags, rel.TagName)
+			}
+			assert.Equal(t, tt.wantTags, tags)
+		})
+	}
+}
+
+func TestFilter_SelectAssets(t *testing.T) {
+	releases := []*Release{{TagName: "v1", Assets: []Asset{{Name: "app.tar.gz"}, {Name: "app.sha256"}}}}
+
+	selected := (&Filter{AssetPattern: "*.tar.gz"}).Select(releases)
+	require.Len(t, selected, 1)
+	require.Len(t, selected[0].Assets, 1)
+	assert.Equal(t, "app.tar.gz", selected[0].Assets[0].Name)
+	assert.Len(t, releases[0].Assets, 2, "the input is not modified")
+}
+
+func TestFilter_Validate(t *testing.T) {
+	assert.NoError(t, (&Filter{TagPattern: "v1.*"}).Validate())
+	assert.Error(t, (&Filter{AssetPattern: "[bad"}).Validate())
+}
+
+func TestAssetPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("base", "acme", "app", "releases", "v1.0", "app.tar.gz"),
+		AssetPath("base", "acme", "app", "v1.0", "app.tar.gz"))
+	assert.Equal(t, filepath.Join("base", "acme", "app", "releases", "release_1", "_"),
+		AssetPath("base", "acme", "app", "release/1", ".."))
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Duplicate records a repository left out because another repository with
+// the same remote was already selected or cloned
+type Duplicate struct {
+	Repository *Repository
+	Of         string // Full name or local path of the copy that is kept
+}
+
+// CloneURLKey returns a comparison key for a clone URL: the lower-cased
+// host/path without scheme, credentials, port or ".git" suffix. HTTPS, ssh://
+// and scp-like (git@host:owner/repo.git) forms of a remote share one key.
+func CloneURLKey(rawURL string) string {
+	value := strings.TrimSpace(rawURL)
+	if !strings.Contains(value, "://") {
+		// scp-like address: [user@]host:path
+		if colon := strings.Index(value, ":"); colon > 0 {
+			value = "ssh://" + value[:colon] + "/" + strings.TrimPrefix(value[colon+1:], "/")
+		}
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return strings.ToLower(strings.TrimSuffix(strings.TrimSuffix(value, "/"), ".git"))
+	}
+
+	path := strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	return strings.ToLower(parsed.Hostname() + "/" + path)
+}
+
+// Deduplicator drops repositories whose remote has already been seen. It is
+// keyed on the normalized clone URL and, for repositories with a provider ID,
+// on host and ID so renamed copies of the same repository are caught too.
+type Deduplicator struct {
+	seen map[string]string
+}
+
+// NewDeduplicator creates an empty deduplicator
+func NewDeduplicator() *Deduplicator {
+	return &Deduplicator{seen: make(map[string]string)}
+}
+
+// Seen registers an existing copy of a remote, e.g. a clone already present
+// in the workspace, under the given description
+func (d *Deduplicator) Seen(cloneURL, of string) {
+	d.seen[CloneURLKey(cloneURL)] = of
+}
+
+// Add registers a repository and reports the kept copy it duplicates, if any
+func (d *Deduplicator) Add(repo *Repository) (string, bool) {
+	keys := []string{CloneURLKey(repo.CloneURL)}
+	if repo.ID != 0 {
+		host := strings.SplitN(keys[0], "/", 2)[0]
+		keys = append(keys, fmt.Sprintf("%s#%d", host, repo.ID))
+	}
+
+	for _, key := range keys {
+		if of, ok := d.seen[key]; ok {
+			return of, true
+		}
+	}
+	for _, key := range keys {
+		d.seen[key] = repo.GetFullName()
+	}
+	return "", false
+}
+
+// Deduplicate returns the repositories with unique remotes, in their original
+// order, and the duplicates that were left out
+func Deduplicate(repos []*Repository) ([]*Repository, []Duplicate) {
+	d := NewDeduplicator()
+	unique := make([]*Repository, 0, len(repos))
+	var duplicates []Duplicate
+
+	for _, repo := range repos {
+		if of, dup := d.Add(repo); dup {
+			duplicates = append(duplicates, Duplicate{Repository: repo, Of: of})
+			continue
+		}
+		unique = append(unique, repo)
+	}
+
+	return unique, duplicates
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneURLKey(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{raw: "https://github.com/Owner/Repo.git", want: "github.com/owner/repo"},
+		{raw: "https://token@github.com/owner/repo/", want: "github.com/owner/repo"},
+		{raw: "git@github.com:owner/repo.git", want: "github.com/owner/repo"},
+		{raw: "ssh://git@github.com:22/owner/repo.git", want: "github.com/owner/repo"},
+		{raw: "https://git.example.com/scm/proj/repo.git", want: "git.example.com/scm/proj/repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			assert.Equal(t, tt.want, CloneURLKey(tt.raw))
+		})
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	newRepo := func(id RepositoryID, owner, cloneURL string) *Repository {
+		repo, err := NewRepository(id, "repo", cloneURL, owner, false, 0, "main")
+		require.NoError(t, err)
+		return repo
+	}
+
+	original := newRepo(1, "org", "https://github.com/org/repo.git")
+	sameURL := newRepo(2, "org", "ssh://git@github.com/Org/repo.git")
+	renamed := newRepo(1, "org", "https://github.com/org/renamed.git")
+	otherHost := newRepo(1, "org", "https://bitbucket.org/org/repo.git")
+
+	unique, duplicates := Deduplicate([]*Repository{original, sameURL, renamed, otherHost})
+
+	assert.Equal(t, []*Repository{original, otherHost}, unique)
+	require.Len(t, duplicates, 2)
+	assert.Same(t, sameURL, duplicates[0].Repository)
+	assert.Equal(t, "org/repo", duplicates[0].Of)
+	assert.Same(t, renamed, duplicates[1].Repository)
+}
+
+func TestDeduplicator_Seen(t *testing.T) {
+	d := NewDeduplicator()
+	d.Seen("https://github.com/org/repo", "mirrors/repo")
+
+	repo, err := NewRepository(0, "repo", "https://github.com/org/repo.git", "org", false, 0, "main")
+	require.NoError(t, err)
+
+	of, dup := d.Add(repo)
+	assert.True(t, dup)
+	assert.Equal(t, "mirrors/repo", of)
+}
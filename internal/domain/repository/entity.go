@@ -18,11 +18,20 @@ type Repository struct {
 	CloneURL      string       `json:"clone_url"`
 	Owner         string       `json:"owner"`
 	IsFork        bool         `json:"fork"`
-	Size          int64        `json:"size"`
+	UpstreamURL   string       `json:"upstream_url,omitempty"` // Clone URL of the parent of a fork, empty when unknown
+	Archived      bool         `json:"archived"`
+	IsTemplate    bool         `json:"is_template,omitempty"`
+	Size          int64        `json:"size"` // Bytes
 	DefaultBranch string       `json:"default_branch"`
 	Language      string       `json:"language,omitempty"`
 	Description   string       `json:"description,omitempty"`
+	Topics        []string     `json:"topics,omitempty"`
+	HasIssues     bool         `json:"has_issues"`
+	Permission    Permission   `json:"permission,omitempty"` // Access of the listing user or team, empty when unknown
+	Visibility    Visibility   `json:"visibility,omitempty"` // Empty when the provider does not report it
 	UpdatedAt     time.Time    `json:"updated_at"`
+	PushedAt      time.Time    `json:"pushed_at,omitempty"`
+	RenamedFrom   string       `json:"renamed_from,omitempty"` // owner/name it was referenced by before a rename or transfer
 }
 
 // NewRepository creates a new repository with validation
@@ -99,11 +108,34 @@ func (r *Repository) GetLocalPath(baseDir string) string {
 	return filepath.Join(baseDir, r.Name)
 }
 
+// IsDotRepository reports whether the repository holds provider configuration
+// or documentation rather than code: dot-repos such as .github and wiki
+// repositories ending in .wiki
+func (r *Repository) IsDotRepository() bool {
+	return strings.HasPrefix(r.Name, ".") || strings.HasSuffix(r.Name, ".wiki")
+}
+
 // GetFullName returns the full name of the repository (owner/name)
 func (r *Repository) GetFullName() string {
 	return fmt.Sprintf("%s/%s", r.Owner, r.Name)
 }
 
+// Provider names the hosting provider of the repository from the host of its
+// clone URL: github, bitbucket or gitlab, or the host of other instances
+func (r *Repository) Provider() string {
+	host, _, _ := strings.Cut(CloneURLKey(r.CloneURL), "/")
+	switch host {
+	case "github.com":
+		return "github"
+	case "bitbucket.org":
+		return "bitbucket"
+	case "gitlab.com":
+		return "gitlab"
+	default:
+		return host
+	}
+}
+
 // IsPublic checks if the repository is public based on clone URL
 func (r *Repository) IsPublic() bool {
 	return strings.HasPrefix(r.CloneURL, "https://")
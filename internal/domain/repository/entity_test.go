@@ -240,6 +240,20 @@ func TestRepository_IsPublic(t *testing.T) {
 	}
 }
 
+func TestRepository_Provider(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/owner/repo.git":           "github",
+		"git@bitbucket.org:workspace/repo.git":        "bitbucket",
+		"https://x-token@gitlab.com/group/repo.git":   "gitlab",
+		"https://git.example.com:8443/scm/p/repo.git": "git.example.com",
+	}
+
+	for cloneURL, want := range tests {
+		repo := &Repository{CloneURL: cloneURL}
+		assert.Equal(t, want, repo.Provider(), cloneURL)
+	}
+}
+
 func TestRepository_Equal(t *testing.T) {
 	repo1 := &Repository{
 		ID:       123,
@@ -350,3 +364,57 @@ func TestRepository_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestRepositoryFilter_Exclusions(t *testing.T) {
+	newRepo := func(name string) *Repository {
+		repo, err := NewRepository(1, name, "https://github.com/org/"+name+".git", "org", false, 0, "main")
+		require.NoError(t, err)
+		return repo
+	}
+
+	template := newRepo("service-template")
+	template.IsTemplate = true
+
+	filter := NewRepositoryFilter()
+	for _, repo := range []*Repository{newRepo("app"), template, newRepo(".github"), newRepo("docs.wiki")} {
+		assert.True(t, filter.ShouldInclude(repo), repo.Name)
+	}
+
+	filter.ExcludeTemplates = true
+	filter.ExcludeDotRepos = true
+	assert.True(t, filter.ShouldInclude(newRepo("app")))
+	assert.False(t, filter.ShouldInclude(template))
+	assert.False(t, filter.ShouldInclude(newRepo(".github")))
+	assert.False(t, filter.ShouldInclude(newRepo("docs.wiki")))
+}
+
+func TestRepositoryFilter_Forks(t *testing.T) {
+	source, err := NewRepository(1, "source", "https://github.com/org/source.git", "org", false, 0, "main")
+	require.NoError(t, err)
+	fork, err := NewRepository(2, "fork", "https://github.com/org/fork.git", "org", true, 0, "main")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		includeForks bool
+		onlyForks    bool
+		wantSource   bool
+		wantFork     bool
+	}{
+		{name: "skip forks", wantSource: true},
+		{name: "include forks", includeForks: true, wantSource: true, wantFork: true},
+		{name: "only forks", onlyForks: true, wantFork: true},
+		{name: "only forks wins", includeForks: true, onlyForks: true, wantFork: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewRepositoryFilter()
+			filter.IncludeForks = tt.includeForks
+			filter.OnlyForks = tt.onlyForks
+
+			assert.Equal(t, tt.wantSource, filter.ShouldInclude(source))
+			assert.Equal(t, tt.wantFork, filter.ShouldInclude(fork))
+		})
+	}
+}
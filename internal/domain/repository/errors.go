@@ -24,6 +24,15 @@ var (
 
 	// ErrRepositorySizeTooLarge indicates repository is too large
 	ErrRepositorySizeTooLarge = errors.New("repository size exceeds limit")
+
+	// ErrAuthenticationFailed indicates the provider rejected the credentials
+	ErrAuthenticationFailed = errors.New("authentication failed")
+
+	// ErrRateLimitExceeded indicates the provider API rate limit is exhausted
+	ErrRateLimitExceeded = errors.New("API rate limit exceeded")
+
+	// ErrStopPaging is returned by a PageHandler to stop fetching further pages
+	ErrStopPaging = errors.New("stop paging")
 )
 
 // IsNotFoundError checks if an error is a not found error
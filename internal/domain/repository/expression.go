@@ -0,0 +1,438 @@
+package repository
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterExpression selects repositories with a boolean expression over
+// their fields, for selections the fixed filters cannot express:
+//
+//	language==go && size<50MB && !fork
+//	(topic==backend || name==api-*) && pushed>=2024-01-01
+//
+// Comparisons are field op value with the operators ==, !=, <, <=, > and
+// >=; text fields only support == and != and match case-insensitive globs.
+// Boolean fields stand alone. Expressions combine with !, &&, || and
+// parentheses; && binds tighter than ||.
+type FilterExpression struct {
+	source string
+	root   expressionNode
+}
+
+// ParseFilterExpression parses a filter expression
+func ParseFilterExpression(expr string) (*FilterExpression, error) {
+	tokens, err := tokenizeExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &expressionParser{source: expr, tokens: tokens}
+	if p.peek().kind == tokenEnd {
+		return nil, &ExpressionError{Expression: expr, Message: "expression is empty"}
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.kind != tokenEnd {
+		return nil, p.errorAt(token, "unexpected %s, expected && or ||", token)
+	}
+	return &FilterExpression{source: expr, root: root}, nil
+}
+
+// Matches reports whether a repository satisfies the expression
+func (e *FilterExpression) Matches(repo *Repository) bool {
+	return e.root.eval(repo)
+}
+
+// String returns the expression as it was parsed
+func (e *FilterExpression) String() string {
+	return e.source
+}
+
+// ExpressionError is a filter expression that does not parse, pointing at
+// the offending position
+type ExpressionError struct {
+	Expression string
+	Position   int // Byte offset of the error in Expression
+	Message    string
+}
+
+// Error returns the message with the expression and a caret under the
+// offending position
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("invalid filter expression at column %d: %s\n  %s\n  %s^",
+		e.Position+1, e.Message, e.Expression, strings.Repeat(" ", e.Position))
+}
+
+// fieldKind is the type of the values a field compares against
+type fieldKind int
+
+const (
+	textField fieldKind = iota
+	listField           // Matches when any of its values does
+	sizeField
+	timeField
+	boolField
+)
+
+// expressionField is a repository field usable in expressions
+type expressionField struct {
+	kind  fieldKind
+	text  func(r *Repository) []string
+	size  func(r *Repository) int64
+	time  func(r *Repository) time.Time
+	value func(r *Repository) bool
+}
+
+// expressionFields are the fields expressions can refer to
+var expressionFields = map[string]expressionField{
+	"name":           {kind: textField, text: func(r *Repository) []string { return []string{r.Name} }},
+	"owner":          {kind: textField, text: func(r *Repository) []string { return []string{r.Owner} }},
+	"full_name":      {kind: textField, text: func(r *Repository) []string { return []string{r.GetFullName()} }},
+	"language":       {kind: textField, text: func(r *Repository) []string { return []string{r.Language} }},
+	"default_branch": {kind: textField, text: func(r *Repository) []string { return []string{r.DefaultBranch} }},
+	"visibility":     {kind: textField, text: func(r *Repository) []string { return []string{string(r.Visibility)} }},
+	"description":    {kind: textField, text: func(r *Repository) []string { return []string{r.Description} }},
+	"topic":          {kind: listField, text: func(r *Repository) []string { return r.Topics }},
+	"size":           {kind: sizeField, size: func(r *Repository) int64 { return r.Size }},
+	"updated":        {kind: timeField, time: func(r *Repository) time.Time { return r.UpdatedAt }},
+	"pushed":         {kind: timeField, time: func(r *Repository) time.Time { return r.PushedAt }},
+	"fork":           {kind: boolField, value: func(r *Repository) bool { return r.IsFork }},
+	"archived":       {kind: boolField, value: func(r *Repository) bool { return r.Archived }},
+	"template":       {kind: boolField, value: func(r *Repository) bool { return r.IsTemplate }},
+	"private":        {kind: boolField, value: func(r *Repository) bool { return r.Visibility == VisibilityPrivate }},
+}
+
+// expressionFieldNames returns the sorted names of the expression fields
+func expressionFieldNames() []string {
+	names := make([]string, 0, len(expressionFields))
+	for name := range expressionFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// expressionNode is a node of a parsed expression
+type expressionNode interface {
+	eval(r *Repository) bool
+}
+
+type andNode struct{ left, right expressionNode }
+
+func (n *andNode) eval(r *Repository) bool { return n.left.eval(r) && n.right.eval(r) }
+
+type orNode struct{ left, right expressionNode }
+
+func (n *orNode) eval(r *Repository) bool { return n.left.eval(r) || n.right.eval(r) }
+
+type notNode struct{ operand expressionNode }
+
+func (n *notNode) eval(r *Repository) bool { return !n.operand.eval(r) }
+
+// predicateNode is a comparison or boolean field, compiled to a predicate
+type predicateNode func(r *Repository) bool
+
+func (n predicateNode) eval(r *Repository) bool { return n(r) }
+
+// expressionParser is a recursive descent parser over the tokens of an
+// expression
+type expressionParser struct {
+	source string
+	tokens []expressionToken
+	pos    int
+}
+
+func (p *expressionParser) peek() expressionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *expressionParser) next() expressionToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEnd {
+		p.pos++
+	}
+	return token
+}
+
+func (p *expressionParser) errorAt(token expressionToken, format string, args ...any) error {
+	return &ExpressionError{Expression: p.source, Position: token.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// parseOr parses operands joined by ||
+func (p *expressionParser) parseOr() (expressionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses operands joined by &&
+func (p *expressionParser) parseAnd() (expressionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses a negation, a parenthesized expression or a predicate
+func (p *expressionParser) parseUnary() (expressionNode, error) {
+	token := p.next()
+	switch token.kind {
+	case tokenNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	case tokenOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenClose {
+			return nil, p.errorAt(closing, "unexpected %s, expected ) to close the ( at column %d", closing, token.pos+1)
+		}
+		return node, nil
+	case tokenWord:
+		return p.parsePredicate(token)
+	default:
+		return nil, p.errorAt(token, "unexpected %s, expected a field, ! or (", token)
+	}
+}
+
+// parsePredicate parses a boolean field or a comparison of a field
+func (p *expressionParser) parsePredicate(fieldToken expressionToken) (expressionNode, error) {
+	name := strings.ToLower(fieldToken.text)
+	field, ok := expressionFields[name]
+	if !ok {
+		return nil, p.errorAt(fieldToken, "unknown field %q, expected one of: %s", fieldToken.text, strings.Join(expressionFieldNames(), ", "))
+	}
+
+	operator := p.peek()
+	if operator.kind != tokenOperator {
+		if field.kind != boolField {
+			return nil, p.errorAt(operator, "unexpected %s, expected a comparison operator after %s", operator, name)
+		}
+		return predicateNode(field.value), nil
+	}
+	p.next()
+
+	valueToken := p.next()
+	if valueToken.kind != tokenWord && valueToken.kind != tokenString {
+		return nil, p.errorAt(valueToken, "unexpected %s, expected a value to compare %s with", valueToken, name)
+	}
+
+	predicate, err := compilePredicate(name, field, operator.text, valueToken.text)
+	if err != nil {
+		return nil, p.errorAt(valueToken, "%s", err)
+	}
+	if predicate == nil {
+		return nil, p.errorAt(operator, "operator %s does not apply to %s, use == or !=", operator.text, name)
+	}
+	return predicate, nil
+}
+
+// compilePredicate builds the predicate comparing a field with a value. It
+// returns nil when the operator does not apply to the field.
+func compilePredicate(name string, field expressionField, operator, value string) (predicateNode, error) {
+	switch field.kind {
+	case textField, listField:
+		if operator != "==" && operator != "!=" {
+			return nil, nil
+		}
+		pattern := strings.ToLower(value)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q", value)
+		}
+		matches := func(r *Repository) bool {
+			return slices.ContainsFunc(field.text(r), func(text string) bool {
+				matched, _ := path.Match(pattern, strings.ToLower(text))
+				return matched
+			})
+		}
+		if operator == "!=" {
+			return func(r *Repository) bool { return !matches(r) }, nil
+		}
+		return matches, nil
+
+	case boolField:
+		if operator != "==" && operator != "!=" {
+			return nil, nil
+		}
+		want, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for %s, expected true or false", value, name)
+		}
+		if operator == "!=" {
+			want = !want
+		}
+		return func(r *Repository) bool { return field.value(r) == want }, nil
+
+	case sizeField:
+		size, err := parseSizeValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %q for %s, expected a size such as 50MB", value, name)
+		}
+		return func(r *Repository) bool { return compareOrdered(field.size(r), operator, size) }, nil
+
+	case timeField:
+		day, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q for %s, expected YYYY-MM-DD", value, name)
+		}
+		// Dates compare by the UTC day of the timestamp
+		return func(r *Repository) bool {
+			t := field.time(r).UTC()
+			return compareOrdered(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix(), operator, day.Unix())
+		}, nil
+	}
+	return nil, nil
+}
+
+// parseSizeValue parses a size of an expression, which unlike other sizes
+// may be zero
+func parseSizeValue(value string) (int64, error) {
+	if value == "0" {
+		return 0, nil
+	}
+	return ParseSize(value)
+}
+
+// compareOrdered applies a comparison operator
+func compareOrdered(a int64, operator string, b int64) bool {
+	switch operator {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	default:
+		return a >= b
+	}
+}
+
+// tokenKind is the kind of a lexical token of an expression
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+// expressionToken is a lexical token of an expression
+type expressionToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// String describes the token for error messages
+func (t expressionToken) String() string {
+	switch t.kind {
+	case tokenEnd:
+		return "end of expression"
+	case tokenString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// wordTerminators end an unquoted word
+const wordTerminators = "()!&|=<>\"' \t"
+
+// tokenizeExpression splits an expression into tokens
+func tokenizeExpression(expr string) ([]expressionToken, error) {
+	var tokens []expressionToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		two := ""
+		if i+1 < len(expr) {
+			two = expr[i : i+2]
+		}
+
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, expressionToken{kind: tokenOpen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, expressionToken{kind: tokenClose, text: ")", pos: i})
+			i++
+		case two == "&&":
+			tokens = append(tokens, expressionToken{kind: tokenAnd, text: two, pos: i})
+			i += 2
+		case two == "||":
+			tokens = append(tokens, expressionToken{kind: tokenOr, text: two, pos: i})
+			i += 2
+		case two == "==" || two == "!=" || two == "<=" || two == ">=":
+			tokens = append(tokens, expressionToken{kind: tokenOperator, text: two, pos: i})
+			i += 2
+		case c == '<' || c == '>':
+			tokens = append(tokens, expressionToken{kind: tokenOperator, text: string(c), pos: i})
+			i++
+		case c == '!':
+			tokens = append(tokens, expressionToken{kind: tokenNot, text: "!", pos: i})
+			i++
+		case c == '=':
+			return nil, &ExpressionError{Expression: expr, Position: i, Message: "single =, use == to compare"}
+		case c == '&' || c == '|':
+			return nil, &ExpressionError{Expression: expr, Position: i,
+				Message: fmt.Sprintf("single %c, use %c%c", c, c, c)}
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, &ExpressionError{Expression: expr, Position: i, Message: "unterminated quoted value"}
+			}
+			tokens = append(tokens, expressionToken{kind: tokenString, text: expr[i+1 : i+1+end], pos: i})
+			i += end + 2
+		default:
+			end := strings.IndexAny(expr[i:], wordTerminators)
+			if end < 0 {
+				end = len(expr) - i
+			}
+			tokens = append(tokens, expressionToken{kind: tokenWord, text: expr[i : i+end], pos: i})
+			i += end
+		}
+	}
+	return append(tokens, expressionToken{kind: tokenEnd, pos: len(expr)}), nil
+}
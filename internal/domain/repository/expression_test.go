@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterExpression_Matches(t *testing.T) {
+	api := &Repository{Name: "api-gateway", Owner: "acme", Language: "Go", Size: 20 << 20,
+		Topics: []string{"backend", "http"}, Visibility: VisibilityPrivate,
+		PushedAt: time.Date(2024, 3, 1, 23, 30, 0, 0, time.UTC)}
+	fork := &Repository{Name: "linux", Owner: "acme", Language: "C", Size: 4 << 30, IsFork: true,
+		Visibility: VisibilityPublic, PushedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+	docs := &Repository{Name: "docs", Owner: "acme", Archived: true, Visibility: VisibilityPublic}
+
+	tests := []struct {
+		expr string
+		want []*Repository
+	}{
+		{`language==go && size<50MB && !fork`, []*Repository{api}},
+		{`language == GO`, []*Repository{api}},
+		{`language!=go`, []*Repository{fork, docs}},
+		{`fork || archived`, []*Repository{fork, docs}},
+		{`!(fork || archived)`, []*Repository{api}},
+		{`fork==false && archived!=true`, []*Repository{api}},
+		{`name==api-*`, []*Repository{api}},
+		{`full_name=="acme/linux"`, []*Repository{fork}},
+		{`topic==backend`, []*Repository{api}},
+		{`topic!=backend`, []*Repository{fork, docs}},
+		{`size>=4GiB || size==0`, []*Repository{fork, docs}},
+		{`pushed>=2024-01-01`, []*Repository{api}},
+		{`pushed==2024-03-01`, []*Repository{api}},
+		{`private`, []*Repository{api}},
+		{`archived || fork && language==c`, []*Repository{fork, docs}},
+		{`(archived || fork) && language==c`, []*Repository{fork}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := ParseFilterExpression(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expr, expr.String())
+
+			var got []*Repository
+			for _, repo := range []*Repository{api, fork, docs} {
+				if expr.Matches(repo) {
+					got = append(got, repo)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFilterExpression_Errors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		message string
+		column  int
+	}{
+		{"", "expression is empty", 1},
+		{"lang==go", `unknown field "lang", expected one of: archived, default_branch`, 1},
+		{"language=go", "single =, use == to compare", 9},
+		{"fork & archived", "single &, use &&", 6},
+		{"language==go fork", `unexpected "fork", expected && or ||`, 14},
+		{"language", `unexpected end of expression, expected a comparison operator after language`, 9},
+		{"size<", "unexpected end of expression, expected a value to compare size with", 6},
+		{"size<huge", `invalid size "huge" for size`, 6},
+		{"name<api", "operator < does not apply to name, use == or !=", 5},
+		{"pushed>yesterday", `invalid date "yesterday" for pushed, expected YYYY-MM-DD`, 8},
+		{"fork==maybe", `invalid value "maybe" for fork, expected true or false`, 7},
+		{"(fork || archived", "unexpected end of expression, expected ) to close the ( at column 1", 18},
+		{"fork &&", "unexpected end of expression, expected a field, ! or (", 8},
+		{`name=="api`, "unterminated quoted value", 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseFilterExpression(tt.expr)
+			require.Error(t, err)
+
+			var exprErr *ExpressionError
+			require.ErrorAs(t, err, &exprErr)
+			assert.Contains(t, exprErr.Message, tt.message)
+			assert.Equal(t, tt.column, exprErr.Position+1)
+		})
+	}
+}
+
+func TestExpressionError_Error(t *testing.T) {
+	_, err := ParseFilterExpression("language==go && sise<50MB")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid filter expression at column 17: unknown field \"sise\"")
+	assert.True(t, strings.HasSuffix(err.Error(), "\n  language==go && sise<50MB\n                  ^"))
+}
+
+func TestRepositoryFilter_Expression(t *testing.T) {
+	expr, err := ParseFilterExpression("size<1MB")
+	require.NoError(t, err)
+
+	filter := NewRepositoryFilter()
+	filter.OnlyPublic = false
+	filter.Expression = expr
+
+	assert.True(t, filter.ShouldInclude(&Repository{Name: "small", Size: 1 << 10}))
+	assert.False(t, filter.ShouldInclude(&Repository{Name: "large", Size: 2 << 20}))
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// IgnoreFileName is the name of the persistent repository exclusion list
+const IgnoreFileName = ".ghcloneignore"
+
+// IgnoreList excludes repositories with gitignore-like name patterns:
+//
+//	# comments and blank lines are skipped
+//	*-archive        glob on the repository name, for every owner
+//	acme/legacy-*    glob on owner/name
+//	!acme-archive    re-include a repository an earlier pattern excluded
+//	[acme]           the patterns that follow only apply to owner acme
+//	[*]              back to every owner
+//
+// Matching is case-insensitive and, as in gitignore, the last matching
+// pattern decides.
+type IgnoreList struct {
+	rules []ignoreRule
+}
+
+// ignoreRule is a single pattern of an ignore list
+type ignoreRule struct {
+	owner   string // Owner glob of the section, "*" for every owner
+	pattern string // Glob on the name, or on owner/name when it has a slash
+	negate  bool
+}
+
+// ParseIgnoreList parses an ignore list
+func ParseIgnoreList(r io.Reader) (*IgnoreList, error) {
+	list := &IgnoreList{}
+	owner := "*"
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated section %q", lineNo, line)
+			}
+			owner = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			if owner == "" {
+				owner = "*"
+			}
+			if _, err := path.Match(owner, ""); err != nil {
+				return nil, fmt.Errorf("line %d: invalid section %q: %w", lineNo, line, err)
+			}
+			continue
+		}
+
+		rule := ignoreRule{owner: owner}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = strings.TrimSpace(line[1:])
+		}
+		rule.pattern = strings.ToLower(strings.Trim(line, "/"))
+		if rule.pattern == "" {
+			return nil, fmt.Errorf("line %d: empty pattern", lineNo)
+		}
+		if _, err := path.Match(rule.pattern, ""); err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", lineNo, line, err)
+		}
+		list.rules = append(list.rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ignore list: %w", err)
+	}
+
+	return list, nil
+}
+
+// Merge appends the patterns of another list, which take precedence over
+// the patterns already in the list
+func (l *IgnoreList) Merge(other *IgnoreList) {
+	if other != nil {
+		l.rules = append(l.rules, other.rules...)
+	}
+}
+
+// Len returns the number of patterns in the list
+func (l *IgnoreList) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.rules)
+}
+
+// Matches reports whether the list excludes a repository
+func (l *IgnoreList) Matches(repo *Repository) bool {
+	if l == nil {
+		return false
+	}
+
+	owner := strings.ToLower(repo.Owner)
+
+	ignored := false
+	for _, rule := range l.rules {
+		if rule.negate != ignored {
+			// The rule cannot change the outcome
+			continue
+		}
+		if matched, _ := path.Match(rule.owner, owner); !matched {
+			continue
+		}
+		if repo.MatchesPattern(rule.pattern) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// MatchesPattern reports whether a glob matches the repository name, or its
+// owner/name when the pattern has a slash. Matching is case-insensitive.
+func (r *Repository) MatchesPattern(pattern string) bool {
+	pattern = strings.ToLower(pattern)
+	subject := strings.ToLower(r.Name)
+	if strings.Contains(pattern, "/") {
+		subject = strings.ToLower(r.Owner) + "/" + subject
+	}
+	matched, _ := path.Match(pattern, subject)
+	return matched
+}
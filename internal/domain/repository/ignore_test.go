@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreList_Matches(t *testing.T) {
+	list, err := ParseIgnoreList(strings.NewReader(`
+# Archived experiments
+*-archive
+!keep-archive
+Other/Legacy-*
+
+[acme]
+sandbox
+tmp-*/
+
+[*]
+scratch
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 6, list.Len())
+
+	tests := []struct {
+		owner, name string
+		ignored     bool
+	}{
+		{"acme", "api-archive", true},
+		{"acme", "keep-archive", false},
+		{"other", "legacy-app", true},
+		{"acme", "legacy-app", false},
+		{"acme", "sandbox", true},
+		{"ACME", "Tmp-1", true},
+		{"other", "sandbox", false},
+		{"other", "scratch", true},
+		{"acme", "api", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.owner+"/"+tt.name, func(t *testing.T) {
+			repo := &Repository{Owner: tt.owner, Name: tt.name}
+			assert.Equal(t, tt.ignored, list.Matches(repo))
+		})
+	}
+}
+
+func TestIgnoreList_Merge(t *testing.T) {
+	global, err := ParseIgnoreList(strings.NewReader("demo-*\n"))
+	require.NoError(t, err)
+	local, err := ParseIgnoreList(strings.NewReader("!demo-app\n"))
+	require.NoError(t, err)
+
+	global.Merge(local)
+
+	assert.True(t, global.Matches(&Repository{Owner: "acme", Name: "demo-site"}))
+	assert.False(t, global.Matches(&Repository{Owner: "acme", Name: "demo-app"}), "later lists take precedence")
+}
+
+func TestParseIgnoreList_Invalid(t *testing.T) {
+	for _, input := range []string{"[acme\n", "repo-[\n", "!\n", "[a[]\n"} {
+		_, err := ParseIgnoreList(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestRepositoryFilter_Ignore(t *testing.T) {
+	repo, err := NewRepository(1, "sandbox", "https://github.com/org/sandbox.git", "org", false, 0, "main")
+	require.NoError(t, err)
+
+	filter := NewRepositoryFilter()
+	assert.True(t, filter.ShouldInclude(repo), "nil ignore list excludes nothing")
+
+	filter.Ignore, err = ParseIgnoreList(strings.NewReader("sandbox\n"))
+	require.NoError(t, err)
+	assert.False(t, filter.ShouldInclude(repo))
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Permission is a repository access level granted to a user or team, from
+// least to most privileged: pull, triage, push, maintain, admin
+type Permission string
+
+const (
+	PermissionPull     Permission = "pull"
+	PermissionTriage   Permission = "triage"
+	PermissionPush     Permission = "push"
+	PermissionMaintain Permission = "maintain"
+	PermissionAdmin    Permission = "admin"
+)
+
+// permissionRanks orders the access levels; unknown permissions rank zero
+var permissionRanks = map[Permission]int{
+	PermissionPull:     1,
+	PermissionTriage:   2,
+	PermissionPush:     3,
+	PermissionMaintain: 4,
+	PermissionAdmin:    5,
+}
+
+// ParsePermission validates a user supplied permission. GitHub's role names
+// read and write are accepted as pull and push.
+func ParsePermission(value string) (Permission, error) {
+	permission := Permission(strings.ToLower(strings.TrimSpace(value)))
+	switch permission {
+	case "read":
+		return PermissionPull, nil
+	case "write":
+		return PermissionPush, nil
+	}
+
+	if _, ok := permissionRanks[permission]; !ok {
+		return "", fmt.Errorf("invalid permission %q (supported: pull, triage, push, maintain, admin)", value)
+	}
+	return permission, nil
+}
+
+// AtLeast reports whether p grants at least the access of min. An unknown
+// permission never satisfies a minimum.
+func (p Permission) AtLeast(min Permission) bool {
+	return permissionRanks[p] > 0 && permissionRanks[p] >= permissionRanks[min]
+}
+
+// String returns the string representation of the permission
+func (p Permission) String() string {
+	return string(p)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePermission(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Permission
+		wantErr bool
+	}{
+		{value: "push", want: PermissionPush},
+		{value: "Admin", want: PermissionAdmin},
+		{value: "read", want: PermissionPull},
+		{value: "write", want: PermissionPush},
+		{value: "owner", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePermission(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPermission_AtLeast(t *testing.T) {
+	assert.True(t, PermissionAdmin.AtLeast(PermissionPush))
+	assert.True(t, PermissionPush.AtLeast(PermissionPush))
+	assert.False(t, PermissionTriage.AtLeast(PermissionPush))
+	assert.False(t, Permission("").AtLeast(PermissionPull))
+}
+
+func TestRepositoryFilter_MinPermission(t *testing.T) {
+	repo, err := NewRepository(1, "repo", "https://github.com/org/repo.git", "org", false, 0, "main")
+	require.NoError(t, err)
+
+	filter := NewRepositoryFilter()
+	filter.MinPermission = PermissionPush
+
+	assert.False(t, filter.ShouldInclude(repo), "unknown permission is excluded")
+
+	repo.Permission = PermissionMaintain
+	assert.True(t, filter.ShouldInclude(repo))
+
+	repo.Permission = PermissionPull
+	assert.False(t, filter.ShouldInclude(repo))
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// defaultReferenceHost is the host of owner/repo shorthand references
+const defaultReferenceHost = "github.com"
+
+// ParseReference builds a repository from an owner/repo shorthand, a
+// host/owner/repo path or a clone URL (https, ssh:// or git@host:owner/repo)
+func ParseReference(ref string) (*Repository, error) {
+	value := strings.TrimSpace(ref)
+	if value == "" {
+		return nil, fmt.Errorf("repository reference cannot be empty")
+	}
+
+	switch {
+	case strings.Contains(value, "://"):
+	case strings.Contains(value, "@") && strings.Contains(value, ":"):
+		// scp-like address: user@host:owner/repo.git
+		colon := strings.Index(value, ":")
+		value = "ssh://" + value[:colon] + "/" + strings.TrimPrefix(value[colon+1:], "/")
+	default:
+		segments := strings.Split(strings.Trim(value, "/"), "/")
+		if len(segments) == 2 {
+			value = "https://" + defaultReferenceHost + "/" + strings.Trim(value, "/")
+		} else {
+			value = "https://" + value
+		}
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository reference %q: %w", ref, err)
+	}
+	if strings.HasPrefix(parsed.Scheme, "http") {
+		parsed.User = nil
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" {
+		return nil, fmt.Errorf("invalid repository reference %q: expected owner/repo or a clone URL", ref)
+	}
+
+	name := strings.TrimSuffix(segments[len(segments)-1], ".git")
+	owner := segments[len(segments)-2]
+	parsed.Path = "/" + strings.Join(segments[:len(segments)-1], "/") + "/" + name + ".git"
+
+	repo, err := NewRepository(RepositoryID(referenceID(parsed.String())), name, parsed.String(), owner, false, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository reference %q: %w", ref, err)
+	}
+	return repo, nil
+}
+
+// ReadReferences parses one repository reference per line. Blank lines and
+// lines starting with # are ignored, and only the first field of a line is
+// used, so the tab separated output of `gh repo list` can be piped in as is.
+func ReadReferences(r io.Reader) ([]*Repository, error) {
+	var repos []*Repository
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		repo, err := ParseReference(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		repos = append(repos, repo)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read repository list: %w", err)
+	}
+
+	return repos, nil
+}
+
+// Rename moves a referenced repository to its current owner and name after
+// a rename or transfer, keeping the scheme and host of its clone URL and
+// recording the old full name in RenamedFrom
+func (r *Repository) Rename(owner, name string) {
+	if owner == r.Owner && name == r.Name {
+		return
+	}
+
+	if parsed, err := url.Parse(r.CloneURL); err == nil {
+		segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+		if len(segments) >= 2 {
+			segments[len(segments)-2] = owner
+			segments[len(segments)-1] = name + ".git"
+			parsed.Path = "/" + strings.Join(segments, "/")
+			r.CloneURL = parsed.String()
+		}
+	}
+	if r.RenamedFrom == "" {
+		r.RenamedFrom = r.GetFullName()
+	}
+	r.Owner = owner
+	r.Name = name
+}
+
+// referenceID derives a stable repository ID from the remote of a reference
+func referenceID(cloneURL string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(CloneURLKey(cloneURL)))
+	return int64(h.Sum64() >> 1)
+}
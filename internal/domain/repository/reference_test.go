@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref       string
+		wantURL   string
+		wantOwner string
+		wantName  string
+		wantErr   bool
+	}{
+		{ref: "octocat/hello-world", wantURL: "https://github.com/octocat/hello-world.git", wantOwner: "octocat", wantName: "hello-world"},
+		{ref: "github.com/octocat/hello-world", wantURL: "https://github.com/octocat/hello-world.git", wantOwner: "octocat", wantName: "hello-world"},
+		{ref: "https://token@bitbucket.org/team/repo.git", wantURL: "https://bitbucket.org/team/repo.git", wantOwner: "team", wantName: "repo"},
+		{ref: "git@github.com:octocat/hello-world.git", wantURL: "ssh://git@github.com/octocat/hello-world.git", wantOwner: "octocat", wantName: "hello-world"},
+		{ref: "https://git.example.com/scm/proj/repo", wantURL: "https://git.example.com/scm/proj/repo.git", wantOwner: "proj", wantName: "repo"},
+		{ref: "", wantErr: true},
+		{ref: "octocat", wantErr: true},
+		{ref: "https://github.com/octocat", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			repo, err := ParseReference(tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantURL, repo.CloneURL)
+			assert.Equal(t, tt.wantOwner, repo.Owner)
+			assert.Equal(t, tt.wantName, repo.Name)
+			assert.NotZero(t, repo.ID)
+		})
+	}
+}
+
+func TestReadReferences(t *testing.T) {
+	input := `# repositories to clone
+octocat/hello-world	A description	public	about 1 day ago
+
+https://github.com/octocat/spoon-knife.git
+`
+	repos, err := ReadReferences(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+	assert.Equal(t, "octocat/hello-world", repos[0].GetFullName())
+	assert.Equal(t, "octocat/spoon-knife", repos[1].GetFullName())
+
+	_, err = ReadReferences(strings.NewReader("octocat/hello-world\nnot-a-repo\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestRepository_Rename(t *testing.T) {
+	repo, err := ParseReference("https://github.com/acme/old.git")
+	require.NoError(t, err)
+
+	repo.Rename("acme", "old")
+	assert.Empty(t, repo.RenamedFrom, "the same name is no rename")
+
+	repo.Rename("acme-labs", "new")
+	assert.Equal(t, "acme-labs/new", repo.GetFullName())
+	assert.Equal(t, "https://github.com/acme-labs/new.git", repo.CloneURL)
+	assert.Equal(t, "acme/old", repo.RenamedFrom)
+
+	repo.Rename("acme-labs", "newer")
+	assert.Equal(t, "acme/old", repo.RenamedFrom, "the first referenced name is kept")
+}
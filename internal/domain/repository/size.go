@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseSize parses a human readable size such as "2GB", "512KiB" or "1.5M"
+// into bytes. Units are binary; plain numbers are bytes.
+func ParseSize(value string) (int64, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	normalized = strings.TrimSuffix(strings.TrimSuffix(normalized, "B"), "I")
+	if normalized == "" {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+
+	multiplier := float64(1)
+	switch normalized[len(normalized)-1] {
+	case 'K':
+		multiplier = 1 << 10
+	case 'M':
+		multiplier = 1 << 20
+	case 'G':
+		multiplier = 1 << 30
+	case 'T':
+		multiplier = 1 << 40
+	}
+	if multiplier > 1 {
+		normalized = normalized[:len(normalized)-1]
+	}
+
+	// ParseFloat also accepts NaN and infinities, which are no sizes
+	amount, err := strconv.ParseFloat(strings.TrimSpace(normalized), 64)
+	bytes := amount * multiplier
+	if err != nil || math.IsNaN(bytes) || bytes < 1 || bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %q: expected a positive size such as 2GB", value)
+	}
+	return int64(bytes), nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortKey orders repositories by one field
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// sortField compares repositories by one field in ascending order
+type sortField struct {
+	descending bool // Default direction of the field
+	compare    func(a, b *Repository) int
+}
+
+// sortFields are the fields repositories can be sorted by
+var sortFields = map[string]sortField{
+	"name": {compare: func(a, b *Repository) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}},
+	"full_name": {compare: func(a, b *Repository) int {
+		return strings.Compare(strings.ToLower(a.GetFullName()), strings.ToLower(b.GetFullName()))
+	}},
+	"owner": {compare: func(a, b *Repository) int {
+		return strings.Compare(strings.ToLower(a.Owner), strings.ToLower(b.Owner))
+	}},
+	"language": {compare: func(a, b *Repository) int {
+		return strings.Compare(strings.ToLower(a.Language), strings.ToLower(b.Language))
+	}},
+	"size": {descending: true, compare: func(a, b *Repository) int {
+		return cmp.Compare(a.Size, b.Size)
+	}},
+	"updated": {descending: true, compare: func(a, b *Repository) int {
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	}},
+	"pushed": {descending: true, compare: func(a, b *Repository) int {
+		return a.PushedAt.Compare(b.PushedAt)
+	}},
+}
+
+// SortFieldNames lists the fields of sort specifications
+var SortFieldNames = []string{"name", "full_name", "owner", "language", "size", "updated", "pushed"}
+
+// ParseSortKeys parses a comma-separated sort specification such as
+// "size,-updated". Keys are applied in order of precedence. A bare field
+// sorts in its natural direction: names ascending, sizes largest first and
+// times most recent first; a "-" prefix sorts descending and "+" ascending.
+func ParseSortKeys(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		name := strings.TrimLeft(part, "+-")
+		field, ok := sortFields[name]
+		if !ok || len(part)-len(name) > 1 {
+			return nil, fmt.Errorf("invalid sort field %q (supported: %s, with a - or + prefix for descending or ascending)",
+				part, strings.Join(SortFieldNames, ", "))
+		}
+
+		key := SortKey{Field: name, Descending: field.descending}
+		switch part[0] {
+		case '-':
+			key.Descending = true
+		case '+':
+			key.Descending = false
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("sort specification cannot be empty")
+	}
+	return keys, nil
+}
+
+// SortRepositories sorts repositories in place by keys in order of
+// precedence. The sort is stable, so repositories that compare equal keep the
+// provider order.
+func SortRepositories(repos []*Repository, keys []SortKey) {
+	sort.SliceStable(repos, func(i, j int) bool {
+		for _, key := range keys {
+			c := sortFields[key.Field].compare(repos[i], repos[j])
+			if key.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// ProviderSort returns the provider-side ordering of PaginationOptions.Sort
+// matching keys, so pages arrive sorted, or false when the provider cannot
+// sort that way
+func ProviderSort(keys []SortKey) (string, bool) {
+	if len(keys) != 1 {
+		return "", false
+	}
+	switch keys[0] {
+	case SortKey{Field: SortByName}:
+		return SortByName, true
+	case SortKey{Field: SortByUpdated, Descending: true}:
+		return SortByUpdated, true
+	}
+	return "", false
+}
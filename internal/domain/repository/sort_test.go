@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []SortKey
+		wantErr bool
+	}{
+		{spec: "name", want: []SortKey{{Field: "name"}}},
+		{spec: "size", want: []SortKey{{Field: "size", Descending: true}}},
+		{spec: "size,-updated", want: []SortKey{{Field: "size", Descending: true}, {Field: "updated", Descending: true}}},
+		{spec: " +Size , -name", want: []SortKey{{Field: "size"}, {Field: "name", Descending: true}}},
+		{spec: "", wantErr: true},
+		{spec: "stars", wantErr: true},
+		{spec: "--size", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			keys, err := ParseSortKeys(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, keys)
+		})
+	}
+}
+
+func TestSortRepositories(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	repos := []*Repository{
+		{Name: "b", Size: 10, UpdatedAt: day},
+		{Name: "A", Size: 20, UpdatedAt: day},
+		{Name: "c", Size: 10, UpdatedAt: day.Add(time.Hour)},
+		{Name: "d", Size: 10, UpdatedAt: day},
+	}
+	names := func() []string {
+		var names []string
+		for _, repo := range repos {
+			names = append(names, repo.Name)
+		}
+		return names
+	}
+
+	keys, err := ParseSortKeys("size,-updated")
+	require.NoError(t, err)
+	SortRepositories(repos, keys)
+	assert.Equal(t, []string{"A", "c", "b", "d"}, names(), "ties keep their order")
+
+	keys, err = ParseSortKeys("name")
+	require.NoError(t, err)
+	SortRepositories(repos, keys)
+	assert.Equal(t, []string{"A", "b", "c", "d"}, names())
+
+	keys, err = ParseSortKeys("+size,-name")
+	require.NoError(t, err)
+	SortRepositories(repos, keys)
+	assert.Equal(t, []string{"d", "c", "b", "A"}, names())
+}
+
+func TestProviderSort(t *testing.T) {
+	for spec, want := range map[string]string{"name": SortByName, "updated": SortByUpdated, "-updated": SortByUpdated} {
+		keys, err := ParseSortKeys(spec)
+		require.NoError(t, err)
+		sort, ok := ProviderSort(keys)
+		assert.True(t, ok, spec)
+		assert.Equal(t, want, sort, spec)
+	}
+
+	for _, spec := range []string{"size", "-name", "+updated", "name,size"} {
+		keys, err := ParseSortKeys(spec)
+		require.NoError(t, err)
+		_, ok := ProviderSort(keys)
+		assert.False(t, ok, spec)
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Source identifies a set of repositories by provider host and owner path,
+// e.g. github.com/kubernetes or gitlab.example.com/group/subgroup
+type Source struct {
+	Host string // Lower-cased host name without port
+	Path string // Owner path without leading or trailing slashes
+}
+
+// ParseSource parses a provider URL such as github.com/org or
+// https://bitbucket.org/workspace/. The scheme is optional.
+func ParseSource(raw string) (*Source, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return nil, fmt.Errorf("source cannot be empty")
+	}
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source %q: %w", raw, err)
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return nil, fmt.Errorf("invalid source %q: only http(s) URLs are supported", raw)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host == "" || !strings.Contains(host, ".") {
+		return nil, fmt.Errorf("invalid source %q: expected <host>/<owner>, e.g. github.com/kubernetes", raw)
+	}
+
+	path := strings.Trim(parsed.Path, "/")
+	if path == "" {
+		return nil, fmt.Errorf("invalid source %q: missing owner after %s", raw, host)
+	}
+
+	return &Source{Host: host, Path: path}, nil
+}
+
+// Segments returns the path segments of the source
+func (s *Source) Segments() []string {
+	return strings.Split(s.Path, "/")
+}
+
+// Owner returns the first path segment, the user, organization or workspace
+// on most providers
+func (s *Source) Owner() string {
+	return s.Segments()[0]
+}
+
+// String returns the source as host/path
+func (s *Source) String() string {
+	return s.Host + "/" + s.Path
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		raw       string
+		wantHost  string
+		wantPath  string
+		wantOwner string
+		wantErr   bool
+	}{
+		{raw: "github.com/kubernetes", wantHost: "github.com", wantPath: "kubernetes", wantOwner: "kubernetes"},
+		{raw: "https://GitHub.com/octocat/", wantHost: "github.com", wantPath: "octocat", wantOwner: "octocat"},
+		{raw: "bitbucket.org/workspace", wantHost: "bitbucket.org", wantPath: "workspace", wantOwner: "workspace"},
+		{raw: "gitlab.example.com:8443/group/subgroup", wantHost: "gitlab.example.com", wantPath: "group/subgroup", wantOwner: "group"},
+		{raw: "", wantErr: true},
+		{raw: "github.com", wantErr: true},
+		{raw: "octocat", wantErr: true},
+		{raw: "ssh://git@github.com/octocat", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			source, err := ParseSource(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHost, source.Host)
+			assert.Equal(t, tt.wantPath, source.Path)
+			assert.Equal(t, tt.wantOwner, source.Owner())
+		})
+	}
+}
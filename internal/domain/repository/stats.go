@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"sort"
+	"time"
+)
+
+// LanguageStats aggregates the repositories using a primary language
+type LanguageStats struct {
+	Language     string `json:"language"`
+	Repositories int    `json:"repositories"`
+	Size         int64  `json:"size"`
+}
+
+// Stats summarizes a set of repositories, e.g. to estimate a clone run
+type Stats struct {
+	TotalRepositories int             `json:"total_repositories"`
+	TotalSize         int64           `json:"total_size"`
+	Forks             int             `json:"forks"`
+	Archived          int             `json:"archived"`
+	Languages         []LanguageStats `json:"languages"`
+	RecentlyPushed    []*Repository   `json:"recently_pushed"`
+}
+
+// UnknownLanguage groups repositories without a detected language
+const UnknownLanguage = "Unknown"
+
+// ComputeStats aggregates repositories, keeping the `recent` most recently pushed ones
+func ComputeStats(repos []*Repository, recent int) *Stats {
+	stats := &Stats{TotalRepositories: len(repos)}
+
+	byLanguage := make(map[string]*LanguageStats)
+	for _, repo := range repos {
+		stats.TotalSize += repo.Size
+		if repo.IsFork {
+			stats.Forks++
+		}
+		if repo.Archived {
+			stats.Archived++
+		}
+
+		language := repo.Language
+		if language == "" {
+			language = UnknownLanguage
+		}
+		entry, ok := byLanguage[language]
+		if !ok {
+			entry = &LanguageStats{Language: language}
+			byLanguage[language] = entry
+		}
+		entry.Repositories++
+		entry.Size += repo.Size
+	}
+
+	for _, entry := range byLanguage {
+		stats.Languages = append(stats.Languages, *entry)
+	}
+	sort.Slice(stats.Languages, func(i, j int) bool {
+		if stats.Languages[i].Repositories != stats.Languages[j].Repositories {
+			return stats.Languages[i].Repositories > stats.Languages[j].Repositories
+		}
+		return stats.Languages[i].Language < stats.Languages[j].Language
+	})
+
+	pushed := make([]*Repository, len(repos))
+	copy(pushed, repos)
+	sort.SliceStable(pushed, func(i, j int) bool {
+		return lastActivity(pushed[i]).After(lastActivity(pushed[j]))
+	})
+	if recent >= 0 && len(pushed) > recent {
+		pushed = pushed[:recent]
+	}
+	stats.RecentlyPushed = pushed
+
+	return stats
+}
+
+// lastActivity returns the last push time, falling back to the last update
+func lastActivity(repo *Repository) time.Time {
+	if !repo.PushedAt.IsZero() {
+		return repo.PushedAt
+	}
+	return repo.UpdatedAt
+}
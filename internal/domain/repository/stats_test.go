@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStats(t *testing.T) {
+	now := time.Now()
+	newRepo := func(name, language string, size int64, fork, archived bool, pushed time.Time) *Repository {
+		repo, err := NewRepository(RepositoryID(len(name)), name,
+			fmt.Sprintf("https://github.com/owner/%s.git", name), "owner", fork, size, "main")
+		require.NoError(t, err)
+		repo.Language = language
+		repo.Archived = archived
+		repo.PushedAt = pushed
+		return repo
+	}
+
+	repos := []*Repository{
+		newRepo("api", "Go", 300, false, false, now.Add(-time.Hour)),
+		newRepo("cli", "Go", 200, true, false, now.Add(-48*time.Hour)),
+		newRepo("web", "TypeScript", 500, false, true, now),
+		newRepo("docs", "", 10, false, false, now.Add(-24*time.Hour)),
+	}
+
+	stats := ComputeStats(repos, 2)
+
+	assert.Equal(t, 4, stats.TotalRepositories)
+	assert.Equal(t, int64(1010), stats.TotalSize)
+	assert.Equal(t, 1, stats.Forks)
+	assert.Equal(t, 1, stats.Archived)
+	assert.Equal(t, []LanguageStats{
+		{Language: "Go", Repositories: 2, Size: 500},
+		{Language: "TypeScript", Repositories: 1, Size: 500},
+		{Language: UnknownLanguage, Repositories: 1, Size: 10},
+	}, stats.Languages)
+
+	require.Len(t, stats.RecentlyPushed, 2)
+	assert.Equal(t, "web", stats.RecentlyPushed[0].Name)
+	assert.Equal(t, "api", stats.RecentlyPushed[1].Name)
+}
+
+func TestComputeStats_Empty(t *testing.T) {
+	stats := ComputeStats(nil, 5)
+
+	assert.Equal(t, 0, stats.TotalRepositories)
+	assert.Empty(t, stats.Languages)
+	assert.Empty(t, stats.RecentlyPushed)
+}
@@ -1,6 +1,10 @@
 package repository
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // RepositoryType represents the type of repository owner
 type RepositoryType string
@@ -13,6 +17,9 @@ const (
 	// Bitbucket repository types
 	RepositoryTypeBitbucketUser      RepositoryType = "bitbucket_users"
 	RepositoryTypeBitbucketWorkspace RepositoryType = "bitbucket_workspaces"
+
+	// Bitbucket Server / Data Center repository types
+	RepositoryTypeBitbucketProject RepositoryType = "bitbucket_projects"
 )
 
 // IsValid checks if the repository type is valid
@@ -20,7 +27,8 @@ func (rt RepositoryType) IsValid() bool {
 	return rt == RepositoryTypeUser ||
 		rt == RepositoryTypeOrganization ||
 		rt == RepositoryTypeBitbucketUser ||
-		rt == RepositoryTypeBitbucketWorkspace
+		rt == RepositoryTypeBitbucketWorkspace ||
+		rt == RepositoryTypeBitbucketProject
 }
 
 // IsGitHubType checks if the repository type is for GitHub
@@ -33,6 +41,12 @@ func (rt RepositoryType) IsBitbucketType() bool {
 	return rt == RepositoryTypeBitbucketUser || rt == RepositoryTypeBitbucketWorkspace
 }
 
+// IsBitbucketServerType checks if the repository type is for a self-hosted
+// Bitbucket Server / Data Center instance
+func (rt RepositoryType) IsBitbucketServerType() bool {
+	return rt == RepositoryTypeBitbucketProject
+}
+
 // String returns the string representation of repository type
 func (rt RepositoryType) String() string {
 	return string(rt)
@@ -41,11 +55,28 @@ func (rt RepositoryType) String() string {
 // RepositoryFilter represents filtering options for repositories
 type RepositoryFilter struct {
 	IncludeForks bool
+	OnlyForks    bool // Keep only forks, regardless of IncludeForks
 	MinSize      int64
 	MaxSize      int64
 	Languages    []string
 	UpdatedAfter time.Time
 	OnlyPublic   bool
+
+	// MinPermission keeps repositories the listing user or team can access at
+	// this level or above; empty disables the check
+	MinPermission Permission
+
+	// Visibility keeps only repositories with this visibility; empty keeps all
+	Visibility Visibility
+
+	ExcludeTemplates bool // Skip template repositories
+	ExcludeDotRepos  bool // Skip .github-style dot-repos and .wiki repositories
+
+	// Ignore excludes repositories matching its name patterns; nil ignores none
+	Ignore *IgnoreList
+
+	// Expression keeps only repositories it matches; nil keeps all
+	Expression *FilterExpression
 }
 
 // NewRepositoryFilter creates a new repository filter with defaults
@@ -62,7 +93,23 @@ func NewRepositoryFilter() *RepositoryFilter {
 // ShouldInclude checks if a repository should be included based on the filter
 func (rf *RepositoryFilter) ShouldInclude(repo *Repository) bool {
 	// Check fork filter
-	if !rf.IncludeForks && repo.IsFork {
+	if repo.IsFork && !rf.IncludeForks && !rf.OnlyForks {
+		return false
+	}
+	if rf.OnlyForks && !repo.IsFork {
+		return false
+	}
+
+	// Check boilerplate repositories
+	if rf.ExcludeTemplates && repo.IsTemplate {
+		return false
+	}
+	if rf.ExcludeDotRepos && repo.IsDotRepository() {
+		return false
+	}
+
+	// Check ignore list
+	if rf.Ignore.Matches(repo) {
 		return false
 	}
 
@@ -78,7 +125,7 @@ func (rf *RepositoryFilter) ShouldInclude(repo *Repository) bool {
 	if len(rf.Languages) > 0 {
 		languageMatch := false
 		for _, lang := range rf.Languages {
-			if repo.Language == lang {
+			if strings.EqualFold(repo.Language, lang) {
 				languageMatch = true
 				break
 			}
@@ -98,15 +145,42 @@ func (rf *RepositoryFilter) ShouldInclude(repo *Repository) bool {
 		return false
 	}
 
+	// Check visibility; an unreported visibility never matches
+	if rf.Visibility != "" && repo.Visibility != rf.Visibility {
+		return false
+	}
+
+	// Check access level
+	if rf.MinPermission != "" && !repo.Permission.AtLeast(rf.MinPermission) {
+		return false
+	}
+
+	// Check filter expression
+	if rf.Expression != nil && !rf.Expression.Matches(repo) {
+		return false
+	}
+
 	return true
 }
 
 // PaginationOptions represents pagination settings
 type PaginationOptions struct {
-	Page    int
-	PerPage int
+	Page     int
+	PerPage  int
+	MaxPages int    // Stop after this many pages, 0 fetches every page
+	Sort     string // Provider-side ordering (SortByName, SortByUpdated), empty keeps the provider default
 }
 
+// Provider-side orderings supported by PaginationOptions.Sort
+const (
+	SortByName    = "name"    // Ascending by name
+	SortByUpdated = "updated" // Most recently updated first
+)
+
+// PageHandler receives the filtered repositories of each fetched page.
+// Returning ErrStopPaging ends the listing early without an error.
+type PageHandler func(repos []*Repository) error
+
 // NewPaginationOptions creates pagination options with defaults
 func NewPaginationOptions() *PaginationOptions {
 	return &PaginationOptions{
@@ -123,9 +197,22 @@ func (po *PaginationOptions) Validate() error {
 	if po.PerPage < 1 || po.PerPage > 100 {
 		po.PerPage = 100
 	}
+	if po.MaxPages < 0 {
+		return fmt.Errorf("max pages cannot be negative")
+	}
+	switch po.Sort {
+	case "", SortByName, SortByUpdated:
+	default:
+		return fmt.Errorf("unsupported sort %q", po.Sort)
+	}
 	return nil
 }
 
+// HasMorePages reports whether another page may be fetched after page
+func (po *PaginationOptions) HasMorePages(page int) bool {
+	return po.MaxPages == 0 || page-po.Page+1 < po.MaxPages
+}
+
 // GetOffset calculates the offset for pagination
 func (po *PaginationOptions) GetOffset() int {
 	return (po.Page - 1) * po.PerPage
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Visibility is who can see a repository. Internal repositories are visible
+// to every member of a GitHub enterprise.
+type Visibility string
+
+const (
+	VisibilityPublic   Visibility = "public"
+	VisibilityPrivate  Visibility = "private"
+	VisibilityInternal Visibility = "internal"
+)
+
+// ParseVisibility validates a user supplied visibility
+func ParseVisibility(value string) (Visibility, error) {
+	visibility := Visibility(strings.ToLower(strings.TrimSpace(value)))
+	switch visibility {
+	case VisibilityPublic, VisibilityPrivate, VisibilityInternal:
+		return visibility, nil
+	default:
+		return "", fmt.Errorf("invalid visibility %q (supported: public, private, internal)", value)
+	}
+}
+
+// VisibilityFromPrivate maps a provider's private flag to a visibility
+func VisibilityFromPrivate(private bool) Visibility {
+	if private {
+		return VisibilityPrivate
+	}
+	return VisibilityPublic
+}
+
+// String returns the string representation of the visibility
+func (v Visibility) String() string {
+	return string(v)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVisibility(t *testing.T) {
+	visibility, err := ParseVisibility("Private")
+	require.NoError(t, err)
+	assert.Equal(t, VisibilityPrivate, visibility)
+
+	_, err = ParseVisibility("secret")
+	assert.Error(t, err)
+}
+
+func TestRepositoryFilter_Visibility(t *testing.T) {
+	repo, err := NewRepository(1, "repo", "https://github.com/org/repo.git", "org", false, 0, "main")
+	require.NoError(t, err)
+
+	filter := NewRepositoryFilter()
+	filter.Visibility = VisibilityPrivate
+
+	assert.False(t, filter.ShouldInclude(repo), "unreported visibility is excluded")
+
+	repo.Visibility = VisibilityPrivate
+	assert.True(t, filter.ShouldInclude(repo))
+
+	repo.Visibility = VisibilityInternal
+	assert.False(t, filter.ShouldInclude(repo))
+}
@@ -0,0 +1,195 @@
+// Package schedule parses cron expressions for recurring runs.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month,
+// month and day of week. Each field is a bit set of the values it matches.
+type Cron struct {
+	expr   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDOM bool // Day of month was "*"
+	anyDOW bool // Day of week was "*"
+}
+
+// field describes the valid range of a cron field
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7}, // 0 and 7 are Sunday
+}
+
+// descriptors are the supported @-shorthands
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a standard five-field cron expression. Fields accept *,
+// values, ranges (1-5), lists (1,3,5) and steps (*/15, 0-30/10); the
+// @hourly, @daily, @weekly, @monthly and @yearly shorthands are supported.
+func ParseCron(expr string) (*Cron, error) {
+	spec := strings.TrimSpace(expr)
+	if descriptor, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(parts))
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+
+	// Sunday may be written as 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return &Cron{
+		expr:   expr,
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		anyDOM: strings.HasPrefix(parts[2], "*"),
+		anyDOW: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseField parses one comma separated cron field into a bit set
+func parseField(value string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(value, ",") {
+		rangePart, step := item, 1
+		if slash := strings.Index(item, "/"); slash >= 0 {
+			rangePart = item[:slash]
+			n, err := strconv.Atoi(item[slash+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, item)
+			}
+			step = n
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			if high, err = parseValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range in %s field %q", f.name, item)
+			}
+		default:
+			n, err := parseValue(rangePart, f)
+			if err != nil {
+				return 0, err
+			}
+			low = n
+			if step == 1 {
+				high = n
+			}
+		}
+
+		for n := low; n <= high; n += step {
+			set |= 1 << uint(n)
+		}
+	}
+	return set, nil
+}
+
+// parseValue parses a single numeric value within the field range
+func parseValue(value string, f field) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s must be between %d and %d, got %q", f.name, f.min, f.max, value)
+	}
+	return n, nil
+}
+
+// String returns the expression as written
+func (c *Cron) String() string {
+	return c.expr
+}
+
+// Next returns the first matching minute strictly after t, in t's location.
+// It returns the zero time when nothing matches within five years, e.g. for
+// "0 0 30 2 *".
+func (c *Cron) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(5, 0, 0)
+
+	for next.Before(limit) {
+		if c.month&(1<<uint(next.Month())) == 0 {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if c.hour&(1<<uint(next.Hour())) == 0 {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if c.minute&(1<<uint(next.Minute())) == 0 {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+// matchesDay applies the cron rule that, when both day fields are
+// restricted, a day matching either of them matches
+func (c *Cron) matchesDay(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case c.anyDOM && c.anyDOW:
+		return true
+	case c.anyDOM:
+		return dowMatch
+	case c.anyDOW:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
@@ -0,0 +1,46 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "0 2 * * *", want: time.Date(2024, 5, 16, 2, 0, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2024, 5, 15, 10, 45, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2024, 5, 15, 11, 0, 0, 0, time.UTC)},
+		{expr: "30 10 * * *", want: time.Date(2024, 5, 16, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 9 * * 1-5", want: time.Date(2024, 5, 16, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 * *", want: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matches
+		{expr: "0 0 20 * 5", want: time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cron.Next(from))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@often"} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -61,6 +61,7 @@ const (
 	ContextKeyRequestID ContextKey = "request_id"
 	ContextKeyUserID    ContextKey = "user_id"
 	ContextKeyOperation ContextKey = "operation"
+	ContextKeyLogger    ContextKey = "logger"
 )
 
 // GetRequestID extracts request ID from context
@@ -89,6 +90,21 @@ func WithOperation(ctx context.Context, operation string) context.Context {
 	return context.WithValue(ctx, ContextKeyOperation, operation)
 }
 
+// GetLogger extracts the logger of an operation from context, falling back
+// to logger without one
+func GetLogger(ctx context.Context, logger Logger) Logger {
+	if contextLogger, ok := ctx.Value(ContextKeyLogger).(Logger); ok {
+		return contextLogger
+	}
+	return logger
+}
+
+// WithLogger adds a logger to context, so the layers an operation runs
+// through log with its fields, e.g. the job ID of a clone
+func WithLogger(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, ContextKeyLogger, logger)
+}
+
 // Result represents a generic operation result
 type Result[T any] struct {
 	Value T
@@ -0,0 +1,74 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/network"
+)
+
+const (
+	// maxRateLimitRetries bounds the retries of a request answered with 429
+	maxRateLimitRetries = 3
+
+	// defaultRateLimitWait is the first backoff when Bitbucket does not send
+	// Retry-After; it doubles with every retry
+	defaultRateLimitWait = 10 * time.Second
+
+	// maxRateLimitWait is the longest backoff waited out; longer ones fail
+	// the request instead
+	maxRateLimitWait = 5 * time.Minute
+)
+
+// send waits for the rate limiter, executes a request without body and
+// updates the rate limiter from the response. Responses with status 429 are
+// retried after the Retry-After time, or an exponential backoff without it.
+func (c *BitbucketClient) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("rate limiter error: %w", err)
+			}
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+		c.updateRateLimitFromResponse(resp)
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+		wait := rateLimitWait(resp, attempt)
+		if wait > maxRateLimitWait {
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+
+		c.logger.Warn("Bitbucket rate limit hit, backing off",
+			shared.StringField("url", req.URL.String()),
+			shared.DurationField("retry_after", wait),
+			shared.IntField("attempt", attempt+1))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// rateLimitWait returns how long to wait before retrying a 429 response
+func rateLimitWait(resp *http.Response, attempt int) time.Duration {
+	if wait, ok := network.ParseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		return wait
+	}
+	return defaultRateLimitWait << attempt
+}
@@ -0,0 +1,126 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// newCloudAPI serves pages of one repository of workspace acme, answering
+// the first throttled requests with 429 and the given Retry-After
+func newCloudAPI(t *testing.T, pages int, throttled int32, retryAfter string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= throttled {
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		resp := BitbucketPageResponse{Page: page, Pagelen: 1, Values: []BitbucketAPIResponse{{
+			UUID:     fmt.Sprintf("{%d}", page),
+			Name:     fmt.Sprintf("repo-%d", page),
+			FullName: fmt.Sprintf("acme/repo-%d", page),
+			Owner:    OwnerInfo{Username: "acme"},
+			Links: LinksInfo{Clone: []CloneLink{
+				{Name: "https", Href: fmt.Sprintf("https://bitbucket.org/acme/repo-%d.git", page)},
+			}},
+		}}}
+		if page < pages {
+			resp.Next = fmt.Sprintf("%s?page=%d", r.URL.Path, page+1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newTestCloudClient(baseURL string) *BitbucketClient {
+	return NewBitbucketClient(&BitbucketClientConfig{
+		BaseURL: baseURL,
+		Logger:  logging.NewNoOpLogger(),
+	})
+}
+
+func TestBitbucketClient_RateLimitBackoff(t *testing.T) {
+	server, requests := newCloudAPI(t, 2, 2, "0")
+
+	repos, err := newTestCloudClient(server.URL).FetchRepositories(context.Background(), "acme",
+		repository.RepositoryTypeBitbucketWorkspace, nil, &repository.PaginationOptions{Page: 1, PerPage: 1})
+	require.NoError(t, err)
+	assert.Len(t, repos, 2)
+	assert.Equal(t, int32(4), requests.Load(), "two throttled requests are retried")
+}
+
+func TestBitbucketClient_RateLimitTooLong(t *testing.T) {
+	server, requests := newCloudAPI(t, 1, 1, "3600")
+
+	_, err := newTestCloudClient(server.URL).FetchRepositories(context.Background(), "acme",
+		repository.RepositoryTypeBitbucketWorkspace, nil, nil)
+	assert.ErrorIs(t, err, repository.ErrRateLimitExceeded)
+	assert.Equal(t, int32(1), requests.Load(), "backoffs above the maximum are not waited out")
+}
+
+func TestBitbucketClient_CancelBetweenPages(t *testing.T) {
+	server, requests := newCloudAPI(t, 5, 0, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var pages int
+	err := newTestCloudClient(server.URL).FetchRepositoryPages(ctx, "acme",
+		repository.RepositoryTypeBitbucketWorkspace, nil, &repository.PaginationOptions{Page: 1, PerPage: 1},
+		func(page []*repository.Repository) error {
+			pages++
+			if pages == 2 {
+				cancel()
+			}
+			return nil
+		})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, int32(2), requests.Load(), "no page is requested after cancellation")
+}
+
+func TestBitbucketClient_PartialResultOnCancel(t *testing.T) {
+	var requests atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) > 1 {
+			// Cancelled while the client backs off from the rate limit
+			cancel()
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(BitbucketPageResponse{Next: "more", Values: []BitbucketAPIResponse{{
+			UUID: "{1}", Name: "app", FullName: "acme/app", Owner: OwnerInfo{Username: "acme"},
+			Links: LinksInfo{Clone: []CloneLink{{Name: "https", Href: "https://bitbucket.org/acme/app.git"}}},
+		}}})
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	repos, err := newTestCloudClient(server.URL).FetchRepositories(ctx, "acme",
+		repository.RepositoryTypeBitbucketWorkspace, nil, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, repos, 1, "the pages fetched before are returned")
+	assert.Equal(t, "acme/app", repos[0].GetFullName())
+	assert.Less(t, time.Since(start), 10*time.Second, "the backoff stops on cancellation")
+}
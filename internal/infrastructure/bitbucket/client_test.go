@@ -121,6 +121,7 @@ func TestBitbucketClient_ConvertForkRepository(t *testing.T) {
 	assert.Equal(t, int64(2048), repo.Size)
 	assert.Equal(t, "develop", repo.DefaultBranch)
 	assert.True(t, repo.IsFork) // This should be detected as a fork
+	assert.Equal(t, "https://bitbucket.org/originaluser/original-repo.git", repo.UpstreamURL)
 }
 
 func TestRepositoryType_IsBitbucketType(t *testing.T) {
@@ -22,6 +22,9 @@ type RateLimiter interface {
 	UpdateResetTime(resetTime time.Time)
 }
 
+// DefaultRateLimit is Bitbucket Cloud's hourly API limit for authenticated requests
+const DefaultRateLimit = 1000
+
 // TokenBucketRateLimiter implements a token bucket rate limiter for Bitbucket
 type TokenBucketRateLimiter struct {
 	mu         sync.Mutex
@@ -38,7 +41,7 @@ type TokenBucketRateLimiter struct {
 func NewTokenBucketRateLimiter(limit int) *TokenBucketRateLimiter {
 	now := time.Now()
 	if limit == 0 {
-		limit = 1000 // Bitbucket's default
+		limit = DefaultRateLimit
 	}
 
 	return &TokenBucketRateLimiter{
@@ -0,0 +1,363 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// ServerRepository represents a repository returned by the Bitbucket Server
+// (Data Center) REST API 1.0
+type ServerRepository struct {
+	ID          int64             `json:"id"`
+	Slug        string            `json:"slug"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Archived    bool              `json:"archived"`
+	Public      bool              `json:"public"`
+	Project     ServerProject     `json:"project"`
+	Origin      *ServerRepository `json:"origin"`
+	Links       ServerLinks       `json:"links"`
+}
+
+// ServerProject represents the project owning a Bitbucket Server repository
+type ServerProject struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// ServerLinks represents Bitbucket Server repository links
+type ServerLinks struct {
+	Clone []CloneLink `json:"clone"`
+}
+
+// ServerPageResponse represents a paged Bitbucket Server API response
+type ServerPageResponse struct {
+	Values        []ServerRepository `json:"values"`
+	Size          int                `json:"size"`
+	Limit         int                `json:"limit"`
+	Start         int                `json:"start"`
+	IsLastPage    bool               `json:"isLastPage"`
+	NextPageStart int                `json:"nextPageStart"`
+}
+
+// BitbucketServerClient handles interactions with a self-hosted Bitbucket
+// Server / Data Center instance
+type BitbucketServerClient struct {
+	httpClient  *http.Client
+	baseURL     string
+	token       string
+	userAgent   string
+	rateLimiter RateLimiter
+	logger      shared.Logger
+}
+
+// BitbucketServerClientConfig holds configuration for Bitbucket Server client
+type BitbucketServerClientConfig struct {
+	BaseURL     string // Instance URL, e.g. https://bitbucket.example.com
+	Token       string // HTTP access token (personal, project or repository)
+	UserAgent   string
+	Timeout     time.Duration
+	Transport   http.RoundTripper // Optional, e.g. with a proxy or extra CAs; nil uses http.DefaultTransport
+	RateLimiter RateLimiter
+	Logger      shared.Logger
+}
+
+// NewBitbucketServerClient creates a new Bitbucket Server API client
+func NewBitbucketServerClient(config *BitbucketServerClientConfig) (*BitbucketServerClient, error) {
+	baseURL, err := NormalizeServerURL(config.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = "repocloner/1.0"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &BitbucketServerClient{
+		httpClient: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: config.Transport,
+		},
+		baseURL:     baseURL,
+		token:       config.Token,
+		userAgent:   config.UserAgent,
+		rateLimiter: config.RateLimiter,
+		logger:      config.Logger,
+	}, nil
+}
+
+// NormalizeServerURL validates a Bitbucket Server base URL and removes any
+// trailing slash
+func NormalizeServerURL(rawURL string) (string, error) {
+	if rawURL == "" {
+		return "", fmt.Errorf("bitbucket server URL cannot be empty")
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return "", fmt.Errorf("invalid bitbucket server URL %q: must be an http(s) URL", rawURL)
+	}
+
+	return strings.TrimRight(rawURL, "/"), nil
+}
+
+// Host returns the host name of the Bitbucket Server instance
+func (c *BitbucketServerClient) Host() string {
+	parsed, err := url.Parse(c.baseURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
+
+// ValidateCredentials checks that the instance is reachable and accepts the
+// configured access token
+func (c *BitbucketServerClient) ValidateCredentials(ctx context.Context) error {
+	endpoint := fmt.Sprintf("%s/rest/api/1.0/projects?limit=1", c.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: check your Bitbucket Server access token", repository.ErrAuthenticationFailed)
+	default:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
+// FetchRepositories fetches repositories of a project
+func (c *BitbucketServerClient) FetchRepositories(
+	ctx context.Context,
+	projectKey string,
+	repoType repository.RepositoryType,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+) ([]*repository.Repository, error) {
+	var allRepos []*repository.Repository
+	err := c.FetchRepositoryPages(ctx, projectKey, repoType, filter, pagination, func(page []*repository.Repository) error {
+		allRepos = append(allRepos, page...)
+		return nil
+	})
+	if err != nil {
+		return allRepos, err
+	}
+
+	c.logger.Info("Successfully fetched repositories",
+		shared.StringField("project", projectKey),
+		shared.IntField("total", len(allRepos)))
+
+	return allRepos, nil
+}
+
+// FetchRepositoryPages fetches the repositories of a project page by page,
+// following nextPageStart until the server reports the last page
+func (c *BitbucketServerClient) FetchRepositoryPages(
+	ctx context.Context,
+	projectKey string,
+	repoType repository.RepositoryType,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	onPage repository.PageHandler,
+) error {
+	if repoType != repository.RepositoryTypeBitbucketProject {
+		return fmt.Errorf("unsupported repository type: %s", repoType)
+	}
+	if pagination == nil {
+		pagination = repository.NewPaginationOptions()
+	}
+
+	c.logger.Info("Fetching repositories from Bitbucket Server",
+		shared.StringField("base_url", c.baseURL),
+		shared.StringField("project", projectKey),
+		shared.IntField("page", pagination.Page),
+		shared.IntField("per_page", pagination.PerPage))
+
+	page := pagination.Page
+	if page == 0 {
+		page = 1
+	}
+	start := (page - 1) * pagination.PerPage
+
+	for {
+		pageResp, err := c.fetchRepositoryPage(ctx, projectKey, start, pagination.PerPage)
+		if err != nil {
+			return fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+
+		included := make([]*repository.Repository, 0, len(pageResp.Values))
+		for _, apiRepo := range pageResp.Values {
+			repo, err := c.convertToDomainRepository(&apiRepo)
+			if err != nil {
+				c.logger.Warn("Failed to convert repository",
+					shared.StringField("repository", apiRepo.Project.Key+"/"+apiRepo.Slug),
+					shared.ErrorField(err))
+				continue
+			}
+			if filter == nil || filter.ShouldInclude(repo) {
+				included = append(included, repo)
+			}
+		}
+
+		if err := onPage(included); err != nil {
+			if errors.Is(err, repository.ErrStopPaging) {
+				return nil
+			}
+			return err
+		}
+
+		if pageResp.IsLastPage || pageResp.NextPageStart <= start || !pagination.HasMorePages(page) {
+			return nil
+		}
+		start = pageResp.NextPageStart
+		page++
+
+		// Check context cancellation
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// fetchRepositoryPage fetches a single page of project repositories
+func (c *BitbucketServerClient) fetchRepositoryPage(
+	ctx context.Context,
+	projectKey string,
+	start, limit int,
+) (*ServerPageResponse, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos?start=%d&limit=%d",
+		c.baseURL, url.PathEscape(projectKey), start, limit)
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limiter error: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	c.logger.Debug("Making Bitbucket Server API request",
+		shared.StringField("url", endpoint),
+		shared.StringField("has_auth", fmt.Sprintf("%t", c.token != "")))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Error("Bitbucket Server API request failed",
+			shared.IntField("status_code", resp.StatusCode),
+			shared.StringField("response_body", string(body)),
+			shared.StringField("url", endpoint))
+
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, fmt.Errorf("%w: check your Bitbucket Server access token", repository.ErrAuthenticationFailed)
+		case http.StatusNotFound:
+			return nil, fmt.Errorf("project %s not found or not accessible", projectKey)
+		case http.StatusTooManyRequests:
+			return nil, repository.ErrRateLimitExceeded
+		}
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var pageResp ServerPageResponse
+	if err := json.Unmarshal(body, &pageResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &pageResp, nil
+}
+
+// convertToDomainRepository converts a Bitbucket Server repository to a
+// domain repository. The repository slug is used as name so it is safe to use
+// as a directory, and the project key as owner.
+func (c *BitbucketServerClient) convertToDomainRepository(apiRepo *ServerRepository) (*repository.Repository, error) {
+	cloneURL := apiRepo.cloneURL()
+
+	// The listing does not include the default branch; an empty branch lets
+	// git check out the remote HEAD
+	repo, err := repository.NewRepository(
+		repository.RepositoryID(apiRepo.ID),
+		apiRepo.Slug,
+		cloneURL,
+		apiRepo.Project.Key,
+		apiRepo.Origin != nil,
+		0,
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.Description = apiRepo.Description
+	repo.Archived = apiRepo.Archived
+	repo.Visibility = repository.VisibilityFromPrivate(!apiRepo.Public)
+	if apiRepo.Origin != nil {
+		repo.UpstreamURL = apiRepo.Origin.cloneURL()
+	}
+	return repo, nil
+}
+
+// cloneURL returns the HTTPS clone link of a repository, or its first link
+func (r *ServerRepository) cloneURL() string {
+	// Bitbucket Server names its HTTPS clone link "http"
+	var cloneURL string
+	for _, link := range r.Links.Clone {
+		if link.Name == "http" || link.Name == "https" {
+			cloneURL = link.Href
+			break
+		}
+	}
+	if cloneURL == "" && len(r.Links.Clone) > 0 {
+		cloneURL = r.Links.Clone[0].Href
+	}
+
+	// Clone links embed the requesting user; credentials are supplied by the
+	// credential helper instead
+	return stripUserInfo(cloneURL)
+}
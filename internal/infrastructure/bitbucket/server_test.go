@@ -0,0 +1,187 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// newServerAPI serves total repositories of project PROJ using Bitbucket
+// Server start/limit paging
+func newServerAPI(t *testing.T, total int, requested *[]string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "/rest/api/1.0/projects/PROJ/repos", r.URL.Path)
+		*requested = append(*requested, r.URL.RawQuery)
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		resp := ServerPageResponse{Start: start, Limit: limit, IsLastPage: start+limit >= total}
+		for i := start; i < total && i < start+limit; i++ {
+			slug := fmt.Sprintf("repo-%d", i)
+			resp.Values = append(resp.Values, ServerRepository{
+				ID:      int64(i + 1),
+				Slug:    slug,
+				Name:    fmt.Sprintf("Repo %d", i),
+				Project: ServerProject{Key: "PROJ"},
+				Links: ServerLinks{Clone: []CloneLink{
+					{Name: "ssh", Href: "ssh://git@bitbucket.example.com:7999/proj/" + slug + ".git"},
+					{Name: "http", Href: "https://admin@bitbucket.example.com/scm/proj/" + slug + ".git"},
+				}},
+			})
+		}
+		resp.Size = len(resp.Values)
+		if !resp.IsLastPage {
+			resp.NextPageStart = start + limit
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestBitbucketServerClient_FetchRepositoryPages(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination *repository.PaginationOptions
+		wantPages  int
+		wantRepos  int
+		wantQuery  []string
+	}{
+		{
+			name:       "follows nextPageStart until the last page",
+			pagination: &repository.PaginationOptions{Page: 1, PerPage: 2},
+			wantPages:  3,
+			wantRepos:  5,
+			wantQuery:  []string{"start=0&limit=2", "start=2&limit=2", "start=4&limit=2"},
+		},
+		{
+			name:       "max pages from a start page",
+			pagination: &repository.PaginationOptions{Page: 2, PerPage: 2, MaxPages: 1},
+			wantPages:  1,
+			wantRepos:  2,
+			wantQuery:  []string{"start=2&limit=2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []string
+			server := newServerAPI(t, 5, &requested)
+			defer server.Close()
+
+			client, err := NewBitbucketServerClient(&BitbucketServerClientConfig{
+				BaseURL: server.URL + "/",
+				Token:   "secret",
+				Logger:  logging.NewNoOpLogger(),
+			})
+			require.NoError(t, err)
+
+			var pages int
+			var repos []*repository.Repository
+			err = client.FetchRepositoryPages(context.Background(), "PROJ", repository.RepositoryTypeBitbucketProject,
+				nil, tt.pagination, func(page []*repository.Repository) error {
+					pages++
+					repos = append(repos, page...)
+					return nil
+				})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantPages, pages)
+			assert.Len(t, repos, tt.wantRepos)
+			assert.Equal(t, tt.wantQuery, requested)
+
+			require.NotEmpty(t, repos)
+			assert.Equal(t, "PROJ", repos[0].Owner)
+			assert.Regexp(t, `^https://bitbucket\.example\.com/scm/proj/repo-\d\.git$`, repos[0].CloneURL,
+				"the http clone link is preferred and stripped of user info")
+		})
+	}
+}
+
+func TestBitbucketServerClient_Unauthorized(t *testing.T) {
+	var requested []string
+	server := newServerAPI(t, 1, &requested)
+	defer server.Close()
+
+	client, err := NewBitbucketServerClient(&BitbucketServerClientConfig{
+		BaseURL: server.URL,
+		Token:   "wrong",
+		Logger:  logging.NewNoOpLogger(),
+	})
+	require.NoError(t, err)
+
+	_, err = client.FetchRepositories(context.Background(), "PROJ", repository.RepositoryTypeBitbucketProject, nil, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, repository.ErrAuthenticationFailed))
+}
+
+func TestNormalizeServerURL(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "https://bitbucket.example.com/", want: "https://bitbucket.example.com"},
+		{input: "https://example.com/bitbucket", want: "https://example.com/bitbucket"},
+		{input: "", wantErr: true},
+		{input: "bitbucket.example.com", wantErr: true},
+		{input: "ssh://git@bitbucket.example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeServerURL(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBitbucketServerClient_ConvertForkRepository(t *testing.T) {
+	client, err := NewBitbucketServerClient(&BitbucketServerClientConfig{
+		BaseURL: "https://bitbucket.example.com",
+		Logger:  logging.NewNoOpLogger(),
+	})
+	require.NoError(t, err)
+
+	repo, err := client.convertToDomainRepository(&ServerRepository{
+		ID:      2,
+		Slug:    "tool",
+		Project: ServerProject{Key: "~ME"},
+		Links: ServerLinks{Clone: []CloneLink{
+			{Name: "http", Href: "https://me@bitbucket.example.com/scm/~me/tool.git"},
+		}},
+		Origin: &ServerRepository{
+			Slug:    "tool",
+			Project: ServerProject{Key: "PROJ"},
+			Links: ServerLinks{Clone: []CloneLink{
+				{Name: "http", Href: "https://me@bitbucket.example.com/scm/proj/tool.git"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, repo.IsFork)
+	assert.Equal(t, "https://bitbucket.example.com/scm/proj/tool.git", repo.UpstreamURL)
+}
@@ -0,0 +1,75 @@
+package concurrency
+
+import "time"
+
+// DefaultAdaptInterval is how often an adaptive worker pool is resized
+const DefaultAdaptInterval = 5 * time.Second
+
+// adaptiveTolerance is the throughput drop attributed to noise rather than to
+// the last added worker
+const adaptiveTolerance = 0.1
+
+// AdaptiveSample is what a worker pool observed during one adjustment interval
+type AdaptiveSample struct {
+	Completed int  // Jobs that finished, successfully or not
+	Throttled int  // Clone attempts that timed out or were rate limited
+	Saturated bool // Every worker was busy with more jobs waiting
+}
+
+// AdaptiveController sizes a worker pool between bounds. It adds a worker
+// while the pool is saturated and throughput keeps increasing, removes the
+// last added worker when it did not pay off and halves the pool on timeouts
+// or rate limiting.
+type AdaptiveController struct {
+	minWorkers     int
+	maxWorkers     int
+	size           int
+	lastThroughput float64
+	grew           bool
+}
+
+// NewAdaptiveController creates a controller starting at initial workers,
+// clamped to [minWorkers, maxWorkers]
+func NewAdaptiveController(minWorkers, maxWorkers, initial int) *AdaptiveController {
+	minWorkers = max(minWorkers, 1)
+	maxWorkers = max(maxWorkers, minWorkers)
+
+	return &AdaptiveController{
+		minWorkers: minWorkers,
+		maxWorkers: maxWorkers,
+		size:       clamp(initial, minWorkers, maxWorkers),
+	}
+}
+
+// Size returns the current worker count
+func (c *AdaptiveController) Size() int {
+	return c.size
+}
+
+// Next records the sample of the last interval and returns the worker count
+// for the next one
+func (c *AdaptiveController) Next(sample AdaptiveSample, interval time.Duration) int {
+	throughput := float64(sample.Completed) / interval.Seconds()
+
+	switch {
+	case sample.Throttled > 0:
+		c.size = clamp(c.size/2, c.minWorkers, c.maxWorkers)
+		c.grew = false
+	case c.grew && throughput < c.lastThroughput*(1-adaptiveTolerance):
+		c.size = clamp(c.size-1, c.minWorkers, c.maxWorkers)
+		c.grew = false
+	case sample.Saturated && throughput >= c.lastThroughput && c.size < c.maxWorkers:
+		c.size++
+		c.grew = true
+	default:
+		c.grew = false
+	}
+
+	c.lastThroughput = throughput
+	return c.size
+}
+
+// clamp limits value to [lower, upper]
+func clamp(value, lower, upper int) int {
+	return min(max(value, lower), upper)
+}
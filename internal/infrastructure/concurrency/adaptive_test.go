@@ -0,0 +1,91 @@
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestAdaptiveController(t *testing.T) {
+	interval := time.Second
+	saturated := func(completed int) AdaptiveSample {
+		return AdaptiveSample{Completed: completed, Saturated: true}
+	}
+
+	tests := []struct {
+		name    string
+		initial int
+		samples []AdaptiveSample
+		want    []int
+	}{
+		{
+			name:    "grows while throughput increases",
+			initial: 2,
+			samples: []AdaptiveSample{saturated(2), saturated(3), saturated(4)},
+			want:    []int{3, 4, 5},
+		},
+		{
+			name:    "stops at the upper bound",
+			initial: 7,
+			samples: []AdaptiveSample{saturated(2), saturated(3), saturated(4)},
+			want:    []int{8, 8, 8},
+		},
+		{
+			name:    "holds when workers are idle",
+			initial: 4,
+			samples: []AdaptiveSample{{Completed: 5}, {Completed: 6}},
+			want:    []int{4, 4},
+		},
+		{
+			name:    "removes a worker that did not pay off",
+			initial: 4,
+			samples: []AdaptiveSample{saturated(10), saturated(5)},
+			want:    []int{5, 4},
+		},
+		{
+			name:    "halves on throttling down to the lower bound",
+			initial: 8,
+			samples: []AdaptiveSample{{Completed: 4, Throttled: 1}, {Throttled: 2}, {Throttled: 1}},
+			want:    []int{4, 2, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewAdaptiveController(2, 8, tt.initial)
+			require.Equal(t, tt.initial, controller.Size())
+
+			var got []int
+			for _, sample := range tt.samples {
+				got = append(got, controller.Next(sample, interval))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewAdaptiveController_ClampsInitial(t *testing.T) {
+	assert.Equal(t, 2, NewAdaptiveController(2, 8, 1).Size())
+	assert.Equal(t, 8, NewAdaptiveController(2, 8, 16).Size())
+	assert.Equal(t, 1, NewAdaptiveController(0, 0, 4).Size())
+}
+
+func TestWorkerPool_Adaptive(t *testing.T) {
+	pool, err := NewWorkerPool(&WorkerPoolConfig{
+		MinWorkers:     2,
+		MaxWorkers:     16,
+		InitialWorkers: 4,
+		Backend:        &blockingBackend{started: make(chan struct{}, 1)},
+		Logger:         logging.NewNoOpLogger(),
+	})
+	require.NoError(t, err)
+	defer func() { _ = pool.ForceClose() }()
+
+	stats := pool.GetStats()
+	assert.True(t, stats.Adaptive)
+	assert.Equal(t, 4, stats.TotalWorkers)
+}
@@ -0,0 +1,80 @@
+package concurrency
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Defaults of the clone retry policy
+const (
+	DefaultMaxRetries     = 3
+	DefaultRetryBaseDelay = 5 * time.Second
+	DefaultRetryMaxDelay  = 2 * time.Minute
+	DefaultRetryJitter    = 0.2
+)
+
+// BackoffPolicy decides how often and how long after a failed attempt a job
+// is retried: the delay doubles from BaseDelay with every attempt, is capped
+// at MaxDelay and shortened by a random fraction of up to Jitter, so jobs that
+// failed together do not retry in lockstep
+type BackoffPolicy struct {
+	MaxRetries int           // Retries after the first attempt, zero disables them
+	BaseDelay  time.Duration // Delay before the first retry
+	MaxDelay   time.Duration // Upper bound of any delay, zero leaves it unbounded
+	Jitter     float64       // Fraction of the delay randomized away, 0 to 1
+}
+
+// DefaultBackoffPolicy returns the retry policy of clone jobs
+func DefaultBackoffPolicy() *BackoffPolicy {
+	return &BackoffPolicy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultRetryBaseDelay,
+		MaxDelay:   DefaultRetryMaxDelay,
+		Jitter:     DefaultRetryJitter,
+	}
+}
+
+// Validate checks the bounds of the policy
+func (p *BackoffPolicy) Validate() error {
+	switch {
+	case p.MaxRetries < 0:
+		return fmt.Errorf("max retries must not be negative")
+	case p.BaseDelay < 0 || p.MaxDelay < 0:
+		return fmt.Errorf("retry delays must not be negative")
+	case p.MaxDelay > 0 && p.BaseDelay > p.MaxDelay:
+		return fmt.Errorf("retry base delay (%s) must not exceed the max delay (%s)", p.BaseDelay, p.MaxDelay)
+	case p.Jitter < 0 || p.Jitter > 1:
+		return fmt.Errorf("retry jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// Delay returns the wait before the retry following a failed attempt, with
+// attempt counted from zero
+func (p *BackoffPolicy) Delay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for range attempt {
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// String describes the policy for logs and banners
+func (p *BackoffPolicy) String() string {
+	if p.MaxRetries == 0 {
+		return "no retries"
+	}
+	return fmt.Sprintf("%d retries, %s to %s backoff, %.0f%% jitter",
+		p.MaxRetries, p.BaseDelay, p.MaxDelay, p.Jitter*100)
+}
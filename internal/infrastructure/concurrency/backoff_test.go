@@ -0,0 +1,51 @@
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffPolicy_Delay(t *testing.T) {
+	policy := &BackoffPolicy{MaxRetries: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.Delay(0))
+	assert.Equal(t, 2*time.Second, policy.Delay(1))
+	assert.Equal(t, 4*time.Second, policy.Delay(2))
+	assert.Equal(t, 5*time.Second, policy.Delay(3), "capped at the max delay")
+	assert.Equal(t, 5*time.Second, policy.Delay(60), "large attempts do not overflow")
+
+	policy.Jitter = 0.5
+	for range 100 {
+		delay := policy.Delay(1)
+		assert.GreaterOrEqual(t, delay, time.Second)
+		assert.LessOrEqual(t, delay, 2*time.Second)
+	}
+}
+
+func TestBackoffPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  BackoffPolicy
+		wantErr string
+	}{
+		{name: "default", policy: *DefaultBackoffPolicy()},
+		{name: "no retries", policy: BackoffPolicy{}},
+		{name: "negative retries", policy: BackoffPolicy{MaxRetries: -1}, wantErr: "must not be negative"},
+		{name: "negative delay", policy: BackoffPolicy{BaseDelay: -time.Second}, wantErr: "must not be negative"},
+		{name: "base above max", policy: BackoffPolicy{BaseDelay: time.Minute, MaxDelay: time.Second}, wantErr: "must not exceed"},
+		{name: "jitter above one", policy: BackoffPolicy{Jitter: 1.5}, wantErr: "between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package concurrency
+
+import (
+	"fmt"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/diskspace"
+)
+
+// diskGuard measures the free space of the file system jobs clone into and,
+// with a reserve, keeps jobs from starting while less than the reserve is
+// left: they are skipped instead of failing halfway through on a full disk
+type diskGuard struct {
+	reserve int64                             // Free bytes to keep, 0 never stops jobs
+	free    func(path string) (uint64, error) // diskspace.Free, replaced in tests
+}
+
+// newDiskGuard creates a guard keeping reserve bytes free
+func newDiskGuard(reserve int64) *diskGuard {
+	return &diskGuard{reserve: max(reserve, 0), free: diskspace.Free}
+}
+
+// measure returns the free bytes of the file system holding the destination
+// of a job, false when they cannot be measured
+func (g *diskGuard) measure(job *cloning.CloneJob) (int64, bool) {
+	free, err := g.free(job.GetDestinationPath())
+	if err != nil {
+		return 0, false
+	}
+	return int64(min(free, uint64(1<<63-1))), true
+}
+
+// check returns the error skipping a job that would start with free bytes
+// left, nil when it may start
+func (g *diskGuard) check(free int64) error {
+	if g.reserve == 0 || free >= g.reserve {
+		return nil
+	}
+	return fmt.Errorf("%w: %s free, keeping %s", cloning.ErrLowDiskSpace,
+		cloning.FormatSize(free), cloning.FormatSize(g.reserve))
+}
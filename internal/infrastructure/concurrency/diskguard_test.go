@@ -0,0 +1,78 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestDiskGuard_Check(t *testing.T) {
+	assert.NoError(t, newDiskGuard(0).check(0), "no reserve never stops jobs")
+
+	guard := newDiskGuard(10 << 30)
+	assert.NoError(t, guard.check(10<<30))
+	err := guard.check(9 << 30)
+	assert.ErrorIs(t, err, cloning.ErrLowDiskSpace)
+	assert.ErrorContains(t, err, "9.0 GB free, keeping 10.0 GB")
+}
+
+// fillingBackend takes a gigabyte of free space per clone
+type fillingBackend struct {
+	blockingBackend
+	free atomic.Int64
+}
+
+func (b *fillingBackend) CloneRepositoryWithProgress(context.Context, *cloning.CloneJob, cloning.TransferProgressFunc) error {
+	b.free.Add(-1 << 30)
+	return nil
+}
+
+func (b *fillingBackend) GetRepositorySize(string) (int64, error) { return 1 << 30, nil }
+
+func TestWorkerPool_MinFreeSpace(t *testing.T) {
+	backend := &fillingBackend{}
+	backend.free.Store(4 << 30)
+	tracker := cloning.NewProgressTracker(4)
+
+	pool, err := NewWorkerPool(&WorkerPoolConfig{
+		MaxWorkers:      1,
+		MinFreeSpace:    2 << 30,
+		Backend:         backend,
+		Logger:          logging.NewNoOpLogger(),
+		ProgressTracker: tracker,
+	})
+	require.NoError(t, err)
+	defer func() { _ = pool.Close() }()
+	pool.disk.free = func(string) (uint64, error) { return uint64(backend.free.Load()), nil }
+
+	var jobs []*cloning.CloneJob
+	for i := 0; i < 4; i++ {
+		repo := &repository.Repository{Name: fmt.Sprintf("app%d", i), Owner: "acme", CloneURL: fmt.Sprintf("https://github.com/acme/app%d.git", i)}
+		jobs = append(jobs, cloning.NewCloneJob(repo, t.TempDir(), nil))
+	}
+	require.NoError(t, pool.SubmitJobs(jobs))
+	go pool.Wait()
+
+	lowSpace := 0
+	for result := range pool.Results() {
+		if errors.Is(result.Job.Error, cloning.ErrLowDiskSpace) {
+			assert.Equal(t, cloning.JobStatusSkipped, result.Job.Status)
+			lowSpace++
+		}
+	}
+
+	progress := tracker.GetProgress()
+	assert.Equal(t, 1, lowSpace, "the job starting below the reserve is skipped")
+	assert.Equal(t, 3, progress.Completed)
+	assert.Equal(t, int64(3<<30), progress.DiskUsage)
+	assert.Equal(t, int64(1<<30), progress.FreeSpace)
+}
@@ -0,0 +1,142 @@
+package concurrency
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+)
+
+// AnyHost is the HostLimits key capping every host without a key of its own
+const AnyHost = "*"
+
+// HostLimits caps the clones running at once per clone URL host, e.g.
+// "bitbucket.org": 4, or per owner on a host with "host/owner" keys. The
+// most specific key of a job applies, falling back to AnyHost; hosts
+// matching no key are only bound by the pool size.
+type HostLimits map[string]int
+
+// NewHostLimits normalizes and validates host=limit entries, e.g. of
+// --host-concurrency
+func NewHostLimits(entries map[string]int) (HostLimits, error) {
+	limits := make(HostLimits, len(entries))
+	for key, limit := range entries {
+		normalized := strings.Trim(strings.ToLower(strings.TrimSpace(key)), "/")
+		if normalized == "" {
+			return nil, fmt.Errorf("host concurrency key cannot be empty")
+		}
+		if limit <= 0 {
+			return nil, fmt.Errorf("host concurrency of %s must be positive, got %d", key, limit)
+		}
+		limits[normalized] = limit
+	}
+	return limits, nil
+}
+
+// slot returns the key of the limit a job counts against and the limit, or
+// an empty key when the job is unlimited
+func (l HostLimits) slot(job *cloning.CloneJob) (string, int) {
+	host := git.CloneURLHost(job.Repository.CloneURL)
+	if host != "" {
+		ownerKey := host + "/" + strings.ToLower(job.Repository.Owner)
+		if limit, ok := l[ownerKey]; ok {
+			return ownerKey, limit
+		}
+		if limit, ok := l[host]; ok {
+			return host, limit
+		}
+	}
+	if limit, ok := l[AnyHost]; ok {
+		// Every host gets a semaphore of its own
+		return AnyHost + host, limit
+	}
+	return "", 0
+}
+
+// hostLimiter is a counting semaphore per HostLimits key. Jobs over the
+// limit of their host are parked instead of occupying a worker, so the
+// workers keep cloning from other hosts; a finishing job hands its slot to
+// the next parked job of the same key.
+type hostLimiter struct {
+	limits  HostLimits
+	mu      sync.Mutex
+	running map[string]int
+	parked  map[string][]func()
+}
+
+// newHostLimiter creates a limiter, or returns nil without limits
+func newHostLimiter(limits HostLimits) *hostLimiter {
+	if len(limits) == 0 {
+		return nil
+	}
+	return &hostLimiter{
+		limits:  limits,
+		running: make(map[string]int),
+		parked:  make(map[string][]func()),
+	}
+}
+
+// slot returns the semaphore key of a job and its limit, or an empty key
+// when the job is unlimited
+func (l *hostLimiter) slot(job *cloning.CloneJob) (string, int) {
+	if l == nil {
+		return "", 0
+	}
+	return l.limits.slot(job)
+}
+
+// acquire takes a slot of a key. When the key is at its limit, start is
+// parked and called on a new goroutine once a slot frees up, holding that
+// slot. It reports whether the slot was taken now.
+func (l *hostLimiter) acquire(key string, limit int, start func()) bool {
+	if l == nil || key == "" {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.running[key] < limit {
+		l.running[key]++
+		return true
+	}
+	l.parked[key] = append(l.parked[key], start)
+	return false
+}
+
+// release frees a slot, handing it to the next parked job of the key
+func (l *hostLimiter) release(key string) {
+	if l == nil || key == "" {
+		return
+	}
+
+	l.mu.Lock()
+	if queue := l.parked[key]; len(queue) > 0 {
+		next := queue[0]
+		if len(queue) == 1 {
+			delete(l.parked, key)
+		} else {
+			l.parked[key] = queue[1:]
+		}
+		l.mu.Unlock()
+		go next()
+		return
+	}
+	l.running[key]--
+	l.mu.Unlock()
+}
+
+// waiting returns the number of parked jobs
+func (l *hostLimiter) waiting() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	waiting := 0
+	for _, queue := range l.parked {
+		waiting += len(queue)
+	}
+	return waiting
+}
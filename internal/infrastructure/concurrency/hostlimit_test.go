@@ -0,0 +1,127 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestNewHostLimits(t *testing.T) {
+	limits, err := NewHostLimits(map[string]int{" Bitbucket.org ": 4, "github.com/Acme/": 2, "*": 8})
+	require.NoError(t, err)
+	assert.Equal(t, HostLimits{"bitbucket.org": 4, "github.com/acme": 2, "*": 8}, limits)
+
+	_, err = NewHostLimits(map[string]int{"github.com": 0})
+	assert.ErrorContains(t, err, "host concurrency of github.com must be positive")
+	_, err = NewHostLimits(map[string]int{" ": 1})
+	assert.ErrorContains(t, err, "key cannot be empty")
+}
+
+func TestHostLimits_Slot(t *testing.T) {
+	limits := HostLimits{"bitbucket.org": 4, "github.com/acme": 2}
+
+	tests := []struct {
+		url, owner string
+		wantKey    string
+		wantLimit  int
+	}{
+		{"https://bitbucket.org/team/app.git", "team", "bitbucket.org", 4},
+		{"git@bitbucket.org:team/app.git", "team", "bitbucket.org", 4},
+		{"https://github.com/Acme/app.git", "Acme", "github.com/acme", 2},
+		{"https://github.com/other/app.git", "other", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			job := cloning.NewCloneJob(&repository.Repository{Name: "app", Owner: tt.owner, CloneURL: tt.url}, "/tmp", nil)
+			key, limit := limits.slot(job)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantLimit, limit)
+		})
+	}
+
+	limits[AnyHost] = 1
+	job := cloning.NewCloneJob(&repository.Repository{Name: "app", Owner: "other", CloneURL: "https://gitlab.com/other/app.git"}, "/tmp", nil)
+	key, limit := limits.slot(job)
+	assert.Equal(t, "*gitlab.com", key, "every host gets its own slots")
+	assert.Equal(t, 1, limit)
+}
+
+// hostCountingBackend records the most clones running at once per host
+type hostCountingBackend struct {
+	blockingBackend
+	mu      sync.Mutex
+	running map[string]int
+	peak    map[string]int
+	overlap bool // Clones of different hosts ran at once
+}
+
+func (b *hostCountingBackend) CloneRepositoryWithProgress(_ context.Context, job *cloning.CloneJob, _ cloning.TransferProgressFunc) error {
+	host := git.CloneURLHost(job.Repository.CloneURL)
+
+	b.mu.Lock()
+	b.running[host]++
+	b.peak[host] = max(b.peak[host], b.running[host])
+	for other, n := range b.running {
+		if other != host && n > 0 {
+			b.overlap = true
+		}
+	}
+	b.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	b.mu.Lock()
+	b.running[host]--
+	b.mu.Unlock()
+	return nil
+}
+
+func TestWorkerPool_HostLimits(t *testing.T) {
+	backend := &hostCountingBackend{running: map[string]int{}, peak: map[string]int{}}
+	tracker := cloning.NewProgressTracker(6)
+
+	pool, err := NewWorkerPool(&WorkerPoolConfig{
+		MaxWorkers:      3,
+		HostLimits:      HostLimits{"bitbucket.org": 1},
+		Backend:         backend,
+		Logger:          logging.NewNoOpLogger(),
+		ProgressTracker: tracker,
+	})
+	require.NoError(t, err)
+	defer func() { _ = pool.Close() }()
+
+	var jobs []*cloning.CloneJob
+	for i := 0; i < 4; i++ {
+		repo := &repository.Repository{Name: fmt.Sprintf("bb%d", i), Owner: "team", CloneURL: fmt.Sprintf("https://bitbucket.org/team/bb%d.git", i)}
+		jobs = append(jobs, cloning.NewCloneJob(repo, t.TempDir(), nil))
+	}
+	for i := 0; i < 2; i++ {
+		repo := &repository.Repository{Name: fmt.Sprintf("gh%d", i), Owner: "acme", CloneURL: fmt.Sprintf("https://github.com/acme/gh%d.git", i)}
+		jobs = append(jobs, cloning.NewCloneJob(repo, t.TempDir(), nil))
+	}
+
+	require.NoError(t, pool.SubmitJobs(jobs))
+	go pool.Wait()
+
+	completed := 0
+	for result := range pool.Results() {
+		assert.True(t, result.Success, result.Job.Repository.GetFullName())
+		completed++
+	}
+
+	assert.Equal(t, 6, completed)
+	assert.Equal(t, 1, backend.peak["bitbucket.org"], "one bitbucket.org clone at a time")
+	assert.True(t, backend.overlap, "github.com clones run beside the limited host")
+	assert.Zero(t, pool.GetStats().HostWaiting)
+	assert.Equal(t, 6, tracker.GetProgress().Completed)
+}
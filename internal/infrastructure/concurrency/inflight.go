@@ -0,0 +1,87 @@
+package concurrency
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// ErrDuplicateJob is matched by errors.Is for jobs rejected because another
+// job with the same destination is queued or running
+var ErrDuplicateJob = errors.New("duplicate clone job")
+
+// DuplicateJobError reports a job whose destination is taken by another job
+// that is still queued or running
+type DuplicateJobError struct {
+	JobID       string // Rejected job
+	ExistingID  string // Job holding the destination
+	Destination string
+}
+
+func (e *DuplicateJobError) Error() string {
+	return fmt.Sprintf("job %s duplicates job %s cloning into %s", e.JobID, e.ExistingID, e.Destination)
+}
+
+// Is matches ErrDuplicateJob
+func (e *DuplicateJobError) Is(target error) bool {
+	return target == ErrDuplicateJob
+}
+
+// inflightJobs tracks the destinations of the queued and running jobs, so two
+// jobs never clone into the same directory at once
+type inflightJobs struct {
+	mu   sync.Mutex
+	jobs map[string]string // Destination key to job ID
+}
+
+func newInflightJobs() *inflightJobs {
+	return &inflightJobs{jobs: make(map[string]string)}
+}
+
+// destinationKey identifies the destination of a job, however its path is
+// spelled
+func destinationKey(job *cloning.CloneJob) string {
+	path := job.GetDestinationPath()
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// claim reserves the destination of job. It reports false without an error
+// when the same job already holds it, and a DuplicateJobError when another
+// job does.
+func (f *inflightJobs) claim(job *cloning.CloneJob) (string, bool, error) {
+	key := destinationKey(job)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch existing, ok := f.jobs[key]; {
+	case !ok:
+		f.jobs[key] = job.ID
+		return key, true, nil
+	case existing == job.ID:
+		return key, false, nil
+	default:
+		return key, false, &DuplicateJobError{JobID: job.ID, ExistingID: existing, Destination: key}
+	}
+}
+
+// holder returns the ID of the queued or running job holding a destination
+func (f *inflightJobs) holder(key string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id, ok := f.jobs[key]
+	return id, ok
+}
+
+// release frees a destination once its job has delivered its result
+func (f *inflightJobs) release(key string) {
+	f.mu.Lock()
+	delete(f.jobs, key)
+	f.mu.Unlock()
+}
@@ -0,0 +1,279 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// resultQueue is an unbounded FIFO of job results feeding a channel. Workers
+// never block on it, however slowly or late the results are consumed.
+type resultQueue struct {
+	mu     sync.Mutex
+	items  []*cloning.JobResult
+	closed bool
+	notify chan struct{} // Wakes the forwarder, buffered so no wake-up is lost
+	out    chan *cloning.JobResult
+}
+
+// newResultQueue creates a queue and starts forwarding its results
+func newResultQueue() *resultQueue {
+	q := &resultQueue{
+		notify: make(chan struct{}, 1),
+		out:    make(chan *cloning.JobResult),
+	}
+	go q.forward()
+	return q
+}
+
+// push appends a result; it must not be called after close
+func (q *resultQueue) push(result *cloning.JobResult) {
+	q.mu.Lock()
+	q.items = append(q.items, result)
+	q.mu.Unlock()
+	q.wake()
+}
+
+// close closes the output channel once the queued results are delivered
+func (q *resultQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.wake()
+}
+
+func (q *resultQueue) wake() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// forward delivers the queued results in order until the queue is closed
+// and drained
+func (q *resultQueue) forward() {
+	defer close(q.out)
+
+	for {
+		q.mu.Lock()
+		items, closed := q.items, q.closed
+		q.items = nil
+		q.mu.Unlock()
+
+		for _, result := range items {
+			q.out <- result
+		}
+		if len(items) > 0 {
+			continue
+		}
+		if closed {
+			return
+		}
+		<-q.notify
+	}
+}
+
+// Batch is a group of jobs submitted together whose results are collected
+// apart from the jobs of other batches, so one pool can run several batches
+// and a use case can run the same pool again. The results of a batch are
+// buffered without bound: submitting blocks only while every worker is busy,
+// never because results were not read yet.
+type Batch struct {
+	id        string
+	pool      *WorkerPool
+	wg        sync.WaitGroup
+	results   *resultQueue
+	closeOnce sync.Once
+	tracker   *cloning.ProgressTracker
+	events    cloning.JobEventFunc
+	drain     chan struct{} // Closed by Drain
+	drainOnce sync.Once
+}
+
+// NewBatch creates an empty batch of the pool
+func (wp *WorkerPool) NewBatch() *Batch {
+	return &Batch{
+		id:      fmt.Sprintf("batch_%d_%d", time.Now().UnixNano(), wp.batches.Add(1)),
+		pool:    wp,
+		results: newResultQueue(),
+		drain:   make(chan struct{}),
+	}
+}
+
+// ID returns the identifier of the batch, logged as batch_id with every job
+// log line so the lines of one run can be correlated
+func (b *Batch) ID() string {
+	return b.id
+}
+
+// SetProgressTracker sets the tracker counting the jobs of the batch; set it
+// before submitting jobs
+func (b *Batch) SetProgressTracker(tracker *cloning.ProgressTracker) {
+	b.tracker = tracker
+}
+
+// SetEventHandler sets the receiver of the lifecycle events of the jobs of
+// the batch, nil disables them; set it before submitting jobs
+func (b *Batch) SetEventHandler(handler cloning.JobEventFunc) {
+	b.events = handler
+}
+
+// Drain stops the jobs of the batch that have not started yet: they report
+// as cancelled while running jobs go on until they finish or their context
+// is cancelled
+func (b *Batch) Drain() {
+	b.drainOnce.Do(func() { close(b.drain) })
+}
+
+// draining reports whether Drain was called
+func (b *Batch) draining() bool {
+	select {
+	case <-b.drain:
+		return true
+	default:
+		return false
+	}
+}
+
+// Submit submits a job cancelled when either ctx or the worker pool is
+// cancelled. Every submitted job delivers exactly one result.
+//
+// Jobs are identified by their destination across the batches of the pool:
+// submitting a job again while it is queued or running does nothing, and a
+// different job cloning into the same destination is rejected with a
+// DuplicateJobError matching ErrDuplicateJob.
+func (b *Batch) Submit(ctx context.Context, job *cloning.CloneJob) error {
+	wp := b.pool
+	if wp.pool.IsClosed() {
+		return fmt.Errorf("worker pool is closed")
+	}
+
+	key, claimed, err := wp.inflight.claim(job)
+	if err != nil || !claimed {
+		return err
+	}
+
+	wp.wg.Add(1)
+	b.wg.Add(1)
+
+	// Counted before submitting: a free worker may start the job before
+	// Submit returns
+	if b.tracker != nil {
+		b.tracker.QueueJob()
+	}
+
+	slot, limit := wp.hosts.slot(job)
+	run := func() {
+		defer wp.wg.Done()
+		defer b.wg.Done()
+		defer wp.inflight.release(key)
+		defer wp.hosts.release(slot)
+
+		jobCtx, cancel := context.WithCancel(ctx)
+		stop := context.AfterFunc(wp.ctx, cancel)
+		defer func() {
+			stop()
+			cancel()
+		}()
+
+		wp.executeJob(jobCtx, b, job)
+	}
+
+	// Jobs of a host at its limit wait apart, leaving the workers to others
+	if !wp.hosts.acquire(slot, limit, func() { wp.submitParked(run) }) {
+		wp.logger.Debug("Clone job waiting for a slot of its host",
+			append(jobFields(b, job), shared.StringField("slot", slot))...)
+		wp.submitted.Add(1)
+		return nil
+	}
+
+	err = wp.pool.Submit(run)
+	if err != nil {
+		if b.tracker != nil {
+			b.tracker.DequeueJob()
+		}
+		wp.hosts.release(slot)
+		wp.inflight.release(key)
+		b.wg.Done()
+		wp.wg.Done()
+		return err
+	}
+	wp.submitted.Add(1)
+	return nil
+}
+
+// submitParked submits a job handed the slot of its host. A closed pool no
+// longer takes jobs; the job then runs on the calling goroutine and, the pool
+// being cancelled, reports its cancellation.
+func (wp *WorkerPool) submitParked(run func()) {
+	if err := wp.pool.Submit(run); err != nil {
+		run()
+	}
+}
+
+// SubmitAll submits jobs in order, stopping at the first one that cannot be
+// submitted. Jobs duplicating the destination of a queued or running job are
+// coalesced into it: they are reported as skipped instead of stopping the
+// submission, so overlapping owners or a retried submission clone each
+// destination once.
+func (b *Batch) SubmitAll(ctx context.Context, jobs []*cloning.CloneJob) error {
+	for _, job := range jobs {
+		err := b.Submit(ctx, job)
+		var duplicate *DuplicateJobError
+		if errors.As(err, &duplicate) {
+			b.Skip(job, fmt.Sprintf("duplicate of job %s cloning into %s", duplicate.ExistingID, duplicate.Destination))
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to submit job %s: %w", job.ID, err)
+		}
+	}
+	return nil
+}
+
+// Skip reports a job of the batch as skipped without running it, e.g. when a
+// pre-scan found its clone. It delivers a result like a submitted job and must
+// not be called after Wait.
+func (b *Batch) Skip(job *cloning.CloneJob, reason string) {
+	job.MarkSkipped(reason)
+	b.pool.reportSkipped(b, job, reason, false)
+}
+
+// Results returns the results of the batch in completion order. The channel
+// is closed by Wait once every submitted job has delivered its result.
+func (b *Batch) Results() <-chan *cloning.JobResult {
+	return b.results.out
+}
+
+// Wait waits for the submitted jobs to finish and closes Results. Jobs must
+// not be submitted to the batch afterwards.
+func (b *Batch) Wait() {
+	b.wg.Wait()
+	b.closeOnce.Do(b.results.close)
+}
+
+// deliver queues the result of a finished job
+func (b *Batch) deliver(result *cloning.JobResult) {
+	b.results.push(result)
+}
+
+// emit publishes a job lifecycle event
+func (b *Batch) emit(event cloning.JobEvent) {
+	if b.events != nil {
+		b.events(event)
+	}
+}
+
+// transferReporter returns a callback feeding backend transfer progress into
+// the tracker of the batch
+func (b *Batch) transferReporter() cloning.TransferProgressFunc {
+	if b.tracker == nil {
+		return nil
+	}
+	return b.tracker.UpdateTransfer
+}
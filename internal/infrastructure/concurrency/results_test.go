@@ -0,0 +1,165 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// instantBackend clones every repository at once, or fails when cancelled
+type instantBackend struct {
+	blockingBackend
+}
+
+func (b *instantBackend) CloneRepositoryWithProgress(ctx context.Context, _ *cloning.CloneJob, _ cloning.TransferProgressFunc) error {
+	return ctx.Err()
+}
+
+// newJobs creates n jobs of distinct repositories
+func newJobs(t *testing.T, n int) []*cloning.CloneJob {
+	t.Helper()
+	dir := t.TempDir()
+	jobs := make([]*cloning.CloneJob, n)
+	for i := range jobs {
+		name := fmt.Sprintf("repo-%d", i)
+		repo, err := repository.NewRepository(repository.RepositoryID(i+1), name, "https://github.com/owner/"+name+".git", "owner", false, 0, "main")
+		require.NoError(t, err)
+		jobs[i] = cloning.NewCloneJob(repo, dir, nil)
+	}
+	return jobs
+}
+
+func newInstantPool(t *testing.T) *WorkerPool {
+	t.Helper()
+	pool, err := NewWorkerPool(&WorkerPoolConfig{
+		MaxWorkers: 8,
+		Backend:    &instantBackend{},
+		Logger:     logging.NewNoOpLogger(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pool.ForceClose() })
+	return pool
+}
+
+// waitTimeout fails the test when fn does not return in time, e.g. because
+// workers are stuck on undelivered results
+func waitTimeout(t *testing.T, timeout time.Duration, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.Fatal("timed out, the results pipeline is stuck")
+	}
+}
+
+func TestBatch_UnboundedResults(t *testing.T) {
+	const n = 10000
+	pool := newInstantPool(t)
+	batch := pool.NewBatch()
+
+	// Nothing reads the results until every job has finished
+	waitTimeout(t, 30*time.Second, func() {
+		require.NoError(t, batch.SubmitAll(context.Background(), newJobs(t, n)))
+		batch.Wait()
+	})
+
+	seen := make(map[string]bool, n)
+	for result := range batch.Results() {
+		assert.True(t, result.Success)
+		seen[result.Job.ID] = true
+	}
+	assert.Len(t, seen, n, "every job delivers exactly one result")
+}
+
+func TestBatch_CancelledLargeBatch(t *testing.T) {
+	const n = 10000
+	pool := newInstantPool(t)
+	batch := pool.NewBatch()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	submitErr := make(chan error, 1)
+	go func() {
+		err := batch.SubmitAll(ctx, newJobs(t, n))
+		batch.Wait()
+		submitErr <- err
+	}()
+
+	count := 0
+	waitTimeout(t, 30*time.Second, func() {
+		for range batch.Results() {
+			if count++; count == n/2 {
+				cancel()
+			}
+		}
+	})
+	require.NoError(t, <-submitErr)
+	assert.Equal(t, n, count, "cancelled jobs deliver their results too")
+}
+
+func TestBatch_ConcurrentBatches(t *testing.T) {
+	pool := newInstantPool(t)
+	first, second := pool.NewBatch(), pool.NewBatch()
+	firstJobs, secondJobs := newJobs(t, 500), newJobs(t, 300)
+
+	go func() {
+		_ = first.SubmitAll(context.Background(), firstJobs)
+		first.Wait()
+	}()
+	go func() {
+		_ = second.SubmitAll(context.Background(), secondJobs)
+		second.Wait()
+	}()
+
+	collect := func(batch *Batch) map[string]bool {
+		ids := make(map[string]bool)
+		for result := range batch.Results() {
+			ids[result.Job.ID] = true
+		}
+		return ids
+	}
+	var firstIDs, secondIDs map[string]bool
+	waitTimeout(t, 30*time.Second, func() {
+		done := make(chan struct{})
+		go func() {
+			secondIDs = collect(second)
+			close(done)
+		}()
+		firstIDs = collect(first)
+		<-done
+	})
+
+	assert.Len(t, firstIDs, 500)
+	assert.Len(t, secondIDs, 300)
+	for _, job := range secondJobs {
+		assert.False(t, firstIDs[job.ID], "results stay in their batch")
+	}
+}
+
+func TestWorkerPool_RunsAgain(t *testing.T) {
+	pool := newInstantPool(t)
+
+	for run := range 3 {
+		batch := pool.NewBatch()
+		require.NoError(t, batch.SubmitAll(context.Background(), newJobs(t, 20)))
+		batch.Wait()
+
+		count := 0
+		for range batch.Results() {
+			count++
+		}
+		assert.Equal(t, 20, count, "run %d", run)
+	}
+}
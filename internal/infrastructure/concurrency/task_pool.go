@@ -0,0 +1,125 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// Task is a unit of work run by a TaskPool
+type Task func(ctx context.Context) error
+
+// TaskPool runs independent tasks other than clone jobs, such as release
+// downloads, on a bounded set of workers with retries and exponential backoff
+type TaskPool struct {
+	pool        *ants.Pool
+	logger      shared.Logger
+	wg          sync.WaitGroup
+	maxRetries  int
+	retryDelay  time.Duration
+	isPermanent func(error) bool
+}
+
+// TaskPoolConfig holds configuration for the task pool
+type TaskPoolConfig struct {
+	MaxWorkers  int
+	MaxRetries  int // Zero disables retries
+	RetryDelay  time.Duration
+	IsPermanent func(error) bool // Optional, errors it accepts are not retried
+	Logger      shared.Logger
+}
+
+// NewTaskPool creates a new task pool
+func NewTaskPool(config *TaskPoolConfig) (*TaskPool, error) {
+	if config.MaxWorkers <= 0 {
+		config.MaxWorkers = runtime.NumCPU() * 2
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = time.Second
+	}
+	if config.IsPermanent == nil {
+		config.IsPermanent = func(error) bool { return false }
+	}
+
+	pool, err := ants.NewPool(config.MaxWorkers, ants.WithOptions(ants.Options{
+		ExpiryDuration: 10 * time.Second,
+		PanicHandler: func(i interface{}) {
+			config.Logger.Error("Task panic",
+				shared.StringField("panic", fmt.Sprintf("%v", i)))
+		},
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task pool: %w", err)
+	}
+
+	return &TaskPool{
+		pool:        pool,
+		logger:      config.Logger,
+		maxRetries:  config.MaxRetries,
+		retryDelay:  config.RetryDelay,
+		isPermanent: config.IsPermanent,
+	}, nil
+}
+
+// Submit runs task on a worker and reports its final error, nil on success,
+// to done. Submission blocks while every worker is busy.
+func (tp *TaskPool) Submit(ctx context.Context, name string, task Task, done func(error)) error {
+	tp.wg.Add(1)
+
+	err := tp.pool.Submit(func() {
+		defer tp.wg.Done()
+		done(tp.run(ctx, name, task))
+	})
+	if err != nil {
+		tp.wg.Done()
+		return fmt.Errorf("failed to submit task %s: %w", name, err)
+	}
+	return nil
+}
+
+// run executes a task with retries
+func (tp *TaskPool) run(ctx context.Context, name string, task Task) error {
+	var err error
+	for attempt := 0; attempt <= tp.maxRetries; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		if err = task(ctx); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || tp.isPermanent(err) || attempt == tp.maxRetries {
+			return err
+		}
+
+		tp.logger.Warn("Task failed, retrying",
+			shared.StringField("task", name),
+			shared.IntField("attempt", attempt+1),
+			shared.IntField("max_attempts", tp.maxRetries+1),
+			shared.ErrorField(err))
+
+		select {
+		case <-time.After(tp.retryDelay * time.Duration(1<<attempt)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// Wait blocks until every submitted task has finished
+func (tp *TaskPool) Wait() {
+	tp.wg.Wait()
+}
+
+// Close waits for running tasks and releases the workers
+func (tp *TaskPool) Close() {
+	tp.wg.Wait()
+	tp.pool.Release()
+}
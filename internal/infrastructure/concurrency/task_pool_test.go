@@ -0,0 +1,78 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+var errPermanent = errors.New("permanent")
+
+func TestTaskPool_Retries(t *testing.T) {
+	pool, err := NewTaskPool(&TaskPoolConfig{
+		MaxWorkers:  2,
+		MaxRetries:  2,
+		RetryDelay:  time.Millisecond,
+		IsPermanent: func(err error) bool { return errors.Is(err, errPermanent) },
+		Logger:      logging.NewNoOpLogger(),
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	var flakyCalls, permanentCalls, failingCalls atomic.Int32
+	var mu sync.Mutex
+	results := map[string]error{}
+	record := func(name string) func(error) {
+		return func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			results[name] = err
+		}
+	}
+
+	ctx := context.Background()
+	require.NoError(t, pool.Submit(ctx, "flaky", func(context.Context) error {
+		if flakyCalls.Add(1) < 2 {
+			return errors.New("transient")
+		}
+		return nil
+	}, record("flaky")))
+	require.NoError(t, pool.Submit(ctx, "permanent", func(context.Context) error {
+		permanentCalls.Add(1)
+		return errPermanent
+	}, record("permanent")))
+	require.NoError(t, pool.Submit(ctx, "failing", func(context.Context) error {
+		failingCalls.Add(1)
+		return errors.New("always")
+	}, record("failing")))
+	pool.Wait()
+
+	assert.NoError(t, results["flaky"])
+	assert.Equal(t, int32(2), flakyCalls.Load())
+	assert.ErrorIs(t, results["permanent"], errPermanent)
+	assert.Equal(t, int32(1), permanentCalls.Load())
+	assert.Error(t, results["failing"])
+	assert.Equal(t, int32(3), failingCalls.Load())
+}
+
+func TestTaskPool_Cancelled(t *testing.T) {
+	pool, err := NewTaskPool(&TaskPoolConfig{MaxWorkers: 1, Logger: logging.NewNoOpLogger()})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var result error
+	require.NoError(t, pool.Submit(ctx, "task", func(context.Context) error { return nil }, func(err error) { result = err }))
+	pool.Wait()
+	assert.ErrorIs(t, result, context.Canceled)
+}
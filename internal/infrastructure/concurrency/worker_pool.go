@@ -2,9 +2,11 @@ package concurrency
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/panjf2000/ants/v2"
@@ -12,28 +14,60 @@ import (
 	"github.com/italoag/repocloner/internal/domain/cloning"
 	"github.com/italoag/repocloner/internal/domain/shared"
 	"github.com/italoag/repocloner/internal/infrastructure/git"
+	"github.com/italoag/repocloner/internal/infrastructure/redact"
 )
 
 // WorkerPool manages concurrent cloning operations using ants
 type WorkerPool struct {
-	pool            *ants.Pool
-	gitClient       *git.GitClient
-	logger          shared.Logger
-	progressTracker *cloning.ProgressTracker
-	results         chan *cloning.JobResult
-	wg              sync.WaitGroup
-	ctx             context.Context
-	cancel          context.CancelFunc
-	maxRetries      int
-	retryDelay      time.Duration
+	pool    *ants.Pool
+	backend git.CloneBackend
+	logger  shared.Logger
+	batch   *Batch // Default batch of SubmitJob, Results and Wait
+	wg      sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+	retry   *BackoffPolicy
+
+	// Destinations of the queued and running jobs of every batch
+	inflight *inflightJobs
+
+	// Per-host concurrency caps, nil without HostLimits
+	hosts *hostLimiter
+
+	// Free space measure and reserve of MinFreeSpace
+	disk *diskGuard
+
+	// Adaptive sizing, nil for a fixed number of workers
+	adaptive  *AdaptiveController
+	finished  atomic.Int64 // Jobs finished since the last adjustment
+	throttled atomic.Int64 // Throttled attempts since the last adjustment
+
+	batches atomic.Uint64 // Sequence of the batch IDs
+
+	// Lifetime counters reported by GetStats
+	submitted atomic.Uint64
+	retries   atomic.Int64
+	jobsDone  atomic.Int64
+	jobTime   atomic.Int64 // Total duration of the finished jobs, in nanoseconds
 }
 
 // WorkerPoolConfig holds configuration for the worker pool
 type WorkerPoolConfig struct {
-	MaxWorkers      int
+	MaxWorkers int
+
+	// MinWorkers below MaxWorkers enables adaptive sizing: the pool starts
+	// with InitialWorkers and is resized every AdaptInterval between
+	// MinWorkers and MaxWorkers based on throughput and throttling
+	MinWorkers     int
+	InitialWorkers int
+	AdaptInterval  time.Duration
+
 	MaxRetries      int
 	RetryDelay      time.Duration
-	GitClient       *git.GitClient
+	Retry           *BackoffPolicy // Overrides MaxRetries and RetryDelay when set
+	HostLimits      HostLimits     // Optional caps of the clones running at once per host
+	MinFreeSpace    int64          // Skip jobs starting with less free disk space, in bytes (0 = never)
+	Backend         git.CloneBackend
 	Logger          shared.Logger
 	ProgressTracker *cloning.ProgressTracker
 }
@@ -44,20 +78,38 @@ func NewWorkerPool(config *WorkerPoolConfig) (*WorkerPool, error) {
 		config.MaxWorkers = runtime.NumCPU() * 2 // Default to 2x CPU cores
 	}
 
-	if config.MaxRetries <= 0 {
-		config.MaxRetries = 3
+	if config.Retry == nil {
+		if config.MaxRetries <= 0 {
+			config.MaxRetries = DefaultMaxRetries
+		}
+		if config.RetryDelay <= 0 {
+			config.RetryDelay = DefaultRetryBaseDelay
+		}
+		config.Retry = &BackoffPolicy{MaxRetries: config.MaxRetries, BaseDelay: config.RetryDelay}
+	}
+	if err := config.Retry.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid retry policy: %w", err)
 	}
 
-	if config.RetryDelay <= 0 {
-		config.RetryDelay = 5 * time.Second
+	var adaptive *AdaptiveController
+	workers := config.MaxWorkers
+	if config.MinWorkers > 0 && config.MinWorkers < config.MaxWorkers {
+		if config.InitialWorkers <= 0 {
+			config.InitialWorkers = config.MinWorkers
+		}
+		if config.AdaptInterval <= 0 {
+			config.AdaptInterval = DefaultAdaptInterval
+		}
+		adaptive = NewAdaptiveController(config.MinWorkers, config.MaxWorkers, config.InitialWorkers)
+		workers = adaptive.Size()
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	// Create ants pool with panic handler
-	pool, err := ants.NewPool(config.MaxWorkers, ants.WithOptions(ants.Options{
+	// Create ants pool with panic handler; pre-allocated pools cannot be resized
+	pool, err := ants.NewPool(workers, ants.WithOptions(ants.Options{
 		ExpiryDuration: 10 * time.Second, // Worker expiry time
-		PreAlloc:       true,             // Pre-allocate workers
+		PreAlloc:       adaptive == nil,  // Pre-allocate workers
 		PanicHandler: func(i interface{}) {
 			config.Logger.Error("Worker panic",
 				shared.StringField("panic", fmt.Sprintf("%v", i)))
@@ -69,137 +121,266 @@ func NewWorkerPool(config *WorkerPoolConfig) (*WorkerPool, error) {
 	}
 
 	wp := &WorkerPool{
-		pool:            pool,
-		gitClient:       config.GitClient,
-		logger:          config.Logger,
-		progressTracker: config.ProgressTracker,
-		results:         make(chan *cloning.JobResult, config.MaxWorkers*2),
-		ctx:             ctx,
-		cancel:          cancel,
-		maxRetries:      config.MaxRetries,
-		retryDelay:      config.RetryDelay,
+		pool:     pool,
+		backend:  config.Backend,
+		logger:   config.Logger,
+		ctx:      ctx,
+		cancel:   cancel,
+		retry:    config.Retry,
+		adaptive: adaptive,
+		inflight: newInflightJobs(),
+		hosts:    newHostLimiter(config.HostLimits),
+		disk:     newDiskGuard(config.MinFreeSpace),
+	}
+	wp.batch = wp.NewBatch()
+	wp.batch.SetProgressTracker(config.ProgressTracker)
+
+	if adaptive != nil {
+		go wp.adapt(config.AdaptInterval)
+		config.Logger.Info("Adaptive worker pool created",
+			shared.IntField("min_workers", config.MinWorkers),
+			shared.IntField("max_workers", config.MaxWorkers),
+			shared.IntField("initial_workers", workers),
+			shared.StringField("retry", config.Retry.String()))
+		return wp, nil
 	}
 
 	config.Logger.Info("Worker pool created",
 		shared.IntField("max_workers", config.MaxWorkers),
-		shared.IntField("max_retries", config.MaxRetries))
+		shared.StringField("retry", config.Retry.String()))
 
 	return wp, nil
 }
 
-// SubmitJob submits a cloning job to the worker pool
-func (wp *WorkerPool) SubmitJob(job *cloning.CloneJob) error {
-	if wp.pool.IsClosed() {
-		return fmt.Errorf("worker pool is closed")
+// adapt resizes an adaptive pool every interval until the pool is closed
+func (wp *WorkerPool) adapt(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-wp.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		sample := AdaptiveSample{
+			Completed: int(wp.finished.Swap(0)),
+			Throttled: int(wp.throttled.Swap(0)),
+			Saturated: wp.pool.Running() >= wp.pool.Cap() && wp.pool.Waiting() > 0,
+		}
+		if sample.Completed == 0 && sample.Throttled == 0 && wp.pool.Running() == 0 {
+			continue // Idle between runs
+		}
+
+		previous := wp.pool.Cap()
+		size := wp.adaptive.Next(sample, interval)
+		if size == previous {
+			continue
+		}
+
+		wp.pool.Tune(size)
+		wp.logger.Info("Worker pool resized",
+			shared.IntField("from", previous),
+			shared.IntField("to", size),
+			shared.IntField("completed", sample.Completed),
+			shared.IntField("throttled", sample.Throttled))
 	}
+}
 
-	wp.wg.Add(1)
+// SubmitJob submits a cloning job to the worker pool. Submitting a job again
+// while it is queued or running does nothing, and a different job cloning
+// into the same destination is rejected with a DuplicateJobError.
+func (wp *WorkerPool) SubmitJob(job *cloning.CloneJob) error {
+	return wp.SubmitJobContext(wp.ctx, job)
+}
 
-	return wp.pool.Submit(func() {
-		defer wp.wg.Done()
-		wp.executeJob(job)
-	})
+// SubmitJobContext submits a cloning job that is cancelled when either ctx or
+// the worker pool is cancelled
+func (wp *WorkerPool) SubmitJobContext(ctx context.Context, job *cloning.CloneJob) error {
+	return wp.batch.Submit(ctx, job)
 }
 
-// SubmitJobs submits multiple cloning jobs to the worker pool
+// SubmitJobs submits multiple cloning jobs to the worker pool, reporting jobs
+// that duplicate the destination of a queued or running job as skipped
 func (wp *WorkerPool) SubmitJobs(jobs []*cloning.CloneJob) error {
-	for _, job := range jobs {
-		if err := wp.SubmitJob(job); err != nil {
-			return fmt.Errorf("failed to submit job %s: %w", job.ID, err)
-		}
-	}
-	return nil
+	return wp.SubmitJobsContext(wp.ctx, jobs)
+}
+
+// SubmitJobsContext submits multiple cloning jobs sharing a cancellation context
+func (wp *WorkerPool) SubmitJobsContext(ctx context.Context, jobs []*cloning.CloneJob) error {
+	return wp.batch.SubmitAll(ctx, jobs)
 }
 
 // executeJob executes a single cloning job with retry logic
-func (wp *WorkerPool) executeJob(job *cloning.CloneJob) {
+func (wp *WorkerPool) executeJob(ctx context.Context, batch *Batch, job *cloning.CloneJob) {
 	startTime := time.Now()
+	defer func() {
+		wp.finished.Add(1)
+		wp.jobsDone.Add(1)
+		wp.jobTime.Add(int64(time.Since(startTime)))
+	}()
 
 	// Mark job as started
 	job.MarkStarted()
-	if wp.progressTracker != nil {
-		wp.progressTracker.StartJob()
+	if batch.tracker != nil {
+		batch.tracker.StartJob()
 	}
+	batch.emit(cloning.NewJobEvent(cloning.JobEventStarted, job))
 
-	wp.logger.Info("Starting clone job",
-		shared.StringField("job_id", job.ID),
-		shared.StringField("repo", job.Repository.GetFullName()),
+	// A draining batch lets its running clones finish but starts no more
+	if batch.draining() {
+		wp.handleJobCancellation(ctx, batch, job)
+		return
+	}
+
+	logger := wp.logger.With(jobFields(batch, job)...)
+	logger.Info("Starting clone job",
 		shared.StringField("destination", job.GetDestinationPath()))
 
+	if tracker := batch.tracker; tracker != nil {
+		defer tracker.FinishTransfer(job.ID)
+	}
+
+	// Jobs stop starting once the disk is nearly full, rather than failing
+	// halfway through a clone
+	if free, ok := wp.recordFreeSpace(batch, job); ok {
+		if err := wp.disk.check(free); err != nil {
+			wp.handleJobLowSpace(batch, job, err)
+			return
+		}
+	}
+
 	var lastErr error
-	for attempt := 0; attempt <= wp.maxRetries; attempt++ {
+	for attempt := 0; attempt <= wp.retry.MaxRetries; attempt++ {
 		select {
-		case <-wp.ctx.Done():
-			wp.handleJobCancellation(job)
+		case <-ctx.Done():
+			wp.handleJobCancellation(ctx, batch, job)
 			return
 		default:
 		}
 
-		// Execute the clone operation
-		err := wp.gitClient.CloneRepository(wp.ctx, job)
+		// Clones that fail while the batch drains are not retried
+		if attempt > 0 && batch.draining() {
+			wp.handleJobCancellation(ctx, batch, job)
+			return
+		}
+
+		// Execute the clone operation, the backend logging with the job fields
+		attemptStart := time.Now()
+		attemptLogger := logger.With(shared.IntField("attempt", attempt+1))
+		attemptCtx := shared.WithLogger(ctx, attemptLogger)
+		err := wp.backend.CloneRepositoryWithProgress(attemptCtx, job, batch.transferReporter())
 
 		if err == nil {
 			// Success
-			wp.handleJobSuccess(job, startTime)
+			job.RecordAttempt(attemptStart, "")
+			wp.handleJobSuccess(batch, job, startTime)
+			return
+		}
+
+		// An existing clone of the same remote is updated instead of skipped
+		var existsErr *git.RepositoryExistsError
+		if errors.As(err, &existsErr) && existsErr.RemoteURL == "" && job.Options.Existing == cloning.ExistingUpdate {
+			if err = wp.backend.UpdateClone(attemptCtx, job); err == nil {
+				job.RecordAttempt(attemptStart, "")
+				wp.handleJobUpdated(batch, job, startTime)
+				return
+			}
+		}
+		job.RecordAttempt(attemptStart, redact.String(err.Error()))
+
+		// Errors caused by cancellation are not retried
+		if ctx.Err() != nil {
+			wp.handleJobCancellation(ctx, batch, job)
 			return
 		}
 
 		lastErr = err
 
+		gitValidator := git.NewGitValidator(wp.logger)
+		if gitValidator.IsThrottleError(err) {
+			wp.throttled.Add(1)
+		}
+
 		// Check if error is retryable
-		if gitValidator := git.NewGitValidator(wp.logger); gitValidator.IsPermanentError(err) {
+		if gitValidator.IsPermanentError(err) {
 			// Permanent error, don't retry
-			wp.logger.Error("Permanent error, not retrying",
-				shared.StringField("job_id", job.ID),
-				shared.StringField("repo", job.Repository.GetFullName()),
-				shared.ErrorField(err))
+			attemptLogger.Error("Permanent error, not retrying", shared.ErrorField(err))
 			break
 		}
 
 		// Check if we should skip (repository already exists)
 		if _, ok := err.(*git.RepositoryExistsError); ok {
-			wp.handleJobSkipped(job, err.Error())
+			wp.handleJobSkipped(batch, job, err.Error())
 			return
 		}
 
 		// Retry logic
-		if attempt < wp.maxRetries {
-			wp.logger.Warn("Clone attempt failed, retrying",
-				shared.StringField("job_id", job.ID),
-				shared.StringField("repo", job.Repository.GetFullName()),
-				shared.IntField("attempt", attempt+1),
-				shared.IntField("max_attempts", wp.maxRetries+1),
+		if attempt < wp.retry.MaxRetries {
+			event := cloning.NewJobEvent(cloning.JobEventRetry, job)
+			event.Error = redact.String(err.Error())
+			batch.emit(event)
+			job.RetryCount = attempt + 1
+			wp.retries.Add(1)
+
+			attemptLogger.Warn("Clone attempt failed, retrying",
+				shared.IntField("max_attempts", wp.retry.MaxRetries+1),
 				shared.ErrorField(err))
 
 			// Wait before retry with exponential backoff
-			retryDelay := wp.retryDelay * time.Duration(1<<attempt)
+			retryDelay := wp.retry.Delay(attempt)
+			job.LastAttempt().RetryDelay = retryDelay
 			select {
 			case <-time.After(retryDelay):
-			case <-wp.ctx.Done():
-				wp.handleJobCancellation(job)
+			case <-ctx.Done():
+				wp.handleJobCancellation(ctx, batch, job)
+				return
+			case <-batch.drain:
+				wp.handleJobCancellation(ctx, batch, job)
 				return
 			}
 		}
 	}
 
 	// All retries exhausted
-	wp.handleJobFailure(job, lastErr)
+	wp.handleJobFailure(batch, job, lastErr)
+}
+
+// jobFields returns the fields identifying a job in every log line
+func jobFields(batch *Batch, job *cloning.CloneJob) []shared.Field {
+	return []shared.Field{
+		shared.StringField("job_id", job.ID),
+		shared.StringField("batch_id", batch.id),
+		shared.StringField("repo", job.Repository.GetFullName()),
+	}
+}
+
+// jobLogger returns a logger of the finished job, adding its last attempt
+// number to the fields of jobFields once it ran
+func (wp *WorkerPool) jobLogger(batch *Batch, job *cloning.CloneJob) shared.Logger {
+	fields := jobFields(batch, job)
+	if attempts := len(job.Attempts); attempts > 0 {
+		fields = append(fields, shared.IntField("attempt", attempts))
+	}
+	return wp.logger.With(fields...)
 }
 
 // handleJobSuccess handles successful job completion
-func (wp *WorkerPool) handleJobSuccess(job *cloning.CloneJob, startTime time.Time) {
+func (wp *WorkerPool) handleJobSuccess(batch *Batch, job *cloning.CloneJob, startTime time.Time) {
 	duration := time.Since(startTime)
 	job.MarkCompleted()
 
 	// Calculate repository size
 	var repoSize int64
-	if size, err := wp.gitClient.GetRepositorySize(job.GetDestinationPath()); err == nil {
+	if size, err := wp.backend.GetRepositorySize(job.GetDestinationPath()); err == nil {
 		repoSize = size
 	}
+	wp.recordFreeSpace(batch, job)
 
 	// Update progress with detailed information
-	if wp.progressTracker != nil {
-		wp.progressTracker.CompleteJobWithDetails(
+	if batch.tracker != nil {
+		batch.tracker.CompleteJobWithDetails(
 			job.Repository.GetFullName(),
 			duration,
 			repoSize,
@@ -207,27 +388,73 @@ func (wp *WorkerPool) handleJobSuccess(job *cloning.CloneJob, startTime time.Tim
 	}
 
 	result := cloning.NewJobResult(job, true, repoSize)
+	wp.resolveHead(batch, job, result)
 
-	wp.logger.Info("Clone job completed successfully",
-		shared.StringField("job_id", job.ID),
-		shared.StringField("repo", job.Repository.GetFullName()),
+	wp.jobLogger(batch, job).Info("Clone job completed successfully",
 		shared.DurationField("duration", duration),
-		shared.IntField("size_bytes", int(repoSize)))
+		shared.IntField("size_bytes", int(repoSize)),
+		shared.StringField("revision", result.Revision))
 
-	select {
-	case wp.results <- result:
-	case <-wp.ctx.Done():
+	event := cloning.NewJobEvent(cloning.JobEventCompleted, job)
+	event.SizeBytes = repoSize
+	event.Revision, event.Branch = result.Revision, result.Branch
+	batch.emit(event)
+
+	batch.deliver(result)
+}
+
+// handleJobUpdated handles jobs done by updating an existing clone
+func (wp *WorkerPool) handleJobUpdated(batch *Batch, job *cloning.CloneJob, startTime time.Time) {
+	duration := time.Since(startTime)
+	job.MarkUpdated()
+
+	var repoSize int64
+	if size, err := wp.backend.GetRepositorySize(job.GetDestinationPath()); err == nil {
+		repoSize = size
 	}
+	wp.recordFreeSpace(batch, job)
+
+	if batch.tracker != nil {
+		batch.tracker.UpdateJobWithDetails(job.Repository.GetFullName(), duration, repoSize)
+	}
+
+	result := cloning.NewJobResult(job, true, repoSize)
+	wp.resolveHead(batch, job, result)
+
+	wp.jobLogger(batch, job).Info("Clone job updated existing repository",
+		shared.DurationField("duration", duration),
+		shared.StringField("revision", result.Revision))
+
+	event := cloning.NewJobEvent(cloning.JobEventUpdated, job)
+	event.SizeBytes = repoSize
+	event.Revision, event.Branch = result.Revision, result.Branch
+	batch.emit(event)
+
+	batch.deliver(result)
+}
+
+// resolveHead records the commit and branch of HEAD of a cloned or updated
+// job in its result. Clones whose HEAD cannot be read record the branch they
+// were cloned from, the default branch of the repository when none was given.
+func (wp *WorkerPool) resolveHead(batch *Batch, job *cloning.CloneJob, result *cloning.JobResult) {
+	state, err := git.InspectRepository(job.GetDestinationPath())
+	if err != nil {
+		wp.jobLogger(batch, job).Debug("Failed to resolve HEAD of clone", shared.ErrorField(err))
+		result.Branch = job.ExpectedBranch()
+		return
+	}
+	result.Revision, result.Branch = state.Revision, state.Branch
 }
 
 // handleJobFailure handles job failure after all retries
-func (wp *WorkerPool) handleJobFailure(job *cloning.CloneJob, err error) {
+func (wp *WorkerPool) handleJobFailure(batch *Batch, job *cloning.CloneJob, err error) {
 	duration := job.Duration()
+	err = redact.Error(err) // git output in errors can echo credentials
 	job.MarkFailed(err)
 
 	// Update progress with detailed information
-	if wp.progressTracker != nil {
-		wp.progressTracker.FailJobWithDetails(
+	if batch.tracker != nil {
+		batch.tracker.FailJobWithDetails(
 			job.Repository.GetFullName(),
 			duration,
 			err,
@@ -236,89 +463,131 @@ func (wp *WorkerPool) handleJobFailure(job *cloning.CloneJob, err error) {
 
 	result := cloning.NewJobResult(job, false, 0)
 
-	wp.logger.Error("Clone job failed permanently",
-		shared.StringField("job_id", job.ID),
-		shared.StringField("repo", job.Repository.GetFullName()),
-		shared.ErrorField(err))
+	wp.jobLogger(batch, job).Error("Clone job failed permanently", shared.ErrorField(err))
 
-	select {
-	case wp.results <- result:
-	case <-wp.ctx.Done():
+	batch.emit(cloning.NewJobEvent(cloning.JobEventFailed, job))
+
+	batch.deliver(result)
+}
+
+// recordFreeSpace measures the free space left for the clones of a job into
+// the tracker of its batch, returning it when it could be measured
+func (wp *WorkerPool) recordFreeSpace(batch *Batch, job *cloning.CloneJob) (int64, bool) {
+	if batch.tracker == nil && wp.disk.reserve == 0 {
+		return 0, false
+	}
+	free, ok := wp.disk.measure(job)
+	if ok && batch.tracker != nil {
+		batch.tracker.SetFreeSpace(free)
 	}
+	return free, ok
 }
 
 // handleJobSkipped handles skipped jobs (e.g., repository already exists)
-func (wp *WorkerPool) handleJobSkipped(job *cloning.CloneJob, reason string) {
-	duration := job.Duration()
+func (wp *WorkerPool) handleJobSkipped(batch *Batch, job *cloning.CloneJob, reason string) {
 	job.MarkSkipped(reason)
+	wp.reportSkipped(batch, job, reason, true)
+}
+
+// handleJobLowSpace skips a job kept from starting by the free space reserve
+func (wp *WorkerPool) handleJobLowSpace(batch *Batch, job *cloning.CloneJob, err error) {
+	job.MarkSkippedBy(err)
+	wp.reportSkipped(batch, job, err.Error(), true)
+}
+
+// reportSkipped reports a job marked as skipped; started tells whether a
+// worker picked it up and counted it in progress
+func (wp *WorkerPool) reportSkipped(batch *Batch, job *cloning.CloneJob, reason string, started bool) {
+	duration := job.Duration()
 
 	// Update progress with detailed information
-	if wp.progressTracker != nil {
-		wp.progressTracker.SkipJobWithDetails(
-			job.Repository.GetFullName(),
-			duration,
-			reason,
-		)
+	if tracker := batch.tracker; tracker != nil {
+		if started {
+			tracker.SkipJobWithDetails(job.Repository.GetFullName(), duration, reason)
+		} else {
+			tracker.SkipUnstartedJobWithDetails(job.Repository.GetFullName(), duration, reason)
+		}
 	}
 
 	result := cloning.NewJobResult(job, true, 0) // Consider skipped as success
 
-	wp.logger.Info("Clone job skipped",
-		shared.StringField("job_id", job.ID),
-		shared.StringField("repo", job.Repository.GetFullName()),
-		shared.StringField("reason", reason))
+	wp.jobLogger(batch, job).Info("Clone job skipped", shared.StringField("reason", reason))
 
-	select {
-	case wp.results <- result:
-	case <-wp.ctx.Done():
-	}
+	batch.emit(cloning.NewJobEvent(cloning.JobEventSkipped, job))
+
+	batch.deliver(result)
 }
 
-// handleJobCancellation handles job cancellation
-func (wp *WorkerPool) handleJobCancellation(job *cloning.CloneJob) {
-	job.MarkFailed(fmt.Errorf("job cancelled"))
+// handleJobCancellation handles job cancellation, telling an expired run
+// deadline apart from the user cancelling
+func (wp *WorkerPool) handleJobCancellation(ctx context.Context, batch *Batch, job *cloning.CloneJob) {
+	duration := job.Duration()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		job.MarkTimedOut()
+	} else {
+		job.MarkCancelled()
+	}
 
-	if wp.progressTracker != nil {
-		wp.progressTracker.FailJob()
+	if batch.tracker != nil {
+		batch.tracker.CancelJobWithDetails(job.Repository.GetFullName(), duration)
 	}
 
-	wp.logger.Info("Clone job cancelled",
-		shared.StringField("job_id", job.ID),
-		shared.StringField("repo", job.Repository.GetFullName()))
+	result := cloning.NewJobResult(job, false, 0)
+
+	wp.jobLogger(batch, job).Info("Clone job cancelled")
+
+	batch.emit(cloning.NewJobEvent(cloning.JobEventCancelled, job))
+
+	batch.deliver(result)
 }
 
-// Wait waits for all submitted jobs to complete
+// Wait waits for the jobs of SubmitJob and SubmitJobs to complete and closes
+// Results. Use a batch of NewBatch to run the pool more than once.
 func (wp *WorkerPool) Wait() {
-	wp.wg.Wait()
-	close(wp.results)
+	wp.batch.Wait()
 }
 
-// Results returns a channel for receiving job results
+// Results returns the results of the jobs of SubmitJob and SubmitJobs
 func (wp *WorkerPool) Results() <-chan *cloning.JobResult {
-	return wp.results
+	return wp.batch.Results()
 }
 
-// GetProgress returns current progress information
+// GetProgress returns the progress of the jobs of SubmitJob and SubmitJobs
 func (wp *WorkerPool) GetProgress() *cloning.Progress {
-	if wp.progressTracker != nil {
-		return wp.progressTracker.GetProgress()
+	if tracker := wp.batch.tracker; tracker != nil {
+		return tracker.GetProgress()
 	}
 	return nil
 }
 
-// SetProgressTracker sets the progress tracker for this worker pool
+// SetProgressTracker sets the progress tracker of the jobs of SubmitJob and
+// SubmitJobs; batches of NewBatch have their own
 func (wp *WorkerPool) SetProgressTracker(tracker *cloning.ProgressTracker) {
-	wp.progressTracker = tracker
+	wp.batch.SetProgressTracker(tracker)
+}
+
+// SetEventHandler sets the receiver of the lifecycle events of the jobs of
+// SubmitJob and SubmitJobs, nil disables them
+func (wp *WorkerPool) SetEventHandler(handler cloning.JobEventFunc) {
+	wp.batch.SetEventHandler(handler)
 }
 
 // GetStats returns worker pool statistics
 func (wp *WorkerPool) GetStats() *WorkerPoolStats {
-	return &WorkerPoolStats{
+	stats := &WorkerPoolStats{
 		TotalWorkers:   wp.pool.Cap(),
 		RunningWorkers: wp.pool.Running(),
 		FreeWorkers:    wp.pool.Free(),
-		SubmittedTasks: 0, // ants v2 doesn't expose this metric
+		SubmittedTasks: wp.submitted.Load(),
+		HostWaiting:    wp.hosts.waiting(),
+		Retries:        int(wp.retries.Load()),
+		Adaptive:       wp.adaptive != nil,
 	}
+	if done := wp.jobsDone.Load(); done > 0 {
+		stats.FinishedTasks = int(done)
+		stats.AverageJobDuration = time.Duration(wp.jobTime.Load() / done)
+	}
+	return stats
 }
 
 // Close gracefully shuts down the worker pool
@@ -362,6 +631,14 @@ type WorkerPoolStats struct {
 	RunningWorkers int    `json:"running_workers"`
 	FreeWorkers    int    `json:"free_workers"`
 	SubmittedTasks uint64 `json:"submitted_tasks"`
+	FinishedTasks  int    `json:"finished_tasks"`
+	HostWaiting    int    `json:"host_waiting"` // Jobs waiting for a slot of their host
+	Retries        int    `json:"retries"`      // Retried clone attempts
+	Adaptive       bool   `json:"adaptive"`     // TotalWorkers changes with throughput
+
+	// AverageJobDuration is the mean duration of the finished jobs,
+	// retries included
+	AverageJobDuration time.Duration `json:"average_job_duration"`
 }
 
 // String returns a string representation of the stats
@@ -375,6 +652,7 @@ type JobManager struct {
 	highPriorityJobs chan *cloning.CloneJob
 	normalJobs       chan *cloning.CloneJob
 	workerPool       *WorkerPool
+	pending          *inflightJobs // Destinations of the jobs still queued here
 	logger           shared.Logger
 	ctx              context.Context
 	cancel           context.CancelFunc
@@ -389,6 +667,7 @@ func NewJobManager(workerPool *WorkerPool, logger shared.Logger) *JobManager {
 		highPriorityJobs: make(chan *cloning.CloneJob, 100),
 		normalJobs:       make(chan *cloning.CloneJob, 1000),
 		workerPool:       workerPool,
+		pending:          newInflightJobs(),
 		logger:           logger,
 		ctx:              ctx,
 		cancel:           cancel,
@@ -403,28 +682,46 @@ func NewJobManager(workerPool *WorkerPool, logger shared.Logger) *JobManager {
 
 // SubmitHighPriorityJob submits a high priority job
 func (jm *JobManager) SubmitHighPriorityJob(job *cloning.CloneJob) error {
-	select {
-	case jm.highPriorityJobs <- job:
-		return nil
-	case <-jm.ctx.Done():
-		return fmt.Errorf("job manager is closed")
-	default:
-		return fmt.Errorf("high priority job queue is full")
-	}
+	return jm.enqueue(jm.highPriorityJobs, job, "high priority job queue is full")
 }
 
 // SubmitJob submits a normal priority job
 func (jm *JobManager) SubmitJob(job *cloning.CloneJob) error {
+	return jm.enqueue(jm.normalJobs, job, "job queue is full")
+}
+
+// enqueue queues a job unless it duplicates a job queued here or in the
+// worker pool. Queueing a job again does nothing; a different job with the
+// same destination is rejected with a DuplicateJobError.
+func (jm *JobManager) enqueue(queue chan *cloning.CloneJob, job *cloning.CloneJob, full string) error {
+	key, claimed, err := jm.pending.claim(job)
+	if err != nil || !claimed {
+		return err
+	}
+	if existing, ok := jm.workerPool.inflight.holder(key); ok && existing != job.ID {
+		jm.pending.release(key)
+		return &DuplicateJobError{JobID: job.ID, ExistingID: existing, Destination: key}
+	}
+
 	select {
-	case jm.normalJobs <- job:
+	case queue <- job:
 		return nil
 	case <-jm.ctx.Done():
+		jm.pending.release(key)
 		return fmt.Errorf("job manager is closed")
 	default:
-		return fmt.Errorf("job queue is full")
+		jm.pending.release(key)
+		return fmt.Errorf("%s", full)
 	}
 }
 
+// submit hands a queued job to the worker pool, which holds its destination
+// from then on
+func (jm *JobManager) submit(job *cloning.CloneJob) error {
+	defer jm.pending.release(destinationKey(job))
+	return jm.workerPool.SubmitJob(job)
+}
+
 // scheduleJobs handles job scheduling prioritization
 func (jm *JobManager) scheduleJobs() {
 	defer jm.wg.Done()
@@ -434,7 +731,7 @@ func (jm *JobManager) scheduleJobs() {
 		case <-jm.ctx.Done():
 			return
 		case job := <-jm.highPriorityJobs:
-			if err := jm.workerPool.SubmitJob(job); err != nil {
+			if err := jm.submit(job); err != nil {
 				jm.logger.Error("Failed to submit high priority job",
 					shared.StringField("job_id", job.ID),
 					shared.ErrorField(err))
@@ -444,7 +741,7 @@ func (jm *JobManager) scheduleJobs() {
 			select {
 			case highPriorityJob := <-jm.highPriorityJobs:
 				// Submit high priority job first
-				if err := jm.workerPool.SubmitJob(highPriorityJob); err != nil {
+				if err := jm.submit(highPriorityJob); err != nil {
 					jm.logger.Error("Failed to submit high priority job",
 						shared.StringField("job_id", highPriorityJob.ID),
 						shared.ErrorField(err))
@@ -453,12 +750,13 @@ func (jm *JobManager) scheduleJobs() {
 				select {
 				case jm.normalJobs <- job:
 				default:
+					jm.pending.release(destinationKey(job))
 					jm.logger.Warn("Normal job queue full, dropping job",
 						shared.StringField("job_id", job.ID))
 				}
 			default:
 				// No high priority jobs, submit normal job
-				if err := jm.workerPool.SubmitJob(job); err != nil {
+				if err := jm.submit(job); err != nil {
 					jm.logger.Error("Failed to submit job",
 						shared.StringField("job_id", job.ID),
 						shared.ErrorField(err))
@@ -0,0 +1,42 @@
+// Package diskspace reports the free space of file systems
+package diskspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// ErrUnsupported is returned on platforms without free space reporting
+var ErrUnsupported = errors.New("free disk space is not available on this platform")
+
+// Free returns the bytes available to the current user on the file system
+// holding path. A path that does not exist yet is measured at its closest
+// existing parent.
+func Free(path string) (uint64, error) {
+	existing, err := ExistingParent(path)
+	if err != nil {
+		return 0, err
+	}
+	return free(existing)
+}
+
+// ExistingParent returns path or its closest parent directory that exists
+func ExistingParent(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", errors.New("no existing parent directory")
+		}
+		path = parent
+	}
+}
@@ -0,0 +1,40 @@
+package diskspace
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExistingParent(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "existing directory", path: dir, want: dir},
+		{name: "missing child", path: filepath.Join(dir, "missing"), want: dir},
+		{name: "missing nested child", path: filepath.Join(dir, "a", "b", "c"), want: dir},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExistingParent(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFree(t *testing.T) {
+	available, err := Free(filepath.Join(t.TempDir(), "missing"))
+	if err == ErrUnsupported {
+		t.Skip(err)
+	}
+	require.NoError(t, err)
+	assert.Positive(t, available)
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package diskspace
+
+import "golang.org/x/sys/windows"
+
+func free(path string) (uint64, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var available, total, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &available, &total, &totalFree); err != nil {
+		return 0, err
+	}
+	return available, nil
+}
@@ -0,0 +1,364 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// Supported clone backend names
+const (
+	BackendGit   = "git"   // Executes the git binary
+	BackendGoGit = "gogit" // Pure Go implementation, no git binary required
+)
+
+// CloneBackend abstracts the mechanism used to clone repositories
+type CloneBackend interface {
+	// Name returns the backend identifier
+	Name() string
+
+	// CloneRepository clones a repository according to the job specifications
+	CloneRepository(ctx context.Context, job *cloning.CloneJob) error
+
+	// CloneRepositoryWithProgress clones a repository reporting transfer progress
+	CloneRepositoryWithProgress(ctx context.Context, job *cloning.CloneJob, onProgress cloning.TransferProgressFunc) error
+
+	// UpdateClone fetches and fast-forwards the existing clone of a job
+	UpdateClone(ctx context.Context, job *cloning.CloneJob) error
+
+	// GetRepositorySize returns the on-disk size of a cloned repository
+	GetRepositorySize(path string) (int64, error)
+
+	// Validate checks that the backend is usable in the current environment
+	Validate(ctx context.Context) error
+}
+
+// commitSHAPattern matches abbreviated and full hexadecimal commit IDs
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,64}$`)
+
+// isCommitSHA reports whether a ref looks like a commit ID rather than a tag name
+func isCommitSHA(ref string) bool {
+	return commitSHAPattern.MatchString(ref)
+}
+
+// NewCloneBackend creates the clone backend with the given name
+func NewCloneBackend(name string, config *GitClientConfig) (CloneBackend, error) {
+	switch strings.ToLower(name) {
+	case "", BackendGit:
+		return NewGitClient(config)
+	case BackendGoGit, "go-git":
+		if config.Routes.HasSSHRoutes() {
+			return nil, fmt.Errorf("SSH jump hosts and proxy commands are not supported by the %s backend, use --backend %s", BackendGoGit, BackendGit)
+		}
+		if len(config.ExtraArgs) > 0 {
+			return nil, fmt.Errorf("extra git clone arguments are not supported by the %s backend, use --backend %s", BackendGoGit, BackendGit)
+		}
+		backend, err := NewGoGitBackend(config)
+		if err != nil {
+			return nil, err
+		}
+		return backend, nil
+	default:
+		return nil, fmt.Errorf("unknown clone backend %q (supported: %s, %s)", name, BackendGit, BackendGoGit)
+	}
+}
+
+// prepareCloneDestination handles existing repositories and creates the parent
+// directory for a clone job
+func prepareCloneDestination(job *cloning.CloneJob, logger shared.Logger) error {
+	destPath := job.GetDestinationPath()
+
+	// A clone of a different remote, e.g. a same-named repository of another
+	// owner, is handled by the conflict policy
+	if actual, ok := conflictingRemote(job, destPath); ok {
+		expected := repository.CloneURLKey(job.Repository.CloneURL)
+
+		switch job.Options.OnConflict {
+		case cloning.ConflictError:
+			return &RemoteConflictError{Path: destPath, Expected: expected, Actual: actual}
+		case cloning.ConflictRename:
+			renamed, err := renameDestination(job)
+			if err != nil {
+				return err
+			}
+			logger.Warn("Destination holds a clone of another remote, cloning under another name",
+				shared.StringField("repo", job.Repository.GetFullName()),
+				shared.StringField("path", destPath),
+				shared.StringField("remote", actual),
+				shared.StringField("renamed_to", renamed))
+			destPath = renamed
+		default:
+			logger.Warn("Destination holds a clone of another remote, skipping",
+				shared.StringField("repo", job.Repository.GetFullName()),
+				shared.StringField("path", destPath),
+				shared.StringField("remote", actual))
+			return &RepositoryExistsError{Path: destPath, RemoteURL: actual}
+		}
+	}
+
+	// An existing repository is skipped, or replaced by cloneAtomically once
+	// the new clone is complete
+	if repositoryExistsAt(destPath) && job.Options.SkipExisting {
+		logger.Info("Repository already exists, skipping",
+			shared.StringField("repo", job.Repository.GetFullName()),
+			shared.StringField("path", destPath))
+		return &RepositoryExistsError{Path: destPath}
+	}
+
+	// Prepare destination directory
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	return nil
+}
+
+// ExistingClone reports whether the destination of a job already holds a
+// clone of the job's remote, which the backends skip when SkipExisting is
+// set. It only stats the destination and reads its git config.
+func ExistingClone(job *cloning.CloneJob) bool {
+	destPath := job.GetDestinationPath()
+	if !repositoryExistsAt(destPath) {
+		return false
+	}
+	_, conflict := conflictingRemote(job, destPath)
+	return !conflict
+}
+
+// partialSuffix marks the directories of clones in progress
+const partialSuffix = ".partial-"
+
+// replacedSuffix marks an existing clone moved aside while the new clone of
+// the same destination is renamed into place
+const replacedSuffix = ".replaced-"
+
+// partialCloneName matches the names of the partial directories of
+// partialClonePath, <name>.partial-job_<nanoseconds>_<sequence>
+var partialCloneName = regexp.MustCompile(`^.+\.partial-job_[0-9]+_[0-9]+$`)
+
+// partialCloneIdle is how long a partial clone must go unmodified before it
+// is taken for the leftover of an interrupted run rather than a clone another
+// process is still running
+const partialCloneIdle = time.Hour
+
+// clonesInProgress holds the absolute partial directories this process is
+// cloning into
+var clonesInProgress sync.Map
+
+// partialClonePath returns the directory a job is cloned into before it is
+// renamed to its destination, <dest>.partial-<job id>
+func partialClonePath(job *cloning.CloneJob) string {
+	return job.GetDestinationPath() + partialSuffix + job.ID
+}
+
+// isPartialClone reports whether a directory name is the one of a partial
+// clone
+func isPartialClone(name string) bool {
+	return partialCloneName.MatchString(name)
+}
+
+// errRecentChange stops isAbandonedPartial at the first recent modification
+var errRecentChange = errors.New("recently modified")
+
+// isAbandonedPartial reports whether the partial clone at path is left by an
+// interrupted run: this process is not cloning into it and nothing below it
+// changed for partialCloneIdle
+func isAbandonedPartial(path string) bool {
+	if _, running := clonesInProgress.Load(path); running {
+		return false
+	}
+
+	cutoff := time.Now().Add(-partialCloneIdle)
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip unreadable entries
+		}
+		if info, err := entry.Info(); err == nil && info.ModTime().After(cutoff) {
+			return errRecentChange
+		}
+		return nil
+	})
+	return !errors.Is(err, errRecentChange)
+}
+
+// cloneAtomically runs clone into a partial directory next to the job
+// destination and renames it into place once complete, so an interrupted
+// clone is never mistaken for an existing one. An existing repository at the
+// destination is kept until then: it is moved aside, removed once the new
+// clone is in place and restored when the rename fails.
+func cloneAtomically(job *cloning.CloneJob, clone func(path string) error) error {
+	partial := partialClonePath(job)
+	_ = os.RemoveAll(partial) // Left behind by a previous attempt
+
+	if abs, err := filepath.Abs(partial); err == nil {
+		clonesInProgress.Store(abs, struct{}{})
+		defer clonesInProgress.Delete(abs)
+	}
+
+	if err := clone(partial); err != nil {
+		_ = os.RemoveAll(partial)
+		return err
+	}
+
+	destPath := job.GetDestinationPath()
+	var replaced string
+	if repositoryExistsAt(destPath) {
+		replaced = destPath + replacedSuffix + job.ID
+		if err := os.Rename(destPath, replaced); err != nil {
+			_ = os.RemoveAll(partial)
+			return fmt.Errorf("failed to move existing repository aside: %w", err)
+		}
+	} else if stat, err := os.Stat(destPath); err == nil && stat.IsDir() {
+		_ = os.Remove(destPath) // Renaming replaces empty directories only on some platforms
+	}
+
+	if err := os.Rename(partial, destPath); err != nil {
+		_ = os.RemoveAll(partial)
+		if replaced != "" {
+			_ = os.Rename(replaced, destPath)
+		}
+		return fmt.Errorf("failed to move clone into place: %w", err)
+	}
+	if replaced != "" {
+		_ = os.RemoveAll(replaced)
+	}
+	return nil
+}
+
+// RemovePartialClones deletes the partial directories left below baseDir
+// (up to maxDepth levels deep) by clones that were interrupted, e.g. by a
+// crash, and returns their paths. Partial clones of this process and those
+// modified within partialCloneIdle are clones in progress and are kept.
+func RemovePartialClones(baseDir string, maxDepth int) ([]string, error) {
+	root, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base directory: %w", err)
+	}
+
+	var partials []string
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // Skip unreadable directories
+		}
+		if !entry.IsDir() || path == root {
+			return nil
+		}
+
+		if isPartialClone(entry.Name()) {
+			if isAbandonedPartial(path) {
+				partials = append(partials, path)
+			}
+			return filepath.SkipDir
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		if strings.HasPrefix(entry.Name(), ".") || repositoryExistsAt(path) ||
+			len(strings.Split(rel, string(os.PathSeparator))) >= maxDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", baseDir, err)
+	}
+
+	for _, partial := range partials {
+		if err := os.RemoveAll(partial); err != nil {
+			return nil, fmt.Errorf("failed to remove partial clone %s: %w", partial, err)
+		}
+	}
+	return partials, nil
+}
+
+// conflictingRemote returns the origin of the clone at path when it is a
+// different remote than the job's. Clones whose origin cannot be read are not
+// treated as conflicts.
+func conflictingRemote(job *cloning.CloneJob, path string) (string, bool) {
+	if !repositoryExistsAt(path) || job.Repository.CloneURL == "" {
+		return "", false
+	}
+
+	origin, err := OriginURL(path)
+	if err != nil || origin == "" {
+		return "", false
+	}
+
+	actual := repository.CloneURLKey(origin)
+	if actual == repository.CloneURLKey(job.Repository.CloneURL) {
+		return "", false
+	}
+	return actual, true
+}
+
+// maxRenameCandidates bounds the alternative names tried for a conflicting clone
+const maxRenameCandidates = 10
+
+// renameDestination points the job at <name>-<owner> next to its conflicting
+// destination, numbering the name when that is taken too. A candidate already
+// holding a clone of the same remote is reused.
+func renameDestination(job *cloning.CloneJob) (string, error) {
+	destPath := job.GetDestinationPath()
+	name := filepath.Base(destPath) + "-" + job.Repository.Owner
+
+	for i := 1; i <= maxRenameCandidates; i++ {
+		candidate := filepath.Join(filepath.Dir(destPath), name)
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", candidate, i)
+		}
+
+		if _, err := os.Stat(candidate); err == nil {
+			if _, conflict := conflictingRemote(job, candidate); conflict || !repositoryExistsAt(candidate) {
+				continue
+			}
+		}
+
+		relative, err := filepath.Rel(job.BaseDirectory, candidate)
+		if err != nil {
+			return "", fmt.Errorf("failed to relocate %s: %w", job.Repository.GetFullName(), err)
+		}
+		job.RelativePath = relative
+		return candidate, nil
+	}
+
+	return "", fmt.Errorf("no free destination for %s next to %s", job.Repository.GetFullName(), destPath)
+}
+
+// repositoryExistsAt checks if a repository, a working tree or a bare mirror,
+// already exists at the given path
+func repositoryExistsAt(path string) bool {
+	gitDir := filepath.Join(path, ".git")
+	if stat, err := os.Stat(gitDir); err == nil {
+		return stat.IsDir()
+	}
+	return isBareRepositoryAt(path)
+}
+
+// DirectorySize sums the size of all files below path
+func DirectorySize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+
+	return size, err
+}
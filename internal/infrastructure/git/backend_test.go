@@ -0,0 +1,286 @@
+package git
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// initClone creates a repository at path with the given origin
+func initClone(t *testing.T, path, origin string) {
+	t.Helper()
+	repo, err := gogit.PlainInit(path, false)
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{origin}})
+	require.NoError(t, err)
+}
+
+func TestPrepareCloneDestination_RemoteConflict(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       cloning.ConflictPolicy
+		existing     map[string]string // Relative path to origin URL
+		wantSkip     bool
+		wantConflict bool
+		wantPath     string
+	}{
+		{
+			name:     "same remote in another form is skipped",
+			policy:   cloning.ConflictError,
+			existing: map[string]string{"tools": "git@github.com:bob/tools.git"},
+			wantSkip: true,
+			wantPath: "tools",
+		},
+		{
+			name:     "different remote is skipped",
+			policy:   cloning.ConflictSkip,
+			existing: map[string]string{"tools": "https://github.com/alice/tools.git"},
+			wantSkip: true,
+			wantPath: "tools",
+		},
+		{
+			name:         "different remote fails",
+			policy:       cloning.ConflictError,
+			existing:     map[string]string{"tools": "https://github.com/alice/tools.git"},
+			wantConflict: true,
+			wantPath:     "tools",
+		},
+		{
+			name:     "different remote is renamed",
+			policy:   cloning.ConflictRename,
+			existing: map[string]string{"tools": "https://github.com/alice/tools.git"},
+			wantPath: "tools-bob",
+		},
+		{
+			name:   "taken rename is numbered",
+			policy: cloning.ConflictRename,
+			existing: map[string]string{
+				"tools":     "https://github.com/alice/tools.git",
+				"tools-bob": "https://github.com/carol/tools.git",
+			},
+			wantPath: "tools-bob-2",
+		},
+		{
+			name:   "earlier rename is reused",
+			policy: cloning.ConflictRename,
+			existing: map[string]string{
+				"tools":     "https://github.com/alice/tools.git",
+				"tools-bob": "https://github.com/bob/tools.git",
+			},
+			wantSkip: true,
+			wantPath: "tools-bob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir := t.TempDir()
+			for path, origin := range tt.existing {
+				initClone(t, filepath.Join(baseDir, path), origin)
+			}
+
+			repo, err := repository.NewRepository(1, "tools", "https://github.com/bob/tools.git", "bob", false, 0, "main")
+			require.NoError(t, err)
+			options := cloning.NewDefaultCloneOptions()
+			options.OnConflict = tt.policy
+			job := cloning.NewCloneJob(repo, baseDir, options)
+
+			err = prepareCloneDestination(job, logging.NewNoOpLogger())
+
+			var existsErr *RepositoryExistsError
+			var conflictErr *RemoteConflictError
+			assert.Equal(t, tt.wantSkip, errors.As(err, &existsErr), "skip: %v", err)
+			assert.Equal(t, tt.wantConflict, errors.As(err, &conflictErr), "conflict: %v", err)
+			if !tt.wantSkip && !tt.wantConflict {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, filepath.Join(baseDir, tt.wantPath), job.GetDestinationPath())
+		})
+	}
+}
+
+func TestCloneAtomically(t *testing.T) {
+	repo, err := repository.NewRepository(1, "tools", "https://github.com/bob/tools.git", "bob", false, 0, "main")
+	require.NoError(t, err)
+
+	t.Run("renamed into place on success", func(t *testing.T) {
+		job := cloning.NewCloneJob(repo, t.TempDir(), nil)
+
+		err := cloneAtomically(job, func(path string) error {
+			assert.Equal(t, partialClonePath(job), path)
+			assert.NoDirExists(t, job.GetDestinationPath())
+			return os.MkdirAll(filepath.Join(path, ".git"), 0755)
+		})
+
+		require.NoError(t, err)
+		assert.DirExists(t, filepath.Join(job.GetDestinationPath(), ".git"))
+		assert.NoDirExists(t, partialClonePath(job))
+	})
+
+	t.Run("nothing left on failure", func(t *testing.T) {
+		job := cloning.NewCloneJob(repo, t.TempDir(), nil)
+
+		err := cloneAtomically(job, func(path string) error {
+			require.NoError(t, os.MkdirAll(filepath.Join(path, ".git"), 0755))
+			return errors.New("connection reset")
+		})
+
+		require.Error(t, err)
+		assert.NoDirExists(t, job.GetDestinationPath())
+		assert.NoDirExists(t, partialClonePath(job))
+	})
+}
+
+func TestCloneAtomically_ReplacesExistingClone(t *testing.T) {
+	repo, err := repository.NewRepository(1, "tools", "https://github.com/bob/tools.git", "bob", false, 0, "main")
+	require.NoError(t, err)
+	options := cloning.NewDefaultCloneOptions()
+	options.SkipExisting = false
+
+	newJob := func(t *testing.T) *cloning.CloneJob {
+		job := cloning.NewCloneJob(repo, t.TempDir(), options)
+		initClone(t, job.GetDestinationPath(), repo.CloneURL)
+		require.NoError(t, os.WriteFile(filepath.Join(job.GetDestinationPath(), "old"), nil, 0644))
+		require.NoError(t, prepareCloneDestination(job, logging.NewNoOpLogger()))
+		return job
+	}
+
+	t.Run("kept until the new clone succeeds", func(t *testing.T) {
+		job := newJob(t)
+
+		err := cloneAtomically(job, func(path string) error {
+			assert.FileExists(t, filepath.Join(job.GetDestinationPath(), "old"), "the existing clone stays while cloning")
+			require.NoError(t, os.MkdirAll(filepath.Join(path, ".git"), 0755))
+			return os.WriteFile(filepath.Join(path, "new"), nil, 0644)
+		})
+
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(job.GetDestinationPath(), "new"))
+		assert.NoFileExists(t, filepath.Join(job.GetDestinationPath(), "old"))
+		assert.NoDirExists(t, job.GetDestinationPath()+replacedSuffix+job.ID)
+	})
+
+	t.Run("kept when the new clone fails", func(t *testing.T) {
+		job := newJob(t)
+
+		err := cloneAtomically(job, func(path string) error {
+			require.NoError(t, os.MkdirAll(filepath.Join(path, ".git"), 0755))
+			return errors.New("connection reset")
+		})
+
+		require.Error(t, err)
+		assert.FileExists(t, filepath.Join(job.GetDestinationPath(), "old"))
+		assert.NoDirExists(t, partialClonePath(job))
+	})
+}
+
+func TestRemovePartialClones(t *testing.T) {
+	baseDir := t.TempDir()
+	for _, dir := range []string{
+		"tools/.git",
+		"tools.partial-job_1_1/.git",
+		"bob/api.partial-job_2_2/.git",
+		"bob/api/.git",
+		"bob/cli.partial-job_4_4/.git",   // This process' clone
+		"carol/notes.partial-draft/.git", // Not a partial clone
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(baseDir, dir), 0755))
+	}
+	ageTree(t, baseDir, 2*partialCloneIdle)
+	// Recently modified, another run's clone in progress
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "bob", "web.partial-job_3_3", ".git"), 0755))
+	inProgress := filepath.Join(baseDir, "bob", "cli.partial-job_4_4")
+	clonesInProgress.Store(inProgress, struct{}{})
+	defer clonesInProgress.Delete(inProgress)
+
+	removed, err := RemovePartialClones(baseDir, 3)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		filepath.Join(baseDir, "tools.partial-job_1_1"),
+		filepath.Join(baseDir, "bob", "api.partial-job_2_2"),
+	}, removed)
+	assert.NoDirExists(t, filepath.Join(baseDir, "tools.partial-job_1_1"))
+	assert.DirExists(t, filepath.Join(baseDir, "tools", ".git"))
+	assert.DirExists(t, filepath.Join(baseDir, "bob", "api", ".git"))
+	assert.DirExists(t, filepath.Join(baseDir, "bob", "web.partial-job_3_3"))
+	assert.DirExists(t, inProgress)
+	assert.DirExists(t, filepath.Join(baseDir, "carol", "notes.partial-draft"))
+}
+
+// ageTree sets the modification time of everything below path age back
+func ageTree(t *testing.T, path string, age time.Duration) {
+	t.Helper()
+	stale := time.Now().Add(-age)
+	require.NoError(t, filepath.WalkDir(path, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, stale, stale)
+	}))
+}
+
+func TestFindLeftovers(t *testing.T) {
+	baseDir := t.TempDir()
+	for _, dir := range []string{
+		"alice/tools/.git",               // Empty .git of a failed clone
+		"alice/api.partial-job_1_1/.git", // Interrupted clone
+		"bob/empty/nested",               // Not a clone
+		"carol/api/.git/objects",
+		"carol/web",                       // Not a clone
+		"carol/cli.partial-job_2_2/.git",  // Clone in progress
+		"dave/notes.partial-draft/drafts", // Not a partial clone
+		"erin/github/web/.git",            // Empty clone below provider and owner folders
+		".cache/empty",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(baseDir, dir), 0755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "carol", "api", ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0644))
+	ageTree(t, baseDir, 2*partialCloneIdle)
+	require.NoError(t, os.Chtimes(filepath.Join(baseDir, "carol", "cli.partial-job_2_2"), time.Now(), time.Now()))
+
+	leftovers, err := FindLeftovers(baseDir, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(baseDir, "alice", "api.partial-job_1_1")}, leftovers.Partials)
+	assert.Equal(t, []string{
+		filepath.Join(baseDir, "alice"),
+		filepath.Join(baseDir, "erin"),
+	}, leftovers.EmptyDirs)
+
+	require.NoError(t, leftovers.Remove())
+	assert.NoDirExists(t, filepath.Join(baseDir, "alice"))
+	assert.NoDirExists(t, filepath.Join(baseDir, "erin"))
+	assert.DirExists(t, filepath.Join(baseDir, "bob", "empty", "nested"))
+	assert.DirExists(t, filepath.Join(baseDir, "carol", "web"))
+	assert.DirExists(t, filepath.Join(baseDir, "carol", "cli.partial-job_2_2"))
+	assert.DirExists(t, filepath.Join(baseDir, "dave", "notes.partial-draft"))
+	assert.FileExists(t, filepath.Join(baseDir, "carol", "api", ".git", "HEAD"))
+	assert.DirExists(t, filepath.Join(baseDir, ".cache"))
+}
+
+func TestPruneEmptyParents(t *testing.T) {
+	baseDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "acme", "api"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "bob", "tools"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "bob", "README"), nil, 0644))
+
+	removed := PruneEmptyParents(filepath.Join(baseDir, "acme", "api", "missing"), baseDir)
+	assert.Equal(t, []string{filepath.Join(baseDir, "acme", "api"), filepath.Join(baseDir, "acme")}, removed)
+
+	removed = PruneEmptyParents(filepath.Join(baseDir, "bob", "tools"), baseDir)
+	assert.Equal(t, []string{filepath.Join(baseDir, "bob", "tools")}, removed)
+	assert.DirExists(t, filepath.Join(baseDir, "bob"))
+	assert.DirExists(t, baseDir)
+}
@@ -0,0 +1,123 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// minBandwidthBurst is the smallest burst allowed so reads are not split too finely
+const minBandwidthBurst = 32 * 1024
+
+// BandwidthLimiter is a token bucket shared by all transfers of a backend,
+// capping the aggregate download rate
+type BandwidthLimiter struct {
+	mu         sync.Mutex
+	rate       float64 // Bytes per second
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+// NewBandwidthLimiter creates a limiter allowing bytesPerSecond of throughput
+func NewBandwidthLimiter(bytesPerSecond int64) *BandwidthLimiter {
+	burst := float64(bytesPerSecond)
+	if burst < minBandwidthBurst {
+		burst = minBandwidthBurst
+	}
+
+	return &BandwidthLimiter{
+		rate:       float64(bytesPerSecond),
+		burst:      burst,
+		tokens:     burst,
+		lastRefill: time.Now(),
+	}
+}
+
+// Burst returns the largest number of bytes that can be consumed at once
+func (l *BandwidthLimiter) Burst() int {
+	return int(l.burst)
+}
+
+// WaitN blocks until n bytes may be consumed or the context is cancelled
+func (l *BandwidthLimiter) WaitN(ctx context.Context, n int) error {
+	// Requests larger than the bucket could never be satisfied
+	if float64(n) > l.burst {
+		n = int(l.burst)
+	}
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.lastRefill).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.lastRefill = now
+
+		if l.tokens >= float64(n) {
+			l.tokens -= float64(n)
+			l.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((float64(n) - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// bitRate matches rates in bits per second, spelled with a lowercase "b" such
+// as "10Mbps", "100kb/s" or "1Gib"
+var bitRate = regexp.MustCompile(`^([0-9]*\.?[0-9]+)\s*([kKmMgGtT]?)(i?)b(?:ps|/s)?$`)
+
+// ParseBandwidth parses a human readable rate such as "10MB", "512KiB/s" or
+// "1.5M" into bytes per second. Plain numbers are interpreted as bytes; a
+// lowercase "b" unit such as "10Mbps" is in bits, with decimal prefixes
+// unless written as "Mib".
+func ParseBandwidth(value string) (int64, error) {
+	raw := strings.TrimSpace(value)
+	if match := bitRate.FindStringSubmatch(raw); match != nil {
+		return parseBitRate(value, match)
+	}
+	size, err := cloning.ParseSize(strings.TrimSuffix(strings.TrimSuffix(raw, "/s"), "ps"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth %q: expected a positive size such as 10MB", value)
+	}
+	return size, nil
+}
+
+// parseBitRate converts a bitRate match into bytes per second
+func parseBitRate(value string, match []string) (int64, error) {
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth %q: %w", value, err)
+	}
+	unit := 1000.0
+	if match[3] != "" {
+		unit = 1024
+	}
+	bits := number
+	if match[2] != "" {
+		bits *= math.Pow(unit, float64(strings.Index("kmgt", strings.ToLower(match[2]))+1))
+	}
+	bytes := bits / 8
+	if bytes < 1 || bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid bandwidth %q: expected at least one byte per second", value)
+	}
+	return int64(bytes), nil
+}
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{"10MB", 10 * 1024 * 1024, false},
+		{"512KiB/s", 512 * 1024, false},
+		{"1.5M", 1572864, false},
+		{"2g", 2 * 1024 * 1024 * 1024, false},
+		{"4096", 4096, false},
+		{"10MBps", 10 * 1024 * 1024, false},
+		{"10MB/s", 10 * 1024 * 1024, false},
+		{"10Mbps", 10 * 1000 * 1000 / 8, false},
+		{"10Mb/s", 10 * 1000 * 1000 / 8, false},
+		{"100kbps", 100 * 1000 / 8, false},
+		{"8Mib", 1024 * 1024, false},
+		{"800bps", 100, false},
+		{"1bps", 0, true},
+		{"NaN", 0, true},
+		{"Inf", 0, true},
+		{"-Inf", 0, true},
+		{"0.5", 0, true},
+		{"1e30TB", 0, true},
+		{"99999999999999999999Tbps", 0, true},
+		{"", 0, true},
+		{"fast", 0, true},
+		{"-1MB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseBandwidth(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestBandwidthLimiter_WaitN(t *testing.T) {
+	limiter := NewBandwidthLimiter(64 * 1024)
+
+	// The initial burst is available immediately
+	start := time.Now()
+	require.NoError(t, limiter.WaitN(context.Background(), 64*1024))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+
+	// The next 16KiB need roughly a quarter of a second to refill
+	start = time.Now()
+	require.NoError(t, limiter.WaitN(context.Background(), 16*1024))
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestBandwidthLimiter_ContextCancelled(t *testing.T) {
+	limiter := NewBandwidthLimiter(1024)
+	require.NoError(t, limiter.WaitN(context.Background(), limiter.Burst()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.WaitN(ctx, 1024), context.Canceled)
+}
+
+func TestGoGitBackend_BandwidthLimitRejectsSSH(t *testing.T) {
+	backend, err := NewGoGitBackend(&GitClientConfig{Logger: logging.NewNoOpLogger(), MaxBandwidth: 1 << 20})
+	require.NoError(t, err)
+
+	repo, err := repository.NewRepository(1, "tools", "ssh://git@github.com/bob/tools.git", "bob", false, 0, "main")
+	require.NoError(t, err)
+	job := cloning.NewCloneJob(repo, t.TempDir(), nil)
+
+	err = backend.CloneRepository(context.Background(), job)
+	var unsupported *UnsupportedOptionError
+	require.ErrorAs(t, err, &unsupported)
+	assert.True(t, NewGitValidator(logging.NewNoOpLogger()).IsPermanentError(err), "not retried")
+	assert.ErrorAs(t, backend.UpdateClone(context.Background(), job), &unsupported)
+}
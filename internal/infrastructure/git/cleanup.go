@@ -0,0 +1,148 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Leftovers are the directories failed and interrupted clones leave behind in
+// a clone tree
+type Leftovers struct {
+	Partials  []string // Partial clones of interrupted runs
+	EmptyDirs []string // Clone destinations holding no files and the owner folders holding only those
+}
+
+// FindLeftovers returns the abandoned partial clones and the empty clone
+// directories below baseDir (up to maxDepth levels deep), sorted by path.
+// Repositories whose tree holds no file at all, such as an empty .git left by
+// a failed clone, count as empty; a directory holding nothing but such
+// leftovers, e.g. an owner folder, is listed instead of its contents. Other
+// empty directories are not clone leftovers and are never listed, nor is
+// baseDir itself.
+func FindLeftovers(baseDir string, maxDepth int) (*Leftovers, error) {
+	root, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base directory: %w", err)
+	}
+	if _, err := os.ReadDir(root); err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", baseDir, err)
+	}
+
+	scan := &leftoverScan{maxDepth: maxDepth}
+	_, empty := scan.visit(root, 0)
+	leftovers := &Leftovers{Partials: scan.partials, EmptyDirs: empty}
+	sort.Strings(leftovers.Partials)
+	sort.Strings(leftovers.EmptyDirs)
+	return leftovers, nil
+}
+
+// Remove deletes the leftovers, partial clones first
+func (l *Leftovers) Remove() error {
+	for _, path := range append(append([]string{}, l.Partials...), l.EmptyDirs...) {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// leftoverScan walks a clone tree collecting partial clones
+type leftoverScan struct {
+	maxDepth int
+	partials []string
+}
+
+// visit scans the directory at depth and returns whether it holds leftovers
+// and nothing else, with its empty clone directories when it does not
+func (s *leftoverScan) visit(dir string, depth int) (bool, []string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, nil // Unreadable directories are kept
+	}
+
+	removable, found := true, false
+	var empty []string
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		switch {
+		case !entry.IsDir():
+			removable = false
+		case isPartialClone(entry.Name()):
+			if isAbandonedPartial(path) {
+				s.partials = append(s.partials, path)
+				found = true
+			} else {
+				removable = false // Clone in progress
+			}
+		case strings.HasPrefix(entry.Name(), "."):
+			removable = false
+		case repositoryExistsAt(path):
+			if holdsFiles(path) {
+				removable = false
+			} else {
+				empty = append(empty, path)
+				found = true
+			}
+		case depth+1 >= s.maxDepth:
+			removable = false
+		default:
+			if childRemovable, nested := s.visit(path, depth+1); childRemovable {
+				empty = append(empty, path)
+				found = true
+			} else {
+				removable = false
+				empty = append(empty, nested...)
+			}
+		}
+	}
+	return removable && found, empty
+}
+
+// errFileFound stops holdsFiles at the first file
+var errFileFound = errors.New("file found")
+
+// holdsFiles reports whether any file, or anything unreadable, is below path
+func holdsFiles(path string) bool {
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return errFileFound
+		}
+		return nil
+	})
+	return err != nil
+}
+
+// PruneEmptyParents removes path when it is an empty directory, then each of
+// its parents that is left empty, up to but excluding baseDir, and returns
+// the directories removed
+func PruneEmptyParents(path, baseDir string) []string {
+	root, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil
+	}
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+
+	var removed []string
+	for dir != root && strings.HasPrefix(dir, root+string(os.PathSeparator)) {
+		stat, err := os.Lstat(dir)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil || !stat.IsDir():
+			return removed
+		case os.Remove(dir) != nil:
+			return removed // Not empty
+		default:
+			removed = append(removed, dir)
+		}
+		dir = filepath.Dir(dir)
+	}
+	return removed
+}
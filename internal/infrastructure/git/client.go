@@ -1,35 +1,73 @@
 package git
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/italoag/repocloner/internal/domain/cloning"
 	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/network"
+	"github.com/italoag/repocloner/internal/infrastructure/redact"
 )
 
 // GitClient handles Git operations
 type GitClient struct {
-	gitPath   string
-	timeout   time.Duration
-	logger    shared.Logger
-	validator *GitValidator
+	gitPath     string
+	timeout     time.Duration
+	logger      shared.Logger
+	validator   *GitValidator
+	credentials *CredentialStore
+	jobLogDir   string
+	networkArgs []string // Proxy and CA options of every git command reaching a remote
+	extraArgs   []string // Extra git clone arguments, see ParseCloneArgs
+	routes      *RouteTable
+
+	// supportsRevision is set when the installed git understands `clone --revision`
+	supportsRevision atomic.Bool
+	// lacksPartialClone is set when the validated git is too old for `clone --filter`
+	lacksPartialClone atomic.Bool
 }
 
+// minRevisionGitVersion is the first git release supporting `git clone --revision`
+var minRevisionGitVersion = [2]int{2, 49}
+
+// minPartialCloneGitVersion is the first git release supporting the blob and
+// tree partial clone filters
+var minPartialCloneGitVersion = [2]int{2, 20}
+
+// DefaultTimeout limits every clone or update attempt unless configured
+const DefaultTimeout = 10 * time.Minute
+
 // GitClientConfig holds configuration for Git client
 type GitClientConfig struct {
-	GitPath string
-	Timeout time.Duration
-	Logger  shared.Logger
+	GitPath      string
+	Timeout      time.Duration // Limit of every clone or update attempt, DefaultTimeout if zero
+	Logger       shared.Logger
+	Credentials  *CredentialStore // Optional per-provider HTTPS credentials
+	MaxBandwidth int64            // Aggregate download cap in bytes/sec (gogit backend only)
+	JobLogDir    string           // Directory for per-repository git output logs, empty disables them
+	Network      *network.Config  // Optional proxy and extra CAs for HTTPS remotes
+	Hosts        HostPolicy       // Clone URL hosts accepted besides the public providers
+	Routes       *RouteTable      // Optional proxies and SSH jump hosts by remote host
+	ExtraArgs    []string         // Extra git clone arguments checked by ParseCloneArgs (git backend only)
 }
 
 // NewGitClient creates a new Git client
 func NewGitClient(config *GitClientConfig) (*GitClient, error) {
+	if config.MaxBandwidth > 0 {
+		return nil, fmt.Errorf("bandwidth limiting is not supported by the %s backend, use --backend %s", BackendGit, BackendGoGit)
+	}
+
 	if config.GitPath == "" {
 		gitPath, err := exec.LookPath("git")
 		if err != nil {
@@ -39,49 +77,92 @@ func NewGitClient(config *GitClientConfig) (*GitClient, error) {
 	}
 
 	if config.Timeout == 0 {
-		config.Timeout = 10 * time.Minute // Default timeout for clone operations
+		config.Timeout = DefaultTimeout
 	}
 
 	validator := NewGitValidator(config.Logger)
+	validator.SetHostPolicy(config.Hosts)
 
 	return &GitClient{
-		gitPath:   config.GitPath,
-		timeout:   config.Timeout,
-		logger:    config.Logger,
-		validator: validator,
+		gitPath:     config.GitPath,
+		timeout:     config.Timeout,
+		logger:      config.Logger,
+		validator:   validator,
+		credentials: config.Credentials,
+		jobLogDir:   config.JobLogDir,
+		networkArgs: config.Network.GitArgs(),
+		extraArgs:   config.ExtraArgs,
+		routes:      config.Routes,
 	}, nil
 }
 
+// Name returns the backend identifier
+func (g *GitClient) Name() string {
+	return BackendGit
+}
+
+// contextLogger returns the logger of ctx, e.g. one carrying the job fields
+// of the worker pool, or the client logger
+func (g *GitClient) contextLogger(ctx context.Context) shared.Logger {
+	return shared.GetLogger(ctx, g.logger)
+}
+
+// Validate checks that the git binary is installed and usable
+func (g *GitClient) Validate(ctx context.Context) error {
+	return g.ValidateGitInstallation(ctx)
+}
+
 // CloneRepository clones a repository according to the job specifications
 func (g *GitClient) CloneRepository(ctx context.Context, job *cloning.CloneJob) error {
+	return g.CloneRepositoryWithProgress(ctx, job, nil)
+}
+
+// CloneRepositoryWithProgress clones a repository, parsing `git clone --progress`
+// output into transfer progress updates when a callback is provided
+func (g *GitClient) CloneRepositoryWithProgress(ctx context.Context, job *cloning.CloneJob, onProgress cloning.TransferProgressFunc) error {
 	if err := g.validator.ValidateCloneJob(job); err != nil {
 		return fmt.Errorf("invalid clone job: %w", err)
 	}
+	if job.Options.Filter != cloning.FilterNone && g.lacksPartialClone.Load() {
+		return fmt.Errorf("partial clone filter %s requires git %d.%d or newer",
+			job.Options.Filter, minPartialCloneGitVersion[0], minPartialCloneGitVersion[1])
+	}
 
-	destPath := job.GetDestinationPath()
+	if err := prepareCloneDestination(job, g.contextLogger(ctx)); err != nil {
+		return err
+	}
 
-	// Check if repository already exists and handle accordingly
-	if g.repositoryExists(destPath) {
-		if job.Options.SkipExisting {
-			g.logger.Info("Repository already exists, skipping",
-				shared.StringField("repo", job.Repository.GetFullName()),
-				shared.StringField("path", destPath))
-			return &RepositoryExistsError{Path: destPath}
-		}
+	log, err := openJobLog(g.jobLogDir, job)
+	if err != nil {
+		g.contextLogger(ctx).Warn("Job log unavailable",
+			shared.StringField("repo", job.Repository.GetFullName()),
+			shared.ErrorField(err))
+		log = nopWriteCloser{io.Discard}
+	}
 
-		// Remove existing directory if not skipping
-		if err := os.RemoveAll(destPath); err != nil {
-			return fmt.Errorf("failed to remove existing repository: %w", err)
+	err = cloneAtomically(job, func(path string) error {
+		if err := g.clone(ctx, job, path, onProgress, log); err != nil {
+			return err
 		}
-	}
+		recordCloneProvenance(path, job, g.contextLogger(ctx))
+		configureUpstream(path, job, g.contextLogger(ctx))
+		return nil
+	})
+	closeJobLog(log, err)
+	return err
+}
 
-	// Prepare destination directory
-	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-		return fmt.Errorf("failed to create destination directory: %w", err)
-	}
+// clone runs git clone into destPath and the follow-up ref checkout and
+// submodule initialization, copying git output into the job log
+func (g *GitClient) clone(ctx context.Context, job *cloning.CloneJob, destPath string, onProgress cloning.TransferProgressFunc, log io.Writer) error {
 
 	// Build git clone command
-	args := g.buildCloneArgs(job)
+	authArgs, authEnv, err := g.remoteOptions(ctx, job.Repository.CloneURL)
+	if err != nil {
+		return &AuthenticationError{Message: fmt.Sprintf("failed to resolve credentials: %v", err)}
+	}
+	cloneArgs := g.buildCloneArgs(job, destPath, onProgress != nil)
+	args := append(authArgs, cloneArgs...)
 
 	// Create context with timeout
 	cloneCtx, cancel := context.WithTimeout(ctx, g.timeout)
@@ -90,29 +171,96 @@ func (g *GitClient) CloneRepository(ctx context.Context, job *cloning.CloneJob)
 	// Execute git clone
 	cmd := exec.CommandContext(cloneCtx, g.gitPath, args...)
 	cmd.Dir = filepath.Dir(destPath)
+	detachProcessGroup(cmd)
+	if job.Options.SkipLFS {
+		// Git LFS leaves pointer files in place of the objects
+		authEnv = append(authEnv, "GIT_LFS_SKIP_SMUDGE=1")
+	}
+	if len(authEnv) > 0 {
+		cmd.Env = append(os.Environ(), authEnv...)
+	}
 
-	// Capture output for debugging
-	output, err := cmd.CombinedOutput()
+	// Capture output for debugging, streaming stderr through the progress parser
+	var outputBuffer bytes.Buffer
+	var writer *progressWriter
+	output := io.MultiWriter(&outputBuffer, log)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if onProgress != nil {
+		writer = newProgressWriter(job, onProgress)
+		cmd.Stderr = io.MultiWriter(output, writer)
+	}
+
+	logCommand(log, cloneArgs)
+
+	err = cmd.Run()
+	if err != nil && ctx.Err() != nil {
+		// git is killed on cancellation and cannot remove the partial clone itself
+		_ = os.RemoveAll(destPath)
+		return fmt.Errorf("git clone interrupted: %w", ctx.Err())
+	}
 	if err != nil {
-		g.logger.Error("Git clone failed",
+		if timeoutErr := jobTimeout(ctx, cloneCtx, "clone", g.timeout); timeoutErr != nil {
+			// git is killed on timeout too and leaves the partial clone behind
+			_ = os.RemoveAll(destPath)
+			return timeoutErr
+		}
+
+		g.contextLogger(ctx).Error("Git clone failed",
 			shared.StringField("repo", job.Repository.GetFullName()),
-			shared.StringField("output", string(output)),
+			shared.StringField("output", outputBuffer.String()),
 			shared.ErrorField(err))
 
 		// Parse git errors for better error messages
-		return g.parseGitError(err, string(output))
+		return g.parseGitError(err, outputBuffer.String())
+	}
+
+	if writer != nil {
+		writer.Flush()
+	}
+
+	if len(job.Options.SparsePaths) > 0 {
+		args := append(slices.Clone(authArgs), "-C", destPath, "sparse-checkout", "set")
+		if output, err := g.runGit(cloneCtx, cmd.Env, log, append(args, job.Options.SparsePaths...)...); err != nil {
+			_ = os.RemoveAll(destPath)
+			return g.cloneStepError(ctx, cloneCtx, g.parseGitError(err, output))
+		}
+	}
+
+	if g.needsRefCheckout(job) {
+		if err := g.checkoutRef(cloneCtx, job, destPath, authArgs, cmd.Env, log); err != nil {
+			// Leave no clone at the wrong revision behind so retries start clean
+			_ = os.RemoveAll(destPath)
+			return g.cloneStepError(ctx, cloneCtx, err)
+		}
+	}
+
+	if job.Options.RecurseSubmodules && !g.recurseSubmodulesDuringClone(job) {
+		if err := g.updateSubmodules(cloneCtx, destPath, job.Options, authArgs, cmd.Env, log, 1); err != nil {
+			_ = os.RemoveAll(destPath)
+			return g.cloneStepError(ctx, cloneCtx, err)
+		}
 	}
 
-	g.logger.Info("Repository cloned successfully",
+	g.contextLogger(ctx).Info("Repository cloned successfully",
 		shared.StringField("repo", job.Repository.GetFullName()),
-		shared.StringField("path", destPath),
+		shared.StringField("path", job.GetDestinationPath()),
 		shared.DurationField("duration", job.Duration()))
 
 	return nil
 }
 
+// cloneStepError reports a failed step after git clone as a timeout when the
+// per-job timeout expired during the step
+func (g *GitClient) cloneStepError(ctx, cloneCtx context.Context, err error) error {
+	if timeoutErr := jobTimeout(ctx, cloneCtx, "clone", g.timeout); timeoutErr != nil {
+		return timeoutErr
+	}
+	return err
+}
+
 // buildCloneArgs builds the arguments for git clone command
-func (g *GitClient) buildCloneArgs(job *cloning.CloneJob) []string {
+func (g *GitClient) buildCloneArgs(job *cloning.CloneJob, destPath string, withProgress bool) []string {
 	args := []string{"clone"}
 
 	// Add depth if specified (shallow clone)
@@ -125,28 +273,177 @@ func (g *GitClient) buildCloneArgs(job *cloning.CloneJob) []string {
 		args = append(args, "--branch", job.Options.Branch)
 	}
 
+	// Newer git versions can clone a tag or commit directly
+	if job.Options.Ref != "" && !g.needsRefCheckout(job) {
+		args = append(args, "--revision", job.Options.Ref)
+	}
+
+	// A partial clone fetches the filtered objects on demand, and a sparse
+	// checkout only needs the blobs of the selected directories
+	filter := job.Options.Filter
+	if filter == cloning.FilterNone && len(job.Options.SparsePaths) > 0 {
+		filter = cloning.FilterBlobless
+	}
+	if filter != cloning.FilterNone {
+		args = append(args, "--filter="+string(filter))
+	}
+	if len(job.Options.SparsePaths) > 0 {
+		args = append(args, "--sparse")
+	}
+
 	// Add recurse submodules if specified
-	if job.Options.RecurseSubmodules {
+	if g.recurseSubmodulesDuringClone(job) {
 		args = append(args, "--recurse-submodules")
+		if job.Options.ShallowSubmodules {
+			args = append(args, "--shallow-submodules")
+		}
 	}
 
 	// Add other useful options
 	args = append(args, "--no-hardlinks") // Don't use hardlinks
-	args = append(args, "--quiet")        // Minimize output
+	if withProgress {
+		args = append(args, "--progress") // Force progress output even without a terminal
+	} else {
+		args = append(args, "--quiet") // Minimize output
+	}
+
+	// Extra arguments come last, so they take precedence over the options above
+	args = append(args, g.extraArgs...)
 
 	// Add URL and destination
-	args = append(args, job.Repository.CloneURL, job.GetDestinationPath())
+	args = append(args, job.Repository.CloneURL, destPath)
 
 	return args
 }
 
+// recurseSubmodulesDuringClone reports whether submodules can be cloned by
+// `git clone --recurse-submodules`. A nesting limit or a ref checkout requires
+// initializing them afterwards instead.
+func (g *GitClient) recurseSubmodulesDuringClone(job *cloning.CloneJob) bool {
+	return job.Options.RecurseSubmodules && job.Options.SubmoduleDepth == 0 && !g.needsRefCheckout(job)
+}
+
+// updateSubmodules initializes the submodules of the repository at path, one
+// nesting level at a time until options.SubmoduleDepth is reached
+func (g *GitClient) updateSubmodules(ctx context.Context, path string, options *cloning.CloneOptions, authArgs, env []string, log io.Writer, level int) error {
+	args := append(append([]string{}, authArgs...), "-C", path, "submodule", "update", "--init", "--quiet")
+	if options.ShallowSubmodules {
+		args = append(args, "--depth", "1")
+	}
+	if output, err := g.runGit(ctx, env, log, args...); err != nil {
+		return g.parseGitError(err, output)
+	}
+
+	if options.SubmoduleDepth > 0 && level >= options.SubmoduleDepth {
+		return nil
+	}
+
+	output, err := g.runGit(ctx, env, nil, "-C", path, "submodule", "foreach", "--quiet", "echo $sm_path")
+	if err != nil {
+		return g.parseGitError(err, output)
+	}
+	for _, submodulePath := range strings.Split(strings.TrimSpace(output), "\n") {
+		if submodulePath == "" {
+			continue
+		}
+		if err := g.updateSubmodules(ctx, filepath.Join(path, submodulePath), options, authArgs, env, log, level+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// needsRefCheckout reports whether the requested ref must be checked out after
+// cloning instead of being passed to `git clone --revision`
+func (g *GitClient) needsRefCheckout(job *cloning.CloneJob) bool {
+	if job.Options.Ref == "" {
+		return false
+	}
+	// --revision always detaches HEAD, so keep the branch checked out when one was requested
+	return job.Options.Branch != "" || !g.supportsRevision.Load()
+}
+
+// checkoutRef moves a fresh clone at destPath to job.Options.Ref. When a
+// branch was requested it is reset to the ref, otherwise HEAD is detached at it.
+func (g *GitClient) checkoutRef(ctx context.Context, job *cloning.CloneJob, destPath string, authArgs, env []string, log io.Writer) error {
+	ref := job.Options.Ref
+	target := ref
+
+	if job.Options.Depth > 0 {
+		// A shallow clone rarely contains the requested revision, fetch it explicitly
+		fetchArgs := append(append([]string{}, authArgs...),
+			"-C", destPath, "fetch", "--quiet", "--depth", strconv.Itoa(job.Options.Depth), "origin", ref)
+		if _, err := g.runGit(ctx, env, log, fetchArgs...); err == nil {
+			target = "FETCH_HEAD"
+		} else {
+			// Servers refuse abbreviated SHAs in fetch requests, fall back to the full history
+			unshallowArgs := append(append([]string{}, authArgs...),
+				"-C", destPath, "fetch", "--quiet", "--unshallow", "--tags", "origin")
+			if output, err := g.runGit(ctx, env, log, unshallowArgs...); err != nil {
+				return g.parseGitError(err, output)
+			}
+		}
+	}
+
+	revision, err := g.runGit(ctx, env, nil, "-C", destPath, "rev-parse", "--verify", "--quiet", target+"^{commit}")
+	if err != nil {
+		return &RefNotFoundError{Ref: ref}
+	}
+	revision = strings.TrimSpace(revision)
+
+	checkoutArgs := []string{"-C", destPath, "checkout", "--quiet", "--detach", revision}
+	if job.Options.Branch != "" {
+		checkoutArgs = []string{"-C", destPath, "reset", "--quiet", "--hard", revision}
+	}
+	if output, err := g.runGit(ctx, env, log, checkoutArgs...); err != nil {
+		return g.parseGitError(err, output)
+	}
+
+	g.contextLogger(ctx).Debug("Checked out ref",
+		shared.StringField("repo", job.Repository.GetFullName()),
+		shared.StringField("ref", ref),
+		shared.StringField("revision", revision))
+
+	return nil
+}
+
+// runGit executes a git command returning its combined output, which is also
+// appended to log when one is given
+func (g *GitClient) runGit(ctx context.Context, env []string, log io.Writer, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, g.gitPath, args...)
+	cmd.Env = env
+	detachProcessGroup(cmd)
+	output, err := cmd.CombinedOutput()
+	if log != nil {
+		logCommand(log, args)
+		_, _ = log.Write(output)
+	}
+	return string(output), err
+}
+
+// remoteOptions returns the extra git arguments and environment needed to
+// reach the clone URL: the proxy and CA options, its route, and the
+// credentials of its provider if configured
+func (g *GitClient) remoteOptions(ctx context.Context, cloneURL string) ([]string, []string, error) {
+	cred, err := g.credentials.Lookup(ctx, cloneURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The http.proxy of a route comes last and overrides --proxy
+	routeArgs, env := routeOptions(g.routes.Lookup(cloneURL), cloneURL)
+	args := slices.Concat(g.networkArgs, routeArgs)
+	if cred == nil {
+		return args, env, nil
+	}
+	host := credentialHost(cloneURL)
+	return append(args, credentialArgs(host)...), append(env, credentialEnv(cred, host)...), nil
+}
+
 // repositoryExists checks if a repository already exists at the given path
 func (g *GitClient) repositoryExists(path string) bool {
-	gitDir := filepath.Join(path, ".git")
-	if stat, err := os.Stat(gitDir); err == nil {
-		return stat.IsDir()
-	}
-	return false
+	return repositoryExistsAt(path)
 }
 
 // parseGitError parses git command errors and returns appropriate error types
@@ -171,7 +468,7 @@ func (g *GitClient) parseGitError(err error, output string) error {
 	default:
 		return &GitError{
 			Message: fmt.Sprintf("Git command failed: %v", err),
-			Output:  output,
+			Output:  redact.String(output),
 		}
 	}
 }
@@ -189,28 +486,43 @@ func (g *GitClient) ValidateGitInstallation(ctx context.Context) error {
 		return fmt.Errorf("unexpected git version output: %s", version)
 	}
 
-	g.logger.Info("Git installation validated", shared.StringField("version", version))
+	g.supportsRevision.Store(gitVersionAtLeast(version, minRevisionGitVersion))
+	g.lacksPartialClone.Store(!gitVersionAtLeast(version, minPartialCloneGitVersion))
+
+	g.contextLogger(ctx).Info("Git installation validated", shared.StringField("version", version))
 	return nil
 }
 
+// gitVersionAtLeast parses `git --version` output and compares major.minor
+func gitVersionAtLeast(versionOutput string, minimum [2]int) bool {
+	fields := strings.Fields(versionOutput)
+	if len(fields) < 3 {
+		return false
+	}
+
+	parts := strings.SplitN(fields[2], ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+
+	return major > minimum[0] || (major == minimum[0] && minor >= minimum[1])
+}
+
 // GetRepositorySize estimates the size of a cloned repository
 func (g *GitClient) GetRepositorySize(path string) (int64, error) {
 	if !g.repositoryExists(path) {
 		return 0, fmt.Errorf("repository does not exist at path: %s", path)
 	}
 
-	var size int64
-	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if !info.IsDir() {
-			size += info.Size()
-		}
-		return nil
-	})
-
-	return size, err
+	return DirectorySize(path)
 }
 
 // CleanupRepository removes a repository directory
@@ -238,7 +550,7 @@ func (g *GitClient) CheckRepositoryIntegrity(ctx context.Context, path string) e
 	cmd := exec.CommandContext(ctx, g.gitPath, "-C", path, "fsck", "--quick")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("repository integrity check failed: %w, output: %s", err, string(output))
+		return fmt.Errorf("repository integrity check failed: %w, output: %s", err, redact.String(string(output)))
 	}
 
 	return nil
@@ -266,11 +578,12 @@ func (g *GitClient) UpdateRepository(ctx context.Context, path string) error {
 	}
 
 	cmd := exec.CommandContext(ctx, g.gitPath, "-C", path, "pull", "--ff-only")
+	detachProcessGroup(cmd)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to update repository: %w, output: %s", err, string(output))
+		return fmt.Errorf("failed to update repository: %w, output: %s", err, redact.String(string(output)))
 	}
 
-	g.logger.Info("Repository updated", shared.StringField("path", path))
+	g.contextLogger(ctx).Info("Repository updated", shared.StringField("path", path))
 	return nil
 }
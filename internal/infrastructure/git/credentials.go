@@ -0,0 +1,242 @@
+package git
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// Provider identifies a Git hosting provider for credential lookup
+type Provider string
+
+const (
+	ProviderGitHub    Provider = "github"
+	ProviderBitbucket Provider = "bitbucket"
+	ProviderGitLab    Provider = "gitlab"
+
+	// ProviderBitbucketServer covers self-hosted Bitbucket Server / Data Center
+	// instances; their hosts are registered with RegisterHost
+	ProviderBitbucketServer Provider = "bitbucket-server"
+)
+
+// Well-known usernames used for token based HTTPS authentication
+const (
+	gitHubTokenUsername    = "x-access-token"
+	bitbucketTokenUsername = "x-bitbucket-api-token-auth"
+	gitLabTokenUsername    = "oauth2"
+
+	// Bitbucket Server accepts HTTP access tokens with this username when no
+	// account name is given (required for project and repository tokens)
+	bitbucketServerTokenUsername = "x-token-auth"
+)
+
+// Environment variables read by the inline credential helper
+const (
+	credentialUsernameEnv = "REPOCLONER_GIT_USERNAME"
+	credentialPasswordEnv = "REPOCLONER_GIT_PASSWORD"
+	credentialHostEnv     = "REPOCLONER_GIT_HOST"
+)
+
+// credentialHelperScript answers "git credential get" requests from the
+// per-process environment so secrets never appear in URLs or argv. It only
+// answers HTTPS requests for the host of the cloned repository, so
+// submodules and redirects to other hosts never receive the credential.
+const credentialHelperScript = `!f() { test "$1" = get || exit 0; p=; h=; ` +
+	`while IFS= read -r l; do case "$l" in protocol=*) p="${l#protocol=}";; host=*) h="${l#host=}";; esac; done; ` +
+	`test "$p" = https && test "$h" = "${` + credentialHostEnv + `}" || exit 0; ` +
+	`echo "username=${` + credentialUsernameEnv + `}"; echo "password=${` + credentialPasswordEnv + `}"; }; f`
+
+// Credential holds the username/password pair handed to git over HTTPS
+type Credential struct {
+	Username string
+	Password string
+}
+
+// CredentialFunc resolves a credential on demand, allowing short-lived tokens
+// (e.g. GitHub App installation tokens) to be refreshed between jobs
+type CredentialFunc func(ctx context.Context) (*Credential, error)
+
+// CredentialStore keeps per-provider credentials and the hosts they apply to
+type CredentialStore struct {
+	mu          sync.RWMutex
+	credentials map[Provider]CredentialFunc
+	hosts       map[string]Provider
+}
+
+// NewCredentialStore creates an empty credential store with the default
+// provider hosts registered
+func NewCredentialStore() *CredentialStore {
+	return &CredentialStore{
+		credentials: make(map[Provider]CredentialFunc),
+		hosts: map[string]Provider{
+			"github.com":    ProviderGitHub,
+			"bitbucket.org": ProviderBitbucket,
+			"gitlab.com":    ProviderGitLab,
+		},
+	}
+}
+
+// RegisterHost associates a custom host (e.g. GitHub Enterprise or a
+// self-managed GitLab) with a provider
+func (s *CredentialStore) RegisterHost(host string, provider Provider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hosts[strings.ToLower(host)] = provider
+}
+
+// SetGitHubToken stores a GitHub personal access token
+func (s *CredentialStore) SetGitHubToken(token string) {
+	s.setProvider(ProviderGitHub, gitHubTokenUsername, token)
+}
+
+// SetGitHubTokenFunc stores a GitHub token resolver that is consulted for every
+// clone, such as a GitHub App installation token source
+func (s *CredentialStore) SetGitHubTokenFunc(tokenFunc func(ctx context.Context) (string, error)) {
+	s.SetProviderFunc(ProviderGitHub, func(ctx context.Context) (*Credential, error) {
+		token, err := tokenFunc(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &Credential{Username: gitHubTokenUsername, Password: token}, nil
+	})
+}
+
+// SetBitbucketToken stores a Bitbucket API token
+func (s *CredentialStore) SetBitbucketToken(token string) {
+	s.setProvider(ProviderBitbucket, bitbucketTokenUsername, token)
+}
+
+// SetBitbucketAppPassword stores a Bitbucket app password for the given username
+func (s *CredentialStore) SetBitbucketAppPassword(username, appPassword string) {
+	s.setProvider(ProviderBitbucket, username, appPassword)
+}
+
+// SetBitbucketServerToken stores a Bitbucket Server HTTP access token. The
+// username may be empty for project and repository tokens.
+func (s *CredentialStore) SetBitbucketServerToken(username, token string) {
+	if username == "" {
+		username = bitbucketServerTokenUsername
+	}
+	s.setProvider(ProviderBitbucketServer, username, token)
+}
+
+// SetGitLabToken stores a GitLab personal or project access token
+func (s *CredentialStore) SetGitLabToken(token string) {
+	s.setProvider(ProviderGitLab, gitLabTokenUsername, token)
+}
+
+// setProvider stores the credential used for all hosts of a provider
+func (s *CredentialStore) setProvider(provider Provider, username, password string) {
+	if password == "" {
+		return
+	}
+
+	cred := &Credential{Username: username, Password: password}
+	s.SetProviderFunc(provider, func(context.Context) (*Credential, error) {
+		return cred, nil
+	})
+}
+
+// SetProviderFunc stores a credential resolver for all hosts of a provider
+func (s *CredentialStore) SetProviderFunc(provider Provider, fn CredentialFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.credentials[provider] = fn
+}
+
+// Lookup returns the credential to use for a clone URL. It returns nil without
+// error when no credential is configured for the URL's host, or when the URL
+// is not HTTPS: tokens are never sent in cleartext.
+func (s *CredentialStore) Lookup(ctx context.Context, cloneURL string) (*Credential, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(cloneURL)
+	if err != nil || parsed.Scheme != "https" {
+		return nil, nil
+	}
+
+	s.mu.RLock()
+	provider, ok := s.hosts[strings.ToLower(parsed.Hostname())]
+	fn := s.credentials[provider]
+	s.mu.RUnlock()
+
+	if !ok || fn == nil {
+		return nil, nil
+	}
+
+	return fn(ctx)
+}
+
+// HasCredentials reports whether any credential has been configured
+func (s *CredentialStore) HasCredentials() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.credentials) > 0
+}
+
+// credentialHost returns the host, with its port if any, that the credential
+// of an HTTPS clone URL is scoped to, as git reports it to helpers
+func credentialHost(cloneURL string) string {
+	parsed, err := url.Parse(cloneURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
+
+// credentialArgs returns the git -c arguments that install the inline helper
+// for the HTTPS URLs of host. The empty helper resets any globally configured
+// helpers for this process.
+func credentialArgs(host string) []string {
+	return []string{
+		"-c", "credential.helper=",
+		"-c", "credential.https://" + host + ".helper=" + credentialHelperScript,
+	}
+}
+
+// credentialEnv returns the environment variables consumed by the helper
+func credentialEnv(cred *Credential, host string) []string {
+	return []string{
+		credentialUsernameEnv + "=" + cred.Username,
+		credentialPasswordEnv + "=" + cred.Password,
+		credentialHostEnv + "=" + host,
+		"GIT_TERMINAL_PROMPT=0",
+	}
+}
+
+// hostBasicAuth is the go-git HTTP basic auth of a credential, sent only to
+// the HTTPS host of the repository it was resolved for: go-git hands the auth
+// of a clone to its submodules and follows redirects with it.
+type hostBasicAuth struct {
+	githttp.BasicAuth
+	host string
+}
+
+// newHostBasicAuth scopes a credential to the host of cloneURL, nil without
+// a credential
+func newHostBasicAuth(cloneURL string, cred *Credential) *hostBasicAuth {
+	if cred == nil {
+		return nil
+	}
+	return &hostBasicAuth{
+		BasicAuth: githttp.BasicAuth{Username: cred.Username, Password: cred.Password},
+		host:      credentialHost(cloneURL),
+	}
+}
+
+// SetAuth adds the credential to requests for the scoped host only
+func (a *hostBasicAuth) SetAuth(r *http.Request) {
+	if a == nil || r.URL.Scheme != "https" || !strings.EqualFold(r.URL.Host, a.host) {
+		return
+	}
+	a.BasicAuth.SetAuth(r)
+}
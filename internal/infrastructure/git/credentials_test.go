@@ -0,0 +1,183 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialStore_Lookup(t *testing.T) {
+	store := NewCredentialStore()
+	store.SetGitHubToken("gh-token")
+	store.SetBitbucketToken("bb-token")
+	store.RegisterHost("git.example.com", ProviderGitLab)
+	store.SetGitLabToken("gl-token")
+
+	tests := []struct {
+		name     string
+		url      string
+		wantOK   bool
+		username string
+		password string
+	}{
+		{"github https", "https://github.com/octocat/hello.git", true, "x-access-token", "gh-token"},
+		{"bitbucket https", "https://bitbucket.org/ws/repo.git", true, "x-bitbucket-api-token-auth", "bb-token"},
+		{"custom gitlab host", "https://git.example.com/group/repo.git", true, "oauth2", "gl-token"},
+		{"ssh url", "git@github.com:octocat/hello.git", false, "", ""},
+		{"cleartext http", "http://github.com/octocat/hello.git", false, "", ""},
+		{"unknown host", "https://example.org/repo.git", false, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cred, err := store.Lookup(context.Background(), tt.url)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, cred != nil)
+			if tt.wantOK {
+				assert.Equal(t, tt.username, cred.Username)
+				assert.Equal(t, tt.password, cred.Password)
+			}
+		})
+	}
+}
+
+func TestCredentialStore_EmptyTokenIgnored(t *testing.T) {
+	store := NewCredentialStore()
+	store.SetGitHubToken("")
+
+	assert.False(t, store.HasCredentials())
+	cred, err := store.Lookup(context.Background(), "https://github.com/octocat/hello.git")
+	require.NoError(t, err)
+	assert.Nil(t, cred)
+}
+
+func TestCredentialStore_NilSafe(t *testing.T) {
+	var store *CredentialStore
+
+	assert.False(t, store.HasCredentials())
+	cred, err := store.Lookup(context.Background(), "https://github.com/octocat/hello.git")
+	require.NoError(t, err)
+	assert.Nil(t, cred)
+}
+
+func TestCredentialStore_TokenFunc(t *testing.T) {
+	store := NewCredentialStore()
+	calls := 0
+	store.SetGitHubTokenFunc(func(context.Context) (string, error) {
+		calls++
+		return "installation-token", nil
+	})
+
+	for i := 0; i < 2; i++ {
+		cred, err := store.Lookup(context.Background(), "https://github.com/org/repo.git")
+		require.NoError(t, err)
+		assert.Equal(t, "x-access-token", cred.Username)
+		assert.Equal(t, "installation-token", cred.Password)
+	}
+	assert.Equal(t, 2, calls, "token func should be consulted for every lookup")
+
+	store.SetGitHubTokenFunc(func(context.Context) (string, error) {
+		return "", errors.New("mint failed")
+	})
+	_, err := store.Lookup(context.Background(), "https://github.com/org/repo.git")
+	assert.Error(t, err)
+}
+
+func TestGitClient_RemoteOptionsKeepTokenOutOfArgs(t *testing.T) {
+	store := NewCredentialStore()
+	store.SetGitHubToken("gh-secret")
+
+	tests := []struct {
+		name        string
+		credentials *CredentialStore
+		wantHelper  bool
+	}{
+		{"authenticated clones", store, true},
+		{"authentication disabled", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewGitClient(&GitClientConfig{GitPath: "git", Credentials: tt.credentials})
+			require.NoError(t, err)
+
+			args, env, err := client.remoteOptions(context.Background(), "https://github.com/acme/private.git")
+			require.NoError(t, err)
+
+			assert.NotContains(t, strings.Join(args, " "), "gh-secret", "the token never appears in argv")
+			assert.Equal(t, tt.wantHelper, slices.Contains(args, "credential.helper="), "global helpers are reset only for the inline one")
+			assert.Equal(t, tt.wantHelper, slices.Contains(env, credentialPasswordEnv+"=gh-secret"))
+		})
+	}
+}
+
+func TestCredentialHelper_ScopedToRepositoryHost(t *testing.T) {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not installed")
+	}
+
+	cred := &Credential{Username: "x-access-token", Password: "gh-secret"}
+	fill := func(t *testing.T, args []string, request string) string {
+		t.Helper()
+		cmd := exec.Command(gitPath, append(args, "credential", "fill")...)
+		cmd.Env = append(os.Environ(), credentialEnv(cred, "github.com")...)
+		cmd.Stdin = strings.NewReader(request)
+		output, _ := cmd.CombinedOutput()
+		return string(output)
+	}
+
+	tests := []struct {
+		name    string
+		request string
+		want    bool
+	}{
+		{"repository host", "protocol=https\nhost=github.com\n\n", true},
+		{"submodule on another host", "protocol=https\nhost=evil.example.com\n\n", false},
+		{"cleartext http", "protocol=http\nhost=github.com\n\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, strings.Contains(fill(t, credentialArgs("github.com"), tt.request), "gh-secret"))
+
+			// The helper checks the host itself too, should it be configured for every URL
+			unscoped := []string{"-c", "credential.helper=", "-c", "credential.helper=" + credentialHelperScript}
+			assert.Equal(t, tt.want, strings.Contains(fill(t, unscoped, tt.request), "gh-secret"))
+		})
+	}
+}
+
+func TestHostBasicAuth(t *testing.T) {
+	auth := newHostBasicAuth("https://github.com/acme/app.git", &Credential{Username: "x-access-token", Password: "gh-secret"})
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://github.com/acme/app.git/info/refs", true},
+		{"https://GitHub.com/acme/lib.git/info/refs", true},
+		{"https://evil.example.com/acme/lib.git/info/refs", false},
+		{"http://github.com/acme/lib.git/info/refs", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			auth.SetAuth(req)
+			_, password, ok := req.BasicAuth()
+			assert.Equal(t, tt.want, ok)
+			if tt.want {
+				assert.Equal(t, "gh-secret", password)
+			}
+		})
+	}
+
+	assert.Nil(t, newHostBasicAuth("https://github.com/acme/app.git", nil))
+}
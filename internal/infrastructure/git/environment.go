@@ -0,0 +1,45 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// InstalledVersion returns the `git --version` output of the git binary on
+// the PATH
+func InstalledVersion(ctx context.Context) (string, error) {
+	output, err := exec.CommandContext(ctx, "git", "--version").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git is not installed or not on the PATH: %w", err)
+	}
+
+	version := strings.TrimSpace(string(output))
+	if !strings.HasPrefix(version, "git version") {
+		return "", fmt.Errorf("unexpected git version output: %s", version)
+	}
+	return version, nil
+}
+
+// LFSVersion returns the `git lfs version` output, failing when Git LFS is
+// not installed
+func LFSVersion(ctx context.Context) (string, error) {
+	output, err := exec.CommandContext(ctx, "git", "lfs", "version").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git lfs is not installed: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// SupportsRevisionClone reports whether a `git --version` output belongs to a
+// release that can clone a single commit with --revision
+func SupportsRevisionClone(versionOutput string) bool {
+	return gitVersionAtLeast(versionOutput, minRevisionGitVersion)
+}
+
+// SupportsPartialClone reports whether a `git --version` output belongs to a
+// release that can clone with the blob and tree partial clone filters
+func SupportsPartialClone(versionOutput string) bool {
+	return gitVersionAtLeast(versionOutput, minPartialCloneGitVersion)
+}
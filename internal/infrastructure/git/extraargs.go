@@ -0,0 +1,136 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// safeCloneFlags are the git clone flags without a value accepted as extra
+// arguments. Flags changing the layout of the clone (--bare, --mirror,
+// --origin, --separate-git-dir) or running code (--template, --upload-pack)
+// are left out.
+var safeCloneFlags = map[string]bool{
+	"--single-branch":          true,
+	"--no-single-branch":       true,
+	"--no-tags":                true,
+	"--reject-shallow":         true,
+	"--no-reject-shallow":      true,
+	"--remote-submodules":      true,
+	"--no-remote-submodules":   true,
+	"--also-filter-submodules": true,
+	"--ipv4":                   true,
+	"--ipv6":                   true,
+	"-4":                       true,
+	"-6":                       true,
+}
+
+// safeCloneOptions are the git clone options taking a value accepted as extra
+// arguments, mapped to the check of their value
+var safeCloneOptions = map[string]func(string) error{
+	"--config":          checkConfigArg,
+	"-c":                checkConfigArg,
+	"--jobs":            checkJobsArg,
+	"-j":                checkJobsArg,
+	"--shallow-since":   checkPlainArg,
+	"--shallow-exclude": checkPlainArg,
+}
+
+// safeConfigKeys are the configuration keys extra --config arguments may set.
+// Keys naming commands or helpers, such as core.sshCommand or
+// credential.helper, would run arbitrary programs and are not accepted.
+var safeConfigKeys = map[string]bool{
+	"core.autocrlf":           true,
+	"core.eol":                true,
+	"core.filemode":           true,
+	"core.ignorecase":         true,
+	"core.longpaths":          true,
+	"core.precomposeunicode":  true,
+	"core.protectntfs":        true,
+	"core.symlinks":           true,
+	"core.compression":        true,
+	"core.bigfilethreshold":   true,
+	"checkout.workers":        true,
+	"fetch.parallel":          true,
+	"submodule.fetchjobs":     true,
+	"pack.threads":            true,
+	"pack.windowmemory":       true,
+	"gc.auto":                 true,
+	"index.version":           true,
+	"feature.manyfiles":       true,
+	"http.postbuffer":         true,
+	"http.lowspeedlimit":      true,
+	"http.lowspeedtime":       true,
+	"http.version":            true,
+	"http.maxrequests":        true,
+	"http.sslbackend":         true,
+	"transfer.fsckobjects":    true,
+	"fetch.fsckobjects":       true,
+	"init.defaultbranch":      true,
+	"advice.detachedhead":     true,
+	"filter.lfs.required":     true,
+	"lfs.concurrenttransfers": true,
+}
+
+// ParseCloneArgs splits extra git clone arguments, each value holding one or
+// more whitespace separated arguments, and checks them against the safe-list:
+// ["--config core.autocrlf=false", "--jobs=4"] is
+// [--config core.autocrlf=false --jobs=4]
+func ParseCloneArgs(values []string) ([]string, error) {
+	var args []string
+	for _, value := range values {
+		args = append(args, strings.Fields(value)...)
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if safeCloneFlags[arg] {
+			continue
+		}
+
+		name, value, inline := strings.Cut(arg, "=")
+		check, ok := safeCloneOptions[name]
+		if !ok {
+			return nil, fmt.Errorf("git clone argument %q is not allowed", arg)
+		}
+		if !inline {
+			if i+1 == len(args) {
+				return nil, fmt.Errorf("git clone argument %s needs a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		if err := check(value); err != nil {
+			return nil, fmt.Errorf("git clone argument %s: %w", name, err)
+		}
+	}
+	return args, nil
+}
+
+// checkConfigArg accepts key=value settings of the safe configuration keys
+func checkConfigArg(value string) error {
+	key, _, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("%q is not key=value", value)
+	}
+	if !safeConfigKeys[strings.ToLower(key)] {
+		return fmt.Errorf("configuration key %q is not allowed", key)
+	}
+	return nil
+}
+
+// checkJobsArg accepts positive job counts
+func checkJobsArg(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+		return fmt.Errorf("%q is not a positive number", value)
+	}
+	return nil
+}
+
+// checkPlainArg accepts values that cannot be taken for an option
+func checkPlainArg(value string) error {
+	if value == "" || strings.HasPrefix(value, "-") {
+		return fmt.Errorf("invalid value %q", value)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func TestParseCloneArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []string
+		wantErr string
+	}{
+		{name: "none"},
+		{name: "split values", values: []string{"--config core.autocrlf=false", "--jobs=4"},
+			want: []string{"--config", "core.autocrlf=false", "--jobs=4"}},
+		{name: "flags", values: []string{"--no-tags", "--single-branch"}, want: []string{"--no-tags", "--single-branch"}},
+		{name: "short config", values: []string{"-c", "core.longpaths=true"}, want: []string{"-c", "core.longpaths=true"}},
+		{name: "config key case", values: []string{"--config=submodule.fetchJobs=8"}, want: []string{"--config=submodule.fetchJobs=8"}},
+		{name: "unsafe flag", values: []string{"--upload-pack=/bin/sh"}, wantErr: `"--upload-pack=/bin/sh" is not allowed`},
+		{name: "layout flag", values: []string{"--bare"}, wantErr: "not allowed"},
+		{name: "unsafe config", values: []string{"--config core.sshCommand=evil"}, wantErr: `configuration key "core.sshCommand" is not allowed`},
+		{name: "config without value", values: []string{"--config"}, wantErr: "needs a value"},
+		{name: "config not key=value", values: []string{"--config core.autocrlf"}, wantErr: "is not key=value"},
+		{name: "jobs", values: []string{"--jobs 0"}, wantErr: "is not a positive number"},
+		{name: "option as value", values: []string{"--shallow-exclude --bare"}, wantErr: "invalid value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := ParseCloneArgs(tt.values)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, args)
+		})
+	}
+}
+
+func TestGitClient_BuildCloneArgs_ExtraArgs(t *testing.T) {
+	client := &GitClient{extraArgs: []string{"--config", "core.autocrlf=false"}}
+	repo, err := repository.NewRepository(1, "api", "https://github.com/acme/api.git", "acme", false, 0, "main")
+	require.NoError(t, err)
+
+	args := client.buildCloneArgs(cloning.NewCloneJob(repo, t.TempDir(), nil), "dest", false)
+	assert.Equal(t, []string{"--config", "core.autocrlf=false", "https://github.com/acme/api.git", "dest"}, args[len(args)-4:],
+		"extra arguments go right before the URL")
+
+	_, err = NewCloneBackend(BackendGoGit, &GitClientConfig{ExtraArgs: []string{"--no-tags"}})
+	assert.ErrorContains(t, err, "not supported by the gogit backend")
+}
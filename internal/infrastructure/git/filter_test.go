@@ -0,0 +1,106 @@
+package git
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestGitClient_BuildCloneArgs_Filter(t *testing.T) {
+	client := &GitClient{}
+	repo, err := repository.NewRepository(1, "mono", "https://github.com/acme/mono.git", "acme", false, 0, "main")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		filter  cloning.CloneFilter
+		sparse  []string
+		want    []string
+		notWant []string
+	}{
+		{name: "full clone", notWant: []string{"--filter", "--sparse"}},
+		{name: "treeless", filter: cloning.FilterTreeless, want: []string{"--filter=tree:0"}, notWant: []string{"--sparse"}},
+		{name: "sparse defaults to blobless", sparse: []string{"docs"}, want: []string{"--filter=blob:none", "--sparse"}},
+		{name: "sparse keeps the filter", filter: cloning.FilterTreeless, sparse: []string{"docs"}, want: []string{"--filter=tree:0", "--sparse"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := cloning.NewDefaultCloneOptions()
+			options.Filter = tt.filter
+			options.SparsePaths = tt.sparse
+			args := strings.Join(client.buildCloneArgs(cloning.NewCloneJob(repo, t.TempDir(), options), "dest", false), " ")
+
+			for _, arg := range tt.want {
+				assert.Contains(t, args, arg)
+			}
+			for _, arg := range tt.notWant {
+				assert.NotContains(t, args, arg)
+			}
+		})
+	}
+}
+
+func TestGitClient_BloblessClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	upstreamDir := t.TempDir()
+	upstream, err := gogit.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	commitFile(t, upstream, "README.md", "hello")
+	require.NoError(t, exec.Command("git", "-C", upstreamDir, "config", "uploadpack.allowFilter", "true").Run())
+
+	client, err := NewGitClient(&GitClientConfig{Logger: logging.NewNoOpLogger()})
+	require.NoError(t, err)
+	require.NoError(t, client.Validate(context.Background()))
+
+	repo, err := repository.NewRepository(1, "mono", "https://github.com/acme/mono.git", "acme", false, 0, "master")
+	require.NoError(t, err)
+	repo.CloneURL = "file://" + upstreamDir
+
+	options := cloning.NewDefaultCloneOptions()
+	options.Depth = 0
+	options.RecurseSubmodules = false
+	options.Filter = cloning.FilterBlobless
+	job := cloning.NewCloneJob(repo, t.TempDir(), options)
+	dest := job.GetDestinationPath()
+
+	require.NoError(t, client.clone(context.Background(), job, dest, nil, io.Discard))
+
+	assert.FileExists(t, filepath.Join(dest, "README.md"))
+	output, err := exec.Command("git", "-C", dest, "config", "remote.origin.partialclonefilter").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "blob:none", strings.TrimSpace(string(output)))
+}
+
+func TestGitClient_FilterRequiresPartialCloneSupport(t *testing.T) {
+	client, err := NewGitClient(&GitClientConfig{GitPath: "git", Logger: logging.NewNoOpLogger()})
+	require.NoError(t, err)
+	client.lacksPartialClone.Store(true)
+
+	repo, err := repository.NewRepository(1, "mono", "https://github.com/acme/mono.git", "acme", false, 0, "main")
+	require.NoError(t, err)
+	options := cloning.NewDefaultCloneOptions()
+	options.Filter = cloning.FilterTreeless
+	job := cloning.NewCloneJob(repo, t.TempDir(), options)
+
+	err = client.CloneRepository(context.Background(), job)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires git 2.20")
+	_, statErr := os.Stat(job.GetDestinationPath())
+	assert.True(t, os.IsNotExist(statErr))
+}
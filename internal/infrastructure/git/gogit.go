@@ -0,0 +1,477 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/network"
+)
+
+// GoGitBackend clones repositories with go-git, without an external git binary
+type GoGitBackend struct {
+	timeout     time.Duration
+	logger      shared.Logger
+	validator   *GitValidator
+	credentials *CredentialStore
+	bandwidth   *BandwidthLimiter
+	jobLogDir   string
+	routes      *RouteTable
+	transport   http.RoundTripper // HTTP(S) transport with the proxy, CA and routes of the backend
+}
+
+// installContextTransport routes go-git HTTP(S) requests through the transport
+// of the backend found in the request context, reporting received bytes. go-git
+// keeps one client per protocol for the whole process, so backends with other
+// settings cannot install their own.
+var installContextTransport sync.Once
+
+// NewGoGitBackend creates a new go-git clone backend
+func NewGoGitBackend(config *GitClientConfig) (*GoGitBackend, error) {
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	transport, err := network.NewTransport(config.Network)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network settings: %w", err)
+	}
+	transport.Proxy = config.Routes.proxyFunc(transport.Proxy)
+
+	installContextTransport.Do(func() {
+		httpClient := &http.Client{Transport: &countingRoundTripper{next: contextRoundTripper{}}}
+		client.InstallProtocol("https", githttp.NewClient(httpClient))
+		client.InstallProtocol("http", githttp.NewClient(httpClient))
+	})
+
+	validator := NewGitValidator(config.Logger)
+	validator.SetHostPolicy(config.Hosts)
+
+	backend := &GoGitBackend{
+		timeout:     config.Timeout,
+		logger:      config.Logger,
+		validator:   validator,
+		credentials: config.Credentials,
+		jobLogDir:   config.JobLogDir,
+		routes:      config.Routes,
+		transport:   transport,
+	}
+	if config.MaxBandwidth > 0 {
+		backend.bandwidth = NewBandwidthLimiter(config.MaxBandwidth)
+	}
+
+	return backend, nil
+}
+
+// Name returns the backend identifier
+func (b *GoGitBackend) Name() string {
+	return BackendGoGit
+}
+
+// sshProxy returns the proxy of the route of an SSH remote; go-git dials
+// SOCKS5 proxies itself. HTTPS remotes are routed by the shared transport.
+func (b *GoGitBackend) sshProxy(cloneURL string) transport.ProxyOptions {
+	if !isSSHURL(cloneURL) {
+		return transport.ProxyOptions{}
+	}
+	return transport.ProxyOptions{URL: b.routes.Lookup(cloneURL).Proxy}
+}
+
+// contextLogger returns the logger of ctx or the backend logger
+func (b *GoGitBackend) contextLogger(ctx context.Context) shared.Logger {
+	return shared.GetLogger(ctx, b.logger)
+}
+
+// Validate always succeeds as go-git needs no external tooling
+func (b *GoGitBackend) Validate(ctx context.Context) error {
+	b.contextLogger(ctx).Info("Using go-git clone backend")
+	return nil
+}
+
+// CloneRepository clones a repository according to the job specifications
+func (b *GoGitBackend) CloneRepository(ctx context.Context, job *cloning.CloneJob) error {
+	return b.CloneRepositoryWithProgress(ctx, job, nil)
+}
+
+// CloneRepositoryWithProgress clones a repository reporting object and byte progress
+func (b *GoGitBackend) CloneRepositoryWithProgress(ctx context.Context, job *cloning.CloneJob, onProgress cloning.TransferProgressFunc) error {
+	if err := b.validator.ValidateCloneJob(job); err != nil {
+		return fmt.Errorf("invalid clone job: %w", err)
+	}
+	if err := b.checkBandwidthLimit(job.Repository.CloneURL); err != nil {
+		return err
+	}
+	if job.Options.Filter != cloning.FilterNone {
+		// go-git cannot negotiate partial clones and fetches every object
+		b.contextLogger(ctx).Debug("Ignoring partial clone filter",
+			shared.StringField("repo", job.Repository.GetFullName()),
+			shared.StringField("filter", string(job.Options.Filter)))
+	}
+
+	if err := prepareCloneDestination(job, b.contextLogger(ctx)); err != nil {
+		return err
+	}
+
+	log, err := openJobLog(b.jobLogDir, job)
+	if err != nil {
+		b.contextLogger(ctx).Warn("Job log unavailable",
+			shared.StringField("repo", job.Repository.GetFullName()),
+			shared.ErrorField(err))
+		log = nopWriteCloser{io.Discard}
+	}
+
+	err = cloneAtomically(job, func(path string) error {
+		if err := b.clone(ctx, job, path, onProgress, log); err != nil {
+			return err
+		}
+		recordCloneProvenance(path, job, b.contextLogger(ctx))
+		configureUpstream(path, job, b.contextLogger(ctx))
+		return nil
+	})
+	closeJobLog(log, err)
+	return err
+}
+
+// clone runs the go-git clone into destPath and the ref checkout, copying
+// remote progress messages into the job log
+func (b *GoGitBackend) clone(ctx context.Context, job *cloning.CloneJob, destPath string, onProgress cloning.TransferProgressFunc, log io.Writer) error {
+	options, err := b.buildCloneOptions(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	cloneCtx, cancel := context.WithTimeout(withTransport(ctx, b.transport), b.timeout)
+	defer cancel()
+
+	var writer *progressWriter
+	options.Progress = log
+	if onProgress != nil {
+		writer = newProgressWriter(job, onProgress)
+		options.Progress = io.MultiWriter(log, writer)
+		cloneCtx = withByteCounter(cloneCtx, writer.reportBytes)
+	}
+	if b.bandwidth != nil {
+		cloneCtx = withBandwidthLimiter(cloneCtx, b.bandwidth)
+	}
+
+	repo, err := gogit.PlainCloneContext(cloneCtx, destPath, false, options)
+	if err != nil {
+		// Leave no partial checkout behind so retries start clean
+		_ = os.RemoveAll(destPath)
+
+		b.contextLogger(ctx).Error("go-git clone failed",
+			shared.StringField("repo", job.Repository.GetFullName()),
+			shared.ErrorField(err))
+
+		if job.Options.Ref != "" && errors.As(err, &gogit.NoMatchingRefSpecError{}) {
+			return &RefNotFoundError{Ref: job.Options.Ref}
+		}
+		return b.mapError(cloneCtx, err)
+	}
+
+	if job.Options.Ref != "" {
+		if err := b.checkoutRef(cloneCtx, repo, job, options.Auth); err != nil {
+			_ = os.RemoveAll(destPath)
+			return err
+		}
+	} else if len(job.Options.SparsePaths) > 0 {
+		if err := b.checkoutSparse(cloneCtx, repo, job.Options.SparsePaths); err != nil {
+			_ = os.RemoveAll(destPath)
+			return err
+		}
+	}
+
+	if writer != nil {
+		writer.Flush()
+	}
+
+	b.contextLogger(ctx).Info("Repository cloned successfully",
+		shared.StringField("repo", job.Repository.GetFullName()),
+		shared.StringField("path", job.GetDestinationPath()),
+		shared.StringField("backend", BackendGoGit),
+		shared.DurationField("duration", job.Duration()))
+
+	return nil
+}
+
+// buildCloneOptions translates job options into go-git clone options
+func (b *GoGitBackend) buildCloneOptions(ctx context.Context, job *cloning.CloneJob) (*gogit.CloneOptions, error) {
+	options := &gogit.CloneOptions{
+		URL:   job.Repository.CloneURL,
+		Depth: job.Options.Depth,
+	}
+
+	// The selected directories are checked out after cloning
+	options.NoCheckout = len(job.Options.SparsePaths) > 0
+
+	if job.Options.Branch != "" {
+		options.ReferenceName = plumbing.NewBranchReferenceName(job.Options.Branch)
+		options.SingleBranch = true
+	}
+
+	if ref := job.Options.Ref; ref != "" {
+		if job.Options.Branch == "" && !isCommitSHA(ref) {
+			// Tags can be cloned directly, keeping shallow clones shallow
+			options.ReferenceName = plumbing.NewTagReferenceName(ref)
+			options.SingleBranch = true
+		} else if options.Depth > 0 {
+			// go-git cannot fetch an individual commit, so the history must contain it
+			b.contextLogger(ctx).Debug("Cloning full history to resolve ref",
+				shared.StringField("repo", job.Repository.GetFullName()),
+				shared.StringField("ref", ref))
+			options.Depth = 0
+		}
+	}
+
+	// Submodules of a pinned ref are initialized after checking it out
+	if job.Options.RecurseSubmodules && job.Options.Ref == "" {
+		options.RecurseSubmodules = submoduleRecursivity(job.Options)
+		options.ShallowSubmodules = job.Options.ShallowSubmodules
+	}
+
+	cred, err := b.credentials.Lookup(ctx, job.Repository.CloneURL)
+	if err != nil {
+		return nil, &AuthenticationError{Message: fmt.Sprintf("failed to resolve credentials: %v", err)}
+	}
+	if cred != nil {
+		// Submodules on other hosts are cloned without it
+		options.Auth = newHostBasicAuth(job.Repository.CloneURL, cred)
+	}
+	options.ProxyOptions = b.sshProxy(job.Repository.CloneURL)
+
+	return options, nil
+}
+
+// checkoutRef moves a fresh clone to job.Options.Ref. When a branch was
+// requested it is reset to the ref, otherwise HEAD is detached at it.
+func (b *GoGitBackend) checkoutRef(ctx context.Context, repo *gogit.Repository, job *cloning.CloneJob, auth transport.AuthMethod) error {
+	ref := job.Options.Ref
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return &RefNotFoundError{Ref: ref}
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return b.mapError(ctx, err)
+	}
+
+	sparse := job.Options.SparsePaths
+	switch {
+	case job.Options.Branch != "" && len(sparse) > 0:
+		err = worktree.ResetSparsely(&gogit.ResetOptions{Commit: *hash, Mode: gogit.HardReset}, sparse)
+	case job.Options.Branch != "":
+		err = worktree.Reset(&gogit.ResetOptions{Commit: *hash, Mode: gogit.HardReset})
+	default:
+		err = worktree.Checkout(&gogit.CheckoutOptions{Hash: *hash, SparseCheckoutDirectories: sparse})
+	}
+	if err != nil {
+		return b.mapError(ctx, err)
+	}
+
+	if job.Options.RecurseSubmodules {
+		submodules, err := worktree.Submodules()
+		if err != nil {
+			return b.mapError(ctx, err)
+		}
+		updateOptions := &gogit.SubmoduleUpdateOptions{
+			Init:              true,
+			RecurseSubmodules: submoduleRecursivity(job.Options),
+			Auth:              auth,
+		}
+		if job.Options.ShallowSubmodules {
+			updateOptions.Depth = 1
+		}
+		if err := submodules.UpdateContext(ctx, updateOptions); err != nil {
+			return b.mapError(ctx, err)
+		}
+	}
+
+	b.contextLogger(ctx).Debug("Checked out ref",
+		shared.StringField("repo", job.Repository.GetFullName()),
+		shared.StringField("ref", ref),
+		shared.StringField("revision", hash.String()))
+
+	return nil
+}
+
+// checkoutSparse checks out the directories of a clone made without checkout.
+// go-git cannot clone without blobs, so unlike git every blob was fetched.
+func (b *GoGitBackend) checkoutSparse(ctx context.Context, repo *gogit.Repository, directories []string) error {
+	head, err := repo.Head()
+	if err != nil {
+		return b.mapError(ctx, err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return b.mapError(ctx, err)
+	}
+
+	err = worktree.Checkout(&gogit.CheckoutOptions{
+		Branch:                    head.Name(),
+		Force:                     true,
+		SparseCheckoutDirectories: directories,
+	})
+	if err != nil {
+		return b.mapError(ctx, err)
+	}
+	return nil
+}
+
+// submoduleRecursivity translates the submodule nesting limit into go-git terms
+func submoduleRecursivity(options *cloning.CloneOptions) gogit.SubmoduleRescursivity {
+	if options.SubmoduleDepth > 0 {
+		return gogit.SubmoduleRescursivity(options.SubmoduleDepth)
+	}
+	return gogit.DefaultSubmoduleRecursionDepth
+}
+
+// mapError converts go-git errors into the package's typed errors
+func (b *GoGitBackend) mapError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, transport.ErrAuthenticationRequired),
+		errors.Is(err, transport.ErrAuthorizationFailed):
+		return &AuthenticationError{Message: "Git authentication failed"}
+	case errors.Is(err, transport.ErrRepositoryNotFound):
+		return &RepositoryNotFoundError{Message: "Repository not found"}
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return &TimeoutError{
+			Message: fmt.Sprintf("clone timed out after %s", b.timeout),
+			Timeout: b.timeout,
+		}
+	}
+
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "no space left on device"):
+		return &DiskSpaceError{Message: "No space left on device"}
+	case strings.Contains(message, "connection refused"),
+		strings.Contains(message, "no such host"),
+		strings.Contains(message, "network is unreachable"):
+		return &NetworkError{Message: err.Error()}
+	default:
+		return &GitError{
+			Message: fmt.Sprintf("go-git clone failed: %v", err),
+			Output:  err.Error(),
+		}
+	}
+}
+
+// GetRepositorySize returns the on-disk size of a cloned repository
+func (b *GoGitBackend) GetRepositorySize(path string) (int64, error) {
+	if !repositoryExistsAt(path) {
+		return 0, fmt.Errorf("repository does not exist at path: %s", path)
+	}
+	return DirectorySize(path)
+}
+
+// byteCounterKey is the context key carrying a byte counter callback
+type byteCounterKey struct{}
+
+// withByteCounter attaches a received-bytes callback to the context used by go-git requests
+func withByteCounter(ctx context.Context, fn func(n int64)) context.Context {
+	return context.WithValue(ctx, byteCounterKey{}, fn)
+}
+
+// bandwidthLimiterKey is the context key carrying the bandwidth limiter
+type bandwidthLimiterKey struct{}
+
+// checkBandwidthLimit fails transfers over SSH when a bandwidth limit is set:
+// the limiter wraps the HTTP transport only, and an SSH clone would silently
+// ignore it
+func (b *GoGitBackend) checkBandwidthLimit(cloneURL string) error {
+	if b.bandwidth != nil && isSSHURL(cloneURL) {
+		return &UnsupportedOptionError{Message: "the bandwidth limit applies to HTTPS clones only; clone over HTTPS or without a limit"}
+	}
+	return nil
+}
+
+// withBandwidthLimiter attaches a bandwidth limiter to the context used by go-git requests
+func withBandwidthLimiter(ctx context.Context, limiter *BandwidthLimiter) context.Context {
+	return context.WithValue(ctx, bandwidthLimiterKey{}, limiter)
+}
+
+// transportKey is the context key carrying the HTTP transport of a backend
+type transportKey struct{}
+
+// withTransport attaches the HTTP transport go-git requests are sent through
+func withTransport(ctx context.Context, transport http.RoundTripper) context.Context {
+	return context.WithValue(ctx, transportKey{}, transport)
+}
+
+// contextRoundTripper sends requests through the transport found in their
+// context, http.DefaultTransport without one
+type contextRoundTripper struct{}
+
+// RoundTrip implements http.RoundTripper
+func (contextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if transport, ok := req.Context().Value(transportKey{}).(http.RoundTripper); ok {
+		return transport.RoundTrip(req)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// countingRoundTripper wraps response bodies so reads are reported to the
+// byte counter and throttled by the bandwidth limiter found in the request context
+type countingRoundTripper struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+
+	ctx := req.Context()
+	onRead, _ := ctx.Value(byteCounterKey{}).(func(n int64))
+	limiter, _ := ctx.Value(bandwidthLimiterKey{}).(*BandwidthLimiter)
+	if onRead != nil || limiter != nil {
+		resp.Body = &countingReadCloser{ReadCloser: resp.Body, ctx: ctx, onRead: onRead, limiter: limiter}
+	}
+	return resp, nil
+}
+
+// countingReadCloser reports and throttles every successful read
+type countingReadCloser struct {
+	io.ReadCloser
+	ctx     context.Context
+	onRead  func(n int64)
+	limiter *BandwidthLimiter
+}
+
+// Read implements io.Reader
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	if c.limiter != nil && len(p) > c.limiter.Burst() {
+		p = p[:c.limiter.Burst()]
+	}
+
+	n, err := c.ReadCloser.Read(p)
+	if n > 0 {
+		if c.limiter != nil {
+			if waitErr := c.limiter.WaitN(c.ctx, n); waitErr != nil {
+				return n, waitErr
+			}
+		}
+		if c.onRead != nil {
+			c.onRead(int64(n))
+		}
+	}
+	return n, err
+}
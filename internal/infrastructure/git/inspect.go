@@ -0,0 +1,111 @@
+package git
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+)
+
+// RepositoryState describes a repository found in a local workspace
+type RepositoryState struct {
+	Path      string // Absolute path of the working tree
+	RemoteURL string // URL of the origin remote
+	Branch    string // Checked out branch, empty when HEAD is detached
+	Revision  string // Commit SHA of HEAD
+}
+
+// InspectRepository reads remote, branch and HEAD information of a local
+// repository without requiring the git binary
+func InspectRepository(path string) (*RepositoryState, error) {
+	repo, err := gogit.PlainOpen(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository at %s: %w", path, err)
+	}
+
+	state := &RepositoryState{Path: path}
+
+	if remote, err := repo.Remote("origin"); err == nil && len(remote.Config().URLs) > 0 {
+		state.RemoteURL = remote.Config().URLs[0]
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD at %s: %w", path, err)
+	}
+
+	state.Revision = head.Hash().String()
+	if head.Name().IsBranch() {
+		state.Branch = head.Name().Short()
+	}
+
+	return state, nil
+}
+
+// OriginURL returns the URL of the origin remote of a local repository, or
+// an empty string when it has none
+func OriginURL(path string) (string, error) {
+	repo, err := gogit.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository at %s: %w", path, err)
+	}
+
+	remote, err := repo.Remote("origin")
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return "", nil
+	}
+	return remote.Config().URLs[0], nil
+}
+
+// FindRepositories returns the working trees below baseDir (up to maxDepth
+// levels deep), sorted by path. Nested repositories such as submodules are
+// not descended into.
+func FindRepositories(baseDir string, maxDepth int) ([]string, error) {
+	root, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base directory: %w", err)
+	}
+
+	var repos []string
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // Skip unreadable directories
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		depth := 0
+		if rel != "." {
+			depth = len(strings.Split(rel, string(os.PathSeparator)))
+		}
+
+		if path != root && (strings.HasPrefix(entry.Name(), ".") || isPartialClone(entry.Name())) {
+			return filepath.SkipDir
+		}
+
+		if path != root && repositoryExistsAt(path) {
+			repos = append(repos, path)
+			return filepath.SkipDir
+		}
+
+		if depth >= maxDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", baseDir, err)
+	}
+
+	sort.Strings(repos)
+	return repos, nil
+}
@@ -0,0 +1,86 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/redact"
+)
+
+// jobLogPath returns the per-repository log file path, <dir>/<owner>/<repo>.log
+func jobLogPath(dir string, job *cloning.CloneJob) string {
+	return filepath.Join(dir, filepath.Base(job.Repository.Owner), filepath.Base(job.Repository.Name)+".log")
+}
+
+// openJobLog opens the job's log file in append mode, so every retry attempt is
+// kept, and records its path on the job. An empty dir disables job logs.
+func openJobLog(dir string, job *cloning.CloneJob) (io.WriteCloser, error) {
+	if dir == "" {
+		return nopWriteCloser{io.Discard}, nil
+	}
+
+	path := jobLogPath(dir, job)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create job log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job log: %w", err)
+	}
+	job.LogFile = path
+
+	log := redactingWriteCloser{file}
+	fmt.Fprintf(log, "=== %s clone %s (attempt %d)\n",
+		time.Now().Format(time.RFC3339), job.Repository.CloneURL, job.RetryCount+1)
+	return log, nil
+}
+
+// closeJobLog records the outcome of the attempt and closes the log
+func closeJobLog(log io.WriteCloser, err error) {
+	if err != nil {
+		fmt.Fprintf(log, "=== failed: %v\n\n", err)
+	} else {
+		fmt.Fprintf(log, "=== done\n\n")
+	}
+	_ = log.Close()
+}
+
+// logCommand writes a git command line to the job log, omitting the
+// credential helper configuration
+func logCommand(log io.Writer, args []string) {
+	var visible []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-c" && i+1 < len(args) && strings.HasPrefix(args[i+1], "credential.") {
+			i++
+			continue
+		}
+		visible = append(visible, args[i])
+	}
+	fmt.Fprintf(log, "$ git %s\n", strings.Join(visible, " "))
+}
+
+// nopWriteCloser adds a no-op Close to a writer
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// redactingWriteCloser masks credentials in everything written to a job log.
+// git writes whole lines, so a credential is not split across writes.
+type redactingWriteCloser struct {
+	io.WriteCloser
+}
+
+func (w redactingWriteCloser) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.WriteCloser, redact.String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package git
+
+import "os/exec"
+
+// detachProcessGroup leaves cmd in the process group of repocloner where
+// process groups are not supported
+func detachProcessGroup(*exec.Cmd) {}
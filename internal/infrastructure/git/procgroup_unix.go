@@ -0,0 +1,19 @@
+//go:build unix
+
+package git
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachProcessGroup runs cmd in a process group of its own, so the SIGINT a
+// terminal sends to its foreground group on Ctrl-C reaches repocloner only and
+// in-flight clones get the shutdown grace period. Cancelling cmd kills the
+// whole group, including the transport helpers git starts.
+func detachProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,72 @@
+//go:build unix
+
+package git
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDetachProcessGroupHelper stands in for repocloner in
+// TestDetachProcessGroup_SurvivesSIGINT: it starts a detached child, catches
+// SIGINT and prints whether the child survived it
+func TestDetachProcessGroupHelper(t *testing.T) {
+	if os.Getenv("REPOCLONER_PROCGROUP_HELPER") != "1" {
+		t.Skip("helper process")
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+
+	cmd := exec.CommandContext(context.Background(), "sleep", "30")
+	detachProcessGroup(cmd)
+	require.NoError(t, cmd.Start())
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+	fmt.Println("started")
+
+	<-signals
+	select {
+	case <-exited:
+		fmt.Println("interrupted")
+	case <-time.After(200 * time.Millisecond):
+		fmt.Println("running")
+		_ = cmd.Process.Kill()
+	}
+}
+
+func TestDetachProcessGroup_SurvivesSIGINT(t *testing.T) {
+	// The helper leads a group of its own, as a shell's foreground job does
+	helper := exec.Command(os.Args[0], "-test.run=^TestDetachProcessGroupHelper$")
+	helper.Env = append(os.Environ(), "REPOCLONER_PROCGROUP_HELPER=1")
+	helper.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	stdout, err := helper.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, helper.Start())
+	defer func() { _ = helper.Process.Kill() }()
+
+	lines := bufio.NewReader(stdout)
+	line, err := lines.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "started", strings.TrimSpace(line))
+
+	// Ctrl-C signals the whole foreground group
+	require.NoError(t, syscall.Kill(-helper.Process.Pid, syscall.SIGINT))
+	line, err = lines.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "running", strings.TrimSpace(line), "git keeps running through the grace period")
+	assert.NoError(t, helper.Wait())
+}
@@ -0,0 +1,168 @@
+package git
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// progressLinePattern matches git progress lines such as
+// "Receiving objects:  45% (450/1000), 1.20 MiB | 512.00 KiB/s"
+var progressLinePattern = regexp.MustCompile(
+	`^(?:remote:\s*)?([A-Za-z][A-Za-z ]*?):\s+(\d+)%\s+\((\d+)/(\d+)\)` +
+		`(?:,\s*([\d.]+)\s*([KMG]?i?B))?(?:\s*\|\s*([\d.]+)\s*([KMG]?i?B)/s)?`)
+
+// parseProgressLine parses a single git progress line
+func parseProgressLine(line string) (cloning.TransferProgress, bool) {
+	matches := progressLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+	if matches == nil {
+		return cloning.TransferProgress{}, false
+	}
+
+	percent, _ := strconv.ParseFloat(matches[2], 64)
+	done, _ := strconv.Atoi(matches[3])
+	total, _ := strconv.Atoi(matches[4])
+
+	progress := cloning.TransferProgress{
+		Phase:        matches[1],
+		Percent:      percent,
+		ObjectsDone:  done,
+		ObjectsTotal: total,
+	}
+
+	if matches[5] != "" {
+		progress.BytesReceived = int64(parseByteQuantity(matches[5], matches[6]))
+	}
+	if matches[7] != "" {
+		progress.BytesPerSecond = parseByteQuantity(matches[7], matches[8])
+	}
+
+	return progress, true
+}
+
+// parseByteQuantity converts a git formatted size ("1.20", "MiB") into bytes
+func parseByteQuantity(value, unit string) float64 {
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+
+	switch strings.ToUpper(strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "i")) {
+	case "K":
+		return amount * 1024
+	case "M":
+		return amount * 1024 * 1024
+	case "G":
+		return amount * 1024 * 1024 * 1024
+	default:
+		return amount
+	}
+}
+
+// progressWriter is an io.Writer that splits git progress output on CR/LF
+// and reports parsed updates through a callback. It can also be fed raw
+// byte counts when the output does not include transfer sizes.
+type progressWriter struct {
+	mu        sync.Mutex
+	buffer    []byte
+	current   cloning.TransferProgress
+	report    cloning.TransferProgressFunc
+	startedAt time.Time
+	lastSent  time.Time
+	interval  time.Duration
+	counted   bool
+}
+
+// newProgressWriter creates a progress writer for the given job
+func newProgressWriter(job *cloning.CloneJob, report cloning.TransferProgressFunc) *progressWriter {
+	return &progressWriter{
+		current: cloning.TransferProgress{
+			JobID:      job.ID,
+			Repository: job.Repository.GetFullName(),
+		},
+		report:    report,
+		startedAt: time.Now(),
+		interval:  100 * time.Millisecond,
+	}
+}
+
+// Write implements io.Writer
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buffer = append(w.buffer, p...)
+	for {
+		idx := strings.IndexAny(string(w.buffer), "\r\n")
+		if idx < 0 {
+			break
+		}
+		line := string(w.buffer[:idx])
+		w.buffer = w.buffer[idx+1:]
+		w.handleLine(line)
+	}
+
+	return len(p), nil
+}
+
+// handleLine parses a complete progress line and reports it
+func (w *progressWriter) handleLine(line string) {
+	parsed, ok := parseProgressLine(line)
+	if !ok {
+		return
+	}
+
+	w.current.Phase = parsed.Phase
+	w.current.Percent = parsed.Percent
+	w.current.ObjectsDone = parsed.ObjectsDone
+	w.current.ObjectsTotal = parsed.ObjectsTotal
+
+	// Raw byte counting is more precise than the rounded sizes git prints
+	if !w.counted && parsed.BytesReceived > 0 {
+		w.current.BytesReceived = parsed.BytesReceived
+		w.current.BytesPerSecond = parsed.BytesPerSecond
+	}
+
+	w.emit(parsed.Percent >= 100)
+}
+
+// reportBytes records bytes received from the network
+func (w *progressWriter) reportBytes(n int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.counted = true
+	w.current.BytesReceived += n
+	if elapsed := time.Since(w.startedAt).Seconds(); elapsed > 0 {
+		w.current.BytesPerSecond = float64(w.current.BytesReceived) / elapsed
+	}
+
+	w.emit(false)
+}
+
+// Flush reports the final state regardless of throttling
+func (w *progressWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.emit(true)
+}
+
+// emit sends the current state, throttling intermediate updates (mutex must be held)
+func (w *progressWriter) emit(force bool) {
+	if w.report == nil {
+		return
+	}
+
+	now := time.Now()
+	if !force && now.Sub(w.lastSent) < w.interval {
+		return
+	}
+	w.lastSent = now
+
+	w.current.UpdatedAt = now
+	w.report(w.current)
+}
@@ -0,0 +1,80 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func TestParseProgressLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantOK      bool
+		phase       string
+		percent     float64
+		done        int
+		total       int
+		bytes       int64
+		bytesPerSec float64
+	}{
+		{
+			name: "receiving with throughput", line: "Receiving objects:  45% (450/1000), 1.50 MiB | 512.00 KiB/s",
+			wantOK: true, phase: "Receiving objects", percent: 45, done: 450, total: 1000,
+			bytes: 1572864, bytesPerSec: 524288,
+		},
+		{
+			name: "remote counting", line: "remote: Counting objects: 100% (10/10), done.",
+			wantOK: true, phase: "Counting objects", percent: 100, done: 10, total: 10,
+		},
+		{
+			name: "resolving deltas", line: "Resolving deltas:  50% (5/10)",
+			wantOK: true, phase: "Resolving deltas", percent: 50, done: 5, total: 10,
+		},
+		{name: "unrelated output", line: "Cloning into 'repo'...", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			progress, ok := parseProgressLine(tt.line)
+			assert.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			assert.Equal(t, tt.phase, progress.Phase)
+			assert.Equal(t, tt.percent, progress.Percent)
+			assert.Equal(t, tt.done, progress.ObjectsDone)
+			assert.Equal(t, tt.total, progress.ObjectsTotal)
+			assert.Equal(t, tt.bytes, progress.BytesReceived)
+			assert.InDelta(t, tt.bytesPerSec, progress.BytesPerSecond, 0.5)
+		})
+	}
+}
+
+func TestProgressWriter_SplitsCarriageReturns(t *testing.T) {
+	repo, err := repository.NewRepository(1, "hello", "https://github.com/octocat/hello.git", "octocat", false, 10, "main")
+	require.NoError(t, err)
+	job := cloning.NewCloneJob(repo, t.TempDir(), cloning.NewDefaultCloneOptions())
+
+	var updates []cloning.TransferProgress
+	writer := newProgressWriter(job, func(p cloning.TransferProgress) {
+		updates = append(updates, p)
+	})
+	writer.interval = 0
+
+	_, err = writer.Write([]byte("Receiving objects:  10% (1/10)\rReceiving obj"))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("ects: 100% (10/10), 2.00 KiB | 1.00 KiB/s, done.\n"))
+	require.NoError(t, err)
+
+	require.Len(t, updates, 2)
+	assert.Equal(t, job.ID, updates[0].JobID)
+	assert.Equal(t, "octocat/hello", updates[0].Repository)
+	assert.Equal(t, float64(10), updates[0].Percent)
+	assert.Equal(t, float64(100), updates[1].Percent)
+	assert.Equal(t, int64(2048), updates[1].BytesReceived)
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// provenanceExcludePattern keeps the provenance file out of git status
+const provenanceExcludePattern = "/" + cloning.ProvenanceFileName
+
+// ReadProvenance reads the provenance file of the clone at path
+func ReadProvenance(path string) (*cloning.Provenance, error) {
+	data, err := os.ReadFile(filepath.Join(path, cloning.ProvenanceFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var provenance cloning.Provenance
+	if err := json.Unmarshal(data, &provenance); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", cloning.ProvenanceFileName, err)
+	}
+	return &provenance, nil
+}
+
+// WriteProvenance writes the provenance file of the clone at path and, in
+// working trees, lists it in .git/info/exclude
+func WriteProvenance(path string, provenance *cloning.Provenance) error {
+	data, err := json.MarshalIndent(provenance, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(path, cloning.ProvenanceFileName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", cloning.ProvenanceFileName, err)
+	}
+
+	gitDir := filepath.Join(path, ".git")
+	if stat, err := os.Stat(gitDir); err != nil || !stat.IsDir() {
+		return nil // Bare repository, or a .git file of a linked worktree
+	}
+	return excludeFromStatus(gitDir, provenanceExcludePattern)
+}
+
+// recordCloneProvenance writes the provenance of a fresh clone. The clone
+// itself succeeded, so a failure is only logged.
+func recordCloneProvenance(path string, job *cloning.CloneJob, logger shared.Logger) {
+	if err := WriteProvenance(path, cloning.NewProvenance(job, time.Now())); err != nil {
+		logger.Warn("Failed to record clone provenance",
+			shared.StringField("repo", job.Repository.GetFullName()),
+			shared.ErrorField(err))
+	}
+}
+
+// recordUpdateProvenance stamps the provenance of an updated clone, creating
+// it for clones made before provenance was recorded
+func recordUpdateProvenance(path string, job *cloning.CloneJob, logger shared.Logger) {
+	now := time.Now()
+	provenance, err := ReadProvenance(path)
+	if err != nil {
+		provenance = cloning.NewProvenance(job, now)
+	}
+	provenance.UpdatedAt = now.UTC()
+
+	if err := WriteProvenance(path, provenance); err != nil {
+		logger.Warn("Failed to record clone provenance",
+			shared.StringField("repo", job.Repository.GetFullName()),
+			shared.ErrorField(err))
+	}
+}
+
+// excludeFromStatus appends a pattern to the info/exclude file of a git
+// directory unless it is already listed
+func excludeFromStatus(gitDir, pattern string) error {
+	excludePath := filepath.Join(gitDir, "info", "exclude")
+
+	if file, err := os.Open(excludePath); err == nil {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if strings.TrimSpace(scanner.Text()) == pattern {
+				_ = file.Close()
+				return nil
+			}
+		}
+		_ = file.Close()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(excludePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(file, "\n# repocloner clone provenance\n%s\n", pattern); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,57 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+func TestWriteProvenance(t *testing.T) {
+	t.Run("working tree", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git", "info"), 0755))
+
+		provenance := &cloning.Provenance{
+			Provider:     "github",
+			RepositoryID: 42,
+			FullName:     "bob/tools",
+			SourceURL:    "https://github.com/bob/tools.git",
+			ClonedAt:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+		require.NoError(t, WriteProvenance(dir, provenance))
+		// Writing again does not list the file twice
+		require.NoError(t, WriteProvenance(dir, provenance))
+
+		read, err := ReadProvenance(dir)
+		require.NoError(t, err)
+		assert.Equal(t, provenance, read)
+
+		exclude, err := os.ReadFile(filepath.Join(dir, ".git", "info", "exclude"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(exclude), "/"+cloning.ProvenanceFileName))
+	})
+
+	t.Run("bare repository", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, WriteProvenance(dir, &cloning.Provenance{FullName: "bob/tools"}))
+		assert.FileExists(t, filepath.Join(dir, cloning.ProvenanceFileName))
+		assert.NoDirExists(t, filepath.Join(dir, ".git"))
+	})
+}
+
+func TestReadProvenance(t *testing.T) {
+	dir := t.TempDir()
+	_, err := ReadProvenance(dir)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, cloning.ProvenanceFileName), []byte("{"), 0644))
+	_, err = ReadProvenance(dir)
+	assert.ErrorContains(t, err, "invalid "+cloning.ProvenanceFileName)
+}
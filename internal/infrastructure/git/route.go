@@ -0,0 +1,216 @@
+package git
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Route sends the git traffic of a remote through a proxy or an SSH bastion,
+// for mirrors that are only reachable through one
+type Route struct {
+	// Proxy is a socks5://, socks5h://, http:// or https:// proxy URL. HTTPS
+	// remotes use it as http.proxy; SSH remotes connect through it with
+	// OpenBSD netcat (nc -X) unless a jump host or proxy command is set.
+	Proxy string
+
+	// SSHJump is the ProxyJump destination of SSH remotes, [user@]host[:port],
+	// or several of them separated by commas
+	SSHJump string
+
+	// SSHProxyCommand is the ProxyCommand of SSH remotes, e.g.
+	// "cloudflared access ssh --hostname %h"
+	SSHProxyCommand string
+}
+
+// IsZero reports whether the route connects directly
+func (r Route) IsZero() bool {
+	return r.Proxy == "" && r.SSHJump == "" && r.SSHProxyCommand == ""
+}
+
+// Validate checks the proxy URL and jump hosts
+func (r Route) Validate() error {
+	if r.Proxy != "" {
+		proxyURL, err := url.Parse(r.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return fmt.Errorf("invalid git proxy URL %q", r.Proxy)
+		}
+		switch proxyURL.Scheme {
+		case "socks5", "socks5h", "http", "https":
+		default:
+			return fmt.Errorf("invalid git proxy URL %q: unsupported scheme %s", r.Proxy, proxyURL.Scheme)
+		}
+	}
+	if r.SSHJump == "" {
+		return nil
+	}
+	if r.SSHProxyCommand != "" {
+		return fmt.Errorf("an SSH jump host and an SSH proxy command cannot be combined")
+	}
+	for _, jump := range strings.Split(r.SSHJump, ",") {
+		if jump == "" || strings.HasPrefix(jump, "-") || strings.ContainsAny(jump, " \t'\"") {
+			return fmt.Errorf("invalid SSH jump host %q", jump)
+		}
+	}
+	return nil
+}
+
+// RouteTable selects the route of clone URLs by host. Keys are host names, a
+// *.domain pattern matching every subdomain, or the provider names github,
+// gitlab and bitbucket for their public hosts.
+type RouteTable struct {
+	Default Route            // Route of hosts without their own, e.g. of --git-proxy
+	Hosts   map[string]Route // Routes by host pattern
+}
+
+// providerHosts maps provider names usable as route keys to their public hosts
+var providerHosts = map[string]string{
+	string(ProviderGitHub):    "github.com",
+	string(ProviderGitLab):    "gitlab.com",
+	string(ProviderBitbucket): "bitbucket.org",
+}
+
+// Validate checks every route of the table
+func (t *RouteTable) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if err := t.Default.Validate(); err != nil {
+		return err
+	}
+	for key, route := range t.Hosts {
+		if err := route.Validate(); err != nil {
+			return fmt.Errorf("git route %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// HasSSHRoutes reports whether a route sets an SSH jump host or proxy command
+func (t *RouteTable) HasSSHRoutes() bool {
+	if t == nil {
+		return false
+	}
+	if t.Default.SSHJump != "" || t.Default.SSHProxyCommand != "" {
+		return true
+	}
+	for _, route := range t.Hosts {
+		if route.SSHJump != "" || route.SSHProxyCommand != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup returns the route of a clone URL: the route of its exact host, of
+// the longest matching *.domain pattern, or the default route
+func (t *RouteTable) Lookup(cloneURL string) Route {
+	if t == nil {
+		return Route{}
+	}
+	host, err := parseCloneURLHost(cloneURL)
+	if err != nil {
+		return t.Default
+	}
+
+	route, best := t.Default, -1
+	for key, candidate := range t.Hosts {
+		pattern := strings.ToLower(key)
+		if providerHost, ok := providerHosts[pattern]; ok {
+			pattern = providerHost
+		}
+		if pattern == host {
+			return candidate
+		}
+		if suffix, ok := strings.CutPrefix(pattern, "*."); ok && strings.HasSuffix(host, "."+suffix) && len(suffix) > best {
+			route, best = candidate, len(suffix)
+		}
+	}
+	return route
+}
+
+// proxyFunc returns the Proxy function of an HTTP transport sending requests
+// to the proxy of their route, or through next without one
+func (t *RouteTable) proxyFunc(next func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	if t == nil {
+		return next
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		if proxy := t.Lookup(req.URL.String()).Proxy; proxy != "" {
+			return url.Parse(proxy)
+		}
+		if next == nil {
+			return nil, nil
+		}
+		return next(req)
+	}
+}
+
+// routeOptions returns the git arguments and environment taking a remote
+// through its route: http.proxy for HTTPS remotes and GIT_SSH_COMMAND for
+// SSH remotes
+func routeOptions(route Route, cloneURL string) ([]string, []string) {
+	if route.IsZero() {
+		return nil, nil
+	}
+
+	if !isSSHURL(cloneURL) {
+		if route.Proxy == "" {
+			return nil, nil
+		}
+		return []string{"-c", "http.proxy=" + route.Proxy}, nil
+	}
+
+	var options []string
+	switch {
+	case route.SSHJump != "":
+		options = append(options, "-o", shellQuote("ProxyJump="+route.SSHJump))
+	case route.SSHProxyCommand != "":
+		options = append(options, "-o", shellQuote("ProxyCommand="+route.SSHProxyCommand))
+	case route.Proxy != "":
+		options = append(options, "-o", shellQuote("ProxyCommand="+netcatProxyCommand(route.Proxy)))
+	default:
+		return nil, nil
+	}
+
+	// Keep the ssh command and options the user configured for git
+	sshCommand := os.Getenv("GIT_SSH_COMMAND")
+	if sshCommand == "" {
+		sshCommand = "ssh"
+	}
+	return nil, []string{"GIT_SSH_COMMAND=" + sshCommand + " " + strings.Join(options, " ")}
+}
+
+// netcatProxyCommand connects SSH through a SOCKS5 or HTTP CONNECT proxy with
+// OpenBSD netcat
+func netcatProxyCommand(proxy string) string {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return ""
+	}
+	protocol, port := "5", "1080"
+	if proxyURL.Scheme == "http" || proxyURL.Scheme == "https" {
+		protocol, port = "connect", "3128"
+	}
+	address := proxyURL.Host
+	if proxyURL.Port() == "" {
+		address = net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+	return fmt.Sprintf("nc -X %s -x %s %%h %%p", protocol, address)
+}
+
+// isSSHURL reports whether a clone URL is an ssh:// URL or an scp-like address
+func isSSHURL(cloneURL string) bool {
+	if scheme, _, ok := strings.Cut(cloneURL, "://"); ok {
+		return scheme == "ssh"
+	}
+	return strings.Contains(cloneURL, "@") && strings.Contains(cloneURL, ":")
+}
+
+// shellQuote quotes a word for the shell that runs GIT_SSH_COMMAND
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,217 @@
+package git
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/infrastructure/network"
+)
+
+func TestRoute_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		route   Route
+		wantErr string
+	}{
+		{name: "direct"},
+		{name: "socks proxy", route: Route{Proxy: "socks5h://127.0.0.1:1080"}},
+		{name: "jump hosts", route: Route{SSHJump: "deploy@bastion.example.com:2222,inner"}},
+		{name: "proxy command", route: Route{SSHProxyCommand: "cloudflared access ssh --hostname %h"}},
+		{name: "proxy without host", route: Route{Proxy: "socks5://"}, wantErr: "invalid git proxy URL"},
+		{name: "unsupported scheme", route: Route{Proxy: "ftp://proxy:21"}, wantErr: "unsupported scheme ftp"},
+		{name: "jump host option", route: Route{SSHJump: "-oProxyCommand=evil"}, wantErr: "invalid SSH jump host"},
+		{name: "jump host space", route: Route{SSHJump: "bastion evil"}, wantErr: "invalid SSH jump host"},
+		{name: "empty jump host", route: Route{SSHJump: "a,,b"}, wantErr: "invalid SSH jump host"},
+		{name: "jump and command", route: Route{SSHJump: "bastion", SSHProxyCommand: "nc %h %p"}, wantErr: "cannot be combined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.route.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRouteTable_Lookup(t *testing.T) {
+	routes := &RouteTable{
+		Default: Route{Proxy: "http://proxy.corp:3128"},
+		Hosts: map[string]Route{
+			"gitlab":              {Proxy: "socks5://127.0.0.1:1080"},
+			"*.corp.example":      {SSHJump: "bastion.corp.example"},
+			"*.eu.corp.example":   {SSHJump: "bastion.eu.corp.example"},
+			"git.eu.corp.example": {SSHProxyCommand: "nc %h %p"},
+		},
+	}
+
+	tests := []struct {
+		cloneURL string
+		want     Route
+	}{
+		{cloneURL: "https://gitlab.com/acme/app.git", want: Route{Proxy: "socks5://127.0.0.1:1080"}},
+		{cloneURL: "git@git.corp.example:acme/app.git", want: Route{SSHJump: "bastion.corp.example"}},
+		{cloneURL: "ssh://git@scm.eu.corp.example/acme/app.git", want: Route{SSHJump: "bastion.eu.corp.example"}},
+		{cloneURL: "ssh://git@git.eu.corp.example/acme/app.git", want: Route{SSHProxyCommand: "nc %h %p"}},
+		{cloneURL: "https://github.com/acme/app.git", want: Route{Proxy: "http://proxy.corp:3128"}},
+		{cloneURL: "https://corp.example/acme/app.git", want: Route{Proxy: "http://proxy.corp:3128"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cloneURL, func(t *testing.T) {
+			assert.Equal(t, tt.want, routes.Lookup(tt.cloneURL))
+		})
+	}
+
+	var none *RouteTable
+	assert.True(t, none.Lookup("https://github.com/acme/app.git").IsZero())
+	assert.False(t, none.HasSSHRoutes())
+	assert.True(t, routes.HasSSHRoutes())
+}
+
+func TestRouteOptions(t *testing.T) {
+	t.Setenv("GIT_SSH_COMMAND", "")
+
+	tests := []struct {
+		name     string
+		route    Route
+		cloneURL string
+		wantArgs []string
+		wantEnv  []string
+	}{
+		{
+			name:     "direct",
+			cloneURL: "git@github.com:acme/app.git",
+		},
+		{
+			name:     "https proxy",
+			route:    Route{Proxy: "socks5://127.0.0.1:1080", SSHJump: "bastion"},
+			cloneURL: "https://github.com/acme/app.git",
+			wantArgs: []string{"-c", "http.proxy=socks5://127.0.0.1:1080"},
+		},
+		{
+			name:     "https without proxy",
+			route:    Route{SSHJump: "bastion"},
+			cloneURL: "https://github.com/acme/app.git",
+		},
+		{
+			name:     "ssh jump",
+			route:    Route{Proxy: "socks5://127.0.0.1:1080", SSHJump: "deploy@bastion:2222"},
+			cloneURL: "git@github.com:acme/app.git",
+			wantEnv:  []string{"GIT_SSH_COMMAND=ssh -o 'ProxyJump=deploy@bastion:2222'"},
+		},
+		{
+			name:     "ssh proxy command",
+			route:    Route{SSHProxyCommand: "cloudflared access ssh --hostname '%h'"},
+			cloneURL: "ssh://git@git.example.com/acme/app.git",
+			wantEnv:  []string{`GIT_SSH_COMMAND=ssh -o 'ProxyCommand=cloudflared access ssh --hostname '\''%h'\'''`},
+		},
+		{
+			name:     "ssh through socks proxy",
+			route:    Route{Proxy: "socks5h://127.0.0.1"},
+			cloneURL: "git@github.com:acme/app.git",
+			wantEnv:  []string{"GIT_SSH_COMMAND=ssh -o 'ProxyCommand=nc -X 5 -x 127.0.0.1:1080 %h %p'"},
+		},
+		{
+			name:     "ssh through http proxy",
+			route:    Route{Proxy: "http://proxy.corp:8080"},
+			cloneURL: "git@github.com:acme/app.git",
+			wantEnv:  []string{"GIT_SSH_COMMAND=ssh -o 'ProxyCommand=nc -X connect -x proxy.corp:8080 %h %p'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, env := routeOptions(tt.route, tt.cloneURL)
+			assert.Equal(t, tt.wantArgs, args)
+			assert.Equal(t, tt.wantEnv, env)
+		})
+	}
+
+	// The ssh command configured for git is kept
+	t.Setenv("GIT_SSH_COMMAND", "ssh -i ~/.ssh/deploy")
+	_, env := routeOptions(Route{SSHJump: "bastion"}, "git@github.com:acme/app.git")
+	assert.Equal(t, []string{"GIT_SSH_COMMAND=ssh -i ~/.ssh/deploy -o 'ProxyJump=bastion'"}, env)
+}
+
+func TestRouteTable_ProxyFunc(t *testing.T) {
+	fallback, err := url.Parse("http://fallback:3128")
+	require.NoError(t, err)
+	routes := &RouteTable{Hosts: map[string]Route{"gitlab": {Proxy: "socks5://127.0.0.1:1080"}}}
+	proxy := routes.proxyFunc(http.ProxyURL(fallback))
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "https://gitlab.com/acme/app.git/info/refs", want: "socks5://127.0.0.1:1080"},
+		{target: "https://github.com/acme/app.git/info/refs", want: "http://fallback:3128"},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, tt.target, nil)
+		require.NoError(t, err)
+		got, err := proxy(req)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got.String())
+	}
+}
+
+func TestNewCloneBackend_SSHRoutes(t *testing.T) {
+	routes := &RouteTable{Hosts: map[string]Route{"git.example.com": {SSHJump: "bastion"}}}
+
+	_, err := NewCloneBackend(BackendGoGit, &GitClientConfig{Routes: routes})
+	assert.ErrorContains(t, err, "not supported by the gogit backend")
+}
+
+func TestNewGoGitBackend_InvalidNetwork(t *testing.T) {
+	_, err := NewCloneBackend(BackendGoGit, &GitClientConfig{
+		Logger:  logging.NewNoOpLogger(),
+		Network: &network.Config{CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+	})
+	assert.ErrorContains(t, err, "invalid network settings")
+}
+
+func TestNewGoGitBackend_OwnTransport(t *testing.T) {
+	// Each backend reaches the remote through its own proxy
+	proxied := func() (*httptest.Server, *atomic.Int32) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			http.NotFound(w, nil)
+		}))
+		t.Cleanup(server.Close)
+		return server, &requests
+	}
+	first, firstRequests := proxied()
+	second, secondRequests := proxied()
+
+	repo, err := repository.NewRepository(1, "app", "https://git.example.invalid/acme/app.git", "acme", false, 0, "main")
+	require.NoError(t, err)
+	for _, proxy := range []*httptest.Server{first, second} {
+		backend, err := NewGoGitBackend(&GitClientConfig{
+			Logger:  logging.NewNoOpLogger(),
+			Network: &network.Config{ProxyURL: proxy.URL},
+		})
+		require.NoError(t, err)
+		job := cloning.NewCloneJob(repo, t.TempDir(), nil)
+		assert.Error(t, backend.clone(context.Background(), job, job.GetDestinationPath(), nil, io.Discard))
+	}
+
+	assert.Positive(t, firstRequests.Load())
+	assert.Positive(t, secondRequests.Load())
+}
@@ -0,0 +1,75 @@
+package git
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestSparseClone(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := gogit.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	for _, dir := range []string{"services/api", "services/web", "docs"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(upstreamDir, dir), 0755))
+		commitFile(t, upstream, filepath.Join(dir, "README.md"), dir)
+	}
+	commitFile(t, upstream, "go.mod", "module example")
+
+	tests := []struct {
+		name  string
+		clone func(t *testing.T, job *cloning.CloneJob) error
+	}{
+		{
+			name: BackendGit,
+			clone: func(t *testing.T, job *cloning.CloneJob) error {
+				if _, err := exec.LookPath("git"); err != nil {
+					t.Skip("git is not installed")
+				}
+				client, err := NewGitClient(&GitClientConfig{Logger: logging.NewNoOpLogger()})
+				require.NoError(t, err)
+				return client.clone(context.Background(), job, job.GetDestinationPath(), nil, io.Discard)
+			},
+		},
+		{
+			name: BackendGoGit,
+			clone: func(t *testing.T, job *cloning.CloneJob) error {
+				backend, err := NewGoGitBackend(&GitClientConfig{Logger: logging.NewNoOpLogger()})
+				require.NoError(t, err)
+				return backend.clone(context.Background(), job, job.GetDestinationPath(), nil, io.Discard)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := repository.NewRepository(1, "mono", "https://github.com/acme/mono.git", "acme", false, 0, "master")
+			require.NoError(t, err)
+			repo.CloneURL = "file://" + upstreamDir
+
+			options := cloning.NewDefaultCloneOptions()
+			options.Depth = 0
+			options.RecurseSubmodules = false
+			options.SparsePaths = []string{"services/api"}
+			job := cloning.NewCloneJob(repo, t.TempDir(), options)
+
+			require.NoError(t, tt.clone(t, job))
+
+			dest := job.GetDestinationPath()
+			assert.FileExists(t, filepath.Join(dest, "services", "api", "README.md"))
+			assert.NoFileExists(t, filepath.Join(dest, "services", "web", "README.md"))
+			assert.NoFileExists(t, filepath.Join(dest, "docs", "README.md"))
+		})
+	}
+}
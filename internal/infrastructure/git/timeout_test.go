@@ -0,0 +1,86 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestCloneTimeout(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := gogit.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	commitFile(t, upstream, "README.md", "hello")
+
+	config := func() *GitClientConfig {
+		return &GitClientConfig{Logger: logging.NewNoOpLogger(), Timeout: time.Nanosecond}
+	}
+	tests := []struct {
+		name  string
+		clone func(t *testing.T, job *cloning.CloneJob) error
+	}{
+		{
+			name: BackendGit,
+			clone: func(t *testing.T, job *cloning.CloneJob) error {
+				if _, err := exec.LookPath("git"); err != nil {
+					t.Skip("git is not installed")
+				}
+				client, err := NewGitClient(config())
+				require.NoError(t, err)
+				return client.clone(context.Background(), job, job.GetDestinationPath(), nil, io.Discard)
+			},
+		},
+		{
+			name: BackendGoGit,
+			clone: func(t *testing.T, job *cloning.CloneJob) error {
+				backend, err := NewGoGitBackend(config())
+				require.NoError(t, err)
+				return backend.clone(context.Background(), job, job.GetDestinationPath(), nil, io.Discard)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := repository.NewRepository(1, "slow", "https://github.com/acme/slow.git", "acme", false, 0, "master")
+			require.NoError(t, err)
+			repo.CloneURL = "file://" + upstreamDir
+
+			options := cloning.NewDefaultCloneOptions()
+			options.RecurseSubmodules = false
+			job := cloning.NewCloneJob(repo, t.TempDir(), options)
+
+			err = tt.clone(t, job)
+
+			var timeoutErr *TimeoutError
+			require.ErrorAs(t, err, &timeoutErr)
+			assert.Equal(t, time.Nanosecond, timeoutErr.Timeout)
+			assert.ErrorIs(t, err, cloning.ErrCloneTimeout)
+			assert.NoDirExists(t, job.GetDestinationPath(), "partial clone removed")
+		})
+	}
+}
+
+func TestJobTimeout(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	assert.ErrorIs(t, jobTimeout(context.Background(), expired, "clone", time.Minute), cloning.ErrCloneTimeout)
+	assert.NoError(t, jobTimeout(context.Background(), context.Background(), "clone", time.Minute))
+
+	// The run deadline is a cancellation, not a per-job timeout
+	assert.NoError(t, jobTimeout(expired, expired, "clone", time.Minute))
+
+	// Connection timeouts reported by git do not match the per-job timeout
+	assert.False(t, errors.Is(&TimeoutError{Message: "Connection timed out"}, cloning.ErrCloneTimeout))
+}
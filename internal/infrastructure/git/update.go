@@ -0,0 +1,174 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// UpdateClone brings the existing clone of a job up to date: `git fetch`
+// and a fast-forward only pull for working trees, `git remote update` for
+// mirrors. A detached HEAD, e.g. a clone at a pinned ref, is only fetched.
+func (g *GitClient) UpdateClone(ctx context.Context, job *cloning.CloneJob) error {
+	destPath := job.GetDestinationPath()
+
+	authArgs, authEnv, err := g.remoteOptions(ctx, job.Repository.CloneURL)
+	if err != nil {
+		return &AuthenticationError{Message: fmt.Sprintf("failed to resolve credentials: %v", err)}
+	}
+	var env []string
+	if len(authEnv) > 0 {
+		env = append(os.Environ(), authEnv...)
+	}
+
+	log, err := openJobLog(g.jobLogDir, job)
+	if err != nil {
+		log = nopWriteCloser{io.Discard}
+	}
+
+	updateCtx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	err = g.update(updateCtx, job, authArgs, env, log)
+	if timeoutErr := jobTimeout(ctx, updateCtx, "update", g.timeout); err != nil && timeoutErr != nil {
+		err = timeoutErr
+	}
+	closeJobLog(log, err)
+	if err != nil {
+		return err
+	}
+	recordUpdateProvenance(destPath, job, g.contextLogger(ctx))
+
+	g.contextLogger(ctx).Info("Repository updated successfully",
+		shared.StringField("repo", job.Repository.GetFullName()),
+		shared.StringField("path", destPath))
+	return nil
+}
+
+// update runs the git commands updating the clone at the job destination
+func (g *GitClient) update(ctx context.Context, job *cloning.CloneJob, authArgs, env []string, log io.Writer) error {
+	destPath := job.GetDestinationPath()
+
+	steps := [][]string{{"remote", "update", "--prune"}}
+	if !isBareRepositoryAt(destPath) {
+		steps = [][]string{{"fetch", "--prune", "--quiet", "origin"}}
+		if _, err := g.runGit(ctx, env, nil, "-C", destPath, "symbolic-ref", "--quiet", "HEAD"); err == nil {
+			steps = append(steps, []string{"pull", "--ff-only", "--quiet"})
+		}
+	}
+
+	for _, step := range steps {
+		args := append(append(append([]string{}, authArgs...), "-C", destPath), step...)
+		if output, err := g.runGit(ctx, env, log, args...); err != nil {
+			return g.parseGitError(err, output)
+		}
+	}
+
+	if job.Options.RecurseSubmodules && !isBareRepositoryAt(destPath) {
+		return g.updateSubmodules(ctx, destPath, job.Options, authArgs, env, log, 1)
+	}
+	return nil
+}
+
+// UpdateClone brings the existing clone of a job up to date by fetching
+// origin and fast-forwarding the checked out branch
+func (b *GoGitBackend) UpdateClone(ctx context.Context, job *cloning.CloneJob) error {
+	if err := b.checkBandwidthLimit(job.Repository.CloneURL); err != nil {
+		return err
+	}
+	destPath := job.GetDestinationPath()
+
+	repo, err := gogit.PlainOpen(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to open repository at %s: %w", destPath, err)
+	}
+
+	cred, err := b.credentials.Lookup(ctx, job.Repository.CloneURL)
+	if err != nil {
+		return &AuthenticationError{Message: fmt.Sprintf("failed to resolve credentials: %v", err)}
+	}
+	auth := newHostBasicAuth(job.Repository.CloneURL, cred)
+
+	log, err := openJobLog(b.jobLogDir, job)
+	if err != nil {
+		log = nopWriteCloser{io.Discard}
+	}
+
+	updateCtx, cancel := context.WithTimeout(withTransport(ctx, b.transport), b.timeout)
+	defer cancel()
+	if b.bandwidth != nil {
+		updateCtx = withBandwidthLimiter(updateCtx, b.bandwidth)
+	}
+
+	err = b.update(updateCtx, repo, auth, b.sshProxy(job.Repository.CloneURL), log)
+	if timeoutErr := jobTimeout(ctx, updateCtx, "update", b.timeout); err != nil && timeoutErr != nil {
+		err = timeoutErr
+	}
+	closeJobLog(log, err)
+	if err != nil {
+		return err
+	}
+	recordUpdateProvenance(destPath, job, b.contextLogger(ctx))
+
+	b.contextLogger(ctx).Info("Repository updated successfully",
+		shared.StringField("repo", job.Repository.GetFullName()),
+		shared.StringField("path", destPath),
+		shared.StringField("backend", BackendGoGit))
+	return nil
+}
+
+// update fetches origin and fast-forwards the checked out branch. go-git
+// pulls refuse anything but fast-forwards.
+func (b *GoGitBackend) update(ctx context.Context, repo *gogit.Repository, auth *hostBasicAuth, proxy transport.ProxyOptions, log io.Writer) error {
+	fetch := &gogit.FetchOptions{RemoteName: "origin", Prune: true, Progress: log, ProxyOptions: proxy}
+	if auth != nil {
+		fetch.Auth = auth
+	}
+	if err := repo.FetchContext(ctx, fetch); err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return b.mapError(ctx, err)
+	}
+
+	worktree, err := repo.Worktree()
+	if errors.Is(err, gogit.ErrIsBareRepository) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open worktree: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	if !head.Name().IsBranch() {
+		return nil
+	}
+
+	pull := &gogit.PullOptions{RemoteName: "origin", ReferenceName: head.Name(), SingleBranch: true, Progress: log, ProxyOptions: proxy}
+	if auth != nil {
+		pull.Auth = auth
+	}
+	if err := worktree.PullContext(ctx, pull); err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return b.mapError(ctx, err)
+	}
+	return nil
+}
+
+// isBareRepositoryAt reports whether path is a bare repository, such as a mirror
+func isBareRepositoryAt(path string) bool {
+	if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+		return false
+	}
+	head, headErr := os.Stat(filepath.Join(path, "HEAD"))
+	objects, objectsErr := os.Stat(filepath.Join(path, "objects"))
+	return headErr == nil && !head.IsDir() && objectsErr == nil && objects.IsDir()
+}
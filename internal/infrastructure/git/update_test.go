@@ -0,0 +1,92 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// commitFile writes a file to the worktree of repo and commits it
+func commitFile(t *testing.T, repo *gogit.Repository, name, content string) plumbing.Hash {
+	t.Helper()
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(worktree.Filesystem.Root(), name), []byte(content), 0644))
+	_, err = worktree.Add(name)
+	require.NoError(t, err)
+	hash, err := worktree.Commit("update "+name, &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	return hash
+}
+
+func TestGoGitBackend_UpdateClone(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := gogit.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	commitFile(t, upstream, "README.md", "first")
+
+	baseDir := t.TempDir()
+	repo, err := repository.NewRepository(1, "tools", "https://github.com/bob/tools.git", "bob", false, 0, "master")
+	require.NoError(t, err)
+	options := cloning.NewDefaultCloneOptions()
+	options.Existing = cloning.ExistingUpdate
+	job := cloning.NewCloneJob(repo, baseDir, options)
+
+	_, err = gogit.PlainClone(job.GetDestinationPath(), false, &gogit.CloneOptions{URL: upstreamDir})
+	require.NoError(t, err)
+
+	latest := commitFile(t, upstream, "README.md", "second")
+
+	backend, err := NewGoGitBackend(&GitClientConfig{Logger: logging.NewNoOpLogger()})
+	require.NoError(t, err)
+	require.NoError(t, backend.UpdateClone(context.Background(), job))
+
+	clone, err := gogit.PlainOpen(job.GetDestinationPath())
+	require.NoError(t, err)
+	head, err := clone.Head()
+	require.NoError(t, err)
+	assert.Equal(t, latest, head.Hash())
+
+	// The update is stamped in the provenance file, which git ignores
+	provenance, err := ReadProvenance(job.GetDestinationPath())
+	require.NoError(t, err)
+	assert.Equal(t, "bob/tools", provenance.FullName)
+	assert.False(t, provenance.UpdatedAt.IsZero())
+	worktree, err := clone.Worktree()
+	require.NoError(t, err)
+	status, err := worktree.Status()
+	require.NoError(t, err)
+	assert.True(t, status.IsClean(), status.String())
+
+	// Nothing new upstream is not an error
+	require.NoError(t, backend.UpdateClone(context.Background(), job))
+}
+
+func TestIsBareRepositoryAt(t *testing.T) {
+	bareDir := t.TempDir()
+	_, err := gogit.PlainInit(bareDir, true)
+	require.NoError(t, err)
+
+	worktreeDir := t.TempDir()
+	_, err = gogit.PlainInit(worktreeDir, false)
+	require.NoError(t, err)
+
+	assert.True(t, isBareRepositoryAt(bareDir))
+	assert.True(t, repositoryExistsAt(bareDir))
+	assert.False(t, isBareRepositoryAt(worktreeDir))
+	assert.False(t, isBareRepositoryAt(t.TempDir()))
+}
@@ -0,0 +1,48 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// UpstreamRemote names the remote pointing at the parent of a fork
+const UpstreamRemote = "upstream"
+
+// AddUpstreamRemote adds an upstream remote fetching from url to a local
+// repository. An existing upstream remote is left unchanged.
+func AddUpstreamRemote(path, url string) error {
+	repo, err := gogit.PlainOpen(path)
+	if err != nil {
+		return fmt.Errorf("failed to open repository at %s: %w", path, err)
+	}
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: UpstreamRemote,
+		URLs: []string{url},
+	})
+	if err != nil && !errors.Is(err, gogit.ErrRemoteExists) {
+		return fmt.Errorf("failed to add %s remote: %w", UpstreamRemote, err)
+	}
+	return nil
+}
+
+// configureUpstream adds the upstream remote of a freshly cloned fork. The
+// clone itself succeeded, so a failure is only logged.
+func configureUpstream(path string, job *cloning.CloneJob, logger shared.Logger) {
+	upstream := job.Repository.UpstreamURL
+	if upstream == "" {
+		return
+	}
+
+	if err := AddUpstreamRemote(path, upstream); err != nil {
+		logger.Warn("Failed to configure upstream remote",
+			shared.StringField("repo", job.Repository.GetFullName()),
+			shared.ErrorField(err))
+	}
+}
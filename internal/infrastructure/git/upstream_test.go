@@ -0,0 +1,28 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddUpstreamRemote(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tool")
+	initClone(t, path, "https://github.com/me/tool.git")
+
+	require.NoError(t, AddUpstreamRemote(path, "https://github.com/acme/tool.git"))
+	// An existing upstream is kept
+	require.NoError(t, AddUpstreamRemote(path, "https://github.com/other/tool.git"))
+
+	repo, err := gogit.PlainOpen(path)
+	require.NoError(t, err)
+	remote, err := repo.Remote(UpstreamRemote)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://github.com/acme/tool.git"}, remote.Config().URLs)
+	assert.Equal(t, "+refs/heads/*:refs/remotes/upstream/*", remote.Config().Fetch[0].String())
+
+	assert.Error(t, AddUpstreamRemote(t.TempDir(), "https://github.com/acme/tool.git"))
+}
@@ -1,11 +1,17 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/italoag/repocloner/internal/domain/cloning"
 	"github.com/italoag/repocloner/internal/domain/shared"
@@ -38,13 +44,28 @@ func (e *RepositoryNotFoundError) Error() string {
 }
 
 type RepositoryExistsError struct {
-	Path string
+	Path      string
+	RemoteURL string // Origin of the existing clone when it is a different remote
 }
 
 func (e *RepositoryExistsError) Error() string {
+	if e.RemoteURL != "" {
+		return fmt.Sprintf("a clone of %s already exists at: %s", e.RemoteURL, e.Path)
+	}
 	return fmt.Sprintf("repository already exists at: %s", e.Path)
 }
 
+// RemoteConflictError reports a destination holding a clone of a different remote
+type RemoteConflictError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+func (e *RemoteConflictError) Error() string {
+	return fmt.Sprintf("destination %s is a clone of %s, expected %s", e.Path, e.Actual, e.Expected)
+}
+
 type PermissionError struct {
 	Message string
 }
@@ -61,14 +82,35 @@ func (e *NetworkError) Error() string {
 	return e.Message
 }
 
+// TimeoutError reports a timed out clone, update or connection. Timeout is
+// the per-job limit that expired, zero when git reported a connection timeout.
 type TimeoutError struct {
 	Message string
+	Timeout time.Duration
 }
 
 func (e *TimeoutError) Error() string {
 	return e.Message
 }
 
+// Is matches cloning.ErrCloneTimeout when the per-job limit expired, so
+// timeouts are counted without depending on the backend
+func (e *TimeoutError) Is(target error) bool {
+	return e.Timeout > 0 && target == cloning.ErrCloneTimeout
+}
+
+// jobTimeout returns a TimeoutError when the per-job timeout of jobCtx
+// expired while ctx, the context of the whole run, is still live
+func jobTimeout(ctx, jobCtx context.Context, operation string, timeout time.Duration) error {
+	if ctx.Err() != nil || !errors.Is(jobCtx.Err(), context.DeadlineExceeded) {
+		return nil
+	}
+	return &TimeoutError{
+		Message: fmt.Sprintf("%s timed out after %s", operation, timeout),
+		Timeout: timeout,
+	}
+}
+
 type DiskSpaceError struct {
 	Message string
 }
@@ -85,9 +127,42 @@ func (e *PathTooLongError) Error() string {
 	return e.Message
 }
 
+// UnsupportedOptionError reports a clone option the backend cannot honour
+// for a repository, such as a bandwidth limit over SSH with go-git
+type UnsupportedOptionError struct {
+	Message string
+}
+
+func (e *UnsupportedOptionError) Error() string {
+	return e.Message
+}
+
+type RefNotFoundError struct {
+	Ref string
+}
+
+func (e *RefNotFoundError) Error() string {
+	return fmt.Sprintf("ref not found: %s", e.Ref)
+}
+
+// defaultAllowedHosts are the public providers whose clone URLs are always accepted
+var defaultAllowedHosts = []string{"github.com", "gitlab.com", "bitbucket.org"}
+
+// HostPolicy decides which clone URL hosts the validator accepts
+type HostPolicy struct {
+	// AllowedHosts are accepted in addition to the public providers; an entry
+	// such as *.example.com also matches every subdomain
+	AllowedHosts []string
+
+	// Strict rejects clone URLs of other hosts instead of logging a warning
+	Strict bool
+}
+
 // GitValidator validates Git operations and repository states
 type GitValidator struct {
-	logger shared.Logger
+	logger      shared.Logger
+	hosts       HostPolicy
+	warnedHosts sync.Map // Unknown hosts already reported
 }
 
 // NewGitValidator creates a new Git validator
@@ -97,6 +172,18 @@ func NewGitValidator(logger shared.Logger) *GitValidator {
 	}
 }
 
+// SetHostPolicy sets the hosts accepted by clone URL validation. Entries are
+// normalized to lower-case host names, so URLs and host:port are accepted.
+func (v *GitValidator) SetHostPolicy(policy HostPolicy) {
+	allowed := make([]string, 0, len(policy.AllowedHosts))
+	for _, entry := range policy.AllowedHosts {
+		if host := normalizeAllowedHost(entry); host != "" {
+			allowed = append(allowed, host)
+		}
+	}
+	v.hosts = HostPolicy{AllowedHosts: allowed, Strict: policy.Strict}
+}
+
 // ValidateCloneJob validates a clone job before execution
 func (v *GitValidator) ValidateCloneJob(job *cloning.CloneJob) error {
 	if job == nil {
@@ -125,40 +212,114 @@ func (v *GitValidator) ValidateCloneJob(job *cloning.CloneJob) error {
 	return nil
 }
 
-// ValidateCloneURL validates a Git clone URL
-func (v *GitValidator) ValidateCloneURL(url string) error {
-	if url == "" {
-		return fmt.Errorf("clone URL cannot be empty")
+// ValidateCloneURL validates a Git clone URL: an https:// or ssh:// URL, or
+// an scp-like user@host:path address, with an owner/repository path. The
+// ".git" suffix is optional. Hosts outside the public providers and the host
+// policy are rejected in strict mode and reported once otherwise.
+func (v *GitValidator) ValidateCloneURL(cloneURL string) error {
+	host, err := parseCloneURLHost(cloneURL)
+	if err != nil {
+		return err
 	}
 
-	// Valid URL patterns for Git
-	patterns := []string{
-		`^https://github\.com/[a-zA-Z0-9._-]+/[a-zA-Z0-9._-]+\.git$`,
-		`^git@github\.com:[a-zA-Z0-9._-]+/[a-zA-Z0-9._-]+\.git$`,
-		`^https://gitlab\.com/[a-zA-Z0-9._/-]+\.git$`,
-		`^git@gitlab\.com:[a-zA-Z0-9._/-]+\.git$`,
-		`^https://bitbucket\.org/[a-zA-Z0-9._-]+/[a-zA-Z0-9._-]+\.git$`,
-		`^git@bitbucket\.org:[a-zA-Z0-9._-]+/[a-zA-Z0-9._-]+\.git$`,
+	if v.isAllowedHost(host) {
+		return nil
+	}
+	if v.hosts.Strict {
+		return fmt.Errorf("clone URL host %s is not allowed, add it to --allowed-hosts", host)
+	}
+	if _, warned := v.warnedHosts.LoadOrStore(host, true); !warned {
+		v.logger.Warn("Cloning from a host outside the allowed hosts, pass --allowed-hosts to silence or --strict-hosts to reject",
+			shared.StringField("host", host))
 	}
+	return nil
+}
 
-	for _, pattern := range patterns {
-		matched, err := regexp.MatchString(pattern, url)
+// isAllowedHost reports whether a host is a public provider or matches the host policy
+func (v *GitValidator) isAllowedHost(host string) bool {
+	for _, allowed := range slices.Concat(defaultAllowedHosts, v.hosts.AllowedHosts) {
+		if host == allowed {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(allowed, "*."); ok && strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CloneURLHost returns the lower-case host of a clone URL, or an empty
+// string when the URL is not a valid clone URL
+func CloneURLHost(raw string) string {
+	host, err := parseCloneURLHost(raw)
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// parseCloneURLHost checks the form of a clone URL and returns its lower-case host
+func parseCloneURLHost(raw string) (string, error) {
+	if raw == "" {
+		return "", fmt.Errorf("clone URL cannot be empty")
+	}
+	// Clone URLs are passed as git arguments, never allow them to look like options
+	if strings.HasPrefix(raw, "-") {
+		return "", fmt.Errorf("clone URL cannot start with '-': %s", raw)
+	}
+
+	var host, path string
+	if strings.Contains(raw, "://") {
+		parsed, err := url.Parse(raw)
 		if err != nil {
-			continue
+			return "", fmt.Errorf("invalid clone URL %s: %w", raw, err)
 		}
-		if matched {
-			return nil
+		if parsed.Scheme != "https" && parsed.Scheme != "ssh" {
+			return "", fmt.Errorf("unsupported clone URL scheme %q, use https or ssh: %s", parsed.Scheme, raw)
 		}
+		host, path = parsed.Hostname(), parsed.Path
+	} else {
+		// scp-like address: user@host:path
+		userHost, scpPath, ok := strings.Cut(raw, ":")
+		at := strings.LastIndex(userHost, "@")
+		if !ok || at <= 0 {
+			return "", fmt.Errorf("invalid or unsupported clone URL format: %s", raw)
+		}
+		host, path = userHost[at+1:], scpPath
+	}
+
+	if host == "" || strings.HasPrefix(host, "-") {
+		return "", fmt.Errorf("clone URL must have a valid host: %s", raw)
 	}
 
-	// More flexible validation for other Git hosting services
-	if strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "git@") {
-		if strings.HasSuffix(url, ".git") {
-			return nil
+	segments := strings.Split(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+	if len(segments) < 2 {
+		return "", fmt.Errorf("clone URL must name an owner and a repository: %s", raw)
+	}
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("clone URL has an invalid path: %s", raw)
 		}
 	}
 
-	return fmt.Errorf("invalid or unsupported clone URL format: %s", url)
+	return strings.ToLower(host), nil
+}
+
+// normalizeAllowedHost reduces an --allowed-hosts entry, which may be a URL or
+// host:port, to a lower-case host name
+func normalizeAllowedHost(entry string) string {
+	host := strings.ToLower(strings.TrimSpace(entry))
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	if name, port, ok := strings.Cut(host, ":"); ok && port != "" {
+		host = name
+	}
+	return host
 }
 
 // ValidateDestinationPath validates the destination path for cloning
@@ -214,6 +375,26 @@ func (v *GitValidator) ValidateCloneOptions(options *cloning.CloneOptions) error
 		return fmt.Errorf("clone depth cannot be negative: %d", options.Depth)
 	}
 
+	if options.SubmoduleDepth < 0 {
+		return fmt.Errorf("submodule depth cannot be negative: %d", options.SubmoduleDepth)
+	}
+
+	if options.Existing != "" {
+		if _, err := cloning.ParseExistingAction(string(options.Existing)); err != nil {
+			return err
+		}
+	}
+
+	if options.OnConflict != "" {
+		if _, err := cloning.ParseConflictPolicy(string(options.OnConflict)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := cloning.ParseCloneFilter(string(options.Filter)); err != nil {
+		return err
+	}
+
 	if options.Branch != "" {
 		// Validate branch name format
 		if err := v.validateBranchName(options.Branch); err != nil {
@@ -221,7 +402,13 @@ func (v *GitValidator) ValidateCloneOptions(options *cloning.CloneOptions) error
 		}
 	}
 
-	return nil
+	if options.Ref != "" {
+		if err := v.validateRefName(options.Ref); err != nil {
+			return fmt.Errorf("invalid ref: %w", err)
+		}
+	}
+
+	return cloning.ValidateSparsePaths(options.SparsePaths)
 }
 
 // validatePathCharacters checks for invalid characters in file paths
@@ -335,6 +522,20 @@ func (v *GitValidator) validateBranchName(branch string) error {
 	return nil
 }
 
+// validateRefName validates a tag name or commit SHA passed to --ref
+func (v *GitValidator) validateRefName(ref string) error {
+	// Refs are passed as git arguments, never allow them to look like options
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("ref cannot start with '-': %s", ref)
+	}
+
+	if isCommitSHA(ref) {
+		return nil
+	}
+
+	return v.validateBranchName(ref)
+}
+
 // ValidateGitRepository checks if a directory contains a valid Git repository
 func (v *GitValidator) ValidateGitRepository(path string) error {
 	gitDir := filepath.Join(path, ".git")
@@ -393,9 +594,37 @@ func (v *GitValidator) IsRetryableError(err error) bool {
 // IsPermanentError determines if a Git error is permanent and shouldn't be retried
 func (v *GitValidator) IsPermanentError(err error) bool {
 	switch err.(type) {
-	case *AuthenticationError, *RepositoryNotFoundError, *PermissionError, *DiskSpaceError, *PathTooLongError:
+	case *AuthenticationError, *RepositoryNotFoundError, *PermissionError, *DiskSpaceError, *PathTooLongError, *RefNotFoundError, *RemoteConflictError, *UnsupportedOptionError:
 		return true
 	}
 
 	return false
 }
+
+// IsThrottleError determines if a Git error suggests the remote is throttling
+// or overloaded (timeouts, HTTP 429), a sign to clone with fewer workers
+func (v *GitValidator) IsThrottleError(err error) bool {
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+
+	var gitErr *GitError
+	if errors.As(err, &gitErr) {
+		output := strings.ToLower(gitErr.Message + "\n" + gitErr.Output)
+		throttleMessages := []string{
+			"429",
+			"too many requests",
+			"rate limit",
+			"timed out",
+		}
+
+		for _, msg := range throttleMessages {
+			if strings.Contains(output, msg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
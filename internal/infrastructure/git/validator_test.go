@@ -0,0 +1,129 @@
+package git
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestValidateCloneOptions_Ref(t *testing.T) {
+	validator := NewGitValidator(logging.NewNoOpLogger())
+
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr bool
+	}{
+		{name: "no ref", ref: ""},
+		{name: "tag", ref: "v1.2.3"},
+		{name: "namespaced tag", ref: "release/2024-01"},
+		{name: "full sha", ref: "0123456789abcdef0123456789abcdef01234567"},
+		{name: "short sha", ref: "0123abc"},
+		{name: "option injection", ref: "--upload-pack=evil", wantErr: true},
+		{name: "reflog syntax", ref: "HEAD@{1}", wantErr: true},
+		{name: "parent syntax", ref: "v1^", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := cloning.NewDefaultCloneOptions()
+			options.Ref = tt.ref
+
+			err := validator.ValidateCloneOptions(options)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGitVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{output: "git version 2.39.5", want: false},
+		{output: "git version 2.49.0", want: true},
+		{output: "git version 2.50.1 (Apple Git-155)", want: true},
+		{output: "git version 3.0.0", want: true},
+		{output: "git version", want: false},
+		{output: "unexpected", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			assert.Equal(t, tt.want, gitVersionAtLeast(tt.output, minRevisionGitVersion))
+		})
+	}
+}
+
+func TestIsThrottleError(t *testing.T) {
+	validator := NewGitValidator(logging.NewNoOpLogger())
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "timeout", err: &TimeoutError{Message: "Connection timed out"}, want: true},
+		{name: "wrapped timeout", err: fmt.Errorf("clone: %w", &TimeoutError{Message: "Clone timed out"}), want: true},
+		{name: "http 429", err: &GitError{Message: "git clone failed", Output: "error: RPC failed; HTTP 429 curl 22"}, want: true},
+		{name: "rate limit", err: &GitError{Message: "git clone failed", Output: "remote: API rate limit exceeded"}, want: true},
+		{name: "other git error", err: &GitError{Message: "git clone failed", Output: "fatal: early EOF"}, want: false},
+		{name: "not found", err: &RepositoryNotFoundError{Message: "Repository not found"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validator.IsThrottleError(tt.err))
+		})
+	}
+}
+
+func TestValidateCloneURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		policy  HostPolicy
+		wantErr bool
+	}{
+		{name: "github https", url: "https://github.com/owner/repo.git"},
+		{name: "github scp", url: "git@github.com:owner/repo.git"},
+		{name: "bitbucket without suffix", url: "https://bitbucket.org/workspace/repo"},
+		{name: "gitlab subgroup", url: "https://gitlab.com/group/sub/repo.git"},
+		{name: "credentials in url", url: "https://x-token-auth@bitbucket.org/workspace/repo.git"},
+		{name: "unknown host warns", url: "https://git.example.com/team/repo"},
+		{name: "unknown host strict", url: "https://git.example.com/team/repo", policy: HostPolicy{Strict: true}, wantErr: true},
+		{name: "allowed host strict", url: "https://git.example.com/team/repo", policy: HostPolicy{AllowedHosts: []string{"git.example.com"}, Strict: true}},
+		{name: "allowed as url with port", url: "ssh://git@git.example.com:7999/proj/repo.git", policy: HostPolicy{AllowedHosts: []string{"https://git.example.com:8443/"}, Strict: true}},
+		{name: "wildcard subdomain", url: "https://scm.corp.example/proj/repo.git", policy: HostPolicy{AllowedHosts: []string{"*.corp.example"}, Strict: true}},
+		{name: "wildcard excludes apex", url: "https://corp.example/proj/repo.git", policy: HostPolicy{AllowedHosts: []string{"*.corp.example"}, Strict: true}, wantErr: true},
+		{name: "empty", url: "", wantErr: true},
+		{name: "option injection", url: "--upload-pack=evil", wantErr: true},
+		{name: "http scheme", url: "http://github.com/owner/repo.git", wantErr: true},
+		{name: "file scheme", url: "file:///tmp/owner/repo.git", wantErr: true},
+		{name: "local path", url: "/tmp/owner/repo.git", wantErr: true},
+		{name: "missing owner", url: "https://github.com/repo.git", wantErr: true},
+		{name: "path traversal", url: "https://github.com/owner/../repo.git", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewGitValidator(logging.NewNoOpLogger())
+			validator.SetHostPolicy(tt.policy)
+
+			err := validator.ValidateCloneURL(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
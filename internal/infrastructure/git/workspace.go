@@ -0,0 +1,89 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+
+	"github.com/italoag/repocloner/internal/infrastructure/redact"
+)
+
+// IsDirty reports whether the working tree of a local repository has
+// uncommitted or untracked changes. Bare repositories are never dirty.
+func IsDirty(path string) (bool, error) {
+	repo, err := gogit.PlainOpen(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open repository at %s: %w", path, err)
+	}
+
+	worktree, err := repo.Worktree()
+	if errors.Is(err, gogit.ErrIsBareRepository) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open working tree at %s: %w", path, err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return false, fmt.Errorf("failed to read status at %s: %w", path, err)
+	}
+	return !status.IsClean(), nil
+}
+
+// LastFetch returns when a local repository last fetched from a remote, read
+// from the modification time of FETCH_HEAD; zero when it never fetched since
+// it was cloned
+func LastFetch(path string) time.Time {
+	gitDir := filepath.Join(path, ".git")
+	if isBareRepositoryAt(path) {
+		gitDir = path
+	}
+
+	info, err := os.Stat(filepath.Join(gitDir, "FETCH_HEAD"))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// GarbageCollect runs `git gc` with the given arguments in a local
+// repository, compressing its objects and pruning unreachable ones
+func GarbageCollect(ctx context.Context, path string, args ...string) error {
+	gcArgs := append([]string{"-C", path, "gc", "--quiet"}, args...)
+	output, err := exec.CommandContext(ctx, "git", gcArgs...).CombinedOutput()
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("git gc failed: %w: %s", err, redact.String(strings.TrimSpace(string(output))))
+	}
+	return nil
+}
+
+// Archive writes the tree of a revision of a local repository to output with
+// `git archive`, every path prefixed with prefix. The format is one of git's
+// archive formats: tar, tar.gz or zip.
+func Archive(ctx context.Context, path, revision, format, prefix, output string) error {
+	args := []string{"-C", path, "archive", "--format=" + format, "--output=" + output}
+	if prefix != "" {
+		args = append(args, "--prefix="+strings.TrimSuffix(prefix, "/")+"/")
+	}
+	args = append(args, revision)
+
+	result, err := exec.CommandContext(ctx, "git", args...).CombinedOutput()
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("git archive failed: %w: %s", err, redact.String(strings.TrimSpace(string(result))))
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// TokenSource provides the token used to authenticate GitHub requests
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticTokenSource always returns the same token (personal access tokens)
+type StaticTokenSource string
+
+// Token returns the static token
+func (s StaticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+const (
+	// appJWTLifetime is the validity of the app JWT (GitHub allows up to 10 minutes)
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockSkew backdates the JWT issue time to tolerate clock drift
+	appJWTClockSkew = 60 * time.Second
+	// installationTokenRefreshMargin refreshes tokens this long before expiry
+	installationTokenRefreshMargin = 5 * time.Minute
+)
+
+// AppAuthConfig holds configuration for GitHub App authentication
+type AppAuthConfig struct {
+	AppID          int64
+	InstallationID int64
+	PrivateKey     []byte // PEM encoded RSA private key
+	BaseURL        string
+	UserAgent      string
+	Timeout        time.Duration
+	Transport      http.RoundTripper // Optional, nil uses http.DefaultTransport
+	Logger         shared.Logger
+}
+
+// AppTokenSource mints and caches GitHub App installation tokens,
+// refreshing them shortly before they expire
+type AppTokenSource struct {
+	appID          int64
+	installationID int64
+	privateKey     *rsa.PrivateKey
+	baseURL        string
+	userAgent      string
+	httpClient     *http.Client
+	logger         shared.Logger
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	now       func() time.Time
+}
+
+// NewAppTokenSource creates a token source for a GitHub App installation
+func NewAppTokenSource(config *AppAuthConfig) (*AppTokenSource, error) {
+	if config.AppID <= 0 {
+		return nil, fmt.Errorf("github app ID is required")
+	}
+	if config.InstallationID <= 0 {
+		return nil, fmt.Errorf("github app installation ID is required")
+	}
+
+	key, err := parseRSAPrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid github app private key: %w", err)
+	}
+
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.github.com"
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = "repocloner/1.0"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &AppTokenSource{
+		appID:          config.AppID,
+		installationID: config.InstallationID,
+		privateKey:     key,
+		baseURL:        config.BaseURL,
+		userAgent:      config.UserAgent,
+		httpClient:     &http.Client{Timeout: config.Timeout, Transport: config.Transport},
+		logger:         config.Logger,
+		now:            time.Now,
+	}, nil
+}
+
+// Token returns a valid installation token, minting a new one when the
+// cached token is missing or about to expire
+func (s *AppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Add(installationTokenRefreshMargin).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	token, expiresAt, err := s.mintInstallationToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.expiresAt = expiresAt
+
+	if s.logger != nil {
+		s.logger.Info("GitHub App installation token refreshed",
+			shared.StringField("installation_id", strconv.FormatInt(s.installationID, 10)),
+			shared.StringField("expires_at", expiresAt.Format(time.RFC3339)))
+	}
+
+	return token, nil
+}
+
+// mintInstallationToken exchanges an app JWT for an installation token
+func (s *AppTokenSource) mintInstallationToken(ctx context.Context) (string, time.Time, error) {
+	jwt, err := s.signJWT()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign app JWT: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", s.baseURL, s.installationID)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", time.Time{}, fmt.Errorf("failed to create installation token: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResponse struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode installation token: %w", err)
+	}
+	if tokenResponse.Token == "" {
+		return "", time.Time{}, fmt.Errorf("installation token response did not include a token")
+	}
+
+	return tokenResponse.Token, tokenResponse.ExpiresAt, nil
+}
+
+// signJWT builds the RS256 JWT that authenticates as the app itself
+func (s *AppTokenSource) signJWT() (string, error) {
+	now := s.now()
+
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	claims := map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(s.appID, 10),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." +
+		base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseRSAPrivateKey parses a PKCS#1 or PKCS#8 PEM encoded RSA key
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+
+	return key, nil
+}
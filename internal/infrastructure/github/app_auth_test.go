@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAppTokenSource(t *testing.T, serverURL string) (*AppTokenSource, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	source, err := NewAppTokenSource(&AppAuthConfig{
+		AppID:          42,
+		InstallationID: 7,
+		PrivateKey:     pemKey,
+		BaseURL:        serverURL,
+	})
+	require.NoError(t, err)
+	return source, key
+}
+
+func TestAppTokenSource_MintsAndCachesToken(t *testing.T) {
+	var requests int
+	var key *rsa.PrivateKey
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/app/installations/7/access_tokens", r.URL.Path)
+
+		// Verify the JWT is signed with the app key and issued by the app
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		require.Len(t, parts, 3)
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+		claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		var claims map[string]any
+		require.NoError(t, json.Unmarshal(claimsJSON, &claims))
+		assert.Equal(t, "42", claims["iss"])
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":"%s"}`,
+			requests, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	source, privateKey := newTestAppTokenSource(t, server.URL)
+	key = privateKey
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_1", token)
+
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_1", token, "cached token should be reused")
+	assert.Equal(t, 1, requests)
+
+	// Move the clock close to expiry to force a refresh
+	source.now = func() time.Time { return time.Now().Add(58 * time.Minute) }
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_2", token)
+	assert.Equal(t, 2, requests)
+}
+
+func TestAppTokenSource_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"bad credentials"}`))
+	}))
+	defer server.Close()
+
+	source, _ := newTestAppTokenSource(t, server.URL)
+
+	_, err := source.Token(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 401")
+}
+
+func TestNewAppTokenSource_Validation(t *testing.T) {
+	_, err := NewAppTokenSource(&AppAuthConfig{InstallationID: 1, PrivateKey: []byte("x")})
+	assert.Error(t, err)
+
+	_, err = NewAppTokenSource(&AppAuthConfig{AppID: 1, PrivateKey: []byte("x")})
+	assert.Error(t, err)
+
+	_, err = NewAppTokenSource(&AppAuthConfig{AppID: 1, InstallationID: 1, PrivateKey: []byte("not a pem")})
+	assert.Error(t, err)
+}
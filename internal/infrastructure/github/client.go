@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,17 +16,55 @@ import (
 
 // GitHubAPIResponse represents the structure of GitHub API responses
 type GitHubAPIResponse struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	FullName      string    `json:"full_name"`
-	CloneURL      string    `json:"clone_url"`
-	Fork          bool      `json:"fork"`
-	Size          int64     `json:"size"`
-	DefaultBranch string    `json:"default_branch"`
-	Language      string    `json:"language"`
-	Description   string    `json:"description"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	Owner         OwnerInfo `json:"owner"`
+	ID            int64            `json:"id"`
+	Name          string           `json:"name"`
+	FullName      string           `json:"full_name"`
+	CloneURL      string           `json:"clone_url"`
+	Fork          bool             `json:"fork"`
+	Archived      bool             `json:"archived"`
+	Private       bool             `json:"private"`
+	IsTemplate    bool             `json:"is_template"`
+	Visibility    string           `json:"visibility"` // public, private or internal
+	Size          int64            `json:"size"`       // Kilobytes
+	DefaultBranch string           `json:"default_branch"`
+	Language      string           `json:"language"`
+	Description   string           `json:"description"`
+	Topics        []string         `json:"topics"`
+	HasIssues     bool             `json:"has_issues"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+	PushedAt      time.Time        `json:"pushed_at"`
+	Owner         OwnerInfo        `json:"owner"`
+	Parent        *ParentInfo      `json:"parent,omitempty"`      // Only reported when fetching a single fork
+	Permissions   *PermissionsInfo `json:"permissions,omitempty"` // Authenticated user, or the team on team listings
+}
+
+// PermissionsInfo represents the access granted on a repository
+type PermissionsInfo struct {
+	Admin    bool `json:"admin"`
+	Maintain bool `json:"maintain"`
+	Push     bool `json:"push"`
+	Triage   bool `json:"triage"`
+	Pull     bool `json:"pull"`
+}
+
+// Highest returns the most privileged permission granted
+func (p *PermissionsInfo) Highest() repository.Permission {
+	switch {
+	case p == nil:
+		return ""
+	case p.Admin:
+		return repository.PermissionAdmin
+	case p.Maintain:
+		return repository.PermissionMaintain
+	case p.Push:
+		return repository.PermissionPush
+	case p.Triage:
+		return repository.PermissionTriage
+	case p.Pull:
+		return repository.PermissionPull
+	default:
+		return ""
+	}
 }
 
 // OwnerInfo represents repository owner information
@@ -36,16 +75,17 @@ type OwnerInfo struct {
 
 // RateLimitInfo represents GitHub API rate limit information
 type RateLimitInfo struct {
-	Limit     int       `json:"limit"`
-	Remaining int       `json:"remaining"`
-	ResetTime time.Time `json:"reset_time"`
+	Limit       int       `json:"limit"`
+	Remaining   int       `json:"remaining"`
+	ResetTime   time.Time `json:"reset_time"`
+	PausedUntil time.Time `json:"paused_until,omitempty"` // Secondary rate limit backoff
 }
 
 // GitHubClient handles interactions with GitHub API
 type GitHubClient struct {
 	httpClient  *http.Client
 	baseURL     string
-	token       string
+	tokenSource TokenSource
 	userAgent   string
 	rateLimiter RateLimiter
 	logger      shared.Logger
@@ -54,9 +94,11 @@ type GitHubClient struct {
 // GitHubClientConfig holds configuration for GitHub client
 type GitHubClientConfig struct {
 	Token       string
+	TokenSource TokenSource // Overrides Token, e.g. for GitHub App installations
 	BaseURL     string
 	UserAgent   string
 	Timeout     time.Duration
+	Transport   http.RoundTripper // Optional, e.g. with a proxy or extra CAs; nil uses http.DefaultTransport
 	RateLimiter RateLimiter
 	Logger      shared.Logger
 }
@@ -73,12 +115,18 @@ func NewGitHubClient(config *GitHubClientConfig) *GitHubClient {
 		config.Timeout = 30 * time.Second
 	}
 
+	tokenSource := config.TokenSource
+	if tokenSource == nil && config.Token != "" {
+		tokenSource = StaticTokenSource(config.Token)
+	}
+
 	return &GitHubClient{
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: config.Transport,
 		},
 		baseURL:     config.BaseURL,
-		token:       config.Token,
+		tokenSource: tokenSource,
 		userAgent:   config.UserAgent,
 		rateLimiter: config.RateLimiter,
 		logger:      config.Logger,
@@ -93,62 +141,119 @@ func (c *GitHubClient) FetchRepositories(
 	filter *repository.RepositoryFilter,
 	pagination *repository.PaginationOptions,
 ) ([]*repository.Repository, error) {
+	var repos []*repository.Repository
+	err := c.FetchRepositoryPages(ctx, owner, repoType, filter, pagination, func(page []*repository.Repository) error {
+		repos = append(repos, page...)
+		return nil
+	})
+	if err != nil {
+		return repos, err
+	}
+
+	c.logger.Info("Successfully fetched repositories",
+		shared.StringField("owner", owner),
+		shared.StringField("type", repoType.String()),
+		shared.IntField("total", len(repos)))
+
+	return repos, nil
+}
+
+// FetchRepositoryPages fetches repositories page by page, handing the filtered
+// repositories of each page to onPage as soon as it arrives
+func (c *GitHubClient) FetchRepositoryPages(
+	ctx context.Context,
+	owner string,
+	repoType repository.RepositoryType,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	onPage repository.PageHandler,
+) error {
+	return c.fetchPages(ctx, fmt.Sprintf("%s/%s/repos", repoType.String(), owner), filter, pagination, onPage)
+}
+
+// FetchTeamRepositoryPages fetches the repositories an organization team has
+// access to, page by page. Repository permissions are those of the team.
+func (c *GitHubClient) FetchTeamRepositoryPages(
+	ctx context.Context,
+	org, team string,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	onPage repository.PageHandler,
+) error {
+	err := c.fetchPages(ctx, fmt.Sprintf("orgs/%s/teams/%s/repos", org, team), filter, pagination, onPage)
+	if errors.Is(err, repository.ErrRepositoryNotFound) {
+		return fmt.Errorf("team %s/%s not found or not visible to the token: %w", org, team, err)
+	}
+	return err
+}
+
+// fetchPages pages through a repository listing endpoint
+func (c *GitHubClient) fetchPages(
+	ctx context.Context,
+	path string,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	onPage repository.PageHandler,
+) error {
 	if pagination == nil {
 		pagination = repository.NewPaginationOptions()
 	}
 
-	var repos []*repository.Repository
-	page := 1
+	page := pagination.Page
+	if page < 1 {
+		page = 1
+	}
 
 	for {
-		pageRepos, hasMore, err := c.fetchRepositoryPage(ctx, owner, repoType, page, pagination.PerPage)
+		pageRepos, hasMore, err := c.fetchRepositoryPage(ctx, path, page, pagination.PerPage, pagination.Sort)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+			return fmt.Errorf("failed to fetch page %d: %w", page, err)
 		}
 
 		// Apply filtering
+		included := make([]*repository.Repository, 0, len(pageRepos))
 		for _, repo := range pageRepos {
 			if filter == nil || filter.ShouldInclude(repo) {
-				repos = append(repos, repo)
+				included = append(included, repo)
 			}
 		}
 
-		if !hasMore {
-			break
+		if err := onPage(included); err != nil {
+			if errors.Is(err, repository.ErrStopPaging) {
+				return nil
+			}
+			return err
+		}
+
+		if !hasMore || !pagination.HasMorePages(page) {
+			return nil
 		}
 		page++
 
 		// Check context cancellation
 		if ctx.Err() != nil {
-			return repos, ctx.Err()
+			return ctx.Err()
 		}
 	}
-
-	c.logger.Info("Successfully fetched repositories",
-		shared.StringField("owner", owner),
-		shared.StringField("type", repoType.String()),
-		shared.IntField("total", len(repos)))
-
-	return repos, nil
 }
 
-// fetchRepositoryPage fetches a single page of repositories
+// fetchRepositoryPage fetches a single page of a repository listing endpoint
 func (c *GitHubClient) fetchRepositoryPage(
 	ctx context.Context,
-	owner string,
-	repoType repository.RepositoryType,
+	path string,
 	page, perPage int,
+	sortBy string,
 ) ([]*repository.Repository, bool, error) {
-	// Wait for rate limiter
-	if c.rateLimiter != nil {
-		if err := c.rateLimiter.Wait(ctx); err != nil {
-			return nil, false, fmt.Errorf("rate limiter error: %w", err)
-		}
+	url := fmt.Sprintf("%s/%s?per_page=%d&page=%d", c.baseURL, path, perPage, page)
+
+	// full_name sorts ascending and updated descending by default
+	switch sortBy {
+	case repository.SortByName:
+		url += "&sort=full_name"
+	case repository.SortByUpdated:
+		url += "&sort=updated"
 	}
 
-	url := fmt.Sprintf("%s/%s/%s/repos?per_page=%d&page=%d",
-		c.baseURL, repoType.String(), owner, perPage, page)
-
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to create request: %w", err)
@@ -158,13 +263,13 @@ func (c *GitHubClient) fetchRepositoryPage(
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", c.userAgent)
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "token "+c.token)
+	if err := c.authorize(req); err != nil {
+		return nil, false, err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to execute request: %w", err)
+		return nil, false, err
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -172,11 +277,6 @@ func (c *GitHubClient) fetchRepositoryPage(
 		}
 	}()
 
-	// Update rate limiter with response headers
-	if c.rateLimiter != nil {
-		c.updateRateLimitFromResponse(resp)
-	}
-
 	// Handle different status codes
 	switch resp.StatusCode {
 	case http.StatusOK:
@@ -184,9 +284,12 @@ func (c *GitHubClient) fetchRepositoryPage(
 	case http.StatusNotFound:
 		return nil, false, repository.ErrRepositoryNotFound
 	case http.StatusUnauthorized:
-		return nil, false, fmt.Errorf("authentication failed: check your token")
-	case http.StatusForbidden:
-		return nil, false, fmt.Errorf("access forbidden: rate limit exceeded or insufficient permissions")
+		return nil, false, fmt.Errorf("%w: check your token", repository.ErrAuthenticationFailed)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if isRateLimited(resp) {
+			return nil, false, fmt.Errorf("%w: resets at %s", repository.ErrRateLimitExceeded, rateLimitReset(resp))
+		}
+		return nil, false, fmt.Errorf("%w: access forbidden, check your token permissions", repository.ErrRepositoryAccessDenied)
 	default:
 		body, _ := io.ReadAll(resp.Body)
 		return nil, false, fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, string(body))
@@ -219,20 +322,58 @@ func (c *GitHubClient) fetchRepositoryPage(
 
 // convertToDomainRepository converts GitHub API response to domain repository
 func (c *GitHubClient) convertToDomainRepository(apiRepo *GitHubAPIResponse) (*repository.Repository, error) {
-	return repository.NewRepository(
+	repo, err := repository.NewRepository(
 		repository.RepositoryID(apiRepo.ID),
 		apiRepo.Name,
 		apiRepo.CloneURL,
 		apiRepo.Owner.Login,
 		apiRepo.Fork,
-		apiRepo.Size,
+		apiRepo.Size*1024, // GitHub reports sizes in kilobytes
 		apiRepo.DefaultBranch,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.Language = apiRepo.Language
+	repo.Description = apiRepo.Description
+	repo.Topics = apiRepo.Topics
+	repo.HasIssues = apiRepo.HasIssues
+	repo.Archived = apiRepo.Archived
+	repo.IsTemplate = apiRepo.IsTemplate
+	repo.UpdatedAt = apiRepo.UpdatedAt
+	repo.PushedAt = apiRepo.PushedAt
+	repo.Permission = apiRepo.Permissions.Highest()
+	if apiRepo.Parent != nil {
+		repo.UpstreamURL = apiRepo.Parent.CloneURL
+	}
+	repo.Visibility = repository.VisibilityFromPrivate(apiRepo.Private)
+	if visibility, err := repository.ParseVisibility(apiRepo.Visibility); err == nil {
+		repo.Visibility = visibility
+	}
+	return repo, nil
+}
+
+// rateLimitReset returns the rate limit reset time advertised by a response
+func rateLimitReset(resp *http.Response) string {
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(reset, 0).Format(time.RFC3339)
+	}
+	return "unknown"
 }
 
 // updateRateLimitFromResponse updates rate limiter based on response headers
 func (c *GitHubClient) updateRateLimitFromResponse(resp *http.Response) {
+	// Search and other resources have budgets of their own
+	if resource := resp.Header.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return
+	}
+
 	if rateLimiter, ok := c.rateLimiter.(*TokenBucketRateLimiter); ok {
+		if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+			rateLimiter.UpdateLimit(limit)
+		}
+
 		if remaining := resp.Header.Get("X-RateLimit-Remaining"); remaining != "" {
 			if remainingInt, err := strconv.Atoi(remaining); err == nil {
 				rateLimiter.UpdateRemaining(remainingInt)
@@ -260,8 +401,8 @@ func (c *GitHubClient) GetRateLimitInfo(ctx context.Context) (*RateLimitInfo, er
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", c.userAgent)
 
-	if c.token != "" {
-		req.Header.Set("Authorization", "token "+c.token)
+	if err := c.authorize(req); err != nil {
+		return nil, err
 	}
 
 	resp, err := c.httpClient.Do(req)
@@ -290,19 +431,63 @@ func (c *GitHubClient) GetRateLimitInfo(ctx context.Context) (*RateLimitInfo, er
 		return nil, fmt.Errorf("failed to decode rate limit response: %w", err)
 	}
 
-	return &RateLimitInfo{
+	info := &RateLimitInfo{
 		Limit:     rateLimitResponse.Rate.Limit,
 		Remaining: rateLimitResponse.Rate.Remaining,
 		ResetTime: time.Unix(int64(rateLimitResponse.Rate.Reset), 0),
-	}, nil
+	}
+
+	// Start the local budget from GitHub's view
+	if rateLimiter, ok := c.rateLimiter.(*TokenBucketRateLimiter); ok {
+		rateLimiter.UpdateLimit(info.Limit)
+		rateLimiter.UpdateRemaining(info.Remaining)
+		rateLimiter.UpdateResetTime(info.ResetTime)
+	}
+
+	return info, nil
+}
+
+// RateLimitStatus returns the locally tracked rate limit budget without
+// calling the API, or nil when the rate limiter does not track one. Seed it
+// with GetRateLimitInfo; responses keep it current.
+func (c *GitHubClient) RateLimitStatus() *RateLimitInfo {
+	rateLimiter, ok := c.rateLimiter.(*TokenBucketRateLimiter)
+	if !ok {
+		return nil
+	}
+
+	info := rateLimiter.Info()
+	return &info
+}
+
+// authorize sets the Authorization header from the configured token source
+func (c *GitHubClient) authorize(req *http.Request) error {
+	if c.tokenSource == nil {
+		return nil
+	}
+
+	token, err := c.tokenSource.Token(req.Context())
+	if err != nil {
+		return fmt.Errorf("failed to obtain GitHub token: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+	return nil
 }
 
 // ValidateToken checks if the provided token is valid
 func (c *GitHubClient) ValidateToken(ctx context.Context) error {
-	if c.token == "" {
+	if c.tokenSource == nil {
 		return fmt.Errorf("no token provided")
 	}
 
+	// Installation tokens cannot call /user; minting one proves the app credentials
+	if _, ok := c.tokenSource.(*AppTokenSource); ok {
+		_, err := c.tokenSource.Token(ctx)
+		return err
+	}
+
 	url := fmt.Sprintf("%s/user", c.baseURL)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -310,7 +495,9 @@ func (c *GitHubClient) ValidateToken(ctx context.Context) error {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "token "+c.token)
+	if err := c.authorize(req); err != nil {
+		return err
+	}
 	req.Header.Set("User-Agent", c.userAgent)
 
 	resp, err := c.httpClient.Do(req)
@@ -333,3 +520,56 @@ func (c *GitHubClient) ValidateToken(ctx context.Context) error {
 
 	return nil
 }
+
+// GetOwnerType reports whether an account is a user or an organization
+func (c *GitHubClient) GetOwnerType(ctx context.Context, owner string) (repository.RepositoryType, error) {
+	url := fmt.Sprintf("%s/users/%s", c.baseURL, owner)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	if err := c.authorize(req); err != nil {
+		return "", err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			c.logger.Warn("failed to close response body", shared.ErrorField(err))
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", fmt.Errorf("%w: GitHub account %s", repository.ErrInvalidOwner, owner)
+	case http.StatusUnauthorized:
+		return "", fmt.Errorf("%w: check your token", repository.ErrAuthenticationFailed)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if isRateLimited(resp) {
+			return "", fmt.Errorf("%w: resets at %s", repository.ErrRateLimitExceeded, rateLimitReset(resp))
+		}
+		return "", fmt.Errorf("%w: access forbidden, check your token permissions", repository.ErrRepositoryAccessDenied)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var account OwnerInfo
+	if err := json.NewDecoder(resp.Body).Decode(&account); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if account.Type == "Organization" {
+		return repository.RepositoryTypeOrganization, nil
+	}
+	return repository.RepositoryTypeUser, nil
+}
@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// newPagedServer serves `pages` full pages of repositories for org "acme"
+func newPagedServer(t *testing.T, pages, perPage int, requested *[]string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requested = append(*requested, r.URL.RawQuery)
+
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		require.NoError(t, err)
+
+		repos := []GitHubAPIResponse{}
+		if page <= pages {
+			for i := 0; i < perPage; i++ {
+				name := fmt.Sprintf("repo-%d-%d", page, i)
+				repos = append(repos, GitHubAPIResponse{
+					ID:       int64(page*1000 + i),
+					Name:     name,
+					CloneURL: "https://github.com/acme/" + name + ".git",
+					Size:     2,
+					Owner:    OwnerInfo{Login: "acme"},
+				})
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(repos))
+	}))
+}
+
+func TestFetchRepositoryPages(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination *repository.PaginationOptions
+		stopAfter  int
+		wantPages  int
+		wantQuery  string
+	}{
+		{
+			name:       "every page",
+			pagination: &repository.PaginationOptions{Page: 1, PerPage: 2},
+			wantPages:  4, // A full last page is followed by an empty one
+			wantQuery:  "per_page=2&page=1",
+		},
+		{
+			name:       "max pages from a start page",
+			pagination: &repository.PaginationOptions{Page: 2, PerPage: 2, MaxPages: 1},
+			wantPages:  1,
+			wantQuery:  "per_page=2&page=2",
+		},
+		{
+			name:       "provider sort",
+			pagination: &repository.PaginationOptions{Page: 1, PerPage: 2, Sort: repository.SortByUpdated},
+			stopAfter:  1,
+			wantPages:  1,
+			wantQuery:  "per_page=2&page=1&sort=updated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []string
+			server := newPagedServer(t, 3, 2, &requested)
+			defer server.Close()
+
+			client := NewGitHubClient(&GitHubClientConfig{BaseURL: server.URL, Logger: logging.NewNoOpLogger()})
+
+			var pages [][]*repository.Repository
+			err := client.FetchRepositoryPages(context.Background(), "acme", repository.RepositoryTypeOrganization,
+				nil, tt.pagination, func(repos []*repository.Repository) error {
+					pages = append(pages, repos)
+					if tt.stopAfter > 0 && len(pages) == tt.stopAfter {
+						return repository.ErrStopPaging
+					}
+					return nil
+				})
+			require.NoError(t, err)
+
+			assert.Len(t, pages, tt.wantPages)
+			require.NotEmpty(t, requested)
+			assert.Equal(t, tt.wantQuery, requested[0])
+			assert.Equal(t, int64(2048), pages[0][0].Size, "sizes are converted from kilobytes")
+		})
+	}
+}
+
+func TestFetchTeamRepositoryPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/acme/teams/platform/repos" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"id":1,"name":"infra","clone_url":"https://github.com/acme/infra.git","owner":{"login":"acme"},
+			 "permissions":{"admin":false,"maintain":true,"push":true,"triage":true,"pull":true}},
+			{"id":2,"name":"docs","clone_url":"https://github.com/acme/docs.git","owner":{"login":"acme"},
+			 "permissions":{"pull":true}}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewGitHubClient(&GitHubClientConfig{BaseURL: server.URL, Logger: logging.NewNoOpLogger()})
+
+	filter := repository.NewRepositoryFilter()
+	filter.MinPermission = repository.PermissionPush
+
+	var repos []*repository.Repository
+	err := client.FetchTeamRepositoryPages(context.Background(), "acme", "platform", filter, nil,
+		func(page []*repository.Repository) error {
+			repos = append(repos, page...)
+			return nil
+		})
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "acme/infra", repos[0].GetFullName())
+	assert.Equal(t, repository.PermissionMaintain, repos[0].Permission)
+
+	err = client.FetchTeamRepositoryPages(context.Background(), "acme", "missing", nil, nil,
+		func([]*repository.Repository) error { return nil })
+	assert.ErrorIs(t, err, repository.ErrRepositoryNotFound)
+	assert.ErrorContains(t, err, "team acme/missing")
+}
+
+func TestConvertToDomainRepository_Visibility(t *testing.T) {
+	client := NewGitHubClient(&GitHubClientConfig{Logger: logging.NewNoOpLogger()})
+
+	tests := []struct {
+		name string
+		api  GitHubAPIResponse
+		want repository.Visibility
+	}{
+		{name: "visibility field", api: GitHubAPIResponse{Private: true, Visibility: "internal"}, want: repository.VisibilityInternal},
+		{name: "private flag", api: GitHubAPIResponse{Private: true}, want: repository.VisibilityPrivate},
+		{name: "public", api: GitHubAPIResponse{}, want: repository.VisibilityPublic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.api.ID = 1
+			tt.api.Name = "repo"
+			tt.api.CloneURL = "https://github.com/acme/repo.git"
+			tt.api.Owner = OwnerInfo{Login: "acme"}
+
+			repo, err := client.convertToDomainRepository(&tt.api)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, repo.Visibility)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DeviceFlowClientID is the client ID of the OAuth app used by the device
+// flow, set at build time with
+// -ldflags "-X github.com/italoag/repocloner/internal/infrastructure/github.DeviceFlowClientID=..."
+var DeviceFlowClientID = ""
+
+// DefaultDeviceFlowScopes are the scopes requested by the device flow: every
+// repository and organization listing of the clone commands
+var DefaultDeviceFlowScopes = []string{ScopeRepo, ScopeReadOrg}
+
+// DefaultDevicePollInterval is the wait between token polls when the device
+// code carries no interval, as RFC 8628 section 3.2 prescribes
+const DefaultDevicePollInterval = 5 * time.Second
+
+// deviceGrantType is the OAuth grant of device flow token requests
+const deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// DeviceFlowConfig holds configuration for the OAuth device flow
+type DeviceFlowConfig struct {
+	ClientID  string
+	Scopes    []string
+	BaseURL   string // Web host of the OAuth endpoints, https://github.com by default
+	UserAgent string
+	Timeout   time.Duration
+	Transport http.RoundTripper // Optional, e.g. with a proxy or extra CAs; nil uses http.DefaultTransport
+}
+
+// DeviceCode is the code the user enters to authorize the device
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"` // Seconds
+	Interval        int    `json:"interval"`   // Minimum seconds between token polls
+}
+
+// DeviceFlow obtains a user token through the OAuth device authorization
+// flow: the user enters a code on github.com while the token is polled for
+type DeviceFlow struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+	clientID   string
+	scopes     []string
+	interval   time.Duration // Poll interval when the device code has none
+}
+
+// NewDeviceFlow creates an OAuth device flow
+func NewDeviceFlow(config *DeviceFlowConfig) *DeviceFlow {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://github.com"
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = "repocloner/1.0"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = DefaultDeviceFlowScopes
+	}
+
+	return &DeviceFlow{
+		httpClient: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: config.Transport,
+		},
+		baseURL:   strings.TrimSuffix(config.BaseURL, "/"),
+		userAgent: config.UserAgent,
+		interval:  DefaultDevicePollInterval,
+		clientID:  config.ClientID,
+		scopes:    config.Scopes,
+	}
+}
+
+// RequestCode starts the flow, returning the code to show the user
+func (f *DeviceFlow) RequestCode(ctx context.Context) (*DeviceCode, error) {
+	if f.clientID == "" {
+		return nil, fmt.Errorf("no OAuth app client ID configured for the device flow")
+	}
+
+	var code DeviceCode
+	err := f.post(ctx, "/login/device/code", url.Values{
+		"client_id": {f.clientID},
+		"scope":     {strings.Join(f.scopes, " ")},
+	}, &code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+	if code.DeviceCode == "" || code.UserCode == "" {
+		return nil, fmt.Errorf("failed to request device code: empty response")
+	}
+	return &code, nil
+}
+
+// tokenResponse is the answer to a token poll: a token or an error code
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+	Interval         int    `json:"interval"`
+}
+
+// PollToken polls until the user authorized the device, returning the
+// access token. It fails when the user denies access or the code expires.
+func (f *DeviceFlow) PollToken(ctx context.Context, code *DeviceCode) (string, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = f.interval
+	}
+	if code.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(code.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("device authorization expired: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+
+		var resp tokenResponse
+		err := f.post(ctx, "/login/oauth/access_token", url.Values{
+			"client_id":   {f.clientID},
+			"device_code": {code.DeviceCode},
+			"grant_type":  {deviceGrantType},
+		}, &resp)
+		if err != nil {
+			return "", fmt.Errorf("failed to poll for the token: %w", err)
+		}
+
+		switch resp.Error {
+		case "":
+			if resp.AccessToken == "" {
+				return "", fmt.Errorf("failed to poll for the token: empty response")
+			}
+			return resp.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			// The answer carries the new minimum interval
+			if resp.Interval > 0 {
+				interval = time.Duration(resp.Interval) * time.Second
+			} else {
+				interval += 5 * time.Second
+			}
+		case "expired_token":
+			return "", fmt.Errorf("device code expired, run the login again")
+		case "access_denied":
+			return "", fmt.Errorf("authorization was denied")
+		default:
+			return "", fmt.Errorf("device authorization failed: %s %s", resp.Error, resp.ErrorDescription)
+		}
+	}
+}
+
+// post sends a form to an OAuth endpoint and decodes the JSON answer
+func (f *DeviceFlow) post(ctx context.Context, path string, form url.Values, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", f.userAgent)
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deviceFlowServer answers code requests and the given token poll answers in
+// turn, repeating the last one
+func deviceFlowServer(t *testing.T, polls ...map[string]any) *httptest.Server {
+	t.Helper()
+	poll := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client", r.PostForm.Get("client_id"))
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+
+		switch r.URL.Path {
+		case "/login/device/code":
+			assert.Equal(t, "repo read:org", r.PostForm.Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"device_code":      "device",
+				"user_code":        "ABCD-1234",
+				"verification_uri": "https://github.com/login/device",
+				"expires_in":       900,
+			})
+		case "/login/oauth/access_token":
+			assert.Equal(t, "device", r.PostForm.Get("device_code"))
+			assert.Equal(t, deviceGrantType, r.PostForm.Get("grant_type"))
+			_ = json.NewEncoder(w).Encode(polls[min(poll, len(polls)-1)])
+			poll++
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDeviceFlow(t *testing.T) {
+	tests := []struct {
+		name    string
+		polls   []map[string]any
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "authorized after pending",
+			polls: []map[string]any{{"error": "authorization_pending"}, {"access_token": "gho_token"}},
+			want:  "gho_token",
+		},
+		{name: "denied", polls: []map[string]any{{"error": "access_denied"}}, wantErr: "authorization was denied"},
+		{name: "expired", polls: []map[string]any{{"error": "expired_token"}}, wantErr: "device code expired"},
+		{name: "unknown error", polls: []map[string]any{{"error": "unsupported_grant_type"}}, wantErr: "unsupported_grant_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := deviceFlowServer(t, tt.polls...)
+			flow := NewDeviceFlow(&DeviceFlowConfig{ClientID: "client", BaseURL: server.URL})
+			flow.interval = time.Millisecond
+
+			code, err := flow.RequestCode(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "ABCD-1234", code.UserCode)
+			assert.Equal(t, "https://github.com/login/device", code.VerificationURI)
+
+			token, err := flow.PollToken(context.Background(), code)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, token)
+		})
+	}
+}
+
+func TestDeviceFlowWithoutClientID(t *testing.T) {
+	flow := NewDeviceFlow(&DeviceFlowConfig{})
+	_, err := flow.RequestCode(context.Background())
+	assert.ErrorContains(t, err, "no OAuth app client ID")
+}
+
+func TestDeviceFlowCancelled(t *testing.T) {
+	server := deviceFlowServer(t, map[string]any{"error": "authorization_pending"})
+	flow := NewDeviceFlow(&DeviceFlowConfig{ClientID: "client", BaseURL: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := flow.PollToken(ctx, &DeviceCode{DeviceCode: "device", Interval: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDeviceFlowDefaultInterval(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": "authorization_pending"})
+	}))
+	t.Cleanup(server.Close)
+	flow := NewDeviceFlow(&DeviceFlowConfig{ClientID: "client", BaseURL: server.URL})
+	assert.Equal(t, DefaultDevicePollInterval, flow.interval)
+
+	// Without an interval the token is not polled in a busy loop
+	flow.interval = 50 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 175*time.Millisecond)
+	defer cancel()
+	_, err := flow.PollToken(ctx, &DeviceCode{DeviceCode: "device", Interval: -1})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.LessOrEqual(t, polls.Load(), int32(3))
+}
@@ -0,0 +1,35 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ParentInfo represents the repository a fork was created from
+type ParentInfo struct {
+	FullName string `json:"full_name"`
+	CloneURL string `json:"clone_url"`
+}
+
+// FetchUpstreamURL returns the clone URL of the parent of a fork, or an empty
+// string for repositories that are not forks. Repository listings do not
+// report parents, so each fork costs one request.
+func (c *GitHubClient) FetchUpstreamURL(ctx context.Context, owner, name string) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, owner, name)
+
+	resp, err := c.get(ctx, url, "application/vnd.github.v3+json")
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s/%s: %w", owner, name, err)
+	}
+	defer c.closeBody(resp)
+
+	var apiRepo GitHubAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiRepo); err != nil {
+		return "", fmt.Errorf("failed to decode %s/%s: %w", owner, name, err)
+	}
+	if apiRepo.Parent == nil {
+		return "", nil
+	}
+	return apiRepo.Parent.CloneURL, nil
+}
@@ -0,0 +1,41 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestFetchUpstreamURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/me/fork":
+			_, _ = w.Write([]byte(`{"name": "fork", "fork": true, "parent": {"full_name": "acme/tool", "clone_url": "https://github.com/acme/tool.git"}}`))
+		case "/repos/me/source":
+			_, _ = w.Write([]byte(`{"name": "source", "fork": false}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGitHubClient(&GitHubClientConfig{BaseURL: server.URL, Logger: logging.NewNoOpLogger()})
+
+	upstream, err := client.FetchUpstreamURL(context.Background(), "me", "fork")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/tool.git", upstream)
+
+	upstream, err = client.FetchUpstreamURL(context.Background(), "me", "source")
+	require.NoError(t, err)
+	assert.Empty(t, upstream)
+
+	_, err = client.FetchUpstreamURL(context.Background(), "me", "missing")
+	assert.ErrorIs(t, err, repository.ErrRepositoryNotFound)
+}
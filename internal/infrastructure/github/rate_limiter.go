@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"time"
 )
@@ -22,76 +21,143 @@ type RateLimiter interface {
 	UpdateResetTime(resetTime time.Time)
 }
 
+// GitHub's primary REST API limits per hour
+const (
+	UnauthenticatedRateLimit = 60
+	AuthenticatedRateLimit   = 5000
+)
+
+// secondaryRateBurst caps request bursts below GitHub's secondary limit of
+// 900 REST points per minute
+const secondaryRateBurst = 900
+
 // TokenBucketRateLimiter implements a token bucket rate limiter
 type TokenBucketRateLimiter struct {
-	mu         sync.Mutex
-	limit      int       // Maximum number of requests per hour
-	remaining  int       // Remaining requests
-	resetTime  time.Time // When the rate limit resets
-	lastRefill time.Time // Last time tokens were refilled
-	tokens     float64   // Current number of tokens
-	refillRate float64   // Tokens per second
+	mu          sync.Mutex
+	limit       int       // Maximum number of requests per hour
+	burst       int       // Maximum number of tokens held at once
+	remaining   int       // Remaining requests
+	resetTime   time.Time // When the rate limit resets
+	lastRefill  time.Time // Last time tokens were refilled
+	tokens      float64   // Current number of tokens
+	refillRate  float64   // Tokens per second
+	pausedUntil time.Time // Secondary rate limit backoff
+}
+
+// NewRateLimiter creates a rate limiter matching GitHub's primary limit for
+// authenticated or anonymous requests, with bursts below the secondary limit
+func NewRateLimiter(authenticated bool) *TokenBucketRateLimiter {
+	limit := UnauthenticatedRateLimit
+	if authenticated {
+		limit = AuthenticatedRateLimit
+	}
+	return NewTokenBucketRateLimiterWithBurst(limit, min(limit, secondaryRateBurst))
 }
 
 // NewTokenBucketRateLimiter creates a new token bucket rate limiter
 func NewTokenBucketRateLimiter(limit int) *TokenBucketRateLimiter {
+	return NewTokenBucketRateLimiterWithBurst(limit, limit)
+}
+
+// NewTokenBucketRateLimiterWithBurst creates a token bucket rate limiter
+// allowing limit requests per hour and at most burst requests at once
+func NewTokenBucketRateLimiterWithBurst(limit, burst int) *TokenBucketRateLimiter {
+	if burst <= 0 || burst > limit {
+		burst = limit
+	}
+
 	now := time.Now()
 	return &TokenBucketRateLimiter{
 		limit:      limit,
+		burst:      burst,
 		remaining:  limit,
 		resetTime:  now.Add(time.Hour),
 		lastRefill: now,
-		tokens:     float64(limit),
+		tokens:     float64(burst),
 		refillRate: float64(limit) / 3600.0, // tokens per second
 	}
 }
 
 // Wait blocks until a request can be made
 func (r *TokenBucketRateLimiter) Wait(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		wait := r.reserve()
+		r.mu.Unlock()
+
+		if wait <= 0 {
+			return nil
+		}
+
+		// Wait without holding the lock so budget queries stay responsive
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Allow checks if a request can be made immediately
+func (r *TokenBucketRateLimiter) Allow() bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	return r.reserve() <= 0
+}
+
+// reserve consumes a token and returns zero, or returns how long to wait
+// before trying again
+func (r *TokenBucketRateLimiter) reserve() time.Duration {
+	now := time.Now()
+	if now.Before(r.pausedUntil) {
+		return r.pausedUntil.Sub(now)
+	}
+
 	r.refillTokens()
 
 	// If we have tokens, consume one and return
 	if r.tokens >= 1.0 {
 		r.tokens -= 1.0
-		return nil
+		if r.remaining > 0 {
+			r.remaining--
+		}
+		return 0
 	}
 
 	// Calculate how long to wait for the next token
-	waitTime := time.Duration((1.0 - r.tokens) / r.refillRate * float64(time.Second))
+	return time.Duration((1.0 - r.tokens) / r.refillRate * float64(time.Second))
+}
 
-	// Wait with context cancellation
-	timer := time.NewTimer(waitTime)
-	defer timer.Stop()
+// Pause blocks every request until the given time, e.g. after a secondary
+// rate limit response
+func (r *TokenBucketRateLimiter) Pause(until time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	select {
-	case <-timer.C:
-		r.refillTokens()
-		if r.tokens >= 1.0 {
-			r.tokens -= 1.0
-			return nil
-		}
-		return fmt.Errorf("rate limit exceeded")
-	case <-ctx.Done():
-		return ctx.Err()
+	if until.After(r.pausedUntil) {
+		r.pausedUntil = until
 	}
 }
 
-// Allow checks if a request can be made immediately
-func (r *TokenBucketRateLimiter) Allow() bool {
+// UpdateLimit updates the hourly limit advertised by GitHub, which depends on
+// the authentication method
+func (r *TokenBucketRateLimiter) UpdateLimit(limit int) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.refillTokens()
-
-	if r.tokens >= 1.0 {
-		r.tokens -= 1.0
-		return true
+	if limit <= 0 || limit == r.limit {
+		return
 	}
 
-	return false
+	if r.burst == r.limit || r.burst > limit {
+		r.burst = min(limit, secondaryRateBurst)
+	}
+	r.limit = limit
+	r.refillRate = float64(limit) / 3600.0
+	r.tokens = min(r.tokens, float64(r.burst))
 }
 
 // UpdateRemaining updates the remaining request count from GitHub headers
@@ -117,18 +183,34 @@ func (r *TokenBucketRateLimiter) UpdateResetTime(resetTime time.Time) {
 
 	// If reset time has passed, refill tokens
 	if time.Now().After(resetTime) {
-		r.tokens = float64(r.limit)
+		r.tokens = float64(r.burst)
+		r.remaining = r.limit
 		r.lastRefill = time.Now()
 	}
 }
 
+// Info returns the rate limit budget as last reported by GitHub, minus the
+// requests made since
+func (r *TokenBucketRateLimiter) Info() RateLimitInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return RateLimitInfo{
+		Limit:       r.limit,
+		Remaining:   r.remaining,
+		ResetTime:   r.resetTime,
+		PausedUntil: r.pausedUntil,
+	}
+}
+
 // refillTokens refills the token bucket based on elapsed time
 func (r *TokenBucketRateLimiter) refillTokens() {
 	now := time.Now()
 
 	// If reset time has passed, fully refill
 	if now.After(r.resetTime) {
-		r.tokens = float64(r.limit)
+		r.tokens = float64(r.burst)
+		r.remaining = r.limit
 		r.lastRefill = now
 		r.resetTime = now.Add(time.Hour)
 		return
@@ -138,18 +220,10 @@ func (r *TokenBucketRateLimiter) refillTokens() {
 	elapsed := now.Sub(r.lastRefill).Seconds()
 	tokensToAdd := elapsed * r.refillRate
 
-	r.tokens = min(r.tokens+tokensToAdd, float64(r.limit))
+	r.tokens = min(r.tokens+tokensToAdd, float64(r.burst))
 	r.lastRefill = now
 }
 
-// min returns the minimum of two float64 values
-func min(a, b float64) float64 {
-	if a < b {
-		return a
-	}
-	return b
-}
-
 // NoOpRateLimiter is a rate limiter that doesn't limit anything
 type NoOpRateLimiter struct{}
 
@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	anonymous := NewRateLimiter(false).Info()
+	assert.Equal(t, UnauthenticatedRateLimit, anonymous.Limit)
+	assert.Equal(t, UnauthenticatedRateLimit, anonymous.Remaining)
+
+	limiter := NewRateLimiter(true)
+	assert.Equal(t, AuthenticatedRateLimit, limiter.Info().Limit)
+	assert.Equal(t, float64(secondaryRateBurst), limiter.tokens, "bursts stay below the secondary limit")
+}
+
+func TestTokenBucketRateLimiter_Budget(t *testing.T) {
+	limiter := NewTokenBucketRateLimiter(10)
+	require.NoError(t, limiter.Wait(context.Background()))
+	assert.Equal(t, 9, limiter.Info().Remaining)
+
+	limiter.UpdateLimit(15000)
+	limiter.UpdateRemaining(14000)
+	info := limiter.Info()
+	assert.Equal(t, 15000, info.Limit)
+	assert.Equal(t, 14000, info.Remaining)
+}
+
+func TestTokenBucketRateLimiter_Pause(t *testing.T) {
+	limiter := NewTokenBucketRateLimiter(100)
+	limiter.Pause(time.Now().Add(time.Hour))
+	assert.False(t, limiter.Allow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestSend_SecondaryRateLimit(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"login": "acme", "type": "Organization"}`))
+	}))
+	defer server.Close()
+
+	client := NewGitHubClient(&GitHubClientConfig{
+		BaseURL:     server.URL,
+		RateLimiter: NewRateLimiter(false),
+		Logger:      logging.NewNoOpLogger(),
+	})
+
+	repoType, err := client.GetOwnerType(context.Background(), "acme")
+	require.NoError(t, err)
+	assert.Equal(t, repository.RepositoryTypeOrganization, repoType)
+	assert.Equal(t, int32(2), requests.Load(), "the request is retried after the backoff")
+}
+
+func TestIsRateLimited(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header map[string]string
+		body   string
+		want   bool
+	}{
+		{name: "primary", status: http.StatusForbidden, header: map[string]string{"X-RateLimit-Remaining": "0"}, want: true},
+		{name: "too many requests", status: http.StatusTooManyRequests, want: true},
+		{name: "retry after", status: http.StatusForbidden, header: map[string]string{"Retry-After": "60"}, want: true},
+		{name: "abuse detection", status: http.StatusForbidden, body: "You have triggered an abuse detection mechanism", want: true},
+		{name: "permissions", status: http.StatusForbidden, body: "Resource not accessible by integration", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			for key, value := range tt.header {
+				recorder.Header().Set(key, value)
+			}
+			recorder.WriteHeader(tt.status)
+			_, _ = recorder.WriteString(tt.body)
+
+			assert.Equal(t, tt.want, isRateLimited(recorder.Result()))
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/release"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// releasesPerPage is the maximum page size of the releases endpoint
+const releasesPerPage = 100
+
+// ReleaseAPIResponse represents a release returned by the GitHub API
+type ReleaseAPIResponse struct {
+	ID          int64              `json:"id"`
+	TagName     string             `json:"tag_name"`
+	Name        string             `json:"name"`
+	Draft       bool               `json:"draft"`
+	Prerelease  bool               `json:"prerelease"`
+	PublishedAt time.Time          `json:"published_at"`
+	Assets      []AssetAPIResponse `json:"assets"`
+}
+
+// AssetAPIResponse represents a release asset returned by the GitHub API
+type AssetAPIResponse struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+	URL         string `json:"url"`
+}
+
+// FetchReleases lists every release of a repository
+func (c *GitHubClient) FetchReleases(ctx context.Context, owner, repo string) ([]*release.Release, error) {
+	var releases []*release.Release
+
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=%d&page=%d", c.baseURL, owner, repo, releasesPerPage, page)
+
+		resp, err := c.get(ctx, url, "application/vnd.github.v3+json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list releases of %s/%s: %w", owner, repo, err)
+		}
+
+		var apiReleases []ReleaseAPIResponse
+		err = json.NewDecoder(resp.Body).Decode(&apiReleases)
+		c.closeBody(resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode releases of %s/%s: %w", owner, repo, err)
+		}
+
+		for _, apiRelease := range apiReleases {
+			releases = append(releases, apiRelease.toDomain())
+		}
+
+		if len(apiReleases) < releasesPerPage {
+			return releases, nil
+		}
+	}
+}
+
+// DownloadAsset streams a release asset into w and returns the bytes written.
+// Assets of private repositories are served through the API URL, which
+// redirects to storage; the token is not forwarded across hosts.
+func (c *GitHubClient) DownloadAsset(ctx context.Context, asset *release.Asset, w io.Writer) (int64, error) {
+	resp, err := c.get(ctx, asset.URL, "application/octet-stream")
+	if err != nil {
+		return 0, fmt.Errorf("failed to download asset %s: %w", asset.Name, err)
+	}
+	defer c.closeBody(resp)
+
+	written, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return written, fmt.Errorf("failed to download asset %s: %w", asset.Name, err)
+	}
+	return written, nil
+}
+
+// get performs an authorized GET request and maps error statuses
+func (c *GitHubClient) get(ctx context.Context, url, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", c.userAgent)
+
+	if err := c.authorize(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNotFound:
+		c.closeBody(resp)
+		return nil, repository.ErrRepositoryNotFound
+	case http.StatusUnauthorized:
+		c.closeBody(resp)
+		return nil, fmt.Errorf("%w: check your token", repository.ErrAuthenticationFailed)
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		defer c.closeBody(resp)
+		if isRateLimited(resp) {
+			return nil, fmt.Errorf("%w: resets at %s", repository.ErrRateLimitExceeded, rateLimitReset(resp))
+		}
+		return nil, fmt.Errorf("%w: access forbidden, check your token permissions", repository.ErrRepositoryAccessDenied)
+	default:
+		defer c.closeBody(resp)
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, string(body))
+	}
+}
+
+// closeBody closes a response body, logging failures
+func (c *GitHubClient) closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		c.logger.Warn("failed to close response body", shared.ErrorField(err))
+	}
+}
+
+// toDomain converts the API release into a domain release
+func (r *ReleaseAPIResponse) toDomain() *release.Release {
+	rel := &release.Release{
+		ID:          r.ID,
+		TagName:     r.TagName,
+		Name:        r.Name,
+		Draft:       r.Draft,
+		Prerelease:  r.Prerelease,
+		PublishedAt: r.PublishedAt,
+		Assets:      make([]release.Asset, 0, len(r.Assets)),
+	}
+	for _, asset := range r.Assets {
+		rel.Assets = append(rel.Assets, release.Asset{
+			ID:          asset.ID,
+			Name:        asset.Name,
+			Size:        asset.Size,
+			ContentType: asset.ContentType,
+			URL:         asset.URL,
+		})
+	}
+	return rel
+}
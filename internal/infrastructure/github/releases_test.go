@@ -0,0 +1,56 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestFetchReleasesAndDownloadAsset(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/repos/acme/app/releases":
+			if r.URL.Query().Get("page") != "1" {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `[{"id":1,"tag_name":"v1.0.0","published_at":"2024-01-02T00:00:00Z",
+				"assets":[{"id":7,"name":"app.tar.gz","size":5,"url":"%s/repos/acme/app/releases/assets/7"}]}]`, server.URL)
+		case "/repos/acme/app/releases/assets/7":
+			assert.Equal(t, "application/octet-stream", r.Header.Get("Accept"))
+			_, _ = w.Write([]byte("hello"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGitHubClient(&GitHubClientConfig{BaseURL: server.URL, Token: "secret", Logger: logging.NewNoOpLogger()})
+
+	releases, err := client.FetchReleases(context.Background(), "acme", "app")
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.Equal(t, "v1.0.0", releases[0].TagName)
+	require.Len(t, releases[0].Assets, 1)
+
+	var buf bytes.Buffer
+	written, err := client.DownloadAsset(context.Background(), &releases[0].Assets[0], &buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), written)
+	assert.Equal(t, "hello", buf.String())
+
+	_, err = client.FetchReleases(context.Background(), "acme", "missing")
+	assert.ErrorIs(t, err, repository.ErrRepositoryNotFound)
+}
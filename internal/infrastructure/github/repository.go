@@ -0,0 +1,28 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// FetchRepository returns a single repository. The API answers requests for
+// renamed or transferred repositories with a 301 to the repository ID, which
+// the HTTP client follows, so the result carries the current owner and name.
+func (c *GitHubClient) FetchRepository(ctx context.Context, owner, name string) (*repository.Repository, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, owner, name)
+
+	resp, err := c.get(ctx, url, "application/vnd.github.v3+json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s/%s: %w", owner, name, err)
+	}
+	defer c.closeBody(resp)
+
+	var apiRepo GitHubAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiRepo); err != nil {
+		return nil, fmt.Errorf("failed to decode %s/%s: %w", owner, name, err)
+	}
+	return c.convertToDomainRepository(&apiRepo)
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// OAuth scopes checked before a run
+const (
+	ScopeRepo    = "repo"     // Private repositories
+	ScopeReadOrg = "read:org" // Organization and team membership
+)
+
+// impliedBy lists the scopes granting a scope as well
+var impliedBy = map[string][]string{
+	"public_repo": {ScopeRepo},
+	"repo:status": {ScopeRepo},
+	ScopeReadOrg:  {"write:org", "admin:org"},
+	"write:org":   {"admin:org"},
+}
+
+// TokenScopes returns the OAuth scopes granted to the configured token from
+// the X-OAuth-Scopes header. known is false when the token does not report
+// scopes: fine-grained personal access tokens and GitHub App installation
+// tokens are granted repository permissions instead.
+func (c *GitHubClient) TokenScopes(ctx context.Context) (scopes []string, known bool, err error) {
+	if c.tokenSource == nil {
+		return nil, false, fmt.Errorf("no token provided")
+	}
+	if _, ok := c.tokenSource.(*AppTokenSource); ok {
+		return nil, false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/user", nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := c.authorize(req); err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, false, err
+	}
+	c.closeBody(resp)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, false, fmt.Errorf("invalid token")
+	default:
+		return nil, false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	values := resp.Header.Values("X-OAuth-Scopes")
+	if len(values) == 0 {
+		return nil, false, nil
+	}
+	for _, value := range values {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes, true, nil
+}
+
+// MissingScopes returns the required scopes that the granted scopes, directly
+// or through a broader scope such as admin:org for read:org, do not cover
+func MissingScopes(granted, required []string) []string {
+	var missing []string
+	for _, scope := range required {
+		if slices.Contains(granted, scope) || slices.ContainsFunc(impliedBy[scope], func(broader string) bool {
+			return slices.Contains(granted, broader)
+		}) {
+			continue
+		}
+		if !slices.Contains(missing, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
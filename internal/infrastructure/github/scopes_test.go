@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestTokenScopes(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    []string
+		status    int
+		want      []string
+		wantKnown bool
+		wantErr   string
+	}{
+		{name: "classic token", header: []string{"repo, read:org"}, status: http.StatusOK, want: []string{"repo", "read:org"}, wantKnown: true},
+		{name: "classic token without scopes", header: []string{""}, status: http.StatusOK, wantKnown: true},
+		{name: "fine-grained token", status: http.StatusOK},
+		{name: "invalid token", status: http.StatusUnauthorized, wantErr: "invalid token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/user", r.URL.Path)
+				assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+				for _, value := range tt.header {
+					w.Header().Add("X-OAuth-Scopes", value)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewGitHubClient(&GitHubClientConfig{Token: "secret", BaseURL: server.URL, Logger: logging.NewNoOpLogger()})
+			scopes, known, err := client.TokenScopes(context.Background())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKnown, known)
+			assert.Equal(t, tt.want, scopes)
+		})
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		granted  []string
+		required []string
+		want     []string
+	}{
+		{name: "granted", granted: []string{"repo", "read:org"}, required: []string{"repo", "read:org"}},
+		{name: "implied by a broader scope", granted: []string{"repo", "admin:org"}, required: []string{"public_repo", "read:org"}},
+		{name: "missing", granted: []string{"public_repo"}, required: []string{"repo", "read:org", "repo"}, want: []string{"repo", "read:org"}},
+		{name: "nothing required", granted: []string{"gist"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MissingScopes(tt.granted, tt.required))
+		})
+	}
+}
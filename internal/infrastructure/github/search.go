@@ -0,0 +1,110 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// maxSearchResults is the number of results the search API returns for a
+// query, regardless of the number of matches
+const maxSearchResults = 1000
+
+// SearchAPIResponse represents a page of repository search results
+type SearchAPIResponse struct {
+	TotalCount        int                 `json:"total_count"`
+	IncompleteResults bool                `json:"incomplete_results"` // The search timed out before finding every match
+	Items             []GitHubAPIResponse `json:"items"`
+}
+
+// SearchRepositoryPages selects repositories with GitHub search qualifiers,
+// e.g. "org:acme language:go archived:false", handing the filtered
+// repositories of each page to onPage. The search API returns at most the
+// first 1000 matches.
+func (c *GitHubClient) SearchRepositoryPages(
+	ctx context.Context,
+	query string,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	onPage repository.PageHandler,
+) error {
+	if pagination == nil {
+		pagination = repository.NewPaginationOptions()
+	}
+
+	page := max(pagination.Page, 1)
+	for {
+		result, err := c.searchRepositoryPage(ctx, query, page, pagination.PerPage)
+		if err != nil {
+			return fmt.Errorf("failed to search page %d: %w", page, err)
+		}
+
+		if page == max(pagination.Page, 1) {
+			if result.TotalCount > maxSearchResults {
+				c.logger.Warn("Search matches more repositories than the API returns, narrow the query to select the rest",
+					shared.StringField("query", query),
+					shared.IntField("matches", result.TotalCount),
+					shared.IntField("returned", maxSearchResults))
+			}
+			if result.IncompleteResults {
+				c.logger.Warn("Search timed out before finding every match, results may be incomplete",
+					shared.StringField("query", query))
+			}
+		}
+
+		included := make([]*repository.Repository, 0, len(result.Items))
+		for _, item := range result.Items {
+			repo, err := c.convertToDomainRepository(&item)
+			if err != nil {
+				c.logger.Warn("Failed to convert repository",
+					shared.StringField("repo", item.FullName),
+					shared.ErrorField(err))
+				continue
+			}
+			if filter == nil || filter.ShouldInclude(repo) {
+				included = append(included, repo)
+			}
+		}
+
+		if err := onPage(included); err != nil {
+			if errors.Is(err, repository.ErrStopPaging) {
+				return nil
+			}
+			return err
+		}
+
+		seen := page * pagination.PerPage
+		hasMore := len(result.Items) == pagination.PerPage && seen < result.TotalCount && seen < maxSearchResults
+		if !hasMore || !pagination.HasMorePages(page) {
+			return nil
+		}
+		page++
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// searchRepositoryPage fetches a single page of search results
+func (c *GitHubClient) searchRepositoryPage(ctx context.Context, query string, page, perPage int) (*SearchAPIResponse, error) {
+	searchURL := fmt.Sprintf("%s/search/repositories?q=%s&per_page=%d&page=%d",
+		c.baseURL, url.QueryEscape(query), perPage, page)
+
+	resp, err := c.get(ctx, searchURL, "application/vnd.github.v3+json")
+	if err != nil {
+		return nil, err
+	}
+	defer c.closeBody(resp)
+
+	var result SearchAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
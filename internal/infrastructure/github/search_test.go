@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestSearchRepositoryPages(t *testing.T) {
+	tests := []struct {
+		name       string
+		totalCount int
+		wantPages  int
+	}{
+		{name: "stops at the last match", totalCount: 5, wantPages: 3},
+		{name: "stops at the search API cap", totalCount: 5000, wantPages: 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/search/repositories", r.URL.Path)
+				queries = append(queries, r.URL.Query().Get("q"))
+
+				page, err := strconv.Atoi(r.URL.Query().Get("page"))
+				require.NoError(t, err)
+				perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+				require.NoError(t, err)
+
+				result := SearchAPIResponse{TotalCount: tt.totalCount}
+				for i := (page - 1) * perPage; i < min(page*perPage, tt.totalCount); i++ {
+					name := fmt.Sprintf("repo-%d", i)
+					result.Items = append(result.Items, GitHubAPIResponse{
+						ID:       int64(i + 1),
+						Name:     name,
+						CloneURL: "https://github.com/acme/" + name + ".git",
+						Fork:     i == 0,
+						Owner:    OwnerInfo{Login: "acme"},
+					})
+				}
+				w.Header().Set("X-RateLimit-Resource", "search")
+				require.NoError(t, json.NewEncoder(w).Encode(result))
+			}))
+			defer server.Close()
+
+			client := NewGitHubClient(&GitHubClientConfig{BaseURL: server.URL, Logger: logging.NewNoOpLogger()})
+
+			filter := repository.NewRepositoryFilter()
+			filter.IncludeForks = true
+
+			pages := 0
+			var repos []*repository.Repository
+			err := client.SearchRepositoryPages(context.Background(), "org:acme language:go", filter,
+				&repository.PaginationOptions{Page: 1, PerPage: 2}, func(page []*repository.Repository) error {
+					pages++
+					repos = append(repos, page...)
+					return nil
+				})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantPages, pages)
+			assert.Len(t, repos, min(tt.totalCount, maxSearchResults))
+			assert.Equal(t, "org:acme language:go", queries[0], "the query is escaped")
+			assert.True(t, repos[0].IsFork, "forks matched by the query are kept")
+		})
+	}
+}
+
+func TestUpdateRateLimitFromResponse_IgnoresOtherResources(t *testing.T) {
+	limiter := NewRateLimiter(true)
+	client := NewGitHubClient(&GitHubClientConfig{RateLimiter: limiter, Logger: logging.NewNoOpLogger()})
+	before := limiter.Info()
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("X-RateLimit-Resource", "search")
+	resp.Header.Set("X-RateLimit-Limit", "30")
+	resp.Header.Set("X-RateLimit-Remaining", "29")
+	client.updateRateLimitFromResponse(resp)
+
+	assert.Equal(t, before.Limit, limiter.Info().Limit)
+	assert.Equal(t, before.Remaining, limiter.Info().Remaining)
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/network"
+)
+
+const (
+	// maxSecondaryRateLimitRetries bounds the retries of a request answered
+	// with a secondary rate limit
+	maxSecondaryRateLimitRetries = 3
+
+	// defaultSecondaryRateLimitWait is the backoff when GitHub does not send
+	// Retry-After, as its documentation recommends
+	defaultSecondaryRateLimitWait = time.Minute
+
+	// maxSecondaryRateLimitWait is the longest backoff waited out; longer
+	// ones fail the request instead
+	maxSecondaryRateLimitWait = 5 * time.Minute
+)
+
+// send waits for the rate limiter, executes an authorized request and updates
+// the rate limiter from the response. Secondary rate limit responses (abuse
+// detection) pause every request for the advertised time before retrying.
+func (c *GitHubClient) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("rate limiter error: %w", err)
+			}
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute request: %w", err)
+		}
+
+		if c.rateLimiter != nil {
+			c.updateRateLimitFromResponse(resp)
+		}
+
+		wait, secondary := secondaryRateLimitWait(resp)
+		if !secondary || wait > maxSecondaryRateLimitWait || attempt >= maxSecondaryRateLimitRetries {
+			return resp, nil
+		}
+		c.closeBody(resp)
+
+		c.logger.Warn("GitHub secondary rate limit hit, backing off",
+			shared.StringField("url", req.URL.String()),
+			shared.DurationField("retry_after", wait),
+			shared.IntField("attempt", attempt+1))
+
+		// Hold back concurrent requests as well
+		if rateLimiter, ok := c.rateLimiter.(*TokenBucketRateLimiter); ok {
+			rateLimiter.Pause(time.Now().Add(wait))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// isRateLimited reports whether a 403 or 429 response is caused by a primary
+// or secondary rate limit rather than missing permissions
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return true
+	}
+	_, secondary := secondaryRateLimitWait(resp)
+	return secondary
+}
+
+// secondaryRateLimitWait reports whether a response is a secondary rate limit
+// and how long GitHub asks to wait. The body of 403 responses is inspected
+// and restored for the caller.
+func secondaryRateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	// An exhausted primary limit lasts until its reset, not a short backoff
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return 0, false
+	}
+
+	if wait, ok := network.ParseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		return wait, true
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return defaultSecondaryRateLimitWait, true
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	message := strings.ToLower(string(body))
+	if strings.Contains(message, "secondary rate limit") || strings.Contains(message, "abuse detection") {
+		return defaultSecondaryRateLimitWait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,87 @@
+// Package keyring stores provider credentials in the OS keyring: the macOS
+// Keychain, the Windows Credential Manager or a Secret Service such as GNOME
+// Keyring or KWallet on Linux.
+package keyring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+// Service names the repocloner entries in the OS keyring
+const Service = "repocloner"
+
+// ErrNotFound reports that no credential is stored for a provider
+var ErrNotFound = errors.New("no credentials stored")
+
+// Credential is the secret and account of a provider
+type Credential struct {
+	Token    string `json:"token"`
+	Username string `json:"username,omitempty"` // Bitbucket app password user or Bitbucket Server account
+	Email    string `json:"email,omitempty"`    // Atlassian account email of Bitbucket API tokens
+}
+
+// Store reads and writes provider credentials
+type Store interface {
+	Get(provider string) (*Credential, error)
+	Set(provider string, credential *Credential) error
+	Delete(provider string) error
+}
+
+// OSStore keeps one keyring entry per provider, holding the credential as JSON
+type OSStore struct {
+	service string
+}
+
+// NewOSStore creates a store backed by the OS keyring
+func NewOSStore() *OSStore {
+	return &OSStore{service: Service}
+}
+
+// Get returns the stored credential of a provider, or ErrNotFound
+func (s *OSStore) Get(provider string) (*Credential, error) {
+	data, err := gokeyring.Get(s.service, provider)
+	if errors.Is(err, gokeyring.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s credentials from the keyring: %w", provider, err)
+	}
+
+	var credential Credential
+	if err := json.Unmarshal([]byte(data), &credential); err != nil {
+		return nil, fmt.Errorf("invalid %s credentials in the keyring: %w", provider, err)
+	}
+	return &credential, nil
+}
+
+// Set stores the credential of a provider, replacing any previous one
+func (s *OSStore) Set(provider string, credential *Credential) error {
+	if credential == nil || credential.Token == "" {
+		return fmt.Errorf("no %s token to store", provider)
+	}
+
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return err
+	}
+	if err := gokeyring.Set(s.service, provider, string(data)); err != nil {
+		return fmt.Errorf("failed to store %s credentials in the keyring: %w", provider, err)
+	}
+	return nil
+}
+
+// Delete removes the credential of a provider, or returns ErrNotFound
+func (s *OSStore) Delete(provider string) error {
+	err := gokeyring.Delete(s.service, provider)
+	if errors.Is(err, gokeyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete %s credentials from the keyring: %w", provider, err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package keyring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+func TestOSStore(t *testing.T) {
+	gokeyring.MockInit()
+	store := NewOSStore()
+
+	_, err := store.Get("github")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, store.Delete("github"), ErrNotFound)
+
+	assert.Error(t, store.Set("github", &Credential{}))
+
+	credential := &Credential{Token: "api-token", Email: "dev@example.com"}
+	require.NoError(t, store.Set("bitbucket", credential))
+	got, err := store.Get("bitbucket")
+	require.NoError(t, err)
+	assert.Equal(t, credential, got)
+
+	require.NoError(t, store.Delete("bitbucket"))
+	_, err = store.Get("bitbucket")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
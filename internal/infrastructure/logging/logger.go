@@ -2,12 +2,16 @@ package logging
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/italoag/repocloner/internal/domain/shared"
 )
@@ -102,7 +106,8 @@ func (lb *LogBuffer) Clear() {
 
 // ZapLogger implements the shared.Logger interface using zap
 type ZapLogger struct {
-	logger *zap.Logger
+	logger  *zap.Logger
+	closers []io.Closer // Rotating log files released on Close
 }
 
 // LoggerConfig holds configuration for the logger
@@ -111,6 +116,48 @@ type LoggerConfig struct {
 	Encoding    string // json, console
 	OutputPaths []string
 	Development bool
+	Rotation    *RotationConfig // Optional rotation of file outputs
+}
+
+// RotationConfig controls size-based rotation and retention of log files
+type RotationConfig struct {
+	MaxSizeMB  int  // Size in megabytes that triggers a rotation
+	MaxBackups int  // Rotated files to keep, 0 keeps all
+	MaxAgeDays int  // Days to keep rotated files, 0 disables age-based removal
+	Compress   bool // Gzip rotated files
+}
+
+// NewDefaultRotationConfig creates rotation settings suitable for long-running use
+func NewDefaultRotationConfig() *RotationConfig {
+	return &RotationConfig{
+		MaxSizeMB:  100,
+		MaxBackups: 5,
+	}
+}
+
+// Validate ensures rotation settings are valid
+func (rc *RotationConfig) Validate() error {
+	if rc.MaxSizeMB <= 0 {
+		return fmt.Errorf("log max size must be positive")
+	}
+	if rc.MaxBackups < 0 {
+		return fmt.Errorf("log max backups cannot be negative")
+	}
+	if rc.MaxAgeDays < 0 {
+		return fmt.Errorf("log max age cannot be negative")
+	}
+	return nil
+}
+
+// newRotatingWriter creates a log file writer rotated according to config
+func newRotatingWriter(path string, config *RotationConfig) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    config.MaxSizeMB,
+		MaxBackups: config.MaxBackups,
+		MaxAge:     config.MaxAgeDays,
+		Compress:   config.Compress,
+	}
 }
 
 // NewZapLogger creates a new zap-based logger
@@ -152,8 +199,15 @@ func NewZapLogger(config *LoggerConfig) (*ZapLogger, error) {
 		return nil, fmt.Errorf("invalid encoding: %s", config.Encoding)
 	}
 
+	if config.Rotation != nil {
+		if err := config.Rotation.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid log rotation: %w", err)
+		}
+	}
+
 	// Create writers
 	var writers []zapcore.WriteSyncer
+	var closers []io.Closer
 	for _, path := range config.OutputPaths {
 		switch path {
 		case "stdout":
@@ -161,6 +215,12 @@ func NewZapLogger(config *LoggerConfig) (*ZapLogger, error) {
 		case "stderr":
 			writers = append(writers, zapcore.AddSync(os.Stderr))
 		default:
+			if config.Rotation != nil {
+				rotating := newRotatingWriter(path, config.Rotation)
+				writers = append(writers, zapcore.AddSync(rotating))
+				closers = append(closers, rotating)
+				continue
+			}
 			file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 			if err != nil {
 				return nil, fmt.Errorf("failed to open log file %s: %w", path, err)
@@ -183,7 +243,7 @@ func NewZapLogger(config *LoggerConfig) (*ZapLogger, error) {
 		logger = zap.New(core, zap.AddCaller())
 	}
 
-	return &ZapLogger{logger: logger}, nil
+	return &ZapLogger{logger: logger, closers: closers}, nil
 }
 
 // Debug logs a debug message
@@ -263,9 +323,15 @@ func (l *ZapLogger) Sync() error {
 	return l.logger.Sync()
 }
 
-// Close closes the logger
+// Close flushes the logger and releases rotating log files
 func (l *ZapLogger) Close() error {
-	return l.logger.Sync()
+	err := l.logger.Sync()
+	for _, closer := range l.closers {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
 }
 
 // NoOpLogger implements a no-operation logger for testing
@@ -323,19 +389,31 @@ func NewConsoleLogger(level string, development bool) (*ZapLogger, error) {
 	return NewZapLogger(config)
 }
 
+// Log file formats of TUILoggerConfig
+const (
+	FormatConsole = "console" // Human-readable lines
+	FormatJSON    = "json"    // One JSON object per line, for Loki or ELK ingestion
+)
+
+// ValidFormats lists the accepted log file formats
+var ValidFormats = []string{FormatConsole, FormatJSON}
+
 // TUILogger provides logging for TUI applications with file output and log buffering
 type TUILogger struct {
 	fileLogger *ZapLogger
 	buffer     *LogBuffer
 	logFile    string
+	fields     []shared.Field // Context fields of With, copied into buffered entries
 }
 
 // TUILoggerConfig holds configuration for TUI logger
 type TUILoggerConfig struct {
-	LogFile     string // File path for persistent logging
-	Level       string // Log level (debug, info, warn, error)
-	BufferSize  int    // Size of the log buffer for TUI display
-	Development bool   // Development mode
+	LogFile     string          // File path for persistent logging
+	Level       string          // Log level (debug, info, warn, error)
+	BufferSize  int             // Size of the log buffer for TUI display
+	Development bool            // Development mode
+	Format      string          // Log file format, FormatConsole (default) or FormatJSON
+	Rotation    *RotationConfig // Optional log file rotation, nil appends to a single file
 }
 
 // NewTUILogger creates a new TUI-compatible logger
@@ -354,12 +432,22 @@ func NewTUILogger(config *TUILoggerConfig) (*TUILogger, error) {
 		return nil, fmt.Errorf("failed to create log directory: %w", err)
 	}
 
-	// Create file logger
+	// Create file logger; JSON lines use the production encoding, with ISO
+	// 8601 timestamps and plain levels, so log shippers can parse them
 	fileLoggerConfig := &LoggerConfig{
 		Level:       config.Level,
-		Encoding:    "json",
+		Encoding:    FormatConsole,
 		OutputPaths: []string{config.LogFile},
 		Development: config.Development,
+		Rotation:    config.Rotation,
+	}
+	switch config.Format {
+	case "", FormatConsole:
+	case FormatJSON:
+		fileLoggerConfig.Encoding = FormatJSON
+		fileLoggerConfig.Development = false
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected one of: %s", config.Format, strings.Join(ValidFormats, ", "))
 	}
 
 	fileLogger, err := NewZapLogger(fileLoggerConfig)
@@ -429,13 +517,14 @@ func (tl *TUILogger) With(fields ...shared.Field) shared.Logger {
 		fileLogger: tl.fileLogger.With(fields...).(*ZapLogger),
 		buffer:     tl.buffer, // Share the same buffer
 		logFile:    tl.logFile,
+		fields:     slices.Concat(tl.fields, fields),
 	}
 }
 
 // addToBuffer adds a log entry to the buffer for TUI display
 func (tl *TUILogger) addToBuffer(level, msg string, fields []shared.Field) {
 	fieldsMap := make(map[string]interface{})
-	for _, field := range fields {
+	for _, field := range slices.Concat(tl.fields, fields) {
 		fieldsMap[field.Key()] = field.Value()
 	}
 
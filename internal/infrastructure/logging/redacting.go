@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/redact"
+)
+
+// RedactingLogger masks credentials in the messages and the string and error
+// fields of another logger, see package redact
+type RedactingLogger struct {
+	logger shared.Logger
+}
+
+// NewRedactingLogger wraps a logger so credentials never reach its outputs
+func NewRedactingLogger(logger shared.Logger) *RedactingLogger {
+	if redacting, ok := logger.(*RedactingLogger); ok {
+		return redacting
+	}
+	return &RedactingLogger{logger: logger}
+}
+
+// Unwrap returns the wrapped logger
+func (l *RedactingLogger) Unwrap() shared.Logger {
+	return l.logger
+}
+
+// Debug logs a redacted debug message
+func (l *RedactingLogger) Debug(msg string, fields ...shared.Field) {
+	l.logger.Debug(redact.String(msg), redactFields(fields)...)
+}
+
+// Info logs a redacted info message
+func (l *RedactingLogger) Info(msg string, fields ...shared.Field) {
+	l.logger.Info(redact.String(msg), redactFields(fields)...)
+}
+
+// Warn logs a redacted warning message
+func (l *RedactingLogger) Warn(msg string, fields ...shared.Field) {
+	l.logger.Warn(redact.String(msg), redactFields(fields)...)
+}
+
+// Error logs a redacted error message
+func (l *RedactingLogger) Error(msg string, fields ...shared.Field) {
+	l.logger.Error(redact.String(msg), redactFields(fields)...)
+}
+
+// Fatal logs a redacted fatal message
+func (l *RedactingLogger) Fatal(msg string, fields ...shared.Field) {
+	l.logger.Fatal(redact.String(msg), redactFields(fields)...)
+}
+
+// With creates a redacting logger with additional fields
+func (l *RedactingLogger) With(fields ...shared.Field) shared.Logger {
+	return &RedactingLogger{logger: l.logger.With(redactFields(fields)...)}
+}
+
+// redactFields masks string and error values; other values cannot hold
+// credentials and pass through unchanged
+func redactFields(fields []shared.Field) []shared.Field {
+	redacted := make([]shared.Field, len(fields))
+	for i, field := range fields {
+		switch value := field.Value().(type) {
+		case string:
+			redacted[i] = shared.StringField(field.Key(), redact.String(value))
+		case error:
+			if field.Key() == "error" {
+				redacted[i] = shared.ErrorField(redact.Error(value))
+			} else {
+				redacted[i] = shared.StringField(field.Key(), redact.String(value.Error()))
+			}
+		default:
+			redacted[i] = field
+		}
+	}
+	return redacted
+}
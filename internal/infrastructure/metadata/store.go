@@ -0,0 +1,241 @@
+// Package metadata persists snapshots of fetched repository metadata in a
+// BoltDB file, so later runs can tell which repositories changed since the
+// previous backup.
+package metadata
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+var (
+	runsBucket         = []byte("runs")         // Run ID -> Run
+	repositoriesBucket = []byte("repositories") // Clone URL key -> latest Record
+	snapshotsBucket    = []byte("snapshots")    // Run ID -> (clone URL key -> Repository)
+)
+
+// Run describes one recorded listing
+type Run struct {
+	ID           uint64    `json:"id"`
+	Target       string    `json:"target"`
+	RecordedAt   time.Time `json:"recorded_at"`
+	Repositories int       `json:"repositories"`
+}
+
+// Record is the most recent metadata known for a repository
+type Record struct {
+	Repository *repository.Repository `json:"repository"`
+	RunID      uint64                 `json:"run_id"`
+	FirstSeen  time.Time              `json:"first_seen"`
+	RecordedAt time.Time              `json:"recorded_at"`
+}
+
+// Store is a BoltDB backed repository metadata store
+type Store struct {
+	db  *bolt.DB
+	now func() time.Time
+}
+
+// Open opens or creates the metadata database at path. The file is locked
+// while open, so concurrent runs sharing a database fail fast.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metadata database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{runsBucket, repositoriesBucket, snapshotsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize metadata database: %w", err)
+	}
+
+	return &Store{db: db, now: time.Now}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// RecordRun stores a snapshot of the repositories fetched for target and
+// updates the latest record of each of them
+func (s *Store) RecordRun(target string, repos []*repository.Repository) (*Run, error) {
+	now := s.now()
+	run := &Run{Target: target, RecordedAt: now, Repositories: len(repos)}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		runs := tx.Bucket(runsBucket)
+		id, err := runs.NextSequence()
+		if err != nil {
+			return err
+		}
+		run.ID = id
+
+		if err := putJSON(runs, itob(id), run); err != nil {
+			return err
+		}
+
+		snapshot, err := tx.Bucket(snapshotsBucket).CreateBucket(itob(id))
+		if err != nil {
+			return err
+		}
+
+		latest := tx.Bucket(repositoriesBucket)
+		for _, repo := range repos {
+			key := []byte(repository.CloneURLKey(repo.CloneURL))
+			if err := putJSON(snapshot, key, repo); err != nil {
+				return err
+			}
+
+			record := &Record{Repository: repo, RunID: id, FirstSeen: now, RecordedAt: now}
+			var previous Record
+			if found, err := getJSON(latest, key, &previous); err != nil {
+				return err
+			} else if found {
+				record.FirstSeen = previous.FirstSeen
+			}
+			if err := putJSON(latest, key, record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record run: %w", err)
+	}
+
+	return run, nil
+}
+
+// Runs returns every recorded run, oldest first
+func (s *Store) Runs() ([]*Run, error) {
+	var runs []*Run
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(runsBucket).ForEach(func(_, value []byte) error {
+			var run Run
+			if err := json.Unmarshal(value, &run); err != nil {
+				return err
+			}
+			runs = append(runs, &run)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read runs: %w", err)
+	}
+	return runs, nil
+}
+
+// Snapshot returns the repositories recorded by a run
+func (s *Store) Snapshot(runID uint64) ([]*repository.Repository, error) {
+	var repos []*repository.Repository
+	err := s.db.View(func(tx *bolt.Tx) error {
+		snapshot := tx.Bucket(snapshotsBucket).Bucket(itob(runID))
+		if snapshot == nil {
+			return fmt.Errorf("run %d not found", runID)
+		}
+		return snapshot.ForEach(func(_, value []byte) error {
+			var repo repository.Repository
+			if err := json.Unmarshal(value, &repo); err != nil {
+				return err
+			}
+			repos = append(repos, &repo)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return repos, nil
+}
+
+// Lookup returns the latest record of a repository, or nil when it was never recorded
+func (s *Store) Lookup(repo *repository.Repository) (*Record, error) {
+	var record *Record
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var found Record
+		ok, err := getJSON(tx.Bucket(repositoriesBucket), []byte(repository.CloneURLKey(repo.CloneURL)), &found)
+		if ok {
+			record = &found
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repository record: %w", err)
+	}
+	return record, nil
+}
+
+// Changed returns the repositories that are new or changed since they were
+// last recorded, keeping their order
+func (s *Store) Changed(repos []*repository.Repository) ([]*repository.Repository, error) {
+	var changed []*repository.Repository
+	err := s.db.View(func(tx *bolt.Tx) error {
+		latest := tx.Bucket(repositoriesBucket)
+		for _, repo := range repos {
+			var record Record
+			found, err := getJSON(latest, []byte(repository.CloneURLKey(repo.CloneURL)), &record)
+			if err != nil {
+				return err
+			}
+			if !found || Changed(record.Repository, repo) {
+				changed = append(changed, repo)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare repositories: %w", err)
+	}
+	return changed, nil
+}
+
+// Changed reports whether current differs from previous in a way that needs
+// a fresh sync: new pushes, a metadata update, a renamed default branch or
+// an archival change
+func Changed(previous, current *repository.Repository) bool {
+	if previous == nil {
+		return true
+	}
+	return current.PushedAt.After(previous.PushedAt) ||
+		current.UpdatedAt.After(previous.UpdatedAt) ||
+		current.DefaultBranch != previous.DefaultBranch ||
+		current.Archived != previous.Archived
+}
+
+// itob encodes a sequence number as a sortable key
+func itob(id uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, id)
+	return key
+}
+
+func putJSON(bucket *bolt.Bucket, key []byte, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(key, data)
+}
+
+func getJSON(bucket *bolt.Bucket, key []byte, value any) (bool, error) {
+	data := bucket.Get(key)
+	if data == nil {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, value)
+}
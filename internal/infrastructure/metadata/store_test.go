@@ -0,0 +1,96 @@
+package metadata
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func newRepo(t *testing.T, name string, pushedAt time.Time) *repository.Repository {
+	t.Helper()
+	repo, err := repository.NewRepository(1, name, "https://github.com/acme/"+name+".git", "acme", false, 0, "main")
+	require.NoError(t, err)
+	repo.UpdatedAt = pushedAt
+	repo.PushedAt = pushedAt
+	return repo
+}
+
+func TestStore_RecordRunAndChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ghclone.db")
+	store, err := Open(path)
+	require.NoError(t, err)
+
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	api := newRepo(t, "api", day)
+	web := newRepo(t, "web", day)
+
+	first, err := store.RecordRun("orgs/acme", []*repository.Repository{api, web})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), first.ID)
+
+	// Reopening keeps everything recorded so far
+	require.NoError(t, store.Close())
+	store, err = Open(path)
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	web = newRepo(t, "web", day.Add(time.Hour))
+	docs := newRepo(t, "docs", day)
+
+	changed, err := store.Changed([]*repository.Repository{api, web, docs})
+	require.NoError(t, err)
+	require.Len(t, changed, 2)
+	assert.Equal(t, "web", changed[0].Name)
+	assert.Equal(t, "docs", changed[1].Name)
+
+	second, err := store.RecordRun("orgs/acme", []*repository.Repository{api, web, docs})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), second.ID)
+
+	runs, err := store.Runs()
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, 3, runs[1].Repositories)
+
+	snapshot, err := store.Snapshot(first.ID)
+	require.NoError(t, err)
+	assert.Len(t, snapshot, 2)
+
+	record, err := store.Lookup(web)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, second.ID, record.RunID)
+	assert.Equal(t, first.RecordedAt.Unix(), record.FirstSeen.Unix())
+
+	missing, err := store.Lookup(newRepo(t, "missing", day))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestChanged(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	previous := &repository.Repository{PushedAt: day, UpdatedAt: day, DefaultBranch: "main"}
+
+	tests := []struct {
+		name    string
+		current repository.Repository
+		want    bool
+	}{
+		{name: "unchanged", current: *previous, want: false},
+		{name: "pushed", current: repository.Repository{PushedAt: day.Add(time.Minute), UpdatedAt: day, DefaultBranch: "main"}, want: true},
+		{name: "branch renamed", current: repository.Repository{PushedAt: day, UpdatedAt: day, DefaultBranch: "trunk"}, want: true},
+		{name: "archived", current: repository.Repository{PushedAt: day, UpdatedAt: day, DefaultBranch: "main", Archived: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Changed(previous, &tt.current))
+		})
+	}
+	assert.True(t, Changed(nil, previous))
+}
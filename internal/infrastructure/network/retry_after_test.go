@@ -0,0 +1,25 @@
+package network
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := ParseRetryAfter("30")
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, wait)
+
+	_, ok = ParseRetryAfter("")
+	assert.False(t, ok)
+
+	_, ok = ParseRetryAfter("soon")
+	assert.False(t, ok)
+
+	wait, ok = ParseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Zero(t, wait)
+}
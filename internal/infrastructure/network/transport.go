@@ -0,0 +1,118 @@
+// Package network builds the HTTP transport shared by the provider API
+// clients and the git settings that route clones through the same proxy and
+// trusted certificate authorities.
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// Config holds proxy and TLS settings for corporate networks
+type Config struct {
+	// ProxyURL routes every request through this proxy; empty follows the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+	ProxyURL string
+
+	// CACertFile is a PEM bundle of certificate authorities trusted in
+	// addition to the system roots, e.g. of a TLS-inspecting proxy
+	CACertFile string
+}
+
+// IsZero reports whether the config leaves the defaults unchanged
+func (c *Config) IsZero() bool {
+	return c == nil || (c.ProxyURL == "" && c.CACertFile == "")
+}
+
+// NewTransport creates an HTTP transport with the proxy and trusted
+// certificate authorities of the config. A nil config behaves like
+// http.DefaultTransport.
+func NewTransport(config *Config) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.IsZero() {
+		return transport, nil
+	}
+
+	if config.ProxyURL != "" {
+		proxyURL, err := parseProxyURL(config.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.CACertFile != "" {
+		pool, err := loadCertPool(config.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return transport, nil
+}
+
+// GitArgs returns the `-c` options passing the settings to git: http.proxy
+// and http.sslCAInfo. Without a proxy URL git keeps reading the proxy
+// environment variables itself.
+func (c *Config) GitArgs() []string {
+	if c.IsZero() {
+		return nil
+	}
+
+	var args []string
+	if c.ProxyURL != "" {
+		args = append(args, "-c", "http.proxy="+c.ProxyURL)
+	}
+	if c.CACertFile != "" {
+		caFile, err := filepath.Abs(c.CACertFile)
+		if err != nil {
+			caFile = c.CACertFile
+		}
+		args = append(args, "-c", "http.sslCAInfo="+caFile)
+	}
+	return args
+}
+
+// parseProxyURL validates a proxy URL; a bare host:port is an HTTP proxy
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		// host:port parses as a scheme and an opaque path
+		if proxyURL, err = url.Parse("http://" + raw); err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", raw)
+		}
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return proxyURL, nil
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: unsupported scheme %s", raw, proxyURL.Scheme)
+	}
+}
+
+// loadCertPool returns the system roots with the certificates of a PEM file added
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,86 @@
+package network
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransport_Proxy(t *testing.T) {
+	tests := []struct {
+		proxy   string
+		want    string
+		wantErr bool
+	}{
+		{proxy: "http://proxy.corp:3128", want: "http://proxy.corp:3128"},
+		{proxy: "proxy.corp:3128", want: "http://proxy.corp:3128"},
+		{proxy: "10.0.0.1:8080", want: "http://10.0.0.1:8080"},
+		{proxy: "socks5://127.0.0.1:1080", want: "socks5://127.0.0.1:1080"},
+		{proxy: "ftp://proxy.corp", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.proxy, func(t *testing.T) {
+			transport, err := NewTransport(&Config{ProxyURL: tt.proxy})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "https://api.github.com", nil)
+			require.NoError(t, err)
+			proxyURL, err := transport.Proxy(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, proxyURL.String())
+		})
+	}
+}
+
+func TestNewTransport_CACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The test server certificate is not trusted by default
+	plain, err := NewTransport(nil)
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: plain}).Get(server.URL)
+	require.Error(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0644))
+
+	transport, err := NewTransport(&Config{CACertFile: caFile})
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0644))
+	_, err = NewTransport(&Config{CACertFile: invalid})
+	assert.ErrorContains(t, err, "no PEM certificates")
+
+	_, err = NewTransport(&Config{CACertFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.Error(t, err)
+}
+
+func TestConfig_GitArgs(t *testing.T) {
+	var none *Config
+	assert.Nil(t, none.GitArgs())
+	assert.Nil(t, (&Config{}).GitArgs())
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	args := (&Config{ProxyURL: "http://proxy.corp:3128", CACertFile: caFile}).GitArgs()
+	assert.Equal(t, []string{"-c", "http.proxy=http://proxy.corp:3128", "-c", "http.sslCAInfo=" + caFile}, args)
+}
@@ -0,0 +1,228 @@
+// Package plugin runs external provider executables that list the
+// repositories of SCM systems repocloner has no client for, such as Gerrit or
+// Phabricator mirrors.
+//
+// The protocol is JSON over stdin and stdout. repocloner starts the plugin,
+// writes one Request object to its stdin and closes it. The plugin writes one
+// repository per line to stdout, using the JSON fields of
+// repository.Repository (name, owner and clone_url are required), and exits
+// with status 0. On failure it exits with a non-zero status and explains why
+// on stderr. Plugins read their own credentials, typically from the
+// environment they inherit.
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// ProtocolVersion is the version of the plugin protocol sent in requests.
+// It changes only with incompatible changes.
+const ProtocolVersion = 1
+
+// ActionList asks the plugin for the repositories of an owner
+const ActionList = "list"
+
+// maxStderr bounds the plugin error output kept for error messages
+const maxStderr = 4096
+
+// Request is the JSON object written to the plugin's stdin
+type Request struct {
+	Protocol int    `json:"protocol"`
+	Action   string `json:"action"`
+	Owner    string `json:"owner"`
+}
+
+// ProviderConfig holds configuration for a provider plugin
+type ProviderConfig struct {
+	Path   string // Plugin executable, looked up in PATH without a separator
+	Args   []string
+	Logger shared.Logger
+}
+
+// Provider lists repositories by running a provider plugin
+type Provider struct {
+	path   string
+	args   []string
+	logger shared.Logger
+}
+
+// NewProvider creates a provider plugin, failing when the executable cannot
+// be found
+func NewProvider(config *ProviderConfig) (*Provider, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("plugin path cannot be empty")
+	}
+
+	path, err := exec.LookPath(config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("provider plugin %s not found: %w", config.Path, err)
+	}
+
+	return &Provider{
+		path:   path,
+		args:   config.Args,
+		logger: config.Logger,
+	}, nil
+}
+
+// Name returns the name of the plugin executable, e.g. for progress targets
+func (p *Provider) Name() string {
+	return strings.TrimSuffix(filepath.Base(p.path), filepath.Ext(p.path))
+}
+
+// FetchRepositoryPages runs the plugin for the owner and hands the
+// repositories it prints to handler in pages of pagination.PerPage, while the
+// plugin is still running. The repository type is not used, the owner means
+// whatever the plugin makes of it, and filters are left to the caller.
+func (p *Provider) FetchRepositoryPages(
+	ctx context.Context,
+	owner string,
+	_ repository.RepositoryType,
+	_ *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	handler repository.PageHandler,
+) error {
+	request, err := json.Marshal(Request{Protocol: ProtocolVersion, Action: ActionList, Owner: owner})
+	if err != nil {
+		return fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stderr limitedBuffer
+	cmd := exec.CommandContext(ctx, p.path, p.args...)
+	cmd.Stdin = bytes.NewReader(append(request, '\n'))
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start provider plugin %s: %w", p.Name(), err)
+	}
+
+	p.logger.Debug("Running provider plugin",
+		shared.StringField("plugin", p.path),
+		shared.StringField("owner", owner))
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start provider plugin %s: %w", p.Name(), err)
+	}
+
+	readErr := p.readRepositories(stdout, pagination, handler)
+	if readErr != nil {
+		// Stop the plugin rather than waiting for it to print the rest
+		cancel()
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+	waitErr := cmd.Wait()
+
+	switch {
+	case readErr != nil:
+		return readErr
+	case waitErr != nil:
+		var exitErr *exec.ExitError
+		if message := strings.TrimSpace(stderr.String()); errors.As(waitErr, &exitErr) && message != "" {
+			return fmt.Errorf("provider plugin %s failed: %s", p.Name(), message)
+		}
+		return fmt.Errorf("provider plugin %s failed: %w", p.Name(), waitErr)
+	}
+	return nil
+}
+
+// readRepositories decodes the repositories printed by the plugin, one JSON
+// object per line, and delivers them in pages
+func (p *Provider) readRepositories(
+	stdout io.Reader,
+	pagination *repository.PaginationOptions,
+	handler repository.PageHandler,
+) error {
+	perPage := repository.NewPaginationOptions().PerPage
+	if pagination != nil && pagination.PerPage > 0 {
+		perPage = pagination.PerPage
+	}
+
+	var page []*repository.Repository
+	flush := func() error {
+		if len(page) == 0 {
+			return nil
+		}
+		err := handler(page)
+		page = nil
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		repo, err := decodeRepository([]byte(text))
+		if err != nil {
+			return fmt.Errorf("provider plugin %s printed an invalid repository on line %d: %w", p.Name(), line, err)
+		}
+		page = append(page, repo)
+		if len(page) == perPage {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read provider plugin %s output: %w", p.Name(), err)
+	}
+	return flush()
+}
+
+// decodeRepository decodes and validates one repository line
+func decodeRepository(data []byte) (*repository.Repository, error) {
+	var repo repository.Repository
+	if err := json.Unmarshal(data, &repo); err != nil {
+		return nil, err
+	}
+	if err := repo.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validatePathSegment("name", repo.Name); err != nil {
+		return nil, err
+	}
+	if err := validatePathSegment("owner", repo.Owner); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// validatePathSegment checks that a repository field used as a directory of
+// the clone path is a single clean path segment, so a plugin cannot place
+// clones outside the base directory
+func validatePathSegment(field, value string) error {
+	if value == "." || value == ".." || strings.ContainsAny(value, `/\`) || filepath.IsAbs(value) || filepath.VolumeName(value) != "" {
+		return fmt.Errorf("repository %s %q must be a single path segment", field, value)
+	}
+	return nil
+}
+
+// limitedBuffer keeps the first maxStderr bytes written to it
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := maxStderr - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,116 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// writePlugin writes a shell script provider plugin and returns its path
+func writePlugin(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "test-provider")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755))
+	return path
+}
+
+func newTestProvider(t *testing.T, path string) *Provider {
+	t.Helper()
+	provider, err := NewProvider(&ProviderConfig{Path: path, Logger: logging.NewNoOpLogger()})
+	require.NoError(t, err)
+	return provider
+}
+
+func TestProvider_FetchRepositoryPages(t *testing.T) {
+	requestFile := filepath.Join(t.TempDir(), "request.json")
+	path := writePlugin(t, `cat > `+requestFile+`
+echo '{"name":"api","owner":"platform","clone_url":"https://gerrit.example.com/platform/api","size":2048}'
+echo
+echo '{"name":"web","owner":"platform","clone_url":"ssh://gerrit.example.com:29418/platform/web","fork":true}'
+echo '{"name":"docs","owner":"platform","clone_url":"https://gerrit.example.com/platform/docs"}'
+`)
+	provider := newTestProvider(t, path)
+	assert.Equal(t, "test-provider", provider.Name())
+
+	var pages [][]*repository.Repository
+	pagination := repository.NewPaginationOptions()
+	pagination.PerPage = 2
+	err := provider.FetchRepositoryPages(context.Background(), "platform", "", nil, pagination,
+		func(page []*repository.Repository) error {
+			pages = append(pages, page)
+			return nil
+		})
+	require.NoError(t, err)
+
+	require.Len(t, pages, 2)
+	require.Len(t, pages[0], 2)
+	assert.Equal(t, "platform/api", pages[0][0].GetFullName())
+	assert.Equal(t, int64(2048), pages[0][0].Size)
+	assert.True(t, pages[0][1].IsFork)
+	assert.Equal(t, "docs", pages[1][0].Name)
+
+	request, err := os.ReadFile(requestFile)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"protocol":1,"action":"list","owner":"platform"}`, string(request))
+}
+
+func TestProvider_FetchRepositoryPagesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		expected string
+	}{
+		{
+			name:     "plugin failure",
+			script:   "echo 'unknown project platform' >&2\nexit 3\n",
+			expected: "provider plugin test-provider failed: unknown project platform",
+		},
+		{
+			name:     "invalid json",
+			script:   "echo 'not json'\n",
+			expected: "invalid repository on line 1",
+		},
+		{
+			name:     "invalid repository",
+			script:   `echo '{"name":"api","owner":"platform","clone_url":"ftp://example.com/api"}'` + "\n",
+			expected: "clone URL must use https or ssh protocol",
+		},
+		{
+			name:     "name outside the base directory",
+			script:   `echo '{"name":"../../x","owner":"platform","clone_url":"https://example.com/x"}'` + "\n",
+			expected: `repository name "../../x" must be a single path segment`,
+		},
+		{
+			name:     "parent directory owner",
+			script:   `echo '{"name":"api","owner":"..","clone_url":"https://example.com/api"}'` + "\n",
+			expected: `repository owner ".." must be a single path segment`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestProvider(t, writePlugin(t, tt.script))
+			err := provider.FetchRepositoryPages(context.Background(), "platform", "", nil, nil,
+				func([]*repository.Repository) error { return nil })
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestNewProvider_NotFound(t *testing.T) {
+	_, err := NewProvider(&ProviderConfig{Path: "./does-not-exist", Logger: logging.NewNoOpLogger()})
+	assert.ErrorContains(t, err, "provider plugin ./does-not-exist not found")
+}
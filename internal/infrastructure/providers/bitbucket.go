@@ -0,0 +1,104 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
+)
+
+// BitbucketProvider lists repositories of Bitbucket Cloud users and
+// workspaces
+type BitbucketProvider struct {
+	client *bitbucket.BitbucketClient
+}
+
+// NewBitbucketProvider creates a provider backed by a Bitbucket Cloud client
+func NewBitbucketProvider(client *bitbucket.BitbucketClient) *BitbucketProvider {
+	return &BitbucketProvider{client: client}
+}
+
+// Name returns the provider name
+func (p *BitbucketProvider) Name() string {
+	return Bitbucket
+}
+
+// Capabilities reports the features of Bitbucket Cloud
+func (p *BitbucketProvider) Capabilities() Capabilities {
+	return Capabilities{
+		OwnerTypes: []repository.RepositoryType{repository.RepositoryTypeBitbucketUser, repository.RepositoryTypeBitbucketWorkspace},
+		RateLimit:  true,
+	}
+}
+
+// FetchRepositories lists the repositories of a user or workspace
+func (p *BitbucketProvider) FetchRepositories(
+	ctx context.Context,
+	owner string,
+	repoType repository.RepositoryType,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	handler repository.PageHandler,
+) error {
+	return p.client.FetchRepositoryPages(ctx, owner, repoType, filter, pagination, handler)
+}
+
+// Validate checks the configured credentials
+func (p *BitbucketProvider) Validate(ctx context.Context) error {
+	return p.client.ValidateCredentials(ctx)
+}
+
+// RateLimitInfo returns the API budget left
+func (p *BitbucketProvider) RateLimitInfo(ctx context.Context) (*RateLimit, error) {
+	info, err := p.client.GetRateLimitInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimit{Limit: info.Limit, Remaining: info.Remaining, ResetTime: info.ResetTime}, nil
+}
+
+// BitbucketServerProvider lists repositories of projects of a self-hosted
+// Bitbucket Server / Data Center instance
+type BitbucketServerProvider struct {
+	client *bitbucket.BitbucketServerClient
+}
+
+// NewBitbucketServerProvider creates a provider backed by a Bitbucket Server
+// client
+func NewBitbucketServerProvider(client *bitbucket.BitbucketServerClient) *BitbucketServerProvider {
+	return &BitbucketServerProvider{client: client}
+}
+
+// Name returns the provider name
+func (p *BitbucketServerProvider) Name() string {
+	return BitbucketServer
+}
+
+// Capabilities reports the features of Bitbucket Server
+func (p *BitbucketServerProvider) Capabilities() Capabilities {
+	return Capabilities{
+		OwnerTypes: []repository.RepositoryType{repository.RepositoryTypeBitbucketProject},
+	}
+}
+
+// FetchRepositories lists the repositories of a project
+func (p *BitbucketServerProvider) FetchRepositories(
+	ctx context.Context,
+	owner string,
+	repoType repository.RepositoryType,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	handler repository.PageHandler,
+) error {
+	return p.client.FetchRepositoryPages(ctx, owner, repoType, filter, pagination, handler)
+}
+
+// Validate checks that the instance accepts the configured token
+func (p *BitbucketServerProvider) Validate(ctx context.Context) error {
+	return p.client.ValidateCredentials(ctx)
+}
+
+// RateLimitInfo returns ErrNotSupported, Bitbucket Server reports no budget
+func (p *BitbucketServerProvider) RateLimitInfo(context.Context) (*RateLimit, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,99 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+)
+
+// GitHubProvider lists repositories of GitHub users and organizations
+type GitHubProvider struct {
+	client *github.GitHubClient
+}
+
+// NewGitHubProvider creates a provider backed by a GitHub client
+func NewGitHubProvider(client *github.GitHubClient) *GitHubProvider {
+	return &GitHubProvider{client: client}
+}
+
+// Name returns the provider name
+func (p *GitHubProvider) Name() string {
+	return GitHub
+}
+
+// Capabilities reports the features of GitHub
+func (p *GitHubProvider) Capabilities() Capabilities {
+	return Capabilities{
+		OwnerTypes:    []repository.RepositoryType{repository.RepositoryTypeUser, repository.RepositoryTypeOrganization},
+		Search:        true,
+		Teams:         true,
+		ForkUpstreams: true,
+		Lookup:        true,
+		RateLimit:     true,
+		Sort:          true,
+	}
+}
+
+// FetchRepositories lists the repositories of a user or organization
+func (p *GitHubProvider) FetchRepositories(
+	ctx context.Context,
+	owner string,
+	repoType repository.RepositoryType,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	handler repository.PageHandler,
+) error {
+	return p.client.FetchRepositoryPages(ctx, owner, repoType, filter, pagination, handler)
+}
+
+// SearchRepositories selects repositories with GitHub search qualifiers
+func (p *GitHubProvider) SearchRepositories(
+	ctx context.Context,
+	query string,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	handler repository.PageHandler,
+) error {
+	return p.client.SearchRepositoryPages(ctx, query, filter, pagination, handler)
+}
+
+// FetchTeamRepositories lists the repositories of an organization team
+func (p *GitHubProvider) FetchTeamRepositories(
+	ctx context.Context,
+	org, team string,
+	filter *repository.RepositoryFilter,
+	pagination *repository.PaginationOptions,
+	handler repository.PageHandler,
+) error {
+	return p.client.FetchTeamRepositoryPages(ctx, org, team, filter, pagination, handler)
+}
+
+// FetchUpstreamURL returns the clone URL of the parent of a fork
+func (p *GitHubProvider) FetchUpstreamURL(ctx context.Context, owner, name string) (string, error) {
+	return p.client.FetchUpstreamURL(ctx, owner, name)
+}
+
+// Host returns the host of the repositories FetchRepository looks up
+func (p *GitHubProvider) Host() string {
+	return "github.com"
+}
+
+// FetchRepository looks up a repository by its name
+func (p *GitHubProvider) FetchRepository(ctx context.Context, owner, name string) (*repository.Repository, error) {
+	return p.client.FetchRepository(ctx, owner, name)
+}
+
+// Validate checks the configured token
+func (p *GitHubProvider) Validate(ctx context.Context) error {
+	return p.client.ValidateToken(ctx)
+}
+
+// RateLimitInfo returns the core API budget left
+func (p *GitHubProvider) RateLimitInfo(ctx context.Context) (*RateLimit, error) {
+	info, err := p.client.GetRateLimitInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimit{Limit: info.Limit, Remaining: info.Remaining, ResetTime: info.ResetTime}, nil
+}
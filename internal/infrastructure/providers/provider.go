@@ -0,0 +1,111 @@
+// Package providers puts the hosting providers repositories are listed from
+// behind one interface, registered by name, so commands select a provider by
+// name and use cases do not depend on a provider's client.
+package providers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// Names of the built-in providers
+const (
+	GitHub          = "github"
+	Bitbucket       = "bitbucket"
+	BitbucketServer = "bitbucket-server"
+)
+
+// ErrNotSupported is returned by providers asked for something their
+// capabilities do not include
+var ErrNotSupported = errors.New("not supported by provider")
+
+// Provider lists the repositories of an owner on a hosting provider
+type Provider interface {
+	// Name is the name the provider is registered and selected by
+	Name() string
+
+	// Capabilities reports the owner types and optional features the
+	// provider supports
+	Capabilities() Capabilities
+
+	// FetchRepositories lists the repositories of an owner page by page
+	FetchRepositories(
+		ctx context.Context,
+		owner string,
+		repoType repository.RepositoryType,
+		filter *repository.RepositoryFilter,
+		pagination *repository.PaginationOptions,
+		handler repository.PageHandler,
+	) error
+
+	// Validate checks that the provider is reachable and accepts the
+	// configured credentials
+	Validate(ctx context.Context) error
+
+	// RateLimitInfo returns the API budget left, or ErrNotSupported when the
+	// provider reports none
+	RateLimitInfo(ctx context.Context) (*RateLimit, error)
+}
+
+// Capabilities describes what a provider supports beyond listing owners.
+// Each optional feature is backed by one of the interfaces below.
+type Capabilities struct {
+	OwnerTypes    []repository.RepositoryType // Owner types FetchRepositories lists
+	Search        bool                        // Searcher
+	Teams         bool                        // TeamLister
+	ForkUpstreams bool                        // UpstreamResolver
+	Lookup        bool                        // RepositoryFinder
+	RateLimit     bool                        // RateLimitInfo reports a budget
+	Sort          bool                        // Pages arrive in PaginationOptions.Sort order
+}
+
+// Supports reports whether the provider lists owners of the given type
+func (c Capabilities) Supports(repoType repository.RepositoryType) bool {
+	return slices.Contains(c.OwnerTypes, repoType)
+}
+
+// RateLimit is the API budget of a provider
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetTime time.Time `json:"reset_time"`
+}
+
+// Searcher selects repositories with a provider's search qualifiers
+type Searcher interface {
+	SearchRepositories(
+		ctx context.Context,
+		query string,
+		filter *repository.RepositoryFilter,
+		pagination *repository.PaginationOptions,
+		handler repository.PageHandler,
+	) error
+}
+
+// TeamLister lists the repositories of a team of an organization
+type TeamLister interface {
+	FetchTeamRepositories(
+		ctx context.Context,
+		org, team string,
+		filter *repository.RepositoryFilter,
+		pagination *repository.PaginationOptions,
+		handler repository.PageHandler,
+	) error
+}
+
+// UpstreamResolver looks up the clone URL of the parent of a fork, which
+// listings do not report
+type UpstreamResolver interface {
+	FetchUpstreamURL(ctx context.Context, owner, name string) (string, error)
+}
+
+// RepositoryFinder looks up a single repository of the provider's host by
+// its name, following renames and transfers
+type RepositoryFinder interface {
+	Host() string
+	FetchRepository(ctx context.Context, owner, name string) (*repository.Repository, error)
+}
@@ -0,0 +1,98 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+)
+
+// Registry holds the configured providers by name
+type Registry struct {
+	providers map[string]Provider
+	order     []string
+}
+
+// NewRegistry creates a registry of the given providers
+func NewRegistry(providers ...Provider) (*Registry, error) {
+	registry := &Registry{providers: make(map[string]Provider)}
+	for _, provider := range providers {
+		if err := registry.Register(provider); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// Default creates the registry of the built-in providers, leaving out those
+// whose client is not configured
+func Default(
+	githubClient *github.GitHubClient,
+	bitbucketClient *bitbucket.BitbucketClient,
+	bitbucketServerClient *bitbucket.BitbucketServerClient,
+) *Registry {
+	registry := &Registry{providers: make(map[string]Provider)}
+	if githubClient != nil {
+		_ = registry.Register(NewGitHubProvider(githubClient))
+	}
+	if bitbucketClient != nil {
+		_ = registry.Register(NewBitbucketProvider(bitbucketClient))
+	}
+	if bitbucketServerClient != nil {
+		_ = registry.Register(NewBitbucketServerProvider(bitbucketServerClient))
+	}
+	return registry
+}
+
+// Register adds a provider, failing when its name is taken
+func (r *Registry) Register(provider Provider) error {
+	name := strings.ToLower(provider.Name())
+	if name == "" {
+		return fmt.Errorf("provider name cannot be empty")
+	}
+	if _, exists := r.providers[name]; exists {
+		return fmt.Errorf("provider %q is already registered", name)
+	}
+	r.providers[name] = provider
+	r.order = append(r.order, name)
+	return nil
+}
+
+// Get returns the provider of a name
+func (r *Registry) Get(name string) (Provider, error) {
+	provider, ok := r.providers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q, must be one of: %s", name, strings.Join(r.Names(), ", "))
+	}
+	return provider, nil
+}
+
+// ForType returns the first registered provider listing owners of a type, or
+// nil when none does
+func (r *Registry) ForType(repoType repository.RepositoryType) Provider {
+	for _, name := range r.order {
+		if provider := r.providers[name]; provider.Capabilities().Supports(repoType) {
+			return provider
+		}
+	}
+	return nil
+}
+
+// Providers returns the registered providers in registration order
+func (r *Registry) Providers() []Provider {
+	providers := make([]Provider, 0, len(r.order))
+	for _, name := range r.order {
+		providers = append(providers, r.providers[name])
+	}
+	return providers
+}
+
+// Names returns the names of the registered providers, sorted
+func (r *Registry) Names() []string {
+	names := append([]string(nil), r.order...)
+	sort.Strings(names)
+	return names
+}
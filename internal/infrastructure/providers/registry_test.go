@@ -0,0 +1,108 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// fakeProvider is a provider listing nothing
+type fakeProvider struct {
+	name         string
+	capabilities Capabilities
+}
+
+func (p *fakeProvider) Name() string               { return p.name }
+func (p *fakeProvider) Capabilities() Capabilities { return p.capabilities }
+func (p *fakeProvider) Validate(context.Context) error {
+	return nil
+}
+func (p *fakeProvider) RateLimitInfo(context.Context) (*RateLimit, error) {
+	return nil, ErrNotSupported
+}
+func (p *fakeProvider) FetchRepositories(
+	_ context.Context,
+	_ string,
+	_ repository.RepositoryType,
+	_ *repository.RepositoryFilter,
+	_ *repository.PaginationOptions,
+	_ repository.PageHandler,
+) error {
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	gitea := &fakeProvider{name: "Gitea", capabilities: Capabilities{OwnerTypes: []repository.RepositoryType{repository.RepositoryTypeOrganization}}}
+	forge := &fakeProvider{name: "forge", capabilities: Capabilities{OwnerTypes: []repository.RepositoryType{repository.RepositoryTypeOrganization, repository.RepositoryTypeUser}}}
+
+	registry, err := NewRegistry(gitea, forge)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"forge", "gitea"}, registry.Names())
+	assert.Equal(t, []Provider{gitea, forge}, registry.Providers(), "providers keep registration order")
+
+	provider, err := registry.Get("GITEA")
+	require.NoError(t, err)
+	assert.Same(t, gitea, provider)
+
+	_, err = registry.Get("gitlab")
+	assert.ErrorContains(t, err, `unknown provider "gitlab", must be one of: forge, gitea`)
+
+	assert.Same(t, gitea, registry.ForType(repository.RepositoryTypeOrganization), "the first registered provider wins")
+	assert.Same(t, forge, registry.ForType(repository.RepositoryTypeUser))
+	assert.Nil(t, registry.ForType(repository.RepositoryTypeBitbucketProject))
+
+	assert.ErrorContains(t, registry.Register(&fakeProvider{name: "gitea"}), `provider "gitea" is already registered`)
+	assert.ErrorContains(t, registry.Register(&fakeProvider{}), "provider name cannot be empty")
+}
+
+func TestDefault(t *testing.T) {
+	logger := logging.NewNoOpLogger()
+	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{Logger: logger})
+	bitbucketClient := bitbucket.NewBitbucketClient(&bitbucket.BitbucketClientConfig{Logger: logger})
+	serverClient, err := bitbucket.NewBitbucketServerClient(&bitbucket.BitbucketServerClientConfig{BaseURL: "https://bitbucket.example.com", Logger: logger})
+	require.NoError(t, err)
+
+	registry := Default(githubClient, bitbucketClient, serverClient)
+	assert.Equal(t, []string{Bitbucket, BitbucketServer, GitHub}, registry.Names())
+
+	tests := []struct {
+		repoType repository.RepositoryType
+		want     string
+	}{
+		{repository.RepositoryTypeUser, GitHub},
+		{repository.RepositoryTypeOrganization, GitHub},
+		{repository.RepositoryTypeBitbucketUser, Bitbucket},
+		{repository.RepositoryTypeBitbucketWorkspace, Bitbucket},
+		{repository.RepositoryTypeBitbucketProject, BitbucketServer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repoType.String(), func(t *testing.T) {
+			provider := registry.ForType(tt.repoType)
+			require.NotNil(t, provider)
+			assert.Equal(t, tt.want, provider.Name())
+		})
+	}
+
+	server, err := registry.Get(BitbucketServer)
+	require.NoError(t, err)
+	assert.False(t, server.Capabilities().RateLimit)
+	_, err = server.RateLimitInfo(context.Background())
+	assert.ErrorIs(t, err, ErrNotSupported)
+
+	githubProvider, err := registry.Get(GitHub)
+	require.NoError(t, err)
+	assert.Implements(t, (*Searcher)(nil), githubProvider)
+	assert.Implements(t, (*TeamLister)(nil), githubProvider)
+	assert.Implements(t, (*UpstreamResolver)(nil), githubProvider)
+	assert.Implements(t, (*RepositoryFinder)(nil), githubProvider)
+
+	assert.Equal(t, []string{GitHub}, Default(githubClient, nil, nil).Names(), "providers without a client are left out")
+}
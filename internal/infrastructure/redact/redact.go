@@ -0,0 +1,103 @@
+// Package redact masks credentials in text bound for logs, error messages and
+// job results: the tokens configured for the run, credentials embedded in
+// URLs, and tokens recognizable by their provider prefix.
+package redact
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Mask replaces every redacted credential
+const Mask = "***"
+
+// minSecretLength keeps short values, which would mask ordinary words, from
+// being registered as secrets
+const minSecretLength = 6
+
+var (
+	mu      sync.RWMutex
+	secrets []string
+)
+
+// patterns match credentials that were never registered, e.g. tokens pasted
+// into a repository list
+var patterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// user:password@ in URLs; a bare user such as ssh://git@ is kept
+	{regexp.MustCompile(`(?i)([a-z][a-z0-9+.-]*://)[^/\s:@]+:[^/\s@]+@`), "${1}" + Mask + "@"},
+	// Authorization header values
+	{regexp.MustCompile(`(?i)(authorization:\s*(?:basic|bearer|token)\s+)[^\s"']+`), "${1}" + Mask},
+	// GitHub, GitLab, Atlassian API tokens and Bitbucket app passwords
+	{regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|glpat-[A-Za-z0-9_-]{20,}|ATATT[A-Za-z0-9_=-]{20,}|ATBB[A-Za-z0-9]{20,})`), Mask},
+}
+
+// AddSecrets registers values to mask wherever they appear, such as the
+// tokens and app passwords of the configured providers. Empty and short
+// values are ignored.
+func AddSecrets(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) < minSecretLength || slices.Contains(secrets, value) {
+			continue
+		}
+		secrets = append(secrets, value)
+	}
+}
+
+// String masks the registered secrets and recognizable credentials in s
+func String(s string) string {
+	if s == "" {
+		return s
+	}
+
+	mu.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+	mu.RUnlock()
+
+	for _, p := range patterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
+
+// Error returns err with a redacted message. The original error stays
+// reachable through errors.Is and errors.As, so callers can still classify it.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*redactedError); ok {
+		return err
+	}
+
+	message := err.Error()
+	redacted := String(message)
+	if redacted == message {
+		return err
+	}
+	return &redactedError{message: redacted, err: err}
+}
+
+// redactedError carries the redacted message of an error
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,77 @@
+package runs
+
+import (
+	"sort"
+	"strings"
+)
+
+// Diff is the change between two runs of the same command
+type Diff struct {
+	Previous *Report
+
+	New         []string     // Repositories listed now but not by the previous run
+	Disappeared []string     // Repositories of the previous run no longer listed
+	FailedAgain []Repository // Repositories failing in both runs, with their current error
+
+	// Repositories with a recorded HEAD in both runs, by whether it moved
+	Advanced  int
+	Unchanged int
+}
+
+// IsEmpty reports whether nothing changed between the runs
+func (d *Diff) IsEmpty() bool {
+	return d == nil || len(d.New)+len(d.Disappeared)+len(d.FailedAgain) == 0
+}
+
+// Compare returns the change from a previous run to the current one, nil
+// without a previous run. Repositories are matched by their case-insensitive
+// owner/name.
+func Compare(previous, current *Report) *Diff {
+	if previous == nil || current == nil {
+		return nil
+	}
+
+	before := repositoriesByName(previous)
+	now := repositoriesByName(current)
+
+	diff := &Diff{Previous: previous}
+	for key, repo := range now {
+		old, listed := before[key]
+		switch {
+		case !listed:
+			diff.New = append(diff.New, repo.Name)
+		case repo.Status == statusFailed && old.Status == statusFailed:
+			diff.FailedAgain = append(diff.FailedAgain, repo)
+		case repo.Revision != "" && old.Revision != "":
+			if repo.Revision == old.Revision {
+				diff.Unchanged++
+			} else {
+				diff.Advanced++
+			}
+		}
+	}
+	for key, repo := range before {
+		if _, listed := now[key]; !listed {
+			diff.Disappeared = append(diff.Disappeared, repo.Name)
+		}
+	}
+
+	sort.Strings(diff.New)
+	sort.Strings(diff.Disappeared)
+	sort.Slice(diff.FailedAgain, func(i, j int) bool {
+		return diff.FailedAgain[i].Name < diff.FailedAgain[j].Name
+	})
+	return diff
+}
+
+// statusFailed is the status of failed repositories, see cloning.JobStatus
+const statusFailed = "failed"
+
+// repositoriesByName indexes the repositories of a report by lower-cased name
+func repositoriesByName(report *Report) map[string]Repository {
+	repos := make(map[string]Repository, len(report.Repositories))
+	for _, repo := range report.Repositories {
+		repos[strings.ToLower(repo.Name)] = repo
+	}
+	return repos
+}
@@ -0,0 +1,56 @@
+package runs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	previous := &Report{ID: "previous", Repositories: []Repository{
+		{Name: "acme/kept", Status: "completed"},
+		{Name: "acme/removed", Status: "completed"},
+		{Name: "acme/broken", Status: "failed", Error: "timeout"},
+		{Name: "acme/fixed", Status: "failed", Error: "timeout"},
+		{Name: "Acme/Renamed-Case", Status: "failed", Error: "denied"},
+	}}
+	current := &Report{ID: "current", Repositories: []Repository{
+		{Name: "acme/kept", Status: "updated"},
+		{Name: "acme/added", Status: "completed"},
+		{Name: "acme/broken", Status: "failed", Error: "repository not found"},
+		{Name: "acme/fixed", Status: "completed"},
+		{Name: "acme/renamed-case", Status: "failed", Error: "denied"},
+	}}
+
+	diff := Compare(previous, current)
+	assert.Same(t, previous, diff.Previous)
+	assert.Equal(t, []string{"acme/added"}, diff.New)
+	assert.Equal(t, []string{"acme/removed"}, diff.Disappeared)
+	assert.Equal(t, []Repository{
+		{Name: "acme/broken", Status: "failed", Error: "repository not found"},
+		{Name: "acme/renamed-case", Status: "failed", Error: "denied"},
+	}, diff.FailedAgain)
+	assert.False(t, diff.IsEmpty())
+
+	assert.Nil(t, Compare(nil, current))
+	unchanged := &Report{Repositories: []Repository{{Name: "acme/kept", Status: "completed"}}}
+	assert.True(t, Compare(unchanged, unchanged).IsEmpty())
+}
+
+func TestCompare_Revisions(t *testing.T) {
+	previous := &Report{Repositories: []Repository{
+		{Name: "acme/active", Status: "completed", Revision: "aaa"},
+		{Name: "acme/stale", Status: "completed", Revision: "bbb"},
+		{Name: "acme/unknown", Status: "completed"},
+	}}
+	current := &Report{Repositories: []Repository{
+		{Name: "acme/active", Status: "updated", Revision: "ccc"},
+		{Name: "acme/stale", Status: "updated", Revision: "bbb"},
+		{Name: "acme/unknown", Status: "updated", Revision: "ddd"},
+	}}
+
+	diff := Compare(previous, current)
+	assert.Equal(t, 1, diff.Advanced)
+	assert.Equal(t, 1, diff.Unchanged)
+	assert.True(t, diff.IsEmpty(), "HEAD changes alone are not listed")
+}
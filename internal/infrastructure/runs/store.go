@@ -0,0 +1,202 @@
+// Package runs keeps a JSON report of every clone run under the data
+// directory, named by the run ID that also tags the log lines of the run.
+package runs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrRunNotFound is returned for run IDs without a report
+var ErrRunNotFound = errors.New("run not found")
+
+// reportExt is the file extension of run reports
+const reportExt = ".json"
+
+// NewID returns a run ID for a run started at the given time: the UTC start
+// time followed by random hex digits, so IDs sort chronologically, e.g.
+// 20250601-142530-3f9a1c
+func NewID(startedAt time.Time) string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return startedAt.UTC().Format("20060102-150405") + "-" + hex.EncodeToString(suffix)
+}
+
+// Report summarizes a finished clone run
+type Report struct {
+	ID         string        `json:"id"`
+	Command    string        `json:"command"` // Command path and arguments, without flags
+	Version    string        `json:"version"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Duration   time.Duration `json:"duration"`
+	BaseDir    string        `json:"base_dir"`
+	LogFile    string        `json:"log_file,omitempty"`
+
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Updated   int `json:"updated"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	Cancelled int `json:"cancelled"`
+	TimedOut  int `json:"timed_out"`
+
+	Repositories []Repository `json:"repositories"`
+}
+
+// Repository is the outcome of one repository of a run
+type Repository struct {
+	Name   string `json:"name"` // owner/name
+	URL    string `json:"url"`  // Clone URL
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	// DefaultBranch is the default branch reported by the provider, empty
+	// when unknown
+	DefaultBranch string `json:"default_branch,omitempty"`
+
+	// HEAD of cloned and updated repositories
+	Revision string `json:"revision,omitempty"` // Commit SHA
+	Branch   string `json:"branch,omitempty"`   // Empty when HEAD is detached
+}
+
+// Store reads and writes the run reports of a directory
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store keeping reports in dir, created on first save
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the directory of the reports
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Path returns the report file of a run
+func (s *Store) Path(id string) string {
+	return filepath.Join(s.dir, id+reportExt)
+}
+
+// Save writes the report of a run through a temporary file, so readers never
+// see a partial report
+func (s *Store) Save(report *Report) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create run directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+
+	path := s.Path(report.ID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+	return nil
+}
+
+// List returns the reports of the store, newest first. Unreadable reports
+// are skipped.
+func (s *Store) List() ([]*Report, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run directory: %w", err)
+	}
+
+	var reports []*Report
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != reportExt {
+			continue
+		}
+		report, err := s.load(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].StartedAt.After(reports[j].StartedAt)
+	})
+	return reports, nil
+}
+
+// Previous returns the latest report of another run of the same command
+// into the same base directory started before report, nil when there is none
+func (s *Store) Previous(report *Report) (*Report, error) {
+	reports, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, previous := range reports {
+		if previous.ID != report.ID && previous.Command == report.Command &&
+			previous.BaseDir == report.BaseDir && previous.StartedAt.Before(report.StartedAt) {
+			return previous, nil
+		}
+	}
+	return nil, nil
+}
+
+// Load returns the report of a run by its ID or a unique prefix of it
+func (s *Store) Load(id string) (*Report, error) {
+	if id == "" {
+		return nil, ErrRunNotFound
+	}
+	if report, err := s.load(s.Path(id)); err == nil {
+		return report, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	reports, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	var match *Report
+	for _, report := range reports {
+		if !strings.HasPrefix(report.ID, id) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("run ID %q is ambiguous, matching %s and %s", id, match.ID, report.ID)
+		}
+		match = report
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: %s", ErrRunNotFound, id)
+	}
+	return match, nil
+}
+
+// load reads a report file
+func (s *Store) load(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid run report %s: %w", path, err)
+	}
+	return &report, nil
+}
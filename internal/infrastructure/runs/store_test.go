@@ -0,0 +1,94 @@
+package runs
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewID(t *testing.T) {
+	startedAt := time.Date(2025, 6, 1, 14, 25, 30, 0, time.UTC)
+
+	id := NewID(startedAt)
+	assert.Regexp(t, regexp.MustCompile(`^20250601-142530-[0-9a-f]{6}$`), id)
+	assert.NotEqual(t, id, NewID(startedAt))
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "runs"))
+
+	reports, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, reports)
+
+	base := time.Date(2025, 6, 1, 14, 0, 0, 0, time.UTC)
+	for i, id := range []string{"20250601-140000-aaaaaa", "20250601-150000-bbbbbb", "20250601-150000-cccccc"} {
+		require.NoError(t, store.Save(&Report{
+			ID:           id,
+			StartedAt:    base.Add(time.Duration(i) * time.Hour),
+			Total:        1,
+			Repositories: []Repository{{Name: "acme/app", Status: "failed", Error: "boom"}},
+		}))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(store.Dir(), "broken.json"), []byte("{"), 0644))
+
+	reports, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+	assert.Equal(t, "20250601-150000-cccccc", reports[0].ID)
+	assert.Equal(t, "20250601-140000-aaaaaa", reports[2].ID)
+
+	tests := []struct {
+		id      string
+		want    string
+		wantErr string
+	}{
+		{id: "20250601-140000-aaaaaa", want: "20250601-140000-aaaaaa"},
+		{id: "20250601-14", want: "20250601-140000-aaaaaa"},
+		{id: "20250601-150000-b", want: "20250601-150000-bbbbbb"},
+		{id: "20250601-15", wantErr: "ambiguous"},
+		{id: "2024", wantErr: "run not found"},
+		{id: "", wantErr: "run not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			report, err := store.Load(tt.id)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, report.ID)
+			assert.Equal(t, []Repository{{Name: "acme/app", Status: "failed", Error: "boom"}}, report.Repositories)
+		})
+	}
+}
+
+func TestStore_Previous(t *testing.T) {
+	store := NewStore(t.TempDir())
+	base := time.Date(2025, 6, 1, 14, 0, 0, 0, time.UTC)
+	save := func(id, command, baseDir string, hours int) *Report {
+		report := &Report{ID: id, Command: command, BaseDir: baseDir, StartedAt: base.Add(time.Duration(hours) * time.Hour)}
+		require.NoError(t, store.Save(report))
+		return report
+	}
+
+	save("old", "repocloner clone org acme", "mirrors", 0)
+	save("other-dir", "repocloner clone org acme", "workspace", 1)
+	save("other-command", "repocloner clone org other", "mirrors", 2)
+	latest := save("latest", "repocloner clone org acme", "mirrors", 3)
+
+	previous, err := store.Previous(latest)
+	require.NoError(t, err)
+	require.NotNil(t, previous)
+	assert.Equal(t, "old", previous.ID)
+
+	previous, err = store.Previous(&Report{ID: "first", Command: "repocloner clone user me", BaseDir: "mirrors", StartedAt: base})
+	require.NoError(t, err)
+	assert.Nil(t, previous)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// progressInterval bounds how often progress events are sent; the tracker
+// reports every transferred chunk
+const progressInterval = 500 * time.Millisecond
+
+// batchSummary is the data of the done event ending the stream of a batch
+type batchSummary struct {
+	Total      int    `json:"total"`
+	Completed  int    `json:"completed"`
+	Updated    int    `json:"updated"`
+	Failed     int    `json:"failed"`
+	Skipped    int    `json:"skipped"`
+	Cancelled  int    `json:"cancelled"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// streamEvents streams the progress of a batch as server-sent events until it
+// finishes or the client goes away:
+//
+//	event: progress  the progress of the batch (cloning.Progress), at most
+//	                 every progressInterval
+//	event: job       a job lifecycle event (cloning.JobEvent), e.g. completed
+//	event: done      the result counts of the batch, ending the stream
+func streamEvents(w http.ResponseWriter, r *http.Request, batch *usecases.CloneBatch) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Clients reconnect after going away, each time with new subscriptions
+	jobs, unsubscribeEvents := batch.SubscribeEvents()
+	defer unsubscribeEvents()
+	updates, unsubscribe := batch.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event string, data any) {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+		flusher.Flush()
+	}
+	send("progress", batch.Progress())
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	var latest *cloning.Progress
+	for jobs != nil || updates != nil {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-jobs:
+			if !ok {
+				jobs = nil
+				continue
+			}
+			send("job", event)
+		case progress, ok := <-updates:
+			if !ok {
+				updates = nil
+				continue
+			}
+			latest = progress
+		case <-ticker.C:
+			if latest != nil {
+				send("progress", latest)
+				latest = nil
+			}
+		}
+	}
+
+	resp, err := batch.Wait()
+	send("progress", batch.Progress())
+	send("done", newBatchSummary(resp, err))
+}
+
+// newBatchSummary builds the done event of a finished batch
+func newBatchSummary(resp *usecases.CloneRepositoriesResponse, err error) batchSummary {
+	if err != nil {
+		return batchSummary{Error: err.Error()}
+	}
+	return batchSummary{
+		Total:      resp.TotalJobs,
+		Completed:  resp.CompletedJobs,
+		Updated:    resp.UpdatedJobs,
+		Failed:     resp.FailedJobs,
+		Skipped:    resp.SkippedJobs,
+		Cancelled:  resp.CancelledJobs,
+		DurationMS: resp.TotalDuration.Milliseconds(),
+	}
+}
@@ -0,0 +1,168 @@
+// Package api serves the progress of the clone batches running in the process
+// over HTTP, with a web dashboard, for teams and services following a
+// long-running clone.
+package api
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// Batches are the clone batches the API serves, implemented by
+// usecases.CloneRepositoriesUseCase
+type Batches interface {
+	BatchProgress() []cloning.BatchSnapshot
+	Batch(id string) *usecases.CloneBatch
+	FinishedProgress(id string) *cloning.Progress // Last known progress of a finished batch, nil when unknown
+}
+
+// Config holds API configuration
+type Config struct {
+	Batches Batches
+	Status  func() string // Provider status, e.g. the rate limit budget; optional
+	Logger  shared.Logger
+}
+
+// batchView is the JSON form of the progress of a batch
+type batchView struct {
+	ID       string             `json:"id"`
+	Progress *cloning.Progress  `json:"progress"`
+	Failures []cloning.JobEvent `json:"failures,omitempty"` // Final events of the failed jobs
+}
+
+// newBatchView returns the view of the batch with the given progress
+func newBatchView(batches Batches, id string, progress *cloning.Progress) batchView {
+	view := batchView{ID: id, Progress: progress}
+	if batch := batches.Batch(id); batch != nil {
+		view.Failures = batch.Failures()
+	}
+	return view
+}
+
+// statusView is the JSON form of the provider status
+type statusView struct {
+	Status string `json:"status"` // Empty when unknown
+}
+
+// dashboard holds the static files of the web dashboard
+//
+//go:embed dashboard
+var dashboard embed.FS
+
+// NewHandler returns the handler of the API and the web dashboard:
+//
+//	GET /                     web dashboard of the running batches
+//	GET /status               provider status, e.g. the rate limit budget
+//	GET /batches              progress and failures of every running batch
+//	GET /batches/{id}         progress and failures of a batch, the last known
+//	                          progress once it finished
+//	GET /batches/{id}/events  server-sent events of a batch, see streamEvents
+func NewHandler(config *Config) http.Handler {
+	batches := config.Batches
+	mux := http.NewServeMux()
+
+	static, _ := fs.Sub(dashboard, "dashboard")
+	mux.Handle("GET /{$}", http.FileServerFS(static))
+
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		view := statusView{}
+		if config.Status != nil {
+			view.Status = config.Status()
+		}
+		writeJSON(w, view)
+	})
+
+	mux.HandleFunc("GET /batches", func(w http.ResponseWriter, r *http.Request) {
+		views := []batchView{}
+		for _, snapshot := range batches.BatchProgress() {
+			views = append(views, newBatchView(batches, snapshot.ID, snapshot.Progress))
+		}
+		writeJSON(w, views)
+	})
+
+	mux.HandleFunc("GET /batches/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		if batch := batches.Batch(id); batch != nil {
+			writeJSON(w, newBatchView(batches, batch.ID, batch.Progress()))
+			return
+		}
+		if progress := batches.FinishedProgress(id); progress != nil {
+			writeJSON(w, batchView{ID: id, Progress: progress})
+			return
+		}
+		http.Error(w, "batch not found", http.StatusNotFound)
+	})
+
+	mux.HandleFunc("GET /batches/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		batch := batches.Batch(r.PathValue("id"))
+		if batch == nil {
+			http.Error(w, "batch not found", http.StatusNotFound)
+			return
+		}
+		streamEvents(w, r, batch)
+	})
+
+	return mux
+}
+
+// writeJSON writes a value as the JSON body of a response
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// Server serves the API in the background
+type Server struct {
+	server   *http.Server
+	listener net.Listener
+	logger   shared.Logger
+}
+
+// Start listens on addr, e.g. :8080 or 127.0.0.1:0, and serves the API until
+// Close
+func Start(addr string, config *Config) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s := &Server{
+		server:   &http.Server{Handler: NewHandler(config), ReadHeaderTimeout: 10 * time.Second},
+		listener: listener,
+		logger:   config.Logger,
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("API server stopped", shared.ErrorField(err))
+		}
+	}()
+	s.logger.Info("Serving the clone API", shared.StringField("address", s.Addr()))
+	return s, nil
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the server, giving open requests a few seconds to finish;
+// event streams end with their batch
+func (s *Server) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		return s.server.Close()
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/application/services"
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// heldBackend holds every clone until release is closed, failing the clones
+// of the repositories named in fail at once
+type heldBackend struct {
+	release chan struct{}
+	fail    string
+}
+
+func (heldBackend) Name() string { return "held" }
+
+func (b heldBackend) CloneRepository(ctx context.Context, job *cloning.CloneJob) error {
+	return b.CloneRepositoryWithProgress(ctx, job, nil)
+}
+
+func (b heldBackend) CloneRepositoryWithProgress(ctx context.Context, job *cloning.CloneJob, _ cloning.TransferProgressFunc) error {
+	if job.Repository.Name == b.fail {
+		return &git.RepositoryNotFoundError{Message: "repository not found"}
+	}
+	select {
+	case <-b.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (heldBackend) UpdateClone(context.Context, *cloning.CloneJob) error { return nil }
+
+func (heldBackend) GetRepositorySize(string) (int64, error) { return 0, nil }
+
+func (heldBackend) Validate(context.Context) error { return nil }
+
+// startBatch starts a batch of two held clones, keeping its progress in
+// history when one is given
+func startBatch(t *testing.T, backend heldBackend, history ...usecases.ProgressHistory) *usecases.CloneRepositoriesUseCase {
+	t.Helper()
+	logger := logging.NewNoOpLogger()
+	pool, err := concurrency.NewWorkerPool(&concurrency.WorkerPoolConfig{MaxWorkers: 2, Backend: backend, Logger: logger})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pool.ForceClose() })
+
+	var repos []*repository.Repository
+	for i := 1; i <= 2; i++ {
+		name := fmt.Sprintf("repo-%d", i)
+		repo, err := repository.NewRepository(repository.RepositoryID(i), name, "https://github.com/owner/"+name+".git", "owner", false, 0, "main")
+		require.NoError(t, err)
+		repos = append(repos, repo)
+	}
+
+	uc := usecases.NewCloneRepositoriesUseCase(pool, cloning.NewDomainCloneService(logger), logger)
+	if len(history) > 0 {
+		uc.SetProgressHistory(history[0])
+	}
+	_, err = uc.Start(context.Background(), &usecases.CloneRepositoriesRequest{
+		Repositories:  repos,
+		BaseDirectory: t.TempDir(),
+		BatchID:       "nightly",
+	})
+	require.NoError(t, err)
+	return uc
+}
+
+func TestHandler_Progress(t *testing.T) {
+	backend := heldBackend{release: make(chan struct{})}
+	uc := startBatch(t, backend)
+	defer close(backend.release)
+
+	server := httptest.NewServer(NewHandler(&Config{Batches: uc}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/batches")
+	require.NoError(t, err)
+	var views []batchView
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&views))
+	_ = resp.Body.Close()
+	require.Len(t, views, 1)
+	assert.Equal(t, "nightly", views[0].ID)
+	assert.Equal(t, 2, views[0].Progress.Total)
+
+	resp, err = http.Get(server.URL + "/batches/nightly")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/batches/missing/events")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_FinishedBatch(t *testing.T) {
+	history := services.NewProgressService(&services.ProgressServiceConfig{Logger: logging.NewNoOpLogger()})
+	defer func() { _ = history.Close() }()
+	backend := heldBackend{release: make(chan struct{})}
+	uc := startBatch(t, backend, history)
+	batch := uc.Batch("nightly")
+	close(backend.release)
+	_, err := batch.Wait()
+	require.NoError(t, err)
+
+	server := httptest.NewServer(NewHandler(&Config{Batches: uc}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/batches/nightly")
+	require.NoError(t, err)
+	var view batchView
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&view))
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "finished batches are served from the history")
+	assert.Equal(t, 2, view.Progress.Completed)
+
+	resp, err = http.Get(server.URL + "/batches/missing")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_Events(t *testing.T) {
+	backend := heldBackend{release: make(chan struct{})}
+	uc := startBatch(t, backend)
+
+	server := httptest.NewServer(NewHandler(&Config{Batches: uc}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/batches/nightly/events")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	close(backend.release) // Subscribed once the headers are sent
+
+	var events []string
+	var done batchSummary
+	completed := 0
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(nil, 1<<20)
+	event := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+			events = append(events, event)
+		case strings.HasPrefix(line, "data: ") && event == "job":
+			var job cloning.JobEvent
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &job))
+			if job.Type == cloning.JobEventCompleted {
+				completed++
+			}
+		case strings.HasPrefix(line, "data: ") && event == "done":
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &done))
+		}
+	}
+
+	require.NotEmpty(t, events)
+	assert.Equal(t, "progress", events[0])
+	assert.Equal(t, "done", events[len(events)-1], "the stream ends with the batch")
+	assert.Equal(t, 2, completed)
+	assert.Equal(t, 2, done.Completed)
+	assert.Equal(t, 2, done.Total)
+}
+
+func TestHandler_EventsClientGone(t *testing.T) {
+	backend := heldBackend{release: make(chan struct{})}
+	uc := startBatch(t, backend)
+	defer close(backend.release)
+	batch := uc.Batch("nightly")
+	before := batch.Subscribers()
+
+	server := httptest.NewServer(NewHandler(&Config{Batches: uc}))
+	defer server.Close()
+
+	// Reconnecting clients must not pile up subscriptions
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/batches/nightly/events", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, before+2, batch.Subscribers(), "a stream subscribes to progress and job events")
+
+		cancel()
+		_ = resp.Body.Close()
+		assert.Eventually(t, func() bool { return batch.Subscribers() == before }, time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestHandler_Dashboard(t *testing.T) {
+	backend := heldBackend{release: make(chan struct{}), fail: "repo-1"}
+	uc := startBatch(t, backend)
+	defer close(backend.release)
+
+	server := httptest.NewServer(NewHandler(&Config{
+		Batches: uc,
+		Status:  func() string { return "GitHub API: 4990/5000 requests left" },
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/")
+	require.NoError(t, err)
+	page, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(page), "<title>repocloner</title>")
+
+	resp, err = http.Get(server.URL + "/status")
+	require.NoError(t, err)
+	var status statusView
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	_ = resp.Body.Close()
+	assert.Equal(t, "GitHub API: 4990/5000 requests left", status.Status)
+
+	assert.Eventually(t, func() bool {
+		resp, err := http.Get(server.URL + "/batches/nightly")
+		if err != nil {
+			return false
+		}
+		defer func() { _ = resp.Body.Close() }()
+		var view batchView
+		if json.NewDecoder(resp.Body).Decode(&view) != nil || len(view.Failures) == 0 {
+			return false
+		}
+		return view.Failures[0].Repository == "owner/repo-1" && view.Failures[0].Error == "repository not found"
+	}, 5*time.Second, 10*time.Millisecond, "failed jobs are listed while the batch runs")
+}
@@ -18,6 +18,8 @@ import (
 	"github.com/italoag/repocloner/internal/infrastructure/git"
 	"github.com/italoag/repocloner/internal/infrastructure/github"
 	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/infrastructure/providers"
+	"github.com/italoag/repocloner/internal/version"
 )
 
 // Command represents a CLI command
@@ -80,7 +82,7 @@ func (app *CLIApplication) Execute(args []string) error {
 
 // showUsage shows general usage information
 func (app *CLIApplication) showUsage() error {
-	fmt.Println("repocloner v0.2.0 - Concurrent GitHub Repository Cloner")
+	fmt.Println(version.Title() + " - Concurrent GitHub Repository Cloner")
 	fmt.Println()
 	fmt.Println("USAGE:")
 	fmt.Println("  repocloner <command> [arguments]")
@@ -280,9 +282,9 @@ func (c *CloneCommand) executeClone(ctx context.Context, config *CloneConfig) er
 	// Initialize GitHub client
 	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{
 		Token:       config.Token,
-		UserAgent:   "repocloner/2.0",
+		UserAgent:   version.UserAgent(),
 		Timeout:     30 * time.Second,
-		RateLimiter: github.NewTokenBucketRateLimiter(5000),
+		RateLimiter: github.NewRateLimiter(config.Token != ""),
 		Logger:      logger.With(shared.StringField("component", "github")),
 	})
 
@@ -305,7 +307,7 @@ func (c *CloneCommand) executeClone(ctx context.Context, config *CloneConfig) er
 		MaxWorkers: config.Concurrency,
 		MaxRetries: 3,
 		RetryDelay: 5 * time.Second,
-		GitClient:  gitClient,
+		Backend:    gitClient,
 		Logger:     logger.With(shared.StringField("component", "worker_pool")),
 	})
 	if err != nil {
@@ -318,7 +320,7 @@ func (c *CloneCommand) executeClone(ctx context.Context, config *CloneConfig) er
 	}()
 
 	// Initialize services
-	fetchUseCase := usecases.NewFetchRepositoriesUseCase(githubClient, nil, logger)
+	fetchUseCase := usecases.NewFetchRepositoriesUseCase(providers.Default(githubClient, nil, nil), logger)
 	cloningService, err := services.NewCloningService(&services.CloningServiceConfig{
 		WorkerPool: workerPool,
 		GitClient:  gitClient,
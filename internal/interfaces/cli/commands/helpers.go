@@ -13,6 +13,9 @@ import (
 	"github.com/italoag/repocloner/internal/domain/shared"
 	"github.com/italoag/repocloner/internal/infrastructure/github"
 	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/infrastructure/providers"
+	"github.com/italoag/repocloner/internal/interfaces/output"
+	"github.com/italoag/repocloner/internal/version"
 )
 
 // ListCommand handles repository listing operations
@@ -50,17 +53,21 @@ OPTIONS:
   --skip-forks            Skip forked repositories (default: true)
   --include-forks         Include forked repositories
   --format <format>       Output format ('table', 'json', 'csv') (default: table)
-  --sort <field>          Sort by field ('name', 'size', 'updated') (default: name)
+  --sort <keys>           Sort keys in order of precedence, - for descending
+                          (name, size, updated, pushed, ...) (default: name)
   --limit <n>             Limit number of results (default: no limit)
   --min-size <bytes>      Minimum repository size in bytes
   --max-size <bytes>      Maximum repository size in bytes
   --language <lang>       Filter by programming language
   --updated-after <date>  Filter repositories updated after date (YYYY-MM-DD)
+  --columns <names>       Columns to print, comma-separated (e.g. name,size,language)
+  --no-header             Leave out the table header and totals and the CSV header row
 
 EXAMPLES:
   repocloner list user octocat
   repocloner list org microsoft --format json
   repocloner list user torvalds --include-forks --sort size
+  repocloner list org kubernetes --sort language,-size
   repocloner list org kubernetes --language go --limit 20
 `
 }
@@ -83,12 +90,14 @@ type ListConfig struct {
 	Token        string
 	SkipForks    bool
 	Format       string
-	Sort         string
+	Sort         []repository.SortKey
 	Limit        int
 	MinSize      int64
 	MaxSize      int64
 	Language     string
 	UpdatedAfter time.Time
+	Columns      []string
+	NoHeader     bool
 }
 
 // parseListArgs parses list command arguments
@@ -96,7 +105,7 @@ func (l *ListCommand) parseListArgs(args []string) (*ListConfig, error) {
 	config := &ListConfig{
 		SkipForks: true,
 		Format:    "table",
-		Sort:      "name",
+		Sort:      []repository.SortKey{{Field: repository.SortByName}},
 		Limit:     -1,
 		MaxSize:   -1,
 		Token:     os.Getenv("GITHUB_TOKEN"),
@@ -133,11 +142,11 @@ func (l *ListCommand) parseListArgs(args []string) (*ListConfig, error) {
 			if i+1 >= len(args) {
 				return nil, fmt.Errorf("--sort requires a value")
 			}
-			sort := strings.ToLower(args[i+1])
-			if sort != "name" && sort != "size" && sort != "updated" {
-				return nil, fmt.Errorf("invalid sort field: %s (must be 'name', 'size', or 'updated')", args[i+1])
+			keys, err := repository.ParseSortKeys(args[i+1])
+			if err != nil {
+				return nil, err
 			}
-			config.Sort = sort
+			config.Sort = keys
 			i += 2
 		case "--limit":
 			if i+1 >= len(args) {
@@ -185,6 +194,15 @@ func (l *ListCommand) parseListArgs(args []string) (*ListConfig, error) {
 			}
 			config.UpdatedAfter = date
 			i += 2
+		case "--columns":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--columns requires a value")
+			}
+			config.Columns = strings.Split(args[i+1], ",")
+			i += 2
+		case "--no-header":
+			config.NoHeader = true
+			i++
 		default:
 			// Positional arguments
 			if config.Type == "" {
@@ -227,14 +245,14 @@ func (l *ListCommand) executeList(ctx context.Context, config *ListConfig) error
 	// Initialize GitHub client
 	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{
 		Token:       config.Token,
-		UserAgent:   "repocloner/2.0",
+		UserAgent:   version.UserAgent(),
 		Timeout:     30 * time.Second,
-		RateLimiter: github.NewTokenBucketRateLimiter(5000),
+		RateLimiter: github.NewRateLimiter(config.Token != ""),
 		Logger:      logger,
 	})
 
 	// Initialize use case
-	fetchUseCase := usecases.NewFetchRepositoriesUseCase(githubClient, nil, logger)
+	fetchUseCase := usecases.NewFetchRepositoriesUseCase(providers.Default(githubClient, nil, nil), logger)
 
 	// Prepare filter
 	filter := repository.NewRepositoryFilter()
@@ -262,7 +280,7 @@ func (l *ListCommand) executeList(ctx context.Context, config *ListConfig) error
 	repositories := fetchResp.Repositories
 
 	// Sort repositories
-	l.sortRepositories(repositories, config.Sort)
+	repository.SortRepositories(repositories, config.Sort)
 
 	// Apply limit
 	if config.Limit > 0 && len(repositories) > config.Limit {
@@ -273,151 +291,35 @@ func (l *ListCommand) executeList(ctx context.Context, config *ListConfig) error
 	return l.displayRepositories(repositories, config)
 }
 
-// sortRepositories sorts repositories by the specified field
-func (l *ListCommand) sortRepositories(repos []*repository.Repository, sortBy string) {
-	// Simple sorting implementation
-	// In a real implementation, you might want to use sort.Slice
-	switch sortBy {
-	case "name":
-		// Already sorted by name from GitHub API
-	case "size":
-		// Sort by size (largest first)
-		for i := 0; i < len(repos)-1; i++ {
-			for j := i + 1; j < len(repos); j++ {
-				if repos[i].Size < repos[j].Size {
-					repos[i], repos[j] = repos[j], repos[i]
-				}
-			}
-		}
-	case "updated":
-		// Sort by update time (most recent first)
-		for i := 0; i < len(repos)-1; i++ {
-			for j := i + 1; j < len(repos); j++ {
-				if repos[i].UpdatedAt.Before(repos[j].UpdatedAt) {
-					repos[i], repos[j] = repos[j], repos[i]
-				}
-			}
-		}
-	}
-}
-
 // displayRepositories displays repositories in the specified format
 func (l *ListCommand) displayRepositories(repos []*repository.Repository, config *ListConfig) error {
-	switch config.Format {
-	case "table":
-		return l.displayTable(repos)
-	case "json":
-		return l.displayJSON(repos)
-	case "csv":
-		return l.displayCSV(repos)
-	default:
-		return fmt.Errorf("unsupported format: %s", config.Format)
+	columns, err := output.Select(output.RepositoryColumns, config.Columns,
+		output.RepositoryColumnNames(config.Format, false))
+	if err != nil {
+		return err
 	}
-}
-
-// displayTable displays repositories in table format
-func (l *ListCommand) displayTable(repos []*repository.Repository) error {
-	if len(repos) == 0 {
-		fmt.Println("No repositories found.")
-		return nil
+	writer, err := output.NewWriter(os.Stdout, columns, output.Options{Format: config.Format, NoHeader: config.NoHeader})
+	if err != nil {
+		return err
 	}
 
-	// Print header
-	fmt.Printf("%-30s %-10s %-15s %-12s %-20s\n", "NAME", "SIZE", "LANGUAGE", "FORK", "UPDATED")
-	fmt.Println(strings.Repeat("-", 87))
-
-	// Print repositories
-	for _, repo := range repos {
-		sizeStr := l.formatSize(repo.Size)
-		language := repo.Language
-		if language == "" {
-			language = "N/A"
-		}
-		fork := "No"
-		if repo.IsFork {
-			fork = "Yes"
-		}
-		updated := repo.UpdatedAt.Format("2006-01-02")
-
-		fmt.Printf("%-30s %-10s %-15s %-12s %-20s\n",
-			l.truncateString(repo.Name, 30),
-			sizeStr,
-			l.truncateString(language, 15),
-			fork,
-			updated)
+	if err := writer.Write(repos); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
 	}
 
-	fmt.Printf("\nTotal: %d repositories\n", len(repos))
-	return nil
-}
-
-// displayJSON displays repositories in JSON format
-func (l *ListCommand) displayJSON(repos []*repository.Repository) error {
-	// Simple JSON output (in production, use json.Marshal)
-	fmt.Println("[")
-	for i, repo := range repos {
-		fmt.Printf("  {\n")
-		fmt.Printf("    \"name\": \"%s\",\n", repo.Name)
-		fmt.Printf("    \"full_name\": \"%s\",\n", repo.GetFullName())
-		fmt.Printf("    \"clone_url\": \"%s\",\n", repo.CloneURL)
-		fmt.Printf("    \"size\": %d,\n", repo.Size)
-		fmt.Printf("    \"language\": \"%s\",\n", repo.Language)
-		fmt.Printf("    \"fork\": %t,\n", repo.IsFork)
-		fmt.Printf("    \"default_branch\": \"%s\",\n", repo.DefaultBranch)
-		fmt.Printf("    \"updated_at\": \"%s\"\n", repo.UpdatedAt.Format(time.RFC3339))
-		if i < len(repos)-1 {
-			fmt.Printf("  },\n")
+	if config.Format == output.FormatTable && !config.NoHeader {
+		if len(repos) == 0 {
+			fmt.Println("No repositories found.")
 		} else {
-			fmt.Printf("  }\n")
+			fmt.Printf("\nTotal: %d repositories\n", len(repos))
 		}
 	}
-	fmt.Println("]")
 	return nil
 }
 
-// displayCSV displays repositories in CSV format
-func (l *ListCommand) displayCSV(repos []*repository.Repository) error {
-	// Print CSV header
-	fmt.Println("name,full_name,clone_url,size,language,fork,default_branch,updated_at")
-
-	// Print repositories
-	for _, repo := range repos {
-		fmt.Printf("%s,%s,%s,%d,%s,%t,%s,%s\n",
-			repo.Name,
-			repo.GetFullName(),
-			repo.CloneURL,
-			repo.Size,
-			repo.Language,
-			repo.IsFork,
-			repo.DefaultBranch,
-			repo.UpdatedAt.Format(time.RFC3339))
-	}
-	return nil
-}
-
-// Helper methods
-
-// formatSize formats size in bytes to human readable format
-func (l *ListCommand) formatSize(bytes int64) string {
-	if bytes < 1024 {
-		return fmt.Sprintf("%dB", bytes)
-	} else if bytes < 1024*1024 {
-		return fmt.Sprintf("%.1fKB", float64(bytes)/1024)
-	} else if bytes < 1024*1024*1024 {
-		return fmt.Sprintf("%.1fMB", float64(bytes)/(1024*1024))
-	} else {
-		return fmt.Sprintf("%.1fGB", float64(bytes)/(1024*1024*1024))
-	}
-}
-
-// truncateString truncates a string to the specified length
-func (l *ListCommand) truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
-}
-
 // HelpCommand provides help information
 type HelpCommand struct {
 	commands map[string]Command
@@ -447,7 +349,7 @@ func (h *HelpCommand) Usage() string {
 func (h *HelpCommand) Execute(ctx context.Context, args []string) error {
 	if len(args) == 0 {
 		// Show general help
-		fmt.Println("repocloner v0.2.0 - Concurrent GitHub Repository Cloner")
+		fmt.Println(version.Title() + " - Concurrent GitHub Repository Cloner")
 		fmt.Println()
 		fmt.Println("USAGE:")
 		fmt.Println("  repocloner <command> [arguments]")
@@ -497,8 +399,11 @@ func (v *VersionCommand) Usage() string {
 
 // Execute executes the version command
 func (v *VersionCommand) Execute(ctx context.Context, args []string) error {
-	fmt.Println("repocloner v0.2.0")
-	fmt.Printf("Go version: %s\n", "go1.24.3")
+	info := version.Get()
+	fmt.Println(version.Title())
+	fmt.Printf("Commit: %s\n", info.Commit)
+	fmt.Printf("Built: %s\n", info.Date)
+	fmt.Printf("Go version: %s\n", info.GoVersion)
 	fmt.Println("Optimized with:")
 	fmt.Println("  - Concurrent processing with ants worker pool")
 	fmt.Println("  - Domain-Driven Design architecture")
@@ -0,0 +1,182 @@
+package fang
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+// GitHub Actions sets GITHUB_STEP_SUMMARY to the Markdown file rendered on
+// the job summary page of the running step
+const actionsSummaryEnv = "GITHUB_STEP_SUMMARY"
+
+// maxSummaryRows bounds each repository list of the job summary, which
+// GitHub limits to 1 MiB per step
+const maxSummaryRows = 500
+
+// finishCloneRun saves the report of a finished clone run, prints its change
+// from the previous run, reports it to GitHub Actions when running in a
+// workflow and applies the failure policy. app is nil when the run has no
+// report to save.
+func finishCloneRun(cmd *cobra.Command, app *Application, resp *usecases.CloneRepositoriesResponse, policy *cloning.FailurePolicy) error {
+	if app != nil && resp != nil {
+		diff, err := app.recordRun(cmd, resp)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to save the run report: %v\n", err)
+		}
+		clonetui.WriteRunDiff(cmd.OutOrStdout(), diff)
+	}
+	if path := os.Getenv(actionsSummaryEnv); path != "" && resp != nil {
+		writeActionsAnnotations(cmd.ErrOrStderr(), clonetui.FailedResults(resp))
+		if err := appendActionsSummary(path, resp); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to write the GitHub Actions job summary: %v\n", err)
+		}
+	}
+	return cloneResultError(resp, policy)
+}
+
+// appendActionsSummary appends the Markdown summary of a run to the job
+// summary file; steps may write several summaries
+func appendActionsSummary(path string, resp *usecases.CloneRepositoriesResponse) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	writeActionsSummary(file, resp)
+	return file.Close()
+}
+
+// writeActionsSummary writes a Markdown table counting the results of a run,
+// followed by the failed, skipped and cloned repositories
+func writeActionsSummary(w io.Writer, resp *usecases.CloneRepositoriesResponse) {
+	byStatus := make(map[cloning.JobStatus][]*cloning.JobResult)
+	for _, result := range resp.Results {
+		byStatus[result.Job.Status] = append(byStatus[result.Job.Status], result)
+	}
+	skipped := len(byStatus[cloning.JobStatusSkipped]) + len(resp.Duplicates)
+
+	fmt.Fprintf(w, "## repocloner clone summary\n\n")
+	if resp.RunID != "" {
+		fmt.Fprintf(w, "Run `%s`\n\n", resp.RunID)
+	}
+	fmt.Fprintf(w, "| Result | Repositories |\n|---|---:|\n")
+	fmt.Fprintf(w, "| ✅ Cloned | %d |\n", len(byStatus[cloning.JobStatusCompleted]))
+	if updated := len(byStatus[cloning.JobStatusUpdated]); updated > 0 {
+		fmt.Fprintf(w, "| 🔄 Updated | %d |\n", updated)
+	}
+	fmt.Fprintf(w, "| ⏭️ Skipped | %d |\n", skipped)
+	fmt.Fprintf(w, "| ❌ Failed | %d |\n", len(byStatus[cloning.JobStatusFailed]))
+	if cancelled := len(byStatus[cloning.JobStatusCancelled]); cancelled > 0 {
+		fmt.Fprintf(w, "| 🛑 Cancelled | %d |\n", cancelled)
+	}
+	fmt.Fprintf(w, "\nFinished in %s.\n", resp.TotalDuration.Round(time.Second))
+	if resp.TooLargeJobs > 0 {
+		fmt.Fprintf(w, "Skipping %d repositories above the size limit saved %s of disk.\n", resp.TooLargeJobs, clonetui.FormatBytes(resp.TooLargeBytes))
+	}
+	if resp.OffBranchJobs > 0 {
+		fmt.Fprintf(w, "Skipped %d repositories whose default branch does not match --default-branch-only.\n", resp.OffBranchJobs)
+	}
+	if resp.LowSpaceJobs > 0 {
+		fmt.Fprintf(w, "Stopped before %d repositories once free disk space fell below --stop-at-free-space.\n", resp.LowSpaceJobs)
+	}
+
+	if failed := byStatus[cloning.JobStatusFailed]; len(failed) > 0 {
+		fmt.Fprintf(w, "\n### ❌ Failed repositories\n\n| Repository | Attempts | Error |\n|---|---:|---|\n")
+		writeSummaryRows(w, len(failed), func(i int) string {
+			job := failed[i].Job
+			return fmt.Sprintf("| %s | %d | %s |", markdownCell(job.Repository.GetFullName()), max(len(job.Attempts), 1), markdownCell(fmt.Sprint(job.Error)))
+		})
+	}
+
+	var renamed []*repository.Repository
+	for _, result := range resp.Results {
+		if result.Job.Repository.RenamedFrom != "" {
+			renamed = append(renamed, result.Job.Repository)
+		}
+	}
+	if len(renamed) > 0 {
+		fmt.Fprintf(w, "\n### ✏️ Renamed repositories\n\nCloned under their current name; update the repository list.\n\n| Listed as | Current name |\n|---|---|\n")
+		writeSummaryRows(w, len(renamed), func(i int) string {
+			return fmt.Sprintf("| %s | %s |", markdownCell(renamed[i].RenamedFrom), markdownCell(renamed[i].GetFullName()))
+		})
+	}
+
+	if skipped > 0 {
+		fmt.Fprintf(w, "\n<details><summary>⏭️ Skipped repositories (%d)</summary>\n\n| Repository | Reason |\n|---|---|\n", skipped)
+		jobs := byStatus[cloning.JobStatusSkipped]
+		writeSummaryRows(w, skipped, func(i int) string {
+			if i < len(jobs) {
+				job := jobs[i].Job
+				reason := strings.TrimPrefix(fmt.Sprint(job.Error), "skipped: ")
+				return fmt.Sprintf("| %s | %s |", markdownCell(job.Repository.GetFullName()), markdownCell(reason))
+			}
+			duplicate := resp.Duplicates[i-len(jobs)]
+			return fmt.Sprintf("| %s | %s |", markdownCell(duplicate.Repository.GetFullName()), markdownCell("same remote as "+duplicate.Of))
+		})
+		fmt.Fprintf(w, "\n</details>\n")
+	}
+
+	cloned := slices.Concat(byStatus[cloning.JobStatusCompleted], byStatus[cloning.JobStatusUpdated])
+	if len(cloned) > 0 {
+		fmt.Fprintf(w, "\n<details><summary>✅ Cloned repositories (%d)</summary>\n\n| Repository | Path |\n|---|---|\n", len(cloned))
+		writeSummaryRows(w, len(cloned), func(i int) string {
+			job := cloned[i].Job
+			return fmt.Sprintf("| %s | `%s` |", markdownCell(job.Repository.GetFullName()), strings.ReplaceAll(job.GetDestinationPath(), "`", "'"))
+		})
+		fmt.Fprintf(w, "\n</details>\n")
+	}
+	fmt.Fprintln(w)
+}
+
+// writeSummaryRows writes up to maxSummaryRows table rows and counts the rest
+func writeSummaryRows(w io.Writer, n int, row func(i int) string) {
+	for i := range min(n, maxSummaryRows) {
+		fmt.Fprintln(w, row(i))
+	}
+	if n > maxSummaryRows {
+		fmt.Fprintf(w, "\n… and %d more\n", n-maxSummaryRows)
+	}
+}
+
+// markdownCell makes text safe inside a Markdown table cell
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// writeActionsAnnotations prints an ::error workflow command per failed
+// repository, shown as annotations of the workflow run. The runner reads
+// workflow commands from stderr too, which keeps stdout free for --output json.
+func writeActionsAnnotations(w io.Writer, failed []*cloning.JobResult) {
+	for _, result := range failed {
+		repo := result.Job.Repository
+		fmt.Fprintf(w, "::error title=%s::%s\n",
+			escapeAnnotationProperty("Clone failed: "+repo.GetFullName()),
+			escapeAnnotationData(annotationMessage(repo, result.Job.Error)))
+	}
+}
+
+// annotationMessage describes a failed clone
+func annotationMessage(repo *repository.Repository, err error) string {
+	return fmt.Sprintf("%s could not be cloned: %v", repo.GetFullName(), err)
+}
+
+// escapeAnnotationData escapes the message of a workflow command
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a property value of a workflow command
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,94 @@
+package fang
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+func actionsTestResponse(t *testing.T) *usecases.CloneRepositoriesResponse {
+	newResult := func(name string, status cloning.JobStatus, jobErr error) *cloning.JobResult {
+		repo, err := repository.NewRepository(1, name, "https://github.com/acme/"+name+".git", "acme", false, 0, "main")
+		require.NoError(t, err)
+		job := cloning.NewCloneJob(repo, "/backup", nil)
+		job.Status = status
+		job.Error = jobErr
+		return &cloning.JobResult{Job: job}
+	}
+	kept, err := repository.NewRepository(2, "copy", "https://github.com/acme/copy.git", "acme", false, 0, "main")
+	require.NoError(t, err)
+
+	return &usecases.CloneRepositoriesResponse{
+		TotalJobs:     4,
+		TotalDuration: 90 * time.Second,
+		Results: []*cloning.JobResult{
+			newResult("api", cloning.JobStatusCompleted, nil),
+			newResult("web", cloning.JobStatusFailed, errors.New("fatal: bad | pipe\nsecond line")),
+			newResult("docs", cloning.JobStatusSkipped, errors.New("skipped: repository already exists")),
+			newResult("old", cloning.JobStatusUpdated, nil),
+		},
+		Duplicates: []repository.Duplicate{{Repository: kept, Of: "acme/api"}},
+	}
+}
+
+func TestWriteActionsSummary(t *testing.T) {
+	var out bytes.Buffer
+	writeActionsSummary(&out, actionsTestResponse(t))
+	summary := out.String()
+
+	assert.Contains(t, summary, "## repocloner clone summary")
+	assert.Contains(t, summary, "| ✅ Cloned | 1 |")
+	assert.Contains(t, summary, "| 🔄 Updated | 1 |")
+	assert.Contains(t, summary, "| ⏭️ Skipped | 2 |")
+	assert.Contains(t, summary, "| ❌ Failed | 1 |")
+	assert.NotContains(t, summary, "Cancelled")
+	assert.Contains(t, summary, "Finished in 1m30s.")
+	assert.Contains(t, summary, `| acme/web | 1 | fatal: bad \| pipe second line |`)
+	assert.Contains(t, summary, "| acme/docs | repository already exists |")
+	assert.Contains(t, summary, "| acme/copy | same remote as acme/api |")
+	assert.Contains(t, summary, "✅ Cloned repositories (2)")
+	assert.Contains(t, summary, "| acme/api | `"+filepath.Join("/backup", "api")+"` |")
+}
+
+func TestWriteActionsAnnotations(t *testing.T) {
+	var out bytes.Buffer
+	writeActionsAnnotations(&out, clonetui.FailedResults(actionsTestResponse(t)))
+	assert.Equal(t,
+		"::error title=Clone failed%3A acme/web::acme/web could not be cloned: fatal: bad | pipe%0Asecond line\n",
+		out.String())
+}
+
+func TestFinishCloneRun_GitHubActions(t *testing.T) {
+	summaryFile := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(summaryFile, []byte("# Earlier step output\n"), 0644))
+	t.Setenv(actionsSummaryEnv, summaryFile)
+
+	cmd := &cobra.Command{}
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+
+	policy, err := cloning.ParseFailurePolicy("any")
+	require.NoError(t, err)
+	err = finishCloneRun(cmd, nil, actionsTestResponse(t), policy)
+	assert.Equal(t, ExitPartialFailure, ExitCode(err), "the failure policy still applies")
+	assert.Empty(t, stdout.String(), "stdout is left to the command output")
+	assert.True(t, strings.HasPrefix(stderr.String(), "::error "))
+
+	data, err := os.ReadFile(summaryFile)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# Earlier step output\n## repocloner clone summary"), "the summary is appended")
+}
@@ -0,0 +1,204 @@
+package fang
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+// ArchiveConfig holds archive command configuration
+type ArchiveConfig struct {
+	Type      repository.RepositoryType
+	Owner     string
+	Format    string // tar.gz, tar or zip
+	Output    string // Archive directory, defaults to <base-dir>/archives
+	Depth     int
+	Existing  ExistingConfig
+	SkipForks bool
+}
+
+// NewArchiveCommand creates the archive command producing snapshot archives
+// of the repositories of a user or organization
+func NewArchiveCommand() *cobra.Command {
+	var config ArchiveConfig
+
+	cmd := &cobra.Command{
+		Use:   "archive [user|org] [owner]",
+		Short: "Clone repositories and export them as archives with checksums",
+		Long: `Clone every repository of a GitHub user or organization into --base-dir and
+export the checked out revision of each one with git archive as
+<owner>-<repo>-<sha>.<format>, for example for compliance snapshots.
+
+Archives are written to --out, <base-dir>/archives by default, together with a
+SHA256SUMS manifest that sha256sum -c verifies. Clones left by an earlier run
+are reused (--existing update refreshes them first) and archives of an
+unchanged revision are kept, so repeated runs only archive what changed.`,
+		Example: `  # Snapshot every repository of an organization as tar.gz
+  repocloner archive org acme
+
+  # Zip archives of the latest commits into a dated directory
+  repocloner archive org acme --format zip --existing update --out snapshots/2025-06-01`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeTypeOwner(githubTypes, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runArchive(cmd, args, &config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Format, "format", usecases.ArchiveTarGz, "Archive format (tar.gz, tar, zip)")
+	completeFlag(cmd, "format", usecases.ArchiveTarGz, usecases.ArchiveTar, usecases.ArchiveZip)
+	cmd.Flags().StringVarP(&config.Output, "out", "o", "", "Archive directory (default: <base-dir>/archives)")
+	cmd.Flags().IntVar(&config.Depth, "depth", 1, "Clone depth for shallow clones (0 for full history)")
+	addExistingFlags(cmd, &config.Existing)
+	cmd.Flags().BoolVar(&config.SkipForks, "skip-forks", true, "Skip forked repositories")
+	cmd.Flags().Bool("include-forks", false, "Include forked repositories (inverse of --skip-forks)")
+
+	return cmd
+}
+
+// runArchive executes the archive command
+func runArchive(cmd *cobra.Command, args []string, config *ArchiveConfig) error {
+	switch strings.ToLower(args[0]) {
+	case "user", "users":
+		config.Type = repository.RepositoryTypeUser
+	case "org", "orgs", "organization":
+		config.Type = repository.RepositoryTypeOrganization
+	default:
+		return fmt.Errorf("invalid repository type '%s', must be 'user' or 'org'", args[0])
+	}
+	config.Owner = args[1]
+
+	if includeForks, _ := cmd.Flags().GetBool("include-forks"); includeForks {
+		config.SkipForks = false
+	}
+
+	format, err := usecases.ParseArchiveFormat(config.Format)
+	if err != nil {
+		return err
+	}
+	if err := config.Existing.validate(); err != nil {
+		return err
+	}
+
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+	if config.Output == "" {
+		config.Output = filepath.Join(globalConfig.BaseDir, "archives")
+	}
+	if err := os.MkdirAll(globalConfig.BaseDir, 0755); err != nil {
+		return fmt.Errorf("failed to create base directory: %w", err)
+	}
+
+	app, _, err := NewApplication(globalConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+	defer func() {
+		if err := app.Close(); err != nil {
+			app.logger.Warn("failed to close application", shared.ErrorField(err))
+		}
+	}()
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Target: %s/%s\n", config.Type, config.Owner)
+	if !globalConfig.HasGitHubAuth() {
+		fmt.Fprintf(out, "Warning: Running without GitHub token (rate limiting may apply)\n")
+	}
+
+	fetchResp, err := app.fetchRepositoriesUseCase.Execute(cmd.Context(),
+		newFetchRequest(config.Type, config.Owner, config.SkipForks))
+	if err != nil {
+		return fmt.Errorf("failed to fetch repositories: %w", err)
+	}
+	if len(fetchResp.Repositories) == 0 {
+		return fmt.Errorf("no repositories found for %s/%s", config.Type, config.Owner)
+	}
+
+	options := cloning.NewDefaultCloneOptions()
+	options.Depth = config.Depth
+	options.RecurseSubmodules = false // git archive leaves submodules out
+	options.CreateOrgDirs = true
+	config.Existing.apply(options)
+
+	fmt.Fprintf(out, "Cloning %d repositories...\n", len(fetchResp.Repositories))
+	cloneTracker := cloning.NewProgressTracker(0)
+	printed := make(chan struct{})
+	go func() {
+		defer close(printed)
+		printJobProgress(out, cloneTracker.Subscribe())
+	}()
+	cloneResp, err := app.cloneRepositoriesUseCase.Execute(cmd.Context(), &usecases.CloneRepositoriesRequest{
+		Repositories:    fetchResp.Repositories,
+		BaseDirectory:   globalConfig.BaseDir,
+		Options:         options,
+		Concurrency:     globalConfig.Concurrency,
+		ProgressTracker: cloneTracker,
+	})
+	<-printed
+	if err != nil {
+		return fmt.Errorf("failed to clone repositories: %w", err)
+	}
+
+	clones := usecases.ClonedJobs(cloneResp.Results)
+	fmt.Fprintf(out, "\nArchiving %d repositories to %s...\n", len(clones), config.Output)
+	archiveTracker := cloning.NewProgressTracker(0)
+	printed = make(chan struct{})
+	go func() {
+		defer close(printed)
+		printJobProgress(out, archiveTracker.Subscribe())
+	}()
+	resp, err := usecases.NewArchiveRepositoriesUseCase(app.logger).Execute(cmd.Context(), &usecases.ArchiveRepositoriesRequest{
+		Clones:          clones,
+		OutputDirectory: config.Output,
+		Format:          format,
+		Concurrency:     globalConfig.Concurrency,
+		ProgressTracker: archiveTracker,
+	})
+	<-printed
+	if err != nil {
+		return fmt.Errorf("failed to archive repositories: %w", err)
+	}
+
+	writeArchiveSummary(out, cloneResp, resp)
+
+	if failed := cloneResp.FailedJobs + resp.Failed; failed > 0 {
+		return &ExitCodeError{
+			Code: ExitPartialFailure,
+			Err: fmt.Errorf("%d repositories failed to clone, %d failed to archive",
+				cloneResp.FailedJobs, resp.Failed),
+		}
+	}
+	return nil
+}
+
+// writeArchiveSummary prints the outcome of an archive run
+func writeArchiveSummary(out io.Writer, cloneResp *usecases.CloneRepositoriesResponse, resp *usecases.ArchiveRepositoriesResponse) {
+	fmt.Fprintf(out, "\nArchives written: %d (%s), unchanged: %d, failed: %d in %s\n",
+		resp.Archived, clonetui.FormatBytes(resp.Bytes), resp.Existing, resp.Failed,
+		resp.TotalDuration.Round(100*time.Millisecond))
+	fmt.Fprintf(out, "Checksums: %s\n", resp.ChecksumsPath)
+
+	for _, result := range cloneResp.Results {
+		if result.Job.Status == cloning.JobStatusFailed {
+			fmt.Fprintf(out, "  %s: clone failed: %v\n", result.Job.Repository.GetFullName(), result.Job.Error)
+		}
+	}
+	for _, archive := range resp.Archives {
+		if archive.Error != nil {
+			fmt.Fprintf(out, "  %s: %v\n", archive.Repository.GetFullName(), archive.Error)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package fang
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+	"github.com/italoag/repocloner/internal/infrastructure/keyring"
+	"github.com/italoag/repocloner/internal/version"
+)
+
+// Providers whose credentials auth login stores in the keyring
+const (
+	authGitHub          = "github"
+	authBitbucket       = "bitbucket"
+	authBitbucketServer = "bitbucket-server"
+	authGitLab          = "gitlab"
+)
+
+// authProviders are the accepted providers of the auth commands
+var authProviders = []string{authGitHub, authBitbucket, authBitbucketServer, authGitLab}
+
+// authTokenFlags maps providers to the flag their stored token stands in for
+var authTokenFlags = map[string]string{
+	authGitHub:          "token",
+	authBitbucket:       "bitbucket-api-token",
+	authBitbucketServer: "bitbucket-server-token",
+	authGitLab:          "gitlab-token",
+}
+
+// credentialStore holds the credentials of auth login, replaced in tests
+var credentialStore keyring.Store = keyring.NewOSStore()
+
+// deviceFlowBaseURL is the host of the GitHub device flow, replaced in tests;
+// empty uses github.com
+var deviceFlowBaseURL = ""
+
+// AuthLoginConfig holds auth login configuration
+type AuthLoginConfig struct {
+	WithToken bool     // Read the token from stdin instead of prompting
+	ClientID  string   // OAuth app of the GitHub device flow; empty prompts for a token
+	Scopes    []string // Scopes requested by the GitHub device flow
+}
+
+// NewAuthCommand creates the auth command storing provider credentials in
+// the OS keyring
+func NewAuthCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Store provider credentials in the OS keyring",
+		Long: `Store provider tokens in the OS keyring (macOS Keychain, Windows Credential
+Manager, or a Secret Service such as GNOME Keyring on Linux) instead of
+environment variables or shell profiles.
+
+Stored credentials are used whenever the matching flag and environment
+variables are not set. --no-keyring ignores them.
+
+Providers: github, bitbucket, bitbucket-server, gitlab`,
+	}
+
+	cmd.AddCommand(newAuthLoginCommand(), newAuthLogoutCommand(), newAuthStatusCommand())
+	return cmd
+}
+
+func newAuthLoginCommand() *cobra.Command {
+	var config AuthLoginConfig
+
+	cmd := &cobra.Command{
+		Use:   "login [provider]",
+		Short: "Store a provider token in the OS keyring",
+		Long: `Store a provider token in the OS keyring.
+
+For github, login runs the OAuth device flow when an OAuth app client ID is
+built in or given with --client-id: it prints a one-time code to enter at
+github.com/login/device and stores the token GitHub issues once authorized.
+Without a client ID, and for the other providers, the token is pasted at a
+prompt or read from stdin with --with-token.`,
+		Example: `  # Authorize in the browser with a one-time code
+  repocloner auth login github
+
+  # Use your own OAuth app for the device flow
+  repocloner auth login github --client-id Iv1.0123456789abcdef
+
+  # Store a Bitbucket API token with its Atlassian account email
+  repocloner auth login bitbucket --bitbucket-email me@example.com
+
+  # Read the token from stdin
+  echo "$TOKEN" | repocloner auth login gitlab --with-token`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.FixedCompletions(authProviders, cobra.ShellCompDirectiveNoFileComp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuthLogin(cmd, args[0], &config)
+		},
+	}
+
+	cmd.Flags().BoolVar(&config.WithToken, "with-token", false, "Read the token from stdin")
+	cmd.Flags().StringVar(&config.ClientID, "client-id", github.DeviceFlowClientID, "OAuth app client ID of the GitHub device flow")
+	cmd.Flags().StringSliceVar(&config.Scopes, "scopes", github.DefaultDeviceFlowScopes, "Scopes requested by the GitHub device flow")
+	return cmd
+}
+
+func newAuthLogoutCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "logout [provider]",
+		Short:             "Remove a provider token from the OS keyring",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cobra.FixedCompletions(authProviders, cobra.ShellCompDirectiveNoFileComp),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			provider, err := parseAuthProvider(args[0])
+			if err != nil {
+				return err
+			}
+
+			err = credentialStore.Delete(provider)
+			if errors.Is(err, keyring.ErrNotFound) {
+				fmt.Fprintf(cmd.OutOrStdout(), "No %s credentials stored\n", provider)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %s credentials from the keyring\n", provider)
+			return nil
+		},
+	}
+}
+
+func newAuthStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show which provider credentials are stored in the OS keyring",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			writeAuthStatus(cmd.OutOrStdout())
+			return nil
+		},
+	}
+}
+
+// parseAuthProvider validates the provider argument of the auth commands
+func parseAuthProvider(value string) (string, error) {
+	provider := strings.ToLower(strings.TrimSpace(value))
+	if !slices.Contains(authProviders, provider) {
+		return "", fmt.Errorf("invalid provider %q, must be one of %s", value, strings.Join(authProviders, ", "))
+	}
+	return provider, nil
+}
+
+// runAuthLogin reads a token and stores it in the keyring. Bitbucket tokens
+// are stored with the account they belong to, taken from the Bitbucket flags
+// or asked for.
+func runAuthLogin(cmd *cobra.Command, value string, config *AuthLoginConfig) error {
+	provider, err := parseAuthProvider(value)
+	if err != nil {
+		return err
+	}
+
+	if provider == authGitHub && !config.WithToken && config.ClientID != "" {
+		token, err := runDeviceFlow(cmd, config)
+		if err != nil {
+			return err
+		}
+		return storeCredential(cmd, provider, &keyring.Credential{Token: token})
+	}
+
+	in := bufio.NewReader(cmd.InOrStdin())
+	out := cmd.ErrOrStderr()
+	interactive := !config.WithToken && stdinIsTerminal()
+	if !config.WithToken && !interactive {
+		return fmt.Errorf("cannot prompt for a token without a terminal, pipe it with --with-token")
+	}
+
+	credential := &keyring.Credential{}
+	switch provider {
+	case authBitbucket:
+		credential.Email, _ = cmd.Flags().GetString("bitbucket-email")
+		credential.Username, _ = cmd.Flags().GetString("bitbucket-username")
+		if credential.Email == "" && credential.Username == "" {
+			if !interactive {
+				return fmt.Errorf("bitbucket login needs --bitbucket-email (API token) or --bitbucket-username (app password)")
+			}
+			fmt.Fprint(out, "Atlassian account email: ")
+			if credential.Email, err = readLine(in); err != nil {
+				return err
+			}
+		}
+	case authBitbucketServer:
+		credential.Username, _ = cmd.Flags().GetString("bitbucket-server-username")
+	}
+
+	if interactive {
+		fmt.Fprintf(out, "Paste your %s token: ", provider)
+		token, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(out)
+		if err != nil {
+			return fmt.Errorf("failed to read token: %w", err)
+		}
+		credential.Token = strings.TrimSpace(string(token))
+	} else {
+		data, err := io.ReadAll(in)
+		if err != nil {
+			return fmt.Errorf("failed to read token: %w", err)
+		}
+		credential.Token = strings.TrimSpace(string(data))
+	}
+	if credential.Token == "" {
+		return fmt.Errorf("no token given")
+	}
+
+	return storeCredential(cmd, provider, credential)
+}
+
+// runDeviceFlow authorizes repocloner through the GitHub OAuth device flow:
+// the user enters the printed code in the browser while the token is polled
+// for. Proxy and CA settings apply to the requests.
+func runDeviceFlow(cmd *cobra.Command, config *AuthLoginConfig) (string, error) {
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	flow := github.NewDeviceFlow(&github.DeviceFlowConfig{
+		ClientID:  config.ClientID,
+		Scopes:    config.Scopes,
+		BaseURL:   deviceFlowBaseURL,
+		UserAgent: version.UserAgent(),
+		Transport: globalConfig.Transport,
+	})
+	code, err := flow.RequestCode(cmd.Context())
+	if err != nil {
+		return "", err
+	}
+
+	out := cmd.ErrOrStderr()
+	fmt.Fprintf(out, "Copy your one-time code: %s\n", code.UserCode)
+	fmt.Fprintf(out, "Open %s in your browser to authorize repocloner\n", code.VerificationURI)
+	fmt.Fprintln(out, "Waiting for authorization...")
+	return flow.PollToken(cmd.Context(), code)
+}
+
+// storeCredential stores a credential read by auth login in the keyring
+func storeCredential(cmd *cobra.Command, provider string, credential *keyring.Credential) error {
+	if err := credentialStore.Set(provider, credential); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Stored %s credentials in the keyring, run `repocloner doctor` to check them\n", provider)
+	return nil
+}
+
+// readLine reads one trimmed line of input
+func readLine(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// writeAuthStatus lists the providers with stored credentials, noting the
+// environment variables that take precedence over them
+func writeAuthStatus(w io.Writer) {
+	for _, provider := range authProviders {
+		credential, err := credentialStore.Get(provider)
+		switch {
+		case errors.Is(err, keyring.ErrNotFound):
+			fmt.Fprintf(w, "%-17s not stored\n", provider)
+			continue
+		case err != nil:
+			fmt.Fprintf(w, "%-17s unavailable: %v\n", provider, err)
+			continue
+		}
+
+		status := "stored"
+		if account := credentialAccount(credential); account != "" {
+			status += " for " + account
+		}
+		if name, _, ok := lookupFlagEnv(authTokenFlags[provider]); ok {
+			status += fmt.Sprintf(" (overridden by %s)", name)
+		}
+		fmt.Fprintf(w, "%-17s %s\n", provider, status)
+	}
+}
+
+// credentialAccount names the account of a credential, if known
+func credentialAccount(credential *keyring.Credential) string {
+	if credential.Email != "" {
+		return credential.Email
+	}
+	return credential.Username
+}
+
+// applyKeyringCredentials fills the tokens that no flag or environment
+// variable set from the keyring. An unavailable keyring, e.g. without a
+// Secret Service on a headless Linux host, leaves the config unchanged.
+func applyKeyringCredentials(cmd *cobra.Command, config *Config) {
+	if noKeyring, _ := cmd.Flags().GetBool("no-keyring"); noKeyring {
+		return
+	}
+
+	if config.Token == "" && !config.UsesGitHubApp() {
+		if credential := storedCredential(authGitHub); credential != nil {
+			config.Token = credential.Token
+		}
+	}
+	if config.BitbucketAPIToken == "" {
+		if credential := storedCredential(authBitbucket); credential != nil {
+			config.BitbucketAPIToken = credential.Token
+			if config.BitbucketEmail == "" {
+				config.BitbucketEmail = credential.Email
+			}
+			if config.BitbucketUsername == "" {
+				config.BitbucketUsername = credential.Username
+			}
+		}
+	}
+	if config.BitbucketServerToken == "" {
+		if credential := storedCredential(authBitbucketServer); credential != nil {
+			config.BitbucketServerToken = credential.Token
+			if config.BitbucketServerUsername == "" {
+				config.BitbucketServerUsername = credential.Username
+			}
+		}
+	}
+	if config.GitLabToken == "" {
+		if credential := storedCredential(authGitLab); credential != nil {
+			config.GitLabToken = credential.Token
+		}
+	}
+}
+
+// storedCredential returns the stored credential of a provider, or nil
+func storedCredential(provider string) *keyring.Credential {
+	credential, err := credentialStore.Get(provider)
+	if err != nil {
+		return nil
+	}
+	return credential
+}
@@ -0,0 +1,185 @@
+package fang
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/infrastructure/keyring"
+)
+
+// memoryStore keeps credentials in memory instead of the OS keyring
+type memoryStore map[string]*keyring.Credential
+
+func (s memoryStore) Get(provider string) (*keyring.Credential, error) {
+	if credential, ok := s[provider]; ok {
+		return credential, nil
+	}
+	return nil, keyring.ErrNotFound
+}
+
+func (s memoryStore) Set(provider string, credential *keyring.Credential) error {
+	s[provider] = credential
+	return nil
+}
+
+func (s memoryStore) Delete(provider string) error {
+	if _, ok := s[provider]; !ok {
+		return keyring.ErrNotFound
+	}
+	delete(s, provider)
+	return nil
+}
+
+// TestMain keeps the tests of the package away from the OS keyring
+func TestMain(m *testing.M) {
+	credentialStore = memoryStore{}
+	os.Exit(m.Run())
+}
+
+// useMemoryStore gives a test its own empty credential store
+func useMemoryStore(t *testing.T) memoryStore {
+	t.Helper()
+	store := memoryStore{}
+	previous := credentialStore
+	credentialStore = store
+	t.Cleanup(func() { credentialStore = previous })
+	return store
+}
+
+// runAuth executes an auth command with stdin and returns its stdout
+func runAuth(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	root := NewRootCommand()
+	root.AddCommand(NewAuthCommand())
+	var out bytes.Buffer
+	root.SetIn(strings.NewReader(stdin))
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{"auth"}, args...))
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestAuthLoginLogout(t *testing.T) {
+	store := useMemoryStore(t)
+
+	_, err := runAuth(t, "ghp_secret\n", "login", "github", "--with-token")
+	require.NoError(t, err)
+	assert.Equal(t, &keyring.Credential{Token: "ghp_secret"}, store[authGitHub])
+
+	_, err = runAuth(t, "api-token", "login", "bitbucket", "--with-token", "--bitbucket-email", "me@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, &keyring.Credential{Token: "api-token", Email: "me@example.com"}, store[authBitbucket])
+
+	_, err = runAuth(t, "api-token", "login", "bitbucket", "--with-token")
+	assert.ErrorContains(t, err, "--bitbucket-email")
+
+	_, err = runAuth(t, "", "login", "gitlab", "--with-token")
+	assert.ErrorContains(t, err, "no token given")
+
+	_, err = runAuth(t, "token", "login", "sourceforge", "--with-token")
+	assert.ErrorContains(t, err, "invalid provider")
+
+	out, err := runAuth(t, "", "logout", "github")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Removed github credentials")
+	assert.NotContains(t, store, authGitHub)
+
+	out, err = runAuth(t, "", "logout", "github")
+	require.NoError(t, err)
+	assert.Contains(t, out, "No github credentials stored")
+}
+
+func TestAuthLoginDeviceFlow(t *testing.T) {
+	store := useMemoryStore(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client", r.PostForm.Get("client_id"))
+		switch r.URL.Path {
+		case "/login/device/code":
+			assert.Equal(t, "repo", r.PostForm.Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"device_code":      "device",
+				"user_code":        "ABCD-1234",
+				"verification_uri": "https://github.com/login/device",
+			})
+		case "/login/oauth/access_token":
+			_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "gho_token"})
+		}
+	}))
+	defer server.Close()
+
+	previous := deviceFlowBaseURL
+	deviceFlowBaseURL = server.URL
+	t.Cleanup(func() { deviceFlowBaseURL = previous })
+
+	out, err := runAuth(t, "", "login", "github", "--client-id", "client", "--scopes", "repo")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Stored github credentials")
+	assert.Equal(t, &keyring.Credential{Token: "gho_token"}, store[authGitHub])
+
+	// --with-token skips the device flow
+	_, err = runAuth(t, "ghp_secret", "login", "github", "--client-id", "client", "--with-token")
+	require.NoError(t, err)
+	assert.Equal(t, &keyring.Credential{Token: "ghp_secret"}, store[authGitHub])
+}
+
+func TestAuthStatus(t *testing.T) {
+	store := useMemoryStore(t)
+	store[authBitbucket] = &keyring.Credential{Token: "api-token", Email: "me@example.com"}
+	store[authGitLab] = &keyring.Credential{Token: "glpat"}
+	t.Setenv("GITLAB_TOKEN", "from-env")
+
+	out, err := runAuth(t, "", "status")
+	require.NoError(t, err)
+	assert.Contains(t, out, "github            not stored")
+	assert.Contains(t, out, "bitbucket         stored for me@example.com\n")
+	assert.Contains(t, out, "gitlab            stored (overridden by GITLAB_TOKEN)")
+}
+
+func TestApplyKeyringCredentials(t *testing.T) {
+	store := useMemoryStore(t)
+	store[authGitHub] = &keyring.Credential{Token: "stored-github"}
+	store[authBitbucket] = &keyring.Credential{Token: "stored-bitbucket", Email: "me@example.com"}
+	store[authGitLab] = &keyring.Credential{Token: "stored-gitlab"}
+	t.Setenv("GITLAB_TOKEN", "env-gitlab")
+	t.Setenv("GITHUB_TOKEN", "")
+
+	config := runGlobalConfig(t)
+	assert.Equal(t, "stored-github", config.Token)
+	assert.Equal(t, "stored-bitbucket", config.BitbucketAPIToken)
+	assert.Equal(t, "me@example.com", config.BitbucketEmail)
+	assert.Equal(t, "env-gitlab", config.GitLabToken, "environment variables win over the keyring")
+
+	config = runGlobalConfig(t, "--no-keyring")
+	assert.Empty(t, config.Token)
+	assert.Empty(t, config.BitbucketAPIToken)
+}
+
+// runGlobalConfig returns the global config of a command run with args
+func runGlobalConfig(t *testing.T, args ...string) *Config {
+	t.Helper()
+	t.Setenv("BITBUCKET_API_TOKEN", "")
+	t.Setenv("BITBUCKET_EMAIL", "")
+
+	var config *Config
+	cmd := &cobra.Command{Use: "test", RunE: func(cmd *cobra.Command, _ []string) error {
+		var err error
+		config, err = getGlobalConfig(cmd)
+		return err
+	}}
+	root := NewRootCommand()
+	root.AddCommand(cmd)
+	root.SetArgs(append([]string{"test"}, args...))
+	require.NoError(t, root.Execute())
+	return config
+}
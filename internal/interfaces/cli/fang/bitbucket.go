@@ -1,32 +1,44 @@
 package fang
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/progress"
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
-	"github.com/italoag/repocloner/internal/application/usecases"
 	"github.com/italoag/repocloner/internal/domain/cloning"
 	"github.com/italoag/repocloner/internal/domain/repository"
 	"github.com/italoag/repocloner/internal/domain/shared"
-	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+	"github.com/italoag/repocloner/internal/version"
 )
 
 // BitbucketCloneConfig holds bitbucket clone command configuration
 type BitbucketCloneConfig struct {
-	Type      repository.RepositoryType
-	Owner     string
-	SkipForks bool
-	Depth     int
-	Branch    string
+	Type              repository.RepositoryType
+	Owner             string
+	SkipForks         bool
+	ForksOnly         bool // Clone only forks
+	Depth             int
+	Branch            string
+	Ref               string
+	Submodules        SubmoduleConfig
+	Sparse            SparseConfig
+	Filter            string // Partial clone filter: blobless, treeless or a git filter spec
+	SkipLFS           bool   // Leave Git LFS pointer files instead of downloading objects
+	Existing          ExistingConfig
+	SizeLimit         SizeLimitConfig
+	DefaultBranchOnly []string // Keep repositories whose default branch matches one of these patterns
+	FailOn            string   // Failure policy: any, none or threshold=N%
+	Order             string   // Scheduling order: fetched, smallest, largest, name or pushed
+	Dedupe            bool     // Skip repositories with an already selected or cloned remote
+	OrgDirs           bool     // Clone into <provider>/<owner>/<repo>
+	Visibility        string   // Keep only public or private repositories
+	Exclusions        ExclusionConfig
+	Yes               bool // Skip the confirmation prompt
 }
 
 // NewBitbucketCloneCommand creates the bitbucket clone subcommand
@@ -34,13 +46,16 @@ func NewBitbucketCloneCommand() *cobra.Command {
 	cloneConfig := &BitbucketCloneConfig{}
 
 	cmd := &cobra.Command{
-		Use:   "bitbucket [user|workspace] <owner>",
-		Short: "Clone repositories from a Bitbucket user or workspace",
+		Use:   "bitbucket [user|workspace|project] <owner>",
+		Short: "Clone repositories from a Bitbucket user, workspace or Server project",
 		Long: `Clone repositories from a Bitbucket user or workspace with concurrent processing.
 
 Supports both individual users and workspaces. Uses Bitbucket's API v2.0 to fetch 
 repository information and performs concurrent cloning with real-time progress tracking.
 
+Self-hosted Bitbucket Server / Data Center instances are supported through the
+project type, which clones every repository of a project key using the REST API 1.0.
+
 Authentication:
   Requires Bitbucket API token and Atlassian account email.
   Set BITBUCKET_API_TOKEN and BITBUCKET_EMAIL environment variables.
@@ -48,6 +63,10 @@ Authentication:
   Your Atlassian account email can be found under Email Aliases on your
   Bitbucket Personal settings page.
 
+  Bitbucket Server requires --bitbucket-server-url (BITBUCKET_SERVER_URL) and
+  an HTTP access token in BITBUCKET_SERVER_TOKEN. Set BITBUCKET_SERVER_USERNAME
+  when using a personal access token.
+
 Examples:
   # Clone all repositories from a user
   bitbucket clone user myusername
@@ -56,8 +75,12 @@ Examples:
   bitbucket clone workspace myworkspace --concurrency 4 --skip-forks
 
   # Clone with specific depth and branch
-  bitbucket clone user myusername --depth 5 --branch develop`,
-		Args: cobra.ExactArgs(2),
+  bitbucket clone user myusername --depth 5 --branch develop
+
+  # Clone every repository of a Bitbucket Server project
+  bitbucket project PROJ --bitbucket-server-url https://bitbucket.example.com`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeTypeOwner(bitbucketTypes, false),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runBitbucketCloneCommand(cmd, args, cloneConfig)
 		},
@@ -66,8 +89,24 @@ Examples:
 	// Command-specific flags
 	cmd.Flags().BoolVar(&cloneConfig.SkipForks, "skip-forks", true, "Skip forked repositories")
 	cmd.Flags().Bool("include-forks", false, "Include forked repositories (inverse of --skip-forks)")
+	addForksOnlyFlag(cmd, &cloneConfig.ForksOnly)
 	cmd.Flags().IntVar(&cloneConfig.Depth, "depth", 1, "Clone depth for shallow clones (0 for full history)")
 	cmd.Flags().StringVar(&cloneConfig.Branch, "branch", "", "Specific branch to clone (default: repository default branch)")
+	cmd.Flags().StringVar(&cloneConfig.Ref, "ref", "", "Tag or commit SHA to check out after cloning")
+	addSubmoduleFlags(cmd, &cloneConfig.Submodules)
+	addSparseFlags(cmd, &cloneConfig.Sparse)
+	addFilterFlag(cmd, &cloneConfig.Filter)
+	addSkipLFSFlag(cmd, &cloneConfig.SkipLFS)
+	addExistingFlags(cmd, &cloneConfig.Existing)
+	addSizeLimitFlag(cmd, &cloneConfig.SizeLimit)
+	addDefaultBranchOnlyFlag(cmd, &cloneConfig.DefaultBranchOnly)
+	addFailOnFlag(cmd, &cloneConfig.FailOn)
+	addOrderFlag(cmd, &cloneConfig.Order)
+	addDedupeFlag(cmd, &cloneConfig.Dedupe)
+	addOrgDirsFlag(cmd, &cloneConfig.OrgDirs)
+	addVisibilityFlag(cmd, &cloneConfig.Visibility)
+	addExclusionFlags(cmd, &cloneConfig.Exclusions)
+	addYesFlag(cmd, &cloneConfig.Yes)
 
 	return cmd
 }
@@ -83,37 +122,68 @@ func runBitbucketCloneCommand(cmd *cobra.Command, args []string, cloneConfig *Bi
 		cloneConfig.Type = repository.RepositoryTypeBitbucketUser
 	case "workspace", "workspaces", "ws":
 		cloneConfig.Type = repository.RepositoryTypeBitbucketWorkspace
+	case "project", "projects":
+		cloneConfig.Type = repository.RepositoryTypeBitbucketProject
 	default:
-		return fmt.Errorf("invalid repository type '%s', must be 'user' or 'workspace'", typeStr)
+		return fmt.Errorf("invalid repository type '%s', must be 'user', 'workspace' or 'project'", typeStr)
 	}
 
 	cloneConfig.Owner = owner
 
 	// Handle include-forks flag (inverse of skip-forks)
-	if includeForks, _ := cmd.Flags().GetBool("include-forks"); includeForks {
+	if includeForks, _ := cmd.Flags().GetBool("include-forks"); includeForks || cloneConfig.ForksOnly {
 		cloneConfig.SkipForks = false
 	}
 
-	// Get global configuration
-	globalConfig, err := getGlobalConfig(cmd)
+	if err := checkConfirmable(cloneConfig.Yes, false); err != nil {
+		return err
+	}
+
+	policy, err := cloning.ParseFailurePolicy(cloneConfig.FailOn)
 	if err != nil {
-		return fmt.Errorf("failed to get global configuration: %w", err)
+		return err
 	}
 
-	// Override Bitbucket credentials from environment if not set
-	if globalConfig.BitbucketAPIToken == "" {
-		globalConfig.BitbucketAPIToken = os.Getenv("BITBUCKET_API_TOKEN")
+	order, err := cloning.ParseJobOrder(cloneConfig.Order)
+	if err != nil {
+		return err
 	}
-	if globalConfig.BitbucketEmail == "" {
-		globalConfig.BitbucketEmail = os.Getenv("BITBUCKET_EMAIL")
+
+	if err := cloneConfig.Existing.validate(); err != nil {
+		return err
 	}
 
-	// Validate Bitbucket credentials are provided
-	if globalConfig.BitbucketAPIToken == "" {
-		return fmt.Errorf("bitbucket API token required: set BITBUCKET_API_TOKEN environment variable")
+	if err := cloneConfig.SizeLimit.validate(); err != nil {
+		return err
 	}
-	if globalConfig.BitbucketEmail == "" {
-		return fmt.Errorf("bitbucket email required: set BITBUCKET_EMAIL environment variable with your Atlassian account email")
+
+	if err := cloning.ValidateBranchPatterns(cloneConfig.DefaultBranchOnly); err != nil {
+		return fmt.Errorf("invalid --default-branch-only: %w", err)
+	}
+
+	if err := cloneConfig.Sparse.load(); err != nil {
+		return err
+	}
+
+	filter, err := cloning.ParseCloneFilter(cloneConfig.Filter)
+	if err != nil {
+		return err
+	}
+	cloneConfig.Filter = string(filter)
+
+	visibility, err := parseVisibilityFlag(cloneConfig.Visibility)
+	if err != nil {
+		return err
+	}
+
+	// Get global configuration
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+
+	if err := validateBitbucketCredentials(cloneConfig.Type, globalConfig); err != nil {
+		return err
 	}
 
 	// Initialize application
@@ -137,229 +207,69 @@ func runBitbucketCloneCommand(cmd *cobra.Command, args []string, cloneConfig *Bi
 		return fmt.Errorf("failed to create base directory: %w", err)
 	}
 
-	// Run TUI application
-	tuiModel := newBitbucketCloneTUIModel(app, cloneConfig, baseDir, tuiLogger)
-	program := tea.NewProgram(tuiModel, tea.WithAltScreen())
-
-	if _, err := program.Run(); err != nil {
-		return fmt.Errorf("TUI application failed: %w", err)
+	fetchReq := newFetchRequest(cloneConfig.Type, cloneConfig.Owner, cloneConfig.SkipForks)
+	selectForks(fetchReq, cloneConfig.ForksOnly)
+	fetchReq.Filter.Visibility = visibility
+	if err := cloneConfig.Exclusions.apply(fetchReq.Filter, baseDir); err != nil {
+		return err
 	}
 
-	return nil
-}
-
-// bitbucketCloneTUIModel represents the TUI state for bitbucket cloning
-type bitbucketCloneTUIModel struct {
-	app          *Application
-	config       *BitbucketCloneConfig
-	baseDir      string
-	logger       *logging.TUILogger
-	progress     progress.Model
-	state        string
-	message      string
-	logs         []string
-	repositories []*repository.Repository
-	done         bool
-	err          error
-}
-
-// newBitbucketCloneTUIModel creates a new TUI model for bitbucket cloning
-func newBitbucketCloneTUIModel(app *Application, config *BitbucketCloneConfig, baseDir string, logger *logging.TUILogger) *bitbucketCloneTUIModel {
-	p := progress.New(progress.WithDefaultGradient())
-
-	return &bitbucketCloneTUIModel{
-		app:      app,
-		config:   config,
-		baseDir:  baseDir,
-		logger:   logger,
-		progress: p,
-		state:    "initializing",
-		logs:     []string{},
+	// Run TUI application
+	resp, err := runClone(cmd, globalConfig, cloneOutputTUI, &clonetui.Config{
+		Title:        version.Title() + " - Bitbucket Repository Cloner",
+		Target:       fmt.Sprintf("%s/%s", cloneConfig.Type, cloneConfig.Owner),
+		Directory:    baseDir,
+		Fetch:        repositoryFetcher(app, fetchReq),
+		FetchTimeout: 5 * time.Minute,
+		CloneUseCase: app.cloneRepositoriesUseCase,
+		Options:      createBitbucketCloneOptions(cloneConfig),
+		Concurrency:  globalConfig.Concurrency,
+		CloneTimeout: 30 * time.Minute,
+		Order:        order,
+		Dedupe:       cloneConfig.Dedupe,
+		Confirm:      !cloneConfig.Yes,
+		Logger:       tuiLogger,
+	})
+	if err != nil {
+		return err
 	}
+	return finishCloneRun(cmd, app, resp, policy)
 }
 
-func (m *bitbucketCloneTUIModel) Init() tea.Cmd {
-	return tea.Batch(
-		m.progress.Init(),
-		bitbucketFetchRepositoriesCmd(m.app, m.config),
-		bitbucketProgressTickCmd(m.app),
-	)
-}
-
-func (m *bitbucketCloneTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		if msg.String() == "ctrl+c" || msg.String() == "q" {
-			m.done = true
-			return m, tea.Quit
-		}
-		return m, nil
-
-	case bitbucketRepositoriesFetchedMsg:
-		m.repositories = msg.repositories
-		m.state = "cloning"
-		m.message = fmt.Sprintf("Found %d repositories. Starting clone...", len(msg.repositories))
-
-		// Start cloning
-		return m, bitbucketStartCloningCmd(m.app, m.repositories, m.baseDir, m.config)
-
-	case bitbucketCloningProgressMsg:
-		if msg.progress != nil {
-			percent := float64(msg.progress.Completed) / float64(msg.progress.Total)
-			m.message = fmt.Sprintf("Cloned %d/%d repositories", msg.progress.Completed, msg.progress.Total)
-			return m, tea.Batch(
-				m.progress.SetPercent(percent),
-				bitbucketProgressTickCmd(m.app),
-			)
-		}
-		return m, bitbucketProgressTickCmd(m.app)
-
-	case bitbucketCloningCompletedMsg:
-		m.state = "completed"
-		m.message = fmt.Sprintf("Cloning completed! %d repositories processed", len(m.repositories))
-		m.done = true
-		return m, tea.Sequence(
-			m.progress.SetPercent(1.0),
-			tea.Tick(2*time.Second, func(t time.Time) tea.Msg { return tea.Quit() }),
-		)
-
-	case bitbucketErrorMsg:
-		m.state = "error"
-		m.message = fmt.Sprintf("Error: %s", msg.err.Error())
-		m.err = msg.err
-		m.done = true
-		return m, tea.Sequence(
-			tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return tea.Quit() }),
-		)
-
-	case progress.FrameMsg:
-		progressModel, cmd := m.progress.Update(msg)
-		m.progress = progressModel.(progress.Model)
-		return m, cmd
-	}
-
-	return m, nil
+// createBitbucketCloneOptions creates clone options from the bitbucket clone config
+func createBitbucketCloneOptions(config *BitbucketCloneConfig) *cloning.CloneOptions {
+	options := cloning.NewDefaultCloneOptions()
+	options.Depth = config.Depth
+	options.Branch = config.Branch
+	options.Ref = config.Ref
+	options.SkipExisting = true
+	options.CreateOrgDirs = config.OrgDirs
+	options.ProviderDirs = config.OrgDirs
+	options.SkipLFS = config.SkipLFS
+	config.Submodules.apply(options)
+	config.Sparse.apply(options)
+	options.Filter = cloning.CloneFilter(config.Filter)
+	config.Existing.apply(options)
+	config.SizeLimit.apply(options)
+	options.DefaultBranches = config.DefaultBranchOnly
+	return options
 }
 
-func (m *bitbucketCloneTUIModel) View() string {
-	if m.done && m.err != nil {
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Render(m.message + "\n")
-	}
-
-	var s strings.Builder
-	s.WriteString(lipgloss.NewStyle().Bold(true).Render("Bitbucket Repository Cloner") + "\n\n")
-	s.WriteString(fmt.Sprintf("Owner: %s (%s)\n", m.config.Owner, m.config.Type.String()))
-	s.WriteString(fmt.Sprintf("Base Directory: %s\n", m.baseDir))
-	s.WriteString(fmt.Sprintf("State: %s\n\n", m.state))
-
-	if m.message != "" {
-		s.WriteString(m.message + "\n\n")
-	}
-
-	if m.state == "cloning" {
-		s.WriteString(m.progress.View() + "\n\n")
-	}
-
-	// Show recent logs
-	logEntries := m.logger.GetLogBuffer().GetRecent(5)
-	if len(logEntries) > 0 {
-		s.WriteString("Recent logs:\n")
-		for _, entry := range logEntries {
-			s.WriteString(fmt.Sprintf("  [%s] %s\n", entry.Level, entry.Message))
+// validateBitbucketCredentials checks that the credentials needed for the
+// repository type are configured
+func validateBitbucketCredentials(repoType repository.RepositoryType, config *Config) error {
+	if repoType.IsBitbucketServerType() {
+		if config.BitbucketServerURL == "" {
+			return fmt.Errorf("bitbucket server URL required: set --bitbucket-server-url or BITBUCKET_SERVER_URL")
 		}
+		return nil
 	}
 
-	if !m.done {
-		s.WriteString("\nPress 'q' or 'ctrl+c' to quit")
-	}
-
-	return s.String()
-}
-
-// Bitbucket-specific TUI message types
-type bitbucketRepositoriesFetchedMsg struct {
-	repositories []*repository.Repository
-}
-
-type bitbucketCloningProgressMsg struct {
-	progress *cloning.Progress
-}
-
-type bitbucketCloningCompletedMsg struct{}
-
-type bitbucketErrorMsg struct {
-	err error
-}
-
-// bitbucketFetchRepositoriesCmd fetches repositories from Bitbucket
-func bitbucketFetchRepositoriesCmd(app *Application, config *BitbucketCloneConfig) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		defer cancel()
-
-		// Create filter for repository fetching
-		filter := repository.NewRepositoryFilter()
-		filter.IncludeForks = !config.SkipForks
-
-		fetchReq := &usecases.FetchRepositoriesRequest{
-			Owner:  config.Owner,
-			Type:   config.Type,
-			Filter: filter,
-		}
-
-		fetchResp, err := app.fetchRepositoriesUseCase.Execute(ctx, fetchReq)
-		if err != nil {
-			return bitbucketErrorMsg{err: fmt.Errorf("failed to fetch repositories: %w", err)}
-		}
-
-		if len(fetchResp.Repositories) == 0 {
-			return bitbucketErrorMsg{err: fmt.Errorf("no repositories found for %s/%s", config.Type, config.Owner)}
-		}
-
-		return bitbucketRepositoriesFetchedMsg{repositories: fetchResp.Repositories}
+	if config.BitbucketAPIToken == "" {
+		return fmt.Errorf("bitbucket API token required: set BITBUCKET_API_TOKEN environment variable")
 	}
-}
-
-// bitbucketStartCloningCmd starts the cloning process
-func bitbucketStartCloningCmd(app *Application, repositories []*repository.Repository, baseDir string, config *BitbucketCloneConfig) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
-		defer cancel()
-
-		// Prepare clone options
-		cloneOptions := &cloning.CloneOptions{
-			Depth:             config.Depth,
-			RecurseSubmodules: true,
-			Branch:            config.Branch,
-			SkipExisting:      true,
-			CreateOrgDirs:     false,
-		}
-
-		// Create clone request
-		cloneReq := &usecases.CloneRepositoriesRequest{
-			Repositories:  repositories,
-			BaseDirectory: baseDir,
-			Options:       cloneOptions,
-			Concurrency:   8, // Default concurrency
-		}
-
-		// Start cloning
-		_, err := app.cloneRepositoriesUseCase.Execute(ctx, cloneReq)
-		if err != nil {
-			return bitbucketErrorMsg{err: fmt.Errorf("failed to clone repositories: %w", err)}
-		}
-
-		return bitbucketCloningCompletedMsg{}
+	if config.BitbucketEmail == "" {
+		return fmt.Errorf("bitbucket email required: set BITBUCKET_EMAIL environment variable with your Atlassian account email")
 	}
-}
-
-// bitbucketProgressTickCmd ticks progress updates
-func bitbucketProgressTickCmd(app *Application) tea.Cmd {
-	return tea.Tick(time.Millisecond*200, func(t time.Time) tea.Msg {
-		if app != nil && app.cloneRepositoriesUseCase != nil {
-			progress := app.cloneRepositoriesUseCase.GetProgress()
-			return bitbucketCloningProgressMsg{progress: progress}
-		}
-		return bitbucketCloningProgressMsg{progress: nil}
-	})
+	return nil
 }
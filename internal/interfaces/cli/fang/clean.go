@@ -0,0 +1,93 @@
+package fang
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+// CleanConfig holds clean command configuration
+type CleanConfig struct {
+	DryRun bool
+	Depth  int
+}
+
+// NewCleanCommand creates the clean command removing the leftovers of failed
+// and interrupted clones from a clone tree
+func NewCleanCommand() *cobra.Command {
+	var config CleanConfig
+
+	cmd := &cobra.Command{
+		Use:   "clean <dir>",
+		Short: "Remove partial clones and empty directories from a clone tree",
+		Long: `Remove what failed and interrupted runs leave below a directory: partial
+clones (<name>.partial-<job>) untouched for an hour, repository directories
+holding no files and the owner directories holding nothing but those. Other
+empty directories are kept.
+
+Clone runs prune the empty directories of their own failed clones when they
+finish; clean tidies up a whole tree, e.g. after runs that were killed.
+--dry-run lists what would be removed.`,
+		Example: `  # Preview what would be removed from ./mirrors
+  repocloner clean ./mirrors --dry-run
+
+  # Remove it
+  repocloner clean ./mirrors`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClean(cmd, args[0], &config)
+		},
+	}
+
+	cmd.Flags().BoolVar(&config.DryRun, "dry-run", false, "List what would be removed without removing it")
+	cmd.Flags().IntVar(&config.Depth, "scan-depth", 3, "Maximum directory depth to search for leftovers")
+
+	return cmd
+}
+
+// runClean executes the clean command
+func runClean(cmd *cobra.Command, dir string, config *CleanConfig) error {
+	logger, err := logging.NewConsoleLogger("warn", false)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	resp, err := usecases.NewCleanWorkspaceUseCase(logger).Execute(cmd.Context(), &usecases.CleanWorkspaceRequest{
+		BaseDirectory: dir,
+		MaxDepth:      config.Depth,
+		DryRun:        config.DryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clean %s: %w", dir, err)
+	}
+
+	writeCleanSummary(cmd.OutOrStdout(), resp)
+	return nil
+}
+
+// writeCleanSummary prints what a clean run removed, or would remove
+func writeCleanSummary(out io.Writer, resp *usecases.CleanWorkspaceResponse) {
+	if resp.Removed() == 0 {
+		fmt.Fprintln(out, "Nothing to clean.")
+		return
+	}
+
+	verb := "Removed"
+	if resp.DryRun {
+		verb = "Would remove"
+	}
+	for _, path := range resp.Partials {
+		fmt.Fprintf(out, "%s partial clone %s\n", verb, path)
+	}
+	for _, path := range resp.EmptyDirs {
+		fmt.Fprintf(out, "%s empty directory %s\n", verb, path)
+	}
+	fmt.Fprintf(out, "\n%s %d partial clones (%s) and %d empty directories\n",
+		verb, len(resp.Partials), clonetui.FormatBytes(resp.PartialBytes), len(resp.EmptyDirs))
+}
@@ -3,15 +3,12 @@ package fang
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/bubbles/progress"
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"github.com/italoag/repocloner/internal/application/usecases"
@@ -19,15 +16,39 @@ import (
 	"github.com/italoag/repocloner/internal/domain/repository"
 	"github.com/italoag/repocloner/internal/domain/shared"
 	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/infrastructure/plugin"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+	"github.com/italoag/repocloner/internal/version"
 )
 
 // CloneConfig holds clone command configuration
 type CloneConfig struct {
-	Type      repository.RepositoryType
-	Owner     string
-	SkipForks bool
-	Depth     int
-	Branch    string
+	Type              repository.RepositoryType
+	Owner             string
+	SkipForks         bool
+	ForksOnly         bool // Clone only forks
+	Depth             int
+	Branch            string
+	Ref               string
+	Submodules        SubmoduleConfig
+	Sparse            SparseConfig
+	Filter            string // Partial clone filter: blobless, treeless or a git filter spec
+	SkipLFS           bool   // Leave Git LFS pointer files instead of downloading objects
+	Existing          ExistingConfig
+	SizeLimit         SizeLimitConfig
+	DefaultBranchOnly []string // Keep repositories whose default branch matches one of these patterns
+	FailOn            string   // Failure policy: any, none or threshold=N%
+	Order             string   // Scheduling order: fetched, smallest, largest, name or pushed
+	Dedupe            bool     // Skip repositories with an already selected or cloned remote
+	OrgDirs           bool     // Clone into <provider>/<owner>/<repo>
+	FromFile          string   // Repository list to clone instead of an owner, "-" for stdin
+	Provider          string   // Provider plugin executable listing the owner
+	Teams             TeamConfig
+	Visibility        string // Keep only public, private or internal repositories
+	Exclusions        ExclusionConfig
+	Also              []string // Additional owners as type:owner
+	Yes               bool     // Skip the confirmation prompt
+	Output            string   // Output mode: tui or json
 }
 
 // NewCloneCommand creates the clone subcommand
@@ -35,8 +56,8 @@ func NewCloneCommand() *cobra.Command {
 	var cloneConfig CloneConfig
 
 	cmd := &cobra.Command{
-		Use:   "clone [type] [owner]",
-		Short: "Clone repositories from a GitHub user or organization",
+		Use:   "clone [type] [owner]... | clone [source-url] | clone --from-file [file] | clone --provider [plugin] [owner]",
+		Short: "Clone repositories from a GitHub user, organization or provider URL",
 		Long: `Clone repositories concurrently from a GitHub user or organization.
 
 The clone command fetches all repositories from the specified user or organization
@@ -48,6 +69,28 @@ Repository Types:
   user, users         Clone from a GitHub user account
   org, orgs           Clone from a GitHub organization
 
+Provider URLs:
+  A single <host>/<owner> argument picks the provider from the host:
+  github.com/<user|org>            GitHub (user or organization is detected)
+  bitbucket.org/<workspace>        Bitbucket Cloud
+  <server-host>/<project-key>      Bitbucket Server (requires --bitbucket-server-url)
+
+Multiple Owners:
+  Repeat [type] [owner] pairs or add --also type:owner to clone several users
+  and organizations in one run. Each owner is cloned into its own directory
+  of the base directory and progress is shown per owner.
+
+Repository Lists:
+  --from-file clones an explicit list instead of listing an owner. Each line
+  holds an owner/repo (GitHub) or a clone URL; blank lines and # comments are
+  ignored. Use - to read the list from stdin.
+
+Provider Plugins:
+  --provider lists the owner with an external executable instead, for SCM
+  systems without a built-in provider. The plugin reads a JSON request
+  ({"protocol":1,"action":"list","owner":"<owner>"}) on stdin and prints one
+  repository per line as JSON (name, owner and clone_url at least) on stdout.
+
 The command supports advanced filtering options, configurable concurrency,
 and comprehensive error handling with detailed progress reporting.`,
 		Example: `  # Clone all repositories from a user
@@ -56,12 +99,43 @@ and comprehensive error handling with detailed progress reporting.`,
   # Clone organization repositories skipping forks
   repocloner clone org microsoft --skip-forks
 
+  # Clone only your forks, each with an upstream remote to its parent
+  repocloner clone user myuser --forks-only
+
   # Clone with custom concurrency and depth
   repocloner clone user torvalds --concurrency 8 --depth 5
 
   # Clone specific branch with custom base directory
-  repocloner clone org kubernetes --branch main --base-dir /tmp/repos`,
-		Args: cobra.ExactArgs(2),
+  repocloner clone org kubernetes --branch main --base-dir /tmp/repos
+
+  # Let the URL pick the provider
+  repocloner clone github.com/kubernetes
+  repocloner clone bitbucket.org/myworkspace
+
+  # Back up only private repositories
+  repocloner clone org myorg --visibility private
+
+  # Skip template repositories and .github-style configuration repositories
+  repocloner clone org myorg --skip-templates --skip-dot-repos
+
+  # Clone only what a team can push to
+  repocloner clone org myorg --team platform --min-permission push
+
+  # Clone several owners in one run
+  repocloner clone org acme --also user:someuser --also org:other-org
+  repocloner clone org acme org other-org
+
+  # Clone an explicit list of repositories
+  repocloner clone --from-file repos.txt
+  gh repo list octocat --limit 50 | repocloner clone --from-file -
+
+  # List the repositories of an internal Gerrit with a provider plugin
+  repocloner clone --provider ./gerrit-provider platform
+
+  # Stream one JSON object per job event to other tooling
+  repocloner clone org myorg --yes --output json | jq 'select(.event == "failed")'`,
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeTypeOwner(githubTypes, true),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCloneCommand(cmd, args, &cloneConfig)
 		},
@@ -70,584 +144,410 @@ and comprehensive error handling with detailed progress reporting.`,
 	// Command-specific flags
 	cmd.Flags().BoolVar(&cloneConfig.SkipForks, "skip-forks", true, "Skip forked repositories")
 	cmd.Flags().Bool("include-forks", false, "Include forked repositories (inverse of --skip-forks)")
+	addForksOnlyFlag(cmd, &cloneConfig.ForksOnly)
 	cmd.Flags().IntVar(&cloneConfig.Depth, "depth", 1, "Clone depth for shallow clones (0 for full history)")
 	cmd.Flags().StringVar(&cloneConfig.Branch, "branch", "", "Specific branch to clone (default: repository default branch)")
+	cmd.Flags().StringVar(&cloneConfig.Ref, "ref", "", "Tag or commit SHA to check out after cloning")
+	addSubmoduleFlags(cmd, &cloneConfig.Submodules)
+	addSparseFlags(cmd, &cloneConfig.Sparse)
+	addFilterFlag(cmd, &cloneConfig.Filter)
+	addSkipLFSFlag(cmd, &cloneConfig.SkipLFS)
+	addExistingFlags(cmd, &cloneConfig.Existing)
+	addSizeLimitFlag(cmd, &cloneConfig.SizeLimit)
+	addDefaultBranchOnlyFlag(cmd, &cloneConfig.DefaultBranchOnly)
+	addFailOnFlag(cmd, &cloneConfig.FailOn)
+	addOrderFlag(cmd, &cloneConfig.Order)
+	addDedupeFlag(cmd, &cloneConfig.Dedupe)
+	addOrgDirsFlag(cmd, &cloneConfig.OrgDirs)
+	addTeamFlags(cmd, &cloneConfig.Teams)
+	addVisibilityFlag(cmd, &cloneConfig.Visibility)
+	addExclusionFlags(cmd, &cloneConfig.Exclusions)
+	cmd.Flags().StringArrayVar(&cloneConfig.Also, "also", nil, "Also clone another owner, as type:owner (e.g. org:acme, user:octocat); repeatable")
+	addYesFlag(cmd, &cloneConfig.Yes)
+	cmd.Flags().StringVar(&cloneConfig.FromFile, "from-file", "", "Clone the repositories listed in a file (owner/repo or URL per line, - for stdin)")
+	cmd.Flags().StringVar(&cloneConfig.Provider, "provider", "", "Provider plugin executable listing the repositories of [owner]")
+	addCloneOutputFlag(cmd, &cloneConfig.Output)
 
 	return cmd
 }
 
 // runCloneCommand executes the clone command logic
 func runCloneCommand(cmd *cobra.Command, args []string, cloneConfig *CloneConfig) error {
-	// Parse and validate arguments
-	typeStr := strings.ToLower(args[0])
-	owner := args[1]
-
-	switch typeStr {
-	case "user", "users":
-		cloneConfig.Type = repository.RepositoryTypeUser
-	case "org", "orgs", "organization":
-		cloneConfig.Type = repository.RepositoryTypeOrganization
-	default:
-		return fmt.Errorf("invalid repository type '%s', must be 'user' or 'org'", typeStr)
+	// Parse and validate arguments; a single argument is a provider URL
+	// resolved once the provider clients are available
+	var listed []*repository.Repository
+	extraOwners, err := parseAlsoFlags(cloneConfig.Also)
+	if err != nil {
+		return err
 	}
 
-	cloneConfig.Owner = owner
+	switch {
+	case cloneConfig.FromFile != "":
+		if len(args) > 0 || len(extraOwners) > 0 || cloneConfig.Provider != "" {
+			return fmt.Errorf("--from-file cannot be combined with a type, owner, source URL, --provider or --also")
+		}
+		repos, err := readRepositoryList(cmd, cloneConfig.FromFile)
+		if err != nil {
+			return err
+		}
+		listed = repos
+	case cloneConfig.Provider != "":
+		if len(args) != 1 || len(extraOwners) > 0 {
+			return fmt.Errorf("--provider requires exactly one owner and cannot be combined with --also")
+		}
+		cloneConfig.Owner = args[0]
+	case len(args) == 0:
+		return fmt.Errorf("requires [type] [owner], a source URL, --from-file or --provider")
+	case len(args) > 1:
+		targets, err := parseOwnerPairs(args)
+		if err != nil {
+			return err
+		}
+		cloneConfig.Type = targets[0].Type
+		cloneConfig.Owner = targets[0].Owner
+		extraOwners = append(targets[1:], extraOwners...)
+	}
 
 	// Handle include-forks flag (inverse of skip-forks)
-	if includeForks, _ := cmd.Flags().GetBool("include-forks"); includeForks {
+	if includeForks, _ := cmd.Flags().GetBool("include-forks"); includeForks || cloneConfig.ForksOnly {
 		cloneConfig.SkipForks = false
 	}
 
-	// Get global configuration
-	globalConfig, err := getGlobalConfig(cmd)
-	if err != nil {
-		return fmt.Errorf("failed to get global configuration: %w", err)
+	if err := validateCloneOutput(cloneConfig.Output); err != nil {
+		return err
 	}
 
-	// Override token from environment if not set
-	if globalConfig.Token == "" {
-		globalConfig.Token = os.Getenv("GITHUB_TOKEN")
+	// The TUI reads keys from the terminal when stdin holds the list; the
+	// JSON output prompts on stdin
+	inputTTY := cloneConfig.FromFile == "-" && cloneConfig.Output != cloneOutputJSON
+	if err := checkConfirmable(cloneConfig.Yes, inputTTY); err != nil {
+		return err
 	}
 
-	// Initialize application
-	app, tuiLogger, err := NewApplication(globalConfig)
+	policy, err := cloning.ParseFailurePolicy(cloneConfig.FailOn)
 	if err != nil {
-		return fmt.Errorf("failed to initialize application: %w", err)
+		return err
 	}
-	defer func() {
-		if err := app.Close(); err != nil {
-			app.logger.Warn("failed to close application", shared.ErrorField(err))
-		}
-	}()
 
-	// Show configuration info before starting TUI
-	fmt.Printf("repocloner v0.2.0 - Concurrent GitHub Repository Cloner\n")
-	fmt.Printf("Target: %s/%s\n", cloneConfig.Type, cloneConfig.Owner)
-	fmt.Printf("Concurrency: %d workers\n", globalConfig.Concurrency)
-	fmt.Printf("Base directory: %s\n", globalConfig.BaseDir)
-	fmt.Printf("Log file: %s\n", tuiLogger.GetLogFile())
-	if globalConfig.Token == "" {
-		fmt.Printf("Warning: Running without GitHub token (rate limiting may apply)\n")
-	}
-	if cloneConfig.SkipForks {
-		fmt.Printf("Skipping forked repositories\n")
+	order, err := cloning.ParseJobOrder(cloneConfig.Order)
+	if err != nil {
+		return err
 	}
-	fmt.Printf("Starting...\n\n")
 
-	// Create destination directory
-	destDir := filepath.Join(globalConfig.BaseDir, cloneConfig.Owner)
-	if err := os.MkdirAll(destDir, 0755); err != nil {
-		return fmt.Errorf("failed to create destination directory: %w", err)
+	if err := cloneConfig.Existing.validate(); err != nil {
+		return err
 	}
 
-	// Start TUI
-	model := newCloneTUIModel(app, cloneConfig, globalConfig, tuiLogger)
-	p := tea.NewProgram(model)
-
-	if _, err := p.Run(); err != nil {
-		app.logger.Error("TUI failed", shared.ErrorField(err))
-		return fmt.Errorf("TUI execution failed: %w", err)
+	if err := cloneConfig.SizeLimit.validate(); err != nil {
+		return err
 	}
 
-	return nil
-}
-
-// TUI Model for clone command
-type cloneTUIModel struct {
-	app            *Application
-	cloneConfig    *CloneConfig
-	globalConfig   *Config
-	repos          []*repository.Repository
-	total          int
-	progress       progress.Model
-	quitting       bool
-	err            error
-	tuiLogger      *logging.TUILogger
-	logHeight      int
-	showLogs       bool
-	actualProgress *cloning.Progress // Store actual progress for display
-}
-
-func newCloneTUIModel(app *Application, cloneConfig *CloneConfig, globalConfig *Config, tuiLogger *logging.TUILogger) cloneTUIModel {
-	return cloneTUIModel{
-		app:          app,
-		cloneConfig:  cloneConfig,
-		globalConfig: globalConfig,
-		progress:     progress.New(progress.WithDefaultGradient()),
-		tuiLogger:    tuiLogger,
-		logHeight:    8, // Show last 8 log entries
-		showLogs:     true,
+	if err := cloning.ValidateBranchPatterns(cloneConfig.DefaultBranchOnly); err != nil {
+		return fmt.Errorf("invalid --default-branch-only: %w", err)
 	}
-}
-
-func (m cloneTUIModel) Init() tea.Cmd {
-	return fetchRepositoriesCmd(m.app, m.cloneConfig)
-}
-
-func (m cloneTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			m.quitting = true
-			return m, tea.Quit
-		case "l":
-			// Toggle log visibility
-			m.showLogs = !m.showLogs
-			return m, nil
-		case "c":
-			// Clear log buffer
-			if m.tuiLogger != nil {
-				m.tuiLogger.GetLogBuffer().Clear()
-			}
-			return m, nil
-		}
-		return m, nil
-
-	case repositoriesMsg:
-		m.repos = msg.repositories
-		m.total = len(msg.repositories)
-		if m.total == 0 {
-			m.err = fmt.Errorf("no repositories found for %s/%s", m.cloneConfig.Type, m.cloneConfig.Owner)
-			m.quitting = true
-			return m, tea.Quit
-		}
-
-		// Start concurrent cloning
-		return m, startCloningCmd(m.app, m.repos, m.globalConfig.BaseDir, m.cloneConfig)
-
-	case cloningStartedMsg:
-		// Start real-time progress tracking
-		return m, realProgressTickCmd(m.app)
-
-	case cloningProgressMsg:
-		// Always continue progress tracking, even if progress is nil
-		if msg.progress != nil {
-			m.actualProgress = msg.progress
-			percent := float64(msg.progress.Completed+msg.progress.Failed+msg.progress.Skipped) / float64(msg.progress.Total)
-			cmd := m.progress.SetPercent(percent)
-
-			// Check for completion with stricter condition - ensure we reach exactly 100%
-			if msg.progress.IsComplete() && percent >= 1.0 {
-				// Force progress bar to 100% and then quit
-				finalCmd := m.progress.SetPercent(1.0)
-				m.quitting = true
-				return m, tea.Batch(finalCmd, tea.Sequence(
-					tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
-						return tea.Quit()
-					}),
-				))
-			}
 
-			// Check for edge case where all jobs are processed but some still show as InProgress
-			processed := msg.progress.Completed + msg.progress.Failed + msg.progress.Skipped
-			if processed >= msg.progress.Total && msg.progress.InProgress > 0 {
-				// This is the bug case - force completion
-				finalCmd := m.progress.SetPercent(1.0)
-				m.quitting = true
-				return m, tea.Batch(finalCmd, tea.Sequence(
-					tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
-						return tea.Quit()
-					}),
-				))
-			}
-
-			return m, tea.Batch(cmd, realProgressTickCmd(m.app), logUpdateCmd())
-		} else {
-			// Progress is nil, but keep tracking
-			return m, tea.Batch(realProgressTickCmd(m.app), logUpdateCmd())
-		}
-
-	case progress.FrameMsg:
-		var cmd tea.Cmd
-		progressModel, cmd := m.progress.Update(msg)
-		m.progress = progressModel.(progress.Model)
-		return m, cmd
-
-	case logUpdateMsg:
-		// Log buffer updated, trigger re-render
-		return m, logUpdateCmd()
-
-	case errorMsg:
-		m.err = msg.err
-		m.quitting = true
-		return m, tea.Quit
-
-	default:
-		return m, nil
+	if err := cloneConfig.Sparse.load(); err != nil {
+		return err
 	}
-}
 
-func (m cloneTUIModel) View() string {
-	if m.err != nil {
-		return fmt.Sprintf("\nError: %v\n\nPress 'q' to exit\n", m.err)
+	filter, err := cloning.ParseCloneFilter(cloneConfig.Filter)
+	if err != nil {
+		return err
 	}
+	cloneConfig.Filter = string(filter)
 
-	if m.quitting {
-		if m.total == 0 {
-			return "\nNo repositories found.\n"
-		}
-
-		// Show completion summary with final statistics
-		var completionMsg strings.Builder
-		completionMsg.WriteString(fmt.Sprintf("\n✅ Cloning completed: %d repositories processed\n", m.total))
-		completionMsg.WriteString(fmt.Sprintf("📁 Directory: %s\n", filepath.Join(m.globalConfig.BaseDir, m.cloneConfig.Owner)))
-
-		if m.actualProgress != nil {
-			completionMsg.WriteString(fmt.Sprintf("📊 Results: ✅ %d completed, ❌ %d failed, ⏭️ %d skipped\n",
-				m.actualProgress.Completed, m.actualProgress.Failed, m.actualProgress.Skipped))
-			if m.actualProgress.ElapsedTime > 0 {
-				completionMsg.WriteString(fmt.Sprintf("⏱️ Duration: %v\n", m.actualProgress.ElapsedTime.Truncate(time.Second)))
-			}
-		}
-
-		if m.tuiLogger != nil {
-			completionMsg.WriteString(fmt.Sprintf("📄 Log file: %s\n", m.tuiLogger.GetLogFile()))
-		}
-
-		return completionMsg.String()
+	visibility, err := parseVisibilityFlag(cloneConfig.Visibility)
+	if err != nil {
+		return err
 	}
 
-	if len(m.repos) == 0 {
-		return "\nFetching repositories...\n"
+	// Get global configuration
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
 	}
 
-	// Header
-	header := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FAFAFA")).
-		Background(lipgloss.Color("#7D56F4")).
-		Padding(0, 1).
-		Render("🚀 repocloner v0.2.0 - Concurrent GitHub Repository Cloner")
-
-	// Progress info
-	info := fmt.Sprintf("Cloning repositories to '%s' directory...", filepath.Join(m.globalConfig.BaseDir, m.cloneConfig.Owner))
-	progressInfo := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7D56F4")).
-		Bold(true).
-		Render(info)
-
-	// Progress bar
-	bar := m.progress.View()
-
-	// Progress details if we have actual progress
-	var progressDetails string
-	if m.actualProgress != nil {
-		progressDetails = m.renderProgressDetails()
+	// Initialize application
+	app, tuiLogger, err := NewApplication(globalConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+	defer func() {
+		if err := app.Close(); err != nil {
+			app.logger.Warn("failed to close application", shared.ErrorField(err))
+		}
+	}()
 
-		// Show completion status when 100% is reached
-		if m.actualProgress.IsComplete() {
-			percentage := m.actualProgress.GetPercentage()
-			if percentage >= 100.0 {
-				successStyle := lipgloss.NewStyle().
-					Foreground(lipgloss.Color("#04B575")).
-					Bold(true)
-				progressDetails += "\n" + successStyle.Render("🎉 All repositories processed! Preparing to exit...")
+	if len(args) == 1 && cloneConfig.Provider == "" {
+		if err := resolveCloneSource(cmd.Context(), app, args[0], cloneConfig); err != nil {
+			return err
+		}
+		if !cloneConfig.Type.IsGitHubType() {
+			if err := validateBitbucketCredentials(cloneConfig.Type, globalConfig); err != nil {
+				return err
 			}
 		}
 	}
 
-	// Recent completion info
-	var recentCompletion string
-	if m.actualProgress != nil && m.actualProgress.RecentCompletion != nil {
-		recentCompletion = m.renderRecentCompletion()
+	if listed != nil {
+		return runCloneList(cmd, app, tuiLogger, globalConfig, cloneConfig, listed, policy, order)
 	}
 
-	// Build the main content
-	content := []string{
-		header,
-		"",
-		progressInfo,
-		bar,
+	if len(extraOwners) > 0 {
+		targets := append([]ownerTarget{{Type: cloneConfig.Type, Owner: cloneConfig.Owner}}, extraOwners...)
+		return runCloneOwners(cmd, app, tuiLogger, globalConfig, cloneConfig, targets, visibility, policy, order)
 	}
 
-	// Add progress details if available
-	if progressDetails != "" {
-		content = append(content, progressDetails)
+	fetchReq := newFetchRequest(cloneConfig.Type, cloneConfig.Owner, cloneConfig.SkipForks)
+	selectForks(fetchReq, cloneConfig.ForksOnly)
+	if err := cloneConfig.Teams.apply(fetchReq); err != nil {
+		return err
 	}
-
-	// Add recent completion if available
-	if recentCompletion != "" {
-		content = append(content, "", recentCompletion)
+	target := fmt.Sprintf("%s/%s", cloneConfig.Type, cloneConfig.Owner)
+	if cloneConfig.Provider != "" {
+		provider, err := plugin.NewProvider(&plugin.ProviderConfig{
+			Path:   cloneConfig.Provider,
+			Logger: app.logger.With(shared.StringField("component", "provider_plugin")),
+		})
+		if err != nil {
+			return err
+		}
+		fetchReq.Lister = provider
+		target = fmt.Sprintf("%s/%s", provider.Name(), cloneConfig.Owner)
 	}
-
-	// Add log section if enabled
-	if m.showLogs && m.tuiLogger != nil {
-		content = append(content, "", m.renderLogs())
+	fetchReq.Filter.Visibility = visibility
+	if err := cloneConfig.Exclusions.apply(fetchReq.Filter, globalConfig.BaseDir); err != nil {
+		return err
 	}
-
-	// Add help text
-	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#626262")).
-		MarginTop(1)
-
-	helpText := "Press 'q' to quit"
-	if m.tuiLogger != nil {
-		if m.showLogs {
-			helpText += " • 'l' to hide logs • 'c' to clear logs"
-		} else {
-			helpText += " • 'l' to show logs"
-		}
+	if err := preflightTokenScopes(cmd.Context(), app, globalConfig, []*usecases.FetchRepositoriesRequest{fetchReq}); err != nil {
+		return err
 	}
 
-	content = append(content, helpStyle.Render(helpText))
-
-	return lipgloss.NewStyle().Padding(1, 2).Render(
-		lipgloss.JoinVertical(lipgloss.Left, content...),
-	)
-}
-
-// renderProgressDetails renders detailed progress information
-func (m cloneTUIModel) renderProgressDetails() string {
-	if m.actualProgress == nil {
-		return ""
+	// Show configuration info before starting TUI
+	messages := cloneMessages(cloneConfig.Output)
+	fmt.Fprintf(messages, "%s - Concurrent Repository Cloner\n", version.Title())
+	fmt.Fprintf(messages, "Target: %s\n", target)
+	if fetchReq.Team != "" {
+		fmt.Fprintf(messages, "Team: %s\n", fetchReq.Team)
+	}
+	fmt.Fprintf(messages, "Concurrency: %s\n", globalConfig.DescribeWorkers())
+	fmt.Fprintf(messages, "Base directory: %s\n", globalConfig.BaseDir)
+	fmt.Fprintf(messages, "Log file: %s\n", tuiLogger.GetLogFile())
+	if cloneConfig.Type.IsGitHubType() && !globalConfig.HasGitHubAuth() {
+		fmt.Fprintf(messages, "Warning: Running without GitHub token (rate limiting may apply)\n")
 	}
-
-	p := m.actualProgress
-	details := fmt.Sprintf(
-		"Progress: %d/%d repositories | ✓ %d completed | ✗ %d failed | ⏭ %d skipped | ⏳ %d in progress",
-		p.Completed+p.Failed+p.Skipped+p.InProgress, p.Total,
-		p.Completed, p.Failed, p.Skipped, p.InProgress,
-	)
-
-	if p.Throughput > 0 {
-		details += fmt.Sprintf(" | %.1f repos/sec", p.Throughput)
+	if cloneConfig.SkipForks {
+		fmt.Fprintf(messages, "Skipping forked repositories\n")
 	}
-
-	if p.ETA > 0 {
-		details += fmt.Sprintf(" | ETA: %s", p.ETA.Truncate(time.Second))
+	if cloneConfig.ForksOnly {
+		fmt.Fprintf(messages, "Cloning forked repositories only\n")
 	}
-
-	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#909090")).
-		Render(details)
-}
-
-// renderRecentCompletion renders information about the most recently completed repository
-func (m cloneTUIModel) renderRecentCompletion() string {
-	if m.actualProgress == nil || m.actualProgress.RecentCompletion == nil {
-		return ""
+	if files := cloneConfig.Exclusions.ignoreFiles; len(files) > 0 {
+		fmt.Fprintf(messages, "Ignore files: %s\n", strings.Join(files, ", "))
 	}
+	fmt.Fprintf(messages, "Starting...\n\n")
 
-	recent := m.actualProgress.RecentCompletion
-	var statusIcon, statusColor string
-
-	switch recent.Status {
-	case cloning.JobStatusCompleted:
-		statusIcon = "✓"
-		statusColor = "#04B575" // Green
-	case cloning.JobStatusFailed:
-		statusIcon = "✗"
-		statusColor = "#FF5F87" // Red
-	case cloning.JobStatusSkipped:
-		statusIcon = "⏭"
-		statusColor = "#FFAF00" // Yellow
-	default:
-		statusIcon = "?"
-		statusColor = "#909090" // Gray
+	// Create destination directory
+	destDir := filepath.Join(globalConfig.BaseDir, cloneConfig.Owner)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
-	// Format the repository name and status
-	repoInfo := fmt.Sprintf("%s %s", statusIcon, recent.Repository)
-	if recent.Duration > 0 {
-		repoInfo += fmt.Sprintf(" (%s)", recent.Duration.Truncate(time.Millisecond*10))
+	var status func() string
+	if cloneConfig.Type.IsGitHubType() {
+		status = githubRateLimitStatus(app)
 	}
 
-	if recent.Size > 0 {
-		repoInfo += fmt.Sprintf(" [%s]", formatBytes(recent.Size))
+	// Start TUI
+	resp, err := runClone(cmd, globalConfig, cloneConfig.Output, &clonetui.Config{
+		Title:        version.Title() + " - Concurrent Repository Cloner",
+		Target:       target,
+		Directory:    destDir,
+		Fetch:        repositoryFetcher(app, fetchReq),
+		CloneUseCase: app.cloneRepositoriesUseCase,
+		Options:      createCloneOptions(cloneConfig),
+		Concurrency:  globalConfig.Concurrency,
+		Order:        order,
+		Dedupe:       cloneConfig.Dedupe,
+		Confirm:      !cloneConfig.Yes,
+		Logger:       tuiLogger,
+		Status:       status,
+	})
+	if err != nil {
+		return err
 	}
+	return finishCloneRun(cmd, app, resp, policy)
+}
 
-	if recent.Error != "" && recent.Status != cloning.JobStatusCompleted {
-		// Truncate error message if too long
-		errorMsg := recent.Error
-		if len(errorMsg) > 60 {
-			errorMsg = errorMsg[:57] + "..."
-		}
-		repoInfo += fmt.Sprintf(" - %s", errorMsg)
+// runCloneList clones an explicit repository list into per-owner directories
+// of the base directory
+func runCloneList(
+	cmd *cobra.Command,
+	app *Application,
+	tuiLogger *logging.TUILogger,
+	globalConfig *Config,
+	cloneConfig *CloneConfig,
+	repos []*repository.Repository,
+	policy *cloning.FailurePolicy,
+	order cloning.JobOrder,
+) error {
+	target := cloneConfig.FromFile
+	if target == "-" {
+		target = "stdin"
+	}
+
+	messages := cloneMessages(cloneConfig.Output)
+	fmt.Fprintf(messages, "%s - Concurrent Repository Cloner\n", version.Title())
+	fmt.Fprintf(messages, "Source: %d repositories from %s\n", len(repos), target)
+	fmt.Fprintf(messages, "Concurrency: %s\n", globalConfig.DescribeWorkers())
+	fmt.Fprintf(messages, "Base directory: %s\n", globalConfig.BaseDir)
+	fmt.Fprintf(messages, "Log file: %s\n", tuiLogger.GetLogFile())
+	fmt.Fprintf(messages, "Starting...\n\n")
+
+	if err := os.MkdirAll(globalConfig.BaseDir, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
-	title := "Recently completed:"
-	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#7D56F4")).
-		Bold(true)
-
-	repoStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(statusColor))
+	options := createCloneOptions(cloneConfig)
+	options.CreateOrgDirs = true
 
-	return titleStyle.Render(title) + " " + repoStyle.Render(repoInfo)
+	resp, err := runClone(cmd, globalConfig, cloneConfig.Output, &clonetui.Config{
+		Title:     version.Title() + " - Concurrent Repository Cloner",
+		Target:    target,
+		Directory: globalConfig.BaseDir,
+		Fetch: func(ctx context.Context) ([]*repository.Repository, error) {
+			app.fetchRepositoriesUseCase.ResolveRenames(ctx, repos)
+			return repos, nil
+		},
+		CloneUseCase: app.cloneRepositoriesUseCase,
+		Options:      options,
+		Concurrency:  globalConfig.Concurrency,
+		Order:        order,
+		Dedupe:       cloneConfig.Dedupe,
+		Confirm:      !cloneConfig.Yes,
+		Logger:       tuiLogger,
+		InputTTY:     cloneConfig.FromFile == "-",
+	})
+	if err != nil {
+		return err
+	}
+	return finishCloneRun(cmd, app, resp, policy)
 }
 
-// renderLogs renders the log display area
-func (m cloneTUIModel) renderLogs() string {
-	if m.tuiLogger == nil {
-		return ""
-	}
-
-	// Get recent log entries
-	entries := m.tuiLogger.GetLogBuffer().GetRecent(m.logHeight)
-
-	if len(entries) == 0 {
-		return lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
-			Padding(0, 1).
-			Width(80).
-			Height(m.logHeight).
-			Align(lipgloss.Center, lipgloss.Center).
-			Render("No logs available")
-	}
-
-	// Format log entries
-	var logLines []string
-	for _, entry := range entries {
-		var style lipgloss.Style
-		switch entry.Level {
-		case "ERROR", "FATAL":
-			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87"))
-		case "WARN":
-			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAF00"))
-		case "INFO":
-			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
-		case "DEBUG":
-			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#626262"))
-		default:
-			style = lipgloss.NewStyle()
+// readRepositoryList reads the repository references of --from-file
+func readRepositoryList(cmd *cobra.Command, path string) ([]*repository.Repository, error) {
+	var input io.Reader = cmd.InOrStdin()
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open repository list: %w", err)
 		}
-
-		logLine := fmt.Sprintf("[%s] %s %s",
-			entry.Level,
-			entry.Timestamp.Format("15:04:05"),
-			entry.Message)
-
-		logLines = append(logLines, style.Render(logLine))
+		defer func() { _ = file.Close() }()
+		input = file
 	}
 
-	// Pad with empty lines if needed
-	for len(logLines) < m.logHeight {
-		logLines = append(logLines, "")
+	repos, err := repository.ReadReferences(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository list %s: %w", path, err)
 	}
-
-	// Create bordered log area
-	logContent := lipgloss.JoinVertical(lipgloss.Left, logLines...)
-
-	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#874BFD")).
-		Padding(0, 1).
-		Width(80).
-		Height(m.logHeight + 2). // +2 for border
-		Render(logContent)
-}
-
-// Tea Commands
-type repositoriesMsg struct {
-	repositories []*repository.Repository
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("repository list %s is empty", path)
+	}
+	return repos, nil
 }
 
-type cloningStartedMsg struct {
-	progressTracker *cloning.ProgressTracker
-}
+// resolveCloneSource sets the repository type and owner from a provider URL
+func resolveCloneSource(ctx context.Context, app *Application, source string, cloneConfig *CloneConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
-type cloningProgressMsg struct {
-	progress *cloning.Progress
-}
+	resolved, err := app.resolveSourceUseCase.Execute(ctx, source)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", source, err)
+	}
 
-type errorMsg struct {
-	err error
+	cloneConfig.Type = resolved.Type
+	cloneConfig.Owner = resolved.Owner
+	return nil
 }
 
-type logUpdateMsg struct{}
+// newFetchRequest creates the request listing the repositories of an owner
+func newFetchRequest(repoType repository.RepositoryType, owner string, skipForks bool) *usecases.FetchRepositoriesRequest {
+	filter := repository.NewRepositoryFilter()
+	filter.IncludeForks = !skipForks
 
-func logUpdateCmd() tea.Cmd {
-	return tea.Tick(time.Millisecond*500, func(t time.Time) tea.Msg {
-		return logUpdateMsg{}
-	})
+	return &usecases.FetchRepositoriesRequest{
+		Owner:  owner,
+		Type:   repoType,
+		Filter: filter,
+	}
 }
 
-func fetchRepositoriesCmd(app *Application, config *CloneConfig) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-		defer cancel()
-
-		filter := repository.NewRepositoryFilter()
-		filter.IncludeForks = !config.SkipForks
-
-		req := &usecases.FetchRepositoriesRequest{
-			Owner:  config.Owner,
-			Type:   config.Type,
-			Filter: filter,
-		}
-
+// repositoryFetcher lists repositories for the clone TUI
+func repositoryFetcher(app *Application, req *usecases.FetchRepositoriesRequest) clonetui.FetchFunc {
+	return func(ctx context.Context) ([]*repository.Repository, error) {
 		resp, err := app.fetchRepositoriesUseCase.Execute(ctx, req)
 		if err != nil {
-			return errorMsg{err: err}
+			return nil, err
 		}
-
-		return repositoriesMsg{repositories: resp.Repositories}
+		app.recordMetadata(fmt.Sprintf("%s/%s", req.Type, req.Owner), resp.Repositories)
+		return resp.Repositories, nil
 	}
 }
 
-func startCloningCmd(app *Application, repos []*repository.Repository, baseDir string, config *CloneConfig) tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Create destination directory
-		destDir := filepath.Join(baseDir, config.Owner)
-
-		req := &usecases.CloneRepositoriesRequest{
-			Repositories:  repos,
-			BaseDirectory: destDir,
-			Options:       createCloneOptions(config),
-			Concurrency:   runtime.NumCPU() * 2,
-		}
-
-		// Start cloning in background
-		go func() {
-			_, err := app.cloneRepositoriesUseCase.Execute(ctx, req)
-			if err != nil {
-				app.logger.Error("Cloning failed", shared.ErrorField(err))
-			}
-		}()
-
-		// Return a message that starts progress tracking
-		return cloningStartedMsg{
-			progressTracker: nil, // Will be populated by real progress updates
-		}
-	}
-}
-
-func realProgressTickCmd(app *Application) tea.Cmd {
-	return tea.Tick(time.Millisecond*200, func(t time.Time) tea.Msg {
-		if app != nil && app.cloneRepositoriesUseCase != nil {
-			progress := app.cloneRepositoriesUseCase.GetProgress()
-			// Return progress even if nil - the TUI will handle it appropriately
-			return cloningProgressMsg{progress: progress}
-		}
-		return cloningProgressMsg{progress: nil}
-	})
-}
-
 // createCloneOptions creates clone options from the clone config
 func createCloneOptions(config *CloneConfig) *cloning.CloneOptions {
 	options := cloning.NewDefaultCloneOptions()
 	options.Depth = config.Depth
 	options.Branch = config.Branch
+	options.Ref = config.Ref
 	options.SkipExisting = true
-	options.CreateOrgDirs = false
-	options.RecurseSubmodules = true
+	options.CreateOrgDirs = config.OrgDirs
+	options.ProviderDirs = config.OrgDirs
+	options.SkipLFS = config.SkipLFS
+	config.Submodules.apply(options)
+	config.Sparse.apply(options)
+	options.Filter = cloning.CloneFilter(config.Filter)
+	config.Existing.apply(options)
+	config.SizeLimit.apply(options)
+	options.DefaultBranches = config.DefaultBranchOnly
 	return options
 }
 
-// formatBytes formats byte size in human readable format
-func formatBytes(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+// selectForks adapts a listing to a clone run: --forks-only keeps only forks,
+// and the parents of included forks are looked up for their upstream remotes
+func selectForks(req *usecases.FetchRepositoriesRequest, forksOnly bool) {
+	req.Filter.OnlyForks = forksOnly
+	req.ResolveUpstreams = true
+}
+
+// addForksOnlyFlag registers the --forks-only flag of clone commands
+func addForksOnlyFlag(cmd *cobra.Command, forksOnly *bool) {
+	cmd.Flags().BoolVar(forksOnly, "forks-only", false,
+		"Clone only forked repositories, adding an upstream remote to their parent")
+}
+
+// addSkipLFSFlag registers the --skip-lfs flag of clone commands
+func addSkipLFSFlag(cmd *cobra.Command, skipLFS *bool) {
+	cmd.Flags().BoolVar(skipLFS, "skip-lfs", false,
+		"Leave Git LFS pointer files instead of downloading LFS objects (git backend)")
+}
+
+// addDefaultBranchOnlyFlag registers the --default-branch-only flag of clone commands
+func addDefaultBranchOnlyFlag(cmd *cobra.Command, patterns *[]string) {
+	cmd.Flags().StringSliceVar(patterns, "default-branch-only", nil,
+		"Skip repositories whose default branch matches none of these patterns, e.g. main or release/*")
+}
+
+// addFilterFlag registers the --filter flag of clone commands
+func addFilterFlag(cmd *cobra.Command, filter *string) {
+	cmd.Flags().StringVar(filter, "filter", "",
+		"Partial clone filter: blobless, treeless or a git filter spec such as blob:limit=1m (git backend)")
+	completeFlag(cmd, "filter", "blobless", "treeless")
+}
+
+// addOrgDirsFlag registers the --org-dirs flag of clone commands
+func addOrgDirsFlag(cmd *cobra.Command, orgDirs *bool) {
+	cmd.Flags().BoolVar(orgDirs, "org-dirs", false,
+		"Clone into <provider>/<owner>/<repo> under the base directory instead of <repo>")
 }
@@ -0,0 +1,26 @@
+package fang
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/interfaces/output"
+)
+
+// ColumnConfig holds the column selection flags of listing commands
+type ColumnConfig struct {
+	Columns  []string // Columns to print in order, empty for the defaults of the format
+	NoHeader bool     // Leave out the table header and totals and the CSV header row
+}
+
+// addColumnFlags registers the --columns and --no-header flags, completing
+// the names of the available columns
+func addColumnFlags(cmd *cobra.Command, config *ColumnConfig, names []string) {
+	cmd.Flags().StringSliceVar(&config.Columns, "columns", nil, "Columns to print, comma-separated, e.g. name,size,language")
+	completeFlag(cmd, "columns", names...)
+	cmd.Flags().BoolVar(&config.NoHeader, "no-header", false, "Leave out the table header and totals and the CSV header row")
+}
+
+// options returns the writer options of an output format
+func (c *ColumnConfig) options(format string) output.Options {
+	return output.Options{Format: format, NoHeader: c.NoHeader}
+}
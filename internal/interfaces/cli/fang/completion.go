@@ -0,0 +1,125 @@
+package fang
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+var (
+	githubTypes    = []string{"user", "org"}
+	listTypes      = []string{"user", "org", "workspace", "project"}
+	bitbucketTypes = []string{"user", "workspace", "project"}
+)
+
+// NewCompletionCommand creates the completion command
+func NewCompletionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate the shell completion script",
+		Long: `Generate the completion script for the given shell.
+
+Completions cover commands, flags, repository types and the values of flags
+such as --format, --sort and --order.`,
+		Example: `  # Bash, for every new session
+  repocloner completion bash > /etc/bash_completion.d/repocloner
+
+  # Zsh, for every new session
+  repocloner completion zsh > "${fpath[1]}/_repocloner"
+
+  # Fish
+  repocloner completion fish > ~/.config/fish/completions/repocloner.fish
+
+  # PowerShell, for the current session
+  repocloner completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			out := cmd.OutOrStdout()
+
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			default:
+				return root.GenPowerShellCompletionWithDesc(out)
+			}
+		},
+	}
+
+	return cmd
+}
+
+// NewManCommand creates the man command
+func NewManCommand() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: "Generate man pages",
+		Long: `Generate man pages for repocloner and every subcommand.
+
+Without --dir the page of the root command is written to standard output.`,
+		Example: `  # One page per command, installed for the current user
+  repocloner man --dir ~/.local/share/man/man1
+
+  # Read the root page directly
+  repocloner man | man -l -`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			root.DisableAutoGenTag = true
+			header := &doc.GenManHeader{Title: "REPOCLONER", Section: "1", Source: "repocloner " + root.Version}
+
+			if dir == "" {
+				return doc.GenMan(root, header, cmd.OutOrStdout())
+			}
+
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("failed to create man page directory: %w", err)
+			}
+			if err := doc.GenManTree(root, header, dir); err != nil {
+				return fmt.Errorf("failed to generate man pages: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Write one page per command to this directory instead of standard output")
+	_ = cmd.MarkFlagDirname("dir")
+
+	return cmd
+}
+
+// registerCompletions adds dynamic completion of the global flags
+func registerCompletions(root *cobra.Command) {
+	completeFlag(root, "backend", git.BackendGit, git.BackendGoGit)
+	completeFlag(root, "log-level", "debug", "info", "warn", "error")
+	completeFlag(root, "log-format", logging.ValidFormats...)
+}
+
+// completeFlag completes the values of a flag from a fixed list
+func completeFlag(cmd *cobra.Command, flag string, values ...string) {
+	_ = cmd.RegisterFlagCompletionFunc(flag, cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// completeTypeOwner completes the repository type of [type] [owner] arguments.
+// With pairs, every other argument is a type.
+func completeTypeOwner(types []string, pairs bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 || (pairs && len(args)%2 == 0) {
+			return types, cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
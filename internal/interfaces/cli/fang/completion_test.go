@@ -0,0 +1,61 @@
+package fang
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteTypeOwner(t *testing.T) {
+	tests := []struct {
+		name  string
+		pairs bool
+		args  []string
+		want  []string
+	}{
+		{name: "type", args: nil, want: githubTypes},
+		{name: "owner", args: []string{"org"}, want: nil},
+		{name: "no third argument", args: []string{"org", "acme"}, want: nil},
+		{name: "next pair type", pairs: true, args: []string{"org", "acme"}, want: githubTypes},
+		{name: "next pair owner", pairs: true, args: []string{"org", "acme", "user"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, directive := completeTypeOwner(githubTypes, tt.pairs)(&cobra.Command{}, tt.args, "")
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+		})
+	}
+}
+
+func TestCompletionCommand(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			root := NewRootCommand()
+			root.AddCommand(NewCompletionCommand())
+
+			var out bytes.Buffer
+			root.SetOut(&out)
+			root.SetArgs([]string{"completion", shell})
+
+			require.NoError(t, root.Execute())
+			assert.Contains(t, out.String(), "repocloner")
+		})
+	}
+}
+
+func TestManCommand(t *testing.T) {
+	root := NewRootCommand()
+	root.AddCommand(NewListCommand(), NewManCommand())
+
+	dir := t.TempDir()
+	root.SetArgs([]string{"man", "--dir", dir})
+	require.NoError(t, root.Execute())
+
+	assert.FileExists(t, dir+"/repocloner.1")
+	assert.FileExists(t, dir+"/repocloner-list.1")
+}
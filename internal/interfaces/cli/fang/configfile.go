@@ -0,0 +1,155 @@
+package fang
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+)
+
+// configFileName is the configuration file read from the user config
+// directory, e.g. ~/.config/repocloner/config.yaml
+const configFileName = "config.yaml"
+
+// FileConfig holds the settings of the configuration file
+type FileConfig struct {
+	// Overrides replace clone options of repositories matching a pattern:
+	//
+	//	overrides:
+	//	  - match: "infra-*"
+	//	    depth: 0
+	//	    lfs: true
+	Overrides cloning.OptionOverrides `yaml:"overrides"`
+
+	// Retry sets the retry policy of failed clone attempts:
+	//
+	//	retry:
+	//	  max_retries: 5
+	//	  base_delay: 2s
+	//	  max_delay: 1m
+	//	  jitter: 0.5
+	Retry *RetryFileConfig `yaml:"retry"`
+
+	// GitRoutes send the clones of a host, *.domain pattern or provider
+	// (github, gitlab, bitbucket) through a proxy or an SSH bastion:
+	//
+	//	git_routes:
+	//	  git.internal.example:
+	//	    ssh_jump: bastion.example.com
+	//	  gitlab:
+	//	    proxy: socks5://127.0.0.1:1080
+	GitRoutes map[string]GitRouteFileConfig `yaml:"git_routes"`
+
+	// Git holds settings of the git commands:
+	//
+	//	git:
+	//	  extra_args: ["--config core.autocrlf=false", "--jobs 4"]
+	Git GitFileConfig `yaml:"git"`
+}
+
+// GitFileConfig holds the git settings of the configuration file
+type GitFileConfig struct {
+	ExtraArgs []string `yaml:"extra_args"` // Appended to git clone, before --git-arg
+}
+
+// GitRouteFileConfig holds the route of a host in the configuration file
+type GitRouteFileConfig struct {
+	Proxy           string `yaml:"proxy"`
+	SSHJump         string `yaml:"ssh_jump"`
+	SSHProxyCommand string `yaml:"ssh_proxy_command"`
+}
+
+// route converts the file settings to a git route
+func (c GitRouteFileConfig) route() git.Route {
+	return git.Route{Proxy: c.Proxy, SSHJump: c.SSHJump, SSHProxyCommand: c.SSHProxyCommand}
+}
+
+// RetryFileConfig holds the retry settings of the configuration file; unset
+// keys keep the defaults and the retry flags take precedence
+type RetryFileConfig struct {
+	MaxRetries *int           `yaml:"max_retries"`
+	BaseDelay  *time.Duration `yaml:"base_delay"`
+	MaxDelay   *time.Duration `yaml:"max_delay"`
+	Jitter     *float64       `yaml:"jitter"`
+}
+
+// apply sets the configured values on a retry policy
+func (c *RetryFileConfig) apply(policy *concurrency.BackoffPolicy) {
+	if c == nil {
+		return
+	}
+	if c.MaxRetries != nil {
+		policy.MaxRetries = *c.MaxRetries
+	}
+	if c.BaseDelay != nil {
+		policy.BaseDelay = *c.BaseDelay
+	}
+	if c.MaxDelay != nil {
+		policy.MaxDelay = *c.MaxDelay
+	}
+	if c.Jitter != nil {
+		policy.Jitter = *c.Jitter
+	}
+}
+
+// defaultConfigFile returns the configuration file of the user config
+// directory, or an empty string when there is no such directory
+func defaultConfigFile() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "repocloner", configFileName)
+}
+
+// loadConfigFile reads the file of --config, or the default configuration
+// file when it exists. Unknown keys are rejected so typos do not go unnoticed.
+func loadConfigFile(cmd *cobra.Command) (*FileConfig, error) {
+	path, _ := cmd.Flags().GetString("config")
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigFile()
+	}
+
+	fileConfig := &FileConfig{}
+	if path == "" {
+		return fileConfig, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return fileConfig, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(fileConfig); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if err := fileConfig.Overrides.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if _, err := git.ParseCloneArgs(fileConfig.Git.ExtraArgs); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: git.extra_args: %w", path, err)
+	}
+	for key, route := range fileConfig.GitRoutes {
+		if err := route.route().Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config file %s: git route %s: %w", path, key, err)
+		}
+	}
+	return fileConfig, nil
+}
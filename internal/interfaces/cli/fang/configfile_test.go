@@ -0,0 +1,155 @@
+package fang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseConfigFile loads the config file of a root command run with args
+func parseConfigFile(t *testing.T, args ...string) (*FileConfig, error) {
+	t.Helper()
+	root := NewRootCommand()
+	require.NoError(t, root.ParseFlags(args))
+	return loadConfigFile(root)
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	// No default file is not an error
+	fileConfig, err := parseConfigFile(t)
+	require.NoError(t, err)
+	assert.Empty(t, fileConfig.Overrides)
+
+	defaultFile := filepath.Join(configHome, "repocloner", configFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(defaultFile), 0755))
+	require.NoError(t, os.WriteFile(defaultFile, []byte(`
+overrides:
+  - match: "infra-*"
+    depth: 0
+    lfs: true
+  - match: acme/legacy
+    branch: develop
+`), 0644))
+
+	fileConfig, err = parseConfigFile(t)
+	require.NoError(t, err)
+	require.Len(t, fileConfig.Overrides, 2)
+	assert.Equal(t, "infra-*", fileConfig.Overrides[0].Match)
+	assert.Equal(t, 0, *fileConfig.Overrides[0].Depth)
+	assert.True(t, *fileConfig.Overrides[0].LFS)
+	assert.Equal(t, "develop", *fileConfig.Overrides[1].Branch)
+
+	// --config replaces the default file
+	other := filepath.Join(t.TempDir(), "other.yaml")
+	require.NoError(t, os.WriteFile(other, []byte(""), 0644))
+	fileConfig, err = parseConfigFile(t, "--config", other)
+	require.NoError(t, err)
+	assert.Empty(t, fileConfig.Overrides)
+}
+
+func TestLoadConfigFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "unknown key", content: "overides: []\n", wantErr: "field overides not found"},
+		{name: "unknown override key", content: "overrides:\n  - match: x\n    dept: 0\n", wantErr: "field dept not found"},
+		{name: "invalid override", content: "overrides:\n  - depth: 0\n", wantErr: "match cannot be empty"},
+		{name: "unknown route key", content: "git_routes:\n  gitlab:\n    socks: x\n", wantErr: "field socks not found"},
+		{name: "invalid route", content: "git_routes:\n  gitlab:\n    proxy: ftp://proxy:21\n", wantErr: "git route gitlab: invalid git proxy URL"},
+		{name: "unsafe git argument", content: "git:\n  extra_args: [\"--upload-pack=evil\"]\n", wantErr: "git.extra_args: git clone argument \"--upload-pack=evil\" is not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			_, err := parseConfigFile(t, "--config", path)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := parseConfigFile(t, "--config", filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read config file")
+}
+
+func TestRetryConfig(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	globalConfig := func(args ...string) (*Config, error) {
+		root := NewRootCommand()
+		require.NoError(t, root.ParseFlags(args))
+		return getGlobalConfig(root)
+	}
+
+	config, err := globalConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 3, config.Retry.MaxRetries)
+	assert.Equal(t, 5*time.Second, config.Retry.BaseDelay)
+
+	defaultFile := filepath.Join(configHome, "repocloner", configFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(defaultFile), 0755))
+	require.NoError(t, os.WriteFile(defaultFile, []byte(`
+retry:
+  max_retries: 5
+  base_delay: 2s
+  jitter: 0.5
+`), 0644))
+
+	config, err = globalConfig("--retry-base-delay", "1s")
+	require.NoError(t, err)
+	assert.Equal(t, 5, config.Retry.MaxRetries)
+	assert.Equal(t, time.Second, config.Retry.BaseDelay, "flags take precedence over the file")
+	assert.Equal(t, 2*time.Minute, config.Retry.MaxDelay)
+	assert.Equal(t, 0.5, config.Retry.Jitter)
+
+	config, err = globalConfig("--max-retries", "0")
+	require.NoError(t, err)
+	assert.Zero(t, config.Retry.MaxRetries)
+
+	_, err = globalConfig("--retry-jitter", "2")
+	assert.ErrorContains(t, err, "invalid retry settings")
+}
+
+func TestGitRoutesConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	globalConfig := func(args ...string) (*Config, error) {
+		root := NewRootCommand()
+		require.NoError(t, root.ParseFlags(args))
+		return getGlobalConfig(root)
+	}
+
+	config, err := globalConfig()
+	require.NoError(t, err)
+	assert.Nil(t, config.GitRoutes, "clones connect directly by default")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+git_routes:
+  gitlab:
+    proxy: socks5://127.0.0.1:1080
+  "*.corp.example":
+    ssh_jump: deploy@bastion.corp.example
+`), 0644))
+
+	config, err = globalConfig("--config", path, "--ssh-proxy-command", "cloudflared access ssh --hostname %h")
+	require.NoError(t, err)
+	require.NotNil(t, config.GitRoutes)
+	assert.Equal(t, "cloudflared access ssh --hostname %h", config.GitRoutes.Default.SSHProxyCommand)
+	assert.Equal(t, "socks5://127.0.0.1:1080", config.GitRoutes.Lookup("https://gitlab.com/acme/app.git").Proxy)
+	assert.Equal(t, "deploy@bastion.corp.example", config.GitRoutes.Lookup("git@git.corp.example:acme/app.git").SSHJump)
+
+	_, err = globalConfig("--ssh-jump", "bastion", "--ssh-proxy-command", "nc %h %p")
+	assert.ErrorContains(t, err, "invalid git route")
+}
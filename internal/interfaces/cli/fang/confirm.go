@@ -0,0 +1,59 @@
+package fang
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+// addYesFlag registers the --yes flag of clone commands
+func addYesFlag(cmd *cobra.Command, yes *bool) {
+	cmd.Flags().BoolVarP(yes, "yes", "y", false, "Clone without confirming the size and duration estimate")
+}
+
+// checkConfirmable fails early when the confirmation prompt could never be
+// answered. inputTTY reports whether the prompt reads keys from the terminal
+// instead of stdin.
+func checkConfirmable(yes, inputTTY bool) error {
+	if yes || inputTTY || stdinIsTerminal() {
+		return nil
+	}
+	return fmt.Errorf("cannot ask for confirmation without a terminal, pass --yes to clone anyway")
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirmClone shows the estimate of a headless run and reads the answer,
+// returning clonetui.ErrDeclined unless the user accepts
+func confirmClone(cmd *cobra.Command, estimate usecases.CloneEstimate) error {
+	return promptClone(cmd.InOrStdin(), cmd.OutOrStdout(), estimate)
+}
+
+// promptClone writes the estimate prompt to out and reads the answer from in
+func promptClone(in io.Reader, out io.Writer, estimate usecases.CloneEstimate) error {
+	fmt.Fprintf(out, "%s. Continue? [y/N] ", clonetui.FormatEstimate(estimate))
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return clonetui.ErrDeclined
+	}
+}
@@ -0,0 +1,39 @@
+package fang
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+func TestConfirmClone(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{input: "y\n", want: nil},
+		{input: "YES\n", want: nil},
+		{input: "n\n", want: clonetui.ErrDeclined},
+		{input: "\n", want: clonetui.ErrDeclined},
+		{input: "", want: clonetui.ErrDeclined},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.input), func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetIn(strings.NewReader(tt.input))
+			cmd.SetOut(&out)
+
+			err := confirmClone(cmd, usecases.CloneEstimate{Repositories: 3, Bytes: 2048, Workers: 4})
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, "About to clone 3 repos (~2.0 KB, est. <1 min with 4 workers). Continue? [y/N] ", out.String())
+		})
+	}
+}
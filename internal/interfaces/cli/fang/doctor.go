@@ -0,0 +1,366 @@
+package fang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
+	"github.com/italoag/repocloner/internal/infrastructure/diskspace"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+	"github.com/italoag/repocloner/internal/version"
+)
+
+// DoctorConfig holds doctor command configuration
+type DoctorConfig struct {
+	MinFreeGB int           // Warn when the base directory has less free space
+	Timeout   time.Duration // Bound for every network check
+}
+
+// checkStatus is the outcome of a doctor check
+type checkStatus int
+
+const (
+	checkPass checkStatus = iota
+	checkWarn
+	checkFail
+	checkSkip
+)
+
+// doctorCheck is the result of one environment check
+type doctorCheck struct {
+	Name   string
+	Status checkStatus
+	Detail string
+}
+
+// NewDoctorCommand creates the doctor command
+func NewDoctorCommand() *cobra.Command {
+	config := &DoctorConfig{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose problems with git, credentials, the base directory and provider APIs",
+		Long: `Check that the environment is ready to clone repositories.
+
+The doctor command checks the git and Git LFS installations, validates the
+credentials of every configured provider, verifies that the base directory is
+writable and has free space, and tests GitHub API connectivity and rate limit
+budget. It exits with status 1 when a check fails; warnings do not fail.`,
+		Example: `  # Check the default configuration
+  repocloner doctor
+
+  # Check the credentials and base directory used by a clone
+  repocloner doctor --token $GITHUB_TOKEN --base-dir /srv/mirror`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd, config)
+		},
+	}
+
+	cmd.Flags().IntVar(&config.MinFreeGB, "min-free-gb", 5, "Warn when the base directory has less free space, in gigabytes")
+	cmd.Flags().DurationVar(&config.Timeout, "timeout", 15*time.Second, "Timeout of every network check")
+
+	return cmd
+}
+
+// runDoctor runs every check and prints the report
+func runDoctor(cmd *cobra.Command, config *DoctorConfig) error {
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	logger := logging.NewNoOpLogger()
+
+	checks := checkGit(ctx, globalConfig)
+	checks = append(checks, checkGitHub(ctx, globalConfig, config.Timeout, logger)...)
+	checks = append(checks,
+		checkBitbucketCloud(ctx, globalConfig, config.Timeout, logger),
+		checkBitbucketServer(ctx, globalConfig, config.Timeout, logger),
+		checkGitLab(globalConfig),
+		checkBaseDirWritable(globalConfig.BaseDir),
+		checkFreeSpace(globalConfig.BaseDir, config.MinFreeGB),
+	)
+
+	failed := writeDoctorReport(cmd.OutOrStdout(), checks)
+	if failed > 0 {
+		return &ExitCodeError{Code: ExitError, Err: fmt.Errorf("%d of %d checks failed", failed, len(checks))}
+	}
+	return nil
+}
+
+// checkGit checks the git and Git LFS installations. git is optional with the
+// pure Go backend.
+func checkGit(ctx context.Context, config *Config) []doctorCheck {
+	missing := checkFail
+	if config.Backend == git.BackendGoGit {
+		missing = checkWarn
+	}
+
+	gitVersion, err := git.InstalledVersion(ctx)
+	if err != nil {
+		return []doctorCheck{
+			{Name: "git", Status: missing, Detail: err.Error()},
+			{Name: "git-lfs", Status: checkSkip, Detail: "requires git"},
+		}
+	}
+
+	gitCheck := doctorCheck{Name: "git", Status: checkPass, Detail: gitVersion}
+	if !git.SupportsRevisionClone(gitVersion) {
+		gitCheck.Detail += " (2.49+ clones --ref commits without fetching the full history)"
+	}
+	if !git.SupportsPartialClone(gitVersion) {
+		gitCheck.Status = checkWarn
+		gitCheck.Detail += " (2.20+ required for --filter)"
+	}
+
+	lfsCheck := doctorCheck{Name: "git-lfs", Status: checkPass}
+	if lfsCheck.Detail, err = git.LFSVersion(ctx); err != nil {
+		lfsCheck.Status = checkWarn
+		lfsCheck.Detail = "not installed, LFS files are cloned as pointer files"
+	}
+
+	return []doctorCheck{gitCheck, lfsCheck}
+}
+
+// checkGitHub validates the GitHub credentials and checks API connectivity
+// and rate limit budget
+func checkGitHub(ctx context.Context, config *Config, timeout time.Duration, logger shared.Logger) []doctorCheck {
+	clientConfig := &github.GitHubClientConfig{
+		Token:       config.Token,
+		UserAgent:   version.UserAgent(),
+		Timeout:     timeout,
+		Transport:   config.Transport,
+		RateLimiter: github.NewRateLimiter(config.HasGitHubAuth()),
+		Logger:      logger,
+	}
+
+	auth := doctorCheck{Name: "GitHub token"}
+	switch {
+	case config.UsesGitHubApp():
+		auth.Name = "GitHub App"
+		tokenSource, err := newGitHubAppTokenSource(config, logger)
+		if err != nil {
+			auth.Status, auth.Detail = checkFail, err.Error()
+			break
+		}
+		clientConfig.TokenSource = tokenSource
+	case !config.HasGitHubAuth():
+		auth.Status = checkWarn
+		auth.Detail = "not configured, anonymous requests are limited to 60 per hour"
+	}
+
+	client := github.NewGitHubClient(clientConfig)
+
+	if auth.Status == checkPass && config.HasGitHubAuth() {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		if err := client.ValidateToken(checkCtx); err != nil {
+			auth.Status, auth.Detail = checkFail, err.Error()
+		} else {
+			auth.Detail = "valid"
+			if scopes, known, err := client.TokenScopes(checkCtx); err == nil && known {
+				auth.Detail = fmt.Sprintf("valid, scopes: %s", formatScopes(scopes))
+			}
+		}
+	}
+
+	return []doctorCheck{auth, checkGitHubAPI(ctx, client, timeout)}
+}
+
+// formatScopes lists OAuth scopes for the doctor report
+func formatScopes(scopes []string) string {
+	if len(scopes) == 0 {
+		return "none"
+	}
+	return strings.Join(scopes, ", ")
+}
+
+// checkGitHubAPI checks GitHub API connectivity and the remaining budget
+func checkGitHubAPI(ctx context.Context, client *github.GitHubClient, timeout time.Duration) doctorCheck {
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	info, err := client.GetRateLimitInfo(checkCtx)
+	if err != nil {
+		return doctorCheck{Name: "GitHub API", Status: checkFail, Detail: err.Error()}
+	}
+
+	check := doctorCheck{
+		Name:   "GitHub API",
+		Status: checkPass,
+		Detail: formatRateLimit(info, time.Now()),
+	}
+	if info.Remaining < info.Limit/10 {
+		check.Status = checkWarn
+	}
+	return check
+}
+
+// checkBitbucketCloud validates the Bitbucket Cloud credentials
+func checkBitbucketCloud(ctx context.Context, config *Config, timeout time.Duration, logger shared.Logger) doctorCheck {
+	check := doctorCheck{Name: "Bitbucket Cloud"}
+	if config.BitbucketAPIToken == "" {
+		check.Status, check.Detail = checkSkip, "not configured"
+		return check
+	}
+
+	client := bitbucket.NewBitbucketClient(&bitbucket.BitbucketClientConfig{
+		Username:  config.BitbucketUsername,
+		Email:     config.BitbucketEmail,
+		APIToken:  config.BitbucketAPIToken,
+		UserAgent: version.UserAgent(),
+		Timeout:   timeout,
+		Transport: config.Transport,
+		Logger:    logger,
+	})
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := client.ValidateCredentials(checkCtx); err != nil {
+		check.Status, check.Detail = checkFail, err.Error()
+		return check
+	}
+	check.Detail = "credentials valid"
+	return check
+}
+
+// checkBitbucketServer validates the Bitbucket Server instance and token
+func checkBitbucketServer(ctx context.Context, config *Config, timeout time.Duration, logger shared.Logger) doctorCheck {
+	check := doctorCheck{Name: "Bitbucket Server"}
+	if config.BitbucketServerURL == "" {
+		check.Status, check.Detail = checkSkip, "not configured"
+		return check
+	}
+
+	client, err := bitbucket.NewBitbucketServerClient(&bitbucket.BitbucketServerClientConfig{
+		BaseURL:   config.BitbucketServerURL,
+		Token:     config.BitbucketServerToken,
+		UserAgent: version.UserAgent(),
+		Timeout:   timeout,
+		Transport: config.Transport,
+		Logger:    logger,
+	})
+	if err != nil {
+		check.Status, check.Detail = checkFail, err.Error()
+		return check
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := client.ValidateCredentials(checkCtx); err != nil {
+		check.Status, check.Detail = checkFail, err.Error()
+		return check
+	}
+
+	check.Detail = client.Host() + " reachable"
+	if config.BitbucketServerToken == "" {
+		check.Status = checkWarn
+		check.Detail += ", no access token configured"
+	}
+	return check
+}
+
+// checkGitLab reports the GitLab token, which is only handed to git
+func checkGitLab(config *Config) doctorCheck {
+	if config.GitLabToken == "" {
+		return doctorCheck{Name: "GitLab", Status: checkSkip, Detail: "not configured"}
+	}
+	return doctorCheck{Name: "GitLab", Status: checkPass, Detail: "token configured for git over HTTPS"}
+}
+
+// checkBaseDirWritable verifies that files can be created in the base
+// directory, or in its closest existing parent when it does not exist yet
+func checkBaseDirWritable(baseDir string) doctorCheck {
+	check := doctorCheck{Name: "Base directory"}
+
+	dir, err := diskspace.ExistingParent(baseDir)
+	if err != nil {
+		check.Status, check.Detail = checkFail, err.Error()
+		return check
+	}
+
+	file, err := os.CreateTemp(dir, ".repocloner-doctor-*")
+	if err != nil {
+		check.Status, check.Detail = checkFail, fmt.Sprintf("%s is not writable: %v", dir, err)
+		return check
+	}
+	_ = file.Close()
+	_ = os.Remove(file.Name())
+
+	check.Status, check.Detail = checkPass, dir+" is writable"
+	if abs, err := filepath.Abs(baseDir); err == nil && abs != dir {
+		check.Detail = fmt.Sprintf("%s will be created, %s is writable", abs, dir)
+	}
+	return check
+}
+
+// checkFreeSpace measures the free space of the base directory
+func checkFreeSpace(baseDir string, minFreeGB int) doctorCheck {
+	check := doctorCheck{Name: "Free disk space"}
+
+	free, err := diskspace.Free(baseDir)
+	if errors.Is(err, diskspace.ErrUnsupported) {
+		check.Status, check.Detail = checkSkip, err.Error()
+		return check
+	}
+	if err != nil {
+		check.Status, check.Detail = checkFail, err.Error()
+		return check
+	}
+
+	check.Status, check.Detail = checkPass, clonetui.FormatBytes(int64(free))+" available"
+	if minimum := uint64(minFreeGB) << 30; free < minimum {
+		check.Status = checkWarn
+		check.Detail += fmt.Sprintf(", below %d GB", minFreeGB)
+	}
+	return check
+}
+
+// writeDoctorReport prints the checks with a colored status and returns the
+// number of failed checks
+func writeDoctorReport(out io.Writer, checks []doctorCheck) int {
+	styles := map[checkStatus]lipgloss.Style{
+		checkPass: lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575")),
+		checkWarn: lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAF00")),
+		checkFail: lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87")),
+		checkSkip: lipgloss.NewStyle().Foreground(lipgloss.Color("#626262")),
+	}
+	icons := map[checkStatus]string{checkPass: "✓", checkWarn: "!", checkFail: "✗", checkSkip: "-"}
+
+	width := 0
+	for _, check := range checks {
+		width = max(width, len(check.Name))
+	}
+
+	counts := make(map[checkStatus]int)
+	for _, check := range checks {
+		counts[check.Status]++
+		style := styles[check.Status]
+		fmt.Fprintf(out, "%s %-*s  %s\n", style.Render(icons[check.Status]), width, check.Name, check.Detail)
+	}
+
+	fmt.Fprintf(out, "\n%d checks: %d passed, %d warnings, %d failed, %d skipped\n",
+		len(checks), counts[checkPass], counts[checkWarn], counts[checkFail], counts[checkSkip])
+	return counts[checkFail]
+}
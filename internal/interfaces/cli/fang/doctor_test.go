@@ -0,0 +1,58 @@
+package fang
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteDoctorReport(t *testing.T) {
+	checks := []doctorCheck{
+		{Name: "git", Status: checkPass, Detail: "git version 2.49.0"},
+		{Name: "git-lfs", Status: checkWarn, Detail: "not installed"},
+		{Name: "GitHub API", Status: checkFail, Detail: "connection refused"},
+		{Name: "GitLab", Status: checkSkip, Detail: "not configured"},
+	}
+
+	var out bytes.Buffer
+	failed := writeDoctorReport(&out, checks)
+
+	assert.Equal(t, 1, failed)
+	assert.Contains(t, out.String(), "git version 2.49.0")
+	assert.Contains(t, out.String(), "GitHub API  connection refused")
+	assert.Contains(t, out.String(), "4 checks: 1 passed, 1 warnings, 1 failed, 1 skipped")
+}
+
+func TestCheckBaseDirWritable(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		baseDir string
+		status  checkStatus
+	}{
+		{name: "existing directory", baseDir: dir, status: checkPass},
+		{name: "directory to be created", baseDir: filepath.Join(dir, "a", "b"), status: checkPass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkBaseDirWritable(tt.baseDir)
+			assert.Equal(t, tt.status, check.Status, check.Detail)
+		})
+	}
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "the probe file must be removed")
+}
+
+func TestCheckFreeSpace(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.Equal(t, checkPass, checkFreeSpace(dir, 0).Status)
+	assert.Equal(t, checkWarn, checkFreeSpace(dir, 1<<30).Status)
+}
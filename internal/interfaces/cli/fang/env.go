@@ -0,0 +1,72 @@
+package fang
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// EnvPrefix prefixes the environment variables that set flags
+const EnvPrefix = "GHCLONE_"
+
+// legacyEnv maps flags to the provider environment variables they accepted
+// before GHCLONE_ variables existed. They keep working at lower precedence.
+var legacyEnv = map[string]string{
+	"token":                      "GITHUB_TOKEN",
+	"github-app-id":              "GITHUB_APP_ID",
+	"github-app-installation-id": "GITHUB_APP_INSTALLATION_ID",
+	"github-app-private-key":     "GITHUB_APP_PRIVATE_KEY_PATH",
+	"bitbucket-api-token":        "BITBUCKET_API_TOKEN",
+	"bitbucket-email":            "BITBUCKET_EMAIL",
+	"bitbucket-username":         "BITBUCKET_USERNAME",
+	"bitbucket-server-url":       "BITBUCKET_SERVER_URL",
+	"bitbucket-server-token":     "BITBUCKET_SERVER_TOKEN",
+	"bitbucket-server-username":  "BITBUCKET_SERVER_USERNAME",
+	"gitlab-token":               "GITLAB_TOKEN",
+}
+
+// EnvName returns the environment variable setting a flag: --skip-forks is
+// GHCLONE_SKIP_FORKS
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// applyEnv sets every flag of cmd that was not given on the command line from
+// the environment. Precedence, highest first: command-line flag,
+// GHCLONE_<FLAG>, legacy provider variable (e.g. GITHUB_TOKEN), flag default.
+func applyEnv(cmd *cobra.Command) error {
+	var errs []error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed {
+			return
+		}
+
+		name, value, ok := lookupFlagEnv(flag.Name)
+		if !ok {
+			return
+		}
+		if err := cmd.Flags().Set(flag.Name, value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s: %w", name, err))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// lookupFlagEnv returns the environment variable that sets a flag and its value
+func lookupFlagEnv(flag string) (string, string, bool) {
+	names := []string{EnvName(flag)}
+	if legacy, ok := legacyEnv[flag]; ok {
+		names = append(names, legacy)
+	}
+
+	for _, name := range names {
+		if value, ok := os.LookupEnv(name); ok && value != "" {
+			return name, value, true
+		}
+	}
+	return "", "", false
+}
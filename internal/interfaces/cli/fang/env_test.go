@@ -0,0 +1,68 @@
+package fang
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvName(t *testing.T) {
+	assert.Equal(t, "GHCLONE_SKIP_FORKS", EnvName("skip-forks"))
+	assert.Equal(t, "GHCLONE_CONCURRENCY", EnvName("concurrency"))
+}
+
+func TestApplyEnv_Precedence(t *testing.T) {
+	t.Setenv("GHCLONE_CONCURRENCY", "3")
+	t.Setenv("GHCLONE_LOG_LEVEL", "debug")
+	t.Setenv("GHCLONE_SKIP_FORKS", "false")
+	t.Setenv("GITHUB_TOKEN", "legacy")
+	t.Setenv("GHCLONE_BITBUCKET_EMAIL", "me@example.com")
+	t.Setenv("BITBUCKET_EMAIL", "legacy@example.com")
+	t.Setenv("GHCLONE_AUTH_CLONES", "true")
+	t.Setenv("GHCLONE_STOP_AT_FREE_SPACE", "10GB")
+
+	var skipForks bool
+	cmd := &cobra.Command{Use: "test", RunE: func(*cobra.Command, []string) error { return nil }}
+	root := NewRootCommand()
+	root.AddCommand(cmd)
+	cmd.Flags().BoolVar(&skipForks, "skip-forks", true, "")
+
+	root.SetArgs([]string{"test", "--log-level", "warn"})
+	require.NoError(t, root.Execute())
+
+	config, err := getGlobalConfig(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, 3, config.Concurrency)
+	assert.Equal(t, "warn", config.LogLevel, "command-line flags win")
+	assert.Equal(t, "legacy", config.Token)
+	assert.Equal(t, "me@example.com", config.BitbucketEmail, "GHCLONE_ variables win over legacy ones")
+	assert.True(t, config.AuthClones)
+	assert.Equal(t, int64(10<<30), config.StopAtFreeSpace)
+	assert.False(t, skipForks)
+}
+
+func TestAuthClones_OffByDefault(t *testing.T) {
+	cmd := &cobra.Command{Use: "test", RunE: func(*cobra.Command, []string) error { return nil }}
+	root := NewRootCommand()
+	root.AddCommand(cmd)
+	root.SetArgs([]string{"test"})
+	require.NoError(t, root.Execute())
+
+	config, err := getGlobalConfig(cmd)
+	require.NoError(t, err)
+	assert.False(t, config.AuthClones, "tokens reach git only with --auth-clones")
+}
+
+func TestApplyEnv_InvalidValue(t *testing.T) {
+	t.Setenv("GHCLONE_CONCURRENCY", "many")
+
+	root := NewRootCommand()
+	root.AddCommand(&cobra.Command{Use: "test", RunE: func(*cobra.Command, []string) error { return nil }})
+	root.SetArgs([]string{"test"})
+
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GHCLONE_CONCURRENCY")
+}
@@ -0,0 +1,106 @@
+package fang
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// ExclusionConfig holds the boilerplate repository filters shared by the list and clone commands
+type ExclusionConfig struct {
+	SkipTemplates bool
+	SkipDotRepos  bool
+	IgnoreFile    string // Ignore list read after the automatic ones
+	NoIgnore      bool   // Skip the automatic ignore lists
+	Where         string // Filter expression, see repository.FilterExpression
+
+	ignore      *repository.IgnoreList // Loaded by the first apply
+	ignoreFiles []string               // Files the ignore list was read from
+}
+
+// addExclusionFlags registers the boilerplate repository filters on a command
+func addExclusionFlags(cmd *cobra.Command, config *ExclusionConfig) {
+	cmd.Flags().BoolVar(&config.SkipTemplates, "skip-templates", false, "Skip template repositories")
+	cmd.Flags().BoolVar(&config.SkipDotRepos, "skip-dot-repos", false, "Skip dot-repos such as .github and *.wiki repositories")
+	cmd.Flags().StringVar(&config.IgnoreFile, "ignore-file", "", "Skip repositories matching the patterns of this file, in addition to "+repository.IgnoreFileName+" files")
+	cmd.Flags().BoolVar(&config.NoIgnore, "no-ignore", false, "Do not read "+repository.IgnoreFileName+" from the base and config directories")
+	cmd.Flags().StringVar(&config.Where, "where", "", `Keep repositories matching a filter expression, e.g. "language==go && size<50MB && !fork"`)
+}
+
+// apply copies the exclusions into a repository filter, loading the ignore
+// lists of the base and config directories on first use
+func (c *ExclusionConfig) apply(filter *repository.RepositoryFilter, baseDir string) error {
+	filter.ExcludeTemplates = c.SkipTemplates
+	filter.ExcludeDotRepos = c.SkipDotRepos
+
+	if c.Where != "" {
+		expression, err := repository.ParseFilterExpression(c.Where)
+		if err != nil {
+			return err
+		}
+		filter.Expression = expression
+	}
+
+	if c.ignore == nil {
+		if err := c.loadIgnore(baseDir); err != nil {
+			return err
+		}
+	}
+	if c.ignore.Len() > 0 {
+		filter.Ignore = c.ignore
+	}
+	return nil
+}
+
+// loadIgnore reads the ignore list of the user config directory, then of the
+// base directory, then --ignore-file; later patterns take precedence
+func (c *ExclusionConfig) loadIgnore(baseDir string) error {
+	c.ignore = &repository.IgnoreList{}
+
+	if !c.NoIgnore {
+		for _, path := range ignoreFilePaths(baseDir) {
+			if err := c.readIgnoreFile(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	if c.IgnoreFile != "" {
+		if err := c.readIgnoreFile(c.IgnoreFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readIgnoreFile merges the patterns of an ignore file
+func (c *ExclusionConfig) readIgnoreFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	list, err := repository.ParseIgnoreList(file)
+	if err != nil {
+		return fmt.Errorf("invalid ignore file %s: %w", path, err)
+	}
+	c.ignore.Merge(list)
+	c.ignoreFiles = append(c.ignoreFiles, path)
+	return nil
+}
+
+// ignoreFilePaths returns the automatic ignore list locations
+func ignoreFilePaths(baseDir string) []string {
+	var paths []string
+	if configDir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(configDir, "repocloner", repository.IgnoreFileName))
+	}
+	return append(paths, filepath.Join(baseDir, repository.IgnoreFileName))
+}
@@ -0,0 +1,70 @@
+package fang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func TestExclusionConfig_IgnoreFiles(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("AppData", configHome)
+	t.Setenv("HOME", configHome)
+	configDir, err := os.UserConfigDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "repocloner"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "repocloner", repository.IgnoreFileName), []byte("demo-*\n"), 0644))
+
+	baseDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, repository.IgnoreFileName), []byte("!demo-app\n[acme]\nsandbox\n"), 0644))
+
+	repo := func(owner, name string) *repository.Repository {
+		return &repository.Repository{Owner: owner, Name: name, CloneURL: "https://github.com/" + owner + "/" + name + ".git"}
+	}
+
+	t.Run("automatic", func(t *testing.T) {
+		var config ExclusionConfig
+		filter := repository.NewRepositoryFilter()
+		require.NoError(t, config.apply(filter, baseDir))
+
+		assert.Len(t, config.ignoreFiles, 2)
+		assert.False(t, filter.ShouldInclude(repo("acme", "demo-site")))
+		assert.True(t, filter.ShouldInclude(repo("acme", "demo-app")), "base directory overrides config directory")
+		assert.False(t, filter.ShouldInclude(repo("acme", "sandbox")))
+		assert.True(t, filter.ShouldInclude(repo("other", "sandbox")))
+	})
+
+	t.Run("no-ignore with explicit file", func(t *testing.T) {
+		extra := filepath.Join(t.TempDir(), "extra")
+		require.NoError(t, os.WriteFile(extra, []byte("sandbox\n"), 0644))
+
+		config := ExclusionConfig{NoIgnore: true, IgnoreFile: extra}
+		filter := repository.NewRepositoryFilter()
+		require.NoError(t, config.apply(filter, baseDir))
+
+		assert.Equal(t, []string{extra}, config.ignoreFiles)
+		assert.True(t, filter.ShouldInclude(repo("acme", "demo-site")))
+		assert.False(t, filter.ShouldInclude(repo("other", "sandbox")))
+	})
+
+	t.Run("missing explicit file", func(t *testing.T) {
+		config := ExclusionConfig{IgnoreFile: filepath.Join(baseDir, "missing")}
+		assert.Error(t, config.apply(repository.NewRepositoryFilter(), baseDir))
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "invalid")
+		require.NoError(t, os.WriteFile(invalid, []byte("[acme\n"), 0644))
+
+		config := ExclusionConfig{NoIgnore: true, IgnoreFile: invalid}
+		err := config.apply(repository.NewRepositoryFilter(), baseDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 1")
+	})
+}
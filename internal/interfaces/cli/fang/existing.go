@@ -0,0 +1,46 @@
+package fang
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// ExistingConfig holds the flags deciding how clone commands treat
+// destinations that already exist
+type ExistingConfig struct {
+	Action     string // Destination holding a clone of the same remote: skip or update
+	OnConflict string // Destination holding a different remote: skip, rename or error
+}
+
+// addExistingFlags registers the existing destination flags on a clone command
+func addExistingFlags(cmd *cobra.Command, config *ExistingConfig) {
+	cmd.Flags().StringVar(&config.Action, "existing", string(cloning.ExistingSkip),
+		"Existing clones of the same remote: skip, or update (git fetch and pull --ff-only)")
+	completeFlag(cmd, "existing", string(cloning.ExistingSkip), string(cloning.ExistingUpdate))
+	cmd.Flags().StringVar(&config.OnConflict, "on-conflict", string(cloning.ConflictSkip),
+		"When a destination holds a clone of a different remote: skip, rename (to <name>-<owner>) or error")
+	completeFlag(cmd, "on-conflict", string(cloning.ConflictSkip), string(cloning.ConflictRename), string(cloning.ConflictError))
+}
+
+// validate checks the flag values, normalizing them for apply
+func (c *ExistingConfig) validate() error {
+	action, err := cloning.ParseExistingAction(c.Action)
+	if err != nil {
+		return err
+	}
+	c.Action = string(action)
+
+	policy, err := cloning.ParseConflictPolicy(c.OnConflict)
+	if err != nil {
+		return err
+	}
+	c.OnConflict = string(policy)
+	return nil
+}
+
+// apply copies the validated settings into clone options
+func (c *ExistingConfig) apply(options *cloning.CloneOptions) {
+	options.Existing = cloning.ExistingAction(c.Action)
+	options.OnConflict = cloning.ConflictPolicy(c.OnConflict)
+}
@@ -0,0 +1,122 @@
+package fang
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+// Process exit codes, stable for scripts and CI jobs
+const (
+	ExitOK             = 0   // Every repository was cloned, or failures were tolerated by --fail-on
+	ExitError          = 1   // Invalid usage, configuration or unexpected errors
+	ExitPartialFailure = 2   // Some repositories failed to clone
+	ExitTotalFailure   = 3   // Every repository failed to clone
+	ExitAuthError      = 4   // Authentication was rejected by the provider or git
+	ExitRateLimited    = 5   // The provider API rate limit is exhausted
+	ExitTimeout        = 6   // The clone run hit its deadline
+	ExitCancelled      = 130 // Cloning was cancelled by the user
+)
+
+// ExitCodeError carries the process exit code for an error
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode maps an error returned by Execute to a process exit code
+func ExitCode(err error) int {
+	var exitErr *ExitCodeError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &exitErr):
+		return exitErr.Code
+	case errors.Is(err, repository.ErrAuthenticationFailed):
+		return ExitAuthError
+	case errors.Is(err, repository.ErrRateLimitExceeded):
+		return ExitRateLimited
+	default:
+		return ExitError
+	}
+}
+
+// addFailOnFlag registers the --fail-on policy flag of clone commands
+func addFailOnFlag(cmd *cobra.Command, failOn *string) {
+	cmd.Flags().StringVar(failOn, "fail-on", string(cloning.FailOnAny),
+		"When failed clones fail the command: any, none or threshold=N%")
+}
+
+// cloneResultError applies the failure policy to a finished clone run,
+// returning an error carrying the exit code when the run must fail
+func cloneResultError(resp *usecases.CloneRepositoriesResponse, policy *cloning.FailurePolicy) error {
+	if resp == nil {
+		return nil
+	}
+
+	if resp.CancelledJobs > 0 {
+		code, reason := ExitCancelled, "cloning cancelled"
+		if runTimedOut(resp) {
+			code, reason = ExitTimeout, "clone run timed out"
+		}
+		return &ExitCodeError{
+			Code: code,
+			Err:  fmt.Errorf("%s: %d repositories were not cloned", reason, resp.CancelledJobs),
+		}
+	}
+
+	failed := clonetui.FailedResults(resp)
+	total := resp.TotalJobs
+	if !policy.Exceeded(total, len(failed)) {
+		return nil
+	}
+
+	code := ExitPartialFailure
+	if allAuthenticationFailures(failed) {
+		code = ExitAuthError
+	} else if len(failed) == total {
+		code = ExitTotalFailure
+	}
+
+	return &ExitCodeError{
+		Code: code,
+		Err:  fmt.Errorf("%d of %d repositories failed to clone (--fail-on %s)", len(failed), total, policy),
+	}
+}
+
+// allAuthenticationFailures reports whether every failure was rejected credentials
+func allAuthenticationFailures(failed []*cloning.JobResult) bool {
+	for _, result := range failed {
+		var authErr *git.AuthenticationError
+		if !errors.As(result.Job.Error, &authErr) {
+			return false
+		}
+	}
+	return len(failed) > 0
+}
+
+// runTimedOut reports whether the cancelled jobs of a run were stopped by the
+// run deadline rather than by the user
+func runTimedOut(resp *usecases.CloneRepositoriesResponse) bool {
+	for _, result := range resp.Results {
+		if result.Job.Status == cloning.JobStatusCancelled && errors.Is(result.Job.Error, cloning.ErrCloneTimeout) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package fang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+func TestCloneResultError_Cancelled(t *testing.T) {
+	cancelled := &cloning.CloneJob{}
+	cancelled.MarkCancelled()
+	timedOut := &cloning.CloneJob{}
+	timedOut.MarkTimedOut()
+
+	tests := []struct {
+		name     string
+		job      *cloning.CloneJob
+		expected int
+		message  string
+	}{
+		{name: "cancelled by the user", job: cancelled, expected: ExitCancelled, message: "cloning cancelled"},
+		{name: "run deadline", job: timedOut, expected: ExitTimeout, message: "clone run timed out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &usecases.CloneRepositoriesResponse{
+				TotalJobs:     1,
+				CancelledJobs: 1,
+				Results:       []*cloning.JobResult{cloning.NewJobResult(tt.job, false, 0)},
+			}
+			err := cloneResultError(resp, &cloning.FailurePolicy{})
+			assert.Equal(t, tt.expected, ExitCode(err))
+			assert.ErrorContains(t, err, tt.message)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package fang
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+// GCConfig holds gc command configuration
+type GCConfig struct {
+	Aggressive bool
+	Prune      string // Expiry date of unreachable objects, empty for the git default
+	Depth      int
+}
+
+// NewGCCommand creates the gc command repacking the repositories of a clone tree
+func NewGCCommand() *cobra.Command {
+	var config GCConfig
+
+	cmd := &cobra.Command{
+		Use:   "gc [dir]",
+		Short: "Garbage collect and repack the repositories of a clone tree",
+		Long: `Run git gc in every repository found below a directory, --concurrency
+repositories at a time, and report the disk space reclaimed.
+
+The directory defaults to --base-dir. Collection is aggressive by default,
+which compresses best but takes longest; --aggressive=false runs a regular gc.`,
+		Example: `  # Repack every clone under ./mirrors
+  repocloner gc ./mirrors
+
+  # A quicker regular gc that also prunes every unreachable object
+  repocloner gc ./mirrors --aggressive=false --prune now`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := ""
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return runGC(cmd, dir, &config)
+		},
+	}
+
+	cmd.Flags().BoolVar(&config.Aggressive, "aggressive", true, "Run git gc --aggressive")
+	cmd.Flags().StringVar(&config.Prune, "prune", "", "Prune unreachable objects older than this date, e.g. now or 2.weeks.ago (default: git's gc.pruneExpire)")
+	cmd.Flags().IntVar(&config.Depth, "scan-depth", 3, "Maximum directory depth to search for repositories")
+
+	return cmd
+}
+
+// args returns the git gc arguments of the configuration
+func (c *GCConfig) args() []string {
+	var args []string
+	if c.Aggressive {
+		args = append(args, "--aggressive")
+	}
+	if c.Prune != "" {
+		args = append(args, "--prune="+c.Prune)
+	}
+	return args
+}
+
+// runGC executes the gc command
+func runGC(cmd *cobra.Command, dir string, config *GCConfig) error {
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+	if dir == "" {
+		dir = globalConfig.BaseDir
+	}
+
+	logger, err := logging.NewConsoleLogger("warn", false)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	out := cmd.OutOrStdout()
+	tracker := cloning.NewProgressTracker(0)
+	printed := make(chan struct{})
+	go func() {
+		defer close(printed)
+		printJobProgress(out, tracker.Subscribe())
+	}()
+
+	resp, err := usecases.NewGarbageCollectUseCase(logger).Execute(cmd.Context(), &usecases.GarbageCollectRequest{
+		BaseDirectory:   dir,
+		MaxDepth:        config.Depth,
+		Concurrency:     globalConfig.Concurrency,
+		Args:            config.args(),
+		ProgressTracker: tracker,
+	})
+	<-printed
+	if err != nil {
+		return fmt.Errorf("failed to garbage collect %s: %w", dir, err)
+	}
+
+	writeGCSummary(out, resp)
+
+	if resp.Failed > 0 {
+		return &ExitCodeError{
+			Code: ExitPartialFailure,
+			Err:  fmt.Errorf("%d repositories failed to garbage collect", resp.Failed),
+		}
+	}
+	return nil
+}
+
+// writeGCSummary prints the outcome of a gc run
+func writeGCSummary(out io.Writer, resp *usecases.GarbageCollectResponse) {
+	if resp.Collected+resp.Failed == 0 {
+		fmt.Fprintln(out, "No repositories found.")
+		return
+	}
+
+	reclaimed := clonetui.FormatBytes(max(resp.Reclaimed(), 0))
+	fmt.Fprintf(out, "\nRepositories collected: %d, failed: %d in %s\n",
+		resp.Collected, resp.Failed, resp.TotalDuration.Round(100*time.Millisecond))
+	fmt.Fprintf(out, "Reclaimed %s (%s before, %s after)\n",
+		reclaimed, clonetui.FormatBytes(resp.SizeBefore), clonetui.FormatBytes(resp.SizeAfter))
+
+	for _, result := range resp.Results {
+		if result.Error != nil {
+			fmt.Fprintf(out, "  %s: %v\n", result.Path, result.Error)
+		}
+	}
+}
@@ -4,8 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"sort"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,6 +16,10 @@ import (
 	"github.com/italoag/repocloner/internal/domain/repository"
 	"github.com/italoag/repocloner/internal/infrastructure/github"
 	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/infrastructure/metadata"
+	"github.com/italoag/repocloner/internal/infrastructure/providers"
+	"github.com/italoag/repocloner/internal/interfaces/output"
+	"github.com/italoag/repocloner/internal/version"
 )
 
 // ListConfig holds list command configuration
@@ -23,40 +28,80 @@ type ListConfig struct {
 	Owner        string
 	SkipForks    bool
 	Format       string
-	Sort         string
+	Sort         string // Comma-separated sort keys, e.g. size,-updated
+	SortKeys     []repository.SortKey
 	Limit        int
 	MinSize      int64
 	MaxSize      int64
 	Language     string
 	UpdatedAfter time.Time
+	Stats        bool
+	Page         int
+	PerPage      int
+	MaxPages     int
+	Teams        TeamConfig
+	Visibility   string
+	Exclusions   ExclusionConfig
+	Changed      bool     // Only repositories new or changed since the last --metadata-db snapshot
+	Output       string   // File to write instead of stdout
+	Provider     string   // Provider listing the owner, implied by the owner type by default
+	Providers    []string // List the owner on each of these providers concurrently
+	Columns      ColumnConfig
 }
 
+// recentlyPushedCount is the number of repositories listed by --stats
+const recentlyPushedCount = 5
+
 // NewListCommand creates the list subcommand
 func NewListCommand() *cobra.Command {
 	var listConfig ListConfig
 
 	cmd := &cobra.Command{
 		Use:   "list [type] [owner]",
-		Short: "List repositories from a GitHub user or organization",
-		Long: `List repositories from a GitHub user or organization with advanced filtering options.
+		Short: "List repositories from a GitHub, Bitbucket or Bitbucket Server owner",
+		Long: `List repositories from a GitHub user or organization, a Bitbucket user or
+workspace or a Bitbucket Server project with advanced filtering options.
 
-The list command fetches repository information from GitHub and displays it in various
+The list command fetches repository information from the provider and displays it in various
 formats including table, JSON, and CSV. It supports comprehensive filtering by size,
 language, fork status, and update date.
 
 Repository Types:
-  user, users         List repositories from a GitHub user account
+  user, users         List repositories from a user account
   org, orgs           List repositories from a GitHub organization
+  workspace, ws       List repositories from a Bitbucket workspace
+  project, projects   List repositories from a Bitbucket Server project
+
+--provider picks the provider of a user or org: with --provider bitbucket, org
+lists the workspace of that name and user a Bitbucket user. Workspaces and
+projects imply their provider. Bitbucket needs the credentials of the
+bitbucket command, Bitbucket Server --bitbucket-server-url.
 
 Output Formats:
   table              Human-readable table format (default)
   json               JSON format for programmatic processing
   csv                CSV format for spreadsheet import
+  parquet            Apache Parquet file for data pipelines (requires --out)
+
+With --out the output is written to a file; unless --format is set, the
+format follows the file extension (.json, .csv or .parquet).
 
 Sorting Options:
   name               Sort by repository name (default)
   size               Sort by repository size (largest first)
-  updated            Sort by last update time (most recent first)`,
+  updated            Sort by last update time (most recent first)
+  pushed             Sort by last push time (most recent first)
+  full_name, owner, language
+
+--sort takes several comma-separated keys in order of precedence, such as
+size,-updated. A - prefix sorts a key descending and + ascending.
+
+Rows are printed as pages arrive from the API when sorting by name or
+updated alone; other orders and --stats need every repository first.
+
+With --providers github,bitbucket the owner is listed on GitHub and Bitbucket
+(the workspace of the same name for org) concurrently, and the merged results
+are printed with a provider column.`,
 		Example: `  # List user repositories in table format
   repocloner list user octocat
 
@@ -67,8 +112,40 @@ Sorting Options:
   repocloner list user torvalds --include-forks --language c --limit 20
 
   # List repositories by size with custom filters
-  repocloner list org kubernetes --sort size --min-size 1000000 --format csv`,
-		Args: cobra.ExactArgs(2),
+  repocloner list org kubernetes --sort size --min-size 1000000 --format csv
+
+  # Group by language, largest first within each language
+  repocloner list org kubernetes --sort language,size
+
+  # Inventory every repository of an organization for a data pipeline
+  repocloner list org microsoft --include-forks --out microsoft.parquet
+
+  # Print the first 50 repositories of a very large organization
+  repocloner list org microsoft --limit 50 | head
+
+  # Fetch only pages 3 to 4 with 50 repositories per page
+  repocloner list org microsoft --page 3 --per-page 50 --max-pages 2
+
+  # List the repositories a team maintains
+  repocloner list org myorg --team platform --min-permission maintain
+
+  # Estimate a clone run: total size, languages, forks and archived repositories
+  repocloner list org kubernetes --stats
+
+  # List a Bitbucket workspace or a Bitbucket Server project
+  repocloner list workspace acme --format csv
+  repocloner list project PROJ --bitbucket-server-url https://bitbucket.example.com
+
+  # List the repositories of a Bitbucket user
+  repocloner list user octocat --provider bitbucket
+
+  # Merge the GitHub organization and the Bitbucket workspace named acme
+  repocloner list org acme --providers github,bitbucket
+
+  # Repositories pushed, updated or added since the previous recorded listing
+  repocloner list org kubernetes --metadata-db ghclone.db --changed`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeTypeOwner(listTypes, false),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runListCommand(cmd, args, &listConfig)
 		},
@@ -77,13 +154,30 @@ Sorting Options:
 	// Command-specific flags
 	cmd.Flags().BoolVar(&listConfig.SkipForks, "skip-forks", true, "Skip forked repositories")
 	cmd.Flags().Bool("include-forks", false, "Include forked repositories (inverse of --skip-forks)")
-	cmd.Flags().StringVar(&listConfig.Format, "format", "table", "Output format (table, json, csv)")
-	cmd.Flags().StringVar(&listConfig.Sort, "sort", "name", "Sort by field (name, size, updated)")
+	cmd.Flags().StringVar(&listConfig.Format, "format", "table", "Output format (table, json, csv, parquet)")
+	cmd.Flags().StringVar(&listConfig.Sort, "sort", "name", "Sort keys in order of precedence, - for descending (name, size, updated, pushed, ...), e.g. size,-updated")
+	cmd.Flags().StringVarP(&listConfig.Output, "out", "o", "", "Output file (default: stdout)")
+	completeFlag(cmd, "format", "table", "json", "csv", "parquet")
+	completeFlag(cmd, "sort", repository.SortFieldNames...)
 	cmd.Flags().IntVar(&listConfig.Limit, "limit", -1, "Limit number of results")
 	cmd.Flags().Int64Var(&listConfig.MinSize, "min-size", 0, "Minimum repository size in bytes")
 	cmd.Flags().Int64Var(&listConfig.MaxSize, "max-size", -1, "Maximum repository size in bytes")
 	cmd.Flags().StringVar(&listConfig.Language, "language", "", "Filter by programming language")
 	cmd.Flags().String("updated-after", "", "Filter repositories updated after date (YYYY-MM-DD)")
+	cmd.Flags().IntVar(&listConfig.Page, "page", 1, "First API page to fetch")
+	cmd.Flags().IntVar(&listConfig.PerPage, "per-page", 100, "Repositories per API page (1-100)")
+	cmd.Flags().IntVar(&listConfig.MaxPages, "max-pages", 0, "Maximum number of API pages to fetch (0 for all)")
+	addTeamFlags(cmd, &listConfig.Teams)
+	addVisibilityFlag(cmd, &listConfig.Visibility)
+	addExclusionFlags(cmd, &listConfig.Exclusions)
+	cmd.Flags().BoolVar(&listConfig.Changed, "changed", false, "Only list repositories new or changed since the last snapshot in --metadata-db")
+	cmd.Flags().BoolVar(&listConfig.Stats, "stats", false, "Print aggregate statistics of the matching repositories instead of listing them (table or json)")
+	cmd.Flags().StringSliceVar(&listConfig.Providers, "providers", nil, "List the owner on several providers concurrently and merge the results, e.g. github,bitbucket")
+	completeFlag(cmd, "providers", listProviders...)
+	cmd.Flags().StringVar(&listConfig.Provider, "provider", "", "Provider listing the owner (github, bitbucket, bitbucket-server), implied by workspace and project")
+	completeFlag(cmd, "provider", singleProviders...)
+	cmd.MarkFlagsMutuallyExclusive("provider", "providers")
+	addColumnFlags(cmd, &listConfig.Columns, output.Names(output.RepositoryColumns))
 
 	return cmd
 }
@@ -99,8 +193,12 @@ func runListCommand(cmd *cobra.Command, args []string, listConfig *ListConfig) e
 		listConfig.Type = repository.RepositoryTypeUser
 	case "org", "orgs", "organization":
 		listConfig.Type = repository.RepositoryTypeOrganization
+	case "workspace", "workspaces", "ws":
+		listConfig.Type = repository.RepositoryTypeBitbucketWorkspace
+	case "project", "projects":
+		listConfig.Type = repository.RepositoryTypeBitbucketProject
 	default:
-		return fmt.Errorf("invalid repository type '%s', must be 'user' or 'org'", typeStr)
+		return fmt.Errorf("invalid repository type '%s', must be 'user', 'org', 'workspace' or 'project'", typeStr)
 	}
 
 	listConfig.Owner = owner
@@ -119,20 +217,43 @@ func runListCommand(cmd *cobra.Command, args []string, listConfig *ListConfig) e
 		listConfig.UpdatedAfter = updatedAfter
 	}
 
+	// The extension of --out picks the format unless it is set explicitly
+	if listConfig.Output != "" && !cmd.Flags().Changed("format") {
+		listConfig.Format = formatFromPath(listConfig.Output)
+	}
+
 	// Validate format
 	switch listConfig.Format {
-	case "table", "json", "csv":
+	case "table", "json", "csv", "parquet":
 		// Valid formats
 	default:
-		return fmt.Errorf("invalid format '%s', must be 'table', 'json', or 'csv'", listConfig.Format)
+		return fmt.Errorf("invalid format '%s', must be 'table', 'json', 'csv' or 'parquet'", listConfig.Format)
 	}
 
-	// Validate sort field
-	switch listConfig.Sort {
-	case "name", "size", "updated":
-		// Valid sort fields
-	default:
-		return fmt.Errorf("invalid sort field '%s', must be 'name', 'size', or 'updated'", listConfig.Sort)
+	if listConfig.Format == "parquet" && listConfig.Output == "" {
+		return fmt.Errorf("--format parquet requires --out")
+	}
+
+	if listConfig.Stats && (listConfig.Format == "csv" || listConfig.Format == "parquet") {
+		return fmt.Errorf("--stats supports the 'table' and 'json' formats")
+	}
+
+	// Validate sort keys
+	sortKeys, err := repository.ParseSortKeys(listConfig.Sort)
+	if err != nil {
+		return err
+	}
+	listConfig.SortKeys = sortKeys
+
+	// Validate paging
+	if listConfig.Page < 1 {
+		return fmt.Errorf("--page must be at least 1")
+	}
+	if listConfig.PerPage < 1 || listConfig.PerPage > 100 {
+		return fmt.Errorf("--per-page must be between 1 and 100")
+	}
+	if listConfig.MaxPages < 0 {
+		return fmt.Errorf("--max-pages cannot be negative")
 	}
 
 	// Get global configuration
@@ -141,34 +262,64 @@ func runListCommand(cmd *cobra.Command, args []string, listConfig *ListConfig) e
 		return fmt.Errorf("failed to get global configuration: %w", err)
 	}
 
-	// Override token from environment if not set
-	if globalConfig.Token == "" {
-		globalConfig.Token = os.Getenv("GITHUB_TOKEN")
+	if listConfig.Changed && globalConfig.MetadataDB == "" {
+		return fmt.Errorf("--changed requires --metadata-db")
+	}
+
+	if err := validateProviders(listConfig, globalConfig); err != nil {
+		return err
+	}
+	if len(listConfig.Providers) == 0 {
+		if err := resolveListProvider(listConfig, globalConfig); err != nil {
+			return err
+		}
 	}
 
 	// Execute list operation
-	return executeList(listConfig, globalConfig)
+	if listConfig.Output == "" {
+		return executeList(os.Stdout, listConfig, globalConfig)
+	}
+	return executeListToFile(cmd, listConfig, globalConfig)
 }
 
-// executeList executes the list operation
-func executeList(config *ListConfig, globalConfig *Config) error {
-	// Initialize logger (quiet for listing)
-	logger, err := logging.NewConsoleLogger("warn", false)
+// executeListToFile writes the listing to --out, removing the file when the
+// listing fails
+func executeListToFile(cmd *cobra.Command, config *ListConfig, globalConfig *Config) error {
+	file, err := os.Create(config.Output)
 	if err != nil {
-		return fmt.Errorf("failed to initialize logger: %w", err)
+		return fmt.Errorf("failed to create output file: %w", err)
 	}
 
-	// Initialize GitHub client
-	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{
-		Token:       globalConfig.Token,
-		UserAgent:   "repocloner/0.2",
-		Timeout:     30 * time.Second,
-		RateLimiter: github.NewTokenBucketRateLimiter(5000),
-		Logger:      logger,
-	})
+	err = executeList(file, config, globalConfig)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write output file: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(config.Output)
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %s output to %s\n", config.Format, config.Output)
+	return nil
+}
+
+// formatFromPath derives the list format from the extension of an output file
+func formatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".csv":
+		return "csv"
+	case ".parquet":
+		return "parquet"
+	default:
+		return "table"
+	}
+}
 
-	// Initialize use case
-	fetchUseCase := usecases.NewFetchRepositoriesUseCase(githubClient, nil, logger)
+// executeList executes the list operation, writing the output to w
+func executeList(w io.Writer, config *ListConfig, globalConfig *Config) error {
+	var err error
 
 	// Prepare filter
 	filter := repository.NewRepositoryFilter()
@@ -184,15 +335,74 @@ func executeList(config *ListConfig, globalConfig *Config) error {
 	if config.Language != "" {
 		filter.Languages = []string{config.Language}
 	}
+	if filter.Visibility, err = parseVisibilityFlag(config.Visibility); err != nil {
+		return err
+	}
+	if err := config.Exclusions.apply(filter, globalConfig.BaseDir); err != nil {
+		return err
+	}
+
+	if len(config.Providers) > 0 {
+		return executeProvidersList(w, config, globalConfig, filter)
+	}
 
-	// Fetch repositories
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	fetchUseCase, registry, err := newListFetchUseCase(globalConfig)
+	if err != nil {
+		return err
+	}
+	provider, err := registry.Get(config.Provider)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
 
 	fetchReq := &usecases.FetchRepositoriesRequest{
-		Owner:  config.Owner,
-		Type:   config.Type,
-		Filter: filter,
+		Owner:    config.Owner,
+		Type:     config.Type,
+		Provider: provider.Name(),
+		Filter:   filter,
+		Pagination: &repository.PaginationOptions{
+			Page:     config.Page,
+			PerPage:  config.PerPage,
+			MaxPages: config.MaxPages,
+		},
+	}
+	if err := config.Teams.apply(fetchReq); err != nil {
+		return err
+	}
+
+	printer, err := newRepositoryPrinter(config.Format, w, false, config.Columns)
+	if err != nil {
+		return err
+	}
+
+	store, err := openListingStore(globalConfig)
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		defer func() { _ = store.Close() }()
+	}
+	target := fmt.Sprintf("%s/%s", config.Type, config.Owner)
+
+	// Stream rows as pages arrive when the API can return them in the requested order
+	if providerSort, ok := repository.ProviderSort(config.SortKeys); ok && provider.Capabilities().Sort && !config.Stats && !config.Changed {
+		var listed []*repository.Repository
+		fetchReq.Pagination.Sort = providerSort
+		fetchReq.OnPage = limitPages(config.Limit, func(repos []*repository.Repository) error {
+			listed = append(listed, repos...)
+			return printer.Print(repos)
+		})
+
+		if _, err := fetchUseCase.Execute(ctx, fetchReq); err != nil {
+			return fmt.Errorf("failed to fetch repositories: %w", err)
+		}
+		if err := recordListing(store, target, listed); err != nil {
+			return err
+		}
+		return printer.Close()
 	}
 
 	fetchResp, err := fetchUseCase.Execute(ctx, fetchReq)
@@ -202,8 +412,29 @@ func executeList(config *ListConfig, globalConfig *Config) error {
 
 	repositories := fetchResp.Repositories
 
+	// Compare with the previous snapshot before recording this one
+	if config.Changed {
+		if repositories, err = store.Changed(repositories); err != nil {
+			return err
+		}
+	}
+	if err := recordListing(store, target, fetchResp.Repositories); err != nil {
+		return err
+	}
+
+	return printListing(w, printer, repositories, config)
+}
+
+// printListing prints a complete listing: the statistics of --stats, or the
+// repositories sorted and limited
+func printListing(w io.Writer, printer repositoryPrinter, repositories []*repository.Repository, config *ListConfig) error {
+	// Statistics cover every matching repository, regardless of --limit
+	if config.Stats {
+		return displayStats(w, repository.ComputeStats(repositories, recentlyPushedCount), config)
+	}
+
 	// Sort repositories
-	sortRepositories(repositories, config.Sort)
+	repository.SortRepositories(repositories, config.SortKeys)
 
 	// Apply limit
 	if config.Limit > 0 && len(repositories) > config.Limit {
@@ -211,133 +442,165 @@ func executeList(config *ListConfig, globalConfig *Config) error {
 	}
 
 	// Display results
-	return displayRepositories(repositories, config)
+	if err := printer.Print(repositories); err != nil {
+		return err
+	}
+	return printer.Close()
 }
 
-// sortRepositories sorts repositories by the specified field
-func sortRepositories(repos []*repository.Repository, sortBy string) {
-	switch sortBy {
-	case "name":
-		sort.Slice(repos, func(i, j int) bool {
-			return strings.ToLower(repos[i].Name) < strings.ToLower(repos[j].Name)
-		})
-	case "size":
-		sort.Slice(repos, func(i, j int) bool {
-			return repos[i].Size > repos[j].Size // Largest first
-		})
-	case "updated":
-		sort.Slice(repos, func(i, j int) bool {
-			return repos[i].UpdatedAt.After(repos[j].UpdatedAt) // Most recent first
-		})
+// newGitHubFetchUseCase creates a fetch use case for GitHub listings, logging
+// only warnings to the console
+func newGitHubFetchUseCase(globalConfig *Config) (*usecases.FetchRepositoriesUseCase, error) {
+	consoleLogger, err := logging.NewConsoleLogger("warn", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
 	}
+	logger := logging.NewRedactingLogger(consoleLogger)
+
+	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{
+		Token:       globalConfig.Token,
+		UserAgent:   version.UserAgent(),
+		Timeout:     30 * time.Second,
+		Transport:   globalConfig.Transport,
+		RateLimiter: github.NewRateLimiter(globalConfig.HasGitHubAuth()),
+		Logger:      logger,
+	})
+
+	return usecases.NewFetchRepositoriesUseCase(providers.Default(githubClient, nil, nil), logger), nil
 }
 
-// displayRepositories displays repositories in the specified format
-func displayRepositories(repos []*repository.Repository, config *ListConfig) error {
-	switch config.Format {
-	case "table":
-		return displayTable(repos)
-	case "json":
-		return displayJSON(repos)
-	case "csv":
-		return displayCSV(repos)
-	default:
-		return fmt.Errorf("unsupported format: %s", config.Format)
+// openListingStore opens the metadata database of --metadata-db, or returns
+// nil when none is configured
+func openListingStore(globalConfig *Config) (*metadata.Store, error) {
+	if globalConfig.MetadataDB == "" {
+		return nil, nil
 	}
+	return metadata.Open(globalConfig.MetadataDB)
 }
 
-// displayTable displays repositories in table format
-func displayTable(repos []*repository.Repository) error {
-	if len(repos) == 0 {
-		fmt.Println("No repositories found.")
+// recordListing stores a snapshot of the listed repositories when a metadata
+// database is open
+func recordListing(store *metadata.Store, target string, repos []*repository.Repository) error {
+	if store == nil {
 		return nil
 	}
+	_, err := store.RecordRun(target, repos)
+	return err
+}
 
-	// Print header
-	fmt.Printf("%-30s %-10s %-15s %-8s %-20s\n", "NAME", "SIZE", "LANGUAGE", "FORK", "UPDATED")
-	fmt.Println(strings.Repeat("-", 83))
-
-	// Print repositories
-	for _, repo := range repos {
-		sizeStr := formatSize(repo.Size)
-		language := repo.Language
-		if language == "" {
-			language = "N/A"
+// limitPages wraps a page handler so at most limit repositories are handled,
+// stopping the listing once the limit is reached. A non-positive limit is unlimited.
+func limitPages(limit int, handle repository.PageHandler) repository.PageHandler {
+	handled := 0
+	return func(repos []*repository.Repository) error {
+		if limit > 0 && handled+len(repos) >= limit {
+			if err := handle(repos[:limit-handled]); err != nil {
+				return err
+			}
+			return repository.ErrStopPaging
 		}
-		fork := "No"
-		if repo.IsFork {
-			fork = "Yes"
+		handled += len(repos)
+		return handle(repos)
+	}
+}
+
+// repositoryPrinter writes repositories in an output format as they arrive
+type repositoryPrinter interface {
+	// Print writes a batch of repositories
+	Print(repos []*repository.Repository) error
+
+	// Close completes the output once every batch was printed
+	Close() error
+}
+
+// newRepositoryPrinter creates the printer for the specified format. With
+// provider set, the default columns name the hosting provider of each
+// repository; Parquet output always has the provider column.
+func newRepositoryPrinter(format string, w io.Writer, provider bool, columns ColumnConfig) (repositoryPrinter, error) {
+	if format == "parquet" {
+		if len(columns.Columns) > 0 {
+			return nil, fmt.Errorf("--columns is not supported by the parquet format")
 		}
-		updated := repo.UpdatedAt.Format("2006-01-02")
+		return newParquetPrinter(w), nil
+	}
 
-		fmt.Printf("%-30s %-10s %-15s %-8s %-20s\n",
-			truncateString(repo.Name, 30),
-			sizeStr,
-			truncateString(language, 15),
-			fork,
-			updated)
+	selected, err := output.Select(output.RepositoryColumns, columns.Columns, output.RepositoryColumnNames(format, provider))
+	if err != nil {
+		return nil, err
 	}
+	writer, err := output.NewWriter(w, selected, columns.options(format))
+	if err != nil {
+		return nil, err
+	}
+	return &rowPrinter{
+		w:      w,
+		writer: writer,
+		totals: format == output.FormatTable && !columns.NoHeader,
+	}, nil
+}
 
-	fmt.Printf("\nTotal: %d repositories\n", len(repos))
-	return nil
+// rowPrinter prints repositories with an output writer, ending tables with
+// the number of repositories
+type rowPrinter struct {
+	w      io.Writer
+	writer output.Writer[*repository.Repository]
+	totals bool // Print the total after the rows
+	count  int
 }
 
-// displayJSON displays repositories in JSON format
-func displayJSON(repos []*repository.Repository) error {
-	// Create a simplified structure for JSON output
-	type jsonRepo struct {
-		Name          string    `json:"name"`
-		FullName      string    `json:"full_name"`
-		CloneURL      string    `json:"clone_url"`
-		Size          int64     `json:"size"`
-		Language      string    `json:"language"`
-		Fork          bool      `json:"fork"`
-		DefaultBranch string    `json:"default_branch"`
-		UpdatedAt     time.Time `json:"updated_at"`
-		Description   string    `json:"description,omitempty"`
-	}
-
-	jsonRepos := make([]jsonRepo, len(repos))
-	for i, repo := range repos {
-		jsonRepos[i] = jsonRepo{
-			Name:          repo.Name,
-			FullName:      repo.GetFullName(),
-			CloneURL:      repo.CloneURL,
-			Size:          repo.Size,
-			Language:      repo.Language,
-			Fork:          repo.IsFork,
-			DefaultBranch: repo.DefaultBranch,
-			UpdatedAt:     repo.UpdatedAt,
-			Description:   repo.Description,
-		}
+// Print writes a batch of rows
+func (p *rowPrinter) Print(repos []*repository.Repository) error {
+	p.count += len(repos)
+	return p.writer.Write(repos)
+}
+
+// Close completes the output, followed by the total of tables
+func (p *rowPrinter) Close() error {
+	if err := p.writer.Close(); err != nil || !p.totals {
+		return err
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(jsonRepos)
+	if p.count == 0 {
+		_, err := fmt.Fprintln(p.w, "No repositories found.")
+		return err
+	}
+	_, err := fmt.Fprintf(p.w, "\nTotal: %d repositories\n", p.count)
+	return err
 }
 
-// displayCSV displays repositories in CSV format
-func displayCSV(repos []*repository.Repository) error {
-	// Print CSV header
-	fmt.Println("name,full_name,clone_url,size,language,fork,default_branch,updated_at,description")
-
-	// Print repositories
-	for _, repo := range repos {
-		// Escape quotes in description
-		description := strings.ReplaceAll(repo.Description, `"`, `""`)
-
-		fmt.Printf(`"%s","%s","%s",%d,"%s",%t,"%s","%s","%s"`+"\n",
-			repo.Name,
-			repo.GetFullName(),
-			repo.CloneURL,
-			repo.Size,
-			repo.Language,
-			repo.IsFork,
-			repo.DefaultBranch,
-			repo.UpdatedAt.Format(time.RFC3339),
-			description)
+// displayStats displays aggregate repository statistics in the specified format
+func displayStats(w io.Writer, stats *repository.Stats, config *ListConfig) error {
+	if config.Format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	fmt.Fprintf(w, "Repositories:   %d\n", stats.TotalRepositories)
+	fmt.Fprintf(w, "Total size:     %s\n", formatSize(stats.TotalSize))
+	fmt.Fprintf(w, "Forks:          %d\n", stats.Forks)
+	fmt.Fprintf(w, "Archived:       %d\n", stats.Archived)
+
+	if len(stats.Languages) > 0 {
+		fmt.Fprintf(w, "\n%-20s %-8s %-10s\n", "LANGUAGE", "REPOS", "SIZE")
+		fmt.Fprintln(w, strings.Repeat("-", 40))
+		for _, language := range stats.Languages {
+			fmt.Fprintf(w, "%-20s %-8d %-10s\n",
+				truncateString(language.Language, 20), language.Repositories, formatSize(language.Size))
+		}
+	}
+
+	if len(stats.RecentlyPushed) > 0 {
+		fmt.Fprintf(w, "\nMost recently pushed:\n")
+		for _, repo := range stats.RecentlyPushed {
+			pushed := repo.PushedAt
+			if pushed.IsZero() {
+				pushed = repo.UpdatedAt
+			}
+			fmt.Fprintf(w, "  %-40s %s\n", truncateString(repo.GetFullName(), 40), pushed.Format("2006-01-02"))
+		}
 	}
+
 	return nil
 }
 
@@ -345,21 +608,10 @@ func displayCSV(repos []*repository.Repository) error {
 
 // formatSize formats size in bytes to human readable format
 func formatSize(bytes int64) string {
-	if bytes < 1024 {
-		return fmt.Sprintf("%dB", bytes)
-	} else if bytes < 1024*1024 {
-		return fmt.Sprintf("%.1fKB", float64(bytes)/1024)
-	} else if bytes < 1024*1024*1024 {
-		return fmt.Sprintf("%.1fMB", float64(bytes)/(1024*1024))
-	} else {
-		return fmt.Sprintf("%.1fGB", float64(bytes)/(1024*1024*1024))
-	}
+	return output.FormatSize(bytes)
 }
 
 // truncateString truncates a string to the specified length
 func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return output.Truncate(s, maxLen)
 }
@@ -0,0 +1,86 @@
+package fang
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func newListedRepository(t *testing.T) *repository.Repository {
+	t.Helper()
+
+	repo, err := repository.NewRepository(1, "repo", "https://github.com/owner/repo.git", "owner", false, 2048, "main")
+	require.NoError(t, err)
+	repo.Language = "Go"
+	repo.Description = "Says \"hi\", then\nleaves"
+	repo.UpdatedAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	return repo
+}
+
+func TestCSVPrinter_Escaping(t *testing.T) {
+	var out bytes.Buffer
+	printer, err := newRepositoryPrinter("csv", &out, false, ColumnConfig{})
+	require.NoError(t, err)
+	require.NoError(t, printer.Print([]*repository.Repository{newListedRepository(t)}))
+	require.NoError(t, printer.Close())
+
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"name", "full_name", "clone_url", "size", "language", "fork", "default_branch", "updated_at", "description"}, records[0])
+	assert.Equal(t, []string{"repo", "owner/repo", "https://github.com/owner/repo.git", "2048", "Go", "false",
+		"main", "2025-01-02T03:04:05Z", "Says \"hi\", then\nleaves"}, records[1])
+}
+
+func TestRepositoryPrinter_Columns(t *testing.T) {
+	repos := []*repository.Repository{newListedRepository(t)}
+
+	var out bytes.Buffer
+	printer, err := newRepositoryPrinter("table", &out, false, ColumnConfig{Columns: []string{"full_name", "size"}, NoHeader: true})
+	require.NoError(t, err)
+	require.NoError(t, printer.Print(repos))
+	require.NoError(t, printer.Close())
+	assert.Equal(t, "owner/repo                               2.0KB     \n", out.String())
+
+	out.Reset()
+	printer, err = newRepositoryPrinter("json", &out, false, ColumnConfig{Columns: []string{"name", "fork"}})
+	require.NoError(t, err)
+	require.NoError(t, printer.Print(repos))
+	require.NoError(t, printer.Close())
+	assert.Equal(t, "[\n  {\n    \"name\": \"repo\",\n    \"fork\": false\n  }\n]\n", out.String())
+
+	_, err = newRepositoryPrinter("csv", &out, false, ColumnConfig{Columns: []string{"stars"}})
+	assert.ErrorContains(t, err, `unknown column "stars"`)
+
+	_, err = newRepositoryPrinter("parquet", &out, false, ColumnConfig{Columns: []string{"name"}})
+	assert.Error(t, err)
+}
+
+func TestParquetPrinter(t *testing.T) {
+	var out bytes.Buffer
+	printer, err := newRepositoryPrinter("parquet", &out, false, ColumnConfig{})
+	require.NoError(t, err)
+	require.NoError(t, printer.Print([]*repository.Repository{newListedRepository(t)}))
+	require.NoError(t, printer.Close())
+
+	rows, err := parquet.Read[parquetRepo](bytes.NewReader(out.Bytes()), int64(out.Len()))
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "owner/repo", rows[0].FullName)
+	assert.Equal(t, int64(2048), rows[0].Size)
+	assert.True(t, rows[0].UpdatedAt.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+}
+
+func TestFormatFromPath(t *testing.T) {
+	assert.Equal(t, "json", formatFromPath("repos.json"))
+	assert.Equal(t, "csv", formatFromPath("repos.CSV"))
+	assert.Equal(t, "parquet", formatFromPath("out/repos.parquet"))
+	assert.Equal(t, "table", formatFromPath("repos.txt"))
+}
@@ -0,0 +1,267 @@
+package fang
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/manifest"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+// ManifestExportConfig holds manifest export configuration
+type ManifestExportConfig struct {
+	Output string
+	Format string
+	Depth  int
+}
+
+// ManifestCloneConfig holds manifest clone configuration
+type ManifestCloneConfig struct {
+	Depth      int
+	Submodules SubmoduleConfig
+	Sparse     SparseConfig
+	Filter     string // Partial clone filter: blobless, treeless or a git filter spec
+	Existing   ExistingConfig
+	FailOn     string // Failure policy: any, none or threshold=N%
+	Order      string // Scheduling order: fetched, smallest, largest, name or pushed
+	Dedupe     bool   // Skip repositories with an already selected or cloned remote
+	Yes        bool   // Skip the confirmation prompt
+}
+
+// NewManifestCommand creates the manifest command with its subcommands
+func NewManifestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Export or clone reproducible multi-repository workspaces",
+		Long: `Manage workspace manifests describing a set of repositories.
+
+A manifest lists every repository of a workspace with its clone URL, path,
+branch and pinned revision. Export a manifest from an existing base directory
+and share it with teammates so they can clone exactly the same set.`,
+		Example: `  # Export the repositories under ./workspace as YAML
+  repocloner manifest export --base-dir ./workspace --out workspace.yaml
+
+  # Export as JSON to stdout
+  repocloner manifest export --format json
+
+  # Clone every repository described by a manifest
+  repocloner manifest clone workspace.yaml --base-dir ./workspace`,
+	}
+
+	cmd.AddCommand(newManifestExportCommand())
+	cmd.AddCommand(newManifestCloneCommand())
+
+	return cmd
+}
+
+// newManifestExportCommand creates the manifest export subcommand
+func newManifestExportCommand() *cobra.Command {
+	var config ManifestExportConfig
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write a manifest describing the repositories in the base directory",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runManifestExport(cmd, &config)
+		},
+	}
+
+	cmd.Flags().StringVarP(&config.Output, "out", "o", "", "Output file (default: stdout)")
+	cmd.Flags().StringVar(&config.Format, "format", "", "Manifest format: yaml or json (default: from --out extension, else yaml)")
+	completeFlag(cmd, "format", "yaml", "json")
+	cmd.Flags().IntVar(&config.Depth, "scan-depth", 3, "Maximum directory depth to search for repositories")
+
+	return cmd
+}
+
+// newManifestCloneCommand creates the manifest clone subcommand
+func newManifestCloneCommand() *cobra.Command {
+	var config ManifestCloneConfig
+
+	cmd := &cobra.Command{
+		Use:   "clone [manifest-file]",
+		Short: "Clone exactly the repositories described by a manifest",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runManifestClone(cmd, args[0], &config)
+		},
+	}
+
+	cmd.Flags().IntVar(&config.Depth, "depth", 0, "Clone depth for shallow clones (0 for full history)")
+	addSubmoduleFlags(cmd, &config.Submodules)
+	addSparseFlags(cmd, &config.Sparse)
+	addFilterFlag(cmd, &config.Filter)
+	addExistingFlags(cmd, &config.Existing)
+	addFailOnFlag(cmd, &config.FailOn)
+	addOrderFlag(cmd, &config.Order)
+	addDedupeFlag(cmd, &config.Dedupe)
+	addYesFlag(cmd, &config.Yes)
+
+	return cmd
+}
+
+// runManifestExport executes the manifest export command
+func runManifestExport(cmd *cobra.Command, config *ManifestExportConfig) error {
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+
+	format := manifest.FormatFromPath(config.Output)
+	if config.Format != "" {
+		if format, err = manifest.ParseFormat(config.Format); err != nil {
+			return err
+		}
+	}
+
+	logger, err := logging.NewConsoleLogger("warn", false)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	exportUseCase := usecases.NewExportManifestUseCase(logger)
+	m, err := exportUseCase.Execute(cmd.Context(), &usecases.ExportManifestRequest{
+		BaseDirectory: globalConfig.BaseDir,
+		MaxDepth:      config.Depth,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export manifest: %w", err)
+	}
+
+	data, err := m.Marshal(format)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if config.Output == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(config.Output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %d repositories to %s\n", len(m.Repositories), config.Output)
+	return nil
+}
+
+// runManifestClone executes the manifest clone command
+func runManifestClone(cmd *cobra.Command, manifestPath string, config *ManifestCloneConfig) error {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	policy, err := cloning.ParseFailurePolicy(config.FailOn)
+	if err != nil {
+		return err
+	}
+
+	order, err := cloning.ParseJobOrder(config.Order)
+	if err != nil {
+		return err
+	}
+
+	if err := config.Existing.validate(); err != nil {
+		return err
+	}
+
+	if err := config.Sparse.load(); err != nil {
+		return err
+	}
+
+	filter, err := cloning.ParseCloneFilter(config.Filter)
+	if err != nil {
+		return err
+	}
+	config.Filter = string(filter)
+
+	if err := checkConfirmable(config.Yes, false); err != nil {
+		return err
+	}
+
+	m, err := manifest.Unmarshal(data, manifest.FormatFromPath(manifestPath))
+	if err != nil {
+		return err
+	}
+	if len(m.Repositories) == 0 {
+		return fmt.Errorf("manifest %s does not list any repositories", manifestPath)
+	}
+
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+
+	if err := os.MkdirAll(globalConfig.BaseDir, 0755); err != nil {
+		return fmt.Errorf("failed to create base directory: %w", err)
+	}
+
+	app, tuiLogger, err := NewApplication(globalConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+	defer func() {
+		if err := app.Close(); err != nil {
+			app.logger.Warn("failed to close application", shared.ErrorField(err))
+		}
+	}()
+
+	options := cloning.NewDefaultCloneOptions()
+	options.Depth = config.Depth
+	config.Submodules.apply(options)
+	config.Sparse.apply(options)
+	options.Filter = cloning.CloneFilter(config.Filter)
+	config.Existing.apply(options)
+
+	cloneReq, err := usecases.NewCloneRequestFromManifest(m, globalConfig.BaseDir, options, globalConfig.Concurrency)
+	if err != nil {
+		return err
+	}
+	cloneReq.Order = order
+	cloneReq.Dedupe = config.Dedupe
+
+	if !config.Yes {
+		estimate := app.cloneRepositoriesUseCase.Estimate(cloneReq.Repositories, cloneReq.Concurrency)
+		if err := confirmClone(cmd, estimate); err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Cloning %d repositories from %s into %s\n", len(cloneReq.Repositories), manifestPath, globalConfig.BaseDir)
+	fmt.Fprintf(out, "Log file: %s\n", tuiLogger.GetLogFile())
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Hour)
+	defer cancel()
+
+	resp, err := app.cloneRepositoriesUseCase.Execute(ctx, cloneReq)
+	if err != nil {
+		return fmt.Errorf("failed to clone manifest repositories: %w", err)
+	}
+
+	clonetui.WriteFailureSummary(out, clonetui.FailedResults(resp))
+	clonetui.WriteTimeoutSummary(out, resp.TimedOutJobs)
+	clonetui.WriteTooLargeSummary(out, resp.TooLargeJobs, resp.TooLargeBytes)
+	clonetui.WriteDiskSummary(out, resp.Progress, resp.LowSpaceJobs)
+	clonetui.WriteDuplicateSummary(out, resp.Duplicates)
+	fmt.Fprintf(out, "Done in %s: ✅ %d completed, ❌ %d failed, ⏭️ %d skipped, 🔄 %d updated",
+		resp.TotalDuration.Truncate(time.Second), resp.CompletedJobs, resp.FailedJobs, resp.SkippedJobs, resp.UpdatedJobs)
+	if resp.CancelledJobs > 0 {
+		fmt.Fprintf(out, ", 🛑 %d cancelled", resp.CancelledJobs)
+	}
+	fmt.Fprintln(out)
+
+	return finishCloneRun(cmd, app, resp, policy)
+}
@@ -0,0 +1,222 @@
+package fang
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/services"
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/interfaces/api"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+// Output modes of the clone command
+const (
+	cloneOutputTUI  = "tui"  // Interactive progress TUI
+	cloneOutputJSON = "json" // One JSON object per job lifecycle event on stdout
+)
+
+// defaultShutdownGrace is how long an interrupted headless run waits for its
+// in-flight clones
+const defaultShutdownGrace = 30 * time.Second
+
+// addCloneOutputFlag registers the --output flag of the clone command
+func addCloneOutputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVar(output, "output", cloneOutputTUI, "Output mode: tui, or json for one JSON object per job event on stdout")
+	completeFlag(cmd, "output", cloneOutputTUI, cloneOutputJSON)
+}
+
+// validateCloneOutput checks the value of --output
+func validateCloneOutput(output string) error {
+	switch output {
+	case cloneOutputTUI, cloneOutputJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid --output %q: use tui or json", output)
+	}
+}
+
+// cloneMessages returns where the clone command prints its banner; JSON
+// output keeps stdout for events
+func cloneMessages(output string) io.Writer {
+	if output == cloneOutputJSON {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// listenAddress returns the address the API listens on for --listen, binding
+// to the loopback interface when no host is given, and whether it is a
+// loopback address. The API has no authentication.
+func listenAddress(listen string) (string, bool) {
+	host, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return listen, false
+	}
+	if host == "" {
+		return net.JoinHostPort("127.0.0.1", port), true
+	}
+	if host == "localhost" {
+		return listen, true
+	}
+	ip := net.ParseIP(host)
+	return listen, ip != nil && ip.IsLoopback()
+}
+
+// runClone clones with the progress TUI, or without it when events are
+// written as JSON. With --listen the API and web dashboard serve the progress
+// of the run, and of earlier runs kept under the data directory.
+func runClone(cmd *cobra.Command, globalConfig *Config, output string, config *clonetui.Config) (*usecases.CloneRepositoriesResponse, error) {
+	if globalConfig.Listen != "" {
+		var logger shared.Logger = logging.NewNoOpLogger()
+		if config.Logger != nil {
+			logger = config.Logger
+		}
+		// Finished batches stay available to the API, across runs too
+		history := services.NewProgressService(&services.ProgressServiceConfig{
+			Logger:    logger,
+			StatePath: filepath.Join(globalConfig.DataDir, "progress.json"),
+		})
+		defer func() { _ = history.Close() }()
+		config.CloneUseCase.SetProgressHistory(history)
+
+		addr, loopback := listenAddress(globalConfig.Listen)
+		if !loopback {
+			fmt.Fprintf(cloneMessages(output), "Warning: the API on %s has no authentication and is reachable from other hosts\n", addr)
+		}
+		server, err := api.Start(addr, &api.Config{
+			Batches: config.CloneUseCase,
+			Status:  config.Status,
+			Logger:  logger,
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = server.Close() }()
+		fmt.Fprintf(cloneMessages(output), "Dashboard: http://%s/\n", server.Addr())
+	}
+
+	if output != cloneOutputJSON {
+		return clonetui.Run(config)
+	}
+	return runCloneEvents(cmd, globalConfig, config)
+}
+
+// runCloneEvents runs the clone of a TUI configuration headless, writing the
+// lifecycle events of every job to stdout as JSON lines. SIGINT and SIGTERM
+// shut the run down gracefully, see waitForClone.
+func runCloneEvents(cmd *cobra.Command, globalConfig *Config, config *clonetui.Config) (*usecases.CloneRepositoriesResponse, error) {
+	fetchTimeout := config.FetchTimeout
+	if fetchTimeout == 0 {
+		fetchTimeout = clonetui.DefaultFetchTimeout
+	}
+
+	// Until cloning starts a signal aborts the run
+	interruptCtx, stopSignals := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	fetchCtx, cancelFetch := context.WithTimeout(interruptCtx, fetchTimeout)
+	repos, err := config.Fetch(fetchCtx)
+	cancelFetch()
+	interrupted := interruptCtx.Err() != nil && cmd.Context().Err() == nil
+	stopSignals()
+	if interrupted {
+		return nil, &ExitCodeError{Code: ExitCancelled, Err: fmt.Errorf("interrupted while fetching repositories")}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repositories found for %s", config.Target)
+	}
+
+	if config.Confirm {
+		estimate := config.CloneUseCase.Estimate(repos, config.Concurrency)
+		if err := promptClone(cmd.InOrStdin(), cmd.ErrOrStderr(), estimate); err != nil {
+			return nil, err
+		}
+	}
+
+	req := &usecases.CloneRepositoriesRequest{
+		Repositories:  repos,
+		BaseDirectory: config.Directory,
+		Options:       config.Options,
+		Concurrency:   config.Concurrency,
+		Order:         config.Order,
+		Dedupe:        config.Dedupe,
+		OnEvent:       jsonEvents(cmd.OutOrStdout()),
+	}
+
+	ctx := cmd.Context()
+	if config.CloneTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.CloneTimeout)
+		defer cancel()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	batch, err := config.CloneUseCase.Start(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repositories: %w", err)
+	}
+	resp, err := waitForClone(cmd.ErrOrStderr(), batch, signals, globalConfig.ShutdownGrace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repositories: %w", err)
+	}
+	return resp, nil
+}
+
+// waitForClone waits for a headless clone batch to finish. On a signal the
+// batch starts no more clones and gives those in flight the grace period to
+// finish; a second signal or the end of the grace period cancels them. The
+// clones that did not run are reported as cancelled, so the run report is
+// still written and the command exits with ExitCancelled.
+func waitForClone(messages io.Writer, batch *usecases.CloneBatch, signals <-chan os.Signal, grace time.Duration) (*usecases.CloneRepositoriesResponse, error) {
+	select {
+	case <-batch.Done():
+		return batch.Wait()
+	case sig := <-signals:
+		fmt.Fprintf(messages, "Received %s, waiting up to %s for in-flight clones (signal again to cancel them)\n", sig, grace)
+		batch.Drain()
+	}
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-batch.Done():
+	case <-timer.C:
+		fmt.Fprintln(messages, "Shutdown grace period over, cancelling in-flight clones")
+		batch.Cancel()
+	case <-signals:
+		fmt.Fprintln(messages, "Cancelling in-flight clones")
+		batch.Cancel()
+	}
+	return batch.Wait()
+}
+
+// jsonEvents returns an event handler writing one JSON object per line
+func jsonEvents(w io.Writer) cloning.JobEventFunc {
+	var mu sync.Mutex
+	encoder := json.NewEncoder(w)
+
+	return func(event cloning.JobEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = encoder.Encode(event)
+	}
+}
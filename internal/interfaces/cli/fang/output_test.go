@@ -0,0 +1,160 @@
+package fang
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestValidateCloneOutput(t *testing.T) {
+	assert.NoError(t, validateCloneOutput("tui"))
+	assert.NoError(t, validateCloneOutput("json"))
+	assert.ErrorContains(t, validateCloneOutput("yaml"), "invalid --output")
+}
+
+func TestJSONEvents(t *testing.T) {
+	var out bytes.Buffer
+	emit := jsonEvents(&out)
+	emit(cloning.JobEvent{Type: cloning.JobEventQueued, JobID: "job-1", Repository: "owner/repo"})
+	emit(cloning.JobEvent{Type: cloning.JobEventFailed, JobID: "job-1", Repository: "owner/repo", Attempt: 3, Error: "boom"})
+
+	var events []map[string]any
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var event map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event), "one JSON object per line")
+		events = append(events, event)
+	}
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "queued", events[0]["event"])
+	assert.NotContains(t, events[0], "attempt")
+	assert.Equal(t, "failed", events[1]["event"])
+	assert.Equal(t, float64(3), events[1]["attempt"])
+	assert.Equal(t, "boom", events[1]["error"])
+}
+
+// heldBackend holds every clone until release is closed, reporting started
+// clones on started
+type heldBackend struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (heldBackend) Name() string { return "held" }
+
+func (b heldBackend) CloneRepository(ctx context.Context, job *cloning.CloneJob) error {
+	return b.CloneRepositoryWithProgress(ctx, job, nil)
+}
+
+func (b heldBackend) CloneRepositoryWithProgress(ctx context.Context, _ *cloning.CloneJob, _ cloning.TransferProgressFunc) error {
+	b.started <- struct{}{}
+	select {
+	case <-b.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (heldBackend) UpdateClone(context.Context, *cloning.CloneJob) error { return nil }
+
+func (heldBackend) GetRepositorySize(string) (int64, error) { return 0, nil }
+
+func (heldBackend) Validate(context.Context) error { return nil }
+
+// startHeldBatch starts three clones on a single worker of a held backend and
+// waits for the first one to run
+func startHeldBatch(t *testing.T, backend heldBackend) *usecases.CloneBatch {
+	t.Helper()
+	logger := logging.NewNoOpLogger()
+	pool, err := concurrency.NewWorkerPool(&concurrency.WorkerPoolConfig{MaxWorkers: 1, Backend: backend, Logger: logger})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pool.ForceClose() })
+
+	var repos []*repository.Repository
+	for i := 1; i <= 3; i++ {
+		name := fmt.Sprintf("repo-%d", i)
+		repo, err := repository.NewRepository(repository.RepositoryID(i), name, "https://github.com/owner/"+name+".git", "owner", false, 0, "main")
+		require.NoError(t, err)
+		repos = append(repos, repo)
+	}
+
+	uc := usecases.NewCloneRepositoriesUseCase(pool, cloning.NewDomainCloneService(logger), logger)
+	batch, err := uc.Start(context.Background(), &usecases.CloneRepositoriesRequest{
+		Repositories:  repos,
+		BaseDirectory: t.TempDir(),
+		Concurrency:   1,
+	})
+	require.NoError(t, err)
+	<-backend.started
+	return batch
+}
+
+func TestWaitForClone_FinishesInFlightClones(t *testing.T) {
+	backend := heldBackend{started: make(chan struct{}, 3), release: make(chan struct{})}
+	batch := startHeldBatch(t, backend)
+
+	signals := make(chan os.Signal, 1)
+	signals <- os.Interrupt
+	time.AfterFunc(50*time.Millisecond, func() { close(backend.release) })
+
+	var messages bytes.Buffer
+	resp, err := waitForClone(&messages, batch, signals, time.Minute)
+	require.NoError(t, err)
+	assert.Contains(t, messages.String(), "waiting up to 1m0s for in-flight clones")
+	assert.Equal(t, 1, resp.CompletedJobs, "the clone in flight finishes")
+	assert.Equal(t, 2, resp.CancelledJobs, "the queued clones never start")
+	assert.ErrorContains(t, cloneResultError(resp, nil), "cloning cancelled")
+}
+
+func TestWaitForClone_CancelsAfterGracePeriod(t *testing.T) {
+	backend := heldBackend{started: make(chan struct{}, 3), release: make(chan struct{})}
+	batch := startHeldBatch(t, backend)
+
+	signals := make(chan os.Signal, 1)
+	signals <- os.Interrupt
+
+	var messages bytes.Buffer
+	resp, err := waitForClone(&messages, batch, signals, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Contains(t, messages.String(), "grace period over")
+	assert.Equal(t, 0, resp.CompletedJobs)
+	assert.Equal(t, 3, resp.CancelledJobs)
+}
+
+func TestListenAddress(t *testing.T) {
+	tests := []struct {
+		listen   string
+		addr     string
+		loopback bool
+	}{
+		{listen: ":8080", addr: "127.0.0.1:8080", loopback: true},
+		{listen: "127.0.0.1:8080", addr: "127.0.0.1:8080", loopback: true},
+		{listen: "localhost:8080", addr: "localhost:8080", loopback: true},
+		{listen: "[::1]:8080", addr: "[::1]:8080", loopback: true},
+		{listen: "0.0.0.0:8080", addr: "0.0.0.0:8080", loopback: false},
+		{listen: "10.0.0.5:8080", addr: "10.0.0.5:8080", loopback: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.listen, func(t *testing.T) {
+			addr, loopback := listenAddress(tt.listen)
+			assert.Equal(t, tt.addr, addr)
+			assert.Equal(t, tt.loopback, loopback)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package fang
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+	"github.com/italoag/repocloner/internal/version"
+)
+
+// ownerTarget is a GitHub user or organization to clone
+type ownerTarget struct {
+	Type  repository.RepositoryType
+	Owner string
+}
+
+func (t ownerTarget) String() string {
+	return fmt.Sprintf("%s/%s", t.Type, t.Owner)
+}
+
+// parseGitHubType parses the user or org type argument of GitHub commands
+func parseGitHubType(value string) (repository.RepositoryType, error) {
+	switch strings.ToLower(value) {
+	case "user", "users":
+		return repository.RepositoryTypeUser, nil
+	case "org", "orgs", "organization":
+		return repository.RepositoryTypeOrganization, nil
+	default:
+		return "", fmt.Errorf("invalid repository type '%s', must be 'user' or 'org'", value)
+	}
+}
+
+// parseOwnerPairs parses positional [type] [owner] pairs
+func parseOwnerPairs(args []string) ([]ownerTarget, error) {
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("expected [type] [owner] pairs, got %d arguments", len(args))
+	}
+
+	targets := make([]ownerTarget, 0, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		repoType, err := parseGitHubType(args[i])
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, ownerTarget{Type: repoType, Owner: args[i+1]})
+	}
+	return targets, nil
+}
+
+// parseAlsoFlags parses --also values written as type:owner or type/owner
+func parseAlsoFlags(values []string) ([]ownerTarget, error) {
+	targets := make([]ownerTarget, 0, len(values))
+	for _, value := range values {
+		typ, owner, ok := strings.Cut(value, ":")
+		if !ok {
+			typ, owner, ok = strings.Cut(value, "/")
+		}
+		if !ok || owner == "" {
+			return nil, fmt.Errorf("invalid --also %q, expected type:owner such as org:acme", value)
+		}
+
+		repoType, err := parseGitHubType(typ)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --also %q: %w", value, err)
+		}
+		targets = append(targets, ownerTarget{Type: repoType, Owner: owner})
+	}
+	return targets, nil
+}
+
+// multiOwnerFetcher lists every owner in turn and merges the results. A
+// repository listed under several owners is cloned once.
+func multiOwnerFetcher(app *Application, requests []*usecases.FetchRepositoriesRequest) clonetui.FetchFunc {
+	return func(ctx context.Context) ([]*repository.Repository, error) {
+		var merged []*repository.Repository
+		seen := make(map[string]bool)
+
+		for _, req := range requests {
+			resp, err := app.fetchRepositoriesUseCase.Execute(ctx, req)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s/%s: %w", req.Type, req.Owner, err)
+			}
+			app.recordMetadata(fmt.Sprintf("%s/%s", req.Type, req.Owner), resp.Repositories)
+
+			for _, repo := range resp.Repositories {
+				key := repository.CloneURLKey(repo.CloneURL)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				merged = append(merged, repo)
+			}
+		}
+		return merged, nil
+	}
+}
+
+// runCloneOwners clones several owners in one run into per-owner directories
+// of the base directory, with progress broken down per owner
+func runCloneOwners(
+	cmd *cobra.Command,
+	app *Application,
+	tuiLogger *logging.TUILogger,
+	globalConfig *Config,
+	cloneConfig *CloneConfig,
+	targets []ownerTarget,
+	visibility repository.Visibility,
+	policy *cloning.FailurePolicy,
+	order cloning.JobOrder,
+) error {
+	requests := make([]*usecases.FetchRepositoriesRequest, 0, len(targets))
+	names := make([]string, 0, len(targets))
+	usesGitHub := false
+	for _, target := range targets {
+		usesGitHub = usesGitHub || target.Type.IsGitHubType()
+		req := newFetchRequest(target.Type, target.Owner, cloneConfig.SkipForks)
+		selectForks(req, cloneConfig.ForksOnly)
+		if err := cloneConfig.Teams.apply(req); err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+		req.Filter.Visibility = visibility
+		if err := cloneConfig.Exclusions.apply(req.Filter, globalConfig.BaseDir); err != nil {
+			return err
+		}
+
+		requests = append(requests, req)
+		names = append(names, target.String())
+	}
+	if err := preflightTokenScopes(cmd.Context(), app, globalConfig, requests); err != nil {
+		return err
+	}
+
+	messages := cloneMessages(cloneConfig.Output)
+	fmt.Fprintf(messages, "%s - Concurrent Repository Cloner\n", version.Title())
+	fmt.Fprintf(messages, "Targets: %s\n", strings.Join(names, ", "))
+	fmt.Fprintf(messages, "Concurrency: %s\n", globalConfig.DescribeWorkers())
+	fmt.Fprintf(messages, "Base directory: %s\n", globalConfig.BaseDir)
+	fmt.Fprintf(messages, "Log file: %s\n", tuiLogger.GetLogFile())
+	if usesGitHub && !globalConfig.HasGitHubAuth() {
+		fmt.Fprintf(messages, "Warning: Running without GitHub token (rate limiting may apply)\n")
+	}
+	if files := cloneConfig.Exclusions.ignoreFiles; len(files) > 0 {
+		fmt.Fprintf(messages, "Ignore files: %s\n", strings.Join(files, ", "))
+	}
+	fmt.Fprintf(messages, "Starting...\n\n")
+
+	if err := os.MkdirAll(globalConfig.BaseDir, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	options := createCloneOptions(cloneConfig)
+	options.CreateOrgDirs = true
+
+	var status func() string
+	if usesGitHub {
+		status = githubRateLimitStatus(app)
+	}
+
+	resp, err := runClone(cmd, globalConfig, cloneConfig.Output, &clonetui.Config{
+		Title:        version.Title() + " - Concurrent Repository Cloner",
+		Target:       strings.Join(names, ", "),
+		Directory:    globalConfig.BaseDir,
+		Fetch:        multiOwnerFetcher(app, requests),
+		CloneUseCase: app.cloneRepositoriesUseCase,
+		Options:      options,
+		Concurrency:  globalConfig.Concurrency,
+		Order:        order,
+		Dedupe:       cloneConfig.Dedupe,
+		Confirm:      !cloneConfig.Yes,
+		ByOwner:      true,
+		Logger:       tuiLogger,
+		Status:       status,
+	})
+	if err != nil {
+		return err
+	}
+	return finishCloneRun(cmd, app, resp, policy)
+}
@@ -0,0 +1,52 @@
+package fang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func TestParseOwnerPairs(t *testing.T) {
+	targets, err := parseOwnerPairs([]string{"org", "acme", "user", "octocat"})
+	require.NoError(t, err)
+	assert.Equal(t, []ownerTarget{
+		{Type: repository.RepositoryTypeOrganization, Owner: "acme"},
+		{Type: repository.RepositoryTypeUser, Owner: "octocat"},
+	}, targets)
+
+	_, err = parseOwnerPairs([]string{"org", "acme", "user"})
+	assert.Error(t, err)
+
+	_, err = parseOwnerPairs([]string{"team", "acme"})
+	assert.Error(t, err)
+}
+
+func TestParseAlsoFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    ownerTarget
+		wantErr bool
+	}{
+		{name: "colon", value: "org:acme", want: ownerTarget{Type: repository.RepositoryTypeOrganization, Owner: "acme"}},
+		{name: "slash", value: "user/octocat", want: ownerTarget{Type: repository.RepositoryTypeUser, Owner: "octocat"}},
+		{name: "missing type", value: "acme", wantErr: true},
+		{name: "missing owner", value: "org:", wantErr: true},
+		{name: "unknown type", value: "team:acme", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets, err := parseAlsoFlags([]string{tt.value})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []ownerTarget{tt.want}, targets)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package fang
+
+import (
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// parquetRepo is the row schema of Parquet output, with the columns of the
+// CSV output and the provider
+type parquetRepo struct {
+	Name          string    `parquet:"name"`
+	FullName      string    `parquet:"full_name"`
+	CloneURL      string    `parquet:"clone_url"`
+	Size          int64     `parquet:"size"`
+	Language      string    `parquet:"language"`
+	Fork          bool      `parquet:"fork"`
+	DefaultBranch string    `parquet:"default_branch"`
+	UpdatedAt     time.Time `parquet:"updated_at,timestamp(millisecond)"`
+	Description   string    `parquet:"description"`
+	Provider      string    `parquet:"provider"`
+}
+
+// parquetPrinter writes repositories as a Parquet file. Rows are buffered
+// into row groups and the file footer is written by Close.
+type parquetPrinter struct {
+	writer *parquet.GenericWriter[parquetRepo]
+}
+
+// newParquetPrinter creates a Parquet printer writing to w
+func newParquetPrinter(w io.Writer) *parquetPrinter {
+	return &parquetPrinter{
+		writer: parquet.NewGenericWriter[parquetRepo](w, parquet.Compression(&parquet.Zstd)),
+	}
+}
+
+// Print writes a batch of rows
+func (p *parquetPrinter) Print(repos []*repository.Repository) error {
+	rows := make([]parquetRepo, len(repos))
+	for i, repo := range repos {
+		rows[i] = parquetRepo{
+			Name:          repo.Name,
+			FullName:      repo.GetFullName(),
+			CloneURL:      repo.CloneURL,
+			Size:          repo.Size,
+			Language:      repo.Language,
+			Fork:          repo.IsFork,
+			DefaultBranch: repo.DefaultBranch,
+			UpdatedAt:     repo.UpdatedAt,
+			Description:   repo.Description,
+			Provider:      repo.Provider(),
+		}
+	}
+
+	_, err := p.writer.Write(rows)
+	return err
+}
+
+// Close flushes the buffered rows and writes the file footer
+func (p *parquetPrinter) Close() error {
+	return p.writer.Close()
+}
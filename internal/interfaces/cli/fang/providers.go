@@ -0,0 +1,244 @@
+package fang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/infrastructure/providers"
+	"github.com/italoag/repocloner/internal/version"
+)
+
+// listProviders are the registered providers accepted by --providers
+var listProviders = []string{providers.GitHub, providers.Bitbucket}
+
+// singleProviders are the registered providers accepted by --provider
+var singleProviders = []string{providers.GitHub, providers.Bitbucket, providers.BitbucketServer}
+
+// resolveListProvider settles the provider of a single-provider listing:
+// --provider, or else the provider of the workspace and project owner types,
+// defaulting to GitHub. The user and org types map onto the owner types of
+// the provider, whose credentials must be set.
+func resolveListProvider(config *ListConfig, globalConfig *Config) error {
+	provider := strings.ToLower(strings.TrimSpace(config.Provider))
+	switch {
+	case provider == "" && config.Type == repository.RepositoryTypeBitbucketWorkspace:
+		provider = providers.Bitbucket
+	case provider == "" && config.Type == repository.RepositoryTypeBitbucketProject:
+		provider = providers.BitbucketServer
+	case provider == "":
+		provider = providers.GitHub
+	case !slices.Contains(singleProviders, provider):
+		return fmt.Errorf("invalid provider %q in --provider, must be %s", provider, strings.Join(singleProviders, ", "))
+	}
+	config.Provider = provider
+	config.Type = providerRepositoryType(provider, config.Type)
+
+	if provider == providers.GitHub {
+		return nil
+	}
+	if config.Teams.Team != "" || config.Teams.MinPermission != "" {
+		return fmt.Errorf("--team and --min-permission only apply to GitHub")
+	}
+	return validateBitbucketCredentials(config.Type, globalConfig)
+}
+
+// validateProviders normalizes --providers and checks that the flags of the
+// listing apply to every provider and that Bitbucket credentials are set
+func validateProviders(config *ListConfig, globalConfig *Config) error {
+	if len(config.Providers) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(config.Providers))
+	for _, provider := range config.Providers {
+		provider = strings.ToLower(strings.TrimSpace(provider))
+		if !slices.Contains(listProviders, provider) {
+			return fmt.Errorf("invalid provider %q in --providers, must be %s", provider, strings.Join(listProviders, " or "))
+		}
+		if !slices.Contains(names, provider) {
+			names = append(names, provider)
+		}
+	}
+	config.Providers = names
+
+	if config.Changed {
+		return fmt.Errorf("--changed cannot be combined with --providers")
+	}
+	if slices.Contains(names, providers.Bitbucket) {
+		if config.Teams.Team != "" || config.Teams.MinPermission != "" {
+			return fmt.Errorf("--team and --min-permission only apply to GitHub, remove bitbucket from --providers")
+		}
+		if err := validateBitbucketCredentials(repository.RepositoryTypeBitbucketUser, globalConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// providerRepositoryType maps the user or org type of the list command to the
+// owner type of a provider: Bitbucket organizations are workspaces, and
+// Bitbucket Server ones projects. Other types are left as they are.
+func providerRepositoryType(provider string, repoType repository.RepositoryType) repository.RepositoryType {
+	switch {
+	case provider == providers.Bitbucket && repoType == repository.RepositoryTypeOrganization:
+		return repository.RepositoryTypeBitbucketWorkspace
+	case provider == providers.Bitbucket && repoType == repository.RepositoryTypeUser:
+		return repository.RepositoryTypeBitbucketUser
+	case provider == providers.BitbucketServer && repoType == repository.RepositoryTypeOrganization:
+		return repository.RepositoryTypeBitbucketProject
+	}
+	return repoType
+}
+
+// executeProvidersList lists the owner on every provider of --providers
+// concurrently and prints the merged results with a provider column
+func executeProvidersList(w io.Writer, config *ListConfig, globalConfig *Config, filter *repository.RepositoryFilter) error {
+	fetchUseCase, _, err := newListFetchUseCase(globalConfig)
+	if err != nil {
+		return err
+	}
+
+	requests := make([]*usecases.FetchRepositoriesRequest, 0, len(config.Providers))
+	for _, provider := range config.Providers {
+		req := &usecases.FetchRepositoriesRequest{
+			Owner:    config.Owner,
+			Type:     providerRepositoryType(provider, config.Type),
+			Provider: provider,
+			Filter:   filter,
+			Pagination: &repository.PaginationOptions{
+				Page:     config.Page,
+				PerPage:  config.PerPage,
+				MaxPages: config.MaxPages,
+			},
+		}
+		if provider == providers.GitHub {
+			if err := config.Teams.apply(req); err != nil {
+				return err
+			}
+		}
+		requests = append(requests, req)
+	}
+
+	printer, err := newRepositoryPrinter(config.Format, w, true, config.Columns)
+	if err != nil {
+		return err
+	}
+
+	store, err := openListingStore(globalConfig)
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		defer func() { _ = store.Close() }()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	listings, err := fetchConcurrently(ctx, fetchUseCase, requests)
+	if err != nil {
+		return err
+	}
+
+	var merged []*repository.Repository
+	for i, req := range requests {
+		if err := recordListing(store, fmt.Sprintf("%s/%s", req.Type, req.Owner), listings[i]); err != nil {
+			return err
+		}
+		merged = append(merged, listings[i]...)
+	}
+
+	return printListing(w, printer, merged, config)
+}
+
+// fetchConcurrently runs the fetch requests in parallel, returning the
+// repositories of each request in request order. A failed request fails the
+// listing, naming the owner type it listed.
+func fetchConcurrently(
+	ctx context.Context,
+	fetchUseCase *usecases.FetchRepositoriesUseCase,
+	requests []*usecases.FetchRepositoriesRequest,
+) ([][]*repository.Repository, error) {
+	listings := make([][]*repository.Repository, len(requests))
+	errs := make([]error, len(requests))
+
+	var wg sync.WaitGroup
+	for i, req := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := fetchUseCase.Execute(ctx, req)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to list %s/%s: %w", req.Type, req.Owner, err)
+				return
+			}
+			listings[i] = resp.Repositories
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return listings, nil
+}
+
+// newListFetchUseCase creates a fetch use case for GitHub, Bitbucket and,
+// when configured, Bitbucket Server listings, with the registry of its
+// providers. It logs only warnings to the console.
+func newListFetchUseCase(globalConfig *Config) (*usecases.FetchRepositoriesUseCase, *providers.Registry, error) {
+	consoleLogger, err := logging.NewConsoleLogger("warn", false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	logger := logging.NewRedactingLogger(consoleLogger)
+
+	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{
+		Token:       globalConfig.Token,
+		UserAgent:   version.UserAgent(),
+		Timeout:     30 * time.Second,
+		Transport:   globalConfig.Transport,
+		RateLimiter: github.NewRateLimiter(globalConfig.HasGitHubAuth()),
+		Logger:      logger,
+	})
+
+	bitbucketClient := bitbucket.NewBitbucketClient(&bitbucket.BitbucketClientConfig{
+		Username:    globalConfig.BitbucketUsername,
+		Email:       globalConfig.BitbucketEmail,
+		APIToken:    globalConfig.BitbucketAPIToken,
+		UserAgent:   version.UserAgent(),
+		Timeout:     30 * time.Second,
+		Transport:   globalConfig.Transport,
+		RateLimiter: bitbucket.NewTokenBucketRateLimiter(bitbucket.DefaultRateLimit),
+		Logger:      logger,
+	})
+
+	var serverClient *bitbucket.BitbucketServerClient
+	if globalConfig.BitbucketServerURL != "" {
+		serverClient, err = bitbucket.NewBitbucketServerClient(&bitbucket.BitbucketServerClientConfig{
+			BaseURL:   globalConfig.BitbucketServerURL,
+			Token:     globalConfig.BitbucketServerToken,
+			UserAgent: version.UserAgent(),
+			Timeout:   30 * time.Second,
+			Transport: globalConfig.Transport,
+			Logger:    logger,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to configure Bitbucket Server: %w", err)
+		}
+	}
+
+	registry := providers.Default(githubClient, bitbucketClient, serverClient)
+	return usecases.NewFetchRepositoriesUseCase(registry, logger), registry, nil
+}
@@ -0,0 +1,214 @@
+package fang
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/providers"
+)
+
+func TestValidateProviders(t *testing.T) {
+	bitbucketAuth := &Config{BitbucketAPIToken: "token", BitbucketEmail: "me@example.com"}
+
+	tests := []struct {
+		name    string
+		config  ListConfig
+		global  *Config
+		want    []string
+		wantErr string
+	}{
+		{name: "none", config: ListConfig{}, global: &Config{}},
+		{
+			name:   "normalized and deduplicated",
+			config: ListConfig{Providers: []string{"GitHub", " bitbucket", "github"}},
+			global: bitbucketAuth,
+			want:   []string{"github", "bitbucket"},
+		},
+		{
+			name:    "unknown provider",
+			config:  ListConfig{Providers: []string{"gitea"}},
+			global:  bitbucketAuth,
+			wantErr: "invalid provider",
+		},
+		{
+			name:    "bitbucket without credentials",
+			config:  ListConfig{Providers: []string{"github", "bitbucket"}},
+			global:  &Config{},
+			wantErr: "bitbucket API token required",
+		},
+		{
+			name:    "team with bitbucket",
+			config:  ListConfig{Providers: []string{"bitbucket"}, Teams: TeamConfig{Team: "platform"}},
+			global:  bitbucketAuth,
+			wantErr: "only apply to GitHub",
+		},
+		{
+			name:   "team with github only",
+			config: ListConfig{Providers: []string{"github"}, Teams: TeamConfig{Team: "platform"}},
+			global: &Config{},
+			want:   []string{"github"},
+		},
+		{
+			name:    "changed",
+			config:  ListConfig{Providers: []string{"github"}, Changed: true},
+			global:  &Config{},
+			wantErr: "--changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProviders(&tt.config, tt.global)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.config.Providers)
+		})
+	}
+}
+
+func TestProviderRepositoryType(t *testing.T) {
+	assert.Equal(t, repository.RepositoryTypeOrganization, providerRepositoryType(providers.GitHub, repository.RepositoryTypeOrganization))
+	assert.Equal(t, repository.RepositoryTypeBitbucketWorkspace, providerRepositoryType(providers.Bitbucket, repository.RepositoryTypeOrganization))
+	assert.Equal(t, repository.RepositoryTypeBitbucketUser, providerRepositoryType(providers.Bitbucket, repository.RepositoryTypeUser))
+	assert.Equal(t, repository.RepositoryTypeBitbucketWorkspace, providerRepositoryType(providers.Bitbucket, repository.RepositoryTypeBitbucketWorkspace))
+	assert.Equal(t, repository.RepositoryTypeBitbucketProject, providerRepositoryType(providers.BitbucketServer, repository.RepositoryTypeOrganization))
+}
+
+func TestResolveListProvider(t *testing.T) {
+	bitbucketAuth := &Config{BitbucketAPIToken: "token", BitbucketEmail: "me@example.com"}
+	serverAuth := &Config{BitbucketServerURL: "https://bitbucket.example.com"}
+
+	tests := []struct {
+		name         string
+		config       ListConfig
+		global       *Config
+		wantProvider string
+		wantType     repository.RepositoryType
+		wantErr      string
+	}{
+		{
+			name:         "github by default",
+			config:       ListConfig{Type: repository.RepositoryTypeOrganization},
+			global:       &Config{},
+			wantProvider: providers.GitHub,
+			wantType:     repository.RepositoryTypeOrganization,
+		},
+		{
+			name:         "workspace implies bitbucket",
+			config:       ListConfig{Type: repository.RepositoryTypeBitbucketWorkspace},
+			global:       bitbucketAuth,
+			wantProvider: providers.Bitbucket,
+			wantType:     repository.RepositoryTypeBitbucketWorkspace,
+		},
+		{
+			name:         "project implies bitbucket server",
+			config:       ListConfig{Type: repository.RepositoryTypeBitbucketProject},
+			global:       serverAuth,
+			wantProvider: providers.BitbucketServer,
+			wantType:     repository.RepositoryTypeBitbucketProject,
+		},
+		{
+			name:         "bitbucket user",
+			config:       ListConfig{Type: repository.RepositoryTypeUser, Provider: " Bitbucket"},
+			global:       bitbucketAuth,
+			wantProvider: providers.Bitbucket,
+			wantType:     repository.RepositoryTypeBitbucketUser,
+		},
+		{
+			name:    "unknown provider",
+			config:  ListConfig{Type: repository.RepositoryTypeUser, Provider: "gitea"},
+			global:  &Config{},
+			wantErr: "invalid provider",
+		},
+		{
+			name:    "bitbucket without credentials",
+			config:  ListConfig{Type: repository.RepositoryTypeBitbucketWorkspace},
+			global:  &Config{},
+			wantErr: "bitbucket API token required",
+		},
+		{
+			name:    "project without server",
+			config:  ListConfig{Type: repository.RepositoryTypeBitbucketProject},
+			global:  &Config{},
+			wantErr: "bitbucket server URL required",
+		},
+		{
+			name:    "team with bitbucket",
+			config:  ListConfig{Type: repository.RepositoryTypeBitbucketWorkspace, Teams: TeamConfig{Team: "platform"}},
+			global:  bitbucketAuth,
+			wantErr: "only apply to GitHub",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := resolveListProvider(&tt.config, tt.global)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProvider, tt.config.Provider)
+			assert.Equal(t, tt.wantType, tt.config.Type)
+		})
+	}
+}
+
+func TestRepositoryPrinters_Provider(t *testing.T) {
+	github := newListedRepository(t)
+	bitbucket, err := repository.NewRepository(2, "repo", "https://bitbucket.org/owner/repo.git", "owner", false, 10, "main")
+	require.NoError(t, err)
+	repos := []*repository.Repository{github, bitbucket}
+
+	t.Run("csv", func(t *testing.T) {
+		var out bytes.Buffer
+		printer, err := newRepositoryPrinter("csv", &out, true, ColumnConfig{})
+		require.NoError(t, err)
+		require.NoError(t, printer.Print(repos))
+		require.NoError(t, printer.Close())
+
+		records, err := csv.NewReader(&out).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, "provider", records[0][len(records[0])-1])
+		assert.Equal(t, "github", records[1][len(records[1])-1])
+		assert.Equal(t, "bitbucket", records[2][len(records[2])-1])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		printer, err := newRepositoryPrinter("json", &out, true, ColumnConfig{})
+		require.NoError(t, err)
+		require.NoError(t, printer.Print(repos))
+		require.NoError(t, printer.Close())
+
+		var rows []struct {
+			Provider string `json:"provider"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &rows))
+		require.Len(t, rows, 2)
+		assert.Equal(t, "github", rows[0].Provider)
+		assert.Equal(t, "bitbucket", rows[1].Provider)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var out bytes.Buffer
+		printer, err := newRepositoryPrinter("table", &out, true, ColumnConfig{})
+		require.NoError(t, err)
+		require.NoError(t, printer.Print(repos))
+		require.NoError(t, printer.Close())
+
+		assert.Contains(t, out.String(), "PROVIDER")
+		assert.Contains(t, out.String(), "bitbucket  repo")
+	})
+}
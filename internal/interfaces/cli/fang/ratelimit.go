@@ -0,0 +1,43 @@
+package fang
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+)
+
+// githubRateLimitStatus returns the clone TUI status line showing the GitHub
+// API budget. The budget is read from GitHub in the background and then kept
+// current from response headers.
+func githubRateLimitStatus(app *Application) func() string {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if _, err := app.githubClient.GetRateLimitInfo(ctx); err != nil {
+			app.logger.Debug("Failed to read the GitHub rate limit", shared.ErrorField(err))
+		}
+	}()
+
+	return func() string {
+		return formatRateLimit(app.githubClient.RateLimitStatus(), time.Now())
+	}
+}
+
+// formatRateLimit describes a rate limit budget for a status line
+func formatRateLimit(info *github.RateLimitInfo, now time.Time) string {
+	if info == nil || info.Limit == 0 {
+		return ""
+	}
+
+	if now.Before(info.PausedUntil) {
+		return fmt.Sprintf("GitHub API: paused by a secondary rate limit for %s",
+			info.PausedUntil.Sub(now).Round(time.Second))
+	}
+
+	resetIn := max(info.ResetTime.Sub(now), 0).Round(time.Minute)
+	return fmt.Sprintf("GitHub API: %d/%d requests left, resets in %s", info.Remaining, info.Limit, resetIn)
+}
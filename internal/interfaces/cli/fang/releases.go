@@ -0,0 +1,198 @@
+package fang
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/release"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+)
+
+// ReleasesConfig holds releases command configuration
+type ReleasesConfig struct {
+	Type        repository.RepositoryType
+	Owner       string
+	Tag         string // Glob matched against release tags
+	Asset       string // Glob matched against asset names
+	Latest      bool
+	Prereleases bool
+	SkipForks   bool
+}
+
+// NewReleasesCommand creates the releases command
+func NewReleasesCommand() *cobra.Command {
+	var config ReleasesConfig
+
+	cmd := &cobra.Command{
+		Use:   "releases [user|org] [owner]",
+		Short: "Download release assets of GitHub repositories",
+		Long: `Download the release assets of every repository of a user or organization.
+
+Assets are stored under <base-dir>/<owner>/<repo>/releases/<tag>/ and
+downloaded concurrently with retries. Assets already present with the
+expected size are skipped, so an interrupted run can simply be repeated.
+Draft releases are never downloaded.`,
+		Example: `  # Download the assets of every release of an organization
+  repocloner releases org kubernetes
+
+  # Only the latest release, Linux tarballs only
+  repocloner releases org kubernetes --latest --asset '*linux*.tar.gz'
+
+  # Releases tagged v1.x, including pre-releases
+  repocloner releases user octocat --tag 'v1.*' --prereleases`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeTypeOwner(githubTypes, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReleases(cmd, args, &config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Tag, "tag", "", "Only download releases whose tag matches this glob (e.g. v1.*)")
+	cmd.Flags().StringVar(&config.Asset, "asset", "", "Only download assets whose name matches this glob (e.g. *.tar.gz)")
+	cmd.Flags().BoolVar(&config.Latest, "latest", false, "Only download the most recent matching release of each repository")
+	cmd.Flags().BoolVar(&config.Prereleases, "prereleases", false, "Include pre-releases")
+	cmd.Flags().BoolVar(&config.SkipForks, "skip-forks", true, "Skip forked repositories")
+	cmd.Flags().Bool("include-forks", false, "Include forked repositories (inverse of --skip-forks)")
+
+	return cmd
+}
+
+// runReleases executes the releases command
+func runReleases(cmd *cobra.Command, args []string, config *ReleasesConfig) error {
+	switch strings.ToLower(args[0]) {
+	case "user", "users":
+		config.Type = repository.RepositoryTypeUser
+	case "org", "orgs", "organization":
+		config.Type = repository.RepositoryTypeOrganization
+	default:
+		return fmt.Errorf("invalid repository type '%s', must be 'user' or 'org'", args[0])
+	}
+	config.Owner = args[1]
+
+	if includeForks, _ := cmd.Flags().GetBool("include-forks"); includeForks {
+		config.SkipForks = false
+	}
+
+	filter := &release.Filter{
+		TagPattern:         config.Tag,
+		AssetPattern:       config.Asset,
+		LatestOnly:         config.Latest,
+		IncludePrereleases: config.Prereleases,
+	}
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+
+	app, _, err := NewApplication(globalConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+	defer func() {
+		if err := app.Close(); err != nil {
+			app.logger.Warn("failed to close application", shared.ErrorField(err))
+		}
+	}()
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Target: %s/%s\n", config.Type, config.Owner)
+	if !globalConfig.HasGitHubAuth() {
+		fmt.Fprintf(out, "Warning: Running without GitHub token (rate limiting may apply)\n")
+	}
+
+	fetchResp, err := app.fetchRepositoriesUseCase.Execute(cmd.Context(),
+		newFetchRequest(config.Type, config.Owner, config.SkipForks))
+	if err != nil {
+		return fmt.Errorf("failed to fetch repositories: %w", err)
+	}
+	if len(fetchResp.Repositories) == 0 {
+		return fmt.Errorf("no repositories found for %s/%s", config.Type, config.Owner)
+	}
+	fmt.Fprintf(out, "Listing releases of %d repositories...\n", len(fetchResp.Repositories))
+
+	tracker := cloning.NewProgressTracker(0)
+	printed := make(chan struct{})
+	go func() {
+		defer close(printed)
+		printJobProgress(out, tracker.Subscribe())
+	}()
+
+	resp, err := app.downloadReleasesUseCase.Execute(cmd.Context(), &usecases.DownloadReleasesRequest{
+		Repositories:    fetchResp.Repositories,
+		BaseDirectory:   globalConfig.BaseDir,
+		Filter:          filter,
+		Concurrency:     globalConfig.Concurrency,
+		MaxRetries:      3,
+		ProgressTracker: tracker,
+	})
+	<-printed
+	if err != nil {
+		return fmt.Errorf("failed to download releases: %w", err)
+	}
+
+	writeReleasesSummary(out, resp)
+
+	if resp.Failed > 0 || len(resp.ListErrors) > 0 {
+		return &ExitCodeError{
+			Code: ExitPartialFailure,
+			Err: fmt.Errorf("%d assets failed to download, %d repositories could not be listed",
+				resp.Failed, len(resp.ListErrors)),
+		}
+	}
+	return nil
+}
+
+// printJobProgress prints a line whenever a job, such as an asset download,
+// finishes. Snapshots may be coalesced, so only the most recent job of a burst
+// is named.
+func printJobProgress(out io.Writer, updates <-chan *cloning.Progress) {
+	processed := 0
+	for progress := range updates {
+		done := progress.Completed + progress.Failed + progress.Skipped
+		if done == processed || progress.RecentCompletion == nil {
+			continue
+		}
+		processed = done
+
+		recent := progress.RecentCompletion
+		status := recent.Status.String()
+		if recent.Error != "" {
+			status = recent.Error
+		}
+		fmt.Fprintf(out, "[%d/%d] %s: %s\n", done, progress.Total, recent.Repository, status)
+	}
+}
+
+// writeReleasesSummary prints the outcome of a releases run
+func writeReleasesSummary(out io.Writer, resp *usecases.DownloadReleasesResponse) {
+	fmt.Fprintf(out, "\nReleases: %d, assets downloaded: %d (%s), skipped: %d, failed: %d in %s\n",
+		resp.Releases, resp.Downloaded, clonetui.FormatBytes(resp.Bytes),
+		resp.Skipped, resp.Failed, resp.TotalDuration.Round(100*time.Millisecond))
+
+	names := make([]string, 0, len(resp.ListErrors))
+	for name := range resp.ListErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %s: %v\n", name, resp.ListErrors[name])
+	}
+	for _, result := range resp.Results {
+		if result.Status == cloning.JobStatusFailed {
+			fmt.Fprintf(out, "  %s@%s/%s: %v\n", result.Repository.GetFullName(), result.Tag, result.Asset.Name, result.Error)
+		}
+	}
+}
@@ -3,8 +3,13 @@ package fang
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/fang"
@@ -12,12 +17,20 @@ import (
 
 	"github.com/italoag/repocloner/internal/application/usecases"
 	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
 	"github.com/italoag/repocloner/internal/domain/shared"
 	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
 	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
 	"github.com/italoag/repocloner/internal/infrastructure/git"
 	"github.com/italoag/repocloner/internal/infrastructure/github"
 	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/infrastructure/metadata"
+	"github.com/italoag/repocloner/internal/infrastructure/network"
+	"github.com/italoag/repocloner/internal/infrastructure/providers"
+	"github.com/italoag/repocloner/internal/infrastructure/redact"
+	"github.com/italoag/repocloner/internal/infrastructure/runs"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+	"github.com/italoag/repocloner/internal/version"
 )
 
 // Application represents the main application with all dependencies
@@ -25,44 +38,67 @@ type Application struct {
 	logger                   shared.Logger
 	githubClient             *github.GitHubClient
 	bitbucketClient          *bitbucket.BitbucketClient
-	gitClient                *git.GitClient
+	cloneBackend             git.CloneBackend
 	workerPool               *concurrency.WorkerPool
 	domainService            *cloning.DomainCloneService
 	fetchRepositoriesUseCase *usecases.FetchRepositoriesUseCase
 	cloneRepositoriesUseCase *usecases.CloneRepositoriesUseCase
+	resolveSourceUseCase     *usecases.ResolveSourceUseCase
+	downloadReleasesUseCase  *usecases.DownloadReleasesUseCase
+	metadataStore            *metadata.Store // nil unless --metadata-db is set
+
+	runID     string      // Tags the log lines and report of this invocation
+	startedAt time.Time   // Start of the invocation
+	runs      *runs.Store // Reports of previous runs, under --data-dir
+	logFile   string
 }
 
 // NewApplication creates and configures the application with all dependencies
 func NewApplication(config *Config) (*Application, *logging.TUILogger, error) {
 	// Initialize TUI logger that writes to file and buffers for display
 	tuiLogger, err := logging.NewTUILogger(&logging.TUILoggerConfig{
-		LogFile:     "logs/repocloner.log",
+		LogFile:     filepath.Join(config.LogDir, "repocloner.log"),
 		Level:       config.LogLevel,
-		BufferSize:  50,
+		BufferSize:  clonetui.LogHistorySize, // Retained for the TUI log viewer
 		Development: true,
+		Format:      config.LogFormat,
+		Rotation:    config.LogRotation,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create TUI logger: %w", err)
 	}
 
-	// Use TUILogger as the main logger (satisfies shared.Logger interface)
-	logger := shared.Logger(tuiLogger)
+	// Use TUILogger as the main logger, masking credentials in every entry.
+	// Every line of the invocation carries its run ID.
+	logger := shared.Logger(logging.NewRedactingLogger(tuiLogger)).With(shared.StringField("run_id", config.RunID))
 
 	logger.Info("Initializing repocloner application",
-		shared.StringField("version", "0.2.0"),
+		shared.StringField("version", version.Get().Version),
 		shared.StringField("go_version", runtime.Version()))
 
+	// Authenticate as a GitHub App installation when configured
+	var githubTokenSource github.TokenSource
+	if config.UsesGitHubApp() {
+		appTokenSource, err := newGitHubAppTokenSource(config, logger)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to configure GitHub App authentication: %w", err)
+		}
+		githubTokenSource = appTokenSource
+	}
+
 	// Initialize GitHub client
 	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{
 		Token:       config.Token,
-		UserAgent:   "repocloner/0.2",
+		TokenSource: githubTokenSource,
+		UserAgent:   version.UserAgent(),
 		Timeout:     30 * time.Second,
-		RateLimiter: github.NewTokenBucketRateLimiter(5000), // GitHub default limit
+		Transport:   config.Transport,
+		RateLimiter: github.NewRateLimiter(config.HasGitHubAuth()),
 		Logger:      logger.With(shared.StringField("component", "github_client")),
 	})
 
 	// Validate GitHub token if provided
-	if config.Token != "" {
+	if config.HasGitHubAuth() {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
@@ -75,12 +111,13 @@ func NewApplication(config *Config) (*Application, *logging.TUILogger, error) {
 
 	// Initialize Bitbucket client
 	bitbucketClient := bitbucket.NewBitbucketClient(&bitbucket.BitbucketClientConfig{
-		Username:    "x-bitbucket-api-token-auth", // For Git operations
-		Email:       config.BitbucketEmail,        // For API operations
+		Username:    config.BitbucketUsername, // Fallback for API operations
+		Email:       config.BitbucketEmail,    // For API operations
 		APIToken:    config.BitbucketAPIToken,
-		UserAgent:   "repocloner/0.2",
+		UserAgent:   version.UserAgent(),
 		Timeout:     30 * time.Second,
-		RateLimiter: bitbucket.NewTokenBucketRateLimiter(1000), // Bitbucket default limit
+		Transport:   config.Transport,
+		RateLimiter: bitbucket.NewTokenBucketRateLimiter(bitbucket.DefaultRateLimit),
 		Logger:      logger.With(shared.StringField("component", "bitbucket_client")),
 	})
 
@@ -96,20 +133,55 @@ func NewApplication(config *Config) (*Application, *logging.TUILogger, error) {
 		}
 	}
 
-	// Initialize Git client
-	gitClient, err := git.NewGitClient(&git.GitClientConfig{
-		Timeout: 10 * time.Minute,
-		Logger:  logger.With(shared.StringField("component", "git_client")),
+	// Initialize Bitbucket Server client when an instance is configured
+	var bitbucketServerClient *bitbucket.BitbucketServerClient
+	if config.BitbucketServerURL != "" {
+		bitbucketServerClient, err = bitbucket.NewBitbucketServerClient(&bitbucket.BitbucketServerClientConfig{
+			BaseURL:   config.BitbucketServerURL,
+			Token:     config.BitbucketServerToken,
+			UserAgent: version.UserAgent(),
+			Timeout:   30 * time.Second,
+			Transport: config.Transport,
+			Logger:    logger.With(shared.StringField("component", "bitbucket_server_client")),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to configure Bitbucket Server: %w", err)
+		}
+	}
+
+	// Configure per-provider credentials handed to git through a credential
+	// helper; without them git authenticates with its own configuration
+	var credentials *git.CredentialStore
+	if config.AuthClones {
+		credentials = newCredentialStore(config, githubTokenSource)
+		if bitbucketServerClient != nil {
+			credentials.RegisterHost(bitbucketServerClient.Host(), git.ProviderBitbucketServer)
+		}
+	} else {
+		logger.Info("Clone authentication disabled, git uses its own credential helpers")
+	}
+
+	// Initialize clone backend (exec git or pure Go)
+	cloneBackend, err := git.NewCloneBackend(config.Backend, &git.GitClientConfig{
+		Timeout:      config.JobTimeout,
+		Logger:       logger.With(shared.StringField("component", "clone_backend")),
+		Credentials:  credentials,
+		MaxBandwidth: config.MaxBandwidth,
+		JobLogDir:    config.LogDir,
+		Network:      &config.Network,
+		Hosts:        cloneHostPolicy(config, bitbucketServerClient),
+		Routes:       config.GitRoutes,
+		ExtraArgs:    config.GitArgs,
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create Git client: %w", err)
+		return nil, nil, fmt.Errorf("failed to create clone backend: %w", err)
 	}
 
-	// Validate Git installation
+	// Validate clone backend (e.g. git installation)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	if err := gitClient.ValidateGitInstallation(ctx); err != nil {
-		return nil, nil, fmt.Errorf("git validation failed: %w", err)
+	if err := cloneBackend.Validate(ctx); err != nil {
+		return nil, nil, fmt.Errorf("clone backend validation failed: %w", err)
 	}
 
 	// Initialize worker pool
@@ -118,13 +190,21 @@ func NewApplication(config *Config) (*Application, *logging.TUILogger, error) {
 		maxWorkers = config.Concurrency
 	}
 
-	workerPool, err := concurrency.NewWorkerPool(&concurrency.WorkerPoolConfig{
-		MaxWorkers: maxWorkers,
-		MaxRetries: 3,
-		RetryDelay: 5 * time.Second,
-		GitClient:  gitClient,
-		Logger:     logger.With(shared.StringField("component", "worker_pool")),
-	})
+	poolConfig := &concurrency.WorkerPoolConfig{
+		MaxWorkers:   maxWorkers,
+		Retry:        config.Retry,
+		HostLimits:   config.HostConcurrency,
+		MinFreeSpace: config.StopAtFreeSpace,
+		Backend:      cloneBackend,
+		Logger:       logger.With(shared.StringField("component", "worker_pool")),
+	}
+	if config.AdaptiveWorkers() {
+		poolConfig.MinWorkers = config.MinWorkers
+		poolConfig.MaxWorkers = config.MaxWorkers
+		poolConfig.InitialWorkers = maxWorkers
+	}
+
+	workerPool, err := concurrency.NewWorkerPool(poolConfig)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create worker pool: %w", err)
 	}
@@ -134,16 +214,36 @@ func NewApplication(config *Config) (*Application, *logging.TUILogger, error) {
 
 	// Initialize use cases
 	fetchRepositoriesUseCase := usecases.NewFetchRepositoriesUseCase(
-		githubClient,
-		bitbucketClient,
+		providers.Default(githubClient, bitbucketClient, bitbucketServerClient),
 		logger.With(shared.StringField("usecase", "fetch_repositories")),
 	)
 
+	resolveSourceUseCase := usecases.NewResolveSourceUseCase(
+		githubClient,
+		bitbucketServerClient,
+		logger.With(shared.StringField("usecase", "resolve_source")),
+	)
+
 	cloneRepositoriesUseCase := usecases.NewCloneRepositoriesUseCase(
 		workerPool,
 		domainService,
 		logger.With(shared.StringField("usecase", "clone_repositories")),
 	)
+	cloneRepositoriesUseCase.SetOptionOverrides(config.CloneOverrides)
+	cloneRepositoriesUseCase.SetRunID(config.RunID)
+
+	downloadReleasesUseCase := usecases.NewDownloadReleasesUseCase(
+		githubClient,
+		logger.With(shared.StringField("usecase", "download_releases")),
+	)
+
+	var metadataStore *metadata.Store
+	if config.MetadataDB != "" {
+		if metadataStore, err = metadata.Open(config.MetadataDB); err != nil {
+			_ = workerPool.Close()
+			return nil, nil, err
+		}
+	}
 
 	logger.Info("Application initialized successfully",
 		shared.IntField("max_workers", maxWorkers))
@@ -152,14 +252,67 @@ func NewApplication(config *Config) (*Application, *logging.TUILogger, error) {
 		logger:                   logger,
 		githubClient:             githubClient,
 		bitbucketClient:          bitbucketClient,
-		gitClient:                gitClient,
+		cloneBackend:             cloneBackend,
 		workerPool:               workerPool,
 		domainService:            domainService,
 		fetchRepositoriesUseCase: fetchRepositoriesUseCase,
 		cloneRepositoriesUseCase: cloneRepositoriesUseCase,
+		resolveSourceUseCase:     resolveSourceUseCase,
+		downloadReleasesUseCase:  downloadReleasesUseCase,
+		metadataStore:            metadataStore,
+		runID:                    config.RunID,
+		startedAt:                config.StartedAt,
+		runs:                     runStore(config),
+		logFile:                  tuiLogger.GetLogFile(),
 	}, tuiLogger, nil
 }
 
+// newCredentialStore builds the git credential store from configured tokens
+func newCredentialStore(config *Config, githubTokenSource github.TokenSource) *git.CredentialStore {
+	store := git.NewCredentialStore()
+	if githubTokenSource != nil {
+		store.SetGitHubTokenFunc(githubTokenSource.Token)
+	} else {
+		store.SetGitHubToken(config.Token)
+	}
+	if config.BitbucketUsername != "" {
+		store.SetBitbucketAppPassword(config.BitbucketUsername, config.BitbucketAPIToken)
+	} else {
+		store.SetBitbucketToken(config.BitbucketAPIToken)
+	}
+	store.SetBitbucketServerToken(config.BitbucketServerUsername, config.BitbucketServerToken)
+	store.SetGitLabToken(config.GitLabToken)
+	return store
+}
+
+// cloneHostPolicy returns the clone URL hosts accepted by the clone backend:
+// --allowed-hosts and the configured Bitbucket Server instance
+func cloneHostPolicy(config *Config, bitbucketServerClient *bitbucket.BitbucketServerClient) git.HostPolicy {
+	allowed := slices.Clone(config.AllowedHosts)
+	if bitbucketServerClient != nil {
+		allowed = append(allowed, bitbucketServerClient.Host())
+	}
+	return git.HostPolicy{AllowedHosts: allowed, Strict: config.StrictHosts}
+}
+
+// newGitHubAppTokenSource loads the app private key and creates the installation token source
+func newGitHubAppTokenSource(config *Config, logger shared.Logger) (*github.AppTokenSource, error) {
+	privateKey, err := os.ReadFile(config.GitHubAppPrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	return github.NewAppTokenSource(&github.AppAuthConfig{
+		AppID:          config.GitHubAppID,
+		InstallationID: config.GitHubAppInstallationID,
+		PrivateKey:     privateKey,
+		UserAgent:      version.UserAgent(),
+		Timeout:        30 * time.Second,
+		Transport:      config.Transport,
+		Logger:         logger.With(shared.StringField("component", "github_app_auth")),
+	})
+}
+
 // Close gracefully shuts down the application
 func (app *Application) Close() error {
 	app.logger.Info("Shutting down application")
@@ -168,12 +321,22 @@ func (app *Application) Close() error {
 		app.logger.Error("Failed to close worker pool", shared.ErrorField(err))
 	}
 
+	if app.metadataStore != nil {
+		if err := app.metadataStore.Close(); err != nil {
+			app.logger.Error("Failed to close metadata database", shared.ErrorField(err))
+		}
+	}
+
 	// Close logger if it's a TUILogger
-	if tuiLogger, ok := app.logger.(*logging.TUILogger); ok {
+	logger := app.logger
+	if redacting, ok := logger.(*logging.RedactingLogger); ok {
+		logger = redacting.Unwrap()
+	}
+	if tuiLogger, ok := logger.(*logging.TUILogger); ok {
 		if err := tuiLogger.Close(); err != nil {
 			return err
 		}
-	} else if zapLogger, ok := app.logger.(*logging.ZapLogger); ok {
+	} else if zapLogger, ok := logger.(*logging.ZapLogger); ok {
 		if err := zapLogger.Close(); err != nil {
 			return err
 		}
@@ -182,22 +345,97 @@ func (app *Application) Close() error {
 	return nil
 }
 
+// recordMetadata stores a snapshot of fetched repositories when --metadata-db
+// is set. A failure only loses the snapshot, so it is logged, not returned.
+func (app *Application) recordMetadata(target string, repos []*repository.Repository) {
+	if app.metadataStore == nil {
+		return
+	}
+	if _, err := app.metadataStore.RecordRun(target, repos); err != nil {
+		app.logger.Warn("Failed to record repository metadata", shared.ErrorField(err))
+	}
+}
+
 // Config holds application configuration
 type Config struct {
 	Token             string // GitHub token
 	BitbucketAPIToken string // Bitbucket API token
 	BitbucketEmail    string // Bitbucket Atlassian account email
+	BitbucketUsername string // Bitbucket username (app password authentication)
+	GitLabToken       string // GitLab access token
+	AuthClones        bool   // Hand the tokens to git for HTTPS clones
 	Concurrency       int
+	MinWorkers        int                        // Adaptive sizing lower bound, 0 unless adaptive
+	MaxWorkers        int                        // Adaptive sizing upper bound, 0 unless adaptive
+	HostConcurrency   concurrency.HostLimits     // Clones running at once per host or host/owner
+	Retry             *concurrency.BackoffPolicy // Retries of failed clone attempts
 	LogLevel          string
+	LogFormat         string // Application log format: console or json
+	LogDir            string // Application log and per-repository logs (<owner>/<repo>.log)
+	DataDir           string // Run reports, under runs/
+	RunID             string // ID of this invocation, see runs.NewID
+	StartedAt         time.Time
+	LogRotation       *logging.RotationConfig // Application log rotation, nil disables it
 	BaseDir           string
+	Backend           string        // Clone backend: git or gogit
+	JobTimeout        time.Duration // Limit of every clone or update attempt
+	ShutdownGrace     time.Duration // Wait for in-flight clones on SIGINT/SIGTERM of headless runs
+	Listen            string        // Address of the HTTP API of running clones, empty disables it
+	MaxBandwidth      int64         // Aggregate clone download cap in bytes/sec (0 = unlimited)
+	StopAtFreeSpace   int64         // Skip the clones left once free disk space falls below this, in bytes (0 = never)
+	MetadataDB        string        // Repository metadata database, empty disables recording
+
+	// Proxy and extra CAs of the API clients and git; Transport is built from
+	// Network and is nil when the defaults apply
+	Network   network.Config
+	Transport http.RoundTripper
+
+	AllowedHosts []string // Clone URL hosts accepted besides the public providers
+	StrictHosts  bool     // Reject clone URLs of other hosts instead of warning
+
+	SkipScopeCheck bool // Start runs without checking the OAuth scopes of the GitHub token
+
+	CloneOverrides cloning.OptionOverrides // Per-pattern clone options of the config file
+	GitRoutes      *git.RouteTable         // Proxies and SSH jump hosts of git remotes, nil connects directly
+	GitArgs        []string                // Extra git clone arguments of the config file and --git-arg
+
+	// GitHub App authentication (takes precedence over Token when set)
+	GitHubAppID             int64
+	GitHubAppInstallationID int64
+	GitHubAppPrivateKeyPath string
+
+	// Bitbucket Server / Data Center (self-hosted)
+	BitbucketServerURL      string // Instance base URL, enables the server client when set
+	BitbucketServerToken    string // HTTP access token
+	BitbucketServerUsername string // Account name for personal tokens (optional)
+}
+
+// UsesGitHubApp reports whether GitHub App authentication is configured
+func (c *Config) UsesGitHubApp() bool {
+	return c.GitHubAppID != 0 || c.GitHubAppInstallationID != 0 || c.GitHubAppPrivateKeyPath != ""
+}
+
+// HasGitHubAuth reports whether any GitHub authentication is configured
+func (c *Config) HasGitHubAuth() bool {
+	return c.Token != "" || c.UsesGitHubApp()
 }
 
 // NewDefaultConfig creates default configuration
 func NewDefaultConfig() *Config {
+	startedAt := time.Now()
 	return &Config{
-		Concurrency: runtime.NumCPU() * 2,
-		LogLevel:    "info",
-		BaseDir:     ".",
+		RunID:         runs.NewID(startedAt),
+		StartedAt:     startedAt,
+		DataDir:       defaultDataDir(),
+		Concurrency:   runtime.NumCPU() * 2,
+		Retry:         concurrency.DefaultBackoffPolicy(),
+		LogLevel:      "info",
+		LogDir:        "logs",
+		LogRotation:   logging.NewDefaultRotationConfig(),
+		BaseDir:       ".",
+		Backend:       git.BackendGit,
+		JobTimeout:    git.DefaultTimeout,
+		ShutdownGrace: defaultShutdownGrace,
 	}
 }
 
@@ -219,7 +457,7 @@ Features:
   • Advanced filtering and configuration options
   • GitHub API rate limiting and token validation
   • Bitbucket API v2.0 support with API token authentication`,
-		Version: "0.2.0",
+		Version: version.Get().String(),
 		Example: `  # Clone all repositories from a GitHub user
   repocloner clone user octocat
 
@@ -239,15 +477,59 @@ Features:
   repocloner completion bash > /etc/bash_completion.d/repocloner`,
 		SilenceUsage:  true,
 		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return applyEnv(cmd)
+		},
 	}
 
 	// Add global flags
+	cmd.PersistentFlags().String("config", "", "Configuration file (default: "+configFileName+" in the user config directory, e.g. ~/.config/repocloner)")
 	cmd.PersistentFlags().String("token", "", "GitHub personal access token (env: GITHUB_TOKEN)")
 	cmd.PersistentFlags().String("bitbucket-api-token", "", "Bitbucket API token (env: BITBUCKET_API_TOKEN)")
 	cmd.PersistentFlags().String("bitbucket-email", "", "Bitbucket Atlassian account email (env: BITBUCKET_EMAIL)")
+	cmd.PersistentFlags().String("bitbucket-username", "", "Bitbucket username when using an app password (env: BITBUCKET_USERNAME)")
+	cmd.PersistentFlags().String("bitbucket-server-url", "", "Bitbucket Server / Data Center base URL (env: BITBUCKET_SERVER_URL)")
+	cmd.PersistentFlags().String("bitbucket-server-token", "", "Bitbucket Server HTTP access token (env: BITBUCKET_SERVER_TOKEN)")
+	cmd.PersistentFlags().String("bitbucket-server-username", "", "Bitbucket Server account name for personal access tokens (env: BITBUCKET_SERVER_USERNAME)")
+	cmd.PersistentFlags().String("gitlab-token", "", "GitLab access token used for git operations (env: GITLAB_TOKEN)")
+	cmd.PersistentFlags().Bool("no-keyring", false, "Ignore the credentials stored in the OS keyring with auth login")
+	cmd.PersistentFlags().Bool("auth-clones", false, "Hand the configured tokens to git for HTTPS clones through a temporary credential helper; by default git authenticates with its own configuration")
+	cmd.PersistentFlags().Int64("github-app-id", 0, "GitHub App ID (env: GITHUB_APP_ID)")
+	cmd.PersistentFlags().Int64("github-app-installation-id", 0, "GitHub App installation ID (env: GITHUB_APP_INSTALLATION_ID)")
+	cmd.PersistentFlags().String("github-app-private-key", "", "Path to the GitHub App private key PEM (env: GITHUB_APP_PRIVATE_KEY_PATH)")
 	cmd.PersistentFlags().String("log-level", "info", "Log level (debug, info, warn, error)")
+	cmd.PersistentFlags().String("log-format", logging.FormatConsole, "Application log format: console, or json for Loki/ELK ingestion")
+	cmd.PersistentFlags().String("log-dir", "logs", "Directory for the application log and per-repository clone logs")
+	cmd.PersistentFlags().String("data-dir", "", "Directory for run reports (default: $XDG_DATA_HOME/repocloner or ~/.local/share/repocloner)")
+	cmd.PersistentFlags().Int("log-max-size", 100, "Rotate the application log after this many megabytes (0 disables rotation)")
+	cmd.PersistentFlags().Int("log-max-backups", 5, "Rotated application logs to keep (0 keeps all)")
+	cmd.PersistentFlags().Int("log-max-age", 0, "Days to keep rotated application logs (0 keeps them regardless of age)")
+	cmd.PersistentFlags().Bool("log-compress", false, "Gzip rotated application logs")
 	cmd.PersistentFlags().Int("concurrency", runtime.NumCPU()*2, "Number of concurrent workers")
+	cmd.PersistentFlags().Int("min-workers", 0, "Resize workers with throughput, never below this count (enables adaptive sizing)")
+	cmd.PersistentFlags().Int("max-workers", 0, "Resize workers with throughput, never above this count (enables adaptive sizing, default: 2x --concurrency)")
+	cmd.PersistentFlags().StringToInt("host-concurrency", nil, "Clones running at once per host or host/owner, e.g. bitbucket.org=4,github.com/acme=2 (* caps every other host)")
+	cmd.PersistentFlags().Int("max-retries", concurrency.DefaultMaxRetries, "Retries of a failed clone attempt (0 disables retries)")
+	cmd.PersistentFlags().Duration("retry-base-delay", concurrency.DefaultRetryBaseDelay, "Delay before the first retry, doubled for every further retry")
+	cmd.PersistentFlags().Duration("retry-max-delay", concurrency.DefaultRetryMaxDelay, "Upper bound of the delay between retries")
+	cmd.PersistentFlags().Float64("retry-jitter", concurrency.DefaultRetryJitter, "Fraction of each retry delay randomized away, 0 to 1")
 	cmd.PersistentFlags().String("base-dir", ".", "Base directory for operations")
+	cmd.PersistentFlags().String("backend", git.BackendGit, "Clone backend (git, gogit)")
+	cmd.PersistentFlags().Duration("timeout", git.DefaultTimeout, "Limit of every clone or update attempt; timed out clones are removed")
+	cmd.PersistentFlags().Duration("shutdown-grace", defaultShutdownGrace, "On SIGINT or SIGTERM of a headless run, wait this long for in-flight clones before cancelling them")
+	cmd.PersistentFlags().String("listen", "", "Serve the progress API and web dashboard of running clones on this address, e.g. 127.0.0.1:8080; a bare port binds to 127.0.0.1")
+	cmd.PersistentFlags().String("max-bandwidth", "", "Cap aggregate download rate of HTTPS clones, e.g. 10MB or 80Mbps (gogit backend; SSH clones fail with a limit set)")
+	cmd.PersistentFlags().String("stop-at-free-space", "", "Stop starting clones once the base directory has less free disk space, e.g. 10GB")
+	cmd.PersistentFlags().String("metadata-db", "", "Record fetched repository metadata in this database file, e.g. ghclone.db")
+	cmd.PersistentFlags().String("proxy", "", "Proxy URL for API requests and clones (default: HTTPS_PROXY, HTTP_PROXY and NO_PROXY)")
+	cmd.PersistentFlags().String("ca-cert", "", "PEM file of certificate authorities to trust in addition to the system roots")
+	cmd.PersistentFlags().String("git-proxy", "", "SOCKS5 or HTTP proxy of git remotes, e.g. socks5://127.0.0.1:1080 (SSH remotes use nc -X)")
+	cmd.PersistentFlags().StringArray("git-arg", nil, "Extra git clone argument, e.g. \"--config core.autocrlf=false\" (repeatable, safe-listed options only, git backend)")
+	cmd.PersistentFlags().String("ssh-jump", "", "SSH jump host of SSH remotes, [user@]host[:port] (git backend only)")
+	cmd.PersistentFlags().String("ssh-proxy-command", "", "SSH ProxyCommand of SSH remotes, e.g. 'cloudflared access ssh --hostname %h' (git backend only)")
+	cmd.PersistentFlags().StringSlice("allowed-hosts", nil, "Self-hosted git hosts to clone from besides github.com, gitlab.com and bitbucket.org, e.g. git.example.com,*.corp.example")
+	cmd.PersistentFlags().Bool("strict-hosts", false, "Reject clone URLs of hosts outside --allowed-hosts instead of warning")
+	cmd.PersistentFlags().Bool("skip-scope-check", false, "Clone without checking that the GitHub token has the OAuth scopes the run needs")
 
 	return cmd
 }
@@ -260,15 +542,37 @@ func Execute(ctx context.Context) error {
 	rootCmd.AddCommand(NewCloneCommand())
 	rootCmd.AddCommand(NewBitbucketCloneCommand())
 	rootCmd.AddCommand(NewListCommand())
-
-	// Apply Fang styling and enhancements
-	return fang.Execute(ctx, rootCmd)
+	rootCmd.AddCommand(NewSearchCommand())
+	rootCmd.AddCommand(NewManifestCommand())
+	rootCmd.AddCommand(NewStatsCommand())
+	rootCmd.AddCommand(NewRunsCommand())
+	rootCmd.AddCommand(NewGCCommand())
+	rootCmd.AddCommand(NewCleanCommand())
+	rootCmd.AddCommand(NewReleasesCommand())
+	rootCmd.AddCommand(NewArchiveCommand())
+	rootCmd.AddCommand(NewScheduleCommand())
+	rootCmd.AddCommand(NewDoctorCommand())
+	rootCmd.AddCommand(NewAuthCommand())
+	rootCmd.AddCommand(NewCompletionCommand())
+	rootCmd.AddCommand(NewManCommand())
+	rootCmd.AddCommand(NewVersionCommand())
+	registerCompletions(rootCmd)
+
+	// Apply Fang styling and enhancements; man pages come from NewManCommand
+	return fang.Execute(ctx, rootCmd, fang.WithoutManpage(), fang.WithoutVersion())
 }
 
 // Helper function to get global config from cobra command
 func getGlobalConfig(cmd *cobra.Command) (*Config, error) {
 	config := NewDefaultConfig()
 
+	fileConfig, err := loadConfigFile(cmd)
+	if err != nil {
+		return nil, err
+	}
+	config.CloneOverrides = fileConfig.Overrides
+	fileConfig.Retry.apply(config.Retry)
+
 	if token, err := cmd.Flags().GetString("token"); err == nil && token != "" {
 		config.Token = token
 	}
@@ -281,14 +585,120 @@ func getGlobalConfig(cmd *cobra.Command) (*Config, error) {
 		config.BitbucketEmail = email
 	}
 
+	if username, err := cmd.Flags().GetString("bitbucket-username"); err == nil && username != "" {
+		config.BitbucketUsername = username
+	}
+
+	applyBitbucketServerConfig(cmd, config)
+
+	if token, err := cmd.Flags().GetString("gitlab-token"); err == nil && token != "" {
+		config.GitLabToken = token
+	}
+
+	applyGitHubAppConfig(cmd, config)
+
+	if authClones, err := cmd.Flags().GetBool("auth-clones"); err == nil {
+		config.AuthClones = authClones
+	}
+
 	if logLevel, err := cmd.Flags().GetString("log-level"); err == nil && logLevel != "" {
 		config.LogLevel = logLevel
 	}
 
+	if logFormat, err := cmd.Flags().GetString("log-format"); err == nil && logFormat != "" {
+		if !slices.Contains(logging.ValidFormats, logFormat) {
+			return nil, fmt.Errorf("invalid --log-format %q, expected one of: %s", logFormat, strings.Join(logging.ValidFormats, ", "))
+		}
+		config.LogFormat = logFormat
+	}
+
+	if logDir, err := cmd.Flags().GetString("log-dir"); err == nil && logDir != "" {
+		config.LogDir = logDir
+	}
+
+	if dataDir, err := cmd.Flags().GetString("data-dir"); err == nil && dataDir != "" {
+		config.DataDir = dataDir
+	}
+
+	if err := applyLogRotationConfig(cmd, config); err != nil {
+		return nil, err
+	}
+
 	if concurrency, err := cmd.Flags().GetInt("concurrency"); err == nil && concurrency > 0 {
 		config.Concurrency = concurrency
 	}
 
+	if err := applyWorkerBounds(cmd, config); err != nil {
+		return nil, err
+	}
+
+	if hostConcurrency, err := cmd.Flags().GetStringToInt("host-concurrency"); err == nil && len(hostConcurrency) > 0 {
+		if config.HostConcurrency, err = concurrency.NewHostLimits(hostConcurrency); err != nil {
+			return nil, fmt.Errorf("invalid --host-concurrency: %w", err)
+		}
+	}
+
+	if err := applyRetryConfig(cmd, config.Retry); err != nil {
+		return nil, err
+	}
+
+	if backend, err := cmd.Flags().GetString("backend"); err == nil && backend != "" {
+		config.Backend = backend
+	}
+
+	if timeout, err := cmd.Flags().GetDuration("timeout"); err == nil {
+		if timeout <= 0 {
+			return nil, fmt.Errorf("--timeout must be positive")
+		}
+		config.JobTimeout = timeout
+	}
+
+	if grace, err := cmd.Flags().GetDuration("shutdown-grace"); err == nil {
+		if grace < 0 {
+			return nil, fmt.Errorf("--shutdown-grace must not be negative")
+		}
+		config.ShutdownGrace = grace
+	}
+
+	if listen, err := cmd.Flags().GetString("listen"); err == nil {
+		config.Listen = listen
+	}
+
+	if bandwidth, err := cmd.Flags().GetString("max-bandwidth"); err == nil && bandwidth != "" {
+		maxBandwidth, err := git.ParseBandwidth(bandwidth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --max-bandwidth: %w", err)
+		}
+		config.MaxBandwidth = maxBandwidth
+	}
+
+	if freeSpace, err := cmd.Flags().GetString("stop-at-free-space"); err == nil && freeSpace != "" {
+		if config.StopAtFreeSpace, err = cloning.ParseSize(freeSpace); err != nil {
+			return nil, fmt.Errorf("invalid --stop-at-free-space: %w", err)
+		}
+	}
+
+	if metadataDB, err := cmd.Flags().GetString("metadata-db"); err == nil {
+		config.MetadataDB = metadataDB
+	}
+
+	if err := applyNetworkConfig(cmd, config); err != nil {
+		return nil, err
+	}
+	if err := applyGitRoutes(cmd, config, fileConfig); err != nil {
+		return nil, err
+	}
+	gitArgs, _ := cmd.Flags().GetStringArray("git-arg")
+	if config.GitArgs, err = git.ParseCloneArgs(append(fileConfig.Git.ExtraArgs, gitArgs...)); err != nil {
+		return nil, fmt.Errorf("invalid --git-arg: %w", err)
+	}
+	applyKeyringCredentials(cmd, config)
+	registerSecrets(config)
+
+	config.AllowedHosts, _ = cmd.Flags().GetStringSlice("allowed-hosts")
+	config.StrictHosts, _ = cmd.Flags().GetBool("strict-hosts")
+	config.SkipScopeCheck, _ = cmd.Flags().GetBool("skip-scope-check")
+
 	if baseDir, err := cmd.Flags().GetString("base-dir"); err == nil && baseDir != "" {
 		// Convert to absolute path
 		if !filepath.IsAbs(baseDir) {
@@ -304,3 +714,176 @@ func getGlobalConfig(cmd *cobra.Command) (*Config, error) {
 
 	return config, nil
 }
+
+// registerSecrets masks the configured credentials in logs and job errors
+func registerSecrets(config *Config) {
+	redact.AddSecrets(
+		config.Token,
+		config.BitbucketAPIToken,
+		config.GitLabToken,
+		config.BitbucketServerToken,
+	)
+	proxies := []string{config.Network.ProxyURL}
+	if config.GitRoutes != nil {
+		proxies = append(proxies, config.GitRoutes.Default.Proxy)
+		for _, route := range config.GitRoutes.Hosts {
+			proxies = append(proxies, route.Proxy)
+		}
+	}
+	for _, raw := range proxies {
+		if proxy, err := url.Parse(raw); err == nil && proxy.User != nil {
+			if password, ok := proxy.User.Password(); ok {
+				redact.AddSecrets(password)
+			}
+		}
+	}
+}
+
+// applyGitRoutes builds the git route table from the git_routes of the config
+// file; --git-proxy, --ssh-jump and --ssh-proxy-command route the other hosts
+func applyGitRoutes(cmd *cobra.Command, config *Config, fileConfig *FileConfig) error {
+	routes := &git.RouteTable{Hosts: make(map[string]git.Route, len(fileConfig.GitRoutes))}
+	for key, route := range fileConfig.GitRoutes {
+		routes.Hosts[key] = route.route()
+	}
+	routes.Default.Proxy, _ = cmd.Flags().GetString("git-proxy")
+	routes.Default.SSHJump, _ = cmd.Flags().GetString("ssh-jump")
+	routes.Default.SSHProxyCommand, _ = cmd.Flags().GetString("ssh-proxy-command")
+	if routes.Default.IsZero() && len(routes.Hosts) == 0 {
+		return nil
+	}
+
+	if err := routes.Default.Validate(); err != nil {
+		return fmt.Errorf("invalid git route: %w", err)
+	}
+	config.GitRoutes = routes
+	return nil
+}
+
+// applyNetworkConfig reads the proxy and CA settings and builds the shared
+// HTTP transport, failing early on an invalid proxy URL or CA file
+func applyNetworkConfig(cmd *cobra.Command, config *Config) error {
+	config.Network.ProxyURL, _ = cmd.Flags().GetString("proxy")
+	config.Network.CACertFile, _ = cmd.Flags().GetString("ca-cert")
+	if config.Network.IsZero() {
+		return nil
+	}
+
+	transport, err := network.NewTransport(&config.Network)
+	if err != nil {
+		return fmt.Errorf("invalid network settings: %w", err)
+	}
+	config.Transport = transport
+	return nil
+}
+
+// applyWorkerBounds reads the adaptive worker pool bounds. Setting either
+// bound enables adaptive sizing starting from --concurrency workers.
+func applyWorkerBounds(cmd *cobra.Command, config *Config) error {
+	minWorkers, _ := cmd.Flags().GetInt("min-workers")
+	maxWorkers, _ := cmd.Flags().GetInt("max-workers")
+	if minWorkers < 0 || maxWorkers < 0 {
+		return fmt.Errorf("--min-workers and --max-workers must not be negative")
+	}
+	if minWorkers == 0 && maxWorkers == 0 {
+		return nil
+	}
+
+	if minWorkers == 0 {
+		minWorkers = 1
+	}
+	if maxWorkers == 0 {
+		maxWorkers = max(config.Concurrency*2, minWorkers)
+	}
+	if minWorkers > maxWorkers {
+		return fmt.Errorf("--min-workers (%d) must not exceed --max-workers (%d)", minWorkers, maxWorkers)
+	}
+
+	config.MinWorkers = minWorkers
+	config.MaxWorkers = maxWorkers
+	return nil
+}
+
+// applyRetryConfig reads the retry flags given on the command line or in the
+// environment; the others keep the configuration file or default values
+func applyRetryConfig(cmd *cobra.Command, policy *concurrency.BackoffPolicy) error {
+	flags := cmd.Flags()
+	if flags.Changed("max-retries") {
+		policy.MaxRetries, _ = flags.GetInt("max-retries")
+	}
+	if flags.Changed("retry-base-delay") {
+		policy.BaseDelay, _ = flags.GetDuration("retry-base-delay")
+	}
+	if flags.Changed("retry-max-delay") {
+		policy.MaxDelay, _ = flags.GetDuration("retry-max-delay")
+	}
+	if flags.Changed("retry-jitter") {
+		policy.Jitter, _ = flags.GetFloat64("retry-jitter")
+	}
+
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("invalid retry settings: %w", err)
+	}
+	return nil
+}
+
+// AdaptiveWorkers reports whether the worker count adapts to throughput
+func (c *Config) AdaptiveWorkers() bool {
+	return c.MinWorkers > 0 && c.MinWorkers < c.MaxWorkers
+}
+
+// DescribeWorkers describes the worker configuration for startup banners
+func (c *Config) DescribeWorkers() string {
+	if c.AdaptiveWorkers() {
+		return fmt.Sprintf("%d-%d adaptive workers, starting at %d",
+			c.MinWorkers, c.MaxWorkers, min(max(c.Concurrency, c.MinWorkers), c.MaxWorkers))
+	}
+	return fmt.Sprintf("%d workers", c.Concurrency)
+}
+
+// applyLogRotationConfig reads the application log rotation flags
+func applyLogRotationConfig(cmd *cobra.Command, config *Config) error {
+	rotation := logging.NewDefaultRotationConfig()
+	if maxSize, err := cmd.Flags().GetInt("log-max-size"); err == nil {
+		if maxSize == 0 {
+			config.LogRotation = nil
+			return nil
+		}
+		rotation.MaxSizeMB = maxSize
+	}
+	if maxBackups, err := cmd.Flags().GetInt("log-max-backups"); err == nil {
+		rotation.MaxBackups = maxBackups
+	}
+	if maxAge, err := cmd.Flags().GetInt("log-max-age"); err == nil {
+		rotation.MaxAgeDays = maxAge
+	}
+	if compress, err := cmd.Flags().GetBool("log-compress"); err == nil {
+		rotation.Compress = compress
+	}
+
+	if err := rotation.Validate(); err != nil {
+		return fmt.Errorf("invalid log rotation settings: %w", err)
+	}
+	config.LogRotation = rotation
+	return nil
+}
+
+// applyGitHubAppConfig reads GitHub App settings
+func applyGitHubAppConfig(cmd *cobra.Command, config *Config) {
+	if appID, err := cmd.Flags().GetInt64("github-app-id"); err == nil {
+		config.GitHubAppID = appID
+	}
+	if installationID, err := cmd.Flags().GetInt64("github-app-installation-id"); err == nil {
+		config.GitHubAppInstallationID = installationID
+	}
+	if keyPath, err := cmd.Flags().GetString("github-app-private-key"); err == nil {
+		config.GitHubAppPrivateKeyPath = keyPath
+	}
+}
+
+// applyBitbucketServerConfig reads Bitbucket Server settings
+func applyBitbucketServerConfig(cmd *cobra.Command, config *Config) {
+	config.BitbucketServerURL, _ = cmd.Flags().GetString("bitbucket-server-url")
+	config.BitbucketServerToken, _ = cmd.Flags().GetString("bitbucket-server-token")
+	config.BitbucketServerUsername, _ = cmd.Flags().GetString("bitbucket-server-username")
+}
@@ -0,0 +1,339 @@
+package fang
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/runs"
+	"github.com/italoag/repocloner/internal/interfaces/output"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+	"github.com/italoag/repocloner/internal/version"
+)
+
+// defaultDataDir returns the directory of run reports:
+// $XDG_DATA_HOME/repocloner, falling back to ~/.local/share/repocloner
+func defaultDataDir() string {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "repocloner")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".local", "share", "repocloner")
+	}
+	return filepath.Join(os.TempDir(), "repocloner")
+}
+
+// runStore returns the store of the run reports under the data directory
+func runStore(config *Config) *runs.Store {
+	return runs.NewStore(filepath.Join(config.DataDir, "runs"))
+}
+
+// recordRun saves the report of a finished clone run and returns its change
+// from the previous run of the same command, nil when there is none
+func (app *Application) recordRun(cmd *cobra.Command, resp *usecases.CloneRepositoriesResponse) (*runs.Diff, error) {
+	report := newRunReport(app.runID, runCommandLine(cmd), resp)
+	report.StartedAt = app.startedAt
+	report.FinishedAt = time.Now()
+	report.Duration = report.FinishedAt.Sub(report.StartedAt)
+	report.LogFile = app.logFile
+
+	previous, err := app.runs.Previous(report)
+	if err != nil {
+		return nil, err
+	}
+	if err := app.runs.Save(report); err != nil {
+		return nil, err
+	}
+	return runs.Compare(previous, report), nil
+}
+
+// runCommandLine returns the command path and arguments of an invocation.
+// Flags are left out, they may carry credentials.
+func runCommandLine(cmd *cobra.Command) string {
+	return strings.Join(append([]string{cmd.CommandPath()}, cmd.Flags().Args()...), " ")
+}
+
+// newRunReport builds the report of a clone run from its response
+func newRunReport(id, command string, resp *usecases.CloneRepositoriesResponse) *runs.Report {
+	report := &runs.Report{
+		ID:        id,
+		Command:   command,
+		Version:   version.Get().Version,
+		Total:     resp.TotalJobs,
+		Completed: resp.CompletedJobs,
+		Updated:   resp.UpdatedJobs,
+		Failed:    resp.FailedJobs,
+		Skipped:   resp.SkippedJobs,
+		Cancelled: resp.CancelledJobs,
+		TimedOut:  resp.TimedOutJobs,
+	}
+
+	for _, result := range resp.Results {
+		job := result.Job
+		repo := runs.Repository{
+			Name:   job.Repository.GetFullName(),
+			URL:    job.Repository.CloneURL,
+			Status: job.Status.String(),
+
+			DefaultBranch: job.Repository.DefaultBranch,
+			Revision:      result.Revision,
+			Branch:        result.Branch,
+		}
+		if job.Status != cloning.JobStatusCompleted && job.Status != cloning.JobStatusUpdated && job.Error != nil {
+			repo.Error = job.Error.Error()
+		}
+		if report.BaseDir == "" {
+			report.BaseDir = job.BaseDirectory
+		}
+		report.Repositories = append(report.Repositories, repo)
+	}
+	return report
+}
+
+// RunsConfig holds runs command configuration
+type RunsConfig struct {
+	Format  string
+	Limit   int
+	Columns ColumnConfig
+}
+
+// NewRunsCommand creates the runs command browsing the reports of previous
+// clone runs
+func NewRunsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runs",
+		Short: "Browse the reports of previous clone runs",
+		Long: `Every clone run gets a run ID, printed in the TUI header, tagging each line
+of the application log (run_id) and naming the report saved under
+--data-dir/runs when the run finishes.
+
+The report records the command, the result counts and the outcome of each
+repository.`,
+		Example: `  # The latest runs
+  repocloner runs list
+
+  # The failures of the latest run
+  repocloner runs show
+
+  # A run by a prefix of its ID, as JSON
+  repocloner runs show 20250601-1425 --format json
+
+  # The log lines of that run
+  grep 20250601-142530-3f9a1c logs/repocloner.log`,
+	}
+
+	cmd.AddCommand(newRunsListCommand(), newRunsShowCommand())
+	return cmd
+}
+
+// newRunsListCommand creates the runs list command
+func newRunsListCommand() *cobra.Command {
+	var config RunsConfig
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List previous clone runs, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRunsList(cmd, &config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Format, "format", output.FormatTable, "Output format (table, json, csv)")
+	completeFlag(cmd, "format", output.FormatTable, output.FormatJSON, output.FormatCSV)
+	cmd.Flags().IntVar(&config.Limit, "limit", 20, "Maximum number of runs to list (0 lists all)")
+	addColumnFlags(cmd, &config.Columns, output.Names(runColumns()))
+
+	return cmd
+}
+
+// runRunsList executes the runs list command
+func runRunsList(cmd *cobra.Command, config *RunsConfig) error {
+	switch config.Format {
+	case output.FormatTable, output.FormatJSON, output.FormatCSV:
+	default:
+		return fmt.Errorf("invalid format '%s', must be 'table', 'json' or 'csv'", config.Format)
+	}
+	if config.Limit < 0 {
+		return fmt.Errorf("invalid limit %d, must not be negative", config.Limit)
+	}
+	columns, err := output.Select(runColumns(), config.Columns.Columns, defaultRunColumns)
+	if err != nil {
+		return err
+	}
+
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+	store := runStore(globalConfig)
+	reports, err := store.List()
+	if err != nil {
+		return err
+	}
+	if config.Limit > 0 && len(reports) > config.Limit {
+		reports = reports[:config.Limit]
+	}
+
+	if config.Format == output.FormatTable && len(reports) == 0 {
+		_, err := fmt.Fprintf(cmd.OutOrStdout(), "No runs recorded in %s.\n", store.Dir())
+		return err
+	}
+
+	writer, err := output.NewWriter(cmd.OutOrStdout(), columns, config.Columns.options(config.Format))
+	if err != nil {
+		return err
+	}
+	if err := writer.Write(reports); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// defaultRunColumns are the columns printed by runs list by default
+var defaultRunColumns = []string{"id", "started", "duration", "total", "completed", "failed", "command"}
+
+// runColumns are the columns of the runs list command
+func runColumns() []output.Column[*runs.Report] {
+	count := func(name string, n func(r *runs.Report) int) output.Column[*runs.Report] {
+		return output.Column[*runs.Report]{Name: name, Width: 9,
+			Text:  func(r *runs.Report) string { return fmt.Sprint(n(r)) },
+			Value: func(r *runs.Report) any { return n(r) }}
+	}
+
+	return []output.Column[*runs.Report]{
+		{Name: "id", Width: 22,
+			Text: func(r *runs.Report) string { return r.ID }},
+		{Name: "started", Width: 19,
+			Text:  func(r *runs.Report) string { return r.StartedAt.Local().Format(time.DateTime) },
+			Value: func(r *runs.Report) any { return r.StartedAt }},
+		{Name: "duration", Width: 10,
+			Text:  func(r *runs.Report) string { return r.Duration.Round(time.Second).String() },
+			Value: func(r *runs.Report) any { return r.Duration.Seconds() }},
+		count("total", func(r *runs.Report) int { return r.Total }),
+		count("completed", func(r *runs.Report) int { return r.Completed }),
+		count("updated", func(r *runs.Report) int { return r.Updated }),
+		count("failed", func(r *runs.Report) int { return r.Failed }),
+		count("skipped", func(r *runs.Report) int { return r.Skipped }),
+		count("cancelled", func(r *runs.Report) int { return r.Cancelled }),
+		{Name: "command", Width: 40,
+			Text: func(r *runs.Report) string { return r.Command }},
+		{Name: "base_dir", Header: "BASE DIR", Width: 30,
+			Text: func(r *runs.Report) string { return r.BaseDir }},
+		{Name: "version", Width: 10,
+			Text: func(r *runs.Report) string { return r.Version }},
+	}
+}
+
+// newRunsShowCommand creates the runs show command
+func newRunsShowCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "show [run-id]",
+		Short: "Show the report of a clone run, the latest by default",
+		Long: `Show the report of a clone run: its command, result counts and the
+repositories that did not clone, and what changed since the previous run of
+the same command. The run is given by its ID or a unique
+prefix of it and defaults to the latest run. JSON output is the complete
+report, including every repository.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := ""
+			if len(args) > 0 {
+				id = args[0]
+			}
+			return runRunsShow(cmd, id, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	completeFlag(cmd, "format", "text", output.FormatJSON)
+
+	return cmd
+}
+
+// runRunsShow executes the runs show command
+func runRunsShow(cmd *cobra.Command, id, format string) error {
+	if format != "text" && format != output.FormatJSON {
+		return fmt.Errorf("invalid format '%s', must be 'text' or 'json'", format)
+	}
+
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+	store := runStore(globalConfig)
+
+	var report *runs.Report
+	if id == "" {
+		reports, err := store.List()
+		if err != nil {
+			return err
+		}
+		if len(reports) == 0 {
+			return fmt.Errorf("no runs recorded in %s", store.Dir())
+		}
+		report = reports[0]
+	} else if report, err = store.Load(id); err != nil {
+		return err
+	}
+
+	if format == output.FormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	writeRunReport(cmd.OutOrStdout(), report)
+
+	previous, err := store.Previous(report)
+	if err != nil {
+		return err
+	}
+	clonetui.WriteRunDiff(cmd.OutOrStdout(), runs.Compare(previous, report))
+	return nil
+}
+
+// writeRunReport prints a run report, listing the repositories that did not
+// clone or update
+func writeRunReport(w io.Writer, report *runs.Report) {
+	fmt.Fprintf(w, "Run:       %s\n", report.ID)
+	fmt.Fprintf(w, "Command:   %s\n", report.Command)
+	fmt.Fprintf(w, "Version:   %s\n", report.Version)
+	fmt.Fprintf(w, "Started:   %s\n", report.StartedAt.Local().Format(time.DateTime))
+	fmt.Fprintf(w, "Duration:  %s\n", report.Duration.Round(time.Second))
+	fmt.Fprintf(w, "Base dir:  %s\n", report.BaseDir)
+	if report.LogFile != "" {
+		fmt.Fprintf(w, "Log file:  %s\n", report.LogFile)
+	}
+	fmt.Fprintf(w, "\nRepositories: %d, cloned: %d, updated: %d, failed: %d, skipped: %d, cancelled: %d\n",
+		report.Total, report.Completed, report.Updated, report.Failed, report.Skipped, report.Cancelled)
+
+	for _, status := range []cloning.JobStatus{cloning.JobStatusFailed, cloning.JobStatusCancelled, cloning.JobStatusSkipped} {
+		var repos []runs.Repository
+		for _, repo := range report.Repositories {
+			if repo.Status == status.String() {
+				repos = append(repos, repo)
+			}
+		}
+		if len(repos) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s (%d):\n", strings.ToUpper(status.String()[:1])+status.String()[1:], len(repos))
+		for _, repo := range repos {
+			if repo.Error != "" {
+				fmt.Fprintf(w, "  %s: %s\n", repo.Name, repo.Error)
+			} else {
+				fmt.Fprintf(w, "  %s\n", repo.Name)
+			}
+		}
+	}
+}
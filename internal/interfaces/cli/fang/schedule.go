@@ -0,0 +1,196 @@
+package fang
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/italoag/repocloner/internal/application/services"
+	"github.com/italoag/repocloner/internal/domain/schedule"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// ScheduleConfig holds schedule command configuration
+type ScheduleConfig struct {
+	RunNow    bool
+	ReportDir string // Per-run logs and runs.jsonl, default <log-dir>/schedule
+}
+
+// NewScheduleCommand creates the schedule command
+func NewScheduleCommand() *cobra.Command {
+	var config ScheduleConfig
+
+	cmd := &cobra.Command{
+		Use:   "schedule <cron> <command> [args...]",
+		Short: "Re-run a clone or list command on a cron schedule",
+		Long: `Run another repocloner command on a cron schedule until interrupted.
+
+The schedule is a standard five-field cron expression (minute, hour, day of
+month, month, day of week) or one of @hourly, @daily, @weekly, @monthly and
+@yearly, evaluated in local time. Runs never overlap: when a run is due while
+the previous one is still active it is skipped and reported as such.
+
+Every run executes as a separate process. Its output is written to
+run-<time>.log and a JSON report line is appended to runs.jsonl, both in the
+report directory. Global flags given to schedule are passed on to each run.`,
+		Example: `  # Nightly mirror of an organization at 02:00
+  repocloner schedule "0 2 * * *" clone org acme --base-dir /srv/mirror
+
+  # Hourly listing recorded for change tracking, starting right away
+  repocloner schedule @hourly --run-now list org acme --metadata-db acme.db`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSchedule(cmd, args, &config)
+		},
+	}
+
+	// Flags after the scheduled command belong to that command
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().BoolVar(&config.RunNow, "run-now", false, "Start a run immediately instead of waiting for the first scheduled time")
+	cmd.Flags().StringVar(&config.ReportDir, "report-dir", "", "Directory for run logs and runs.jsonl (default: <log-dir>/schedule)")
+
+	return cmd
+}
+
+// runSchedule executes the schedule command
+func runSchedule(cmd *cobra.Command, args []string, config *ScheduleConfig) error {
+	cron, err := schedule.ParseCron(args[0])
+	if err != nil {
+		return err
+	}
+
+	target, _, err := cmd.Root().Find(args[1:])
+	if err != nil || target == cmd.Root() || target == cmd {
+		return fmt.Errorf("unknown command to schedule: %s", args[1])
+	}
+
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+
+	if config.ReportDir == "" {
+		config.ReportDir = filepath.Join(globalConfig.LogDir, "schedule")
+	}
+	if err := os.MkdirAll(config.ReportDir, 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	consoleLogger, err := logging.NewConsoleLogger(globalConfig.LogLevel, false)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer func() { _ = consoleLogger.Close() }()
+	logger := logging.NewRedactingLogger(consoleLogger)
+
+	runArgs := append(append([]string{}, args[1:]...), inheritedFlagArgs(cmd)...)
+	out := cmd.OutOrStdout()
+
+	scheduler, err := services.NewScheduler(&services.SchedulerConfig{
+		Schedule: cron,
+		RunNow:   config.RunNow,
+		Logger:   logger,
+		Run: func(ctx context.Context, run *services.RunReport) error {
+			run.LogFile = filepath.Join(config.ReportDir,
+				fmt.Sprintf("run-%s.log", run.ScheduledAt.Format("20060102-150405")))
+			return runScheduledCommand(ctx, executable, runArgs, run.LogFile)
+		},
+		OnReport: func(report *services.RunReport) {
+			if err := appendRunReport(filepath.Join(config.ReportDir, "runs.jsonl"), report); err != nil {
+				logger.Warn("Failed to write run report", shared.ErrorField(err))
+			}
+			fmt.Fprintln(out, formatRunReport(report))
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintf(out, "Scheduling %q: %s (reports in %s)\n", cron, joinArgs(args[1:]), config.ReportDir)
+	return scheduler.Run(ctx)
+}
+
+// inheritedFlagArgs returns the global flags set on the command line so each
+// run uses the same configuration
+func inheritedFlagArgs(cmd *cobra.Command) []string {
+	var args []string
+	cmd.InheritedFlags().Visit(func(flag *pflag.Flag) {
+		args = append(args, fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String()))
+	})
+	return args
+}
+
+// runScheduledCommand runs one scheduled command as a child process with its
+// output in logFile
+func runScheduledCommand(ctx context.Context, executable string, args []string, logFile string) error {
+	file, err := os.Create(logFile)
+	if err != nil {
+		return fmt.Errorf("failed to create run log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	child := exec.CommandContext(ctx, executable, args...)
+	child.Stdout = file
+	child.Stderr = file
+	// Nobody is around to answer confirmation prompts of unattended runs
+	child.Env = append(os.Environ(), EnvName("yes")+"=true")
+	child.Cancel = func() error { return child.Process.Signal(os.Interrupt) }
+
+	if err := child.Run(); err != nil {
+		return fmt.Errorf("%s: %w", joinArgs(args[:1]), err)
+	}
+	return nil
+}
+
+// appendRunReport appends a JSON line describing a run to path
+func appendRunReport(path string, report *services.RunReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// formatRunReport summarizes a run on one line
+func formatRunReport(report *services.RunReport) string {
+	switch {
+	case report.Skipped:
+		return fmt.Sprintf("run %d: skipped, previous run still in progress", report.ID)
+	case report.Error != "":
+		return fmt.Sprintf("run %d: failed after %s: %s (log: %s)", report.ID, report.Duration.Round(time.Second), report.Error, report.LogFile)
+	default:
+		return fmt.Sprintf("run %d: finished in %s (log: %s)", report.ID, report.Duration.Round(time.Second), report.LogFile)
+	}
+}
+
+// joinArgs renders command arguments for display
+func joinArgs(args []string) string {
+	return strings.Join(args, " ")
+}
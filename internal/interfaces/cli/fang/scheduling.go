@@ -0,0 +1,22 @@
+package fang
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// addOrderFlag registers the --order scheduling flag of clone commands
+func addOrderFlag(cmd *cobra.Command, order *string) {
+	cmd.Flags().StringVar(order, "order", string(cloning.OrderFetched),
+		"Order jobs are submitted in: fetched, smallest, largest, name or pushed; it decides which clones start first, not the order they finish in")
+	completeFlag(cmd, "order",
+		string(cloning.OrderFetched), string(cloning.OrderSmallest), string(cloning.OrderLargest),
+		string(cloning.OrderName), string(cloning.OrderPushed))
+}
+
+// addDedupeFlag registers the --dedupe flag of clone commands
+func addDedupeFlag(cmd *cobra.Command, dedupe *bool) {
+	cmd.Flags().BoolVar(dedupe, "dedupe", false,
+		"Skip repositories whose remote URL is already selected or cloned in the base directory")
+}
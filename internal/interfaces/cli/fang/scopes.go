@@ -0,0 +1,103 @@
+package fang
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+)
+
+// scopeRequirement is an OAuth scope a run needs and why
+type scopeRequirement struct {
+	Scope  string
+	Reason string
+}
+
+// requiredScopes returns the OAuth scopes that the GitHub listings of a run
+// need from a classic token, one requirement per scope
+func requiredScopes(requests []*usecases.FetchRepositoriesRequest) []scopeRequirement {
+	var required []scopeRequirement
+	add := func(scope, reason string) {
+		for _, r := range required {
+			if r.Scope == scope {
+				return
+			}
+		}
+		required = append(required, scopeRequirement{Scope: scope, Reason: reason})
+	}
+
+	for _, req := range requests {
+		if !req.Type.IsGitHubType() {
+			continue
+		}
+		switch {
+		case req.Team != "":
+			add(github.ScopeReadOrg, fmt.Sprintf("list the repositories of team %s/%s", req.Owner, req.Team))
+		case req.Type == repository.RepositoryTypeOrganization:
+			add(github.ScopeReadOrg, fmt.Sprintf("list the repositories of organization %s", req.Owner))
+		}
+		if req.Filter != nil && (req.Filter.Visibility == repository.VisibilityPrivate || req.Filter.Visibility == repository.VisibilityInternal) {
+			add(github.ScopeRepo, fmt.Sprintf("clone %s repositories", req.Filter.Visibility))
+		}
+	}
+	return required
+}
+
+// preflightTokenScopes fails a run early when the classic GitHub token lacks
+// a scope the run needs. Tokens that do not report scopes, such as
+// fine-grained tokens and GitHub App installations, are not checked, and a
+// failed check only logs a warning: the run reports real access errors.
+func preflightTokenScopes(ctx context.Context, app *Application, config *Config, requests []*usecases.FetchRepositoriesRequest) error {
+	if config.SkipScopeCheck || config.Token == "" || config.UsesGitHubApp() {
+		return nil
+	}
+	required := requiredScopes(requests)
+	if len(required) == 0 {
+		return nil
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	granted, known, err := app.githubClient.TokenScopes(checkCtx)
+	if err != nil {
+		app.logger.Warn("GitHub token scope check failed", shared.ErrorField(err))
+		return nil
+	}
+	if !known {
+		app.logger.Debug("GitHub token does not report OAuth scopes, skipping scope check")
+		return nil
+	}
+
+	return missingScopesError(granted, required)
+}
+
+// missingScopesError lists the required scopes that are not granted, or
+// returns nil when every scope is granted
+func missingScopesError(granted []string, required []scopeRequirement) error {
+	scopes := make([]string, len(required))
+	for i, r := range required {
+		scopes[i] = r.Scope
+	}
+	missing := github.MissingScopes(granted, scopes)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "GitHub token is missing required scopes: %s", strings.Join(missing, ", "))
+	for _, r := range required {
+		for _, scope := range missing {
+			if r.Scope == scope {
+				fmt.Fprintf(&b, "\n  %s: needed to %s", r.Scope, r.Reason)
+			}
+		}
+	}
+	b.WriteString("\nGrant them at https://github.com/settings/tokens, or pass --skip-scope-check to run anyway")
+	return fmt.Errorf("%w: %s", repository.ErrAuthenticationFailed, b.String())
+}
@@ -0,0 +1,70 @@
+package fang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+func TestRequiredScopes(t *testing.T) {
+	privateFilter := repository.NewRepositoryFilter()
+	privateFilter.Visibility = repository.VisibilityPrivate
+
+	tests := []struct {
+		name     string
+		requests []*usecases.FetchRepositoriesRequest
+		want     []scopeRequirement
+	}{
+		{
+			name:     "public user listing",
+			requests: []*usecases.FetchRepositoriesRequest{{Type: repository.RepositoryTypeUser, Owner: "octocat"}},
+		},
+		{
+			name: "organization and private repositories",
+			requests: []*usecases.FetchRepositoriesRequest{
+				{Type: repository.RepositoryTypeOrganization, Owner: "acme", Filter: privateFilter},
+				{Type: repository.RepositoryTypeOrganization, Owner: "other"},
+			},
+			want: []scopeRequirement{
+				{Scope: "read:org", Reason: "list the repositories of organization acme"},
+				{Scope: "repo", Reason: "clone private repositories"},
+			},
+		},
+		{
+			name:     "team",
+			requests: []*usecases.FetchRepositoriesRequest{{Type: repository.RepositoryTypeOrganization, Owner: "acme", Team: "platform"}},
+			want:     []scopeRequirement{{Scope: "read:org", Reason: "list the repositories of team acme/platform"}},
+		},
+		{
+			name:     "bitbucket is not checked",
+			requests: []*usecases.FetchRepositoriesRequest{{Type: repository.RepositoryTypeBitbucketWorkspace, Owner: "acme", Filter: privateFilter}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, requiredScopes(tt.requests))
+		})
+	}
+}
+
+func TestMissingScopesError(t *testing.T) {
+	required := []scopeRequirement{
+		{Scope: "read:org", Reason: "list the repositories of organization acme"},
+		{Scope: "repo", Reason: "clone private repositories"},
+	}
+
+	assert.NoError(t, missingScopesError([]string{"repo", "admin:org"}, required))
+
+	err := missingScopesError([]string{"public_repo"}, required)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, repository.ErrAuthenticationFailed)
+	assert.Equal(t, ExitAuthError, ExitCode(err))
+	assert.Contains(t, err.Error(), "missing required scopes: read:org, repo")
+	assert.Contains(t, err.Error(), "repo: needed to clone private repositories")
+	assert.Contains(t, err.Error(), "--skip-scope-check")
+}
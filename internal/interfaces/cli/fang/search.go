@@ -0,0 +1,253 @@
+package fang
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/interfaces/output"
+	"github.com/italoag/repocloner/internal/interfaces/tui/clonetui"
+	"github.com/italoag/repocloner/internal/version"
+)
+
+// SearchConfig holds search command configuration
+type SearchConfig struct {
+	Query   string
+	Format  string
+	Limit   int
+	Columns ColumnConfig
+	Clone   bool        // Clone the matches instead of listing them
+	Cloning CloneConfig // Clone settings of --clone
+}
+
+// NewSearchCommand creates the search subcommand
+func NewSearchCommand() *cobra.Command {
+	var config SearchConfig
+
+	cmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Select GitHub repositories with a search query",
+		Long: `Select GitHub repositories with the qualifiers of the GitHub search API and
+list or clone the matches.
+
+Search qualifiers express selections the filter flags cannot, for example
+topics, stars, licenses or several owners at once:
+  org:acme language:go archived:false
+  user:octocat topic:cli stars:>10
+  org:acme org:acme-labs pushed:>2024-01-01 fork:true
+
+The search API returns at most the first 1000 matches of a query; narrow the
+query (e.g. by pushed: or created: ranges) to select more. Forks only match
+with fork:true or fork:only.
+
+With --clone the matches are cloned into <base-dir>/<owner>/<repo> with the
+same TUI and settings as the clone command.`,
+		Example: `  # List the active Go repositories of an organization
+  repocloner search "org:acme language:go archived:false"
+
+  # Clone them
+  repocloner search "org:acme language:go archived:false" --clone
+
+  # Popular repositories of a topic, as JSON
+  repocloner search "topic:kubernetes stars:>1000" --limit 20 --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config.Query = args[0]
+			return runSearchCommand(cmd, &config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Format, "format", "table", "Output format of the matches (table, json, csv)")
+	completeFlag(cmd, "format", "table", "json", "csv")
+	cmd.Flags().IntVar(&config.Limit, "limit", -1, "Limit number of matches")
+	addColumnFlags(cmd, &config.Columns, output.Names(output.RepositoryColumns))
+	cmd.Flags().BoolVar(&config.Clone, "clone", false, "Clone the matching repositories instead of listing them")
+
+	// Clone settings, used with --clone
+	cmd.Flags().IntVar(&config.Cloning.Depth, "depth", 1, "Clone depth for shallow clones (0 for full history)")
+	cmd.Flags().StringVar(&config.Cloning.Branch, "branch", "", "Specific branch to clone (default: repository default branch)")
+	addSubmoduleFlags(cmd, &config.Cloning.Submodules)
+	addSparseFlags(cmd, &config.Cloning.Sparse)
+	addFilterFlag(cmd, &config.Cloning.Filter)
+	addSkipLFSFlag(cmd, &config.Cloning.SkipLFS)
+	addExistingFlags(cmd, &config.Cloning.Existing)
+	addSizeLimitFlag(cmd, &config.Cloning.SizeLimit)
+	addDefaultBranchOnlyFlag(cmd, &config.Cloning.DefaultBranchOnly)
+	addFailOnFlag(cmd, &config.Cloning.FailOn)
+	addOrderFlag(cmd, &config.Cloning.Order)
+	addDedupeFlag(cmd, &config.Cloning.Dedupe)
+	addOrgDirsFlag(cmd, &config.Cloning.OrgDirs)
+	addYesFlag(cmd, &config.Cloning.Yes)
+	addCloneOutputFlag(cmd, &config.Cloning.Output)
+
+	return cmd
+}
+
+// runSearchCommand executes the search command logic
+func runSearchCommand(cmd *cobra.Command, config *SearchConfig) error {
+	config.Query = strings.TrimSpace(config.Query)
+	if config.Query == "" {
+		return fmt.Errorf("search query cannot be empty")
+	}
+
+	globalConfig, err := getGlobalConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get global configuration: %w", err)
+	}
+
+	if config.Clone {
+		return runSearchClone(cmd, globalConfig, config)
+	}
+
+	switch config.Format {
+	case "table", "json", "csv":
+	default:
+		return fmt.Errorf("invalid format '%s', must be 'table', 'json' or 'csv'", config.Format)
+	}
+
+	fetchUseCase, err := newGitHubFetchUseCase(globalConfig)
+	if err != nil {
+		return err
+	}
+
+	printer, err := newRepositoryPrinter(config.Format, cmd.OutOrStdout(), false, config.Columns)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+	defer cancel()
+
+	req := newSearchRequest(config.Query)
+	req.OnPage = limitPages(config.Limit, printer.Print)
+	if _, err := fetchUseCase.Execute(ctx, req); err != nil {
+		return fmt.Errorf("failed to search repositories: %w", err)
+	}
+	return printer.Close()
+}
+
+// runSearchClone clones the matches of a search into per-owner directories of
+// the base directory
+func runSearchClone(cmd *cobra.Command, globalConfig *Config, config *SearchConfig) error {
+	cloneConfig := &config.Cloning
+	if err := validateCloneOutput(cloneConfig.Output); err != nil {
+		return err
+	}
+	if err := checkConfirmable(cloneConfig.Yes, false); err != nil {
+		return err
+	}
+
+	policy, err := cloning.ParseFailurePolicy(cloneConfig.FailOn)
+	if err != nil {
+		return err
+	}
+
+	order, err := cloning.ParseJobOrder(cloneConfig.Order)
+	if err != nil {
+		return err
+	}
+
+	if err := cloneConfig.Existing.validate(); err != nil {
+		return err
+	}
+
+	if err := cloneConfig.SizeLimit.validate(); err != nil {
+		return err
+	}
+
+	if err := cloning.ValidateBranchPatterns(cloneConfig.DefaultBranchOnly); err != nil {
+		return fmt.Errorf("invalid --default-branch-only: %w", err)
+	}
+
+	if err := cloneConfig.Sparse.load(); err != nil {
+		return err
+	}
+
+	filter, err := cloning.ParseCloneFilter(cloneConfig.Filter)
+	if err != nil {
+		return err
+	}
+	cloneConfig.Filter = string(filter)
+
+	app, tuiLogger, err := NewApplication(globalConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+	defer func() {
+		if err := app.Close(); err != nil {
+			app.logger.Warn("failed to close application", shared.ErrorField(err))
+		}
+	}()
+
+	messages := cloneMessages(cloneConfig.Output)
+	fmt.Fprintf(messages, "%s - Concurrent Repository Cloner\n", version.Title())
+	fmt.Fprintf(messages, "Search: %s\n", config.Query)
+	fmt.Fprintf(messages, "Concurrency: %s\n", globalConfig.DescribeWorkers())
+	fmt.Fprintf(messages, "Base directory: %s\n", globalConfig.BaseDir)
+	fmt.Fprintf(messages, "Log file: %s\n", tuiLogger.GetLogFile())
+	if !globalConfig.HasGitHubAuth() {
+		fmt.Fprintf(messages, "Warning: Running without GitHub token (the search API allows 10 requests per minute)\n")
+	}
+	fmt.Fprintf(messages, "Starting...\n\n")
+
+	if err := os.MkdirAll(globalConfig.BaseDir, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	options := createCloneOptions(cloneConfig)
+	options.CreateOrgDirs = true
+
+	req := newSearchRequest(config.Query)
+	req.ResolveUpstreams = true
+	fetch := func(ctx context.Context) ([]*repository.Repository, error) {
+		var repos []*repository.Repository
+		req.OnPage = limitPages(config.Limit, func(page []*repository.Repository) error {
+			repos = append(repos, page...)
+			return nil
+		})
+		if _, err := app.fetchRepositoriesUseCase.Execute(ctx, req); err != nil {
+			return nil, err
+		}
+		return repos, nil
+	}
+
+	resp, err := runClone(cmd, globalConfig, cloneConfig.Output, &clonetui.Config{
+		Title:        version.Title() + " - Concurrent Repository Cloner",
+		Target:       fmt.Sprintf("search %q", config.Query),
+		Directory:    globalConfig.BaseDir,
+		Fetch:        fetch,
+		CloneUseCase: app.cloneRepositoriesUseCase,
+		Options:      options,
+		Concurrency:  globalConfig.Concurrency,
+		Order:        order,
+		Dedupe:       cloneConfig.Dedupe,
+		Confirm:      !cloneConfig.Yes,
+		Logger:       tuiLogger,
+		Status:       githubRateLimitStatus(app),
+	})
+	if err != nil {
+		return err
+	}
+	return finishCloneRun(cmd, app, resp, policy)
+}
+
+// newSearchRequest creates the request selecting the repositories of a
+// search. The query decides which forks match, so none are filtered out.
+func newSearchRequest(query string) *usecases.FetchRepositoriesRequest {
+	filter := repository.NewRepositoryFilter()
+	filter.IncludeForks = true
+
+	return &usecases.FetchRepositoriesRequest{
+		Query:      query,
+		Filter:     filter,
+		Pagination: repository.NewPaginationOptions(),
+	}
+}
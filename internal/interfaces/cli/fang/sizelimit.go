@@ -0,0 +1,40 @@
+package fang
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// SizeLimitConfig holds the --skip-larger-than flag of clone commands
+type SizeLimitConfig struct {
+	SkipLargerThan string // Skip repositories reported larger, e.g. 2GB; empty for no limit
+	maxSize        int64
+}
+
+// addSizeLimitFlag registers the --skip-larger-than flag on a clone command
+func addSizeLimitFlag(cmd *cobra.Command, config *SizeLimitConfig) {
+	cmd.Flags().StringVar(&config.SkipLargerThan, "skip-larger-than", "",
+		"Skip repositories whose provider-reported size exceeds this, e.g. 2GB")
+}
+
+// validate parses the size limit for apply
+func (c *SizeLimitConfig) validate() error {
+	if c.SkipLargerThan == "" {
+		c.maxSize = 0
+		return nil
+	}
+	maxSize, err := cloning.ParseSize(c.SkipLargerThan)
+	if err != nil {
+		return fmt.Errorf("invalid --skip-larger-than: %w", err)
+	}
+	c.maxSize = maxSize
+	return nil
+}
+
+// apply copies the validated limit into clone options
+func (c *SizeLimitConfig) apply(options *cloning.CloneOptions) {
+	options.MaxSize = c.maxSize
+}
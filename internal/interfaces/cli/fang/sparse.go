@@ -0,0 +1,69 @@
+package fang
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// SparseConfig holds the sparse checkout flags shared by the clone commands
+type SparseConfig struct {
+	Paths []string // Directories to check out, from --sparse
+	File  string   // File listing directories to check out, one per line
+}
+
+// addSparseFlags registers the sparse checkout flags on a clone command
+func addSparseFlags(cmd *cobra.Command, config *SparseConfig) {
+	cmd.Flags().StringSliceVar(&config.Paths, "sparse", nil,
+		"Check out only these directories of a blob-less clone (comma-separated)")
+	cmd.Flags().StringVar(&config.File, "sparse-file", "",
+		"File listing the directories to check out, one per line (# starts a comment)")
+}
+
+// load reads the directories of --sparse-file into the sparse paths and
+// validates them
+func (c *SparseConfig) load() error {
+	if c.File != "" {
+		paths, err := readSparseFile(c.File)
+		if err != nil {
+			return err
+		}
+		c.Paths = append(c.Paths, paths...)
+	}
+	return cloning.ValidateSparsePaths(c.Paths)
+}
+
+// apply copies the sparse paths into clone options
+func (c *SparseConfig) apply(options *cloning.CloneOptions) {
+	options.SparsePaths = c.Paths
+}
+
+// readSparseFile reads cone directories from a file, skipping blank lines
+// and comments. A leading slash, as written in sparse-checkout files, is
+// dropped since paths are relative to the repository root anyway.
+func readSparseFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sparse file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var paths []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, strings.TrimLeft(line, "/"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sparse file: %w", err)
+	}
+	return paths, nil
+}
@@ -0,0 +1,25 @@
+package fang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparseConfig_Load(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "sparse.txt")
+	require.NoError(t, os.WriteFile(file, []byte("# services\n/services/api\n\nlibs/common\n"), 0644))
+
+	config := SparseConfig{Paths: []string{"docs"}, File: file}
+	require.NoError(t, config.load())
+	assert.Equal(t, []string{"docs", "services/api", "libs/common"}, config.Paths)
+
+	config = SparseConfig{Paths: []string{"../outside"}}
+	assert.Error(t, config.load())
+
+	config = SparseConfig{File: filepath.Join(t.TempDir(), "missing.txt")}
+	assert.Error(t, config.load())
+}
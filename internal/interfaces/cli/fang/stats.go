@@ -0,0 +1,194 @@
+package fang
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/interfaces/output"
+)
+
+// StatsConfig holds stats command configuration
+type StatsConfig struct {
+	Format  string
+	Depth   int
+	Columns ColumnConfig
+}
+
+// NewStatsCommand creates the stats command analyzing an existing clone tree
+func NewStatsCommand() *cobra.Command {
+	var config StatsConfig
+
+	cmd := &cobra.Command{
+		Use:   "stats [dir]",
+		Short: "Analyze the repositories of an existing clone tree",
+		Long: `Walk a base directory and report every git repository found in it: its disk
+usage, remote host, whether the working tree has uncommitted changes and when
+it last fetched, followed by a breakdown per host.
+
+The directory defaults to --base-dir. Repositories are searched up to
+--scan-depth levels deep, which covers the flat, --org-dirs and
+--provider-dirs layouts by default.
+
+--columns picks the repository columns of the table and CSV formats; JSON
+output is the complete report.`,
+		Example: `  # Analyze the clones under ./workspace
+  repocloner stats ./workspace
+
+  # Report as JSON, e.g. for monitoring
+  repocloner stats ./mirrors --format json
+
+  # Dirty clones as CSV rows
+  repocloner stats --format csv --columns path,dirty --no-header | grep ,true`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := ""
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return runStats(cmd, dir, &config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.Format, "format", output.FormatTable, "Output format (table, json, csv)")
+	completeFlag(cmd, "format", output.FormatTable, output.FormatJSON, output.FormatCSV)
+	cmd.Flags().IntVar(&config.Depth, "scan-depth", 3, "Maximum directory depth to search for repositories")
+	addColumnFlags(cmd, &config.Columns, output.Names(workspaceColumns(time.Now())))
+
+	return cmd
+}
+
+// runStats executes the stats command
+func runStats(cmd *cobra.Command, dir string, config *StatsConfig) error {
+	switch config.Format {
+	case output.FormatTable, output.FormatJSON, output.FormatCSV:
+	default:
+		return fmt.Errorf("invalid format '%s', must be 'table', 'json' or 'csv'", config.Format)
+	}
+	columns, err := output.Select(workspaceColumns(time.Now()), config.Columns.Columns, defaultWorkspaceColumns)
+	if err != nil {
+		return err
+	}
+
+	if dir == "" {
+		globalConfig, err := getGlobalConfig(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to get global configuration: %w", err)
+		}
+		dir = globalConfig.BaseDir
+	}
+
+	logger, err := logging.NewConsoleLogger("warn", false)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer func() { _ = logger.Close() }()
+
+	stats, err := usecases.NewWorkspaceStatsUseCase(logger).Execute(cmd.Context(), &usecases.WorkspaceStatsRequest{
+		BaseDirectory: dir,
+		MaxDepth:      config.Depth,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to analyze %s: %w", dir, err)
+	}
+
+	if config.Format == output.FormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+	return displayWorkspaceStats(cmd.OutOrStdout(), stats, columns, config.Columns.options(config.Format))
+}
+
+// defaultWorkspaceColumns are the repository columns printed by default
+var defaultWorkspaceColumns = []string{"path", "host", "size", "dirty", "last_fetch"}
+
+// workspaceColumns are the columns of the repositories of a clone tree. Last
+// fetch times are printed relative to now.
+func workspaceColumns(now time.Time) []output.Column[usecases.CloneStats] {
+	return []output.Column[usecases.CloneStats]{
+		{Name: "path", Width: 40,
+			Text: func(c usecases.CloneStats) string { return c.Path }},
+		{Name: "host", Width: 20,
+			Text:  func(c usecases.CloneStats) string { return cmp.Or(c.Host, "-") },
+			Value: func(c usecases.CloneStats) any { return c.Host }},
+		{Name: "remote_url", Header: "REMOTE", Width: 50,
+			Text: func(c usecases.CloneStats) string { return c.RemoteURL }},
+		{Name: "branch", Width: 20,
+			Text: func(c usecases.CloneStats) string { return c.Branch }},
+		{Name: "size", Width: 10,
+			Text:  func(c usecases.CloneStats) string { return formatSize(c.Size) },
+			Value: func(c usecases.CloneStats) any { return c.Size }},
+		{Name: "dirty", Width: 6,
+			Text: func(c usecases.CloneStats) string {
+				if c.Dirty {
+					return "Yes"
+				}
+				return "No"
+			},
+			Value: func(c usecases.CloneStats) any { return c.Dirty }},
+		{Name: "last_fetch", Header: "LAST FETCH", Width: 14,
+			Text: func(c usecases.CloneStats) string {
+				if c.LastFetch.IsZero() {
+					return "never"
+				}
+				return formatAge(now.Sub(c.LastFetch))
+			},
+			Value: func(c usecases.CloneStats) any { return c.LastFetch }},
+		{Name: "error", Width: 40,
+			Text: func(c usecases.CloneStats) string { return c.Error }},
+	}
+}
+
+// displayWorkspaceStats writes the repositories of a clone tree in the
+// columns and format of opts. Tables end with the breakdown per host unless
+// the header is left out.
+func displayWorkspaceStats(w io.Writer, stats *usecases.WorkspaceStats, columns []output.Column[usecases.CloneStats], opts output.Options) error {
+	table := opts.Format == output.FormatTable
+	if table && len(stats.Repositories) == 0 {
+		_, err := fmt.Fprintf(w, "No repositories found in %s.\n", stats.BaseDirectory)
+		return err
+	}
+
+	writer, err := output.NewWriter(w, columns, opts)
+	if err != nil {
+		return err
+	}
+	if err := writer.Write(stats.Repositories); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil || !table || opts.NoHeader {
+		return err
+	}
+
+	fmt.Fprintf(w, "\n%-20s %-8s %-10s\n", "HOST", "REPOS", "SIZE")
+	fmt.Fprintln(w, strings.Repeat("-", 40))
+	for _, host := range stats.Hosts {
+		fmt.Fprintf(w, "%-20s %-8d %-10s\n", truncateString(host.Host, 20), host.Repositories, formatSize(host.Size))
+	}
+
+	_, err = fmt.Fprintf(w, "\nTotal: %d repositories, %s, %d dirty\n",
+		len(stats.Repositories), formatSize(stats.TotalSize), stats.Dirty)
+	return err
+}
+
+// formatAge formats a duration as a coarse age such as "3h ago" or "12d ago"
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
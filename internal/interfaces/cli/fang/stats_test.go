@@ -0,0 +1,68 @@
+package fang
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/interfaces/output"
+)
+
+func TestDisplayWorkspaceStats(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stats := &usecases.WorkspaceStats{
+		BaseDirectory: "/mirrors",
+		Repositories: []usecases.CloneStats{
+			{Path: "alice/tools", Host: "github.com", Size: 2048, Dirty: true, LastFetch: now.Add(-3 * time.Hour)},
+			{Path: "bob/api", Size: 512},
+		},
+		Hosts: []usecases.HostStats{
+			{Host: "github.com", Repositories: 1, Size: 2048},
+			{Host: "unknown", Repositories: 1, Size: 512},
+		},
+		TotalSize: 2560,
+		Dirty:     1,
+	}
+
+	columns, err := output.Select(workspaceColumns(now), nil, defaultWorkspaceColumns)
+	require.NoError(t, err)
+	table := output.Options{Format: output.FormatTable}
+
+	var out bytes.Buffer
+	require.NoError(t, displayWorkspaceStats(&out, stats, columns, table))
+
+	assert.Regexp(t, `alice/tools\s+github.com\s+2.0KB\s+Yes\s+3h ago`, out.String())
+	assert.Regexp(t, `bob/api\s+-\s+512B\s+No\s+never`, out.String())
+	assert.Regexp(t, `unknown\s+1\s+512B`, out.String())
+	assert.Contains(t, out.String(), "Total: 2 repositories, 2.5KB, 1 dirty")
+
+	out.Reset()
+	require.NoError(t, displayWorkspaceStats(&out, &usecases.WorkspaceStats{BaseDirectory: "/empty"}, columns, table))
+	assert.Equal(t, "No repositories found in /empty.\n", out.String())
+
+	columns, err = output.Select(workspaceColumns(now), []string{"path", "dirty", "size"}, defaultWorkspaceColumns)
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, displayWorkspaceStats(&out, stats, columns, output.Options{Format: output.FormatCSV, NoHeader: true}))
+	assert.Equal(t, "alice/tools,true,2048\nbob/api,false,512\n", out.String())
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		age      time.Duration
+		expected string
+	}{
+		{30 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{26 * time.Hour, "1d ago"},
+		{10 * 24 * time.Hour, "10d ago"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatAge(tt.age))
+	}
+}
@@ -0,0 +1,28 @@
+package fang
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// SubmoduleConfig holds the submodule flags shared by the clone commands
+type SubmoduleConfig struct {
+	NoSubmodules      bool
+	SubmoduleDepth    int
+	ShallowSubmodules bool
+}
+
+// addSubmoduleFlags registers the submodule flags on a clone command
+func addSubmoduleFlags(cmd *cobra.Command, config *SubmoduleConfig) {
+	cmd.Flags().BoolVar(&config.NoSubmodules, "no-submodules", false, "Do not initialize submodules")
+	cmd.Flags().IntVar(&config.SubmoduleDepth, "submodule-depth", 0, "Maximum submodule nesting level to initialize (0 for unlimited)")
+	cmd.Flags().BoolVar(&config.ShallowSubmodules, "shallow-submodules", false, "Clone submodules with a history depth of 1")
+}
+
+// apply copies the submodule settings into clone options
+func (c *SubmoduleConfig) apply(options *cloning.CloneOptions) {
+	options.RecurseSubmodules = !c.NoSubmodules
+	options.SubmoduleDepth = c.SubmoduleDepth
+	options.ShallowSubmodules = c.ShallowSubmodules
+}
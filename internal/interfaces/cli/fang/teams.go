@@ -0,0 +1,44 @@
+package fang
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// TeamConfig holds the GitHub team scoping flags shared by the clone and list commands
+type TeamConfig struct {
+	Team          string
+	MinPermission string
+}
+
+// addTeamFlags registers the team scoping flags on a command
+func addTeamFlags(cmd *cobra.Command, config *TeamConfig) {
+	cmd.Flags().StringVar(&config.Team, "team", "", "Only repositories of this organization team (team slug)")
+	cmd.Flags().StringVar(&config.MinPermission, "min-permission", "",
+		"Minimum access of the team, or of the token user without --team: pull, triage, push, maintain or admin")
+}
+
+// apply scopes a fetch request to the configured team and minimum permission
+func (c *TeamConfig) apply(req *usecases.FetchRepositoriesRequest) error {
+	if c.Team != "" && req.Type != repository.RepositoryTypeOrganization {
+		return fmt.Errorf("--team requires an organization (org) owner")
+	}
+	req.Team = c.Team
+
+	if c.MinPermission != "" {
+		permission, err := repository.ParsePermission(c.MinPermission)
+		if err != nil {
+			return err
+		}
+		if req.Filter == nil {
+			req.Filter = repository.NewRepositoryFilter()
+		}
+		req.Filter.MinPermission = permission
+	}
+
+	return nil
+}
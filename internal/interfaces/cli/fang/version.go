@@ -0,0 +1,48 @@
+package fang
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/version"
+)
+
+// NewVersionCommand creates the version command
+func NewVersionCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show version, commit and build information",
+		Example: `  repocloner version
+  repocloner version --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := version.Get()
+			out := cmd.OutOrStdout()
+
+			switch format {
+			case "json":
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(info)
+			case "text":
+				fmt.Fprintln(out, version.Title())
+				fmt.Fprintf(out, "Commit:     %s\n", info.Commit)
+				fmt.Fprintf(out, "Built:      %s\n", info.Date)
+				fmt.Fprintf(out, "Go version: %s\n", info.GoVersion)
+				fmt.Fprintf(out, "Platform:   %s\n", info.Platform)
+				return nil
+			default:
+				return fmt.Errorf("unsupported format: %s (use text or json)", format)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	completeFlag(cmd, "format", "text", "json")
+
+	return cmd
+}
@@ -0,0 +1,21 @@
+package fang
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// addVisibilityFlag registers the --visibility filter of the list and clone commands
+func addVisibilityFlag(cmd *cobra.Command, visibility *string) {
+	cmd.Flags().StringVar(visibility, "visibility", "", "Only repositories with this visibility: public, private or internal")
+	completeFlag(cmd, "visibility", "public", "private", "internal")
+}
+
+// parseVisibilityFlag validates --visibility; empty keeps every repository
+func parseVisibilityFlag(value string) (repository.Visibility, error) {
+	if value == "" {
+		return "", nil
+	}
+	return repository.ParseVisibility(value)
+}
@@ -0,0 +1,27 @@
+package output
+
+import "fmt"
+
+// FormatSize formats a size in bytes in a human readable unit
+func FormatSize(bytes int64) string {
+	if bytes < 1024 {
+		return fmt.Sprintf("%dB", bytes)
+	} else if bytes < 1024*1024 {
+		return fmt.Sprintf("%.1fKB", float64(bytes)/1024)
+	} else if bytes < 1024*1024*1024 {
+		return fmt.Sprintf("%.1fMB", float64(bytes)/(1024*1024))
+	} else {
+		return fmt.Sprintf("%.1fGB", float64(bytes)/(1024*1024*1024))
+	}
+}
+
+// Truncate shortens a string to at most maxLen bytes, ending it with "..."
+func Truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	if maxLen <= 3 {
+		return s[:maxLen]
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,107 @@
+// Package output renders rows as tables, JSON or CSV with selectable columns,
+// shared by the listing and reporting commands
+package output
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// Column describes one field of rows of type T
+type Column[T any] struct {
+	Name   string // Key in --columns, JSON objects and CSV headers
+	Header string // Table header, defaults to the upper-cased name
+	Width  int    // Table width, longer values are truncated; 0 leaves the value as is
+
+	// Text renders the human readable table cell
+	Text func(T) string
+
+	// Value returns the machine readable value of JSON and CSV output,
+	// defaulting to Text
+	Value func(T) any
+
+	OmitEmpty bool // Leave the JSON key out when Value is the zero value
+}
+
+// header returns the table header of the column
+func (c Column[T]) header() string {
+	if c.Header != "" {
+		return c.Header
+	}
+	return strings.ToUpper(c.Name)
+}
+
+// value returns the machine readable value of a row
+func (c Column[T]) value(row T) any {
+	if c.Value != nil {
+		return c.Value(row)
+	}
+	return c.Text(row)
+}
+
+// Names returns the names of columns, e.g. for flag completion
+func Names[T any](columns []Column[T]) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// Select returns the named columns in the order given, or the defaults when
+// no name is given. Unknown names fail listing the available columns.
+func Select[T any](columns []Column[T], names, defaults []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		names = defaults
+	}
+
+	selected := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		i := slices.IndexFunc(columns, func(column Column[T]) bool { return column.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(Names(columns), ", "))
+		}
+		selected = append(selected, columns[i])
+	}
+	return selected, nil
+}
+
+// Options configure a writer
+type Options struct {
+	Format   string // table, json or csv
+	NoHeader bool   // Leave out the table header and the CSV header row
+}
+
+// Writer writes rows in an output format as they arrive
+type Writer[T any] interface {
+	// Write writes a batch of rows
+	Write(rows []T) error
+
+	// Close completes the output once every batch was written
+	Close() error
+}
+
+// NewWriter creates the writer of the format of the options, writing the
+// given columns to w
+func NewWriter[T any](w io.Writer, columns []Column[T], opts Options) (Writer[T], error) {
+	switch opts.Format {
+	case FormatTable, "":
+		return &tableWriter[T]{w: w, columns: columns, noHeader: opts.NoHeader}, nil
+	case FormatJSON:
+		return &jsonWriter[T]{w: w, columns: columns}, nil
+	case FormatCSV:
+		return newCSVWriter(w, columns, opts.NoHeader), nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", opts.Format)
+	}
+}
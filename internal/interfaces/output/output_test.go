@@ -0,0 +1,101 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRow struct {
+	Name  string
+	Count int
+	Tags  []string
+	Seen  time.Time
+}
+
+var testColumns = []Column[testRow]{
+	{Name: "name", Width: 8, Text: func(r testRow) string { return r.Name }},
+	{Name: "count", Header: "N", Width: 3,
+		Text:  func(r testRow) string { return "#" },
+		Value: func(r testRow) any { return r.Count }},
+	{Name: "tags", OmitEmpty: true,
+		Text:  func(r testRow) string { return "" },
+		Value: func(r testRow) any { return r.Tags }},
+	{Name: "seen",
+		Text:  func(r testRow) string { return "" },
+		Value: func(r testRow) any { return r.Seen }},
+}
+
+var testRows = []testRow{
+	{Name: "a-very-long-name", Count: 2, Tags: []string{"x", "y"}, Seen: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+	{Name: "b", Count: 3},
+}
+
+func write(t *testing.T, columns []Column[testRow], opts Options) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	writer, err := NewWriter(&out, columns, opts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(testRows[:1]))
+	require.NoError(t, writer.Write(testRows[1:]))
+	require.NoError(t, writer.Close())
+	return out.String()
+}
+
+func TestSelect(t *testing.T) {
+	columns, err := Select(testColumns, nil, []string{"count", "name"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"count", "name"}, Names(columns))
+
+	columns, err = Select(testColumns, []string{" Name ", "tags"}, []string{"count"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "tags"}, Names(columns))
+
+	_, err = Select(testColumns, []string{"size"}, nil)
+	assert.EqualError(t, err, `unknown column "size" (available: name, count, tags, seen)`)
+}
+
+func TestTableWriter(t *testing.T) {
+	columns := testColumns[:2]
+	assert.Equal(t, "NAME     N  \n------------\na-ver... #  \nb        #  \n", write(t, columns, Options{Format: FormatTable}))
+	assert.Equal(t, "a-ver... #  \nb        #  \n", write(t, columns, Options{Format: FormatTable, NoHeader: true}))
+}
+
+func TestJSONWriter(t *testing.T) {
+	out := write(t, testColumns, Options{Format: FormatJSON})
+
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	require.Len(t, rows, 2)
+	assert.Equal(t, map[string]any{"name": "a-very-long-name", "count": 2.0, "tags": []any{"x", "y"}, "seen": "2025-01-02T03:04:05Z"}, rows[0])
+	assert.NotContains(t, rows[1], "tags")
+
+	var empty bytes.Buffer
+	writer, err := NewWriter(&empty, testColumns, Options{Format: FormatJSON})
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	assert.Equal(t, "[]\n", empty.String())
+}
+
+func TestCSVWriter(t *testing.T) {
+	assert.Equal(t, "name,count,tags,seen\na-very-long-name,2,x;y,2025-01-02T03:04:05Z\nb,3,,\n",
+		write(t, testColumns, Options{Format: FormatCSV}))
+	assert.Equal(t, "a-very-long-name,2,x;y,2025-01-02T03:04:05Z\nb,3,,\n",
+		write(t, testColumns, Options{Format: FormatCSV, NoHeader: true}))
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewWriter(&bytes.Buffer{}, testColumns, Options{Format: "xml"})
+	assert.Error(t, err)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate("short", 10))
+	assert.Equal(t, "abcdefg...", Truncate("abcdefghijklmnop", 10))
+	assert.Equal(t, "ab", Truncate("abcdef", 2))
+}
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// RepositoryColumns are the columns of repository listings
+var RepositoryColumns = []Column[*repository.Repository]{
+	{Name: "provider", Width: 10, OmitEmpty: true,
+		Text: func(r *repository.Repository) string { return r.Provider() }},
+	{Name: "name", Width: 30,
+		Text: func(r *repository.Repository) string { return r.Name }},
+	{Name: "full_name", Width: 40,
+		Text: func(r *repository.Repository) string { return r.GetFullName() }},
+	{Name: "owner", Width: 20,
+		Text: func(r *repository.Repository) string { return r.Owner }},
+	{Name: "clone_url", Width: 50,
+		Text: func(r *repository.Repository) string { return r.CloneURL }},
+	{Name: "size", Width: 10,
+		Text:  func(r *repository.Repository) string { return FormatSize(r.Size) },
+		Value: func(r *repository.Repository) any { return r.Size }},
+	{Name: "language", Width: 15,
+		Text:  func(r *repository.Repository) string { return orNA(r.Language) },
+		Value: func(r *repository.Repository) any { return r.Language }},
+	{Name: "fork", Width: 8,
+		Text:  func(r *repository.Repository) string { return yesNo(r.IsFork) },
+		Value: func(r *repository.Repository) any { return r.IsFork }},
+	{Name: "archived", Width: 8,
+		Text:  func(r *repository.Repository) string { return yesNo(r.Archived) },
+		Value: func(r *repository.Repository) any { return r.Archived }},
+	{Name: "visibility", Width: 10, OmitEmpty: true,
+		Text: func(r *repository.Repository) string { return string(r.Visibility) }},
+	{Name: "default_branch", Header: "BRANCH", Width: 15,
+		Text: func(r *repository.Repository) string { return r.DefaultBranch }},
+	{Name: "updated_at", Header: "UPDATED", Width: 20,
+		Text:  func(r *repository.Repository) string { return formatDate(r.UpdatedAt) },
+		Value: func(r *repository.Repository) any { return r.UpdatedAt }},
+	{Name: "pushed_at", Header: "PUSHED", Width: 20, OmitEmpty: true,
+		Text:  func(r *repository.Repository) string { return formatDate(r.PushedAt) },
+		Value: func(r *repository.Repository) any { return r.PushedAt }},
+	{Name: "description", Width: 50, OmitEmpty: true,
+		Text: func(r *repository.Repository) string { return r.Description }},
+	{Name: "topics", Width: 30, OmitEmpty: true,
+		Text:  func(r *repository.Repository) string { return strings.Join(r.Topics, ",") },
+		Value: func(r *repository.Repository) any { return r.Topics }},
+}
+
+// Default repository columns of the table format and of the JSON and CSV
+// formats, which add the provider column to multi-provider listings
+var (
+	DefaultRepositoryTableColumns = []string{"name", "size", "language", "fork", "updated_at"}
+	DefaultRepositoryDataColumns  = []string{"name", "full_name", "clone_url", "size", "language", "fork", "default_branch", "updated_at", "description"}
+)
+
+// RepositoryColumnNames returns the default repository columns of a format,
+// with the provider column when provider is set
+func RepositoryColumnNames(format string, provider bool) []string {
+	if format == FormatTable {
+		if provider {
+			return append([]string{"provider"}, DefaultRepositoryTableColumns...)
+		}
+		return DefaultRepositoryTableColumns
+	}
+	if provider {
+		return append(slices.Clone(DefaultRepositoryDataColumns), "provider")
+	}
+	return DefaultRepositoryDataColumns
+}
+
+// yesNo renders a flag as a table cell
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
+
+// orNA renders an unknown value as N/A
+func orNA(value string) string {
+	if value == "" {
+		return "N/A"
+	}
+	return value
+}
+
+// formatDate renders a time as a date, or - when unknown
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
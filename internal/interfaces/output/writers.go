@@ -0,0 +1,218 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tableWriter writes rows as fixed width columns under a header
+type tableWriter[T any] struct {
+	w        io.Writer
+	columns  []Column[T]
+	noHeader bool
+	started  bool
+}
+
+// Write writes table rows, preceded by the header on the first call
+func (t *tableWriter[T]) Write(rows []T) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if !t.started && !t.noHeader {
+		headers := make([]string, len(t.columns))
+		width := len(t.columns) - 1
+		for i, column := range t.columns {
+			headers[i] = column.header()
+			width += max(column.Width, len(headers[i]))
+		}
+		if _, err := fmt.Fprintln(t.w, t.line(headers)); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(t.w, strings.Repeat("-", width)); err != nil {
+			return err
+		}
+	}
+	t.started = true
+
+	cells := make([]string, len(t.columns))
+	for _, row := range rows {
+		for i, column := range t.columns {
+			cells[i] = column.Text(row)
+		}
+		if _, err := fmt.Fprintln(t.w, t.line(cells)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// line pads and truncates cells to the widths of their columns
+func (t *tableWriter[T]) line(cells []string) string {
+	var b strings.Builder
+	for i, column := range t.columns {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if column.Width > 0 {
+			fmt.Fprintf(&b, "%-*s", column.Width, Truncate(cells[i], column.Width))
+		} else {
+			b.WriteString(cells[i])
+		}
+	}
+	return b.String()
+}
+
+// Close has nothing left to write; totals are up to the caller
+func (t *tableWriter[T]) Close() error {
+	return nil
+}
+
+// jsonWriter writes rows as an indented JSON array of objects, keyed by
+// column name in column order, one element at a time
+type jsonWriter[T any] struct {
+	w       io.Writer
+	columns []Column[T]
+	count   int
+}
+
+// Write writes array elements
+func (j *jsonWriter[T]) Write(rows []T) error {
+	for _, row := range rows {
+		var b strings.Builder
+		b.WriteString("{")
+		fields := 0
+		for _, column := range j.columns {
+			value := column.value(row)
+			if column.OmitEmpty && isZero(value) {
+				continue
+			}
+			key, err := json.Marshal(column.Name)
+			if err != nil {
+				return err
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to encode column %s: %w", column.Name, err)
+			}
+			if fields > 0 {
+				b.WriteString(",")
+			}
+			fmt.Fprintf(&b, "\n    %s: %s", key, data)
+			fields++
+		}
+		b.WriteString("\n  }")
+
+		separator := ",\n  "
+		if j.count == 0 {
+			separator = "[\n  "
+		}
+		if _, err := fmt.Fprintf(j.w, "%s%s", separator, b.String()); err != nil {
+			return err
+		}
+		j.count++
+	}
+	return nil
+}
+
+// Close terminates the array
+func (j *jsonWriter[T]) Close() error {
+	if j.count == 0 {
+		_, err := fmt.Fprintln(j.w, "[]")
+		return err
+	}
+
+	_, err := fmt.Fprintln(j.w, "\n]")
+	return err
+}
+
+// csvWriter writes rows as CSV records, quoted and escaped as needed
+type csvWriter[T any] struct {
+	w             *csv.Writer
+	columns       []Column[T]
+	headerWritten bool
+}
+
+// newCSVWriter creates a CSV writer, which writes no header row when noHeader
+// is set
+func newCSVWriter[T any](w io.Writer, columns []Column[T], noHeader bool) *csvWriter[T] {
+	return &csvWriter[T]{w: csv.NewWriter(w), columns: columns, headerWritten: noHeader}
+}
+
+// writeHeader writes the header row once
+func (c *csvWriter[T]) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+	return c.w.Write(Names(c.columns))
+}
+
+// Write writes CSV records
+func (c *csvWriter[T]) Write(rows []T) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	record := make([]string, len(c.columns))
+	for _, row := range rows {
+		for i, column := range c.columns {
+			record[i] = csvValue(column.value(row))
+		}
+		if err := c.w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	// Flush every batch so rows appear as they arrive
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// Close writes the header when no row was written
+func (c *csvWriter[T]) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// csvValue formats a column value as a CSV field
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ";")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isZero reports whether a column value is the zero value of its type
+func isZero(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice {
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,182 @@
+package clonetui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// repositoriesMsg carries the fetched repositories
+type repositoriesMsg struct {
+	repositories []*repository.Repository
+}
+
+// cloningStartedMsg is sent once the clone run has been started
+type cloningStartedMsg struct {
+	run *cloneRun
+}
+
+// cloningProgressMsg carries a progress snapshot published by the tracker
+type cloningProgressMsg struct {
+	progress *cloning.Progress
+}
+
+// cloningFinishedMsg is sent once the clone use case has returned
+type cloningFinishedMsg struct {
+	response *usecases.CloneRepositoriesResponse
+	err      error
+}
+
+// errorMsg reports a failure that stops the TUI
+type errorMsg struct {
+	err error
+}
+
+// Log panel refresh: new entries re-render it at most every
+// logRefreshInterval. Without new entries a fallback refresh, which also keeps
+// the status line current, backs off up to maxLogIdleInterval so idle runs
+// do not wake the terminal constantly.
+const (
+	logRefreshInterval = 500 * time.Millisecond
+	maxLogIdleInterval = 5 * time.Second
+)
+
+// logUpdateMsg triggers a re-render of the log panel
+type logUpdateMsg struct {
+	logged bool // New entries arrived, false for the idle fallback
+}
+
+// logUpdateCmd waits for new log entries, or for the idle fallback after
+// idle. A nil channel only waits for the fallback.
+func logUpdateCmd(logs <-chan struct{}, idle time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		timer := time.NewTimer(idle)
+		defer timer.Stop()
+
+		select {
+		case <-logs:
+			// Coalesce a burst of entries into one render
+			time.Sleep(logRefreshInterval)
+			return logUpdateMsg{logged: true}
+		case <-timer.C:
+			return logUpdateMsg{}
+		}
+	}
+}
+
+// nextLogIdle returns the fallback interval following an update: reset by
+// new entries, doubled up to maxLogIdleInterval otherwise
+func nextLogIdle(idle time.Duration, logged bool) time.Duration {
+	if logged || idle <= 0 {
+		return logRefreshInterval
+	}
+	return min(idle*2, maxLogIdleInterval)
+}
+
+// fetchRepositoriesCmd lists the repositories through the configured fetch function
+func fetchRepositoriesCmd(config *Config) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), config.FetchTimeout)
+		defer cancel()
+
+		repos, err := config.Fetch(ctx)
+		if err != nil {
+			return errorMsg{err: err}
+		}
+
+		return repositoriesMsg{repositories: repos}
+	}
+}
+
+// startCloningCmd starts cloning the repositories in the background
+func startCloningCmd(config *Config, repos []*repository.Repository) tea.Cmd {
+	return func() tea.Msg {
+		req := &usecases.CloneRepositoriesRequest{
+			Repositories:  repos,
+			BaseDirectory: config.Directory,
+			Options:       config.Options,
+			Concurrency:   config.Concurrency,
+			Order:         config.Order,
+			Dedupe:        config.Dedupe,
+		}
+		if config.ByOwner {
+			req.Batches = cloning.NewBatchProgress()
+		}
+
+		return cloningStartedMsg{run: startCloneRun(config.CloneUseCase, req, config.CloneTimeout)}
+	}
+}
+
+// cloneRun streams the progress of a background clone batch into Bubble Tea
+type cloneRun struct {
+	updates <-chan *cloning.Progress
+	result  chan cloningFinishedMsg
+	cancel  context.CancelFunc
+	batch   *usecases.CloneBatch   // Nil when the batch could not start
+	batches *cloning.BatchProgress // Per-owner progress, nil unless Config.ByOwner
+}
+
+// startCloneRun starts the clone request as a batch of its own. The progress
+// subscription is registered before any job starts, so no update is missed.
+// A zero timeout runs without a deadline.
+func startCloneRun(useCase *usecases.CloneRepositoriesUseCase, req *usecases.CloneRepositoriesRequest, timeout time.Duration) *cloneRun {
+	tracker := cloning.NewProgressTracker(len(req.Repositories))
+	req.ProgressTracker = tracker
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	run := &cloneRun{
+		updates: tracker.Subscribe(),
+		result:  make(chan cloningFinishedMsg, 1),
+		cancel:  cancel,
+		batches: req.Batches,
+	}
+
+	batch, err := useCase.Start(ctx, req)
+	if err != nil {
+		cancel()
+		run.result <- cloningFinishedMsg{err: err}
+		return run
+	}
+	run.batch = batch
+
+	go func() {
+		defer cancel()
+
+		resp, err := batch.Wait()
+		run.result <- cloningFinishedMsg{response: resp, err: err}
+	}()
+
+	return run
+}
+
+// Cancel stops in-flight clones; remaining jobs are reported as cancelled and
+// the run finishes with a partial result
+func (r *cloneRun) Cancel() {
+	if r.batch != nil {
+		r.batch.Cancel()
+	}
+	r.cancel()
+}
+
+// next returns a command waiting for the next progress update, or for the
+// result of the run once the tracker has been closed
+func (r *cloneRun) next() tea.Cmd {
+	return func() tea.Msg {
+		if progress, ok := <-r.updates; ok {
+			return cloningProgressMsg{progress: progress}
+		}
+		return <-r.result
+	}
+}
@@ -0,0 +1,36 @@
+package clonetui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+)
+
+// ErrDeclined is returned when the user answers no to the confirmation prompt
+var ErrDeclined = errors.New("clone cancelled at the confirmation prompt")
+
+// FormatEstimate describes a clone run before it starts, e.g. "About to clone
+// 342 repos (~4.5 GB, est. 25 min with 8 workers)"
+func FormatEstimate(estimate usecases.CloneEstimate) string {
+	noun := "repos"
+	if estimate.Repositories == 1 {
+		noun = "repo"
+	}
+	return fmt.Sprintf("About to clone %d %s (~%s, est. %s with %d workers)",
+		estimate.Repositories, noun, FormatBytes(estimate.Bytes),
+		formatEstimateDuration(estimate.Duration), estimate.Workers)
+}
+
+// formatEstimateDuration rounds an estimate to the precision it deserves
+func formatEstimateDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1 min"
+	case d < time.Hour:
+		return fmt.Sprintf("%d min", int(d.Round(time.Minute).Minutes()))
+	default:
+		return fmt.Sprintf("%.1f h", d.Hours())
+	}
+}
@@ -0,0 +1,134 @@
+package clonetui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+)
+
+// maxListedFailures bounds the failure summary printed after a run
+const maxListedFailures = 20
+
+// FailedResults returns the results of jobs that failed; cancelled jobs have
+// their own status and are not included
+func FailedResults(resp *usecases.CloneRepositoriesResponse) []*cloning.JobResult {
+	if resp == nil {
+		return nil
+	}
+
+	var failed []*cloning.JobResult
+	for _, result := range resp.Results {
+		if result.Job.Status != cloning.JobStatusFailed {
+			continue
+		}
+		failed = append(failed, result)
+	}
+	return failed
+}
+
+// WriteFailureSummary lists failed repositories with their error and job log
+func WriteFailureSummary(w io.Writer, failed []*cloning.JobResult) {
+	if len(failed) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "❌ Failed repositories:\n")
+	for i, result := range failed {
+		if i == maxListedFailures {
+			fmt.Fprintf(w, "  ... and %d more\n", len(failed)-maxListedFailures)
+			break
+		}
+		fmt.Fprintf(w, "  ✗ %s: %v\n", result.Job.Repository.GetFullName(), result.Job.Error)
+		if result.Job.LogFile != "" {
+			fmt.Fprintf(w, "    📄 %s\n", result.Job.LogFile)
+		}
+	}
+}
+
+// WriteTimeoutSummary counts the clones that failed on the per-job timeout,
+// pointing at --timeout since their partial clones were removed
+func WriteTimeoutSummary(w io.Writer, timedOut int) {
+	if timedOut == 0 {
+		return
+	}
+	fmt.Fprintf(w, "⏰ %d repositories timed out and were removed; raise --timeout for large repositories\n", timedOut)
+}
+
+// WriteTooLargeSummary counts the repositories skipped by --skip-larger-than
+// and the disk space their clones would have taken
+func WriteTooLargeSummary(w io.Writer, tooLarge int, bytes int64) {
+	if tooLarge == 0 {
+		return
+	}
+	fmt.Fprintf(w, "📦 Skipped %d repositories above the size limit, saving %s of disk\n", tooLarge, FormatBytes(bytes))
+}
+
+// WriteOffBranchSummary counts the repositories skipped by --default-branch-only
+func WriteOffBranchSummary(w io.Writer, offBranch int) {
+	if offBranch == 0 {
+		return
+	}
+	fmt.Fprintf(w, "🌿 Skipped %d repositories whose default branch does not match --default-branch-only\n", offBranch)
+}
+
+// WriteDiskSummary reports the disk space taken by the clones of a run and
+// left free, and the repositories skipped for --stop-at-free-space
+func WriteDiskSummary(w io.Writer, progress *cloning.Progress, lowSpace int) {
+	if progress != nil && (progress.DiskUsage > 0 || progress.FreeSpace > 0) {
+		fmt.Fprintf(w, "💾 %s\n", formatDiskUsage(progress))
+	}
+	if lowSpace > 0 {
+		fmt.Fprintf(w, "💾 Skipped %d repositories once free disk space fell below --stop-at-free-space; free some space and run again\n", lowSpace)
+	}
+}
+
+// formatDiskUsage formats the disk space written by the clones and left free
+func formatDiskUsage(p *cloning.Progress) string {
+	usage := FormatBytes(p.DiskUsage) + " written"
+	if p.FreeSpace > 0 {
+		usage += ", " + FormatBytes(p.FreeSpace) + " free"
+	}
+	return usage
+}
+
+// WriteRenameSummary lists the repositories cloned under their current name
+// because they were renamed or transferred since they were referenced
+func WriteRenameSummary(w io.Writer, results []*cloning.JobResult) {
+	var renamed []*repository.Repository
+	for _, result := range results {
+		if result.Job.Repository.RenamedFrom != "" {
+			renamed = append(renamed, result.Job.Repository)
+		}
+	}
+	if len(renamed) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "✏️  %d repositories were renamed, update your list:\n", len(renamed))
+	for i, repo := range renamed {
+		if i == maxListedFailures {
+			fmt.Fprintf(w, "  ... and %d more\n", len(renamed)-maxListedFailures)
+			break
+		}
+		fmt.Fprintf(w, "  %s → %s\n", repo.RenamedFrom, repo.GetFullName())
+	}
+}
+
+// WriteDuplicateSummary lists repositories skipped as duplicates of another remote
+func WriteDuplicateSummary(w io.Writer, duplicates []repository.Duplicate) {
+	if len(duplicates) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "🔁 Skipped %d duplicate repositories:\n", len(duplicates))
+	for i, duplicate := range duplicates {
+		if i == maxListedFailures {
+			fmt.Fprintf(w, "  ... and %d more\n", len(duplicates)-maxListedFailures)
+			break
+		}
+		fmt.Fprintf(w, "  ↷ %s (same remote as %s)\n", duplicate.Repository.GetFullName(), duplicate.Of)
+	}
+}
@@ -0,0 +1,240 @@
+package clonetui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// LogHistorySize is the number of log entries the TUI logger should retain
+// for the log viewer, well beyond the lines of the log panel
+const LogHistorySize = 2000
+
+// Default size of the log viewer before the terminal reports its size
+const (
+	defaultViewerWidth  = 100
+	defaultViewerHeight = 20
+)
+
+// logViewerChrome is the number of lines around the viewport: title, status,
+// borders and help
+const logViewerChrome = 7
+
+// logViewer is the full screen log viewer toggled with 'L'. It scrolls the
+// retained log history, filters it to errors or a search query, and follows
+// new entries until the user scrolls up, which pins the view.
+type logViewer struct {
+	viewport   viewport.Model
+	errorsOnly bool
+	query      string
+	searching  bool // Keys edit the query
+	follow     bool // Stay at the newest entry as entries arrive
+	shown      int  // Entries matching the filters
+	total      int  // Entries retained
+}
+
+// newLogViewer creates a viewer following the newest entries
+func newLogViewer(width, height int) *logViewer {
+	v := &logViewer{follow: true}
+	v.viewport = viewport.New(0, 0)
+	v.resize(width, height)
+	return v
+}
+
+// resize fits the viewer into a terminal of the given size
+func (v *logViewer) resize(width, height int) {
+	if width <= 0 {
+		width = defaultViewerWidth
+	}
+	if height <= 0 {
+		height = defaultViewerHeight + logViewerChrome
+	}
+	v.viewport.Width = max(width-6, 20) // Padding and border
+	v.viewport.Height = max(height-logViewerChrome, 3)
+}
+
+// refresh renders the entries matching the filters into the viewport,
+// keeping the scroll position unless following
+func (v *logViewer) refresh(entries []logging.LogEntry) {
+	v.total = len(entries)
+	lines := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if v.matches(entry) {
+			lines = append(lines, formatLogEntry(entry, true))
+		}
+	}
+	v.shown = len(lines)
+
+	if len(lines) == 0 {
+		v.viewport.SetContent("No matching log entries")
+	} else {
+		v.viewport.SetContent(strings.Join(lines, "\n"))
+	}
+	if v.follow {
+		v.viewport.GotoBottom()
+	}
+}
+
+// matches reports whether an entry passes the level filter and contains the
+// query, case-insensitively, in its message or field values
+func (v *logViewer) matches(entry logging.LogEntry) bool {
+	if v.errorsOnly && entry.Level != "ERROR" && entry.Level != "FATAL" {
+		return false
+	}
+	if v.query == "" {
+		return true
+	}
+
+	query := strings.ToLower(v.query)
+	if strings.Contains(strings.ToLower(entry.Message), query) {
+		return true
+	}
+	for _, value := range entry.Fields {
+		if strings.Contains(strings.ToLower(fmt.Sprint(value)), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// update handles a key of the viewer and reports whether it closes it
+func (v *logViewer) update(msg tea.KeyMsg, entries []logging.LogEntry) (bool, tea.Cmd) {
+	if v.searching {
+		switch msg.Type {
+		case tea.KeyEnter:
+			v.searching = false
+		case tea.KeyEsc:
+			v.searching = false
+			v.query = ""
+		case tea.KeyBackspace:
+			if runes := []rune(v.query); len(runes) > 0 {
+				v.query = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			v.query += string(msg.Runes)
+		}
+		v.refresh(entries)
+		return false, nil
+	}
+
+	switch msg.String() {
+	case "L", "esc", "q":
+		return true, nil
+	case "/":
+		v.searching = true
+		return false, nil
+	case "e":
+		v.errorsOnly = !v.errorsOnly
+		v.refresh(entries)
+		return false, nil
+	case "p":
+		v.follow = !v.follow
+		if v.follow {
+			v.viewport.GotoBottom()
+		}
+		return false, nil
+	case "G", "end":
+		v.follow = true
+		v.viewport.GotoBottom()
+		return false, nil
+	case "g", "home":
+		v.follow = false
+		v.viewport.GotoTop()
+		return false, nil
+	}
+
+	// Scrolling up pins the view, scrolling back to the end follows again
+	offset := v.viewport.YOffset
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	if v.viewport.YOffset < offset {
+		v.follow = false
+	} else if v.viewport.AtBottom() && v.viewport.YOffset > offset {
+		v.follow = true
+	}
+	return false, cmd
+}
+
+// view renders the viewer
+func (v *logViewer) view() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("#7D56F4")).
+		Padding(0, 1)
+	statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#909090"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#626262"))
+
+	mode := "following"
+	if !v.follow {
+		mode = fmt.Sprintf("pinned at %.0f%%", v.viewport.ScrollPercent()*100)
+	}
+	status := []string{fmt.Sprintf("%d of %d entries", v.shown, v.total), mode}
+	if v.errorsOnly {
+		status = append(status, "errors only")
+	}
+	if v.query != "" || v.searching {
+		search := fmt.Sprintf("search %q", v.query)
+		if v.searching {
+			search = "search: " + v.query + "█"
+		}
+		status = append(status, search)
+	}
+
+	help := "↑/↓ pgup/pgdn scroll • g/G top/end • '/' search • 'e' errors only • 'p' pin/follow • 'L' or esc to close"
+	if v.searching {
+		help = "Type to search messages and fields • enter to keep • esc to clear"
+	}
+
+	body := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#874BFD")).
+		Padding(0, 1).
+		Render(v.viewport.View())
+
+	return lipgloss.NewStyle().Padding(0, 2).Render(lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("📜 Logs"),
+		statusStyle.Render(strings.Join(status, " • ")),
+		body,
+		helpStyle.Render(help),
+	))
+}
+
+// formatLogEntry renders a log entry as one line, optionally followed by its
+// fields sorted by key
+func formatLogEntry(entry logging.LogEntry, withFields bool) string {
+	line := fmt.Sprintf("[%s] %s %s", entry.Level, entry.Timestamp.Format("15:04:05"), entry.Message)
+	if withFields && len(entry.Fields) > 0 {
+		keys := make([]string, 0, len(entry.Fields))
+		for key := range entry.Fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			line += fmt.Sprintf(" %s=%v", key, entry.Fields[key])
+		}
+	}
+	return levelStyle(entry.Level).Render(line)
+}
+
+// levelStyle returns the style of the log lines of a level
+func levelStyle(level string) lipgloss.Style {
+	switch level {
+	case "ERROR", "FATAL":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87"))
+	case "WARN":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAF00"))
+	case "INFO":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	case "DEBUG":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#626262"))
+	default:
+		return lipgloss.NewStyle()
+	}
+}
@@ -0,0 +1,113 @@
+package clonetui
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func testLogEntries(n int) []logging.LogEntry {
+	entries := make([]logging.LogEntry, n)
+	for i := range entries {
+		entries[i] = logging.LogEntry{
+			Timestamp: time.Now(),
+			Level:     "INFO",
+			Message:   fmt.Sprintf("entry %d", i),
+			Fields:    map[string]interface{}{"job_id": fmt.Sprintf("job_%d", i)},
+		}
+	}
+	return entries
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestLogViewer_Filters(t *testing.T) {
+	entries := testLogEntries(10)
+	entries[3].Level = "ERROR"
+	entries[7].Level = "WARN"
+
+	v := newLogViewer(80, 30)
+	v.refresh(entries)
+	assert.Equal(t, 10, v.shown)
+
+	v.update(runes("e"), entries)
+	assert.Equal(t, 1, v.shown, "errors only")
+	assert.Contains(t, v.viewport.View(), "entry 3")
+
+	v.update(runes("e"), entries)
+	v.update(runes("/"), entries)
+	require.True(t, v.searching)
+	v.update(runes("JOB_5"), entries)
+	assert.Equal(t, 1, v.shown, "search matches field values case-insensitively")
+	v.update(tea.KeyMsg{Type: tea.KeyEnter}, entries)
+	assert.False(t, v.searching)
+	assert.Equal(t, "JOB_5", v.query)
+	assert.Contains(t, v.view(), `search "JOB_5"`)
+
+	v.update(runes("/"), entries)
+	v.update(tea.KeyMsg{Type: tea.KeyEsc}, entries)
+	assert.Empty(t, v.query, "esc clears the search")
+	assert.Equal(t, 10, v.shown)
+
+	closed, _ := v.update(tea.KeyMsg{Type: tea.KeyEsc}, entries)
+	assert.True(t, closed)
+}
+
+func TestLogViewer_FollowAndPin(t *testing.T) {
+	entries := testLogEntries(100)
+	v := newLogViewer(80, 20)
+	v.refresh(entries)
+	require.True(t, v.follow)
+	assert.True(t, v.viewport.AtBottom())
+
+	// Scrolling up pins the view while entries arrive
+	v.update(tea.KeyMsg{Type: tea.KeyPgUp}, entries)
+	assert.False(t, v.follow)
+	offset := v.viewport.YOffset
+	entries = append(entries, testLogEntries(10)...)
+	v.refresh(entries)
+	assert.Equal(t, offset, v.viewport.YOffset)
+	assert.Contains(t, v.view(), "pinned")
+
+	// End follows the newest entries again
+	v.update(runes("G"), entries)
+	assert.True(t, v.follow)
+	assert.True(t, v.viewport.AtBottom())
+	entries = append(entries, testLogEntries(10)...)
+	v.refresh(entries)
+	assert.True(t, v.viewport.AtBottom())
+
+	v.update(runes("p"), entries)
+	assert.False(t, v.follow, "p pins")
+}
+
+func TestModel_LogViewer(t *testing.T) {
+	logger, err := logging.NewTUILogger(&logging.TUILoggerConfig{
+		LogFile:    filepath.Join(t.TempDir(), "repocloner.log"),
+		Level:      "info",
+		BufferSize: LogHistorySize,
+	})
+	require.NoError(t, err)
+	defer func() { _ = logger.Close() }()
+	logger.Error("clone failed")
+
+	m := New(&Config{Logger: logger})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	updated, _ = updated.(Model).Update(runes("L"))
+	result := updated.(Model)
+	require.NotNil(t, result.logViewer)
+	assert.Equal(t, 114, result.logViewer.viewport.Width)
+	assert.Contains(t, result.View(), "clone failed")
+
+	updated, _ = result.Update(runes("L"))
+	assert.Nil(t, updated.(Model).logViewer)
+}
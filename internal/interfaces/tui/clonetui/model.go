@@ -0,0 +1,790 @@
+// Package clonetui implements the interactive clone progress TUI shared by all
+// provider commands. A command supplies a fetch function and the clone
+// settings; the TUI lists the repositories, clones them concurrently and shows
+// progress details, active transfers, the most recent completion and logs.
+package clonetui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+// DefaultFetchTimeout bounds repository listing when Config.FetchTimeout is unset
+const DefaultFetchTimeout = 2 * time.Minute
+
+// FetchFunc lists the repositories to clone
+type FetchFunc func(ctx context.Context) ([]*repository.Repository, error)
+
+// Config parameterizes the clone TUI for a provider command
+type Config struct {
+	Title        string // Header, e.g. "repocloner v0.2.0 - Concurrent Repository Cloner"
+	Target       string // Describes the owner in messages, e.g. "orgs/kubernetes"
+	Directory    string // Destination directory of the clones
+	Fetch        FetchFunc
+	FetchTimeout time.Duration // Defaults to DefaultFetchTimeout
+
+	CloneUseCase *usecases.CloneRepositoriesUseCase
+	Options      *cloning.CloneOptions
+	Concurrency  int
+	Order        cloning.JobOrder // Scheduling order of the clone jobs
+	Dedupe       bool             // Skip repositories whose remote is already cloned
+	ByOwner      bool             // Break progress down per owner, for multi-owner runs
+	CloneTimeout time.Duration    // Zero runs without a deadline
+
+	// Confirm shows the size and duration estimate once the repositories are
+	// listed and waits for the user to accept it before cloning
+	Confirm bool
+
+	Logger *logging.TUILogger // Optional, enables the log panel
+
+	// Status optionally returns an extra status line refreshed with the view,
+	// e.g. the remaining API rate limit budget
+	Status func() string
+
+	// InputTTY reads keys from the terminal instead of stdin, for commands
+	// that consume stdin themselves
+	InputTTY bool
+}
+
+// Run executes the TUI until cloning finishes or the user quits. It returns
+// the clone response, which is nil when cloning never started, and the error
+// that stopped the run.
+func Run(config *Config) (*usecases.CloneRepositoriesResponse, error) {
+	var options []tea.ProgramOption
+	if config.InputTTY {
+		options = append(options, tea.WithInputTTY())
+	}
+
+	finalModel, err := tea.NewProgram(New(config), options...).Run()
+	if err != nil {
+		return nil, fmt.Errorf("TUI execution failed: %w", err)
+	}
+
+	m, ok := finalModel.(Model)
+	if !ok {
+		return nil, nil
+	}
+	if m.declined {
+		return nil, ErrDeclined
+	}
+	return m.response, m.err
+}
+
+// Model is the Bubble Tea model of the clone TUI
+type Model struct {
+	config         *Config
+	repos          []*repository.Repository
+	total          int
+	progress       progress.Model
+	quitting       bool
+	err            error
+	logHeight      int
+	showLogs       bool
+	showWorkers    bool              // Show the worker pool panel
+	showGraph      bool              // Show the throughput graph
+	showOwners     bool              // Expand the per-owner progress of multi-owner runs
+	actualProgress *cloning.Progress // Latest progress snapshot for display
+	run            *cloneRun
+	cancelling     bool // Quit was requested while cloning
+	failures       []*cloning.JobResult
+	response       *usecases.CloneRepositoriesResponse
+	confirming     bool // Waiting for the user to accept the estimate
+	declined       bool // The user declined the estimate
+	estimate       usecases.CloneEstimate
+	triage         *triage       // Failure triage screen, shown when a run ends with failures
+	logViewer      *logViewer    // Full screen log viewer, open while not nil
+	width, height  int           // Terminal size, zero until reported
+	logIdle        time.Duration // Fallback refresh interval of the log panel, see nextLogIdle
+}
+
+// New creates the clone TUI model
+func New(config *Config) Model {
+	if config.FetchTimeout == 0 {
+		config.FetchTimeout = DefaultFetchTimeout
+	}
+
+	return Model{
+		config:     config,
+		progress:   progress.New(progress.WithDefaultGradient()),
+		logHeight:  8, // Show last 8 log entries
+		showLogs:   true,
+		showGraph:  true,
+		showOwners: true,
+	}
+}
+
+// Init starts fetching the repositories
+func (m Model) Init() tea.Cmd {
+	return fetchRepositoriesCmd(m.config)
+}
+
+// Update handles key presses, fetch results and progress updates
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.triage != nil {
+		return m.updateTriage(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.logViewer != nil {
+			m.logViewer.resize(m.width, m.height)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.confirming {
+			return m.updateConfirm(msg)
+		}
+		if m.logViewer != nil && msg.String() != "ctrl+c" {
+			closed, cmd := m.logViewer.update(msg, m.logHistory())
+			if closed {
+				m.logViewer = nil
+			}
+			return m, cmd
+		}
+		switch msg.String() {
+		case "q", "ctrl+c":
+			// The first quit cancels in-flight clones and waits for the partial result
+			if m.run != nil && !m.cancelling && !m.quitting {
+				m.cancelling = true
+				m.run.Cancel()
+				return m, nil
+			}
+			m.quitting = true
+			return m, tea.Quit
+		case "l":
+			// Toggle log visibility
+			m.showLogs = !m.showLogs
+			return m, nil
+		case "L":
+			// Open the full log viewer
+			if m.config.Logger != nil {
+				m.logViewer = newLogViewer(m.width, m.height)
+				m.logViewer.refresh(m.logHistory())
+			}
+			return m, nil
+		case "w":
+			// Toggle the worker pool panel
+			m.showWorkers = !m.showWorkers
+			return m, nil
+		case "g":
+			// Toggle the throughput graph
+			m.showGraph = !m.showGraph
+			return m, nil
+		case "o":
+			// Collapse or expand the per-owner progress
+			m.showOwners = !m.showOwners
+			return m, nil
+		case "c":
+			// Clear log buffer
+			if m.config.Logger != nil {
+				m.config.Logger.GetLogBuffer().Clear()
+			}
+			return m, nil
+		}
+		return m, nil
+
+	case repositoriesMsg:
+		m.repos = msg.repositories
+		m.total = len(msg.repositories)
+		if m.total == 0 {
+			m.err = fmt.Errorf("no repositories found for %s", m.config.Target)
+			m.quitting = true
+			return m, tea.Quit
+		}
+
+		if m.config.Confirm {
+			m.confirming = true
+			m.estimate = m.config.CloneUseCase.Estimate(m.repos, m.config.Concurrency)
+			return m, nil
+		}
+
+		// Start concurrent cloning
+		return m, startCloningCmd(m.config, m.repos)
+
+	case cloningStartedMsg:
+		// Stream progress updates published by the tracker
+		m.run = msg.run
+		m.logIdle = nextLogIdle(0, true)
+		return m, tea.Batch(m.run.next(), logUpdateCmd(m.logNotify(), m.logIdle))
+
+	case cloningProgressMsg:
+		m.actualProgress = msg.progress
+		cmd := m.progress.SetPercent(msg.progress.GetPercentage() / 100.0)
+		return m, tea.Batch(cmd, m.run.next())
+
+	case cloningFinishedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			m.quitting = true
+			return m, tea.Quit
+		}
+		if msg.response != nil {
+			m.response = msg.response
+			m.failures = FailedResults(msg.response)
+			if msg.response.Progress != nil {
+				m.actualProgress = msg.response.Progress
+			}
+		}
+
+		// Let the user triage failures instead of exiting right away
+		if len(m.failures) > 0 && !m.cancelling {
+			m.logViewer = nil
+			m.triage = newTriage(m.failures)
+			return m, nil
+		}
+
+		// Render the full progress bar before quitting
+		m.quitting = true
+		return m, tea.Batch(m.progress.SetPercent(1.0), tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
+			return tea.Quit()
+		}))
+
+	case progress.FrameMsg:
+		progressModel, cmd := m.progress.Update(msg)
+		m.progress = progressModel.(progress.Model)
+		return m, cmd
+
+	case logUpdateMsg:
+		// Log buffer updated, trigger re-render
+		if m.logViewer != nil {
+			m.logViewer.refresh(m.logHistory())
+		}
+		m.logIdle = nextLogIdle(m.logIdle, msg.logged)
+		return m, logUpdateCmd(m.logNotify(), m.logIdle)
+
+	case errorMsg:
+		m.err = msg.err
+		m.quitting = true
+		return m, tea.Quit
+
+	default:
+		return m, nil
+	}
+}
+
+// updateConfirm handles the answer to the confirmation prompt
+func (m Model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.confirming = false
+		return m, startCloningCmd(m.config, m.repos)
+	case "n", "N", "q", "esc", "enter", "ctrl+c":
+		m.confirming = false
+		m.declined = true
+		m.quitting = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// View renders the TUI
+func (m Model) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("\nError: %v\n\nPress 'q' to exit\n", m.err)
+	}
+
+	if m.declined {
+		return fmt.Sprintf("\n%s. Cancelled.\n", FormatEstimate(m.estimate))
+	}
+
+	if m.triage != nil {
+		return m.renderTriage()
+	}
+
+	if m.quitting {
+		if m.total == 0 {
+			return "\nNo repositories found.\n"
+		}
+		return m.renderSummary()
+	}
+
+	if m.logViewer != nil {
+		return m.logViewer.view()
+	}
+
+	if len(m.repos) == 0 {
+		if status := m.renderStatus(); status != "" {
+			return "\nFetching repositories...\n" + status + "\n"
+		}
+		return "\nFetching repositories...\n"
+	}
+
+	// Header
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("#7D56F4")).
+		Padding(0, 1).
+		Render("🚀 " + m.config.Title)
+	if m.config.CloneUseCase != nil && m.config.CloneUseCase.RunID() != "" {
+		header += lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#626262")).
+			Render("  run " + m.config.CloneUseCase.RunID())
+	}
+
+	if m.confirming {
+		prompt := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7D56F4")).
+			Bold(true).
+			Render(FormatEstimate(m.estimate) + ". Continue? [y/N]")
+		return lipgloss.NewStyle().Padding(1, 2).Render(
+			lipgloss.JoinVertical(lipgloss.Left, header, "", prompt),
+		)
+	}
+
+	// Progress info
+	info := fmt.Sprintf("Cloning repositories to '%s' directory...", m.config.Directory)
+	progressInfo := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true).
+		Render(info)
+
+	// Progress bar
+	bar := m.progress.View()
+
+	// Progress details if we have actual progress
+	var progressDetails string
+	if m.actualProgress != nil {
+		progressDetails = m.renderProgressDetails()
+
+		// Show completion status when 100% is reached
+		if m.actualProgress.IsComplete() && m.actualProgress.GetPercentage() >= 100.0 {
+			successStyle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#04B575")).
+				Bold(true)
+			progressDetails += "\n" + successStyle.Render("🎉 All repositories processed! Preparing to exit...")
+		}
+	}
+
+	// Build the main content
+	content := []string{
+		header,
+		"",
+		progressInfo,
+		bar,
+	}
+
+	// Add progress details if available
+	if progressDetails != "" {
+		content = append(content, progressDetails)
+	}
+
+	// Add the provider status line, e.g. the API rate limit budget, which
+	// the worker pool panel includes when shown
+	if m.showWorkers && m.config.CloneUseCase != nil {
+		content = append(content, "", m.renderWorkerPanel())
+	} else if status := m.renderStatus(); status != "" {
+		content = append(content, status)
+	}
+
+	// Add the per-owner breakdown of multi-owner runs
+	if owners := m.renderOwnerProgress(); owners != "" {
+		content = append(content, "", owners)
+	}
+
+	// Add the throughput history
+	if m.showGraph {
+		if graph := renderThroughput(m.actualProgress); graph != "" {
+			content = append(content, "", graph)
+		}
+	}
+
+	// Add per-repository transfer progress
+	if transfers := renderActiveTransfers(m.actualProgress); transfers != "" {
+		content = append(content, "", transfers)
+	}
+
+	// Add recent completion if available
+	if recentCompletion := m.renderRecentCompletion(); recentCompletion != "" {
+		content = append(content, "", recentCompletion)
+	}
+
+	// Add log section if enabled
+	if m.showLogs && m.config.Logger != nil {
+		content = append(content, "", m.renderLogs())
+	}
+
+	// Add help text
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#626262")).
+		MarginTop(1)
+
+	helpText := "Press 'q' to quit"
+	if m.cancelling {
+		helpText = "Cancelling in-flight clones... press 'q' again to quit immediately"
+	}
+	if m.config.CloneUseCase != nil {
+		if m.showWorkers {
+			helpText += " • 'w' to hide workers"
+		} else {
+			helpText += " • 'w' to show workers"
+		}
+	}
+	if m.showGraph {
+		helpText += " • 'g' to hide graph"
+	} else {
+		helpText += " • 'g' to show graph"
+	}
+	if m.hasOwnerProgress() {
+		if m.showOwners {
+			helpText += " • 'o' to collapse owners"
+		} else {
+			helpText += " • 'o' to expand owners"
+		}
+	}
+	if m.config.Logger != nil {
+		if m.showLogs {
+			helpText += " • 'l' to hide logs • 'c' to clear logs"
+		} else {
+			helpText += " • 'l' to show logs"
+		}
+		helpText += " • 'L' for all logs"
+	}
+
+	content = append(content, helpStyle.Render(helpText))
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(
+		lipgloss.JoinVertical(lipgloss.Left, content...),
+	)
+}
+
+// renderSummary renders the completion summary with final statistics
+func (m Model) renderSummary() string {
+	var summary strings.Builder
+	if m.cancelling {
+		processed := 0
+		if m.actualProgress != nil {
+			processed = m.actualProgress.Processed() - m.actualProgress.Cancelled
+		}
+		summary.WriteString(fmt.Sprintf("\n🛑 Cloning cancelled: %d of %d repositories processed\n", processed, m.total))
+	} else {
+		summary.WriteString(fmt.Sprintf("\n✅ Cloning completed: %d repositories processed\n", m.total))
+	}
+	summary.WriteString(fmt.Sprintf("📁 Directory: %s\n", m.config.Directory))
+
+	if m.actualProgress != nil {
+		summary.WriteString(fmt.Sprintf("📊 Results: ✅ %d completed, ❌ %d failed, ⏭️ %d skipped",
+			m.actualProgress.Completed, m.actualProgress.Failed, m.actualProgress.Skipped))
+		if m.actualProgress.Updated > 0 {
+			summary.WriteString(fmt.Sprintf(", 🔄 %d updated", m.actualProgress.Updated))
+		}
+		if m.actualProgress.Cancelled > 0 {
+			summary.WriteString(fmt.Sprintf(", 🛑 %d cancelled", m.actualProgress.Cancelled))
+		}
+		summary.WriteString("\n")
+		if m.actualProgress.ElapsedTime > 0 {
+			summary.WriteString(fmt.Sprintf("⏱️ Duration: %v\n", m.actualProgress.ElapsedTime.Truncate(time.Second)))
+		}
+	}
+
+	if m.run != nil && m.run.batches != nil && len(m.run.batches.Snapshot()) > 1 {
+		for _, batch := range m.run.batches.Snapshot() {
+			p := batch.Progress
+			summary.WriteString(fmt.Sprintf("   %s: ✅ %d completed, ❌ %d failed, ⏭️ %d skipped\n",
+				batch.ID, p.Completed, p.Failed, p.Skipped))
+		}
+	}
+
+	WriteFailureSummary(&summary, m.failures)
+	if m.response != nil {
+		WriteTimeoutSummary(&summary, m.response.TimedOutJobs)
+		WriteTooLargeSummary(&summary, m.response.TooLargeJobs, m.response.TooLargeBytes)
+		WriteOffBranchSummary(&summary, m.response.OffBranchJobs)
+		WriteDiskSummary(&summary, m.actualProgress, m.response.LowSpaceJobs)
+		WriteDuplicateSummary(&summary, m.response.Duplicates)
+		WriteRenameSummary(&summary, m.response.Results)
+	}
+
+	if m.config.Logger != nil {
+		summary.WriteString(fmt.Sprintf("📄 Log file: %s\n", m.config.Logger.GetLogFile()))
+	}
+
+	return summary.String()
+}
+
+// renderProgressDetails renders detailed progress information
+func (m Model) renderProgressDetails() string {
+	if m.actualProgress == nil {
+		return ""
+	}
+
+	p := m.actualProgress
+	details := fmt.Sprintf(
+		"Progress: %d/%d repositories | ✓ %d completed | ✗ %d failed | ⏭ %d skipped",
+		p.Processed()+p.InProgress, p.Total,
+		p.Completed, p.Failed, p.Skipped,
+	)
+	if p.Updated > 0 {
+		details += fmt.Sprintf(" | 🔄 %d updated", p.Updated)
+	}
+	if p.Cancelled > 0 {
+		details += fmt.Sprintf(" | 🛑 %d cancelled", p.Cancelled)
+	}
+	details += fmt.Sprintf(" | ⏳ %d in progress", p.InProgress)
+	if waiting := p.Waiting(); waiting > 0 {
+		details += fmt.Sprintf(" | 🕒 %d queued", waiting)
+	}
+
+	if p.Throughput > 0 {
+		details += fmt.Sprintf(" | %.1f repos/sec", p.Throughput)
+	}
+
+	if p.ETA > 0 {
+		details += " | ETA: " + formatETA(p)
+	}
+
+	if p.DiskUsage > 0 || p.FreeSpace > 0 {
+		details += " | 💾 " + formatDiskUsage(p)
+	}
+
+	if workers := m.renderWorkers(); workers != "" {
+		details += " | " + workers
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#909090")).
+		Render(details)
+}
+
+// formatETA formats the estimated time remaining, with the size-weighted and
+// count-based estimates side by side when the repository sizes are known
+func formatETA(p *cloning.Progress) string {
+	eta := p.ETA.Truncate(time.Second).String()
+	if p.SizeETA > 0 && p.CountETA > 0 {
+		eta += fmt.Sprintf(" (by size %s, by count %s)",
+			p.SizeETA.Truncate(time.Second), p.CountETA.Truncate(time.Second))
+	}
+	return eta
+}
+
+// renderStatus renders the optional status line of the command
+func (m Model) renderStatus() string {
+	if m.config.Status == nil {
+		return ""
+	}
+
+	status := m.config.Status()
+	if status == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#909090")).
+		Render(status)
+}
+
+// renderWorkers renders the live worker count, which adaptive pools change
+// while cloning
+func (m Model) renderWorkers() string {
+	if m.config.CloneUseCase == nil {
+		return ""
+	}
+
+	stats := m.config.CloneUseCase.WorkerStats()
+	if stats.Adaptive {
+		return fmt.Sprintf("👷 %d/%d workers (adaptive)", stats.RunningWorkers, stats.TotalWorkers)
+	}
+	return fmt.Sprintf("👷 %d/%d workers", stats.RunningWorkers, stats.TotalWorkers)
+}
+
+// ownerBatches returns the per-owner progress of a multi-owner run, nil for
+// runs of a single owner
+func (m Model) ownerBatches() []cloning.BatchSnapshot {
+	if m.run == nil || m.run.batches == nil {
+		return nil
+	}
+
+	batches := m.run.batches.Snapshot()
+	if len(batches) < 2 {
+		return nil
+	}
+	return batches
+}
+
+// hasOwnerProgress reports whether the view breaks progress down per owner
+func (m Model) hasOwnerProgress() bool {
+	return m.ownerBatches() != nil
+}
+
+// renderOwnerProgress renders the progress of each provider and owner of a
+// multi-owner run, collapsed to a single line unless showOwners
+func (m Model) renderOwnerProgress() string {
+	batches := m.ownerBatches()
+	if batches == nil {
+		return ""
+	}
+
+	finished := 0
+	for _, batch := range batches {
+		if batch.Progress.IsComplete() {
+			finished++
+		}
+	}
+	header := fmt.Sprintf("%d/%d owners done", finished, len(batches))
+	if lagging := laggingOwner(batches); lagging != "" {
+		header += ", furthest behind: " + lagging
+	}
+
+	lines := make([]string, 0, len(batches)+1)
+	if !m.showOwners {
+		lines = append(lines, "▸ "+header)
+	} else {
+		lines = append(lines, "▾ "+header)
+
+		width := 0
+		for _, batch := range batches {
+			width = max(width, len(batch.ID))
+		}
+		for _, batch := range batches {
+			p := batch.Progress
+			lines = append(lines, fmt.Sprintf("  %-*s %s %d/%d | ✓ %d | ✗ %d | ⏭ %d",
+				width, batch.ID, miniBar(p.GetPercentage(), 20),
+				p.Processed(), p.Total, p.Completed, p.Failed, p.Skipped))
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#909090")).
+		Render(strings.Join(lines, "\n"))
+}
+
+// laggingOwner returns the unfinished owner with the lowest share of its
+// repositories processed, empty once every owner is done
+func laggingOwner(batches []cloning.BatchSnapshot) string {
+	lagging, lowest := "", 101.0
+	for _, batch := range batches {
+		if batch.Progress.IsComplete() {
+			continue
+		}
+		if percentage := batch.Progress.GetPercentage(); percentage < lowest {
+			lagging, lowest = batch.ID, percentage
+		}
+	}
+	return lagging
+}
+
+// renderRecentCompletion renders information about the most recently completed repository
+func (m Model) renderRecentCompletion() string {
+	if m.actualProgress == nil || m.actualProgress.RecentCompletion == nil {
+		return ""
+	}
+
+	recent := m.actualProgress.RecentCompletion
+	var statusIcon, statusColor string
+
+	switch recent.Status {
+	case cloning.JobStatusCompleted:
+		statusIcon = "✓"
+		statusColor = "#04B575" // Green
+	case cloning.JobStatusFailed:
+		statusIcon = "✗"
+		statusColor = "#FF5F87" // Red
+	case cloning.JobStatusSkipped:
+		statusIcon = "⏭"
+		statusColor = "#FFAF00" // Yellow
+	case cloning.JobStatusUpdated:
+		statusIcon = "🔄"
+		statusColor = "#04B575" // Green
+	case cloning.JobStatusCancelled:
+		statusIcon = "🛑"
+		statusColor = "#909090" // Gray
+	default:
+		statusIcon = "?"
+		statusColor = "#909090" // Gray
+	}
+
+	// Format the repository name and status
+	repoInfo := fmt.Sprintf("%s %s", statusIcon, recent.Repository)
+	if recent.Duration > 0 {
+		repoInfo += fmt.Sprintf(" (%s)", recent.Duration.Truncate(time.Millisecond*10))
+	}
+
+	if recent.Size > 0 {
+		repoInfo += fmt.Sprintf(" [%s]", FormatBytes(recent.Size))
+	}
+
+	if recent.Error != "" && recent.Status != cloning.JobStatusCompleted {
+		repoInfo += fmt.Sprintf(" - %s", truncateString(recent.Error, 60))
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true)
+
+	repoStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(statusColor))
+
+	return titleStyle.Render("Recently completed:") + " " + repoStyle.Render(repoInfo)
+}
+
+// logHistory returns every retained log entry, oldest first
+func (m Model) logHistory() []logging.LogEntry {
+	if m.config.Logger == nil {
+		return nil
+	}
+	return m.config.Logger.GetLogBuffer().GetRecent(0)
+}
+
+// logNotify returns the channel signalling new log entries, nil without a
+// log panel
+func (m Model) logNotify() <-chan struct{} {
+	if m.config.Logger == nil {
+		return nil
+	}
+	return m.config.Logger.GetLogBuffer().GetNotifyChannel()
+}
+
+// renderLogs renders the log display area
+func (m Model) renderLogs() string {
+	if m.config.Logger == nil {
+		return ""
+	}
+
+	// Get recent log entries
+	entries := m.config.Logger.GetLogBuffer().GetRecent(m.logHeight)
+
+	if len(entries) == 0 {
+		return lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#874BFD")).
+			Padding(0, 1).
+			Width(80).
+			Height(m.logHeight).
+			Align(lipgloss.Center, lipgloss.Center).
+			Render("No logs available")
+	}
+
+	// Format log entries
+	var logLines []string
+	for _, entry := range entries {
+		logLines = append(logLines, formatLogEntry(entry, false))
+	}
+
+	// Pad with empty lines if needed
+	for len(logLines) < m.logHeight {
+		logLines = append(logLines, "")
+	}
+
+	// Create bordered log area
+	logContent := lipgloss.JoinVertical(lipgloss.Left, logLines...)
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#874BFD")).
+		Padding(0, 1).
+		Width(80).
+		Height(m.logHeight + 2). // +2 for border
+		Render(logContent)
+}
@@ -0,0 +1,199 @@
+package clonetui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+)
+
+func TestModel_NoRepositories(t *testing.T) {
+	m := New(&Config{
+		Target: "orgs/empty",
+		Fetch: func(context.Context) ([]*repository.Repository, error) {
+			return nil, nil
+		},
+	})
+
+	msg := m.Init()()
+	updated, cmd := m.Update(msg)
+	require.NotNil(t, cmd, "the TUI quits")
+
+	result := updated.(Model)
+	require.Error(t, result.err)
+	assert.Contains(t, result.err.Error(), "orgs/empty")
+}
+
+func TestFailedResults_ExcludesCancelled(t *testing.T) {
+	repo, err := repository.NewRepository(1, "repo", "https://github.com/owner/repo.git", "owner", false, 0, "main")
+	require.NoError(t, err)
+
+	newResult := func(status cloning.JobStatus, jobErr error) *cloning.JobResult {
+		job := cloning.NewCloneJob(repo, t.TempDir(), nil)
+		job.Status = status
+		job.Error = jobErr
+		job.LogFile = "logs/owner/repo.log"
+		return &cloning.JobResult{Job: job}
+	}
+
+	resp := &usecases.CloneRepositoriesResponse{Results: []*cloning.JobResult{
+		newResult(cloning.JobStatusCompleted, nil),
+		newResult(cloning.JobStatusFailed, errors.New("boom")),
+		newResult(cloning.JobStatusCancelled, cloning.ErrJobCancelled),
+	}}
+
+	failed := FailedResults(resp)
+	require.Len(t, failed, 1)
+
+	var out bytes.Buffer
+	WriteFailureSummary(&out, failed)
+	assert.Contains(t, out.String(), "owner/repo: boom")
+	assert.Contains(t, out.String(), "logs/owner/repo.log")
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", FormatBytes(512))
+	assert.Equal(t, "1.5 KB", FormatBytes(1536))
+	assert.Equal(t, "2.0 GB", FormatBytes(2<<30))
+}
+
+func TestModel_ConfirmEstimate(t *testing.T) {
+	repo, err := repository.NewRepository(1, "repo", "https://github.com/owner/repo.git", "owner", false, 300<<20, "main")
+	require.NoError(t, err)
+
+	newModel := func() Model {
+		m := New(&Config{
+			Target: "orgs/owner",
+			Fetch: func(context.Context) ([]*repository.Repository, error) {
+				return []*repository.Repository{repo}, nil
+			},
+			CloneUseCase: usecases.NewCloneRepositoriesUseCase(nil,
+				cloning.NewDomainCloneService(logging.NewNoOpLogger()), logging.NewNoOpLogger()),
+			Concurrency: 2,
+			Confirm:     true,
+		})
+		updated, cmd := m.Update(m.Init()())
+		assert.Nil(t, cmd, "cloning waits for the answer")
+		return updated.(Model)
+	}
+
+	m := newModel()
+	require.True(t, m.confirming)
+	assert.Contains(t, m.View(), "About to clone 1 repo (~300.0 MB, est. 3 min with 2 workers). Continue? [y/N]")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	require.NotNil(t, cmd)
+	assert.True(t, updated.(Model).declined)
+
+	updated, cmd = newModel().Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.NotNil(t, cmd, "cloning starts")
+	assert.False(t, updated.(Model).confirming)
+	assert.False(t, updated.(Model).declined)
+}
+
+func TestFormatEstimate(t *testing.T) {
+	assert.Equal(t, "About to clone 342 repos (~4.5 GB, est. 25 min with 8 workers)", FormatEstimate(usecases.CloneEstimate{
+		Repositories: 342,
+		Bytes:        4831838208,
+		Duration:     25 * time.Minute,
+		Workers:      8,
+	}))
+	assert.Equal(t, "<1 min", formatEstimateDuration(30*time.Second))
+	assert.Equal(t, "2.5 h", formatEstimateDuration(150*time.Minute))
+}
+
+func TestFormatETA(t *testing.T) {
+	assert.Equal(t, "1m30s", formatETA(&cloning.Progress{ETA: 90 * time.Second, CountETA: 90 * time.Second}))
+	assert.Equal(t, "9m0s (by size 9m0s, by count 1m30s)", formatETA(&cloning.Progress{
+		ETA:      9 * time.Minute,
+		SizeETA:  9 * time.Minute,
+		CountETA: 90 * time.Second,
+	}))
+}
+
+func TestFormatDiskUsage(t *testing.T) {
+	assert.Equal(t, "1.5 GB written", formatDiskUsage(&cloning.Progress{DiskUsage: 3 << 29}))
+	assert.Equal(t, "1.5 GB written, 10.0 GB free", formatDiskUsage(&cloning.Progress{DiskUsage: 3 << 29, FreeSpace: 10 << 30}))
+}
+
+func TestModel_ToggleWorkerPanel(t *testing.T) {
+	m := New(&Config{})
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	assert.True(t, updated.(Model).showWorkers)
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	assert.False(t, updated.(Model).showWorkers)
+}
+
+func TestModel_OwnerProgress(t *testing.T) {
+	batches := cloning.NewBatchProgress()
+	done := batches.AddBatch("github/acme", 1)
+	done.CompleteJob()
+	batches.AddBatch("github/other", 2).CompleteJob()
+	batches.AddBatch("bitbucket/team", 4)
+
+	m := New(&Config{})
+	m.run = &cloneRun{batches: batches}
+
+	view := m.renderOwnerProgress()
+	assert.Contains(t, view, "▾ 1/3 owners done, furthest behind: bitbucket/team")
+	assert.Contains(t, view, "github/other")
+	assert.Contains(t, view, "1/2")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	view = updated.(Model).renderOwnerProgress()
+	assert.Contains(t, view, "▸ 1/3 owners done, furthest behind: bitbucket/team")
+	assert.NotContains(t, view, "github/other")
+
+	m.run = &cloneRun{batches: cloning.NewBatchProgress()}
+	assert.Empty(t, m.renderOwnerProgress())
+}
+
+func TestFormatWorkerPanel(t *testing.T) {
+	stats := &concurrency.WorkerPoolStats{
+		TotalWorkers:       8,
+		RunningWorkers:     3,
+		FreeWorkers:        5,
+		FinishedTasks:      12,
+		HostWaiting:        2,
+		Retries:            2,
+		AverageJobDuration: 4230 * time.Millisecond,
+	}
+	progress := &cloning.Progress{Total: 20, Completed: 10, Failed: 2, InProgress: 3}
+
+	panel := formatWorkerPanel(stats, progress, "GitHub API: 4200/5000 requests left")
+	assert.Contains(t, panel, "3 running, 5 free of 8")
+	assert.Contains(t, panel, "5 jobs")
+	assert.Contains(t, panel, "2 held by --host-concurrency")
+	assert.Contains(t, panel, "4.2s over 12 jobs")
+	assert.Contains(t, panel, "4200/5000")
+
+	panel = formatWorkerPanel(&concurrency.WorkerPoolStats{TotalWorkers: 4, FreeWorkers: 4, Adaptive: true}, nil, "")
+	assert.Contains(t, panel, "0 running, 4 free of 4 (adaptive)")
+	assert.Contains(t, panel, "- over 0 jobs")
+}
+
+func TestLogUpdateCmd(t *testing.T) {
+	logs := make(chan struct{}, 1)
+	logs <- struct{}{}
+	assert.Equal(t, logUpdateMsg{logged: true}, logUpdateCmd(logs, time.Hour)())
+	assert.Equal(t, logUpdateMsg{}, logUpdateCmd(nil, time.Millisecond)())
+
+	idle := nextLogIdle(0, true)
+	assert.Equal(t, logRefreshInterval, idle)
+	for range 10 {
+		idle = nextLogIdle(idle, false)
+	}
+	assert.Equal(t, maxLogIdleInterval, idle, "idle refreshes back off")
+	assert.Equal(t, logRefreshInterval, nextLogIdle(idle, true), "new entries reset the backoff")
+}
@@ -0,0 +1,57 @@
+package clonetui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/italoag/repocloner/internal/infrastructure/runs"
+)
+
+var (
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87"))
+	diffFailedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAF00"))
+)
+
+// WriteRunDiff prints what changed since the previous run of the same
+// command, diff style: new repositories, repositories that disappeared
+// remotely and repositories that failed both times
+func WriteRunDiff(w io.Writer, diff *runs.Diff) {
+	if diff == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "\n🔀 Since run %s (%s):\n", diff.Previous.ID, diff.Previous.StartedAt.Local().Format("2006-01-02 15:04"))
+	if diff.Advanced+diff.Unchanged > 0 {
+		fmt.Fprintf(w, "  HEAD advanced in %d repositories, unchanged in %d\n", diff.Advanced, diff.Unchanged)
+	}
+	if diff.IsEmpty() {
+		fmt.Fprintf(w, "  no new, disappeared or repeatedly failing repositories\n")
+		return
+	}
+
+	writeDiffLines(w, diff.New, func(name string) string {
+		return diffAddedStyle.Render("+ " + name)
+	})
+	writeDiffLines(w, diff.Disappeared, func(name string) string {
+		return diffRemovedStyle.Render("- " + name + " (gone remotely)")
+	})
+	writeDiffLines(w, diff.FailedAgain, func(repo runs.Repository) string {
+		return diffFailedStyle.Render("! " + repo.Name + " failed again: " + repo.Error)
+	})
+	fmt.Fprintf(w, "  %d new, %d disappeared, %d failed both times\n",
+		len(diff.New), len(diff.Disappeared), len(diff.FailedAgain))
+}
+
+// writeDiffLines writes up to maxListedFailures lines of a diff section
+func writeDiffLines[T any](w io.Writer, items []T, line func(T) string) {
+	for i, item := range items {
+		if i == maxListedFailures {
+			fmt.Fprintf(w, "  ... and %d more\n", len(items)-maxListedFailures)
+			break
+		}
+		fmt.Fprintf(w, "  %s\n", line(item))
+	}
+}
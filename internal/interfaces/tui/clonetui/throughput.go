@@ -0,0 +1,78 @@
+package clonetui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// sparklineWidth is the number of most recent samples drawn by the graph
+const sparklineWidth = 60
+
+// sparkBlocks are the levels of a sparkline, from idle to the peak
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// renderThroughput renders the throughput graph toggled with 'g': sparklines
+// of the repositories and megabytes per second of the latest samples, to
+// spot a run slowing down on rate limits or large repositories
+func renderThroughput(p *cloning.Progress) string {
+	if p == nil || len(p.History) < 2 {
+		return ""
+	}
+
+	history := p.History[max(len(p.History)-sparklineWidth, 0):]
+	repos := make([]float64, len(history))
+	megabytes := make([]float64, len(history))
+	for i, sample := range history {
+		repos[i] = sample.JobsPerSecond
+		megabytes[i] = sample.BytesPerSecond / (1024 * 1024)
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#909090"))
+	graphStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+
+	row := func(label string, values []float64, unit string) string {
+		return labelStyle.Render(fmt.Sprintf("  %-8s", label)) +
+			graphStyle.Render(Sparkline(values)) +
+			labelStyle.Render(fmt.Sprintf("  %.1f %s, peak %.1f", values[len(values)-1], unit, maxValue(values)))
+	}
+
+	span := time.Duration(len(history)) * cloning.ThroughputInterval
+	return strings.Join([]string{
+		titleStyle.Render(fmt.Sprintf("Throughput (last %s):", span)),
+		row("repos/s", repos, "repos/s"),
+		row("MB/s", megabytes, "MB/s"),
+	}, "\n")
+}
+
+// Sparkline draws values as block characters scaled to their maximum. Zero
+// values draw the lowest block, so idle intervals stay visible.
+func Sparkline(values []float64) string {
+	peak := maxValue(values)
+
+	var b strings.Builder
+	for _, value := range values {
+		level := 0
+		if peak > 0 && value > 0 {
+			level = int(value / peak * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// maxValue returns the largest of values, or zero without values
+func maxValue(values []float64) float64 {
+	peak := 0.0
+	for _, value := range values {
+		peak = max(peak, value)
+	}
+	return peak
+}
@@ -0,0 +1,32 @@
+package clonetui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▁▄█", Sparkline([]float64{0, 0.1, 2, 4}))
+	assert.Equal(t, "▁▁▁", Sparkline([]float64{0, 0, 0}))
+	assert.Empty(t, Sparkline(nil))
+}
+
+func TestRenderThroughput(t *testing.T) {
+	assert.Empty(t, renderThroughput(nil))
+	assert.Empty(t, renderThroughput(&cloning.Progress{History: make([]cloning.ThroughputSample, 1)}))
+
+	history := make([]cloning.ThroughputSample, sparklineWidth+5)
+	for i := range history {
+		history[i] = cloning.ThroughputSample{JobsPerSecond: 2, BytesPerSecond: 3 * 1024 * 1024}
+	}
+	history[len(history)-1] = cloning.ThroughputSample{JobsPerSecond: 1, BytesPerSecond: 1024 * 1024}
+
+	graph := renderThroughput(&cloning.Progress{History: history})
+	assert.Contains(t, graph, "Throughput (last "+(sparklineWidth*time.Second).String()+")")
+	assert.Contains(t, graph, "1.0 repos/s, peak 2.0")
+	assert.Contains(t, graph, "1.0 MB/s, peak 3.0")
+}
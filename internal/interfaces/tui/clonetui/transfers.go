@@ -0,0 +1,79 @@
+package clonetui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+)
+
+// maxTransferRows limits how many active transfers are rendered at once
+const maxTransferRows = 8
+
+// renderActiveTransfers renders a mini progress bar per running clone
+func renderActiveTransfers(p *cloning.Progress) string {
+	if p == nil || len(p.Transfers) == 0 {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7D56F4")).
+		Bold(true)
+	rowStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#909090"))
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("Active transfers (%d):", len(p.Transfers)))}
+
+	for i, transfer := range p.Transfers {
+		if i == maxTransferRows {
+			lines = append(lines, rowStyle.Render(fmt.Sprintf("  … and %d more", len(p.Transfers)-maxTransferRows)))
+			break
+		}
+
+		row := fmt.Sprintf("  %-30s %s %3.0f%%", truncateString(transfer.Repository, 30), miniBar(transfer.Percent, 16), transfer.Percent)
+		if transfer.ObjectsTotal > 0 {
+			row += fmt.Sprintf("  %d/%d objs", transfer.ObjectsDone, transfer.ObjectsTotal)
+		}
+		if transfer.BytesReceived > 0 {
+			row += "  " + FormatBytes(transfer.BytesReceived)
+		}
+		if transfer.BytesPerSecond > 0 {
+			row += fmt.Sprintf("  %.2f MB/s", transfer.BytesPerSecond/(1024*1024))
+		}
+		if transfer.Phase != "" {
+			row += "  " + strings.ToLower(transfer.Phase)
+		}
+
+		lines = append(lines, rowStyle.Render(row))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// miniBar renders a fixed-width text progress bar
+func miniBar(percent float64, width int) string {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+
+	filled := int(percent / 100 * float64(width))
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// FormatBytes formats byte size in human readable format
+func FormatBytes(bytes int64) string {
+	return cloning.FormatSize(bytes)
+}
+
+// truncateString truncates a string to the specified length
+func truncateString(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
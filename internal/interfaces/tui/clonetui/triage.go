@@ -0,0 +1,435 @@
+package clonetui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+)
+
+// triage is the failure triage screen shown when a run ends with failures.
+// It lists the failed repositories and lets the user retry them, read their
+// job log or export the list before exiting.
+type triage struct {
+	rows     []*cloning.JobResult
+	attempts map[*repository.Repository]int // Clone attempts across the run and its retries
+	selected map[*repository.Repository]bool
+	cursor   int
+	status   string // Outcome of the last action
+
+	retry         *cloneRun // In-flight retry, nil when idle
+	retryProgress *cloning.Progress
+	retryTotal    int
+}
+
+// retryProgressMsg carries a progress snapshot of a triage retry
+type retryProgressMsg struct {
+	progress *cloning.Progress
+}
+
+// retryFinishedMsg is sent once a triage retry has returned
+type retryFinishedMsg cloningFinishedMsg
+
+// triageStatusMsg reports the outcome of a triage action, e.g. a closed pager
+type triageStatusMsg struct {
+	status string
+}
+
+// newTriage creates the triage screen for the failed results of a run
+func newTriage(failed []*cloning.JobResult) *triage {
+	t := &triage{
+		attempts: make(map[*repository.Repository]int, len(failed)),
+		selected: make(map[*repository.Repository]bool),
+	}
+	for _, result := range failed {
+		t.attempts[result.Job.Repository] = result.Job.RetryCount + 1
+	}
+	t.setRows(failed)
+	return t
+}
+
+// setRows replaces the listed failures, keeping the cursor in range and
+// dropping selections of repositories that no longer fail
+func (t *triage) setRows(failed []*cloning.JobResult) {
+	t.rows = failed
+
+	listed := make(map[*repository.Repository]bool, len(failed))
+	for _, result := range failed {
+		listed[result.Job.Repository] = true
+	}
+	for repo := range t.selected {
+		if !listed[repo] {
+			delete(t.selected, repo)
+		}
+	}
+
+	t.cursor = min(t.cursor, max(len(failed)-1, 0))
+}
+
+// targets returns the repositories to act on: the selected rows, or the row
+// under the cursor when nothing is selected
+func (t *triage) targets() []*repository.Repository {
+	var repos []*repository.Repository
+	for _, result := range t.rows {
+		if t.selected[result.Job.Repository] {
+			repos = append(repos, result.Job.Repository)
+		}
+	}
+	if len(repos) == 0 && len(t.rows) > 0 {
+		repos = append(repos, t.rows[t.cursor].Job.Repository)
+	}
+	return repos
+}
+
+// next returns a command waiting for the next progress update of the retry,
+// or for its result once the tracker has been closed
+func (t *triage) next() tea.Cmd {
+	run := t.retry
+	return func() tea.Msg {
+		if progress, ok := <-run.updates; ok {
+			return retryProgressMsg{progress: progress}
+		}
+		return retryFinishedMsg(<-run.result)
+	}
+}
+
+// updateTriage handles the keys and messages of the triage screen
+func (m Model) updateTriage(msg tea.Msg) (tea.Model, tea.Cmd) {
+	t := m.triage
+
+	switch msg := msg.(type) {
+	case retryProgressMsg:
+		t.retryProgress = msg.progress
+		return m, t.next()
+
+	case retryFinishedMsg:
+		t.retry = nil
+		t.retryProgress = nil
+		if msg.err != nil {
+			t.status = fmt.Sprintf("Retry failed: %v", msg.err)
+			return m, nil
+		}
+		m.mergeRetry(msg.response)
+		if len(m.failures) == 0 {
+			m.triage = nil
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+
+	case triageStatusMsg:
+		t.status = msg.status
+		return m, nil
+
+	case tea.KeyMsg:
+		if t.retry != nil {
+			switch msg.String() {
+			case "q", "esc", "ctrl+c":
+				t.retry.Cancel()
+				t.status = "Cancelling the retry..."
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.triage = nil
+			m.quitting = true
+			return m, tea.Quit
+		case "up", "k":
+			t.cursor = max(t.cursor-1, 0)
+		case "down", "j":
+			t.cursor = min(t.cursor+1, len(t.rows)-1)
+		case " ", "x":
+			repo := t.rows[t.cursor].Job.Repository
+			if t.selected[repo] {
+				delete(t.selected, repo)
+			} else {
+				t.selected[repo] = true
+			}
+		case "a":
+			if len(t.selected) == len(t.rows) {
+				clear(t.selected)
+			} else {
+				for _, result := range t.rows {
+					t.selected[result.Job.Repository] = true
+				}
+			}
+		case "r":
+			repos := t.targets()
+			t.retry = startRetryRun(m.config, repos)
+			t.retryTotal = len(repos)
+			t.status = ""
+			return m, t.next()
+		case "enter", "o":
+			return m, openJobLogCmd(t.rows[t.cursor].Job)
+		case "e":
+			path, err := exportFailures(m.config.Directory, t.rows, t.attempts)
+			if err != nil {
+				t.status = fmt.Sprintf("Export failed: %v", err)
+			} else {
+				t.status = fmt.Sprintf("Exported %d failures to %s", len(t.rows), path)
+			}
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// mergeRetry folds the results of a retry into the response of the run
+func (m *Model) mergeRetry(retry *usecases.CloneRepositoriesResponse) {
+	t := m.triage
+	if retry == nil || m.response == nil {
+		return
+	}
+
+	index := make(map[*repository.Repository]int, len(m.response.Results))
+	for i, result := range m.response.Results {
+		index[result.Job.Repository] = i
+	}
+
+	fixed := 0
+	for _, result := range retry.Results {
+		i, ok := index[result.Job.Repository]
+		// Jobs cancelled before they ran keep the result of the original run
+		if !ok || result.Job.Status == cloning.JobStatusCancelled {
+			continue
+		}
+
+		t.attempts[result.Job.Repository] += result.Job.RetryCount + 1
+		countResult(m.response, m.actualProgress, m.response.Results[i].Job.Status, -1)
+		countResult(m.response, m.actualProgress, result.Job.Status, 1)
+		m.response.Results[i] = result
+		if result.Job.Status != cloning.JobStatusFailed {
+			fixed++
+		}
+	}
+
+	m.response.TimedOutJobs = usecases.CountTimedOut(m.response.Results)
+	m.response.LowSpaceJobs = usecases.CountLowSpace(m.response.Results)
+	m.failures = FailedResults(m.response)
+	t.setRows(m.failures)
+	t.status = fmt.Sprintf("Retried %d repositories: %d succeeded, %d still failing",
+		t.retryTotal, fixed, t.retryTotal-fixed)
+}
+
+// countResult adds delta to the counters of a job status
+func countResult(resp *usecases.CloneRepositoriesResponse, progress *cloning.Progress, status cloning.JobStatus, delta int) {
+	if progress == nil {
+		progress = &cloning.Progress{}
+	}
+
+	switch status {
+	case cloning.JobStatusCompleted:
+		resp.CompletedJobs += delta
+		progress.Completed += delta
+	case cloning.JobStatusFailed:
+		resp.FailedJobs += delta
+		progress.Failed += delta
+	case cloning.JobStatusSkipped:
+		resp.SkippedJobs += delta
+		progress.Skipped += delta
+	case cloning.JobStatusUpdated:
+		resp.UpdatedJobs += delta
+		progress.Updated += delta
+	case cloning.JobStatusCancelled:
+		resp.CancelledJobs += delta
+		progress.Cancelled += delta
+	}
+}
+
+// startRetryRun clones the repositories again with the settings of the run
+func startRetryRun(config *Config, repos []*repository.Repository) *cloneRun {
+	req := &usecases.CloneRepositoriesRequest{
+		Repositories:  repos,
+		BaseDirectory: config.Directory,
+		Options:       config.Options,
+		Concurrency:   config.Concurrency,
+		Order:         config.Order,
+	}
+	return startCloneRun(config.CloneUseCase, req, config.CloneTimeout)
+}
+
+// openJobLogCmd shows the git output log of a job in $PAGER
+func openJobLogCmd(job *cloning.CloneJob) tea.Cmd {
+	if job.LogFile == "" {
+		return func() tea.Msg {
+			return triageStatusMsg{status: fmt.Sprintf("No job log for %s, job logs are disabled", job.Repository.GetFullName())}
+		}
+	}
+
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less"}
+		if runtime.GOOS == "windows" {
+			pager = []string{"more"}
+		}
+	}
+
+	cmd := exec.Command(pager[0], append(pager[1:], job.LogFile)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return triageStatusMsg{status: fmt.Sprintf("Failed to open %s: %v", job.LogFile, err)}
+		}
+		return triageStatusMsg{}
+	})
+}
+
+// exportFailures writes the failures to a timestamped file of dir. The first
+// field of each line is the clone URL, so the file can be passed to
+// `clone --from-file` as is.
+func exportFailures(dir string, failed []*cloning.JobResult, attempts map[*repository.Repository]int) (string, error) {
+	path := filepath.Join(dir, fmt.Sprintf("repocloner-failures-%s.txt", time.Now().Format("20060102-150405")))
+
+	var out strings.Builder
+	out.WriteString("# Failed repositories: clone URL, error class, attempts and error\n")
+	out.WriteString("# Clone them again with: repocloner clone --from-file " + path + "\n")
+	for _, result := range failed {
+		job := result.Job
+		fmt.Fprintf(&out, "%s\t%s\t%d\t%s\n", job.Repository.CloneURL, ErrorClass(job.Error),
+			attempts[job.Repository], strings.Join(strings.Fields(fmt.Sprint(job.Error)), " "))
+	}
+
+	if err := os.WriteFile(path, []byte(out.String()), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write failure list: %w", err)
+	}
+	return path, nil
+}
+
+// ErrorClass names the kind of a clone failure, e.g. "auth" or "network"
+func ErrorClass(err error) string {
+	var (
+		authErr       *git.AuthenticationError
+		notFoundErr   *git.RepositoryNotFoundError
+		existsErr     *git.RepositoryExistsError
+		conflictErr   *git.RemoteConflictError
+		permissionErr *git.PermissionError
+		networkErr    *git.NetworkError
+		timeoutErr    *git.TimeoutError
+		diskErr       *git.DiskSpaceError
+		pathErr       *git.PathTooLongError
+		refErr        *git.RefNotFoundError
+		gitErr        *git.GitError
+	)
+
+	switch {
+	case err == nil:
+		return "-"
+	case errors.As(err, &authErr):
+		return "auth"
+	case errors.As(err, &notFoundErr):
+		return "not found"
+	case errors.As(err, &existsErr):
+		return "exists"
+	case errors.As(err, &conflictErr):
+		return "conflict"
+	case errors.As(err, &permissionErr):
+		return "permission"
+	case errors.As(err, &networkErr):
+		return "network"
+	case errors.As(err, &timeoutErr), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &diskErr):
+		return "disk space"
+	case errors.As(err, &pathErr):
+		return "path too long"
+	case errors.As(err, &refErr):
+		return "ref not found"
+	case errors.As(err, &gitErr):
+		return "git"
+	default:
+		return "other"
+	}
+}
+
+// renderTriage renders the failure triage screen
+func (m Model) renderTriage() string {
+	t := m.triage
+
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("#FF5F87")).
+		Padding(0, 1).
+		Render(fmt.Sprintf("❌ %d of %d repositories failed", len(t.rows), m.total))
+
+	nameWidth := len("Repository")
+	for _, result := range t.rows {
+		nameWidth = max(nameWidth, len(result.Job.Repository.GetFullName()))
+	}
+	nameWidth = min(nameWidth, 50)
+
+	headStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Bold(true)
+	rowStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#909090"))
+	cursorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87")).Bold(true)
+
+	lines := []string{headStyle.Render(fmt.Sprintf("      %-*s  %-13s  %s", nameWidth, "Repository", "Error", "Attempts"))}
+	for i, result := range t.rows {
+		repo := result.Job.Repository
+		mark := "[ ]"
+		if t.selected[repo] {
+			mark = "[x]"
+		}
+		pointer := " "
+		style := rowStyle
+		if i == t.cursor {
+			pointer = "›"
+			style = cursorStyle
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%s %s  %-*s  %-13s  %d", pointer, mark,
+			nameWidth, truncateString(repo.GetFullName(), nameWidth), ErrorClass(result.Job.Error), t.attempts[repo])))
+	}
+
+	content := []string{header, "", strings.Join(lines, "\n")}
+
+	if len(t.rows) > 0 {
+		job := t.rows[t.cursor].Job
+		detail := fmt.Sprintf("%v", job.Error)
+		if job.LogFile != "" {
+			detail += "\n📄 " + job.LogFile
+		}
+		content = append(content, "", lipgloss.NewStyle().Width(100).Render(detail))
+	}
+
+	if t.retry != nil {
+		done := 0
+		if t.retryProgress != nil {
+			done = t.retryProgress.Processed()
+		}
+		content = append(content, "", lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7D56F4")).
+			Bold(true).
+			Render(fmt.Sprintf("🔁 Retrying %d/%d repositories...", done, t.retryTotal)))
+	}
+
+	if t.status != "" {
+		content = append(content, "", lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAF00")).Render(t.status))
+	}
+
+	helpText := "↑/↓ move • space select • 'a' select all • 'r' retry • enter open log • 'e' export • 'q' quit"
+	if t.retry != nil {
+		helpText = "Press 'q' to cancel the retry"
+	}
+	content = append(content, lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#626262")).
+		MarginTop(1).
+		Render(helpText))
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(
+		lipgloss.JoinVertical(lipgloss.Left, content...),
+	)
+}
@@ -0,0 +1,141 @@
+package clonetui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+)
+
+func newTriageResult(t *testing.T, repo *repository.Repository, status cloning.JobStatus, jobErr error) *cloning.JobResult {
+	t.Helper()
+
+	job := cloning.NewCloneJob(repo, t.TempDir(), nil)
+	job.Status = status
+	job.Error = jobErr
+	return &cloning.JobResult{Job: job}
+}
+
+func key(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestModel_TriageFailures(t *testing.T) {
+	var repos []*repository.Repository
+	for i := range 3 {
+		repo, err := repository.NewRepository(repository.RepositoryID(i+1), fmt.Sprintf("repo%d", i), fmt.Sprintf("https://github.com/owner/repo%d.git", i), "owner", false, 0, "main")
+		require.NoError(t, err)
+		repos = append(repos, repo)
+	}
+
+	failed := newTriageResult(t, repos[1], cloning.JobStatusFailed, &git.AuthenticationError{Message: "denied"})
+	failed.Job.RetryCount = 2
+	resp := &usecases.CloneRepositoriesResponse{
+		TotalJobs:     3,
+		CompletedJobs: 1,
+		FailedJobs:    2,
+		Results: []*cloning.JobResult{
+			newTriageResult(t, repos[0], cloning.JobStatusCompleted, nil),
+			failed,
+			newTriageResult(t, repos[2], cloning.JobStatusFailed, &git.NetworkError{Message: "reset"}),
+		},
+		Progress: &cloning.Progress{Total: 3, Completed: 1, Failed: 2},
+	}
+
+	dir := t.TempDir()
+	m := New(&Config{Directory: dir})
+	m.total = 3
+
+	updated, cmd := m.Update(cloningFinishedMsg{response: resp})
+	assert.Nil(t, cmd, "the TUI waits for the user")
+	m = updated.(Model)
+	require.NotNil(t, m.triage)
+	require.Len(t, m.triage.rows, 2)
+	assert.Contains(t, m.View(), "2 of 3 repositories failed")
+	assert.Equal(t, 3, m.triage.attempts[repos[1]])
+
+	// Without a selection the row under the cursor is the target
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	assert.Equal(t, []*repository.Repository{repos[2]}, m.triage.targets())
+
+	updated, _ = m.Update(key("a"))
+	m = updated.(Model)
+	assert.Equal(t, []*repository.Repository{repos[1], repos[2]}, m.triage.targets())
+
+	updated, _ = m.Update(key("e"))
+	m = updated.(Model)
+	require.Contains(t, m.triage.status, "Exported 2 failures")
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	exported, err := os.ReadFile(dir + "/" + entries[0].Name())
+	require.NoError(t, err)
+	assert.Contains(t, string(exported), "https://github.com/owner/repo1.git\tauth\t3\tdenied")
+
+	listed, err := repository.ReadReferences(bytes.NewReader(exported))
+	require.NoError(t, err)
+	assert.Len(t, listed, 2, "the export can be cloned with --from-file")
+
+	// One repository recovers on retry, the other keeps failing
+	m.triage.retryTotal = 2
+	updated, cmd = m.Update(retryFinishedMsg{response: &usecases.CloneRepositoriesResponse{Results: []*cloning.JobResult{
+		newTriageResult(t, repos[1], cloning.JobStatusCompleted, nil),
+		newTriageResult(t, repos[2], cloning.JobStatusFailed, &git.NetworkError{Message: "reset"}),
+	}}})
+	assert.Nil(t, cmd)
+	m = updated.(Model)
+	require.Len(t, m.triage.rows, 1)
+	assert.Equal(t, 2, resp.CompletedJobs)
+	assert.Equal(t, 1, resp.FailedJobs)
+	assert.Equal(t, 2, m.actualProgress.Completed)
+	assert.Equal(t, 2, m.triage.attempts[repos[2]])
+	assert.Equal(t, "Retried 2 repositories: 1 succeeded, 1 still failing", m.triage.status)
+
+	updated, cmd = m.Update(key("q"))
+	require.NotNil(t, cmd)
+	m = updated.(Model)
+	assert.Nil(t, m.triage)
+	assert.Contains(t, m.View(), "repo2")
+}
+
+func TestModel_NoTriageWhenCancelled(t *testing.T) {
+	repo, err := repository.NewRepository(1, "repo", "https://github.com/owner/repo.git", "owner", false, 0, "main")
+	require.NoError(t, err)
+
+	m := New(&Config{})
+	m.cancelling = true
+	updated, cmd := m.Update(cloningFinishedMsg{response: &usecases.CloneRepositoriesResponse{Results: []*cloning.JobResult{
+		newTriageResult(t, repo, cloning.JobStatusFailed, errors.New("boom")),
+	}}})
+	require.NotNil(t, cmd)
+	assert.Nil(t, updated.(Model).triage)
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "-"},
+		{&git.AuthenticationError{}, "auth"},
+		{fmt.Errorf("clone: %w", &git.RepositoryNotFoundError{}), "not found"},
+		{&git.TimeoutError{}, "timeout"},
+		{&git.GitError{}, "git"},
+		{errors.New("boom"), "other"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ErrorClass(tt.err))
+	}
+}
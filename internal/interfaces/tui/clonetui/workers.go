@@ -0,0 +1,73 @@
+package clonetui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+)
+
+// renderWorkerPanel renders the worker pool panel toggled with 'w'. It reads
+// the live pool statistics, so it refreshes with every progress update.
+func (m Model) renderWorkerPanel() string {
+	if m.config.CloneUseCase == nil {
+		return ""
+	}
+
+	status := ""
+	if m.config.Status != nil {
+		status = m.config.Status()
+	}
+	return formatWorkerPanel(m.config.CloneUseCase.WorkerStats(), m.actualProgress, status)
+}
+
+// formatWorkerPanel lays out the worker pool statistics, the jobs waiting for
+// a worker and the provider status, e.g. the remaining rate limit budget
+func formatWorkerPanel(stats *concurrency.WorkerPoolStats, p *cloning.Progress, status string) string {
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#909090"))
+	row := func(label, value string) string {
+		return labelStyle.Render(fmt.Sprintf("%-15s", label)) + value
+	}
+
+	workers := fmt.Sprintf("%d running, %d free of %d", stats.RunningWorkers, stats.FreeWorkers, stats.TotalWorkers)
+	if stats.Adaptive {
+		workers += " (adaptive)"
+	}
+
+	queued := "0 jobs"
+	if p != nil {
+		queued = fmt.Sprintf("%d jobs (%d submitted, %d pending)", p.Waiting(), p.Queued, p.Pending())
+	}
+	if stats.HostWaiting > 0 {
+		queued += fmt.Sprintf(", %d held by --host-concurrency", stats.HostWaiting)
+	}
+
+	average := "-"
+	if stats.AverageJobDuration > 0 {
+		average = stats.AverageJobDuration.Round(100 * time.Millisecond).String()
+	}
+
+	if status == "" {
+		status = "-"
+	}
+
+	rows := []string{
+		lipgloss.NewStyle().Bold(true).Render("👷 Worker Pool"),
+		row("Workers", workers),
+		row("Queued", queued),
+		row("Retries", fmt.Sprintf("%d", stats.Retries)),
+		row("Average job", fmt.Sprintf("%s over %d jobs", average, stats.FinishedTasks)),
+		row("Rate limit", status),
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#874BFD")).
+		Padding(0, 1).
+		Width(80).
+		Render(strings.Join(rows, "\n"))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/italoag/repocloner/internal/domain/cloning"
 	"github.com/italoag/repocloner/internal/domain/repository"
 	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/version"
 )
 
 // AppModel represents the main application model
@@ -463,7 +464,7 @@ func (m *AppModel) View() string {
 	}
 
 	var content []string
-	content = append(content, "🚀 repocloner v0.2.0")
+	content = append(content, "🚀 "+version.Title())
 	content = append(content, "State: "+m.state.String())
 	content = append(content, "Status: "+m.statusMsg)
 
@@ -0,0 +1,87 @@
+// Package version holds the build metadata of repocloner. The variables are
+// set at build time with
+//
+//	-ldflags "-X github.com/italoag/repocloner/internal/version.Version=v1.2.3
+//	          -X github.com/italoag/repocloner/internal/version.Commit=abc1234
+//	          -X github.com/italoag/repocloner/internal/version.Date=2025-01-01T00:00:00Z"
+//
+// Builds without ldflags, such as go install, fall back to the module version
+// and VCS details recorded by the Go toolchain.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Build metadata injected with -ldflags
+var (
+	Version = "dev"
+	Commit  = "unknown"
+	Date    = "unknown"
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// Get returns the build metadata, completed from the Go build information
+// when it was not injected
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	if info.Version == "dev" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		info.Version = build.Main.Version
+	}
+	for _, setting := range build.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.Commit == "unknown":
+			info.Commit = setting.Value
+		case setting.Key == "vcs.time" && info.Date == "unknown":
+			info.Date = setting.Value
+		}
+	}
+	return info
+}
+
+// ShortCommit returns the commit abbreviated to seven characters
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 7 {
+		return i.Commit[:7]
+	}
+	return i.Commit
+}
+
+// String returns the version with its commit and build date,
+// e.g. "v0.2.0 (commit abc1234, built 2025-01-01T00:00:00Z)"
+func (i Info) String() string {
+	return fmt.Sprintf("%s (commit %s, built %s)", i.Version, i.ShortCommit(), i.Date)
+}
+
+// UserAgent returns the User-Agent header of API requests, e.g. "repocloner/0.2.0"
+func UserAgent() string {
+	return "repocloner/" + strings.TrimPrefix(Get().Version, "v")
+}
+
+// Title returns the name and version shown in headers, e.g. "repocloner v0.2.0"
+func Title() string {
+	return "repocloner " + Get().Version
+}
@@ -0,0 +1,44 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfoString(t *testing.T) {
+	tests := []struct {
+		name string
+		info Info
+		want string
+	}{
+		{
+			name: "injected",
+			info: Info{Version: "v0.3.0", Commit: "0123456789abcdef", Date: "2025-01-01T00:00:00Z"},
+			want: "v0.3.0 (commit 0123456, built 2025-01-01T00:00:00Z)",
+		},
+		{
+			name: "unknown",
+			info: Info{Version: "dev", Commit: "unknown", Date: "unknown"},
+			want: "dev (commit unknown, built unknown)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.info.String())
+		})
+	}
+}
+
+func TestInjectedMetadata(t *testing.T) {
+	defer func(version, commit, date string) { Version, Commit, Date = version, commit, date }(Version, Commit, Date)
+	Version, Commit, Date = "v1.2.3", "abcdef0123", "2025-06-01T12:00:00Z"
+
+	info := Get()
+	assert.Equal(t, "v1.2.3", info.Version)
+	assert.Equal(t, "abcdef0123", info.Commit)
+	assert.Equal(t, "2025-06-01T12:00:00Z", info.Date)
+	assert.Equal(t, "repocloner/1.2.3", UserAgent())
+	assert.Equal(t, "repocloner v1.2.3", Title())
+}
@@ -0,0 +1,279 @@
+// Package cloner is the embeddable API of repocloner. It lists repositories
+// from GitHub, Bitbucket Cloud and Bitbucket Server and clones them
+// concurrently, without any of the CLI or TUI layers:
+//
+//	c, err := cloner.New(&cloner.Config{GitHubToken: os.Getenv("GITHUB_TOKEN")})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := c.CloneOwner(ctx, cloner.TypeOrganization, "kubernetes", &cloner.Options{
+//		BaseDirectory: "./repos",
+//	})
+package cloner
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/shared"
+	"github.com/italoag/repocloner/internal/infrastructure/bitbucket"
+	"github.com/italoag/repocloner/internal/infrastructure/concurrency"
+	"github.com/italoag/repocloner/internal/infrastructure/git"
+	"github.com/italoag/repocloner/internal/infrastructure/github"
+	"github.com/italoag/repocloner/internal/infrastructure/logging"
+	"github.com/italoag/repocloner/internal/infrastructure/providers"
+	"github.com/italoag/repocloner/internal/version"
+)
+
+// Config holds provider credentials and clone engine settings
+type Config struct {
+	GitHubToken   string
+	GitHubBaseURL string // Defaults to https://api.github.com
+
+	BitbucketUsername string // Set for app passwords, empty for API tokens
+	BitbucketAPIToken string
+
+	BitbucketServerURL      string // Enables Bitbucket Server / Data Center projects
+	BitbucketServerToken    string
+	BitbucketServerUsername string
+
+	Backend      string   // BackendGit (default) or BackendGoGit
+	Concurrency  int      // Defaults to twice the number of CPUs
+	MaxRetries   int      // Defaults to 3
+	MaxBandwidth int64    // Aggregate download cap in bytes/sec, BackendGoGit only
+	MinFreeSpace int64    // Skip the clones left once free disk space falls below this many bytes
+	JobLogDir    string   // Directory for per-repository git logs, empty disables them
+	GitArgs      []string // Extra git clone arguments, e.g. "--config core.autocrlf=false", BackendGit only
+	UserAgent    string
+	Logger       Logger // Defaults to a no-op logger
+
+	// HostConcurrency caps the clones running at once per host, e.g.
+	// "bitbucket.org": 4, or per owner with "host/owner" keys; "*" caps
+	// every other host
+	HostConcurrency map[string]int
+}
+
+// Options configures a single clone run
+type Options struct {
+	BaseDirectory string
+	Clone         *CloneOptions // Defaults to NewCloneOptions()
+	Filter        *Filter       // Used by CloneOwner, defaults to NewFilter()
+	Order         JobOrder
+	Dedupe        bool
+	Timeout       time.Duration   // Zero runs until ctx is done
+	OnProgress    func(*Progress) // Optional, called for every progress update
+}
+
+// Cloner lists and clones repositories. It is safe to run several clones
+// with one Cloner; each run gets its own worker pool.
+type Cloner struct {
+	config        *Config
+	logger        Logger
+	backend       git.CloneBackend
+	domainService *cloning.DomainCloneService
+	fetch         *usecases.FetchRepositoriesUseCase
+	hostLimits    concurrency.HostLimits
+}
+
+// NewNoOpLogger returns a logger that discards everything
+func NewNoOpLogger() Logger {
+	return logging.NewNoOpLogger()
+}
+
+// New creates a cloner from the given configuration
+func New(config *Config) (*Cloner, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	if config.Logger == nil {
+		config.Logger = NewNoOpLogger()
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = version.UserAgent()
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = runtime.NumCPU() * 2
+	}
+	hostLimits, err := concurrency.NewHostLimits(config.HostConcurrency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host concurrency: %w", err)
+	}
+	logger := config.Logger
+
+	githubClient := github.NewGitHubClient(&github.GitHubClientConfig{
+		Token:       config.GitHubToken,
+		BaseURL:     config.GitHubBaseURL,
+		UserAgent:   config.UserAgent,
+		Timeout:     30 * time.Second,
+		RateLimiter: github.NewTokenBucketRateLimiter(5000),
+		Logger:      logger.With(shared.StringField("component", "github_client")),
+	})
+
+	bitbucketClient := bitbucket.NewBitbucketClient(&bitbucket.BitbucketClientConfig{
+		Username:    config.BitbucketUsername,
+		APIToken:    config.BitbucketAPIToken,
+		UserAgent:   config.UserAgent,
+		Timeout:     30 * time.Second,
+		RateLimiter: bitbucket.NewTokenBucketRateLimiter(1000),
+		Logger:      logger.With(shared.StringField("component", "bitbucket_client")),
+	})
+
+	credentials := git.NewCredentialStore()
+	credentials.SetGitHubToken(config.GitHubToken)
+	if config.BitbucketUsername != "" {
+		credentials.SetBitbucketAppPassword(config.BitbucketUsername, config.BitbucketAPIToken)
+	} else {
+		credentials.SetBitbucketToken(config.BitbucketAPIToken)
+	}
+	credentials.SetBitbucketServerToken(config.BitbucketServerUsername, config.BitbucketServerToken)
+
+	var bitbucketServerClient *bitbucket.BitbucketServerClient
+	if config.BitbucketServerURL != "" {
+		var err error
+		bitbucketServerClient, err = bitbucket.NewBitbucketServerClient(&bitbucket.BitbucketServerClientConfig{
+			BaseURL:   config.BitbucketServerURL,
+			Token:     config.BitbucketServerToken,
+			UserAgent: config.UserAgent,
+			Timeout:   30 * time.Second,
+			Logger:    logger.With(shared.StringField("component", "bitbucket_server_client")),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure Bitbucket Server: %w", err)
+		}
+		credentials.RegisterHost(bitbucketServerClient.Host(), git.ProviderBitbucketServer)
+	}
+
+	gitArgs, err := git.ParseCloneArgs(config.GitArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := git.NewCloneBackend(config.Backend, &git.GitClientConfig{
+		Timeout:      10 * time.Minute,
+		Logger:       logger.With(shared.StringField("component", "clone_backend")),
+		Credentials:  credentials,
+		MaxBandwidth: config.MaxBandwidth,
+		JobLogDir:    config.JobLogDir,
+		ExtraArgs:    gitArgs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clone backend: %w", err)
+	}
+
+	return &Cloner{
+		config:        config,
+		hostLimits:    hostLimits,
+		logger:        logger,
+		backend:       backend,
+		domainService: cloning.NewDomainCloneService(logger.With(shared.StringField("component", "domain_service"))),
+		fetch: usecases.NewFetchRepositoriesUseCase(
+			providers.Default(githubClient, bitbucketClient, bitbucketServerClient),
+			logger.With(shared.StringField("usecase", "fetch_repositories")),
+		),
+	}, nil
+}
+
+// Validate checks that the clone backend is usable, e.g. that git is installed
+func (c *Cloner) Validate(ctx context.Context) error {
+	return c.backend.Validate(ctx)
+}
+
+// ListRepositories lists the repositories of an owner. A nil filter uses NewFilter().
+func (c *Cloner) ListRepositories(ctx context.Context, repoType RepositoryType, owner string, filter *Filter) ([]*Repository, error) {
+	if filter == nil {
+		filter = NewFilter()
+	}
+
+	resp, err := c.fetch.Execute(ctx, &usecases.FetchRepositoriesRequest{
+		Owner:  owner,
+		Type:   repoType,
+		Filter: filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Repositories, nil
+}
+
+// CloneOwner lists the repositories of an owner and clones them into
+// opts.BaseDirectory
+func (c *Cloner) CloneOwner(ctx context.Context, repoType RepositoryType, owner string, opts *Options) (*Result, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("options cannot be nil")
+	}
+
+	repos, err := c.ListRepositories(ctx, repoType, owner, opts.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of %s: %w", owner, err)
+	}
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repositories found for %s/%s", repoType, owner)
+	}
+
+	return c.Clone(ctx, repos, opts)
+}
+
+// Clone clones the given repositories concurrently into opts.BaseDirectory.
+// Cancelling ctx stops in-flight clones and returns a partial result.
+func (c *Cloner) Clone(ctx context.Context, repos []*Repository, opts *Options) (*Result, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("options cannot be nil")
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	// The worker pool closes its results once a run completes, so every run
+	// gets a fresh one
+	workerPool, err := concurrency.NewWorkerPool(&concurrency.WorkerPoolConfig{
+		MaxWorkers:   c.config.Concurrency,
+		HostLimits:   c.hostLimits,
+		MinFreeSpace: c.config.MinFreeSpace,
+		MaxRetries:   c.config.MaxRetries,
+		Backend:      c.backend,
+		Logger:       c.logger.With(shared.StringField("component", "worker_pool")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worker pool: %w", err)
+	}
+	defer func() { _ = workerPool.Close() }()
+
+	tracker := cloning.NewProgressTracker(len(repos))
+	done := make(chan struct{})
+	if opts.OnProgress != nil {
+		updates := tracker.Subscribe()
+		go func() {
+			defer close(done)
+			for progress := range updates {
+				opts.OnProgress(progress)
+			}
+		}()
+	} else {
+		close(done)
+	}
+
+	useCase := usecases.NewCloneRepositoriesUseCase(
+		workerPool,
+		c.domainService,
+		c.logger.With(shared.StringField("usecase", "clone_repositories")),
+	)
+
+	resp, err := useCase.Execute(ctx, &usecases.CloneRepositoriesRequest{
+		Repositories:    repos,
+		BaseDirectory:   opts.BaseDirectory,
+		Options:         opts.Clone,
+		Concurrency:     c.config.Concurrency,
+		Order:           opts.Order,
+		Dedupe:          opts.Dedupe,
+		ProgressTracker: tracker,
+	})
+	<-done
+
+	return resp, err
+}
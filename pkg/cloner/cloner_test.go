@@ -0,0 +1,52 @@
+package cloner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloner_ListRepositories(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/acme/repos" || r.URL.Query().Get("page") != "1" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"id":1,"name":"app","clone_url":"https://github.com/acme/app.git","owner":{"login":"acme"}},
+			{"id":2,"name":"fork","clone_url":"https://github.com/acme/fork.git","fork":true,"owner":{"login":"acme"}}
+		]`))
+	}))
+	defer api.Close()
+
+	c, err := New(&Config{GitHubBaseURL: api.URL, Backend: BackendGoGit})
+	require.NoError(t, err)
+
+	repos, err := c.ListRepositories(context.Background(), TypeOrganization, "acme", nil)
+	require.NoError(t, err)
+	require.Len(t, repos, 1, "forks are excluded by default")
+	assert.Equal(t, "acme/app", repos[0].GetFullName())
+}
+
+func TestCloner_CloneValidation(t *testing.T) {
+	c, err := New(&Config{Backend: BackendGoGit})
+	require.NoError(t, err)
+
+	repo, err := ParseReference("octocat/hello-world")
+	require.NoError(t, err)
+
+	_, err = c.Clone(context.Background(), []*Repository{repo}, nil)
+	assert.Error(t, err)
+
+	_, err = c.Clone(context.Background(), []*Repository{repo}, &Options{})
+	assert.ErrorContains(t, err, "base directory")
+}
+
+func TestNew_UnknownBackend(t *testing.T) {
+	_, err := New(&Config{Backend: "svn"})
+	assert.Error(t, err)
+}
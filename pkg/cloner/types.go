@@ -0,0 +1,84 @@
+package cloner
+
+import (
+	"github.com/italoag/repocloner/internal/application/usecases"
+	"github.com/italoag/repocloner/internal/domain/cloning"
+	"github.com/italoag/repocloner/internal/domain/repository"
+	"github.com/italoag/repocloner/internal/domain/shared"
+)
+
+// Repository is a repository returned by a provider
+type Repository = repository.Repository
+
+// RepositoryType selects the provider and owner kind to list
+type RepositoryType = repository.RepositoryType
+
+// Filter narrows down listed repositories
+type Filter = repository.RepositoryFilter
+
+// CloneOptions controls how each repository is cloned
+type CloneOptions = cloning.CloneOptions
+
+// JobOrder selects the order in which repositories are cloned
+type JobOrder = cloning.JobOrder
+
+// Progress is a snapshot of a running clone
+type Progress = cloning.Progress
+
+// JobResult is the outcome of cloning a single repository
+type JobResult = cloning.JobResult
+
+// Duplicate is a repository skipped by Options.Dedupe
+type Duplicate = repository.Duplicate
+
+// Result summarizes a clone run
+type Result = usecases.CloneRepositoriesResponse
+
+// Logger receives structured log output; see NewNoOpLogger
+type Logger = shared.Logger
+
+// Repository types
+const (
+	TypeUser               = repository.RepositoryTypeUser
+	TypeOrganization       = repository.RepositoryTypeOrganization
+	TypeBitbucketUser      = repository.RepositoryTypeBitbucketUser
+	TypeBitbucketWorkspace = repository.RepositoryTypeBitbucketWorkspace
+	TypeBitbucketProject   = repository.RepositoryTypeBitbucketProject
+)
+
+// Job orders
+const (
+	OrderFetched  = cloning.OrderFetched
+	OrderSmallest = cloning.OrderSmallest
+	OrderLargest  = cloning.OrderLargest
+	OrderName     = cloning.OrderName
+	OrderPushed   = cloning.OrderPushed
+)
+
+// Clone backends
+const (
+	BackendGit   = "git"   // Runs the git executable
+	BackendGoGit = "gogit" // Pure Go, supports MaxBandwidth
+)
+
+// NewFilter returns the default filter, which excludes forks
+func NewFilter() *Filter {
+	return repository.NewRepositoryFilter()
+}
+
+// NewCloneOptions returns the default clone options: shallow clones with
+// submodules, skipping repositories that already exist
+func NewCloneOptions() *CloneOptions {
+	return cloning.NewDefaultCloneOptions()
+}
+
+// ParseReference builds a repository from owner/repo or a clone URL
+func ParseReference(ref string) (*Repository, error) {
+	return repository.ParseReference(ref)
+}
+
+// ParseFilterExpression parses a filter expression such as
+// "language==go && size<50MB && !fork", to set as Filter.Expression
+func ParseFilterExpression(expr string) (*repository.FilterExpression, error) {
+	return repository.ParseFilterExpression(expr)
+}
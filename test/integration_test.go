@@ -117,7 +117,7 @@ func TestWorkerPool_Integration(t *testing.T) {
 		MaxWorkers: 2,
 		MaxRetries: 1,
 		RetryDelay: 1 * time.Second,
-		GitClient:  gitClient,
+		Backend:    gitClient,
 		Logger:     logger,
 	})
 	require.NoError(t, err)